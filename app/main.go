@@ -2,25 +2,24 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/osmosis-labs/sqs/chaininfo/client"
 	"github.com/osmosis-labs/sqs/domain"
 	sqslog "github.com/osmosis-labs/sqs/log"
 	"github.com/spf13/viper"
 	_ "github.com/swaggo/echo-swagger"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/otel/sdk/resource"
-	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 
 	"github.com/osmosis-labs/osmosis/v26/app"
@@ -63,10 +62,16 @@ func main() {
 
 	// Validate config
 	if err := config.Validate(); err != nil {
-		fmt.Println("Error validating config:", err)
-		return
+		log.Fatalf("error validating config: %v", err)
 	}
 
+	// logger
+	logger, err := sqslog.NewLogger(config.LoggerIsProduction, config.LoggerFilename, config.LoggerLevel)
+	if err != nil {
+		panic(fmt.Errorf("error while creating logger: %s", err))
+	}
+	logger.Info("Starting sidecar query server")
+
 	// Handle SIGINT and SIGTERM signals to initiate shutdown
 	exitChan := make(chan os.Signal, 1)
 	signal.Notify(exitChan, os.Interrupt, syscall.SIGTERM)
@@ -91,7 +96,7 @@ func main() {
 			panic(err)
 		}
 
-		tp, err := initOTELTracer(ctx, res)
+		tp, err := initOTELTracer(ctx, res, newGRPCSpanExporter, config.OTEL.FailOpen, logger)
 		if err != nil {
 			panic(err)
 		}
@@ -110,54 +115,53 @@ func main() {
 
 	encCfg := app.MakeEncodingConfig()
 
-	// logger
-	logger, err := sqslog.NewLogger(config.LoggerIsProduction, config.LoggerFilename, config.LoggerLevel)
-	if err != nil {
-		panic(fmt.Errorf("error while creating logger: %s", err))
-	}
-	logger.Info("Starting sidecar query server")
-
 	// If fails, it means that the node is not reachable
 	if _, err := chainClient.GetLatestHeight(ctx); err != nil {
 		panic(err)
 	}
 
-	sidecarQueryServer, err := NewSideCarQueryServer(encCfg.Marshaler, *config, logger)
+	sidecarQueryServer, err := NewSideCarQueryServer(ctx, encCfg.Marshaler, *config, logger)
 	if err != nil {
 		panic(err)
 	}
 
+	shutdownDone := make(chan struct{})
+
 	go func() {
-		<-exitChan
-		cancel() // Trigger shutdown
+		defer close(shutdownDone)
 
-		err := sidecarQueryServer.Shutdown(ctx)
-		if err != nil {
-			log.Fatal(err)
+		// Shut down on either a signal or the server stopping on its own.
+		select {
+		case <-exitChan:
+		case <-ctx.Done():
 		}
+		cancel() // Trigger shutdown
 
-		os.Exit(0)
+		shutdownTimeout := time.Duration(config.ShutdownTimeoutSeconds) * time.Second
+		if err := shutdownWithTimeout(sidecarQueryServer, shutdownTimeout); err != nil {
+			logger.Error("error shutting down sidecar query server", zap.Error(err))
+		}
 	}()
 
-	if err := sidecarQueryServer.Start(ctx); err != nil {
+	if err := sidecarQueryServer.Start(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		panic(err)
 	}
+
+	// Unblock the shutdown if the server stopped without a signal.
+	cancel()
+
+	// The server stops accepting connections as soon as the shutdown begins.
+	// Wait for the in-flight requests to drain before exiting.
+	<-shutdownDone
 }
 
-// initOTELTracer initializes the OTEL tracer
-// and wires it up with the Sentry exporter.
-func initOTELTracer(ctx context.Context, res *resource.Resource) (*sdktrace.TracerProvider, error) {
-	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure())
-	if err != nil {
-		log.Fatal("can't initialize grpc trace exporter", zap.Error(err))
-		return nil, err
-	}
+// shutdownWithTimeout shuts down the given server, letting the in-flight requests
+// drain for at most the given timeout so that the shutdown cannot hang indefinitely.
+// Note that the context is derived from the background context since the server context
+// is already canceled once the shutdown begins.
+func shutdownWithTimeout(server SideCarQueryServer, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(res),
-	)
-	otel.SetTracerProvider(tp)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-	return tp, nil
+	return server.Shutdown(ctx)
 }
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/log"
+)
+
+func TestShutdownWithTimeout(t *testing.T) {
+	const (
+		shutdownTimeout = 200 * time.Millisecond
+
+		// Long enough for the test to fail if the server is not forcefully stopped.
+		slowRequestDuration = 10 * time.Second
+	)
+
+	tests := []struct {
+		name string
+
+		requestDuration time.Duration
+
+		expectedErr        error
+		expectedRequestErr bool
+	}{
+		{
+			name: "in-flight request drains before the timeout",
+
+			requestDuration: shutdownTimeout / 4,
+		},
+		{
+			name: "slow-draining request is force-stopped after the timeout",
+
+			requestDuration: slowRequestDuration,
+
+			expectedErr:        context.DeadlineExceeded,
+			expectedRequestErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HideBanner = true
+			e.HidePort = true
+
+			requestStarted := make(chan struct{})
+			e.GET("/slow", func(c echo.Context) error {
+				close(requestStarted)
+
+				select {
+				case <-time.After(tt.requestDuration):
+				case <-c.Request().Context().Done():
+				}
+
+				return c.NoContent(http.StatusOK)
+			})
+
+			server := &sideCarQueryServer{
+				e:          e,
+				sqsAddress: "127.0.0.1:0",
+				logger:     &log.NoOpLogger{},
+			}
+
+			go func() {
+				_ = server.Start(context.Background())
+			}()
+
+			// Wait for the server to start listening.
+			require.Eventually(t, func() bool {
+				return e.ListenerAddr() != nil
+			}, time.Second, 10*time.Millisecond)
+
+			requestErr := make(chan error, 1)
+			go func() {
+				resp, err := http.Get("http://" + e.ListenerAddr().String() + "/slow")
+				if err == nil {
+					resp.Body.Close()
+				}
+				requestErr <- err
+			}()
+
+			<-requestStarted
+
+			// System under test
+			start := time.Now()
+			err := shutdownWithTimeout(server, shutdownTimeout)
+			elapsed := time.Since(start)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// Validate that the shutdown does not hang past the timeout.
+			require.Less(t, elapsed, slowRequestDuration/2)
+
+			select {
+			case err := <-requestErr:
+				if tt.expectedRequestErr {
+					require.Error(t, err)
+				} else {
+					require.NoError(t, err)
+				}
+			case <-time.After(slowRequestDuration / 2):
+				t.Fatal("in-flight request did not complete after shutdown")
+			}
+		})
+	}
+}
+
+// blockingPricesPrecomputeWorker is a prices precompute worker that blocks until the context is done.
+type blockingPricesPrecomputeWorker struct {
+	domain.PricesPrecomputeWorker
+
+	stopped chan struct{}
+}
+
+func (w *blockingPricesPrecomputeWorker) Start(ctx context.Context) {
+	<-ctx.Done()
+	close(w.stopped)
+}
+
+// Tests that the server shutdown stops the prices precompute worker.
+func TestShutdown_StopsPricesPrecomputeWorker(t *testing.T) {
+	worker := &blockingPricesPrecomputeWorker{stopped: make(chan struct{})}
+
+	server := &sideCarQueryServer{
+		e:      echo.New(),
+		logger: &log.NoOpLogger{},
+
+		stopPricesPrecomputeWorker: startPricesPrecomputeWorker(context.Background(), worker),
+	}
+
+	// System under test
+	err := shutdownWithTimeout(server, time.Second)
+	require.NoError(t, err)
+
+	select {
+	case <-worker.stopped:
+	default:
+		t.Fatal("prices precompute worker was not stopped")
+	}
+}
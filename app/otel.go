@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+
+	"github.com/osmosis-labs/sqs/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// tracerProvider is a trace provider that can be shut down.
+type tracerProvider interface {
+	trace.TracerProvider
+
+	// Shutdown flushes and stops the tracer provider.
+	Shutdown(ctx context.Context) error
+}
+
+// noopTracerProvider is a tracer provider with no-op spans.
+// It is used when tracing fails to initialize in fail-open mode.
+type noopTracerProvider struct {
+	noop.TracerProvider
+}
+
+var (
+	_ tracerProvider = &sdktrace.TracerProvider{}
+	_ tracerProvider = noopTracerProvider{}
+)
+
+// Shutdown implements tracerProvider.
+func (noopTracerProvider) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// spanExporterFactory creates the span exporter for the OTEL tracer.
+type spanExporterFactory func(ctx context.Context) (sdktrace.SpanExporter, error)
+
+// newGRPCSpanExporter creates the OTLP grpc span exporter.
+func newGRPCSpanExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	return otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure())
+}
+
+// initOTELTracer initializes the OTEL tracer
+// and wires it up with the exporter created by the given factory.
+// If the exporter fails to initialize and failOpen is true, a warning is logged
+// and a tracer provider with no-op spans is returned instead of an error.
+func initOTELTracer(ctx context.Context, res *resource.Resource, exporterFactory spanExporterFactory, failOpen bool, logger log.Logger) (tracerProvider, error) {
+	exporter, err := exporterFactory(ctx)
+	if err != nil {
+		if !failOpen {
+			return nil, err
+		}
+
+		logger.Warn("can't initialize trace exporter, continuing without tracing", zap.Error(err))
+
+		tp := noopTracerProvider{}
+		otel.SetTracerProvider(tp)
+		return tp, nil
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return tp, nil
+}
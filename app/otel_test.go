@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInitOTELTracer(t *testing.T) {
+	errExporter := errors.New("exporter unavailable")
+
+	failingExporterFactory := func(ctx context.Context) (sdktrace.SpanExporter, error) {
+		return nil, errExporter
+	}
+
+	inMemoryExporterFactory := func(ctx context.Context) (sdktrace.SpanExporter, error) {
+		return tracetest.NewInMemoryExporter(), nil
+	}
+
+	tests := []struct {
+		name            string
+		exporterFactory spanExporterFactory
+		failOpen        bool
+
+		expectedErr         error
+		expectedIsRecording bool
+	}{
+		{
+			name:            "exporter initialized",
+			exporterFactory: inMemoryExporterFactory,
+			failOpen:        true,
+
+			expectedIsRecording: true,
+		},
+		{
+			name:            "exporter error with fail open - no-op provider",
+			exporterFactory: failingExporterFactory,
+			failOpen:        true,
+
+			expectedIsRecording: false,
+		},
+		{
+			name:            "exporter error without fail open - error",
+			exporterFactory: failingExporterFactory,
+			failOpen:        false,
+
+			expectedErr: errExporter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// System under test
+			tp, err := initOTELTracer(ctx, resource.Empty(), tt.exporterFactory, tt.failOpen, &log.NoOpLogger{})
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				require.Nil(t, tp)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, tp)
+
+			_, span := tp.Tracer("test").Start(ctx, "test-span")
+			require.Equal(t, tt.expectedIsRecording, span.IsRecording())
+			span.End()
+
+			require.NoError(t, tp.Shutdown(ctx))
+		})
+	}
+}
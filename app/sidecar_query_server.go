@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net"
 	"net/http"
 
@@ -67,11 +68,19 @@ type SideCarQueryServer interface {
 	Start(context.Context) error
 }
 
+// pricesStreamBufferSize is the number of pending price updates buffered per prices stream
+// subscriber before the oldest ones are dropped.
+const pricesStreamBufferSize = 16
+
 type sideCarQueryServer struct {
 	tokensUseCase mvc.TokensUsecase
 	e             *echo.Echo
 	sqsAddress    string
 	logger        log.Logger
+
+	// stopPricesPrecomputeWorker stops the prices precompute worker and waits for it to return
+	// until the context is done. Nil if the worker is disabled.
+	stopPricesPrecomputeWorker func(context.Context) error
 }
 
 // GetTokensUseCase implements SideCarQueryServer.
@@ -85,8 +94,27 @@ func (sqs *sideCarQueryServer) GetLogger() log.Logger {
 }
 
 // Shutdown implements SideCarQueryServer.
+// Waits for the in-flight requests to drain until the context is done.
+// If they fail to drain in time, the remaining connections are forcefully closed.
+// Stops the background workers afterwards.
 func (sqs *sideCarQueryServer) Shutdown(ctx context.Context) error {
-	return sqs.e.Shutdown(ctx)
+	if err := sqs.e.Shutdown(ctx); err != nil {
+		if closeErr := sqs.e.Close(); closeErr != nil {
+			err = errors.Join(err, closeErr)
+		}
+		return errors.Join(err, sqs.stopWorkers(ctx))
+	}
+
+	return sqs.stopWorkers(ctx)
+}
+
+// stopWorkers stops the background workers, waiting for them to return until the context is done.
+func (sqs *sideCarQueryServer) stopWorkers(ctx context.Context) error {
+	if sqs.stopPricesPrecomputeWorker == nil {
+		return nil
+	}
+
+	return sqs.stopPricesPrecomputeWorker(ctx)
 }
 
 // Start implements SideCarQueryServer.
@@ -101,12 +129,14 @@ func (sqs *sideCarQueryServer) Start(context.Context) error {
 }
 
 // NewSideCarQueryServer creates a new sidecar query server (SQS).
-func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger log.Logger) (SideCarQueryServer, error) {
+// The background workers run until the given context is done or the server is shut down.
+func NewSideCarQueryServer(ctx context.Context, appCodec codec.Codec, config domain.Config, logger log.Logger) (SideCarQueryServer, error) {
 	// Setup echo server
 	e := echo.New()
-	middleware := middleware.InitMiddleware(config.CORS, config.FlightRecord, logger)
+	middleware := middleware.InitMiddleware(config.CORS, config.FlightRecord, config.RequestLogging, logger)
 	e.Use(middleware.CORS)
 	e.Use(middleware.InstrumentMiddleware)
+	e.Use(middleware.RequestLoggingMiddleware)
 	e.Use(otelecho.Middleware("sqs"), middleware.TraceWithParamsMiddleware())
 
 	routerRepository := routerrepo.New(logger)
@@ -134,6 +164,9 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 
 	tokensUseCase.SetTokenRegistryLoader(chainRegistryHTTPFetcher)
 
+	// Set the human denom aliases merged with the registry.
+	tokensUseCase.SetHumanDenomAliases(config.HumanDenomAliases)
+
 	// Check the status of the grpc gateway
 	if err := checkGRPCGatewayStatus(config.ChainGRPCGatewayEndpoint); err != nil {
 		return nil, err
@@ -146,26 +179,45 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 	}
 
 	// Initialize candidate route searcher
-	candidateRouteSearcher := routerUseCase.NewCandidateRouteFinder(routerRepository, logger)
+	candidateRouteSearcher, err := routerUseCase.NewCandidateRouteSearcher(config.Router.CandidateRouteSearchAlgorithm, routerRepository, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	// Initialize router repository, usecase
 	routerUsecase := routerUseCase.NewRouterUsecase(routerRepository, poolsUseCase, candidateRouteSearcher, tokensUseCase, *config.Router, poolsUseCase.GetCosmWasmPoolConfig(), logger, cache.New(), cache.New())
 
+	// Skip generalized cosmwasm pools with an open circuit in the candidate route search if configured.
+	// Cached routes through such pools are skipped by the pools usecase when converting candidate routes.
+	if config.Pools.CosmWasmCircuitBreakerFailureThreshold > 0 {
+		circuitBreakerFilter := domain.CandidateRouteCircuitBreakerFilterOptionCb{
+			CircuitBreaker: poolsUseCase,
+		}
+		routerUsecase.RegisterCandidateRoutePoolFilter(circuitBreakerFilter.ShouldSkipPool)
+	}
+
 	// Initialize system handler
 	chainInfoRepository := chaininforepo.New()
 	chainInfoUseCase := chaininfousecase.NewChainInfoUsecase(chainInfoRepository)
 
 	cosmWasmPoolConfig := poolsUseCase.GetCosmWasmPoolConfig()
 
-	// Initialize chain pricing strategy
-	pricingSimpleRouterUsecase := routerUseCase.NewRouterUsecase(routerRepository, poolsUseCase, candidateRouteSearcher, tokensUseCase, *config.Router, cosmWasmPoolConfig, logger, cache.New(), cache.New())
-	chainPricingSource, err := pricing.NewPricingStrategy(*config.Pricing, tokensUseCase, pricingSimpleRouterUsecase)
+	// Get the default quote denom, failing fast if misconfigured.
+	defaultQuoteDenom, err := pricing.GetDefaultQuoteChainDenom(*config.Pricing, tokensUseCase)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the market cap estimates config.
+	circulatingSupplies, err := pricing.GetCirculatingSupplies(*config.Pricing, tokensUseCase)
 	if err != nil {
 		return nil, err
 	}
+	tokensUseCase.SetMarketCapConfig(defaultQuoteDenom, circulatingSupplies)
 
-	// Get the default quote denom
-	defaultQuoteDenom, err := tokensUseCase.GetChainDenom(config.Pricing.DefaultQuoteHumanDenom)
+	// Initialize chain pricing strategy
+	pricingSimpleRouterUsecase := routerUseCase.NewRouterUsecase(routerRepository, poolsUseCase, candidateRouteSearcher, tokensUseCase, *config.Router, cosmWasmPoolConfig, logger, cache.New(), cache.New())
+	chainPricingSource, err := pricing.NewPricingStrategy(*config.Pricing, tokensUseCase, pricingSimpleRouterUsecase)
 	if err != nil {
 		return nil, err
 	}
@@ -179,6 +231,9 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 		return nil, err
 	}
 
+	// Retry transient chain errors so that they do not blank out portfolio categories
+	passthroughGRPCClient = passthroughdomain.NewRetryingPassthroughGRPCClient(passthroughGRPCClient, config.Passthrough.GRPCMaxRetries, time.Millisecond*time.Duration(config.Passthrough.GRPCRetryBackoffMs))
+
 	// Initialize passthrough query use case
 	passthroughUseCase := passthroughUseCase.NewPassThroughUsecase(passthroughGRPCClient, poolsUseCase, tokensUseCase, liquidityPricer, defaultQuoteDenom, logger)
 	if err != nil {
@@ -202,14 +257,17 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 	orderBookRepository := orderbookrepository.New()
 	orderBookUseCase := orderbookusecase.New(orderBookRepository, orderBookAPIClient, poolsUseCase, tokensUseCase, logger)
 
+	// Initialize prices broadcaster that streams the pricing worker updates to the subscribers.
+	pricesBroadcaster := pricingWorker.NewPricesBroadcaster(pricesStreamBufferSize)
+
 	// HTTP handlers
 	poolsHttpDelivery.NewPoolsHandler(e, poolsUseCase)
 	passthroughHttpDelivery.NewPassthroughHandler(e, passthroughUseCase, orderBookUseCase, logger)
-	systemhttpdelivery.NewSystemHandler(e, config, logger, chainInfoUseCase)
-	if err := tokenshttpdelivery.NewTokensHandler(e, *config.Pricing, tokensUseCase, pricingSimpleRouterUsecase, logger); err != nil {
+	systemhttpdelivery.NewSystemHandler(e, config, logger, chainInfoUseCase, poolsUseCase, tokensUseCase)
+	if err := tokenshttpdelivery.NewTokensHandler(e, *config.Pricing, tokensUseCase, pricingSimpleRouterUsecase, pricesBroadcaster, logger); err != nil {
 		return nil, err
 	}
-	routerHttpDelivery.NewRouterHandler(e, routerUsecase, tokensUseCase, logger)
+	routerHttpDelivery.NewRouterHandler(e, routerUsecase, tokensUseCase, poolsUseCase, logger)
 
 	// Create a Numia HTTP client
 	passthroughConfig := config.Passthrough
@@ -229,12 +287,32 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 	// Register the pool fees fetcher with the passthrough use case
 	poolsUseCase.RegisterPoolFeesFetcher(poolFeesFetcher)
 
+	// Start the prices precompute worker if enabled
+	var stopPricesPrecomputeWorker func(context.Context) error
+	if config.Pricing.PrecomputeIntervalMs > 0 {
+		pricesPrecomputeWorker := pricingWorker.NewPricesPrecomputeWorker(tokensUseCase, defaultQuoteDenom, time.Duration(config.Pricing.PrecomputeIntervalMs)*time.Millisecond, config.Pricing.MaxConcurrentPriceComputations, logger)
+
+		stopPricesPrecomputeWorker = startPricesPrecomputeWorker(ctx, pricesPrecomputeWorker)
+	}
+
 	// Start grpc ingest server if enabled
 	grpcIngesterConfig := config.GRPCIngester
 	if grpcIngesterConfig.Enabled {
 		quotePriceUpdateWorker := pricingWorker.New(tokensUseCase, defaultQuoteDenom, config.Pricing.WorkerMinPoolLiquidityCap, logger)
 
-		poolLiquidityComputeWorker := pricingWorker.NewPoolLiquidityWorker(tokensUseCase, poolsUseCase, liquidityPricer, logger)
+		poolLiquidityComputeWorker := pricingWorker.NewPoolLiquidityWorker(tokensUseCase, poolsUseCase, liquidityPricer, config.Pricing.SkippedRepricingDenomPrefixes, logger)
+		poolLiquidityComputeWorker.RegisterPricingSource(chainPricingSource)
+		poolLiquidityComputeWorker.SetNotificationCoalesceWindow(time.Duration(config.Pricing.ListenerNotificationCoalesceWindowMs) * time.Millisecond)
+		poolLiquidityComputeWorker.SetLiquidityCapHistoryRetention(config.Pricing.LiquidityCapHistoryRetention)
+
+		// Skip pools with stale liquidity data in routing if configured.
+		if config.Router.MaxLiquidityDataBlockAge > 0 {
+			staleLiquidityFilter := domain.CandidateRouteStaleLiquidityFilterOptionCb{
+				HeightGetter: poolLiquidityComputeWorker,
+				MaxBlockAge:  config.Router.MaxLiquidityDataBlockAge,
+			}
+			routerUsecase.RegisterCandidateRoutePoolFilter(staleLiquidityFilter.ShouldSkipPool)
+		}
 
 		candidateRouteSearchDataWorker := routerWorker.NewCandidateRouteSearchDataWorker(poolsUseCase, routerRepository, config.Router.PreferredPoolIDs, cosmWasmPoolConfig, logger)
 
@@ -248,6 +326,12 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 		// pool liquidity compute worker listens to the quote price update worker.
 		quotePriceUpdateWorker.RegisterListener(poolLiquidityComputeWorker)
 
+		// prices broadcaster streams the quote price updates to the subscribers.
+		quotePriceUpdateWorker.RegisterListener(pricesBroadcaster)
+
+		// tokens use case invalidates the cached chain prices of the updated denoms.
+		quotePriceUpdateWorker.RegisterListener(tokensUseCase)
+
 		// Initialize ingest handler and usecase
 		ingestUseCase, err := ingestusecase.NewIngestUsecase(
 			poolsUseCase,
@@ -321,9 +405,34 @@ func NewSideCarQueryServer(appCodec codec.Codec, config domain.Config, logger lo
 		logger:        logger,
 		e:             e,
 		sqsAddress:    config.ServerAddress,
+
+		stopPricesPrecomputeWorker: stopPricesPrecomputeWorker,
 	}, nil
 }
 
+// startPricesPrecomputeWorker starts the given prices precompute worker in the background until the context is done.
+// Returns the function that stops the worker and waits for it to return until the given context is done.
+func startPricesPrecomputeWorker(ctx context.Context, worker domain.PricesPrecomputeWorker) func(context.Context) error {
+	workerCtx, cancelWorker := context.WithCancel(ctx)
+	workerDone := make(chan struct{})
+
+	go func() {
+		defer close(workerDone)
+		worker.Start(workerCtx)
+	}()
+
+	return func(ctx context.Context) error {
+		cancelWorker()
+
+		select {
+		case <-workerDone:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // checkGRPCGatewayStatus checks the status of the grpc gateway.
 // Returns nil if the grpc gateway is reachable.
 // Returns error if the grpc gateway is unreachable.
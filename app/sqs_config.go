@@ -52,5 +52,7 @@ var DefaultConfig = domain.Config{
 		TimeseriesURL:                "https://stage-proxy-data-api.osmosis-labs.workers.dev",
 		APRFetchIntervalMinutes:      5,
 		PoolFeesFetchIntervalMinutes: 5,
+		GRPCMaxRetries:               2,
+		GRPCRetryBackoffMs:           100,
 	},
 }
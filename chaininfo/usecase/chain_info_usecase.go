@@ -95,6 +95,11 @@ func (p *chainInfoUseCase) GetLatestHeight() (uint64, error) {
 	return latestHeight, nil
 }
 
+// GetLatestObservedHeight implements mvc.ChainInfoUsecase.
+func (p *chainInfoUseCase) GetLatestObservedHeight() uint64 {
+	return p.chainInfoRepository.GetLatestHeight()
+}
+
 // StoreLatestHeight implements mvc.ChainInfoUsecase.
 func (p *chainInfoUseCase) StoreLatestHeight(height uint64) {
 	p.chainInfoRepository.StoreLatestHeight(height)
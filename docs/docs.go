@@ -15,6 +15,44 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/passthrough/account-balances/{address}": {
+            "get": {
+                "description": "The returned data represents the spendable balances of the specified address broken down by-coin",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Returns priced spendable balances associated with the given address.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Spendable balances by-coin and their total capitalization",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_osmosis-labs_sqs_domain_passthrough.PortfolioAssetsCategoryResult"
+                        }
+                    },
+                    "206": {
+                        "description": "Best-effort balances if failed to price them",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_osmosis-labs_sqs_domain_passthrough.PortfolioAssetsCategoryResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Response error",
+                        "schema": {
+                            "$ref": "#/definitions/domain.ResponseError"
+                        }
+                    }
+                }
+            }
+        },
         "/passthrough/active-orders": {
             "get": {
                 "description": "The returned data represents all active orders for all orderbooks available for the specified address.",
@@ -111,6 +149,18 @@ const docTemplate = `{
                         "description": "Include market incentives data in the pool response",
                         "name": "with_market_incentives",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort the pools by: id, liquidity_cap or spread_factor",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sort the pools in descending order",
+                        "name": "sort_desc",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -177,9 +227,29 @@ const docTemplate = `{
                 }
             }
         },
+        "/pools/liquidity-errors": {
+            "get": {
+                "description": "Returns the list of pools whose liquidity capitalization could not be computed, along with the reason.\nThis is useful for investigating missing prices.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get pools with liquidity capitalization errors.",
+                "responses": {
+                    "200": {
+                        "description": "List of pool IDs with the liquidity capitalization error",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.PoolLiquidityCapErrorResult"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/router/custom-direct-quote": {
             "get": {
-                "description": "Call does not search for the route rather directly computes the quote for the given poolID.\nNOTE: Endpoint only supports multi-hop routes, split routes are not supported.\n\nFor exact amount in swap method, the ` + "`" + `tokenIn` + "`" + ` and ` + "`" + `tokenOutDenom` + "`" + ` are required.\nFor exact amount out swap method, the ` + "`" + `tokenOut` + "`" + ` and ` + "`" + `tokenInDenom` + "`" + ` are required.\nMixing swap method parameters in other way than specified will result in an error.\n",
+                "description": "Call does not search for the route rather directly computes the quote for the given poolID.\nNOTE: Endpoint only supports multi-hop routes, split routes are not supported.\n\nFor exact amount in swap method, the ` + "`" + `tokenIn` + "`" + ` and ` + "`" + `tokenOutDenom` + "`" + ` are required.\nFor exact amount out swap method, the ` + "`" + `tokenOut` + "`" + ` and ` + "`" + `tokenInDenom` + "`" + ` are required.\nFor exact amount out swap method, the ` + "`" + `poolID` + "`" + ` and ` + "`" + `tokenInDenom` + "`" + ` lists are ordered as in the chain's exact amount out swap routes,\ni.e. the i-th pool takes the i-th token in denom and the last pool produces the ` + "`" + `tokenOut` + "`" + `.\nThe legacy order of the exact amount out lists starting from the ` + "`" + `tokenOut` + "`" + ` is accepted when ` + "`" + `tokenOutFirst` + "`" + ` is set.\nMixing swap method parameters in other way than specified will result in an error.\n",
                 "produces": [
                     "application/json"
                 ],
@@ -234,6 +304,12 @@ const docTemplate = `{
                         "description": "Boolean flag indicating whether to apply exponents to the spot price. False by default.",
                         "name": "applyExponents",
                         "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Boolean flag indicating whether the exact amount out ` + "`" + `poolID` + "`" + ` and ` + "`" + `tokenInDenom` + "`" + ` lists are in the legacy order starting from the ` + "`" + `tokenOut` + "`" + `. False by default.",
+                        "name": "tokenOutFirst",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -244,6 +320,53 @@ const docTemplate = `{
                 }
             }
         },
+        "/router/debug/cosmwasm-circuit-breaker": {
+            "get": {
+                "description": "Returns the circuit breaker statuses of the generalized CosmWasm pools with at least one\nfailed network call since the last success. Pools with an open circuit are excluded from routing.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "CosmWasm circuit breaker statuses",
+                "operationId": "get-cosmwasm-circuit-breaker-statuses",
+                "responses": {
+                    "200": {
+                        "description": "The circuit breaker statuses sorted by pool ID",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/domain.CosmWasmCircuitBreakerPoolStatus"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/router/debug/state": {
+            "get": {
+                "description": "Returns a summary of the current router state for live inspection without writing any files.\nThe tick models of the concentrated pools are omitted unless ` + "`" + `includeTickMap` + "`" + ` is set to true.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Router state summary",
+                "operationId": "get-router-state-summary",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Boolean flag indicating whether to include the tick models of the concentrated pools. False by default.",
+                        "name": "includeTickMap",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The router state summary",
+                        "schema": {
+                            "$ref": "#/definitions/router_delivery_http.RouterStateSummaryResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/router/quote": {
             "get": {
                 "description": "Returns the best quote it can compute for the exact in or exact out token swap method.\n\nFor exact amount in swap method, the ` + "`" + `tokenIn` + "`" + ` and ` + "`" + `tokenOutDenom` + "`" + ` are required.\nFor exact amount out swap method, the ` + "`" + `tokenOut` + "`" + ` and ` + "`" + `tokenInDenom` + "`" + ` are required.\nMixing swap method parameters in other way than specified will result in an error.\n\nWhen ` + "`" + `singleRoute` + "`" + ` parameter is set to true, it gives the best single quote while excluding splits.",
@@ -353,6 +476,116 @@ const docTemplate = `{
                 }
             }
         },
+        "/router/routes/dry-run": {
+            "get": {
+                "description": "Returns all routes that can be used for routing from tokenIn to tokenOutDenom without estimating quotes.\nEach route pool is enriched with its type, denoms, liquidity capitalization and whether it is a canonical orderbook.\nThis is useful for inspecting routing before requesting quotes.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Candidate Routes Dry Run",
+                "operationId": "get-router-routes-dry-run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The string representation of the denom of the token in",
+                        "name": "tokenIn",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The string representation of the denom of the token out",
+                        "name": "tokenOutDenom",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Boolean flag indicating whether the given denoms are human readable or not. Human denoms get converted to chain internally",
+                        "name": "humanDenoms",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The candidate routes with the pool metadata",
+                        "schema": {
+                            "$ref": "#/definitions/router_delivery_http.CandidateRoutesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/router/taker-fee-pair": {
+            "get": {
+                "description": "Returns the taker fee for the given denom pair without requiring a pool ID.\nThis is useful for previewing fees before a pool is selected.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Taker fee for a denom pair",
+                "operationId": "get-taker-fee-pair",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "uosmo",
+                        "description": "The first denom of the pair.",
+                        "name": "denom0",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "uion",
+                        "description": "The second denom of the pair.",
+                        "name": "denom1",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The taker fee for the denom pair",
+                        "schema": {
+                            "$ref": "#/definitions/sqsdomain.TakerFeeForPair"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens/market-cap": {
+            "get": {
+                "description": "returns the market capitalization estimate of the token in the system-configured quote denomination.\nIt combines the chain price of the token with its configured circulating supply.\nFor tokens without circulating supply data, the total liquidity across all Osmosis pools is used instead and ` + "`" + `is_best_effort` + "`" + ` is set to true.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Market Cap",
+                "operationId": "get-market-cap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Denom that can either be a human denom or a chain denom based on humanDenoms parameter",
+                        "name": "denom",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Specify true if input denomination is in human-readable format; defaults to false",
+                        "name": "humanDenoms",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.MarketCap"
+                        }
+                    }
+                }
+            }
+        },
         "/tokens/metadata": {
             "get": {
                 "description": "returns token metadata with chain denom, human denom, and precision.\nFor testnet, uses osmo-test-5 asset list. For mainnet, uses osmosis-1 asset list.\nSee ` + "`" + `config.json` + "`" + ` and ` + "`" + `config-testnet.json` + "`" + ` in root for details.",
@@ -382,6 +615,50 @@ const docTemplate = `{
                 }
             }
         },
+        "/tokens/metadata/list": {
+            "get": {
+                "description": "returns a page of the metadata of all tokens ordered by human denom.\nThe tokens can optionally be filtered by whether they are listed and whether they have a coingecko id.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Token Metadata List",
+                "operationId": "get-token-metadata-list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of tokens to return. All remaining tokens if zero or not given",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tokens to skip; defaults to 0",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return only listed tokens if true and only unlisted tokens if false. All tokens if not given",
+                        "name": "listed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return only tokens with a coingecko id if true and only tokens without one if false. All tokens if not given",
+                        "name": "hasCoingeckoId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/domain.TokenMetadataPage"
+                        }
+                    }
+                }
+            }
+        },
         "/tokens/pool-metadata": {
             "get": {
                 "description": "returns pool denom metadata. As of today, this metadata is represented by the local market cap of the token computed over all Osmosis pools.\nFor testnet, uses osmo-test-5 asset list. For mainnet, uses osmosis-1 asset list.\nSee ` + "`" + `config.json` + "`" + ` and ` + "`" + `config-testnet.json` + "`" + ` in root for details.",
@@ -437,6 +714,18 @@ const docTemplate = `{
                         "description": "Specify the pricing source. Values can be 0 (chain) or 1 (coingecko); default to 0 (chain)",
                         "name": "pricingSource",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote denomination overriding the system-configured one (human-readable or chain format based on humanDenoms parameter). Only supported by the chain pricing source.",
+                        "name": "quote",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum liquidity capitalization of the pools used to compute the prices, overriding the system-configured one. Must be either the system-configured one or one of the configured allowed-min-pool-liquidity-caps. Only supported by the chain pricing source.",
+                        "name": "minLiquidityCap",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -454,6 +743,40 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/tokens/prices/stream": {
+            "get": {
+                "description": "Upgrades the connection to a WebSocket that streams the chain prices against the default quote denomination\nas they are recomputed by the pricing worker at the end of each block.\nEach message is a JSON map from base denomination to quote denomination to price, containing only\nthe subscribed base denominations whose prices were recomputed within the block.\nPing frames are sent periodically as heartbeats. If the client falls behind, the oldest pending updates are dropped.",
+                "summary": "Stream prices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of base denominations to subscribe to (human-readable or chain format based on humanDenoms parameter). All denominations if empty.",
+                        "name": "base",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Specify true if input denominations are in human-readable format; defaults to false",
+                        "name": "humanDenoms",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols to WebSocket.",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "object",
+                                "additionalProperties": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -474,6 +797,89 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.CosmWasmCircuitBreakerPoolStatus": {
+            "type": "object",
+            "properties": {
+                "consecutive_failures": {
+                    "type": "integer"
+                },
+                "opened_at": {
+                    "description": "OpenedAt is the time at which the circuit was last opened. Zero if it was never opened.",
+                    "type": "string"
+                },
+                "pool_id": {
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/domain.CosmWasmCircuitBreakerState"
+                }
+            }
+        },
+        "domain.CosmWasmCircuitBreakerState": {
+            "type": "string",
+            "enum": [
+                "closed",
+                "open",
+                "half-open"
+            ],
+            "x-enum-varnames": [
+                "CosmWasmCircuitBreakerClosed",
+                "CosmWasmCircuitBreakerOpen",
+                "CosmWasmCircuitBreakerHalfOpen"
+            ]
+        },
+        "domain.MarketCap": {
+            "type": "object",
+            "properties": {
+                "circulating_supply": {
+                    "description": "CirculatingSupply is the circulating supply of the token in human units.\n@Type string",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/osmomath.BigDec"
+                        }
+                    ]
+                },
+                "denom": {
+                    "description": "Denom is the chain denom of the token.",
+                    "type": "string"
+                },
+                "is_best_effort": {
+                    "description": "IsBestEffort is true if the circulating supply is not known and the total\nliquidity across all pools is used in its place, underestimating the market cap.",
+                    "type": "boolean"
+                },
+                "market_cap": {
+                    "description": "MarketCap is the price multiplied by the circulating supply.\n@Type string",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/osmomath.BigDec"
+                        }
+                    ]
+                },
+                "price": {
+                    "description": "Price is the price of the token in the quote denom.\n@Type string",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/osmomath.BigDec"
+                        }
+                    ]
+                },
+                "quote_denom": {
+                    "description": "QuoteDenom is the chain denom in which the price and the market cap are quoted.",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.PoolLiquidityCapErrorResult": {
+            "type": "object",
+            "properties": {
+                "liquidity_cap_error": {
+                    "type": "string"
+                },
+                "pool_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "domain.ResponseError": {
             "type": "object",
             "properties": {
@@ -510,6 +916,26 @@ const docTemplate = `{
                 }
             }
         },
+        "domain.TokenMetadataPage": {
+            "type": "object",
+            "properties": {
+                "next_offset": {
+                    "description": "NextOffset is the offset to fetch the next page with.\nZero if there are no more tokens.",
+                    "type": "integer"
+                },
+                "tokens": {
+                    "description": "Tokens are the token metadata within the page.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Token"
+                    }
+                },
+                "total": {
+                    "description": "Total is the number of tokens matching the filters across all pages.",
+                    "type": "integer"
+                }
+            }
+        },
         "github_com_cosmos_cosmos-sdk_types.Coin": {
             "type": "object",
             "properties": {
@@ -623,7 +1049,7 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "account_coins_result": {
-                    "description": "AccountCoinsResult represents coins only from user balances (contrary to TotalValueCap).",
+                    "description": "AccountCoinsResult represents coins only from user balances (contrary to TotalValueCap).\nFor staked, unstaking, in-locks, pooled and concentrated positions categories, it is only set if a detailed portfolio is requested.",
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/github_com_osmosis-labs_sqs_domain_passthrough.AccountCoinsResult"
@@ -633,6 +1059,10 @@ const docTemplate = `{
                     "description": "Capitalization represents the total value of the assets in the portfolio.\nincludes capitalization of user balances, value in locks, bonding or unbonding\nas well as the concentrated positions.",
                     "type": "string"
                 },
+                "hidden_count": {
+                    "description": "HiddenCount is the number of zero-value coins excluded from AccountCoinsResult.\nOnly set if zero-value coins are requested to be hidden.",
+                    "type": "integer"
+                },
                 "is_best_effort": {
                     "type": "boolean"
                 }
@@ -663,6 +1093,106 @@ const docTemplate = `{
                 }
             }
         },
+        "math.LegacyDec": {
+            "type": "object"
+        },
+        "osmomath.BigDec": {
+            "type": "object"
+        },
+        "osmomath.Int": {
+            "type": "object"
+        },
+        "router_delivery_http.CandidatePoolResponse": {
+            "type": "object",
+            "properties": {
+                "denoms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_canonical_orderbook": {
+                    "type": "boolean"
+                },
+                "liquidity_cap": {
+                    "$ref": "#/definitions/osmomath.Int"
+                },
+                "token_out_denom": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/types.PoolType"
+                }
+            }
+        },
+        "router_delivery_http.CandidateRouteResponse": {
+            "type": "object",
+            "properties": {
+                "is_canonical_orderbook_route": {
+                    "type": "boolean"
+                },
+                "pools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/router_delivery_http.CandidatePoolResponse"
+                    }
+                }
+            }
+        },
+        "router_delivery_http.CandidateRouteSearchDataSummary": {
+            "type": "object",
+            "properties": {
+                "canonical_orderbooks_count": {
+                    "description": "CanonicalOrderbooksCount is the total number of canonical orderbooks across all denoms.",
+                    "type": "integer"
+                },
+                "denoms_count": {
+                    "description": "DenomsCount is the number of denoms with candidate route search data.",
+                    "type": "integer"
+                },
+                "sorted_pools_count": {
+                    "description": "SortedPoolsCount is the total number of sorted pools across all denoms.",
+                    "type": "integer"
+                }
+            }
+        },
+        "router_delivery_http.CandidateRoutesResponse": {
+            "type": "object",
+            "properties": {
+                "contains_canonical_orderbook": {
+                    "type": "boolean"
+                },
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/router_delivery_http.CandidateRouteResponse"
+                    }
+                }
+            }
+        },
+        "router_delivery_http.RouterStateSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "candidate_route_search_data": {
+                    "$ref": "#/definitions/router_delivery_http.CandidateRouteSearchDataSummary"
+                },
+                "pools_count": {
+                    "type": "integer"
+                },
+                "taker_fees_count": {
+                    "type": "integer"
+                },
+                "tick_map": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/sqsdomain.TickModel"
+                    }
+                }
+            }
+        },
         "sqsdomain.CandidatePool": {
             "type": "object",
             "properties": {
@@ -708,8 +1238,68 @@ const docTemplate = `{
                 }
             }
         },
+        "sqsdomain.LiquidityDepthsWithRange": {
+            "type": "object",
+            "properties": {
+                "liquidity_amount": {
+                    "$ref": "#/definitions/math.LegacyDec"
+                },
+                "lower_tick": {
+                    "type": "integer"
+                },
+                "upper_tick": {
+                    "type": "integer"
+                }
+            }
+        },
+        "sqsdomain.TakerFeeForPair": {
+            "type": "object",
+            "properties": {
+                "denom0": {
+                    "type": "string"
+                },
+                "denom1": {
+                    "type": "string"
+                },
+                "takerFee": {
+                    "type": "string"
+                }
+            }
+        },
+        "sqsdomain.TickModel": {
+            "type": "object",
+            "properties": {
+                "current_tick_index": {
+                    "type": "integer"
+                },
+                "has_no_liquidity": {
+                    "type": "boolean"
+                },
+                "ticks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/sqsdomain.LiquidityDepthsWithRange"
+                    }
+                }
+            }
+        },
         "types.Int": {
             "type": "object"
+        },
+        "types.PoolType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "Balancer",
+                "Stableswap",
+                "Concentrated",
+                "CosmWasm"
+            ]
         }
     }
 }`
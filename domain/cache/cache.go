@@ -43,12 +43,19 @@ func (c *Cache) Set(key string, value interface{}, expiration time.Duration) {
 
 // Get retrieves the value associated with a key from the cache.
 func (c *Cache) Get(key string) (interface{}, bool) {
+	value, _, found := c.GetWithExpiration(key)
+	return value, found
+}
+
+// GetWithExpiration retrieves the value associated with a key from the cache
+// alongside the time at which it expires. The expiration is zero if the item never expires.
+func (c *Cache) GetWithExpiration(key string) (interface{}, time.Time, bool) {
 	c.mutex.RLock()
 
 	item, exists := c.data[key]
 	if !exists {
 		c.mutex.RUnlock()
-		return nil, false
+		return nil, time.Time{}, false
 	}
 
 	if !item.Expiration.IsZero() && time.Now().After(item.Expiration) {
@@ -59,12 +66,12 @@ func (c *Cache) Get(key string) (interface{}, bool) {
 		c.mutex.Lock()
 		delete(c.data, key)
 		c.mutex.Unlock()
-		return nil, false
+		return nil, time.Time{}, false
 	}
 
 	c.mutex.RUnlock()
 
-	return item.Value, true
+	return item.Value, item.Expiration, true
 }
 
 // Delete removes an item from the cache.
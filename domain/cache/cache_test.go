@@ -179,3 +179,67 @@ func TestCache_SetExpiration(t *testing.T) {
 		})
 	}
 }
+
+func TestCache_GetWithExpiration(t *testing.T) {
+	c := cache.New()
+
+	tests := []struct {
+		name             string
+		key              string
+		expiration       time.Duration
+		sleep            time.Duration
+		expectExist      bool
+		expectExpiration bool
+	}{
+		{
+			name:             "Set with Expiration - returns expiration",
+			key:              "key1",
+			expiration:       time.Minute,
+			expectExist:      true,
+			expectExpiration: true,
+		},
+		{
+			name:        "Set with Expiration - Key Expires",
+			key:         "key2",
+			expiration:  time.Nanosecond,
+			sleep:       10 * time.Millisecond,
+			expectExist: false,
+		},
+		{
+			name:        "Set with No Expiration - zero expiration",
+			key:         "key3",
+			expiration:  cache.NoExpiration,
+			expectExist: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now()
+			c.Set(tt.key, tt.name, tt.expiration)
+			after := time.Now()
+
+			time.Sleep(tt.sleep)
+
+			value, expiration, exists := c.GetWithExpiration(tt.key)
+			if exists != tt.expectExist {
+				t.Errorf("Expected key %s to exist: %v, got: %v", tt.key, tt.expectExist, exists)
+			}
+
+			if tt.expectExist && value != tt.name {
+				t.Errorf("Expected value for key %s: %v, got: %v", tt.key, tt.name, value)
+			}
+
+			if !tt.expectExpiration {
+				if !expiration.IsZero() {
+					t.Errorf("Expected zero expiration for key %s, got: %v", tt.key, expiration)
+				}
+				return
+			}
+
+			if expiration.Before(before.Add(tt.expiration)) || expiration.After(after.Add(tt.expiration)) {
+				t.Errorf("Expected expiration for key %s within [%v, %v], got: %v", tt.key, before.Add(tt.expiration), after.Add(tt.expiration), expiration)
+			}
+		})
+	}
+}
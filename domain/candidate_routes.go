@@ -15,6 +15,11 @@ type CandidateRouteSearchOptions struct {
 	MaxRoutes int
 	// MaxPoolsPerRoute is the maximum number of pools to consider for each route.
 	MaxPoolsPerRoute int
+	// MaxPoolsConsidered is the maximum number of the highest ranked pools to consider for each denom.
+	// The pools are ranked by liquidity capitalization with the preferred pools boosted.
+	// Only the pools that are not skipped by the pool filters or the min liquidity cap are counted.
+	// Zero if unlimited.
+	MaxPoolsConsidered int
 	// MinPoolLiquidityCap is the minimum liquidity cap for a pool to be considered.
 	MinPoolLiquidityCap uint64
 	// DisableCache specifies if route cache should be disbled.
@@ -53,6 +58,97 @@ func (c CandidateRoutePoolIDFilterOptionCb) ShouldSkipPool(pool *sqsdomain.PoolW
 	return ok
 }
 
+// CandidateRouteDenomFilterOptionCb encapsulates the allowed and denied denoms, exposing an API
+// to determine whether the given pool contains a restricted denom and should be skipped.
+type CandidateRouteDenomFilterOptionCb struct {
+	// AllowedDenoms are the only denoms that pools may contain. Empty if all denoms are allowed.
+	AllowedDenoms map[string]struct{}
+	// DeniedDenoms are the denoms that pools may never contain.
+	DeniedDenoms map[string]struct{}
+	// TokenInDenom and TokenOutDenom are the denoms of the swap.
+	// They bypass the allowlist.
+	TokenInDenom  string
+	TokenOutDenom string
+}
+
+// ShouldSkipPool returns true if at least one of the given pool denoms is in c.DeniedDenoms or,
+// if c.AllowedDenoms is non-empty, is neither in c.AllowedDenoms nor is the token in or token out denom.
+func (c CandidateRouteDenomFilterOptionCb) ShouldSkipPool(pool *sqsdomain.PoolWrapper) bool {
+	for _, denom := range pool.GetPoolDenoms() {
+		if _, ok := c.DeniedDenoms[denom]; ok {
+			return true
+		}
+
+		if len(c.AllowedDenoms) == 0 || denom == c.TokenInDenom || denom == c.TokenOutDenom {
+			continue
+		}
+
+		if _, ok := c.AllowedDenoms[denom]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LiquidityDataHeightGetter provides the heights at which the pool liquidity data was last updated.
+type LiquidityDataHeightGetter interface {
+	// GetHeightForDenom returns the latest height at which the liquidity data of the given denom was repriced.
+	// Returns zero if the denom was never repriced.
+	GetHeightForDenom(denom string) uint64
+	// GetLatestUpdateHeight returns the latest height of any liquidity data update.
+	GetLatestUpdateHeight() uint64
+}
+
+// CandidateRouteStaleLiquidityFilterOptionCb encapsulates the maximum age of the pool liquidity data
+// exposing an API to determine whether the given pool has stale liquidity data and should be skipped.
+type CandidateRouteStaleLiquidityFilterOptionCb struct {
+	HeightGetter LiquidityDataHeightGetter
+	// MaxBlockAge is the maximum number of blocks since the liquidity data of any pool denom was last repriced.
+	MaxBlockAge uint64
+}
+
+// ShouldSkipPool returns true if at least one of the given pool denoms has liquidity data
+// that was last repriced more than c.MaxBlockAge blocks before the latest update height.
+// Denoms that were never repriced are not considered stale so that routing is not blocked
+// before the first liquidity update.
+func (c CandidateRouteStaleLiquidityFilterOptionCb) ShouldSkipPool(pool *sqsdomain.PoolWrapper) bool {
+	latestHeight := c.HeightGetter.GetLatestUpdateHeight()
+	if latestHeight <= c.MaxBlockAge {
+		return false
+	}
+	minFreshHeight := latestHeight - c.MaxBlockAge
+
+	for _, denom := range pool.GetPoolDenoms() {
+		denomHeight := c.HeightGetter.GetHeightForDenom(denom)
+		if denomHeight != 0 && denomHeight < minFreshHeight {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CosmWasmCircuitBreaker provides the circuit breaker state of the generalized CosmWasm pools.
+type CosmWasmCircuitBreaker interface {
+	// IsCosmWasmPoolCircuitOpen returns true if the circuit of the given pool is open
+	// due to consecutive network call failures, meaning that the pool should not be routed through.
+	IsCosmWasmPoolCircuitOpen(poolID uint64) bool
+}
+
+// CandidateRouteCircuitBreakerFilterOptionCb encapsulates the CosmWasm pool circuit breaker
+// exposing an API to determine whether the given pool has an open circuit and should be skipped.
+type CandidateRouteCircuitBreakerFilterOptionCb struct {
+	CircuitBreaker CosmWasmCircuitBreaker
+}
+
+// ShouldSkipPool returns true if the circuit of the given pool is open.
+// Only prunes the candidate route search. Routes read from cache are checked
+// against the circuit breaker when converted from candidate routes.
+func (c CandidateRouteCircuitBreakerFilterOptionCb) ShouldSkipPool(pool *sqsdomain.PoolWrapper) bool {
+	return c.CircuitBreaker.IsCosmWasmPoolCircuitOpen(pool.GetId())
+}
+
 var (
 	// ShouldSkipOrderbookPool skips orderbook pools
 	// by returning true if pool.SQSModel.CosmWasmPoolModel is not nil
@@ -63,6 +159,30 @@ var (
 	}
 )
 
+// CandidateRouteSearchAlgorithm is the algorithm used by the candidate route search.
+type CandidateRouteSearchAlgorithm string
+
+const (
+	// CandidateRouteSearchAlgorithmBFS searches breadth-first, finding the routes
+	// with the fewest pools first. This is the default.
+	CandidateRouteSearchAlgorithmBFS CandidateRouteSearchAlgorithm = "bfs"
+	// CandidateRouteSearchAlgorithmGreedy searches depth-first, always following the highest
+	// liquidity pools first. Converges faster on denoms with many pools at the cost
+	// of potentially missing short routes through lower liquidity pools.
+	CandidateRouteSearchAlgorithmGreedy CandidateRouteSearchAlgorithm = "greedy"
+)
+
+// IsValid returns true if the algorithm is supported.
+// The empty algorithm is valid and defaults to CandidateRouteSearchAlgorithmBFS.
+func (a CandidateRouteSearchAlgorithm) IsValid() bool {
+	switch a {
+	case "", CandidateRouteSearchAlgorithmBFS, CandidateRouteSearchAlgorithmGreedy:
+		return true
+	default:
+		return false
+	}
+}
+
 // CandidateRouteSearcher is the interface for finding candidate routes.
 type CandidateRouteSearcher interface {
 	// FindCandidateRoutes finds candidate routes for a given tokenIn and tokenOutDenom
@@ -2,8 +2,10 @@ package domain_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/osmosis-labs/sqs/domain"
+	cosmwasmdomain "github.com/osmosis-labs/sqs/domain/cosmwasm"
 	"github.com/osmosis-labs/sqs/domain/mocks"
 	"github.com/osmosis-labs/sqs/sqsdomain"
 	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
@@ -134,3 +136,233 @@ func TestCandidateRouteSearchOptions_ShouldSkipPool(t *testing.T) {
 		})
 	}
 }
+
+// mockLiquidityDataHeightGetter is a mock implementation of domain.LiquidityDataHeightGetter.
+type mockLiquidityDataHeightGetter struct {
+	heightForDenom     map[string]uint64
+	latestUpdateHeight uint64
+}
+
+func (m mockLiquidityDataHeightGetter) GetHeightForDenom(denom string) uint64 {
+	return m.heightForDenom[denom]
+}
+
+func (m mockLiquidityDataHeightGetter) GetLatestUpdateHeight() uint64 {
+	return m.latestUpdateHeight
+}
+
+// This test validates that the stale liquidity filter skips pools with at least one denom
+// whose liquidity data is older than the max block age while keeping the fresh ones.
+func TestCandidateRouteStaleLiquidityFilterOptionCb_ShouldSkipPool(t *testing.T) {
+	const (
+		denomA = "denomA"
+		denomB = "denomB"
+
+		maxBlockAge   = uint64(10)
+		latestHeight  = uint64(100)
+		freshHeight   = latestHeight - maxBlockAge
+		staleHeight   = freshHeight - 1
+		unknownHeight = uint64(0)
+	)
+
+	defaultPool := sqsdomain.PoolWrapper{
+		ChainModel: &mocks.ChainPoolMock{
+			ID: 1,
+		},
+		SQSModel: sqsdomain.SQSPool{
+			PoolDenoms: []string{denomA, denomB},
+		},
+	}
+
+	tests := []struct {
+		name string
+
+		heightForDenom     map[string]uint64
+		latestUpdateHeight uint64
+
+		expectedShouldSkip bool
+	}{
+		{
+			name: "all denoms fresh -> returns false",
+
+			heightForDenom:     map[string]uint64{denomA: latestHeight, denomB: freshHeight},
+			latestUpdateHeight: latestHeight,
+
+			expectedShouldSkip: false,
+		},
+		{
+			name: "one denom stale -> returns true",
+
+			heightForDenom:     map[string]uint64{denomA: latestHeight, denomB: staleHeight},
+			latestUpdateHeight: latestHeight,
+
+			expectedShouldSkip: true,
+		},
+		{
+			name: "all denoms stale -> returns true",
+
+			heightForDenom:     map[string]uint64{denomA: staleHeight, denomB: staleHeight},
+			latestUpdateHeight: latestHeight,
+
+			expectedShouldSkip: true,
+		},
+		{
+			name: "denom never repriced -> returns false",
+
+			heightForDenom:     map[string]uint64{denomA: latestHeight, denomB: unknownHeight},
+			latestUpdateHeight: latestHeight,
+
+			expectedShouldSkip: false,
+		},
+		{
+			name: "latest height within max block age -> returns false",
+
+			heightForDenom:     map[string]uint64{denomA: 1, denomB: 1},
+			latestUpdateHeight: maxBlockAge,
+
+			expectedShouldSkip: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			staleLiquidityFilter := domain.CandidateRouteStaleLiquidityFilterOptionCb{
+				HeightGetter: mockLiquidityDataHeightGetter{
+					heightForDenom:     tc.heightForDenom,
+					latestUpdateHeight: tc.latestUpdateHeight,
+				},
+				MaxBlockAge: maxBlockAge,
+			}
+
+			opts := domain.CandidateRouteSearchOptions{
+				PoolFiltersAnyOf: []domain.CandidateRoutePoolFiltrerCb{
+					staleLiquidityFilter.ShouldSkipPool,
+				},
+			}
+
+			// System under test.
+			shouldSkip := opts.ShouldSkipPool(&defaultPool)
+
+			// Validate result.
+			require.Equal(t, tc.expectedShouldSkip, shouldSkip)
+		})
+	}
+}
+
+// This test validates that the circuit breaker filter skips only the pools with an open circuit.
+func TestCandidateRouteCircuitBreakerFilterOptionCb_ShouldSkipPool(t *testing.T) {
+	const (
+		openPoolID   = uint64(1)
+		closedPoolID = uint64(2)
+	)
+
+	circuitBreaker := cosmwasmdomain.NewCircuitBreaker(1, time.Hour)
+	circuitBreaker.RecordFailure(openPoolID)
+
+	circuitBreakerFilter := domain.CandidateRouteCircuitBreakerFilterOptionCb{
+		CircuitBreaker: circuitBreaker,
+	}
+
+	opts := domain.CandidateRouteSearchOptions{
+		PoolFiltersAnyOf: []domain.CandidateRoutePoolFiltrerCb{
+			circuitBreakerFilter.ShouldSkipPool,
+		},
+	}
+
+	require.True(t, opts.ShouldSkipPool(&sqsdomain.PoolWrapper{ChainModel: &mocks.ChainPoolMock{ID: openPoolID}}))
+	require.False(t, opts.ShouldSkipPool(&sqsdomain.PoolWrapper{ChainModel: &mocks.ChainPoolMock{ID: closedPoolID}}))
+}
+
+// This test validates that the denom filter skips pools containing denied denoms
+// or, in allowlist mode, denoms that are not allowed unless they are the token in or token out denoms.
+func TestCandidateRouteDenomFilterOptionCb_ShouldSkipPool(t *testing.T) {
+	const (
+		denomIn           = "denomIn"
+		denomOut          = "denomOut"
+		denomIntermediate = "denomIntermediate"
+	)
+
+	newPool := func(denoms ...string) *sqsdomain.PoolWrapper {
+		return &sqsdomain.PoolWrapper{
+			ChainModel: &mocks.ChainPoolMock{
+				ID: 1,
+			},
+			SQSModel: sqsdomain.SQSPool{
+				PoolDenoms: denoms,
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+
+		pool          *sqsdomain.PoolWrapper
+		allowedDenoms map[string]struct{}
+		deniedDenoms  map[string]struct{}
+
+		expectedShouldSkip bool
+	}{
+		{
+			name: "no allowed or denied denoms -> returns false",
+
+			pool: newPool(denomIn, denomIntermediate),
+
+			expectedShouldSkip: false,
+		},
+		{
+			name: "denied intermediate denom -> returns true",
+
+			pool:         newPool(denomIn, denomIntermediate),
+			deniedDenoms: map[string]struct{}{denomIntermediate: {}},
+
+			expectedShouldSkip: true,
+		},
+		{
+			name: "denied token in denom -> returns true",
+
+			pool:         newPool(denomIn, denomOut),
+			deniedDenoms: map[string]struct{}{denomIn: {}},
+
+			expectedShouldSkip: true,
+		},
+		{
+			name: "allowed intermediate denom -> returns false",
+
+			pool:          newPool(denomIn, denomIntermediate),
+			allowedDenoms: map[string]struct{}{denomIntermediate: {}},
+
+			expectedShouldSkip: false,
+		},
+		{
+			name: "intermediate denom not allowed -> returns true",
+
+			pool:          newPool(denomIn, denomIntermediate),
+			allowedDenoms: map[string]struct{}{"other": {}},
+
+			expectedShouldSkip: true,
+		},
+		{
+			name: "direct token in and token out denoms bypass allowlist -> returns false",
+
+			pool:          newPool(denomIn, denomOut),
+			allowedDenoms: map[string]struct{}{"other": {}},
+
+			expectedShouldSkip: false,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			denomFilter := domain.CandidateRouteDenomFilterOptionCb{
+				AllowedDenoms: tc.allowedDenoms,
+				DeniedDenoms:  tc.deniedDenoms,
+				TokenInDenom:  denomIn,
+				TokenOutDenom: denomOut,
+			}
+
+			require.Equal(t, tc.expectedShouldSkip, denomFilter.ShouldSkipPool(tc.pool))
+		})
+	}
+}
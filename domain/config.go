@@ -22,6 +22,9 @@ type Plugin interface {
 type Config struct {
 	// Defines the web server configuration.
 	ServerAddress string `mapstructure:"server-address"`
+	// The number of seconds to wait for the in-flight requests to drain on shutdown
+	// before the server is forcefully stopped.
+	ShutdownTimeoutSeconds int `mapstructure:"shutdown-timeout-seconds"`
 
 	// Defines the logger configuration.
 	LoggerFilename     string `mapstructure:"logger-filename"`
@@ -38,8 +41,16 @@ type Config struct {
 	// Defines the block interval at which the assets are updated.
 	UpdateAssetsHeightInterval int `mapstructure:"update-assets-height-interval"`
 
+	// HumanDenomAliases maps the human denom aliases to either a chain denom or a human denom from the registry.
+	// Merged with the registry when translating human denoms to chain denoms.
+	// For example, "atom" -> "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2".
+	HumanDenomAliases map[string]string `mapstructure:"human-denom-aliases"`
+
 	FlightRecord *FlightRecordConfig `mapstructure:"flight-record"`
 
+	// Request logging configuration.
+	RequestLogging *RequestLoggingConfig `mapstructure:"request-logging"`
+
 	// Router encapsulates the router config.
 	Router *RouterConfig `mapstructure:"router"`
 
@@ -66,6 +77,7 @@ const envPrefix = "SQS"
 var (
 	DefaultConfig = Config{
 		ServerAddress:              ":9092",
+		ShutdownTimeoutSeconds:     10,
 		LoggerFilename:             "sqs.log",
 		LoggerIsProduction:         false,
 		LoggerLevel:                "info",
@@ -79,6 +91,10 @@ var (
 			TraceThresholdMS: 1000,
 			TraceFileName:    "/tmp/sqs-flight-record.trace",
 		},
+		RequestLogging: &RequestLoggingConfig{
+			Enabled: true,
+			Level:   "debug",
+		},
 		Pools: &PoolsConfig{
 			TransmuterCodeIDs: []uint64{
 				148,
@@ -99,9 +115,15 @@ var (
 				641,
 				842,
 			},
+			CanonicalOrderbookPoolIDs: []uint64{},
+			// Disabled by default.
+			CosmWasmCircuitBreakerFailureThreshold: 0,
+			CosmWasmCircuitBreakerCooldownSeconds:  60,
 		},
 		Router: &RouterConfig{
 			PreferredPoolIDs:                 []uint64{},
+			AllowedDenoms:                    []string{},
+			DeniedDenoms:                     []string{},
 			MaxPoolsPerRoute:                 4,
 			MaxRoutes:                        20,
 			MaxSplitRoutes:                   3,
@@ -142,12 +164,17 @@ var (
 			CoingeckoUrl:              "https://prices.osmosis.zone/api/v3/simple/price",
 			CoingeckoQuoteCurrency:    "usd",
 			WorkerMinPoolLiquidityCap: 1,
+			SkippedRepricingDenomPrefixes: []string{
+				"gamm/pool",
+			},
 		},
 		Passthrough: &passthroughdomain.PassthroughConfig{
 			NumiaURL:                     "https://public-osmosis-api.numia.dev",
 			TimeseriesURL:                "https://stage-proxy-data-api.osmosis-labs.workers.dev",
 			APRFetchIntervalMinutes:      5,
 			PoolFeesFetchIntervalMinutes: 5,
+			GRPCMaxRetries:               2,
+			GRPCRetryBackoffMs:           100,
 		},
 		GRPCIngester: &GRPCIngesterConfig{
 			Enabled:                        true,
@@ -164,6 +191,7 @@ var (
 		OTEL: &OTELConfig{
 			Enabled:     true,
 			Environment: "sqs-dev",
+			FailOpen:    true,
 		},
 		CORS: &CORSConfig{
 			AllowedHeaders: "Origin, Accept, Content-Type, X-Requested-With, X-Server-Time, Origin, Accept, Content-Type, X-Requested-With, X-Server-Time, Accept-Encoding, sentry-trace, baggage",
@@ -177,19 +205,33 @@ var (
 // Additionally, it sets up environment variable mappings using reflection.
 // It also handles the Plugins field by decoding it using a custom decode hook.
 // It uses Viper to handle environment variables and reflection to automatically generate environment variable mappings.
+//
+// The configuration values are determined in the following order of precedence (highest to lowest):
+// 1. Environment variables (e.g. SQS_ROUTER_MAX_ROUTES)
+// 2. Configuration file
+// 3. Default values (DefaultConfig)
+//
 // CONTRACT: viper.ReadInConfig() is called before this function.
 func UnmarshalConfig() (*Config, error) {
-	config := DefaultConfig
+	return unmarshalConfig(viper.GetViper())
+}
+
+// unmarshalConfig unmarshals the config from the given viper instance.
+// See UnmarshalConfig for details.
+func unmarshalConfig(v *viper.Viper) (*Config, error) {
+	// Copy the defaults so that decoding does not mutate the nested
+	// structs shared with DefaultConfig.
+	config := deepCopy(reflect.ValueOf(DefaultConfig)).Interface().(Config)
 
-	viper.SetEnvPrefix(envPrefix)
-	viper.AutomaticEnv()
-	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	v.SetEnvPrefix(envPrefix)
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
 
 	// Set up environment variable mappings using reflection
-	bindEnvRecursive(reflect.ValueOf(&config), "")
+	bindEnvRecursive(v, reflect.TypeOf(config), "")
 
 	// Use Viper's Unmarshal method to decode the configuration, except for the Plugins field
-	if err := viper.Unmarshal(&config, viper.DecodeHook(
+	if err := v.Unmarshal(&config, viper.DecodeHook(
 		mapstructure.ComposeDecodeHookFunc(
 			mapstructure.StringToSliceHookFunc(","),
 			viperDecodeHookFunc(),
@@ -250,13 +292,12 @@ func viperDecodeHookFunc() mapstructure.DecodeHookFunc {
 // including nested structs, without having to manually specify each binding.
 // For example, if a mapstructure tag is "foo", the environment variable will be "SQS_FOO".
 // If nested structs are present such as "foo.bar", the environment variable will be "SQS_FOO_BAR".
-func bindEnvRecursive(v reflect.Value, prefix string) {
-	t := v.Type()
-
+// The mappings are generated from the types rather than the values so that nested structs
+// that are unset in the defaults can still be configured via environment variables.
+func bindEnvRecursive(v *viper.Viper, t reflect.Type, prefix string) {
 	// Assume pointer to struct
 	if t.Kind() == reflect.Ptr {
-		v = v.Elem()
-		t = v.Type()
+		t = t.Elem()
 	}
 
 	// If not a struct after dereferencing, return
@@ -266,7 +307,6 @@ func bindEnvRecursive(v reflect.Value, prefix string) {
 
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
-		value := v.Field(i)
 
 		// Get the mapstructure tag, if any
 		tag := field.Tag.Get("mapstructure")
@@ -277,17 +317,70 @@ func bindEnvRecursive(v reflect.Value, prefix string) {
 		envName := prefix + tag
 
 		// For nested structs, recurse
-		if value.Kind() == reflect.Struct || (value.Kind() == reflect.Ptr && value.Elem().Kind() == reflect.Struct) {
-			bindEnvRecursive(value, envName+".")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Struct || (fieldType.Kind() == reflect.Ptr && fieldType.Elem().Kind() == reflect.Struct) {
+			bindEnvRecursive(v, fieldType, envName+".")
 		} else {
 			// Bind the environment variable
-			if err := viper.BindEnv(envName); err != nil {
+			if err := v.BindEnv(envName); err != nil {
 				panic(err)
 			}
 		}
 	}
 }
 
+// deepCopy returns a deep copy of the given value, following pointers,
+// interfaces, slices, maps and exported struct fields.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		cpy := reflect.New(v.Elem().Type())
+		cpy.Elem().Set(deepCopy(v.Elem()))
+		return cpy
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cpy := reflect.New(v.Type()).Elem()
+		cpy.Set(deepCopy(v.Elem()))
+		return cpy
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cpy := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cpy.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return cpy
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cpy := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cpy.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return cpy
+	case reflect.Struct:
+		cpy := reflect.New(v.Type()).Elem()
+		// Copy unexported fields by value.
+		cpy.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if cpy.Field(i).CanSet() {
+				cpy.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return cpy
+	default:
+		return v
+	}
+}
+
 // OrderBookPluginConfig encapsulates the order book plugin configuration.
 type OrderBookPluginConfig struct {
 	Enabled bool   `mapstructure:"enabled"`
@@ -315,6 +408,9 @@ type EndpointOTELConfig struct {
 type OTELConfig struct {
 	Enabled     bool   `mapstructure:"enabled"`
 	Environment string `mapstructure:"environment"`
+	// FailOpen defines whether the server continues without tracing
+	// if the trace exporter fails to initialize. Otherwise, the server fails to start.
+	FailOpen bool `mapstructure:"fail-open"`
 }
 
 // CORSConfig represents HTTP CORS headers configuration.
@@ -337,11 +433,104 @@ type FlightRecordConfig struct {
 	TraceFileName string `mapstructure:"trace-file-name"`
 }
 
+// RequestLoggingConfig encapsulates the request logging configuration.
+type RequestLoggingConfig struct {
+	// Enabled defines if the request logging is enabled.
+	Enabled bool `mapstructure:"enabled"`
+	// Level defines the log level at which the requests are logged.
+	// One of "debug", "info", "warn" or "error".
+	Level string `mapstructure:"level"`
+}
+
+// requestLoggingLevels are the log levels supported by the request logging.
+var requestLoggingLevels = map[string]struct{}{
+	"debug": {},
+	"info":  {},
+	"warn":  {},
+	"error": {},
+}
+
 // Validate validates the config. Returns an error if the config is invalid.
 // Nil is returned if the config is valid.
 func (c Config) Validate() error {
+	// Validate the router config.
+	if err := validateRouterConfig(c.Router); err != nil {
+		return err
+	}
+
+	// Validate the shutdown timeout.
+	if c.ShutdownTimeoutSeconds <= 0 {
+		return fmt.Errorf("shutdown-timeout-seconds (%d) must be greater than zero", c.ShutdownTimeoutSeconds)
+	}
+
+	// Validate the pricing quote probe amounts.
+	if c.Pricing != nil {
+		for quoteHumanDenom, probeAmount := range c.Pricing.QuoteProbeAmounts {
+			if probeAmount == 0 {
+				return fmt.Errorf("pricing quote-probe-amounts for (%s) must be greater than zero", quoteHumanDenom)
+			}
+		}
+	}
+
+	// Validate the request logging level.
+	if c.RequestLogging != nil && c.RequestLogging.Enabled {
+		if _, ok := requestLoggingLevels[c.RequestLogging.Level]; !ok {
+			return fmt.Errorf("request-logging level (%s) must be one of debug, info, warn or error", c.RequestLogging.Level)
+		}
+	}
+
+	return nil
+}
+
+// validateRouterConfig validates the router config.
+// Returns an error if the router options are inconsistent. Nil is returned if the config is valid.
+func validateRouterConfig(routerConfig *RouterConfig) error {
+	if routerConfig.MaxPoolsPerRoute <= 0 {
+		return fmt.Errorf("max-pools-per-route (%d) must be greater than zero", routerConfig.MaxPoolsPerRoute)
+	}
+
+	if routerConfig.MaxPoolsConsidered < 0 {
+		return fmt.Errorf("max-pools-considered (%d) must not be negative", routerConfig.MaxPoolsConsidered)
+	}
+
+	if !routerConfig.CandidateRouteSearchAlgorithm.IsValid() {
+		return fmt.Errorf("candidate-route-search-algorithm (%s) must be one of (%s, %s)", routerConfig.CandidateRouteSearchAlgorithm, CandidateRouteSearchAlgorithmBFS, CandidateRouteSearchAlgorithmGreedy)
+	}
+
+	if routerConfig.MaxSplitRoutes > routerConfig.MaxRoutes {
+		return fmt.Errorf("max-split-routes (%d) must not be greater than max-routes (%d)", routerConfig.MaxSplitRoutes, routerConfig.MaxRoutes)
+	}
+
+	if routerConfig.PreferredPoolTieToleranceBps > BasisPointsDenominator {
+		return fmt.Errorf("preferred-pool-tie-tolerance-bps (%d) must not be greater than %d", routerConfig.PreferredPoolTieToleranceBps, BasisPointsDenominator)
+	}
+
+	if routerConfig.MinSplitRouteOutPortion < 0 || routerConfig.MinSplitRouteOutPortion >= 1 {
+		return fmt.Errorf("min-split-route-out-portion (%f) must be in the range [0, 1)", routerConfig.MinSplitRouteOutPortion)
+	}
+
+	if routerConfig.MaxResponseRoutes < 0 {
+		return fmt.Errorf("max-response-routes (%d) must not be negative", routerConfig.MaxResponseRoutes)
+	}
+
+	// The top route must always fit in the response.
+	if routerConfig.MaxResponsePools != 0 && routerConfig.MaxResponsePools < routerConfig.MaxPoolsPerRoute {
+		return fmt.Errorf("max-response-pools (%d) must be zero or not less than max-pools-per-route (%d)", routerConfig.MaxResponsePools, routerConfig.MaxPoolsPerRoute)
+	}
+
+	deniedDenoms := make(map[string]struct{}, len(routerConfig.DeniedDenoms))
+	for _, denom := range routerConfig.DeniedDenoms {
+		deniedDenoms[denom] = struct{}{}
+	}
+
+	for _, denom := range routerConfig.AllowedDenoms {
+		if _, ok := deniedDenoms[denom]; ok {
+			return fmt.Errorf("denom (%s) must not be both in allowed-denoms and denied-denoms", denom)
+		}
+	}
+
 	// Validate the dynamic min liquidity cap filters.
-	if err := validateDynamicMinLiquidityCapDesc(c.Router.DynamicMinLiquidityCapFiltersDesc); err != nil {
+	if err := validateDynamicMinLiquidityCapDesc(routerConfig.DynamicMinLiquidityCapFiltersDesc); err != nil {
 		return err
 	}
 
@@ -2,8 +2,13 @@ package domain_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
 	"github.com/osmosis-labs/sqs/domain"
 )
 
@@ -59,3 +64,378 @@ func TestValidateDynamicMinLiquidityCapDesc(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRouterConfig(t *testing.T) {
+	validRouterConfig := func() *domain.RouterConfig {
+		return &domain.RouterConfig{
+			MaxPoolsPerRoute: 4,
+			MaxRoutes:        20,
+			MaxSplitRoutes:   3,
+			DynamicMinLiquidityCapFiltersDesc: []domain.DynamicMinLiquidityCapFilterEntry{
+				{MinTokensCap: 500, FilterValue: 50},
+				{MinTokensCap: 100, FilterValue: 10},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *domain.RouterConfig)
+		wantErr error
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *domain.RouterConfig) {},
+			wantErr: nil,
+		},
+		{
+			name: "valid config: max split routes equal to max routes",
+			modify: func(c *domain.RouterConfig) {
+				c.MaxSplitRoutes = c.MaxRoutes
+			},
+			wantErr: nil,
+		},
+		{
+			name: "valid config: empty dynamic min liquidity cap filters",
+			modify: func(c *domain.RouterConfig) {
+				c.DynamicMinLiquidityCapFiltersDesc = nil
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid config: zero max pools per route",
+			modify: func(c *domain.RouterConfig) {
+				c.MaxPoolsPerRoute = 0
+			},
+			wantErr: fmt.Errorf("max-pools-per-route (0) must be greater than zero"),
+		},
+		{
+			name: "invalid config: negative max pools per route",
+			modify: func(c *domain.RouterConfig) {
+				c.MaxPoolsPerRoute = -1
+			},
+			wantErr: fmt.Errorf("max-pools-per-route (-1) must be greater than zero"),
+		},
+		{
+			name: "valid config: greedy candidate route search algorithm",
+			modify: func(c *domain.RouterConfig) {
+				c.CandidateRouteSearchAlgorithm = domain.CandidateRouteSearchAlgorithmGreedy
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid config: unsupported candidate route search algorithm",
+			modify: func(c *domain.RouterConfig) {
+				c.CandidateRouteSearchAlgorithm = "dfs"
+			},
+			wantErr: fmt.Errorf("candidate-route-search-algorithm (dfs) must be one of (bfs, greedy)"),
+		},
+		{
+			name: "invalid config: max split routes greater than max routes",
+			modify: func(c *domain.RouterConfig) {
+				c.MaxSplitRoutes = c.MaxRoutes + 1
+			},
+			wantErr: fmt.Errorf("max-split-routes (21) must not be greater than max-routes (20)"),
+		},
+		{
+			name: "valid config: max response pools equal to max pools per route",
+			modify: func(c *domain.RouterConfig) {
+				c.MaxResponseRoutes = 1
+				c.MaxResponsePools = c.MaxPoolsPerRoute
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid config: negative max response routes",
+			modify: func(c *domain.RouterConfig) {
+				c.MaxResponseRoutes = -1
+			},
+			wantErr: fmt.Errorf("max-response-routes (-1) must not be negative"),
+		},
+		{
+			name: "invalid config: max response pools less than max pools per route",
+			modify: func(c *domain.RouterConfig) {
+				c.MaxResponsePools = c.MaxPoolsPerRoute - 1
+			},
+			wantErr: fmt.Errorf("max-response-pools (3) must be zero or not less than max-pools-per-route (4)"),
+		},
+		{
+			name: "invalid config: dynamic min liquidity cap filters not sorted descending",
+			modify: func(c *domain.RouterConfig) {
+				c.DynamicMinLiquidityCapFiltersDesc = []domain.DynamicMinLiquidityCapFilterEntry{
+					{MinTokensCap: 100, FilterValue: 10},
+					{MinTokensCap: 500, FilterValue: 50},
+				}
+			},
+			wantErr: fmt.Errorf("min_tokens_cap must be in descending order"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routerConfig := validRouterConfig()
+			tt.modify(routerConfig)
+
+			err := domain.ValidateRouterConfig(routerConfig)
+
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, tt.wantErr.Error())
+		})
+	}
+}
+
+func TestConfigValidate_DefaultConfig(t *testing.T) {
+	require.NoError(t, domain.DefaultConfig.Validate())
+}
+
+func TestConfigValidate_RequestLoggingLevel(t *testing.T) {
+	config := domain.DefaultConfig
+	config.RequestLogging = &domain.RequestLoggingConfig{
+		Enabled: true,
+		Level:   "verbose",
+	}
+
+	require.EqualError(t, config.Validate(), "request-logging level (verbose) must be one of debug, info, warn or error")
+
+	// Invalid level is ignored when request logging is disabled.
+	config.RequestLogging.Enabled = false
+	require.NoError(t, config.Validate())
+}
+
+// TestConfigValidate_ShutdownTimeoutSeconds tests that the shutdown timeout
+// must be greater than zero.
+func TestConfigValidate_ShutdownTimeoutSeconds(t *testing.T) {
+	config := domain.DefaultConfig
+
+	for _, invalidTimeout := range []int{-1, 0} {
+		config.ShutdownTimeoutSeconds = invalidTimeout
+		require.EqualError(t, config.Validate(), fmt.Sprintf("shutdown-timeout-seconds (%d) must be greater than zero", invalidTimeout))
+	}
+
+	config.ShutdownTimeoutSeconds = 1
+	require.NoError(t, config.Validate())
+}
+
+// Tests that the config is unmarshaled with the precedence of
+// environment variables over the config file over the default values.
+func TestUnmarshalConfig_Precedence(t *testing.T) {
+	const configFileContents = `{
+		"server-address": ":9093",
+		"router": {
+			"max-routes": 10,
+			"max-split-routes": 2
+		},
+		"pricing": {
+			"cache-expiry-ms": 3000
+		},
+		"otel": {
+			"environment": "sqs-file"
+		}
+	}`
+
+	tests := []struct {
+		name string
+
+		withConfigFile bool
+		env            map[string]string
+
+		expectedConfig func() domain.Config
+	}{
+		{
+			name: "defaults only",
+
+			expectedConfig: func() domain.Config {
+				return domain.DefaultConfig
+			},
+		},
+		{
+			name: "config file overrides defaults",
+
+			withConfigFile: true,
+
+			expectedConfig: func() domain.Config {
+				config := copyDefaultConfig()
+				config.ServerAddress = ":9093"
+				config.Router.MaxRoutes = 10
+				config.Router.MaxSplitRoutes = 2
+				config.Pricing.CacheExpiryMs = 3000
+				config.OTEL.Environment = "sqs-file"
+				return config
+			},
+		},
+		{
+			name: "env overrides defaults",
+
+			env: map[string]string{
+				"SQS_ROUTER_MAX_ROUTES":         "15",
+				"SQS_ROUTER_PREFERRED_POOL_IDS": "1,2",
+				"SQS_PRICING_CACHE_EXPIRY_MS":   "5000",
+				"SQS_OTEL_ENABLED":              "false",
+				"SQS_REQUEST_LOGGING_LEVEL":     "info",
+			},
+
+			expectedConfig: func() domain.Config {
+				config := copyDefaultConfig()
+				config.Router.MaxRoutes = 15
+				config.Router.PreferredPoolIDs = []uint64{1, 2}
+				config.Pricing.CacheExpiryMs = 5000
+				config.OTEL.Enabled = false
+				config.RequestLogging.Level = "info"
+				return config
+			},
+		},
+		{
+			name: "env overrides config file",
+
+			withConfigFile: true,
+			env: map[string]string{
+				"SQS_SERVER_ADDRESS":          ":9094",
+				"SQS_ROUTER_MAX_ROUTES":       "15",
+				"SQS_PRICING_CACHE_EXPIRY_MS": "5000",
+				"SQS_OTEL_ENVIRONMENT":        "sqs-env",
+			},
+
+			expectedConfig: func() domain.Config {
+				config := copyDefaultConfig()
+				config.ServerAddress = ":9094"
+				config.Router.MaxRoutes = 15
+				// Not overridden by env, hence the config file value.
+				config.Router.MaxSplitRoutes = 2
+				config.Pricing.CacheExpiryMs = 5000
+				config.OTEL.Environment = "sqs-env"
+				return config
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			v := viper.New()
+			if tt.withConfigFile {
+				configPath := filepath.Join(t.TempDir(), "config.json")
+				require.NoError(t, os.WriteFile(configPath, []byte(configFileContents), 0o600))
+
+				v.SetConfigFile(configPath)
+				require.NoError(t, v.ReadInConfig())
+			}
+
+			// System under test
+			config, err := domain.UnmarshalConfigWithViper(v)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expectedConfig(), *config)
+		})
+	}
+
+	// Validate that unmarshaling did not mutate the defaults.
+	require.Equal(t, 20, domain.DefaultConfig.Router.MaxRoutes)
+	require.Equal(t, 2000, domain.DefaultConfig.Pricing.CacheExpiryMs)
+	require.True(t, domain.DefaultConfig.OTEL.Enabled)
+}
+
+// copyDefaultConfig returns a copy of the default config with the nested
+// configs that are modified by the tests copied as well.
+func copyDefaultConfig() domain.Config {
+	config := domain.DefaultConfig
+
+	router := *config.Router
+	config.Router = &router
+
+	pricing := *config.Pricing
+	config.Pricing = &pricing
+
+	otel := *config.OTEL
+	config.OTEL = &otel
+
+	requestLogging := *config.RequestLogging
+	config.RequestLogging = &requestLogging
+
+	return config
+}
+
+func TestConfigValidate_QuoteProbeAmounts(t *testing.T) {
+	config := domain.DefaultConfig
+	pricing := *config.Pricing
+	pricing.QuoteProbeAmounts = map[string]uint64{
+		"usdc": 0,
+	}
+	config.Pricing = &pricing
+
+	require.EqualError(t, config.Validate(), "pricing quote-probe-amounts for (usdc) must be greater than zero")
+
+	pricing.QuoteProbeAmounts["usdc"] = 1000
+	require.NoError(t, config.Validate())
+}
+
+// TestConfigValidate_MinSplitRouteOutPortion tests that the min split route out portion
+// must be in the range [0, 1).
+func TestConfigValidate_MinSplitRouteOutPortion(t *testing.T) {
+	config := domain.DefaultConfig
+	router := *config.Router
+	config.Router = &router
+
+	for _, invalidPortion := range []float64{-0.1, 1} {
+		router.MinSplitRouteOutPortion = invalidPortion
+		require.Error(t, config.Validate())
+	}
+
+	for _, validPortion := range []float64{0, 0.05} {
+		router.MinSplitRouteOutPortion = validPortion
+		require.NoError(t, config.Validate())
+	}
+}
+
+// TestConfigValidate_MaxPoolsConsidered tests that the max pools considered
+// must not be negative.
+func TestConfigValidate_MaxPoolsConsidered(t *testing.T) {
+	config := domain.DefaultConfig
+	router := *config.Router
+	config.Router = &router
+
+	router.MaxPoolsConsidered = -1
+	require.EqualError(t, config.Validate(), "max-pools-considered (-1) must not be negative")
+
+	for _, validMaxPoolsConsidered := range []int{0, 10} {
+		router.MaxPoolsConsidered = validMaxPoolsConsidered
+		require.NoError(t, config.Validate())
+	}
+}
+
+// TestConfigValidate_AllowedAndDeniedDenoms tests that a denom
+// must not be both allowed and denied.
+func TestConfigValidate_AllowedAndDeniedDenoms(t *testing.T) {
+	config := domain.DefaultConfig
+	router := *config.Router
+	config.Router = &router
+
+	router.AllowedDenoms = []string{"uosmo", "uion"}
+	router.DeniedDenoms = []string{"uion"}
+	require.EqualError(t, config.Validate(), "denom (uion) must not be both in allowed-denoms and denied-denoms")
+
+	router.DeniedDenoms = []string{"uatom"}
+	require.NoError(t, config.Validate())
+}
+
+// TestConfigValidate_PreferredPoolTieToleranceBps tests that the preferred pool tie tolerance
+// must not be greater than the basis points denominator.
+func TestConfigValidate_PreferredPoolTieToleranceBps(t *testing.T) {
+	config := domain.DefaultConfig
+	router := *config.Router
+	config.Router = &router
+
+	router.PreferredPoolTieToleranceBps = domain.BasisPointsDenominator + 1
+	require.EqualError(t, config.Validate(), "preferred-pool-tie-tolerance-bps (10001) must not be greater than 10000")
+
+	for _, validToleranceBps := range []uint64{0, 50, domain.BasisPointsDenominator} {
+		router.PreferredPoolTieToleranceBps = validToleranceBps
+		require.NoError(t, config.Validate())
+	}
+}
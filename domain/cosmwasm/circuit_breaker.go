@@ -0,0 +1,185 @@
+package cosmwasmdomain
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// CircuitBreaker tracks the consecutive failures of the network calls made by generalized CosmWasm pools.
+// Once a pool reaches the failure threshold, its circuit opens and the pool is excluded from routing
+// for the cooldown period. After the cooldown, the circuit is half-open: a single network call at a time
+// is allowed to probe the pool. A success closes the circuit while a failure reopens it.
+type CircuitBreaker struct {
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu           sync.RWMutex
+	poolStatuses map[uint64]*circuitBreakerPoolStatus
+
+	// now returns the current time. Overridable in tests.
+	now func() time.Time
+}
+
+// circuitBreakerPoolStatus is the status of a pool with at least one failure since the last success.
+type circuitBreakerPoolStatus struct {
+	consecutiveFailures int
+	openedAt            time.Time
+	// probeStartedAt is the time the in-flight half-open probe started at. Zero if there is none.
+	probeStartedAt time.Time
+}
+
+var _ domain.CosmWasmCircuitBreaker = &CircuitBreaker{}
+
+// NewCircuitBreaker returns a new circuit breaker that opens the circuit of a pool after failureThreshold
+// consecutive failures, keeping it open for the given cooldown.
+func NewCircuitBreaker(failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		poolStatuses:     make(map[uint64]*circuitBreakerPoolStatus),
+		now:              time.Now,
+	}
+}
+
+// RecordSuccess records a successful network call for the given pool, closing its circuit.
+func (c *CircuitBreaker) RecordSuccess(poolID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.poolStatuses, poolID)
+}
+
+// RecordFailure records a failed network call for the given pool.
+// Opens the circuit if the failure threshold is reached. A failure while the circuit
+// is half-open reopens it for another cooldown period. Failures while the circuit is already
+// open do not extend the cooldown.
+func (c *CircuitBreaker) RecordFailure(poolID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status, ok := c.poolStatuses[poolID]
+	if !ok {
+		status = &circuitBreakerPoolStatus{}
+		c.poolStatuses[poolID] = status
+	}
+
+	// Calls that were already in flight when the circuit opened might still fail.
+	// Only the transition to the open state starts the cooldown.
+	wasOpen := c.getState(status) == domain.CosmWasmCircuitBreakerOpen
+
+	status.consecutiveFailures++
+	status.probeStartedAt = time.Time{}
+
+	if !wasOpen && status.consecutiveFailures >= c.failureThreshold {
+		status.openedAt = c.now()
+
+		domain.SQSCosmWasmCircuitBreakerOpenedCounter.Inc()
+	}
+}
+
+// AllowRequest returns true if a network call may be made for the given pool.
+// While the circuit is half-open, only a single call at a time is allowed to probe the pool.
+// The probe is released by recording its result. A probe that is not released within the cooldown
+// is considered lost so that the pool is probed again.
+func (c *CircuitBreaker) AllowRequest(poolID uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status, ok := c.poolStatuses[poolID]
+	if !ok {
+		return true
+	}
+
+	switch c.getState(status) {
+	case domain.CosmWasmCircuitBreakerOpen:
+		return false
+	case domain.CosmWasmCircuitBreakerHalfOpen:
+		if c.isProbeInFlight(status) {
+			return false
+		}
+
+		status.probeStartedAt = c.now()
+		return true
+	default:
+		return true
+	}
+}
+
+// ReleaseProbe releases the half-open probe of the given pool without recording a result.
+// Used for the calls that were cancelled before the pool responded.
+func (c *CircuitBreaker) ReleaseProbe(poolID uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if status, ok := c.poolStatuses[poolID]; ok {
+		status.probeStartedAt = time.Time{}
+	}
+}
+
+// IsCosmWasmPoolCircuitOpen implements domain.CosmWasmCircuitBreaker.
+// Returns false once the cooldown elapses so that the pool is probed unless
+// another call is already probing it.
+func (c *CircuitBreaker) IsCosmWasmPoolCircuitOpen(poolID uint64) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	status, ok := c.poolStatuses[poolID]
+	if !ok {
+		return false
+	}
+
+	switch c.getState(status) {
+	case domain.CosmWasmCircuitBreakerOpen:
+		return true
+	case domain.CosmWasmCircuitBreakerHalfOpen:
+		return c.isProbeInFlight(status)
+	default:
+		return false
+	}
+}
+
+// GetPoolStatuses returns the circuit breaker statuses of all pools with at least one
+// failure since the last success. Sorted by pool ID.
+func (c *CircuitBreaker) GetPoolStatuses() []domain.CosmWasmCircuitBreakerPoolStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	result := make([]domain.CosmWasmCircuitBreakerPoolStatus, 0, len(c.poolStatuses))
+	for poolID, status := range c.poolStatuses {
+		result = append(result, domain.CosmWasmCircuitBreakerPoolStatus{
+			PoolID:              poolID,
+			State:               c.getState(status),
+			ConsecutiveFailures: status.consecutiveFailures,
+			OpenedAt:            status.openedAt,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PoolID < result[j].PoolID
+	})
+
+	return result
+}
+
+// getState returns the state of the circuit given the pool status.
+// CONTRACT: the caller holds the lock.
+func (c *CircuitBreaker) getState(status *circuitBreakerPoolStatus) domain.CosmWasmCircuitBreakerState {
+	if status.consecutiveFailures < c.failureThreshold {
+		return domain.CosmWasmCircuitBreakerClosed
+	}
+
+	if c.now().Before(status.openedAt.Add(c.cooldown)) {
+		return domain.CosmWasmCircuitBreakerOpen
+	}
+
+	return domain.CosmWasmCircuitBreakerHalfOpen
+}
+
+// isProbeInFlight returns true if the half-open probe of the pool has started and has not been lost.
+// CONTRACT: the caller holds the lock.
+func (c *CircuitBreaker) isProbeInFlight(status *circuitBreakerPoolStatus) bool {
+	return !status.probeStartedAt.IsZero() && c.now().Before(status.probeStartedAt.Add(c.cooldown))
+}
@@ -0,0 +1,155 @@
+package cosmwasmdomain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/domain"
+	cosmwasmdomain "github.com/osmosis-labs/sqs/domain/cosmwasm"
+)
+
+const (
+	defaultPoolID    = uint64(1)
+	failureThreshold = 3
+	cooldown         = time.Minute
+)
+
+// Tests that the circuit breaker opens after the failure threshold is reached,
+// becomes half-open after the cooldown, and closes or reopens depending on the probe result.
+func TestCircuitBreaker_OpenAndClose(t *testing.T) {
+	currentTime := time.Unix(1_700_000_000, 0).UTC()
+
+	circuitBreaker := cosmwasmdomain.NewCircuitBreaker(failureThreshold, cooldown)
+	circuitBreaker.SetNow(func() time.Time { return currentTime })
+
+	openedCountBefore := testutil.ToFloat64(domain.SQSCosmWasmCircuitBreakerOpenedCounter)
+
+	// Failures below the threshold keep the circuit closed.
+	for i := 0; i < failureThreshold-1; i++ {
+		circuitBreaker.RecordFailure(defaultPoolID)
+		require.False(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+	}
+	require.Equal(t, []domain.CosmWasmCircuitBreakerPoolStatus{
+		{
+			PoolID:              defaultPoolID,
+			State:               domain.CosmWasmCircuitBreakerClosed,
+			ConsecutiveFailures: failureThreshold - 1,
+		},
+	}, circuitBreaker.GetPoolStatuses())
+
+	// Reaching the threshold opens the circuit.
+	circuitBreaker.RecordFailure(defaultPoolID)
+	require.True(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+	require.Equal(t, openedCountBefore+1, testutil.ToFloat64(domain.SQSCosmWasmCircuitBreakerOpenedCounter))
+	require.Equal(t, []domain.CosmWasmCircuitBreakerPoolStatus{
+		{
+			PoolID:              defaultPoolID,
+			State:               domain.CosmWasmCircuitBreakerOpen,
+			ConsecutiveFailures: failureThreshold,
+			OpenedAt:            currentTime,
+		},
+	}, circuitBreaker.GetPoolStatuses())
+
+	// Failures while the circuit is open neither extend the cooldown nor count as another opening.
+	openedAt := currentTime
+	currentTime = currentTime.Add(time.Second)
+	circuitBreaker.RecordFailure(defaultPoolID)
+	require.Equal(t, openedCountBefore+1, testutil.ToFloat64(domain.SQSCosmWasmCircuitBreakerOpenedCounter))
+	require.Equal(t, openedAt, circuitBreaker.GetPoolStatuses()[0].OpenedAt)
+	require.Equal(t, failureThreshold+1, circuitBreaker.GetPoolStatuses()[0].ConsecutiveFailures)
+
+	// Other pools are unaffected.
+	require.False(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID+1))
+
+	// Right before the cooldown elapses, the circuit is still open.
+	currentTime = openedAt.Add(cooldown - time.Second)
+	require.True(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+
+	// After the cooldown, the circuit is half-open so that the pool is probed.
+	currentTime = currentTime.Add(time.Second)
+	require.False(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+	require.Equal(t, domain.CosmWasmCircuitBreakerHalfOpen, circuitBreaker.GetPoolStatuses()[0].State)
+
+	// A failed probe reopens the circuit for another cooldown.
+	circuitBreaker.RecordFailure(defaultPoolID)
+	require.True(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+	require.Equal(t, currentTime, circuitBreaker.GetPoolStatuses()[0].OpenedAt)
+	require.Equal(t, openedCountBefore+2, testutil.ToFloat64(domain.SQSCosmWasmCircuitBreakerOpenedCounter))
+
+	// A successful probe after the cooldown closes the circuit and resets the failures.
+	currentTime = currentTime.Add(cooldown)
+	require.False(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+	circuitBreaker.RecordSuccess(defaultPoolID)
+	require.False(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+	require.Empty(t, circuitBreaker.GetPoolStatuses())
+
+	// The failure count starts over after closing.
+	circuitBreaker.RecordFailure(defaultPoolID)
+	require.False(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+}
+
+// Tests that a success before reaching the threshold resets the consecutive failures.
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	circuitBreaker := cosmwasmdomain.NewCircuitBreaker(failureThreshold, cooldown)
+
+	for i := 0; i < failureThreshold-1; i++ {
+		circuitBreaker.RecordFailure(defaultPoolID)
+	}
+	circuitBreaker.RecordSuccess(defaultPoolID)
+
+	for i := 0; i < failureThreshold-1; i++ {
+		circuitBreaker.RecordFailure(defaultPoolID)
+	}
+	require.False(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+}
+
+// Tests that only a single probe at a time is allowed while the circuit is half-open.
+func TestCircuitBreaker_HalfOpenSingleProbe(t *testing.T) {
+	currentTime := time.Unix(1_700_000_000, 0).UTC()
+
+	circuitBreaker := cosmwasmdomain.NewCircuitBreaker(failureThreshold, cooldown)
+	circuitBreaker.SetNow(func() time.Time { return currentTime })
+
+	// Closed circuit allows all requests.
+	require.True(t, circuitBreaker.AllowRequest(defaultPoolID))
+	require.True(t, circuitBreaker.AllowRequest(defaultPoolID))
+
+	for i := 0; i < failureThreshold; i++ {
+		circuitBreaker.RecordFailure(defaultPoolID)
+	}
+
+	// Open circuit allows no requests.
+	require.False(t, circuitBreaker.AllowRequest(defaultPoolID))
+
+	// Half-open circuit allows a single probe.
+	currentTime = currentTime.Add(cooldown)
+	require.True(t, circuitBreaker.AllowRequest(defaultPoolID))
+	require.False(t, circuitBreaker.AllowRequest(defaultPoolID))
+	require.True(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+	require.Equal(t, domain.CosmWasmCircuitBreakerHalfOpen, circuitBreaker.GetPoolStatuses()[0].State)
+
+	// Releasing the probe of a cancelled call allows the next one.
+	circuitBreaker.ReleaseProbe(defaultPoolID)
+	require.False(t, circuitBreaker.IsCosmWasmPoolCircuitOpen(defaultPoolID))
+	require.True(t, circuitBreaker.AllowRequest(defaultPoolID))
+	require.False(t, circuitBreaker.AllowRequest(defaultPoolID))
+
+	// A lost probe is expired after the cooldown.
+	currentTime = currentTime.Add(cooldown)
+	require.True(t, circuitBreaker.AllowRequest(defaultPoolID))
+
+	// A failed probe reopens the circuit.
+	circuitBreaker.RecordFailure(defaultPoolID)
+	require.False(t, circuitBreaker.AllowRequest(defaultPoolID))
+
+	// The probe after the next cooldown closes the circuit on success.
+	currentTime = currentTime.Add(cooldown)
+	require.True(t, circuitBreaker.AllowRequest(defaultPoolID))
+	circuitBreaker.RecordSuccess(defaultPoolID)
+	require.True(t, circuitBreaker.AllowRequest(defaultPoolID))
+	require.True(t, circuitBreaker.AllowRequest(defaultPoolID))
+	require.Empty(t, circuitBreaker.GetPoolStatuses())
+}
@@ -16,6 +16,9 @@ type CosmWasmPoolsParams struct {
 	Config                domain.CosmWasmPoolRouterConfig
 	WasmClient            wasmtypes.QueryClient
 	ScalingFactorGetterCb domain.ScalingFactorGetterCb
+	// CircuitBreaker tracks the failures of the network calls made by generalized CosmWasm pools.
+	// Nil if disabled.
+	CircuitBreaker *CircuitBreaker
 }
 
 // QueryCosmwasmContract queries the cosmwasm contract given the contract address, request and response
@@ -0,0 +1,8 @@
+package cosmwasmdomain
+
+import "time"
+
+// SetNow sets the function returning the current time on the circuit breaker.
+func (c *CircuitBreaker) SetNow(now func() time.Time) {
+	c.now = now
+}
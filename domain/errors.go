@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 var (
@@ -30,6 +31,14 @@ func GetStatusCode(err error) int {
 		return http.StatusOK
 	}
 
+	// Typed errors that are mapped to a status code regardless of wrapping.
+	if errors.As(err, &NoCandidateRoutesError{}) {
+		return http.StatusNotFound
+	}
+	if errors.As(err, &ZeroAmountOutError{}) {
+		return http.StatusUnprocessableEntity
+	}
+
 	switch err {
 	case ErrInternalServerError:
 		return http.StatusInternalServerError
@@ -65,6 +74,15 @@ func (e UnsupportedCosmWasmPoolError) Error() string {
 	return fmt.Sprintf("Pool %d is a CosmWasm pool but is not supported", e.PoolId)
 }
 
+// CosmWasmPoolCircuitOpenError is an error type for a CosmWasm pool whose circuit is open.
+type CosmWasmPoolCircuitOpenError struct {
+	PoolId uint64
+}
+
+func (e CosmWasmPoolCircuitOpenError) Error() string {
+	return fmt.Sprintf("Pool %d is a CosmWasm pool with an open circuit", e.PoolId)
+}
+
 type PoolNotFoundError struct {
 	PoolID uint64
 }
@@ -90,6 +108,18 @@ func (e TakerFeeNotFoundForDenomPairError) Error() string {
 	return fmt.Sprintf("taker fee not found for denom pair (%s, %s)", e.Denom0, e.Denom1)
 }
 
+// TakerFeeNotFoundForPoolError is returned when the taker fee for a denom pair
+// of the given pool is not found.
+type TakerFeeNotFoundForPoolError struct {
+	PoolID uint64
+	Denom0 string
+	Denom1 string
+}
+
+func (e TakerFeeNotFoundForPoolError) Error() string {
+	return fmt.Sprintf("taker fee not found for pool %d, denom in (%s), denom out (%s)", e.PoolID, e.Denom0, e.Denom1)
+}
+
 type FailedToCastPoolModelError struct {
 	ExpectedModel string
 	ActualModel   string
@@ -296,6 +326,38 @@ func (e PriceNotFoundForPoolLiquidityCapError) Error() string {
 	return fmt.Sprintf("price not found (zero) for denom %s", e.Denom)
 }
 
+// DenomRepricingSkippedError is returned when the repricing of the denom is skipped
+// because it was already repriced at a later height or it is a gamm share.
+type DenomRepricingSkippedError struct {
+	Denom  string
+	Height uint64
+}
+
+func (e DenomRepricingSkippedError) Error() string {
+	return fmt.Sprintf("repricing skipped for denom (%s) at height (%d)", e.Denom, e.Height)
+}
+
+// CapitalizationOverflowError is returned when the capitalization computed
+// for the given denom exceeds the range of osmomath.Int.
+type CapitalizationOverflowError struct {
+	Denom string
+}
+
+func (e CapitalizationOverflowError) Error() string {
+	return fmt.Sprintf("capitalization overflows for denom (%s)", e.Denom)
+}
+
+// ZeroPricesError is returned in strict pricing mode when some
+// of the requested base/quote pairs priced to zero.
+type ZeroPricesError struct {
+	// BaseQuotePairs are formatted as "base/quote".
+	BaseQuotePairs []string
+}
+
+func (e ZeroPricesError) Error() string {
+	return fmt.Sprintf("prices are zero for the following base/quote pairs: %s", strings.Join(e.BaseQuotePairs, ", "))
+}
+
 type FailCastCanonicalOrderbookEntryError struct {
 	BaseQuoteKey string
 }
@@ -329,3 +391,58 @@ type StaticRateLimiterInvalidUpperLimitError struct {
 func (e StaticRateLimiterInvalidUpperLimitError) Error() string {
 	return fmt.Sprintf("invalid upper limit (%s) for weight (%s) and denom (%s)", e.UpperLimit, e.Weight, e.Denom)
 }
+
+type NoRoutesError struct {
+	TokenInDenom string
+}
+
+func (e NoRoutesError) Error() string {
+	return fmt.Sprintf("no routes were provided for token in (%s)", e.TokenInDenom)
+}
+
+// NoCandidateRoutesError is returned when no candidate routes are found
+// between the token in and token out denoms.
+// The error message is kept the same as the one of the previously untyped error for compatibility.
+type NoCandidateRoutesError struct {
+	TokenIn  string
+	TokenOut string
+}
+
+func (e NoCandidateRoutesError) Error() string {
+	return "no candidate routes found"
+}
+
+// ZeroAmountOutError is returned when the best quote between the token in
+// and token out denoms results in no tokens out.
+// The error message is kept the same as the one of the previously untyped error for compatibility.
+type ZeroAmountOutError struct {
+	TokenIn  string
+	TokenOut string
+}
+
+func (e ZeroAmountOutError) Error() string {
+	return "best we can do is no tokens out"
+}
+
+type RoutedSpotPriceNoRouteError struct {
+	BaseDenom  string
+	QuoteDenom string
+}
+
+func (e RoutedSpotPriceNoRouteError) Error() string {
+	return fmt.Sprintf("no route found to compute routed spot price, base denom (%s), quote denom (%s)", e.BaseDenom, e.QuoteDenom)
+}
+
+// BalancesPricingError is returned when the balances were fetched successfully
+// but failed to be priced. The result accompanying the error is a best-effort one.
+type BalancesPricingError struct {
+	Err error
+}
+
+func (e BalancesPricingError) Error() string {
+	return fmt.Sprintf("failed to price balances: %v", e.Err)
+}
+
+func (e BalancesPricingError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,36 @@
+package domain_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// TestGetStatusCode tests that errors are mapped to the expected HTTP status codes.
+func TestGetStatusCode(t *testing.T) {
+	testCases := []struct {
+		name               string
+		err                error
+		expectedStatusCode int
+	}{
+		{"nil error", nil, http.StatusOK},
+		{"not found", domain.ErrNotFound, http.StatusNotFound},
+		{"conflict", domain.ErrConflict, http.StatusConflict},
+		{"unknown error", errors.New("unknown"), http.StatusInternalServerError},
+		{"no candidate routes", domain.NoCandidateRoutesError{TokenIn: "uosmo", TokenOut: "uion"}, http.StatusNotFound},
+		{"wrapped no candidate routes", fmt.Errorf("wrapped: %w", domain.NoCandidateRoutesError{TokenIn: "uosmo", TokenOut: "uion"}), http.StatusNotFound},
+		{"zero amount out", domain.ZeroAmountOutError{TokenIn: "uosmo", TokenOut: "uion"}, http.StatusUnprocessableEntity},
+		{"wrapped zero amount out", fmt.Errorf("wrapped: %w", domain.ZeroAmountOutError{TokenIn: "uosmo", TokenOut: "uion"}), http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expectedStatusCode, domain.GetStatusCode(tc.err))
+		})
+	}
+}
@@ -1,5 +1,15 @@
 package domain
 
+import "github.com/spf13/viper"
+
 func ValidateDynamicMinLiquidityCapDesc(values []DynamicMinLiquidityCapFilterEntry) error {
 	return validateDynamicMinLiquidityCapDesc(values)
 }
+
+func ValidateRouterConfig(routerConfig *RouterConfig) error {
+	return validateRouterConfig(routerConfig)
+}
+
+func UnmarshalConfigWithViper(v *viper.Viper) (*Config, error) {
+	return unmarshalConfig(v)
+}
@@ -7,6 +7,7 @@ var _ mvc.ChainInfoUsecase = &ChainInfoUsecaseMock{}
 // ChainInfoUsecaseMock is a mock implementation of the ChainInfoUsecase interface
 type ChainInfoUsecaseMock struct {
 	GetLatestHeightFunc                         func() (uint64, error)
+	GetLatestObservedHeightFunc                 func() uint64
 	StoreLatestHeightFunc                       func(height uint64)
 	ValidatePriceUpdatesFunc                    func() error
 	ValidatePoolLiquidityUpdatesFunc            func() error
@@ -20,6 +21,13 @@ func (m *ChainInfoUsecaseMock) GetLatestHeight() (uint64, error) {
 	return 0, nil
 }
 
+func (m *ChainInfoUsecaseMock) GetLatestObservedHeight() uint64 {
+	if m.GetLatestObservedHeightFunc != nil {
+		return m.GetLatestObservedHeightFunc()
+	}
+	return 0
+}
+
 func (m *ChainInfoUsecaseMock) StoreLatestHeight(height uint64) {
 	if m.StoreLatestHeightFunc != nil {
 		m.StoreLatestHeightFunc(height)
@@ -8,12 +8,14 @@ import (
 )
 
 type LiquidityPricerMock struct {
-	PriceBalancesFunc func(balances types.Coins, blockPriceUpdates domain.PricesResult) (math.Int, string)
+	PriceBalancesFunc func(balances types.Coins, blockPriceUpdates domain.PricesResult) (math.Int, string, error)
 	PriceCoinFunc     func(liquidity types.Coin, price osmomath.BigDec) math.LegacyDec
+	GetQuoteDenomFunc func() string
+	SetQuoteDenomFunc func(quoteDenom string)
 }
 
 // PriceBalances implements domain.LiquidityPricer.
-func (l *LiquidityPricerMock) PriceBalances(balances types.Coins, blockPriceUpdates domain.PricesResult) (math.Int, string) {
+func (l *LiquidityPricerMock) PriceBalances(balances types.Coins, blockPriceUpdates domain.PricesResult) (math.Int, string, error) {
 	if l.PriceBalancesFunc != nil {
 		return l.PriceBalancesFunc(balances, blockPriceUpdates)
 	}
@@ -28,4 +30,21 @@ func (l *LiquidityPricerMock) PriceCoin(liquidity types.Coin, price osmomath.Big
 	panic("unimplemented")
 }
 
+// GetQuoteDenom implements domain.LiquidityPricer.
+func (l *LiquidityPricerMock) GetQuoteDenom() string {
+	if l.GetQuoteDenomFunc != nil {
+		return l.GetQuoteDenomFunc()
+	}
+	panic("unimplemented")
+}
+
+// SetQuoteDenom implements domain.LiquidityPricer.
+func (l *LiquidityPricerMock) SetQuoteDenom(quoteDenom string) {
+	if l.SetQuoteDenomFunc != nil {
+		l.SetQuoteDenomFunc(quoteDenom)
+		return
+	}
+	panic("unimplemented")
+}
+
 var _ domain.LiquidityPricer = &LiquidityPricerMock{}
@@ -13,6 +13,7 @@ var _ orderbookgrpcclientdomain.OrderBookClient = (*OrderbookGRPCClientMock)(nil
 type OrderbookGRPCClientMock struct {
 	GetOrdersByTickCb            func(ctx context.Context, contractAddress string, tick int64) (orderbookdomain.Orders, error)
 	GetActiveOrdersCb            func(ctx context.Context, contractAddress string, ownerAddress string) (orderbookdomain.Orders, uint64, error)
+	GetOrderHistoryCb            func(ctx context.Context, contractAddress string, ownerAddress string) ([]orderbookdomain.HistoricalOrder, error)
 	GetTickUnrealizedCancelsCb   func(ctx context.Context, contractAddress string, tickIDs []int64) ([]orderbookgrpcclientdomain.UnrealizedTickCancels, error)
 	FetchTickUnrealizedCancelsCb func(ctx context.Context, chunkSize int, contractAddress string, tickIDs []int64) ([]orderbookgrpcclientdomain.UnrealizedTickCancels, error)
 	MockQueryTicksCb             func(ctx context.Context, contractAddress string, ticks []int64) ([]orderbookdomain.Tick, error)
@@ -35,6 +36,14 @@ func (o *OrderbookGRPCClientMock) GetActiveOrders(ctx context.Context, contractA
 	return nil, 0, nil
 }
 
+func (o *OrderbookGRPCClientMock) GetOrderHistory(ctx context.Context, contractAddress string, ownerAddress string) ([]orderbookdomain.HistoricalOrder, error) {
+	if o.GetOrderHistoryCb != nil {
+		return o.GetOrderHistoryCb(ctx, contractAddress, ownerAddress)
+	}
+
+	return nil, nil
+}
+
 func (o *OrderbookGRPCClientMock) GetTickUnrealizedCancels(ctx context.Context, contractAddress string, tickIDs []int64) ([]orderbookgrpcclientdomain.UnrealizedTickCancels, error) {
 	if o.GetTickUnrealizedCancelsCb != nil {
 		return o.GetTickUnrealizedCancelsCb(ctx, contractAddress, tickIDs)
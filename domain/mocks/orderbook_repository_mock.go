@@ -12,6 +12,8 @@ type OrderbookRepositoryMock struct {
 	GetAllTicksFunc func(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool)
 	GetTicksFunc    func(poolID uint64, tickIDs []int64) (map[int64]orderbookdomain.OrderbookTick, error)
 	GetTickByIDFunc func(poolID uint64, tickID int64) (orderbookdomain.OrderbookTick, bool)
+
+	InvalidateTicksFunc func(poolID uint64, tickIDs []int64)
 }
 
 // StoreTicks implements OrderBookRepository.
@@ -46,3 +48,12 @@ func (m *OrderbookRepositoryMock) GetTickByID(poolID uint64, tickID int64) (orde
 	}
 	panic("GetTickByID not implemented")
 }
+
+// InvalidateTicks implements OrderBookRepository.
+func (m *OrderbookRepositoryMock) InvalidateTicks(poolID uint64, tickIDs []int64) {
+	if m.InvalidateTicksFunc != nil {
+		m.InvalidateTicksFunc(poolID, tickIDs)
+		return
+	}
+	panic("InvalidateTicks not implemented")
+}
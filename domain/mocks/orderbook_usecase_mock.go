@@ -3,6 +3,8 @@ package mocks
 import (
 	"context"
 
+	"github.com/osmosis-labs/osmosis/osmomath"
+
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
 	orderbookdomain "github.com/osmosis-labs/sqs/domain/orderbook"
@@ -13,11 +15,16 @@ var _ mvc.OrderBookUsecase = &OrderbookUsecaseMock{}
 
 // OrderbookUsecaseMock is a mock implementation of the RouterUsecase interface
 type OrderbookUsecaseMock struct {
-	ProcessPoolFunc               func(ctx context.Context, pool sqsdomain.PoolI) error
-	GetAllTicksFunc               func(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool)
-	GetActiveOrdersFunc           func(ctx context.Context, address string) ([]orderbookdomain.LimitOrder, bool, error)
-	GetActiveOrdersStreamFunc     func(ctx context.Context, address string) <-chan orderbookdomain.OrderbookResult
-	CreateFormattedLimitOrderFunc func(orderbook domain.CanonicalOrderBooksResult, order orderbookdomain.Order) (orderbookdomain.LimitOrder, error)
+	ProcessPoolFunc                         func(ctx context.Context, pool sqsdomain.PoolI) error
+	GetAllTicksFunc                         func(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool)
+	GetOrderbookSpotPriceFunc               func(base, quote string) (osmomath.BigDec, error)
+	GetOrderbookDepthFunc                   func(ctx context.Context, base, quote string, levels int) (orderbookdomain.OrderbookDepth, bool, error)
+	GetActiveOrdersFunc                     func(ctx context.Context, address string, opts ...orderbookdomain.ActiveOrdersOption) (orderbookdomain.ActiveOrdersPage, error)
+	GetActiveOrderFunc                      func(ctx context.Context, ownerAddress, contractAddress string, orderID int64) (orderbookdomain.LimitOrder, error)
+	GetOrderHistoryFunc                     func(ctx context.Context, ownerAddress string) ([]orderbookdomain.LimitOrder, bool, error)
+	GetActiveOrdersStreamFunc               func(ctx context.Context, address string) <-chan orderbookdomain.OrderbookResult
+	ProcessOrderBookActiveOrdersVerboseFunc func(ctx context.Context, orderbook domain.CanonicalOrderBooksResult, ownerAddress string) ([]orderbookdomain.LimitOrder, []orderbookdomain.OrderProcessingError, error)
+	CreateFormattedLimitOrderFunc           func(orderbook domain.CanonicalOrderBooksResult, order orderbookdomain.Order) (orderbookdomain.LimitOrder, error)
 }
 
 func (m *OrderbookUsecaseMock) ProcessPool(ctx context.Context, pool sqsdomain.PoolI) error {
@@ -34,9 +41,37 @@ func (m *OrderbookUsecaseMock) GetAllTicks(poolID uint64) (map[int64]orderbookdo
 	panic("unimplemented")
 }
 
-func (m *OrderbookUsecaseMock) GetActiveOrders(ctx context.Context, address string) ([]orderbookdomain.LimitOrder, bool, error) {
+func (m *OrderbookUsecaseMock) GetOrderbookSpotPrice(base, quote string) (osmomath.BigDec, error) {
+	if m.GetOrderbookSpotPriceFunc != nil {
+		return m.GetOrderbookSpotPriceFunc(base, quote)
+	}
+	panic("unimplemented")
+}
+
+func (m *OrderbookUsecaseMock) GetOrderbookDepth(ctx context.Context, base, quote string, levels int) (orderbookdomain.OrderbookDepth, bool, error) {
+	if m.GetOrderbookDepthFunc != nil {
+		return m.GetOrderbookDepthFunc(ctx, base, quote, levels)
+	}
+	panic("unimplemented")
+}
+
+func (m *OrderbookUsecaseMock) GetActiveOrders(ctx context.Context, address string, opts ...orderbookdomain.ActiveOrdersOption) (orderbookdomain.ActiveOrdersPage, error) {
 	if m.GetActiveOrdersFunc != nil {
-		return m.GetActiveOrdersFunc(ctx, address)
+		return m.GetActiveOrdersFunc(ctx, address, opts...)
+	}
+	panic("unimplemented")
+}
+
+func (m *OrderbookUsecaseMock) GetActiveOrder(ctx context.Context, ownerAddress, contractAddress string, orderID int64) (orderbookdomain.LimitOrder, error) {
+	if m.GetActiveOrderFunc != nil {
+		return m.GetActiveOrderFunc(ctx, ownerAddress, contractAddress, orderID)
+	}
+	panic("unimplemented")
+}
+
+func (m *OrderbookUsecaseMock) GetOrderHistory(ctx context.Context, ownerAddress string) ([]orderbookdomain.LimitOrder, bool, error) {
+	if m.GetOrderHistoryFunc != nil {
+		return m.GetOrderHistoryFunc(ctx, ownerAddress)
 	}
 	panic("unimplemented")
 }
@@ -47,6 +82,13 @@ func (m *OrderbookUsecaseMock) GetActiveOrdersStream(ctx context.Context, addres
 	}
 	panic("unimplemented")
 }
+func (m *OrderbookUsecaseMock) ProcessOrderBookActiveOrdersVerbose(ctx context.Context, orderbook domain.CanonicalOrderBooksResult, ownerAddress string) ([]orderbookdomain.LimitOrder, []orderbookdomain.OrderProcessingError, error) {
+	if m.ProcessOrderBookActiveOrdersVerboseFunc != nil {
+		return m.ProcessOrderBookActiveOrdersVerboseFunc(ctx, orderbook, ownerAddress)
+	}
+	panic("unimplemented")
+}
+
 func (m *OrderbookUsecaseMock) WithCreateFormattedLimitOrder(order orderbookdomain.LimitOrder, err error) {
 	m.CreateFormattedLimitOrderFunc = func(domain.CanonicalOrderBooksResult, orderbookdomain.Order) (orderbookdomain.LimitOrder, error) {
 		return order, err
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
+)
+
+var _ mvc.PassthroughUsecase = &PassthroughUsecaseMock{}
+
+// PassthroughUsecaseMock is a mock implementation of the PassthroughUsecase interface
+type PassthroughUsecaseMock struct {
+	GetPortfolioAssetsFunc    func(ctx context.Context, address string, opts ...domain.PortfolioAssetsOption) (passthroughdomain.PortfolioAssetsResult, error)
+	GetPortfolioWithDeltaFunc func(ctx context.Context, address string, previous osmomath.Dec) (passthroughdomain.PortfolioDeltaResult, error)
+	GetPricedBalancesFunc     func(ctx context.Context, address string) ([]passthroughdomain.AccountCoinsResult, osmomath.Dec, error)
+}
+
+func (m *PassthroughUsecaseMock) GetPortfolioAssets(ctx context.Context, address string, opts ...domain.PortfolioAssetsOption) (passthroughdomain.PortfolioAssetsResult, error) {
+	if m.GetPortfolioAssetsFunc != nil {
+		return m.GetPortfolioAssetsFunc(ctx, address, opts...)
+	}
+	panic("unimplemented")
+}
+
+func (m *PassthroughUsecaseMock) GetPortfolioWithDelta(ctx context.Context, address string, previous osmomath.Dec) (passthroughdomain.PortfolioDeltaResult, error) {
+	if m.GetPortfolioWithDeltaFunc != nil {
+		return m.GetPortfolioWithDeltaFunc(ctx, address, previous)
+	}
+	panic("unimplemented")
+}
+
+func (m *PassthroughUsecaseMock) GetPricedBalances(ctx context.Context, address string) ([]passthroughdomain.AccountCoinsResult, osmomath.Dec, error) {
+	if m.GetPricedBalancesFunc != nil {
+		return m.GetPricedBalancesFunc(ctx, address)
+	}
+	panic("unimplemented")
+}
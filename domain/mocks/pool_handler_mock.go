@@ -85,3 +85,8 @@ func (p *PoolHandlerMock) StorePools(pools []sqsdomain.PoolI) error {
 func (p *PoolHandlerMock) CalcExitCFMMPool(poolID uint64, exitingShares math.Int) (types.Coins, error) {
 	panic("unimplemented")
 }
+
+// CalcExitCFMMPoolBatch implements mvc.PoolHandler.
+func (p *PoolHandlerMock) CalcExitCFMMPoolBatch(requests []domain.CalcExitCFMMPoolRequest) ([]types.Coins, []error) {
+	panic("unimplemented")
+}
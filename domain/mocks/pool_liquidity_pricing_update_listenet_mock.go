@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/osmosis-labs/sqs/domain"
@@ -10,21 +11,51 @@ import (
 type PoolLiquidityPricingMock struct {
 	// represents the last height this mock was called with.
 	lastHeightCalled uint64
+	// represents the last block pool metadata this mock was called with.
+	lastBlockPoolMetadata domain.BlockPoolMetadata
+	// represents the number of times this mock was called.
+	callCount int
+
+	mx sync.Mutex
 }
 
 var _ domain.PoolLiquidityComputeListener = &PoolLiquidityPricingMock{}
 
 // OnPoolLiquidityCompute implements domain.PoolLiquidityComputeListener.
 func (p *PoolLiquidityPricingMock) OnPoolLiquidityCompute(ctx context.Context, height uint64, blockPoolMetaData domain.BlockPoolMetadata) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
 	p.lastHeightCalled = height
+	p.lastBlockPoolMetadata = blockPoolMetaData
+	p.callCount++
 	return nil
 }
 
 // GetLastHeightCalled returns the last heigh when this mock was executed.
 func (p *PoolLiquidityPricingMock) GetLastHeightCalled() uint64 {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
 	return p.lastHeightCalled
 }
 
+// GetLastBlockPoolMetadata returns the last block pool metadata this mock was executed with.
+func (p *PoolLiquidityPricingMock) GetLastBlockPoolMetadata() domain.BlockPoolMetadata {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	return p.lastBlockPoolMetadata
+}
+
+// GetCallCount returns the number of times this mock was executed.
+func (p *PoolLiquidityPricingMock) GetCallCount() int {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	return p.callCount
+}
+
 func NewPoolLiquidityPricingMock(timeout time.Duration) *PoolLiquidityPricingMock {
 	return &PoolLiquidityPricingMock{}
 }
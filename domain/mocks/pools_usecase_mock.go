@@ -19,16 +19,27 @@ import (
 var _ mvc.PoolsUsecase = &PoolsUsecaseMock{}
 
 type PoolsUsecaseMock struct {
-	GetAllPoolsFunc                     func() ([]sqsdomain.PoolI, error)
-	GetPoolsFunc                        func(opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error)
-	StorePoolsFunc                      func(pools []sqsdomain.PoolI) error
-	GetRoutesFromCandidatesFunc         func(candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error)
-	GetTickModelMapFunc                 func(poolIDs []uint64) (map[uint64]*sqsdomain.TickModel, error)
-	GetPoolFunc                         func(poolID uint64) (sqsdomain.PoolI, error)
-	GetPoolSpotPriceFunc                func(ctx context.Context, poolID uint64, takerFee osmomath.Dec, quoteAsset, baseAsset string) (osmomath.BigDec, error)
-	GetCosmWasmPoolConfigFunc           func() domain.CosmWasmPoolRouterConfig
-	CalcExitCFMMPoolFunc                func(poolID uint64, exitingShares osmomath.Int) (sdk.Coins, error)
-	GetAllCanonicalOrderbookPoolIDsFunc func() ([]domain.CanonicalOrderBooksResult, error)
+	GetAllPoolsFunc                       func() ([]sqsdomain.PoolI, error)
+	GetPoolsFunc                          func(opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error)
+	StorePoolsFunc                        func(pools []sqsdomain.PoolI) error
+	GetRoutesFromCandidatesFunc           func(candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error)
+	GetTickModelMapFunc                   func(poolIDs []uint64) (map[uint64]*sqsdomain.TickModel, error)
+	GetPoolFunc                           func(poolID uint64) (sqsdomain.PoolI, error)
+	GetPoolsWithLiquidityErrorsFunc       func() ([]domain.PoolLiquidityCapErrorResult, error)
+	GetPoolsForPairFunc                   func(denomA, denomB string, opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error)
+	GetPoolSpotPriceFunc                  func(ctx context.Context, poolID uint64, takerFee osmomath.Dec, quoteAsset, baseAsset string) (osmomath.BigDec, error)
+	GetPoolWithPricesFunc                 func(ctx context.Context, poolID uint64) (domain.EnrichedPool, error)
+	GetCosmWasmPoolConfigFunc             func() domain.CosmWasmPoolRouterConfig
+	CalcExitCFMMPoolFunc                  func(poolID uint64, exitingShares osmomath.Int) (sdk.Coins, error)
+	CalcExitCFMMPoolBatchFunc             func(requests []domain.CalcExitCFMMPoolRequest) ([]sdk.Coins, []error)
+	GetAllCanonicalOrderbookPoolIDsFunc   func(opts ...domain.CanonicalOrderbooksOption) ([]domain.CanonicalOrderBooksResult, error)
+	IsCanonicalOrderbookPoolFunc          func(poolID uint64) bool
+	IsCosmWasmPoolCircuitOpenFunc         func(poolID uint64) bool
+	ArePoolsLoadedFunc                    func() bool
+	GetCosmWasmCircuitBreakerStatusesFunc func() []domain.CosmWasmCircuitBreakerPoolStatus
+
+	GetCanonicalOrderbookPoolFunc           func(baseDenom, quoteDenom string) (uint64, string, error)
+	GetCanonicalOrderbookPoolWithReasonFunc func(baseDenom, quoteDenom string) (uint64, string, osmomath.Int, error)
 
 	Pools        []sqsdomain.PoolI
 	TickModelMap map[uint64]*sqsdomain.TickModel
@@ -36,19 +47,49 @@ type PoolsUsecaseMock struct {
 
 // IsCanonicalOrderbookPool implements mvc.PoolsUsecase.
 func (pm *PoolsUsecaseMock) IsCanonicalOrderbookPool(poolID uint64) bool {
+	if pm.IsCanonicalOrderbookPoolFunc != nil {
+		return pm.IsCanonicalOrderbookPoolFunc(poolID)
+	}
 	panic("unimplemented")
 }
 
 // GetAllCanonicalOrderbookPoolIDs implements mvc.PoolsUsecase.
-func (pm *PoolsUsecaseMock) GetAllCanonicalOrderbookPoolIDs() ([]domain.CanonicalOrderBooksResult, error) {
+func (pm *PoolsUsecaseMock) GetAllCanonicalOrderbookPoolIDs(opts ...domain.CanonicalOrderbooksOption) ([]domain.CanonicalOrderBooksResult, error) {
 	if pm.GetAllCanonicalOrderbookPoolIDsFunc != nil {
-		return pm.GetAllCanonicalOrderbookPoolIDsFunc()
+		return pm.GetAllCanonicalOrderbookPoolIDsFunc(opts...)
 	}
 	panic("unimplemented")
 }
 
 // GetCanonicalOrderbookPool implements mvc.PoolsUsecase.
 func (pm *PoolsUsecaseMock) GetCanonicalOrderbookPool(baseDenom string, quoteDenom string) (uint64, string, error) {
+	if pm.GetCanonicalOrderbookPoolFunc != nil {
+		return pm.GetCanonicalOrderbookPoolFunc(baseDenom, quoteDenom)
+	}
+	panic("unimplemented")
+}
+
+// GetCanonicalOrderbookPoolWithReason implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) GetCanonicalOrderbookPoolWithReason(baseDenom string, quoteDenom string) (uint64, string, osmomath.Int, error) {
+	if pm.GetCanonicalOrderbookPoolWithReasonFunc != nil {
+		return pm.GetCanonicalOrderbookPoolWithReasonFunc(baseDenom, quoteDenom)
+	}
+	panic("unimplemented")
+}
+
+// GetPoolsWithLiquidityErrors implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) GetPoolsWithLiquidityErrors() ([]domain.PoolLiquidityCapErrorResult, error) {
+	if pm.GetPoolsWithLiquidityErrorsFunc != nil {
+		return pm.GetPoolsWithLiquidityErrorsFunc()
+	}
+	panic("unimplemented")
+}
+
+// GetPoolsForPair implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) GetPoolsForPair(denomA string, denomB string, opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error) {
+	if pm.GetPoolsForPairFunc != nil {
+		return pm.GetPoolsForPairFunc(denomA, denomB, opts...)
+	}
 	panic("unimplemented")
 }
 
@@ -60,6 +101,30 @@ func (pm *PoolsUsecaseMock) StorePools(pools []sqsdomain.PoolI) error {
 	panic("unimplemented")
 }
 
+// IsCosmWasmPoolCircuitOpen implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) IsCosmWasmPoolCircuitOpen(poolID uint64) bool {
+	if pm.IsCosmWasmPoolCircuitOpenFunc != nil {
+		return pm.IsCosmWasmPoolCircuitOpenFunc(poolID)
+	}
+	panic("unimplemented")
+}
+
+// ArePoolsLoaded implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) ArePoolsLoaded() bool {
+	if pm.ArePoolsLoadedFunc != nil {
+		return pm.ArePoolsLoadedFunc()
+	}
+	panic("unimplemented")
+}
+
+// GetCosmWasmCircuitBreakerStatuses implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) GetCosmWasmCircuitBreakerStatuses() []domain.CosmWasmCircuitBreakerPoolStatus {
+	if pm.GetCosmWasmCircuitBreakerStatusesFunc != nil {
+		return pm.GetCosmWasmCircuitBreakerStatusesFunc()
+	}
+	panic("unimplemented")
+}
+
 // GetCosmWasmPoolConfig implements mvc.PoolsUsecase.
 func (pm *PoolsUsecaseMock) GetCosmWasmPoolConfig() domain.CosmWasmPoolRouterConfig {
 	if pm.GetCosmWasmPoolConfigFunc != nil {
@@ -154,6 +219,14 @@ func (pm *PoolsUsecaseMock) GetPoolSpotPrice(ctx context.Context, poolID uint64,
 	panic("unimplemented")
 }
 
+// GetPoolWithPrices implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) GetPoolWithPrices(ctx context.Context, poolID uint64) (domain.EnrichedPool, error) {
+	if pm.GetPoolWithPricesFunc != nil {
+		return pm.GetPoolWithPricesFunc(ctx, poolID)
+	}
+	panic("unimplemented")
+}
+
 // CalcExitCFMMPool implements mvc.PoolsUsecase.
 func (pm *PoolsUsecaseMock) CalcExitCFMMPool(poolID uint64, exitingShares osmomath.Int) (sdk.Coins, error) {
 	if pm.CalcExitCFMMPoolFunc != nil {
@@ -162,4 +235,12 @@ func (pm *PoolsUsecaseMock) CalcExitCFMMPool(poolID uint64, exitingShares osmoma
 	panic("unimplemented")
 }
 
+// CalcExitCFMMPoolBatch implements mvc.PoolsUsecase.
+func (pm *PoolsUsecaseMock) CalcExitCFMMPoolBatch(requests []domain.CalcExitCFMMPoolRequest) ([]sdk.Coins, []error) {
+	if pm.CalcExitCFMMPoolBatchFunc != nil {
+		return pm.CalcExitCFMMPoolBatchFunc(requests)
+	}
+	panic("unimplemented")
+}
+
 var _ mvc.PoolsUsecase = &PoolsUsecaseMock{}
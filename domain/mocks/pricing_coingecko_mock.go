@@ -9,21 +9,41 @@ import (
 
 const (
 	ATOM_COINGECKO_ID = "cosmos"
+
+	BTC_VS_CURRENCY = "btc"
 )
 
 var (
 	NilBigDec = osmomath.BigDec{}
 	OneBigDec = osmomath.NewBigDec(1)
 	AtomPrice = osmomath.NewBigDec(5)
+
+	// BtcPrice is the mock price of BTC in the default vs currency.
+	// Prices in BTC are derived from the default vs currency prices.
+	BtcPrice = osmomath.NewBigDec(50000)
+	// AtomBtcPrice is the mock price of ATOM in BTC.
+	AtomBtcPrice = AtomPrice.Quo(BtcPrice)
+	// OneBtcPrice is the mock price in BTC of the tokens priced at one in the default vs currency.
+	OneBtcPrice = OneBigDec.Quo(BtcPrice)
 )
 
 // DefaultMockCoingeckoPriceGetter is a mock implementation of CoingeckoPriceGetterFn
-var DefaultMockCoingeckoPriceGetter coingeckopricing.CoingeckoPriceGetterFn = func(ctx context.Context, baseDenom string, coingeckoId string) (osmomath.BigDec, error) {
-	if coingeckoId == "" {
-		return NilBigDec, nil
-	} else if coingeckoId == ATOM_COINGECKO_ID {
-		return AtomPrice, nil
-	} else {
-		return OneBigDec, nil
+var DefaultMockCoingeckoPriceGetter coingeckopricing.CoingeckoPriceGetterFn = func(ctx context.Context, baseDenom string, coingeckoId string, vsCurrencies []string) (map[string]osmomath.BigDec, error) {
+	result := make(map[string]osmomath.BigDec, len(vsCurrencies))
+	for _, vsCurrency := range vsCurrencies {
+		price := OneBigDec
+		if coingeckoId == "" {
+			result[vsCurrency] = NilBigDec
+			continue
+		} else if coingeckoId == ATOM_COINGECKO_ID {
+			price = AtomPrice
+		}
+
+		if vsCurrency == BTC_VS_CURRENCY {
+			price = price.Quo(BtcPrice)
+		}
+
+		result[vsCurrency] = price
 	}
+	return result, nil
 }
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/cache"
+)
+
+// PricingSourceMock is a mock implementation of the domain.PricingSource interface
+type PricingSourceMock struct {
+	GetPriceFunc            func(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error)
+	InitializeCacheFunc     func(*cache.Cache)
+	GetFallbackStrategyFunc func(quoteDenom string) domain.PricingSourceType
+	InvalidateCacheFunc     func(excludedQuoteDenom string, baseDenoms ...string)
+}
+
+var _ domain.PricingSource = &PricingSourceMock{}
+
+// GetPrice implements domain.PricingSource.
+func (m *PricingSourceMock) GetPrice(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+	if m.GetPriceFunc != nil {
+		return m.GetPriceFunc(ctx, baseDenom, quoteDenom, opts...)
+	}
+	return osmomath.ZeroBigDec(), nil
+}
+
+// InitializeCache implements domain.PricingSource.
+func (m *PricingSourceMock) InitializeCache(c *cache.Cache) {
+	if m.InitializeCacheFunc != nil {
+		m.InitializeCacheFunc(c)
+	}
+}
+
+// InvalidateCache implements domain.PricingSource.
+func (m *PricingSourceMock) InvalidateCache(excludedQuoteDenom string, baseDenoms ...string) {
+	if m.InvalidateCacheFunc != nil {
+		m.InvalidateCacheFunc(excludedQuoteDenom, baseDenoms...)
+	}
+}
+
+// GetFallbackStrategy implements domain.PricingSource.
+func (m *PricingSourceMock) GetFallbackStrategy(quoteDenom string) domain.PricingSourceType {
+	if m.GetFallbackStrategyFunc != nil {
+		return m.GetFallbackStrategyFunc(quoteDenom)
+	}
+	return domain.NoneSourceType
+}
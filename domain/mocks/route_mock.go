@@ -15,7 +15,7 @@ type RouteMock struct {
 	GetPoolsFunc                        func() []domain.RoutablePool
 	GetTokenOutDenomFunc                func() string
 	GetTokenInDenomFunc                 func() string
-	PrepareResultPoolsFunc              func(ctx context.Context, tokenIn types.Coin, logger log.Logger) ([]domain.RoutablePool, math.LegacyDec, math.LegacyDec, error)
+	PrepareResultPoolsFunc              func(ctx context.Context, tokenIn types.Coin, logger log.Logger) ([]domain.RoutablePool, math.LegacyDec, math.LegacyDec, []types.Coin, error)
 	StringFunc                          func() string
 }
 
@@ -65,7 +65,7 @@ func (r *RouteMock) GetTokenInDenom() string {
 }
 
 // PrepareResultPools implements domain.Route.
-func (r *RouteMock) PrepareResultPools(ctx context.Context, tokenIn types.Coin, logger log.Logger) ([]domain.RoutablePool, math.LegacyDec, math.LegacyDec, error) {
+func (r *RouteMock) PrepareResultPools(ctx context.Context, tokenIn types.Coin, logger log.Logger) ([]domain.RoutablePool, math.LegacyDec, math.LegacyDec, []types.Coin, error) {
 	if r.PrepareResultPoolsFunc != nil {
 		return r.PrepareResultPoolsFunc(ctx, tokenIn, logger)
 	}
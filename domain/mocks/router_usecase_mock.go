@@ -19,22 +19,30 @@ type RouterUsecaseMock struct {
 	GetSimpleQuoteFunc                           func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error)
 	GetPoolSpotPriceFunc                         func(ctx context.Context, poolID uint64, quoteAsset, baseAsset string) (osmomath.BigDec, error)
 	GetOptimalQuoteFunc                          func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error)
+	GetRoutedSpotPriceFunc                       func(ctx context.Context, baseDenom, quoteDenom string) (osmomath.BigDec, error)
 	GetOptimalQuoteInGivenOutFunc                func(ctx context.Context, tokenOut sdk.Coin, tokenInDenom string, opts ...domain.RouterOption) (domain.Quote, error)
 	GetBestSingleRouteQuoteFunc                  func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string) (domain.Quote, error)
 	GetCustomDirectQuoteFunc                     func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, poolID uint64) (domain.Quote, error)
+	GetCustomDirectQuoteInGivenOutFunc           func(ctx context.Context, tokenOut sdk.Coin, tokenInDenom string, poolID uint64) (domain.Quote, error)
 	GetCustomDirectQuoteMultiPoolFunc            func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom []string, poolIDs []uint64) (domain.Quote, error)
 	GetCustomDirectQuoteMultiPoolInGivenOutFunc  func(ctx context.Context, tokenOut sdk.Coin, tokenInDenom []string, poolIDs []uint64) (domain.Quote, error)
 	GetCandidateRoutesFunc                       func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string) (sqsdomain.CandidateRoutes, error)
-	GetTakerFeeFunc                              func(poolID uint64) ([]sqsdomain.TakerFeeForPair, error)
+	GetTakerFeeFunc                              func(poolID uint64) ([]domain.TakerFeeForPair, error)
+	GetTakerFeeForPairFunc                       func(denom0, denom1 string) (osmomath.Dec, bool)
+	GetTakerFeesFunc                             func(poolIDs []uint64) (map[uint64][]domain.TakerFeeForPair, error)
 	SetTakerFeesFunc                             func(takerFees sqsdomain.TakerFeeMap)
 	GetCachedCandidateRoutesFunc                 func(ctx context.Context, tokenInDenom, tokenOutDenom string) (sqsdomain.CandidateRoutes, bool, error)
 	StoreRouterStateFilesFunc                    func() error
 	GetRouterStateFunc                           func() (domain.RouterState, error)
+	GetRouterStateSnapshotFunc                   func(includeTickMap bool) (domain.RouterState, error)
 	GetSortedPoolsFunc                           func() []sqsdomain.PoolI
 	GetConfigFunc                                func() domain.RouterConfig
 	ConvertMinTokensPoolLiquidityCapToFilterFunc func(minTokensPoolLiquidityCap uint64) uint64
 	SetSortedPoolsFunc                           func(pools []sqsdomain.PoolI)
+	RegisterCandidateRoutePoolFilterFunc         func(filter domain.CandidateRoutePoolFiltrerCb)
 	GetMinPoolLiquidityCapFilterFunc             func(tokenInDenom string, tokenOutDenom string) (uint64, error)
+	PreviewDynamicFilterFunc                     func(baseDenom, quoteDenom string) (uint64, uint64, error)
+	ValidateCachedRouteFunc                      func(ctx context.Context, routes sqsdomain.CandidateRoutes) (bool, error)
 }
 
 // GetMinPoolLiquidityCapFilter implements mvc.RouterUsecase.
@@ -66,6 +74,13 @@ func (m *RouterUsecaseMock) GetOptimalQuote(ctx context.Context, tokenIn sdk.Coi
 	panic("unimplemented")
 }
 
+func (m *RouterUsecaseMock) GetRoutedSpotPrice(ctx context.Context, baseDenom, quoteDenom string) (osmomath.BigDec, error) {
+	if m.GetRoutedSpotPriceFunc != nil {
+		return m.GetRoutedSpotPriceFunc(ctx, baseDenom, quoteDenom)
+	}
+	panic("unimplemented")
+}
+
 func (m *RouterUsecaseMock) GetOptimalQuoteInGivenOut(ctx context.Context, tokenOut sdk.Coin, tokenInDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
 	if m.GetOptimalQuoteInGivenOutFunc != nil {
 		return m.GetOptimalQuoteInGivenOutFunc(ctx, tokenOut, tokenInDenom, opts...)
@@ -87,6 +102,13 @@ func (m *RouterUsecaseMock) GetCustomDirectQuote(ctx context.Context, tokenIn sd
 	panic("unimplemented")
 }
 
+func (m *RouterUsecaseMock) GetCustomDirectQuoteInGivenOut(ctx context.Context, tokenOut sdk.Coin, tokenInDenom string, poolID uint64) (domain.Quote, error) {
+	if m.GetCustomDirectQuoteInGivenOutFunc != nil {
+		return m.GetCustomDirectQuoteInGivenOutFunc(ctx, tokenOut, tokenInDenom, poolID)
+	}
+	panic("unimplemented")
+}
+
 func (m *RouterUsecaseMock) GetCustomDirectQuoteMultiPool(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom []string, poolIDs []uint64) (domain.Quote, error) {
 	if m.GetCustomDirectQuoteMultiPoolFunc != nil {
 		return m.GetCustomDirectQuoteMultiPoolFunc(ctx, tokenIn, tokenOutDenom, poolIDs)
@@ -108,13 +130,27 @@ func (m *RouterUsecaseMock) GetCandidateRoutes(ctx context.Context, tokenIn sdk.
 	return sqsdomain.CandidateRoutes{}, nil
 }
 
-func (m *RouterUsecaseMock) GetTakerFee(poolID uint64) ([]sqsdomain.TakerFeeForPair, error) {
+func (m *RouterUsecaseMock) GetTakerFee(poolID uint64) ([]domain.TakerFeeForPair, error) {
 	if m.GetTakerFeeFunc != nil {
 		return m.GetTakerFeeFunc(poolID)
 	}
 	panic("unimplemented")
 }
 
+func (m *RouterUsecaseMock) GetTakerFeeForPair(denom0, denom1 string) (osmomath.Dec, bool) {
+	if m.GetTakerFeeForPairFunc != nil {
+		return m.GetTakerFeeForPairFunc(denom0, denom1)
+	}
+	panic("unimplemented")
+}
+
+func (m *RouterUsecaseMock) GetTakerFees(poolIDs []uint64) (map[uint64][]domain.TakerFeeForPair, error) {
+	if m.GetTakerFeesFunc != nil {
+		return m.GetTakerFeesFunc(poolIDs)
+	}
+	panic("unimplemented")
+}
+
 func (m *RouterUsecaseMock) SetTakerFees(takerFees sqsdomain.TakerFeeMap) {
 	if m.SetTakerFeesFunc != nil {
 		m.SetTakerFeesFunc(takerFees)
@@ -142,6 +178,13 @@ func (m *RouterUsecaseMock) GetRouterState() (domain.RouterState, error) {
 	return domain.RouterState{}, nil
 }
 
+func (m *RouterUsecaseMock) GetRouterStateSnapshot(includeTickMap bool) (domain.RouterState, error) {
+	if m.GetRouterStateSnapshotFunc != nil {
+		return m.GetRouterStateSnapshotFunc(includeTickMap)
+	}
+	return domain.RouterState{}, nil
+}
+
 func (m *RouterUsecaseMock) GetSortedPools() []sqsdomain.PoolI {
 	if m.GetSortedPoolsFunc != nil {
 		return m.GetSortedPoolsFunc()
@@ -163,8 +206,32 @@ func (m *RouterUsecaseMock) ConvertMinTokensPoolLiquidityCapToFilter(minTokensPo
 	panic("unimplemented")
 }
 
+// PreviewDynamicFilter implements mvc.RouterUsecase.
+func (m *RouterUsecaseMock) PreviewDynamicFilter(baseDenom, quoteDenom string) (uint64, uint64, error) {
+	if m.PreviewDynamicFilterFunc != nil {
+		return m.PreviewDynamicFilterFunc(baseDenom, quoteDenom)
+	}
+	panic("unimplemented")
+}
+
+// ValidateCachedRoute implements mvc.RouterUsecase.
+func (m *RouterUsecaseMock) ValidateCachedRoute(ctx context.Context, routes sqsdomain.CandidateRoutes) (bool, error) {
+	if m.ValidateCachedRouteFunc != nil {
+		return m.ValidateCachedRouteFunc(ctx, routes)
+	}
+	panic("unimplemented")
+}
+
 func (m *RouterUsecaseMock) SetSortedPools(pools []sqsdomain.PoolI) {
 	if m.SetSortedPoolsFunc != nil {
 		m.SetSortedPoolsFunc(pools)
 	}
 }
+
+func (m *RouterUsecaseMock) RegisterCandidateRoutePoolFilter(filter domain.CandidateRoutePoolFiltrerCb) {
+	if m.RegisterCandidateRoutePoolFilterFunc != nil {
+		m.RegisterCandidateRoutePoolFilterFunc(filter)
+		return
+	}
+	panic("unimplemented")
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
@@ -25,12 +26,17 @@ type TokensUsecaseMock struct {
 	GetPoolDenomsMetadataFunc            func(chainDenoms []string) domain.PoolDenomMetaDataMap
 	GetFullPoolDenomMetadataFunc         func() domain.PoolDenomMetaDataMap
 	RegisterPricingStrategyFunc          func(source domain.PricingSourceType, strategy domain.PricingSource)
+	InvalidatePriceCacheFunc             func(denoms ...string)
 	IsValidChainDenomFunc                func(chainDenom string) bool
 	IsValidPricingSourceFunc             func(pricingSource int) bool
 	GetCoingeckoIdByChainDenomFunc       func(chainDenom string) (string, error)
+	GetDenomsByCoingeckoIDFunc           func(id string) []string
+	GetMarketCapFunc                     func(ctx context.Context, denom string) (domain.MarketCap, error)
+	FindDivergentStablePricesFunc        func(ctx context.Context, denoms []string, tolerance osmomath.Dec) ([]domain.DivergenceReport, error)
 	UpdateAssetsAtHeightIntervalSyncFunc func(height uint64) error
 	SetTokenRegistryLoaderFunc           func(loader domain.TokenRegistryLoader)
 	ClearPoolDenomMetadataFunc           func()
+	GetLastAssetListRefreshTimeFunc      func() time.Time
 }
 
 var _ mvc.TokensUsecase = &TokensUsecaseMock{}
@@ -130,6 +136,12 @@ func (m *TokensUsecaseMock) RegisterPricingStrategy(source domain.PricingSourceT
 	}
 }
 
+func (m *TokensUsecaseMock) InvalidatePriceCache(denoms ...string) {
+	if m.InvalidatePriceCacheFunc != nil {
+		m.InvalidatePriceCacheFunc(denoms...)
+	}
+}
+
 func (m *TokensUsecaseMock) IsValidChainDenom(chainDenom string) bool {
 	if m.IsValidChainDenomFunc != nil {
 		return m.IsValidChainDenomFunc(chainDenom)
@@ -151,6 +163,27 @@ func (m *TokensUsecaseMock) GetCoingeckoIdByChainDenom(chainDenom string) (strin
 	return "", nil
 }
 
+func (m *TokensUsecaseMock) GetDenomsByCoingeckoID(id string) []string {
+	if m.GetDenomsByCoingeckoIDFunc != nil {
+		return m.GetDenomsByCoingeckoIDFunc(id)
+	}
+	return nil
+}
+
+func (m *TokensUsecaseMock) GetMarketCap(ctx context.Context, denom string) (domain.MarketCap, error) {
+	if m.GetMarketCapFunc != nil {
+		return m.GetMarketCapFunc(ctx, denom)
+	}
+	panic("unimplemented")
+}
+
+func (m *TokensUsecaseMock) FindDivergentStablePrices(ctx context.Context, denoms []string, tolerance osmomath.Dec) ([]domain.DivergenceReport, error) {
+	if m.FindDivergentStablePricesFunc != nil {
+		return m.FindDivergentStablePricesFunc(ctx, denoms, tolerance)
+	}
+	panic("unimplemented")
+}
+
 func (m *TokensUsecaseMock) UpdateAssetsAtHeightIntervalSync(height uint64) error {
 	if m.UpdateAssetsAtHeightIntervalSyncFunc != nil {
 		return m.UpdateAssetsAtHeightIntervalSyncFunc(height)
@@ -172,3 +205,11 @@ func (m *TokensUsecaseMock) ClearPoolDenomMetadata() {
 	}
 	panic("unimplemented")
 }
+
+// GetLastAssetListRefreshTime implements mvc.TokensUsecase.
+func (m *TokensUsecaseMock) GetLastAssetListRefreshTime() time.Time {
+	if m.GetLastAssetListRefreshTimeFunc != nil {
+		return m.GetLastAssetListRefreshTimeFunc()
+	}
+	panic("unimplemented")
+}
@@ -6,6 +6,10 @@ type ChainInfoUsecase interface {
 	// and returns an error if the height is stale.
 	// That is, if the height has not been updated within a certain time frame.
 	GetLatestHeight() (uint64, error)
+	// GetLatestObservedHeight returns the latest height stored
+	// without validating whether it is stale.
+	// Returns zero if no height was observed yet.
+	GetLatestObservedHeight() uint64
 	// StoreLatestHeight stores the latest height in the usecase
 	StoreLatestHeight(height uint64)
 	// ValidatePriceUpdates validates the price updates
@@ -3,6 +3,8 @@ package mvc
 import (
 	"context"
 
+	"github.com/osmosis-labs/osmosis/osmomath"
+
 	"github.com/osmosis-labs/sqs/domain"
 	orderbookdomain "github.com/osmosis-labs/sqs/domain/orderbook"
 	"github.com/osmosis-labs/sqs/sqsdomain"
@@ -15,14 +17,46 @@ type OrderBookUsecase interface {
 	// GetTicks returns the orderbook ticks for a given orderbook pool id.
 	GetAllTicks(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool)
 
-	// GetOrder returns all active orderbook orders for a given address.
-	GetActiveOrders(ctx context.Context, address string) ([]orderbookdomain.LimitOrder, bool, error)
+	// GetOrderbookSpotPrice returns the mid price between the best bid and the best ask ticks
+	// of the canonical orderbook for the given base and quote denoms, normalized by the precision of the denoms.
+	// The price is inverted if the canonical orderbook has the given base and quote denoms reversed.
+	// Returns error if there is no canonical orderbook or if it has no bid or no ask liquidity.
+	GetOrderbookSpotPrice(base, quote string) (osmomath.BigDec, error)
+
+	// GetOrderbookDepth returns the bid and ask liquidity of the canonical orderbook
+	// for the given base and quote denoms at up to the given number of price levels per side.
+	// Bid liquidity is in quote denom units while ask liquidity is in base denom units.
+	// Levels of zero or less returns all price levels.
+	// Bool indicates whether the result is best effort, i.e. some ticks were missing and skipped.
+	GetOrderbookDepth(ctx context.Context, base, quote string, levels int) (orderbookdomain.OrderbookDepth, bool, error)
+
+	// GetActiveOrders returns a page of active orderbook orders for a given address.
+	// Orders are sorted by order ID and then by pool ID.
+	// Options can be provided to filter the orders by direction and status and to paginate them.
+	// Without pagination, all orders are returned.
+	// The returned page contains the cursor for the next page, empty if there are no more orders.
+	GetActiveOrders(ctx context.Context, address string, opts ...orderbookdomain.ActiveOrdersOption) (orderbookdomain.ActiveOrdersPage, error)
+
+	// GetActiveOrder returns the active orderbook order with the given ID for a given address
+	// in the canonical orderbook with the given contract address.
+	// Returns error if the orderbook is not canonical, the order is not found or the order fails to be formatted.
+	GetActiveOrder(ctx context.Context, ownerAddress, contractAddress string, orderID int64) (orderbookdomain.LimitOrder, error)
+
+	// GetOrderHistory returns recently claimed or cancelled orderbook orders for a given address.
+	// The status of each order is set to its terminal state.
+	// Bool indicates whether the result is best effort, i.e. some orders failed to be processed.
+	GetOrderHistory(ctx context.Context, ownerAddress string) ([]orderbookdomain.LimitOrder, bool, error)
 
 	// GetActiveOrdersStream returns a channel for streaming limit orderbook orders for a given address.
 	// The caller should range over the channel, but note that channel is never closed since there may be multiple
 	// sender goroutines.
 	GetActiveOrdersStream(ctx context.Context, address string) <-chan orderbookdomain.OrderbookResult
 
+	// ProcessOrderBookActiveOrdersVerbose returns the active formatted limit orders for a given address in the given orderbook
+	// together with the errors of the orders that failed to be processed and were skipped.
+	// Errors if fails to fetch the active orders.
+	ProcessOrderBookActiveOrdersVerbose(ctx context.Context, orderbook domain.CanonicalOrderBooksResult, ownerAddress string) ([]orderbookdomain.LimitOrder, []orderbookdomain.OrderProcessingError, error)
+
 	// CreateFormattedLimitOrder creates a formatted limit order from the given orderbook and order.
 	CreateFormattedLimitOrder(orderbook domain.CanonicalOrderBooksResult, order orderbookdomain.Order) (orderbookdomain.LimitOrder, error)
 }
@@ -3,6 +3,8 @@ package mvc
 import (
 	"context"
 
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
 	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
 )
 
@@ -10,5 +12,18 @@ import (
 type PassthroughUsecase interface {
 	// GetPortfolioAssets returns the total value of the assets in the portfolio
 	// of the user with the given address.
-	GetPortfolioAssets(ctx context.Context, address string) (passthroughdomain.PortfolioAssetsResult, error)
+	// The capitalization is computed in the default quote denom unless configured otherwise via options.
+	// Only user balances and total assets are broken down by coin unless a detailed portfolio is requested.
+	GetPortfolioAssets(ctx context.Context, address string, opts ...domain.PortfolioAssetsOption) (passthroughdomain.PortfolioAssetsResult, error)
+
+	// GetPortfolioWithDelta returns the total value of the assets in the portfolio of the user with the given address
+	// as well as its absolute and percentage change relative to the given previous total value.
+	GetPortfolioWithDelta(ctx context.Context, address string, previous osmomath.Dec) (passthroughdomain.PortfolioDeltaResult, error)
+
+	// GetPricedBalances returns the spendable balances of the user with the given address
+	// instrumented with their capitalization values as well as the total capitalization.
+	// Contrary to GetPortfolioAssets, it skips all other categories such as locks and staking.
+	// Returns error if fails to get the balances. If fails to price them, a best-effort result is returned
+	// alongside domain.BalancesPricingError.
+	GetPricedBalances(ctx context.Context, address string) ([]passthroughdomain.AccountCoinsResult, osmomath.Dec, error)
 }
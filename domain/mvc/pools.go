@@ -18,15 +18,30 @@ type PoolsUsecase interface {
 
 	GetAllPools() ([]sqsdomain.PoolI, error)
 
+	// ArePoolsLoaded returns true if at least one pool has been stored by ingest.
+	// Cheap to call, meant for readiness probes.
+	ArePoolsLoaded() bool
+
 	// GetRoutesFromCandidates converts candidate routes to routes intrusmented with all the data necessary for estimating
 	// a swap. This data entails the pool data, the taker fee.
+	// Skips the routes through generalized cosmwasm pools with an open circuit.
 	GetRoutesFromCandidates(candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error)
 
 	GetTickModelMap(poolIDs []uint64) (map[uint64]*sqsdomain.TickModel, error)
 	// GetPool returns the pool with the given ID.
 	GetPool(poolID uint64) (sqsdomain.PoolI, error)
+	// GetPoolsWithLiquidityErrors returns all pools whose liquidity capitalization
+	// could not be computed, along with the reason. Sorted by pool ID.
+	GetPoolsWithLiquidityErrors() ([]domain.PoolLiquidityCapErrorResult, error)
+	// GetPoolsForPair returns all pools containing both of the given denoms.
+	// Honors the min liquidity cap and sort options. Sorts by pool ID if no sort option is given.
+	// Returns empty result if no pool contains the pair.
+	GetPoolsForPair(denomA, denomB string, opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error)
 	// GetPoolSpotPrice returns the spot price of the given pool given the taker fee, quote and base assets.
 	GetPoolSpotPrice(ctx context.Context, poolID uint64, takerFee osmomath.Dec, quoteAsset, baseAsset string) (osmomath.BigDec, error)
+	// GetPoolWithPrices returns the pool with the given ID along with the spot prices between all of its denoms.
+	// Returns error if the pool is not found or if any of the spot prices fails to compute.
+	GetPoolWithPrices(ctx context.Context, poolID uint64) (domain.EnrichedPool, error)
 
 	GetCosmWasmPoolConfig() domain.CosmWasmPoolRouterConfig
 
@@ -35,14 +50,29 @@ type PoolsUsecase interface {
 	// Returns error if the pool is not found for the given pair.
 	GetCanonicalOrderbookPool(baseDenom, quoteDenom string) (uint64, string, error)
 
+	// GetCanonicalOrderbookPoolWithReason is like GetCanonicalOrderbookPool but also returns
+	// the liquidity capitalization that made the pool canonical for the given pair.
+	// Returns error if the pool is not found for the given pair.
+	GetCanonicalOrderbookPoolWithReason(baseDenom, quoteDenom string) (uint64, string, osmomath.Int, error)
+
 	// GetAllCanonicalOrderbookPoolIDs returns all the canonical orderbook results
 	// where each base/quote denom is associated with a default pool ID.
 	// Sorts the results by pool ID.
-	GetAllCanonicalOrderbookPoolIDs() ([]domain.CanonicalOrderBooksResult, error)
+	// If a minimum liquidity cap is configured, orderbooks below it are excluded
+	// and the results are sorted by liquidity cap in descending order instead.
+	GetAllCanonicalOrderbookPoolIDs(opts ...domain.CanonicalOrderbooksOption) ([]domain.CanonicalOrderBooksResult, error)
 
 	// IsCanonicalOrderbookPool returns true if the given pool ID is a canonical orderbook pool
 	// for some token pair.
 	IsCanonicalOrderbookPool(poolID uint64) bool
+
+	// IsCosmWasmPoolCircuitOpen returns true if the circuit of the given generalized cosmwasm pool
+	// is open due to consecutive network call failures.
+	IsCosmWasmPoolCircuitOpen(poolID uint64) bool
+
+	// GetCosmWasmCircuitBreakerStatuses returns the circuit breaker statuses of the generalized
+	// cosmwasm pools with at least one failure since the last success.
+	GetCosmWasmCircuitBreakerStatuses() []domain.CosmWasmCircuitBreakerPoolStatus
 }
 
 type PoolHandler interface {
@@ -55,6 +85,12 @@ type PoolHandler interface {
 	// CalcExitCFMMPool estimates the coins returned from redeeming CFMM pool shares given a pool ID and the GAMM shares to convert
 	// poolID must be a CFMM pool. Returns error if not.
 	CalcExitCFMMPool(poolID uint64, exitingShares osmomath.Int) (sdk.Coins, error)
+
+	// CalcExitCFMMPoolBatch is the batch variant of CalcExitCFMMPool.
+	// Returns the coins and the errors indexed by the position of the request so that requests for the same pool ID
+	// are computed independently. The coins are nil for the requests that failed and the errors are nil for the ones that succeeded.
+	// A failure of one request does not affect the others.
+	CalcExitCFMMPoolBatch(requests []domain.CalcExitCFMMPoolRequest) ([]sdk.Coins, []error)
 }
 
 type CandidateRouteSearchPoolHandler interface {
@@ -60,6 +60,12 @@ type RouterUsecase interface {
 	// GetOptimalQuote returns the optimal quote for the given tokenIn and tokenOutDenom.
 	GetOptimalQuote(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error)
 
+	// GetRoutedSpotPrice returns the implied spot price of the base denom in terms of the quote denom
+	// over the best single route. Unlike GetPoolSpotPrice, it does not require a pool containing both denoms.
+	// The price is derived from a simple quote for a small amount of base and, as a result, includes fees.
+	// Returns domain.RoutedSpotPriceNoRouteError if there is no route between the denoms.
+	GetRoutedSpotPrice(ctx context.Context, baseDenom, quoteDenom string) (osmomath.BigDec, error)
+
 	// GetOptimalQuoteInGivenOut returns the optimal quote for the given token swap method exact amount out.
 	GetOptimalQuoteInGivenOut(ctx context.Context, tokenOut sdk.Coin, tokenInDenom string, opts ...domain.RouterOption) (domain.Quote, error)
 
@@ -67,16 +73,31 @@ type RouterUsecase interface {
 	// It does not search for the route. It directly computes the quote for the given poolID.
 	// This allows to bypass a min liquidity requirement in the router when attempting to swap over a specific pool.
 	GetCustomDirectQuote(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, poolID uint64) (domain.Quote, error)
+	// GetCustomDirectQuoteInGivenOut returns the custom direct quote for the given tokenOut, tokenInDenom and poolID
+	// for the token swap method exact amount out.
+	// Returns an error if either denom is not in the pool or if the pool is an orderbook.
+	GetCustomDirectQuoteInGivenOut(ctx context.Context, tokenOut sdk.Coin, tokenInDenom string, poolID uint64) (domain.Quote, error)
 	// GetCustomDirectQuoteMultiPool calculates direct custom quote for given tokenIn and tokenOutDenom over given poolID route.
 	// Underlying implementation uses GetCustomDirectQuote.
 	GetCustomDirectQuoteMultiPool(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom []string, poolIDs []uint64) (domain.Quote, error)
-	// GetCustomDirectQuoteMultiPool calculates direct custom quote for given tokenOut and tokenInDenom over given poolID route.
-	// Underlying implementation uses GetCustomDirectQuote.
+	// GetCustomDirectQuoteMultiPoolInGivenOut calculates direct custom quote for given tokenOut and tokenInDenom over given poolID route.
+	// The pools and the token in denoms are ordered from the token in to the token out as in the chain's exact amount out swap routes:
+	// the i-th pool takes the i-th token in denom and the last pool produces the token out.
+	// Underlying implementation traverses the pools in reverse using GetCustomDirectQuoteInGivenOut.
 	GetCustomDirectQuoteMultiPoolInGivenOut(ctx context.Context, tokenOut sdk.Coin, tokenInDenom []string, poolIDs []uint64) (domain.Quote, error)
 	// GetCandidateRoutes returns the candidate routes for the given tokenIn and tokenOutDenom.
 	GetCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string) (sqsdomain.CandidateRoutes, error)
 	// GetTakerFee returns the taker fee for all token pairs in a pool.
-	GetTakerFee(poolID uint64) ([]sqsdomain.TakerFeeForPair, error)
+	// If the default taker fee fallback is enabled, the default taker fee is returned for the pairs
+	// with a missing taker fee, flagged as default. Otherwise, an error is returned.
+	GetTakerFee(poolID uint64) ([]domain.TakerFeeForPair, error)
+	// GetTakerFeeForPair returns the taker fee for the given denom pair without requiring a pool ID.
+	// Returns true if the taker fee for the pair is found. False otherwise.
+	GetTakerFeeForPair(denom0, denom1 string) (osmomath.Dec, bool)
+	// GetTakerFees returns the taker fee for all token pairs in each of the given pools keyed by pool ID.
+	// Unless the default taker fee fallback is enabled, returns domain.TakerFeeNotFoundForPoolError
+	// identifying the first pool and pair with a missing taker fee.
+	GetTakerFees(poolIDs []uint64) (map[uint64][]domain.TakerFeeForPair, error)
 	// SetTakerFees sets the taker fees for all token pairs in all pools.
 	SetTakerFees(takerFees sqsdomain.TakerFeeMap)
 	// GetCachedCandidateRoutes returns the candidate routes for the given tokenIn and tokenOutDenom from cache.
@@ -84,11 +105,21 @@ type RouterUsecase interface {
 	// Since we may cache zero routes, it returns false if the routes are not present in cache. Returns true otherwise.
 	// Returns error if cache is disabled.
 	GetCachedCandidateRoutes(ctx context.Context, tokenInDenom, tokenOutDenom string) (sqsdomain.CandidateRoutes, bool, error)
+	// ValidateCachedRoute returns true if all pools in the given cached routes still exist
+	// and contain the denoms that the routes swap over. Returns false otherwise.
+	// Cached routes that are no longer executable are evicted from cache when read.
+	// Returns error if fails to retrieve a pool for a reason other than it not being found.
+	ValidateCachedRoute(ctx context.Context, routes sqsdomain.CandidateRoutes) (bool, error)
 	// StoreRoutes stores all router state in the files locally. Used for debugging.
 	StoreRouterStateFiles() error
 
 	GetRouterState() (domain.RouterState, error)
 
+	// GetRouterStateSnapshot returns the current router state without writing any files.
+	// The tick models of the concentrated pools are only fetched if includeTickMap is true
+	// since they might be large.
+	GetRouterStateSnapshot(includeTickMap bool) (domain.RouterState, error)
+
 	// GetSortedPools returns the sorted pools based on the router configuration.
 	GetSortedPools() []sqsdomain.PoolI
 
@@ -108,6 +139,18 @@ type RouterUsecase interface {
 	// the default router min pool liquidity capitalization is returned.
 	ConvertMinTokensPoolLiquidityCapToFilter(minTokensPoolLiquidityCap uint64) uint64
 
+	// PreviewDynamicFilter returns the min pool liquidity capitalization between the base and quote denoms
+	// together with the min pool liquidity capitalization filter that it maps to.
+	// It is useful for debugging why certain pools are excluded from routing between the denoms.
+	// Unlike GetMinPoolLiquidityCapFilter, it does not fall back to the default filter.
+	// Returns error if the min pool liquidity capitalization between the denoms cannot be resolved.
+	PreviewDynamicFilter(baseDenom, quoteDenom string) (minCap uint64, filter uint64, err error)
+
+	// RegisterCandidateRoutePoolFilter registers a candidate route pool filter that is applied
+	// when computing optimal quotes in addition to the ones configured via routing options.
+	// Pools for which the filter returns true are skipped in the candidate route search.
+	RegisterCandidateRoutePoolFilter(filter domain.CandidateRoutePoolFiltrerCb)
+
 	// SetSortedPools stores the pools in the router.
 	// CONTRACT: the pools are already sorted according to the desired parameters.
 	// See sortPools() function.
@@ -3,6 +3,7 @@ package mvc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/labstack/echo/v4"
@@ -79,6 +80,10 @@ type TokensUsecase interface {
 	// RegisterPricingStrategy registers a pricing strategy for a given pricing source.
 	RegisterPricingStrategy(source domain.PricingSourceType, strategy domain.PricingSource)
 
+	// InvalidatePriceCache removes the cached chain prices of the given base denoms
+	// so that they are recomputed on the next request.
+	InvalidatePriceCache(denoms ...string)
+
 	IsValidChainDenom(chainDenom string) bool
 
 	// IsValidPricingSource checks if the pricing source is a valid one
@@ -87,6 +92,26 @@ type TokensUsecase interface {
 	// GetCoingeckoIdByChainDenom gets the Coingecko ID by chain denom
 	GetCoingeckoIdByChainDenom(chainDenom string) (string, error)
 
+	// GetDenomsByCoingeckoID returns all chain denoms mapping to the given Coingecko ID, sorted.
+	// Multiple bridged variants of the same asset may share an ID.
+	// Returns empty slice if no chain denom maps to the ID.
+	GetDenomsByCoingeckoID(id string) []string
+
+	// GetMarketCap returns the market capitalization estimate of the given chain denom,
+	// combining its price against the configured quote denom with its circulating supply.
+	// For tokens without circulating supply data, the total liquidity across all pools is
+	// used instead and the result is flagged as best-effort.
+	// Returns error if the price or the supply estimate cannot be determined.
+	GetMarketCap(ctx context.Context, denom string) (domain.MarketCap, error)
+
+	// FindDivergentStablePrices returns the reports for the given chain denoms whose chain prices
+	// against USDC and USDT diverge by more than the given tolerance relative to the larger price.
+	// The reports are sorted by denom.
+	// Returns error if the stablecoin denoms or the prices cannot be retrieved.
+	// Returns domain.ZeroPricesError alongside the reports of the other denoms
+	// if some denoms fail to be priced against either of the stablecoins.
+	FindDivergentStablePrices(ctx context.Context, denoms []string, tolerance osmomath.Dec) ([]domain.DivergenceReport, error)
+
 	// ClearPoolDenomMetadata implements mvc.TokensUsecase.
 	// WARNING: use with caution, this will clear all pool denom metadata
 	ClearPoolDenomMetadata()
@@ -96,6 +121,10 @@ type TokensUsecase interface {
 
 	// SetTokenRegistryLoader sets the token registry loader.
 	SetTokenRegistryLoader(loader domain.TokenRegistryLoader)
+
+	// GetLastAssetListRefreshTime returns the time at which the tokens were last
+	// loaded from the asset list. Returns zero time if the tokens were never loaded.
+	GetLastAssetListRefreshTime() time.Time
 }
 
 // ValidateChainDenomQueryParam validates the chain denom query parameter.
@@ -0,0 +1,22 @@
+package orderbookdomain
+
+import "github.com/osmosis-labs/osmosis/osmomath"
+
+// OrderbookDepthLevel represents the total liquidity at a single tick of the orderbook.
+type OrderbookDepthLevel struct {
+	TickID int64           `json:"tick_id"`
+	Price  osmomath.BigDec `json:"price"`
+	// Liquidity is denominated in the quote denom for bids
+	// and in the base denom for asks.
+	Liquidity osmomath.Dec `json:"liquidity"`
+}
+
+// OrderbookDepth represents the bid and ask price levels of an orderbook.
+// Bids are sorted by price in descending order and asks in ascending order,
+// so that the best bid and the best ask come first.
+// Bid liquidity is in quote denom units while ask liquidity is in base denom units.
+type OrderbookDepth struct {
+	PoolID uint64                `json:"pool_id"`
+	Bids   []OrderbookDepthLevel `json:"bids"`
+	Asks   []OrderbookDepthLevel `json:"asks"`
+}
@@ -0,0 +1,6 @@
+package orderbookdomain
+
+// ResetTickPriceCache purges the tick price cache.
+func ResetTickPriceCache() {
+	tickPriceCache.Purge()
+}
@@ -0,0 +1,74 @@
+package orderbookdomain
+
+// ActiveOrdersFilter defines the criteria for filtering and paginating formatted active limit orders.
+// Zero value matches all orders.
+type ActiveOrdersFilter struct {
+	// OrderDirection restricts orders to the given direction ("bid" or "ask").
+	// Empty matches both directions.
+	OrderDirection string
+	// Statuses restricts orders to the given statuses.
+	// Empty matches any status.
+	Statuses []OrderStatus
+	// Limit is the maximum number of orders returned across all orderbooks.
+	// Zero returns all orders.
+	Limit int
+	// Cursor is the opaque cursor returned with the previous page of orders.
+	// Empty starts from the first order.
+	Cursor string
+}
+
+// ActiveOrdersOption configures the ActiveOrdersFilter.
+type ActiveOrdersOption func(*ActiveOrdersFilter)
+
+// WithOrderDirection configures the filter to only match orders with the given direction.
+func WithOrderDirection(direction string) ActiveOrdersOption {
+	return func(f *ActiveOrdersFilter) {
+		f.OrderDirection = direction
+	}
+}
+
+// WithOrderStatuses configures the filter to only match orders with any of the given statuses.
+// For example, StatusOpen for orders that are not filled at all
+// and StatusPartiallyFilled for orders that are filled partially.
+func WithOrderStatuses(statuses ...OrderStatus) ActiveOrdersOption {
+	return func(f *ActiveOrdersFilter) {
+		f.Statuses = statuses
+	}
+}
+
+// WithPagination configures the filter to return at most limit orders positioned after the given cursor.
+// Limit of zero returns all orders and empty cursor starts from the first order.
+func WithPagination(limit int, cursor string) ActiveOrdersOption {
+	return func(f *ActiveOrdersFilter) {
+		f.Limit = limit
+		f.Cursor = cursor
+	}
+}
+
+// NewActiveOrdersFilter returns the filter configured by the given options.
+func NewActiveOrdersFilter(opts ...ActiveOrdersOption) ActiveOrdersFilter {
+	filter := ActiveOrdersFilter{}
+	for _, opt := range opts {
+		opt(&filter)
+	}
+	return filter
+}
+
+// Matches returns true if the given formatted limit order satisfies the filter.
+func (f ActiveOrdersFilter) Matches(order LimitOrder) bool {
+	if f.OrderDirection != "" && order.OrderDirection != f.OrderDirection {
+		return false
+	}
+
+	if len(f.Statuses) == 0 {
+		return true
+	}
+
+	for _, status := range f.Statuses {
+		if order.Status == status {
+			return true
+		}
+	}
+
+	return false
+}
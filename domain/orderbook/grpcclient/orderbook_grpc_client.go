@@ -18,6 +18,9 @@ type OrderBookClient interface {
 	// GetActiveOrders fetches active orders by owner from the orderbook contract.
 	GetActiveOrders(ctx context.Context, contractAddress string, ownerAddress string) (orderbookdomain.Orders, uint64, error)
 
+	// GetOrderHistory fetches recently claimed or cancelled orders by owner from the orderbook contract.
+	GetOrderHistory(ctx context.Context, contractAddress string, ownerAddress string) ([]orderbookdomain.HistoricalOrder, error)
+
 	// GetTickUnrealizedCancels fetches unrealized cancels by tick from the orderbook contract.
 	GetTickUnrealizedCancels(ctx context.Context, contractAddress string, tickIDs []int64) ([]UnrealizedTickCancels, error)
 
@@ -75,6 +78,16 @@ func (o *orderbookClientImpl) GetActiveOrders(ctx context.Context, contractAddre
 	return orders.Orders, orders.Count, nil
 }
 
+// GetOrderHistory implements OrderBookClient.
+func (o *orderbookClientImpl) GetOrderHistory(ctx context.Context, contractAddress string, ownerAddress string) ([]orderbookdomain.HistoricalOrder, error) {
+	var orders orderHistoryResponse
+	if err := cosmwasmdomain.QueryCosmwasmContract(ctx, o.wasmClient, contractAddress, orderHistoryRequest{OrderHistoryByOwner: ordersByOwner{Owner: ownerAddress}}, &orders); err != nil {
+		return nil, err
+	}
+
+	return orders.Orders, nil
+}
+
 // GetTickUnrealizedCancels implements OrderBookClient.
 func (o *orderbookClientImpl) GetTickUnrealizedCancels(ctx context.Context, contractAddress string, tickIDs []int64) ([]UnrealizedTickCancels, error) {
 	var unrealizedCancels unrealizedCancelsResponse
@@ -56,6 +56,16 @@ type activeOrdersResponse struct {
 	Count  uint64                 `json:"count"`
 }
 
+// orderHistoryRequest is a struct that represents the payload for the order_history_by_owner query.
+type orderHistoryRequest struct {
+	OrderHistoryByOwner ordersByOwner `json:"order_history_by_owner"`
+}
+
+// orderHistoryResponse is a struct that represents the response payload for the order_history_by_owner query.
+type orderHistoryResponse struct {
+	Orders []orderbookdomain.HistoricalOrder `json:"orders"`
+}
+
 // ticksByID is a struct that represents the request payload for the queryTicksRequest query.
 type ticksByID struct {
 	TickIDs []int64 `json:"tick_ids"`
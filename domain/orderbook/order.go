@@ -53,6 +53,31 @@ func (o Order) Status(percentFilled float64) (OrderStatus, error) {
 	return StatusOpen, nil
 }
 
+// Historical order states returned by the orderbook contract.
+const (
+	HistoricalOrderStateClaimed   = "claimed"
+	HistoricalOrderStateCancelled = "cancelled"
+)
+
+// HistoricalOrder represents an order in the orderbook returned by the orderbook contract
+// that has reached a terminal state.
+type HistoricalOrder struct {
+	Order
+	State string `json:"state"`
+}
+
+// Status returns the terminal status of the historical order based on its state.
+func (o HistoricalOrder) Status() (OrderStatus, error) {
+	switch o.State {
+	case HistoricalOrderStateClaimed:
+		return StatusFullyClaimed, nil
+	case HistoricalOrderStateCancelled:
+		return StatusCancelled, nil
+	default:
+		return "", fmt.Errorf("unknown historical order state: %s", o.State)
+	}
+}
+
 // Orders represents a list of orders in the orderbook returned by the orderbook contract.
 type Orders []Order
 
@@ -101,3 +126,19 @@ type OrderbookResult struct {
 	IsBestEffort bool
 	Error        error
 }
+
+// OrderProcessingError represents an error that occurred while processing a specific order.
+type OrderProcessingError struct {
+	OrderID int64
+	Err     error
+}
+
+// Error implements the error interface.
+func (e OrderProcessingError) Error() string {
+	return fmt.Sprintf("failed to process order %d: %v", e.OrderID, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e OrderProcessingError) Unwrap() error {
+	return e.Err
+}
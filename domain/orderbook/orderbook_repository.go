@@ -15,4 +15,9 @@ type OrderBookRepository interface {
 	// GetTickByID returns a specific orderbook tick for a given orderbook pool id.
 	// Returns false if the tick is not found.
 	GetTickByID(poolID uint64, tickID int64) (OrderbookTick, bool)
+
+	// InvalidateTicks removes specific orderbook ticks for a given orderbook pool id.
+	// It is used when a new block updates the ticks but their latest state cannot be stored
+	// so that stale ticks are never returned.
+	InvalidateTicks(poolID uint64, tickIDs []int64)
 }
@@ -0,0 +1,74 @@
+package orderbookdomain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// activeOrdersCursorSeparator separates the pool ID and order ID in the decoded cursor.
+const activeOrdersCursorSeparator = "/"
+
+// ActiveOrdersCursor represents the position of the last returned order
+// within the deterministically sorted list of active orders.
+// Orders are sorted by order ID and, for equal order IDs, by pool ID.
+type ActiveOrdersCursor struct {
+	PoolID  uint64
+	OrderID int64
+}
+
+// Encode returns the opaque string representation of the cursor.
+func (c ActiveOrdersCursor) Encode() string {
+	raw := strconv.FormatUint(c.PoolID, 10) + activeOrdersCursorSeparator + strconv.FormatInt(c.OrderID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// IsAfter returns true if the order with the given pool ID and order ID
+// is positioned after the cursor in the sorted list of active orders.
+func (c ActiveOrdersCursor) IsAfter(poolID uint64, orderID int64) bool {
+	if orderID != c.OrderID {
+		return orderID > c.OrderID
+	}
+	return poolID > c.PoolID
+}
+
+// DecodeActiveOrdersCursor decodes the opaque cursor string produced by ActiveOrdersCursor.Encode.
+// Returns error if the cursor is malformed.
+func DecodeActiveOrdersCursor(cursor string) (ActiveOrdersCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ActiveOrdersCursor{}, err
+	}
+
+	parts := strings.Split(string(raw), activeOrdersCursorSeparator)
+	if len(parts) != 2 {
+		return ActiveOrdersCursor{}, fmt.Errorf("expected pool ID and order ID, got %q", raw)
+	}
+
+	poolID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return ActiveOrdersCursor{}, err
+	}
+
+	orderID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return ActiveOrdersCursor{}, err
+	}
+
+	return ActiveOrdersCursor{
+		PoolID:  poolID,
+		OrderID: orderID,
+	}, nil
+}
+
+// ActiveOrdersPage represents a single page of active orders.
+type ActiveOrdersPage struct {
+	LimitOrders []LimitOrder
+	// NextCursor is the cursor to fetch the next page with.
+	// Empty if there are no more orders.
+	NextCursor string
+	// IsBestEffort is true if at least one order across all orderbooks
+	// failed to be processed, not just the ones within the page.
+	IsBestEffort bool
+}
@@ -0,0 +1,35 @@
+package orderbookdomain
+
+import (
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/osmosis-labs/osmosis/osmomath"
+
+	clmath "github.com/osmosis-labs/osmosis/v26/x/concentrated-liquidity/math"
+)
+
+// tickPriceCacheSize is the maximum number of tick prices cached.
+// key is 8 bytes, value is ~152 bytes so at 100k keys its max RAM is ~30MB.
+const tickPriceCacheSize = 100000
+
+// tickPriceCache caches the prices of the ticks that were successfully converted.
+// It is bounded so that the least recently used ticks are evicted.
+var tickPriceCache, _ = lru.New2Q[int64, osmomath.BigDec](tickPriceCacheSize)
+
+// TickToPrice converts the given tick ID to a price.
+// Successful conversions are cached so that the same tick is not recomputed while it is cached.
+// Returns a copy of the cached price so that callers are free to mutate it.
+// Errors if the tick is outside the supported tick range.
+func TickToPrice(tickID int64) (osmomath.BigDec, error) {
+	if cached, ok := tickPriceCache.Get(tickID); ok {
+		return cached.Clone(), nil
+	}
+
+	price, err := clmath.TickToPrice(tickID)
+	if err != nil {
+		return osmomath.BigDec{}, err
+	}
+
+	tickPriceCache.Add(tickID, price.Clone())
+
+	return price, nil
+}
@@ -0,0 +1,92 @@
+package orderbookdomain_test
+
+import (
+	"testing"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	cltypes "github.com/osmosis-labs/osmosis/v26/x/concentrated-liquidity/types"
+	orderbookdomain "github.com/osmosis-labs/sqs/domain/orderbook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTickToPrice(t *testing.T) {
+	orderbookdomain.ResetTickPriceCache()
+	t.Cleanup(orderbookdomain.ResetTickPriceCache)
+
+	tests := []struct {
+		name        string
+		tickID      int64
+		expected    osmomath.BigDec
+		expectError bool
+	}{
+		{
+			name:     "tick zero",
+			tickID:   0,
+			expected: osmomath.OneBigDec(),
+		},
+		{
+			name:     "positive tick",
+			tickID:   9000000,
+			expected: osmomath.NewBigDec(10),
+		},
+		{
+			name:     "negative tick",
+			tickID:   -100000,
+			expected: osmomath.MustNewBigDecFromStr("0.99"),
+		},
+		{
+			name:     "max tick",
+			tickID:   cltypes.MaxTick,
+			expected: cltypes.MaxSpotPriceBigDec,
+		},
+		{
+			name:        "above max tick",
+			tickID:      cltypes.MaxTick + 1,
+			expectError: true,
+		},
+		{
+			name:     "min current tick",
+			tickID:   cltypes.MinCurrentTickV2,
+			expected: cltypes.MinSpotPriceV2,
+		},
+		{
+			name:        "below min current tick",
+			tickID:      cltypes.MinCurrentTickV2 - 1,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Convert twice to cover both the computed and the cached price.
+			for i := 0; i < 2; i++ {
+				price, err := orderbookdomain.TickToPrice(tt.tickID)
+				if tt.expectError {
+					assert.Error(t, err)
+					continue
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.String(), price.String())
+			}
+		})
+	}
+}
+
+func TestTickToPrice_CachedPriceIsNotMutated(t *testing.T) {
+	const tickID = 9000000
+
+	orderbookdomain.ResetTickPriceCache()
+	t.Cleanup(orderbookdomain.ResetTickPriceCache)
+
+	price, err := orderbookdomain.TickToPrice(tickID)
+	assert.NoError(t, err)
+
+	// Mutate the returned price in place.
+	price.MulMut(osmomath.NewBigDec(2))
+
+	price, err = orderbookdomain.TickToPrice(tickID)
+	assert.NoError(t, err)
+	assert.Equal(t, osmomath.NewBigDec(10).String(), price.String())
+}
@@ -9,4 +9,9 @@ type PassthroughConfig struct {
 	APRFetchIntervalMinutes int `mapstructure:"apr-fetch-interval-minutes"`
 	// The interval at which the pool fees data is fetched.
 	PoolFeesFetchIntervalMinutes int `mapstructure:"pool-fees-fetch-interval-minutes"`
+	// The maximum number of retries of the chain GRPC calls failing with transient errors.
+	GRPCMaxRetries int `mapstructure:"grpc-max-retries"`
+	// The backoff before the first retry of the chain GRPC calls.
+	// It is doubled on every subsequent retry.
+	GRPCRetryBackoffMs int `mapstructure:"grpc-retry-backoff-ms"`
 }
@@ -18,9 +18,14 @@ type PortfolioAssetsCategoryResult struct {
 	// as well as the concentrated positions.
 	Capitalization osmomath.Dec `json:"capitalization"`
 	// AccountCoinsResult represents coins only from user balances (contrary to TotalValueCap).
+	// For staked, unstaking, in-locks, pooled and concentrated positions categories, it is only set if a detailed portfolio is requested.
 	AccountCoinsResult []AccountCoinsResult `json:"account_coins_result,omitempty"`
 
 	IsBestEffort bool `json:"is_best_effort"`
+
+	// HiddenCount is the number of zero-value coins excluded from AccountCoinsResult.
+	// Only set if zero-value coins are requested to be hidden.
+	HiddenCount int `json:"hidden_count,omitempty"`
 }
 
 // AccountCoinsResult represents the coin balance as well as its capitalization value.
@@ -28,3 +33,17 @@ type AccountCoinsResult struct {
 	Coin                sdk.Coin     `json:"coin"`
 	CapitalizationValue osmomath.Dec `json:"cap_value"`
 }
+
+// PortfolioDeltaResult represents the total value of the portfolio
+// as well as its change relative to a previous value.
+type PortfolioDeltaResult struct {
+	// TotalValueCap is the current capitalization of the total assets in the portfolio.
+	TotalValueCap osmomath.Dec `json:"total_value_cap"`
+	// AbsoluteDelta is the difference between the current and the previous total value.
+	AbsoluteDelta osmomath.Dec `json:"absolute_delta"`
+	// PercentageDelta is the absolute delta as a percentage of the previous total value.
+	// Zero if the previous total value is zero.
+	PercentageDelta osmomath.Dec `json:"percentage_delta"`
+
+	IsBestEffort bool `json:"is_best_effort"`
+}
@@ -0,0 +1,136 @@
+package passthroughdomain
+
+import (
+	"context"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retryingPassthroughGRPCClient wraps the passthrough GRPC client and retries
+// the calls failing with transient errors using exponential backoff.
+type retryingPassthroughGRPCClient struct {
+	client PassthroughGRPCClient
+
+	// maxRetries is the maximum number of retries after the initial attempt.
+	maxRetries int
+	// initialBackoff is the backoff before the first retry.
+	// It is doubled on every subsequent retry.
+	initialBackoff time.Duration
+}
+
+// positionsBalancesResult represents the result of the UserPositionsBalances call.
+type positionsBalancesResult struct {
+	pooledCoins sdk.Coins
+	rewardCoins sdk.Coins
+}
+
+var _ PassthroughGRPCClient = &retryingPassthroughGRPCClient{}
+
+// NewRetryingPassthroughGRPCClient returns a passthrough GRPC client that retries the calls of the given client
+// failing with transient errors up to maxRetries times, doubling the backoff starting from initialBackoff.
+// Once the retries are exhausted, the last error is returned.
+// If maxRetries is zero, the calls are not retried.
+func NewRetryingPassthroughGRPCClient(client PassthroughGRPCClient, maxRetries int, initialBackoff time.Duration) PassthroughGRPCClient {
+	return &retryingPassthroughGRPCClient{
+		client:         client,
+		maxRetries:     maxRetries,
+		initialBackoff: initialBackoff,
+	}
+}
+
+// AccountLockedCoins implements PassthroughGRPCClient.
+func (r *retryingPassthroughGRPCClient) AccountLockedCoins(ctx context.Context, address string) (sdk.Coins, error) {
+	return withRetry(ctx, r, func() (sdk.Coins, error) {
+		return r.client.AccountLockedCoins(ctx, address)
+	})
+}
+
+// AccountUnlockingCoins implements PassthroughGRPCClient.
+func (r *retryingPassthroughGRPCClient) AccountUnlockingCoins(ctx context.Context, address string) (sdk.Coins, error) {
+	return withRetry(ctx, r, func() (sdk.Coins, error) {
+		return r.client.AccountUnlockingCoins(ctx, address)
+	})
+}
+
+// AllBalances implements PassthroughGRPCClient.
+func (r *retryingPassthroughGRPCClient) AllBalances(ctx context.Context, address string) (sdk.Coins, error) {
+	return withRetry(ctx, r, func() (sdk.Coins, error) {
+		return r.client.AllBalances(ctx, address)
+	})
+}
+
+// DelegatorDelegations implements PassthroughGRPCClient.
+func (r *retryingPassthroughGRPCClient) DelegatorDelegations(ctx context.Context, address string) (sdk.Coins, error) {
+	return withRetry(ctx, r, func() (sdk.Coins, error) {
+		return r.client.DelegatorDelegations(ctx, address)
+	})
+}
+
+// DelegatorUnbondingDelegations implements PassthroughGRPCClient.
+func (r *retryingPassthroughGRPCClient) DelegatorUnbondingDelegations(ctx context.Context, address string) (sdk.Coins, error) {
+	return withRetry(ctx, r, func() (sdk.Coins, error) {
+		return r.client.DelegatorUnbondingDelegations(ctx, address)
+	})
+}
+
+// UserPositionsBalances implements PassthroughGRPCClient.
+func (r *retryingPassthroughGRPCClient) UserPositionsBalances(ctx context.Context, address string) (sdk.Coins, sdk.Coins, error) {
+	result, err := withRetry(ctx, r, func() (positionsBalancesResult, error) {
+		pooledCoins, rewardCoins, err := r.client.UserPositionsBalances(ctx, address)
+		return positionsBalancesResult{
+			pooledCoins: pooledCoins,
+			rewardCoins: rewardCoins,
+		}, err
+	})
+
+	return result.pooledCoins, result.rewardCoins, err
+}
+
+// DelegationRewards implements PassthroughGRPCClient.
+func (r *retryingPassthroughGRPCClient) DelegationRewards(ctx context.Context, address string) (sdk.Coins, error) {
+	return withRetry(ctx, r, func() (sdk.Coins, error) {
+		return r.client.DelegationRewards(ctx, address)
+	})
+}
+
+// GetChainGRPCClient implements PassthroughGRPCClient.
+func (r *retryingPassthroughGRPCClient) GetChainGRPCClient() *grpc.ClientConn {
+	return r.client.GetChainGRPCClient()
+}
+
+// withRetry calls fn, retrying it with exponential backoff while it fails with a transient error
+// and the retries configured on the given client are not exhausted.
+// Stops early if the context is done. Returns the result and error of the last attempt.
+func withRetry[T any](ctx context.Context, r *retryingPassthroughGRPCClient, fn func() (T, error)) (T, error) {
+	result, err := fn()
+
+	backoff := r.initialBackoff
+	for retry := 0; retry < r.maxRetries && err != nil && isTransientError(err); retry++ {
+		select {
+		case <-ctx.Done():
+			return result, err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+
+		result, err = fn()
+	}
+
+	return result, err
+}
+
+// isTransientError returns true if the given error is a GRPC error
+// that may succeed if retried.
+func isTransientError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
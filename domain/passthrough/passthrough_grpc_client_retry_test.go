@@ -0,0 +1,146 @@
+package passthroughdomain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
+)
+
+func TestRetryingPassthroughGRPCClient(t *testing.T) {
+	const (
+		maxRetries     = 3
+		initialBackoff = time.Millisecond
+	)
+
+	var (
+		transientError    = status.Error(codes.Unavailable, "node unavailable")
+		nonTransientError = errors.New("non-transient error")
+
+		expectedCoins = sdk.NewCoins(sdk.NewCoin("uosmo", osmomath.NewInt(1_000_000)))
+	)
+
+	tests := []struct {
+		name string
+
+		numFailures  int
+		failureError error
+
+		expectedCalls int
+		expectedCoins sdk.Coins
+		expectedError error
+	}{
+		{
+			name: "succeeds on first attempt",
+
+			expectedCalls: 1,
+			expectedCoins: expectedCoins,
+		},
+		{
+			name: "fails twice with transient error then succeeds",
+
+			numFailures:  2,
+			failureError: transientError,
+
+			expectedCalls: 3,
+			expectedCoins: expectedCoins,
+		},
+		{
+			name: "fails with transient error until retries are exhausted",
+
+			numFailures:  maxRetries + 1,
+			failureError: transientError,
+
+			expectedCalls: maxRetries + 1,
+			expectedError: transientError,
+		},
+		{
+			name: "non-transient error is not retried",
+
+			numFailures:  1,
+			failureError: nonTransientError,
+
+			expectedCalls: 1,
+			expectedError: nonTransientError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				delegationsCalls int
+				positionsCalls   int
+			)
+
+			grpcClientMock := &mocks.PassthroughGRPCClientMock{
+				MockDelegatorDelegationsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+					delegationsCalls++
+					if delegationsCalls <= tt.numFailures {
+						return nil, tt.failureError
+					}
+					return expectedCoins, nil
+				},
+				MockUserPositionsBalancesCb: func(ctx context.Context, address string) (sdk.Coins, sdk.Coins, error) {
+					positionsCalls++
+					if positionsCalls <= tt.numFailures {
+						return nil, nil, tt.failureError
+					}
+					return expectedCoins, expectedCoins, nil
+				},
+			}
+
+			client := passthroughdomain.NewRetryingPassthroughGRPCClient(grpcClientMock, maxRetries, initialBackoff)
+
+			// System under test
+			coins, err := client.DelegatorDelegations(context.TODO(), "address")
+			pooledCoins, rewardCoins, positionsErr := client.UserPositionsBalances(context.TODO(), "address")
+
+			assert.Equal(t, tt.expectedCalls, delegationsCalls)
+			assert.Equal(t, tt.expectedCalls, positionsCalls)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.ErrorIs(t, positionsErr, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, positionsErr)
+			assert.Equal(t, tt.expectedCoins, coins)
+			assert.Equal(t, tt.expectedCoins, pooledCoins)
+			assert.Equal(t, tt.expectedCoins, rewardCoins)
+		})
+	}
+}
+
+// Tests that retries stop once the context is done.
+func TestRetryingPassthroughGRPCClient_ContextDone(t *testing.T) {
+	var calls int
+
+	grpcClientMock := &mocks.PassthroughGRPCClientMock{
+		MockAllBalancesCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			calls++
+			return nil, status.Error(codes.Unavailable, "node unavailable")
+		},
+	}
+
+	client := passthroughdomain.NewRetryingPassthroughGRPCClient(grpcClientMock, 3, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// System under test
+	_, err := client.AllBalances(ctx, "address")
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -2,9 +2,14 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v26/x/poolmanager/types"
+
+	"github.com/osmosis-labs/sqs/sqsdomain"
 )
 
 // CosmWasmPoolRouterConfig is the config for the CosmWasm pools in the router
@@ -84,6 +89,106 @@ func (c CanonicalOrderBooksResult) Validate() error {
 	return nil
 }
 
+// CanonicalOrderbooksOptions defines the options for filtering canonical orderbooks.
+type CanonicalOrderbooksOptions struct {
+	// MinLiquidityCap excludes orderbooks with liquidity capitalization below this value.
+	// Nil disables the filter.
+	MinLiquidityCap osmomath.Int
+}
+
+// CanonicalOrderbooksOption configures the canonical orderbooks filter options.
+type CanonicalOrderbooksOption func(*CanonicalOrderbooksOptions)
+
+// WithMinCanonicalOrderbookLiquidityCap configures the minimum liquidity
+// capitalization for canonical orderbooks to be returned.
+func WithMinCanonicalOrderbookLiquidityCap(minLiquidityCap osmomath.Int) CanonicalOrderbooksOption {
+	return func(o *CanonicalOrderbooksOptions) {
+		o.MinLiquidityCap = minLiquidityCap
+	}
+}
+
+// PoolLiquidityCapErrorResult is a structure for serializing a pool whose
+// liquidity capitalization could not be computed, along with the reason.
+type PoolLiquidityCapErrorResult struct {
+	PoolID            uint64 `json:"pool_id"`
+	LiquidityCapError string `json:"liquidity_cap_error"`
+}
+
+// CosmWasmCircuitBreakerState is the state of the circuit breaker of a generalized CosmWasm pool.
+type CosmWasmCircuitBreakerState string
+
+const (
+	// CosmWasmCircuitBreakerClosed is the state of a pool that is routed through normally.
+	CosmWasmCircuitBreakerClosed CosmWasmCircuitBreakerState = "closed"
+	// CosmWasmCircuitBreakerOpen is the state of a pool that is excluded from routing
+	// after too many consecutive failures.
+	CosmWasmCircuitBreakerOpen CosmWasmCircuitBreakerState = "open"
+	// CosmWasmCircuitBreakerHalfOpen is the state of a pool whose cooldown has elapsed.
+	// The pool is routed through again so that the next network call probes whether it recovered.
+	CosmWasmCircuitBreakerHalfOpen CosmWasmCircuitBreakerState = "half-open"
+)
+
+// CosmWasmCircuitBreakerPoolStatus is a structure for serializing the circuit breaker status of
+// a generalized CosmWasm pool with at least one failed network call since the last success.
+type CosmWasmCircuitBreakerPoolStatus struct {
+	PoolID              uint64                      `json:"pool_id"`
+	State               CosmWasmCircuitBreakerState `json:"state"`
+	ConsecutiveFailures int                         `json:"consecutive_failures"`
+	// OpenedAt is the time at which the circuit was last opened. Zero if it was never opened.
+	OpenedAt time.Time `json:"opened_at"`
+}
+
+// EnrichedPool is a pool along with the spot prices between all of its denoms.
+type EnrichedPool struct {
+	Pool sqsdomain.PoolI `json:"pool"`
+	// SpotPrices maps each pool denom as the base to every other pool denom as the quote to the spot price.
+	SpotPrices PricesResult `json:"spot_prices"`
+}
+
+// CalcExitCFMMPoolRequest defines a request to estimate the coins returned
+// from redeeming the given GAMM shares of a CFMM pool.
+type CalcExitCFMMPoolRequest struct {
+	PoolID        uint64
+	ExitingShares osmomath.Int
+}
+
+// PoolSortField defines the field by which pools are sorted.
+type PoolSortField int
+
+const (
+	// PoolSortFieldID sorts pools by pool ID.
+	PoolSortFieldID PoolSortField = iota
+	// PoolSortFieldLiquidityCap sorts pools by liquidity capitalization.
+	PoolSortFieldLiquidityCap
+	// PoolSortFieldSpreadFactor sorts pools by spread factor.
+	PoolSortFieldSpreadFactor
+)
+
+// poolSortFieldNames maps the string representation of the pool sort fields
+// accepted by the API to the sort fields.
+var poolSortFieldNames = map[string]PoolSortField{
+	"id":            PoolSortFieldID,
+	"liquidity_cap": PoolSortFieldLiquidityCap,
+	"spread_factor": PoolSortFieldSpreadFactor,
+}
+
+// ParsePoolSortField parses the given string into a pool sort field.
+// Returns error if the string does not correspond to any sort field.
+func ParsePoolSortField(field string) (PoolSortField, error) {
+	sortField, ok := poolSortFieldNames[field]
+	if !ok {
+		return 0, fmt.Errorf("invalid pool sort field: %s", field)
+	}
+	return sortField, nil
+}
+
+// PoolsSortOptions defines how pools are sorted.
+type PoolsSortOptions struct {
+	Field PoolSortField
+	// Desc is true if pools are sorted in descending order.
+	Desc bool
+}
+
 type PoolsOptions struct {
 	MinPoolLiquidityCap  uint64
 	PoolIDFilter         []uint64
@@ -91,6 +196,12 @@ type PoolsOptions struct {
 	// HadEmptyFilter is true if the pool ID filter was empty.
 	// This signifies avoid getting all pools and rather exit early.
 	HadEmptyFilter bool
+	// PoolTypeFilter is the set of pool types to retain after the other filters are applied.
+	// Nil if pools of all types are retained.
+	PoolTypeFilter map[poolmanagertypes.PoolType]struct{}
+	// SortBy defines how the pools are sorted after filtering.
+	// Nil if pools are not sorted.
+	SortBy *PoolsSortOptions
 }
 
 // PoolsOption configures the pools filter options.
@@ -122,3 +233,30 @@ func WithMarketIncentives(withMarketIncentives bool) PoolsOption {
 		o.WithMarketIncentives = withMarketIncentives
 	}
 }
+
+// WithPoolTypeFilter configures the pools options to only retain the pools of the given types.
+// The filter is applied after the other filters. No types signifies retaining pools of all types.
+func WithPoolTypeFilter(poolTypes ...poolmanagertypes.PoolType) PoolsOption {
+	return func(o *PoolsOptions) {
+		if len(poolTypes) == 0 {
+			o.PoolTypeFilter = nil
+			return
+		}
+
+		o.PoolTypeFilter = make(map[poolmanagertypes.PoolType]struct{}, len(poolTypes))
+		for _, poolType := range poolTypes {
+			o.PoolTypeFilter[poolType] = struct{}{}
+		}
+	}
+}
+
+// WithPoolsSortBy configures the pools options to sort the pools by the given field
+// after filtering. Ties are broken by pool ID in ascending order.
+func WithPoolsSortBy(field PoolSortField, desc bool) PoolsOption {
+	return func(o *PoolsOptions) {
+		o.SortBy = &PoolsSortOptions{
+			Field: field,
+			Desc:  desc,
+		}
+	}
+}
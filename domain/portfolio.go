@@ -0,0 +1,42 @@
+package domain
+
+// PortfolioAssetsOptions configures the portfolio assets computation.
+type PortfolioAssetsOptions struct {
+	// QuoteDenom is the denom in which the capitalization of the portfolio is computed.
+	// Empty falls back to the default quote denom.
+	QuoteDenom string
+	// IsDetailed indicates whether to break down the staked, unstaking, in-locks, pooled and
+	// concentrated positions categories by individual coins in addition to their capitalization.
+	IsDetailed bool
+	// HideZeroValue indicates whether to exclude coins with zero capitalization
+	// from the per-coin breakdown of the categories.
+	HideZeroValue bool
+}
+
+// PortfolioAssetsOption configures the portfolio assets options.
+type PortfolioAssetsOption func(*PortfolioAssetsOptions)
+
+// WithPortfolioQuoteDenom configures the quote denom in which
+// the portfolio capitalization is computed.
+func WithPortfolioQuoteDenom(quoteDenom string) PortfolioAssetsOption {
+	return func(o *PortfolioAssetsOptions) {
+		o.QuoteDenom = quoteDenom
+	}
+}
+
+// WithDetailedPortfolio configures the portfolio assets to include
+// the per-coin breakdown for the staked, unstaking, in-locks, pooled and concentrated positions categories.
+func WithDetailedPortfolio() PortfolioAssetsOption {
+	return func(o *PortfolioAssetsOptions) {
+		o.IsDetailed = true
+	}
+}
+
+// WithHideZeroValue configures the portfolio assets to exclude coins with zero
+// capitalization (e.g. dust or unpriced denoms) from the per-coin breakdown.
+// The number of excluded coins is reported per category instead.
+func WithHideZeroValue() PortfolioAssetsOption {
+	return func(o *PortfolioAssetsOptions) {
+		o.HideZeroValue = true
+	}
+}
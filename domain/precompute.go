@@ -1,4 +1,4 @@
-package usecase
+package domain
 
 import (
 	"github.com/osmosis-labs/osmosis/osmomath"
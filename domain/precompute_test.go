@@ -1,11 +1,13 @@
-package usecase_test
+package domain_test
 
 import (
 	"fmt"
 	"testing"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
-	"github.com/osmosis-labs/sqs/router/usecase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/sqs/domain"
 )
 
 var (
@@ -21,7 +23,7 @@ var (
 	TenE1      = osmomath.NewInt(10)
 )
 
-func (s *RouterTestSuite) TestGetPrecomputeOrderOfMagnitude() {
+func TestGetPrecomputeOrderOfMagnitude(t *testing.T) {
 	type testcase struct {
 		amount osmomath.Int
 	}
@@ -48,26 +50,26 @@ func (s *RouterTestSuite) TestGetPrecomputeOrderOfMagnitude() {
 	}
 
 	for name, tc := range tests {
-		s.Run(name, func() {
+		t.Run(name, func(t *testing.T) {
 
-			actual := usecase.GetPrecomputeOrderOfMagnitude(tc.amount)
+			actual := domain.GetPrecomputeOrderOfMagnitude(tc.amount)
 
 			expected := osmomath.OrderOfMagnitude(tc.amount.ToLegacyDec())
 
-			s.Require().Equal(expected, actual)
+			require.Equal(t, expected, actual)
 		})
 	}
 
 }
 
-// go test -benchmem -run=^$ -bench ^BenchmarkGetPrecomputeOrderOfMagnitude$ github.com/osmosis-labs/sqs/router/usecase -count=6
+// go test -benchmem -run=^$ -bench ^BenchmarkGetPrecomputeOrderOfMagnitude$ github.com/osmosis-labs/sqs/domain -count=6
 func BenchmarkGetPrecomputeOrderOfMagnitude(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		_ = usecase.GetPrecomputeOrderOfMagnitude(testAmount)
+		_ = domain.GetPrecomputeOrderOfMagnitude(testAmount)
 	}
 }
 
-// go test -benchmem -run=^$ -bench ^BenchmarkOrderOfMagnitude$ github.com/osmosis-labs/sqs/router/usecase -count=6 > old
+// go test -benchmem -run=^$ -bench ^BenchmarkOrderOfMagnitude$ github.com/osmosis-labs/sqs/domain -count=6 > old
 func BenchmarkOrderOfMagnitude(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = osmomath.OrderOfMagnitude(testAmount.ToLegacyDec())
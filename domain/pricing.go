@@ -1,12 +1,16 @@
 package domain
 
 import (
+	"bytes"
 	"context"
+	"sort"
+	"strconv"
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/sqs/domain/cache"
+	"github.com/osmosis-labs/sqs/sqsdomain/json"
 )
 
 // PricingSourceType defines the enumeration
@@ -23,6 +27,20 @@ const (
 	NoneSourceType = -1
 )
 
+// String returns the string representation of the pricing source type.
+func (p PricingSourceType) String() string {
+	switch p {
+	case ChainPricingSourceType:
+		return "chain"
+	case CoinGeckoPricingSourceType:
+		return "coingecko"
+	case NoneSourceType:
+		return "none"
+	default:
+		return "unknown"
+	}
+}
+
 // PricingSource defines an interface that must be fulfilled by the specific
 // implementation of the pricing source.
 type PricingSource interface {
@@ -35,10 +53,25 @@ type PricingSource interface {
 	// Panics if cache is already set.
 	InitializeCache(*cache.Cache)
 
+	// InvalidateCache removes the cached prices of the given base denoms against all quote denoms
+	// other than excludedQuoteDenom so that they are recomputed on the next request.
+	// An empty excludedQuoteDenom removes the prices against all quote denoms.
+	InvalidateCache(excludedQuoteDenom string, baseDenoms ...string)
+
 	// GetFallBackStrategy determines what pricing source should be fallen back to in case this pricing source fails
 	GetFallbackStrategy(quoteDenom string) PricingSourceType
 }
 
+// MultiQuotePricingSource is a pricing source that can price a base denom
+// against multiple quote denoms at once.
+type MultiQuotePricingSource interface {
+	PricingSource
+
+	// GetPricesForQuotes returns the prices of the base denom against each of the quote denoms
+	// or otherwise error, if any.
+	GetPricesForQuotes(ctx context.Context, baseDenom string, quoteDenoms []string, opts ...PricingOption) (map[string]osmomath.BigDec, error)
+}
+
 // PricingOptions defines the options for retrieving the prices.
 type PricingOptions struct {
 	// RecomputePrices defines whether to recompute the prices or attempt to retrieve
@@ -51,6 +84,12 @@ type PricingOptions struct {
 	RecomputePricesIsSpotPriceComputeMethod bool
 	// MinPoolLiquidityCap defines the minimum liquidity required to consider a pool for pricing.
 	MinPoolLiquidityCap uint64
+	// IsStrict defines whether to return an error if any of the computed prices is zero
+	// instead of silently including the zero price in the result.
+	IsStrict bool
+	// DisableCacheMetrics defines whether to skip recording the pricing cache hit and miss metrics.
+	// Used when warming up the cache so that the subsequent lookups are not double-counted.
+	DisableCacheMetrics bool
 }
 
 // PricingOption configures the pricing options.
@@ -73,7 +112,9 @@ func WithRecomputePricesQuoteBasedMethod() PricingOption {
 }
 
 // WithMinPricingPoolLiquidityCap configures the min liquidity capitalization option
-// for pricing. Note, that non-pricing routing has its own RouterOption to configure
+// for pricing so that only the pools above this liquidity floor are used to compute the prices.
+// The prices computed with a floor other than the configured default are cached separately per floor.
+// Note, that non-pricing routing has its own RouterOption to configure
 // the min liquidity capitalization.
 func WithMinPricingPoolLiquidityCap(minPoolLiquidityCap uint64) PricingOption {
 	return func(o *PricingOptions) {
@@ -81,6 +122,23 @@ func WithMinPricingPoolLiquidityCap(minPoolLiquidityCap uint64) PricingOption {
 	}
 }
 
+// WithStrictPricing configures the pricing options to return an error
+// listing all base/quote pairs that priced to zero instead of including them
+// in the result.
+func WithStrictPricing() PricingOption {
+	return func(o *PricingOptions) {
+		o.IsStrict = true
+	}
+}
+
+// WithoutPricingCacheMetrics configures the pricing options to skip
+// recording the pricing cache hit and miss metrics.
+func WithoutPricingCacheMetrics() PricingOption {
+	return func(o *PricingOptions) {
+		o.DisableCacheMetrics = true
+	}
+}
+
 // PricingConfig defines the configuration for the pricing.
 type PricingConfig struct {
 	// The number of milliseconds to cache the pricing data for.
@@ -103,22 +161,80 @@ type PricingConfig struct {
 	MaxRoutes        int `mapstructure:"max-routes"`
 	// MinPoolLiquidityCap is the minimum liquidity capitalization required for a pool to be considered in the router.
 	MinPoolLiquidityCap uint64 `mapstructure:"min-pool-liquidity-cap"`
+	// AllowedMinPoolLiquidityCaps are the min liquidity capitalizations, other than MinPoolLiquidityCap,
+	// that the prices endpoint accepts as an override. The prices are cached separately per floor
+	// so that the overrides are restricted to this set to bound the cache.
+	// If empty, only MinPoolLiquidityCap is accepted.
+	AllowedMinPoolLiquidityCaps []uint64 `mapstructure:"allowed-min-pool-liquidity-caps"`
 	// WorkerMinPoolLiquiidtyCap is the minimum liquidity capitalization required for a pool to be considered in the pricing worker.
+	// The chain prices pre-computed by the worker are cached under the same key as the ones computed with
+	// MinPoolLiquidityCap so that they are served for the requests with the default liquidity floor.
 	WorkerMinPoolLiquidityCap uint64 `mapstructure:"worker-min-pool-liquidity-cap"`
+	// SkippedRepricingDenomPrefixes are the prefixes of the synthetic denoms (e.g. share denoms) for which
+	// the pool liquidity pricing worker skips repricing the denom metadata.
+	// If empty, only the gamm share denoms are skipped.
+	SkippedRepricingDenomPrefixes []string `mapstructure:"skipped-repricing-denom-prefixes"`
+	// QuoteProbeAmounts maps the quote human denom to the amount of the quote token, in human units,
+	// that is swapped when computing chain prices against it. The amount is scaled by the quote denom precision.
+	// Larger amounts help thin pools get a representative price.
+	// Quote denoms that are not present default to 10 units.
+	QuoteProbeAmounts map[string]uint64 `mapstructure:"quote-probe-amounts"`
+	// ListenerNotificationCoalesceWindowMs is the number of milliseconds for which the pool liquidity
+	// pricing worker coalesces the listener notifications per height. As a result, the listeners receive
+	// at most one notification per height with the merged denoms and pool IDs.
+	// Zero disables the coalescing.
+	ListenerNotificationCoalesceWindowMs int `mapstructure:"listener-notification-coalesce-window-ms"`
+	// LiquidityCapHistoryRetention is the maximum number of total liquidity capitalization history entries
+	// retained per denom by the pool liquidity pricing worker. The oldest entries are evicted first.
+	// Zero disables the history.
+	LiquidityCapHistoryRetention int `mapstructure:"liquidity-cap-history-retention"`
+	// StablecoinHumanDenoms are the human denoms of the stablecoins used by the pricing heuristics.
+	// For example, the Coingecko pricing source only accepts these as quote denoms since its prices
+	// are quoted in the coingecko-quote-currency (usd).
+	// Each entry must be a known human denom, validated at startup.
+	// If empty, defaults to usdc and usdt.
+	StablecoinHumanDenoms []string `mapstructure:"stablecoin-human-denoms"`
+	// PrecomputeIntervalMs is the number of milliseconds between the runs of the prices precompute worker
+	// that prices all listed tokens against the default quote denom, populating the pricing cache.
+	// Zero disables the worker.
+	PrecomputeIntervalMs int `mapstructure:"precompute-interval-ms"`
+	// MaxConcurrentPriceComputations is the maximum number of prices computed concurrently
+	// by the prices precompute worker.
+	// If zero, defaults to 10.
+	MaxConcurrentPriceComputations int `mapstructure:"max-concurrent-price-computations"`
+	// CoingeckoQuoteCurrencies maps the human denoms of the additional quote denoms supported
+	// by the Coingecko pricing source to the Coingecko vs currency. For example, "wbtc": "btc".
+	// Each human denom must be known, validated at startup.
+	CoingeckoQuoteCurrencies map[string]string `mapstructure:"coingecko-quote-currencies"`
+	// CirculatingSupplies maps the human denoms to their circulating supply in human units
+	// used for the market cap estimation. For example, "osmo": "700000000".
+	// Each human denom must be known and each supply a non-negative decimal, validated at startup.
+	CirculatingSupplies map[string]string `mapstructure:"circulating-supplies"`
 }
 
-// FormatCacheKey formats the cache key for the given denoms.
-func FormatPricingCacheKey(a, b string) string {
+// FormatPricingCacheKey formats the cache key for the given denoms priced by the given source.
+// The source is part of the key so that the prices of different sources sharing a cache are never
+// served in place of each other. The keys of the prior format without the source are never read
+// and expire naturally.
+func FormatPricingCacheKey(source PricingSourceType, a, b string) string {
 	if a < b {
 		a, b = b, a
 	}
 
 	var sb strings.Builder
+	sb.WriteString(source.String())
+	sb.WriteString("/")
 	sb.WriteString(a)
 	sb.WriteString(b)
 	return sb.String()
 }
 
+// FormatPricingCacheKeyWithMinPoolLiquidityCap formats the cache key for the given denoms
+// priced by the given source using only the pools with at least the given liquidity capitalization.
+func FormatPricingCacheKeyWithMinPoolLiquidityCap(source PricingSourceType, a, b string, minPoolLiquidityCap uint64) string {
+	return FormatPricingCacheKey(source, a, b) + "/" + strconv.FormatUint(minPoolLiquidityCap, 10)
+}
+
 type PricingWorker interface {
 	// UpdatePricesAsync updates prices for the tokens from the unique block pool metadata
 	// that contains information about changed denoms and pools within a block.
@@ -135,12 +251,41 @@ type PricingWorker interface {
 	RegisterListener(listener PricingUpdateListener)
 }
 
+// PricesPrecomputeWorker defines the interface for the worker that precomputes
+// the prices for all listed tokens against the default quote denom on an interval,
+// populating the pricing cache.
+type PricesPrecomputeWorker interface {
+	// Start precomputes the prices immediately and then on every interval
+	// until the context is done. Blocks until then.
+	Start(ctx context.Context)
+
+	// PrecomputePrices computes the prices for all listed tokens against the default quote denom,
+	// populating the pricing cache.
+	// Returns the number of denoms priced and the number of denoms that failed to be priced.
+	PrecomputePrices(ctx context.Context) (numPriced int, numFailed int)
+}
+
 // PricingUpdateListener defines the interface for the pricing update listener.
 type PricingUpdateListener interface {
 	// OnPricingUpdate notifies the listener of the pricing update.
 	OnPricingUpdate(ctx context.Context, height uint64, blockMetaData BlockPoolMetadata, pricesBaseQuoteDenomMap PricesResult, quoteDenom string) error
 }
 
+// PricesBroadcaster defines the interface for broadcasting the pricing updates
+// computed by the pricing worker to the subscribers.
+type PricesBroadcaster interface {
+	// Implements PricingUpdateListener
+	PricingUpdateListener
+
+	// Subscribe subscribes to the pricing updates for the given base denoms.
+	// If no base denoms are given, the updates for all denoms are received.
+	// Each update only contains the prices recomputed within the block.
+	// If the subscriber falls behind, the oldest pending update is dropped.
+	// Returns the channel receiving the updates and a function to unsubscribe.
+	// The channel is closed on unsubscribe.
+	Subscribe(baseDenoms []string) (<-chan PricesResult, func())
+}
+
 // PoolLiquidityPricerWorker defines the interface for the pool liquidity pricer worker.
 type PoolLiquidityPricerWorker interface {
 	// Implements PricingUpdateListener
@@ -164,15 +309,44 @@ type PoolLiquidityPricerWorker interface {
 	// computed as best-effort (set to zero if cannot compute).
 	CreatePoolDenomMetaData(updatedBlockDenom string, updateHeight uint64, blockPriceUpdates PricesResult, quoteDenom string, blockPoolMetadata BlockPoolMetadata) (PoolDenomMetaData, error)
 
+	// RepriceSingleDenom reprices the token liquidity metadata for the given denom on demand, e.g. after a manual liquidity correction.
+	// The price is retrieved from the registered pricing source and the total liquidity is summed across the balances of all pools.
+	// The repriced metadata is stored in the tokens pool liquidity handler and the latest update height for the denom is updated.
+	// Returns the computed pool denom metadata.
+	// Returns DenomRepricingSkippedError if there is an update for the denom with a later height or if the denom is a gamm share.
+	// Otherwise, returns the same errors as CreatePoolDenomMetaData with the metadata computed as best-effort.
+	RepriceSingleDenom(ctx context.Context, denom string, height uint64, quoteDenom string) (PoolDenomMetaData, error)
+
 	// GetHeightForDenom returns zero if the height is not found or fails to cast it to the return type.
 	GetHeightForDenom(denom string) uint64
 
+	// GetLiquidityCapHistory returns the retained history of the total liquidity capitalization for the given denom
+	// ordered by height ascending. If limit is positive, only the latest limit entries are returned.
+	// Returns empty result if the history is not found or if the history retention is disabled.
+	GetLiquidityCapHistory(denom string, limit int) []LiquidityCapHistoryEntry
+
+	// GetLatestUpdateHeight returns the latest height of the pricing updates received by the worker.
+	// Returns zero if no update was received.
+	GetLatestUpdateHeight() uint64
+
 	// StoreHeightForDenom stores the latest height for the given denom.
 	StoreHeightForDenom(denom string, height uint64)
 
+	// RepriceAllPoolsLiquidityCap switches the quote denom of the liquidity pricer to the given one
+	// and reprices the liquidity capitalization of all pools against it.
+	// Relies on the blockPriceUpdates to contain prices against the new quote denom.
+	// Resets the latest update heights for all denoms since they were tracked for the metadata priced
+	// in the previous quote denom.
+	// Returns error if fails to get or store the pools.
+	RepriceAllPoolsLiquidityCap(quoteDenom string, blockPriceUpdates PricesResult) error
+
 	// RegisterListener register pool liquidity compute lister that receives hook updates
 	// on completion of the worker workload.
 	RegisterListener(listener PoolLiquidityComputeListener)
+
+	// DeregisterListener removes the previously registered pool liquidity compute listener
+	// so that it stops receiving hook updates. No-op if the listener is not registered.
+	DeregisterListener(listener PoolLiquidityComputeListener)
 }
 
 type DenomPriceInfo struct {
@@ -187,15 +361,24 @@ type LiquidityPricer interface {
 	//
 	// If no error occurs, the error string is empty.
 	//
+	// Returns CapitalizationOverflowError if the capitalization of any balance or their sum
+	// exceeds the range of osmomath.Int.
+	//
 	// The purpose of such handling is to ensure that we silently skip any errors but apply partial liquidity capitalization
 	// updates. The best-effort liquidity capitalization ranking improves the quality of by-liquidity ranking in the router.
-	PriceBalances(balances sdk.Coins, blockPriceUpdates PricesResult) (osmomath.Int, string)
+	PriceBalances(balances sdk.Coins, blockPriceUpdates PricesResult) (osmomath.Int, string, error)
 
 	// PriceCoin computes the capitalization of the liquidity for the given denom
 	// using the total liquidity and the price.
 	// Returs zero if the price is zero or if there is any internal error.
 	// Otherwise, returns the computed liquidity capitalization from total liquidity and price.
 	PriceCoin(liquidity sdk.Coin, price osmomath.BigDec) osmomath.Dec
+
+	// GetQuoteDenom returns the quote denom that balances are priced against.
+	GetQuoteDenom() string
+
+	// SetQuoteDenom sets the quote denom that balances are priced against.
+	SetQuoteDenom(quoteDenom string)
 }
 
 // PoolLiquidityComputeListener defines the interface for the pool liquidity compute listener.
@@ -212,6 +395,23 @@ type PoolLiquidityComputeListener interface {
 // separating the API response for backward compatibility.
 type PricesResult map[string]map[string]osmomath.BigDec
 
+// DivergenceReport describes a denom whose chain prices against USDC and USDT
+// diverge beyond the tolerance, indicating thin pools or mispricing.
+type DivergenceReport struct {
+	// Denom is the chain denom of the token.
+	Denom string `json:"denom"`
+	// USDCPrice is the price of the token in USDC.
+	// @Type string
+	USDCPrice osmomath.BigDec `json:"usdc_price"`
+	// USDTPrice is the price of the token in USDT.
+	// @Type string
+	USDTPrice osmomath.BigDec `json:"usdt_price"`
+	// Divergence is the absolute difference between the prices
+	// relative to the larger of the two prices.
+	// @Type string
+	Divergence osmomath.BigDec `json:"divergence"`
+}
+
 // GetPriceForDenom returns the price for the given baseDenom and quote denom.
 // Returns zero if the price is not found.
 func (prices PricesResult) GetPriceForDenom(baseDenom string, quoteDenom string) osmomath.BigDec {
@@ -227,3 +427,104 @@ func (prices PricesResult) GetPriceForDenom(baseDenom string, quoteDenom string)
 
 	return price.Clone()
 }
+
+// MarshalJSON implements json.Marshaler.
+// Emits the prices as full-precision decimal strings with the base and quote denoms
+// in ascending order so that the output is stable across calls.
+// Nil prices are emitted as zero.
+func (prices PricesResult) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteByte('{')
+	for i, baseDenom := range sortedKeys(prices) {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		if err := writeJSONKey(&buf, baseDenom); err != nil {
+			return nil, err
+		}
+
+		quotePrices := prices[baseDenom]
+
+		buf.WriteByte('{')
+		for j, quoteDenom := range sortedKeys(quotePrices) {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeJSONKey(&buf, quoteDenom); err != nil {
+				return nil, err
+			}
+
+			price := quotePrices[quoteDenom]
+			if price.IsNil() {
+				price = osmomath.ZeroBigDec()
+			}
+
+			buf.WriteByte('"')
+			buf.WriteString(price.String())
+			buf.WriteByte('"')
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// Parses the prices from the decimal strings emitted by MarshalJSON.
+func (prices *PricesResult) UnmarshalJSON(data []byte) error {
+	var rawPrices map[string]map[string]string
+	if err := json.Unmarshal(data, &rawPrices); err != nil {
+		return err
+	}
+
+	if rawPrices == nil {
+		*prices = nil
+		return nil
+	}
+
+	result := make(PricesResult, len(rawPrices))
+	for baseDenom, rawQuotePrices := range rawPrices {
+		quotePrices := make(map[string]osmomath.BigDec, len(rawQuotePrices))
+		for quoteDenom, rawPrice := range rawQuotePrices {
+			price, err := osmomath.NewBigDecFromStr(rawPrice)
+			if err != nil {
+				return err
+			}
+
+			quotePrices[quoteDenom] = price
+		}
+
+		result[baseDenom] = quotePrices
+	}
+
+	*prices = result
+
+	return nil
+}
+
+// sortedKeys returns the keys of the given map in ascending order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeJSONKey writes the given key as an escaped JSON string followed by a colon.
+func writeJSONKey(buf *bytes.Buffer, key string) error {
+	keyBz, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+
+	buf.Write(keyBz)
+	buf.WriteByte(':')
+
+	return nil
+}
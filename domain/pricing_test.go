@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
@@ -92,3 +93,80 @@ func TestPricesResultGetPriceForDenom(t *testing.T) {
 		})
 	}
 }
+
+// TestPricesResultJSON tests that the prices result is marshaled into full-precision decimal strings
+// with a stable key order and that unmarshaling the output results in the original prices.
+func TestPricesResultJSON(t *testing.T) {
+	var (
+		fullPrecisionPrice = osmomath.MustNewBigDecFromStr("1234567.123456789012345678901234567890123456")
+		smallPrice         = osmomath.MustNewBigDecFromStr("0.000000000000000000000000000000000001")
+	)
+
+	tests := []struct {
+		name string
+
+		pricesResult domain.PricesResult
+
+		expectedJSON string
+	}{
+		{
+			name: "multiple base and quote denoms",
+
+			pricesResult: domain.PricesResult{
+				"uosmo": {
+					"uusdc": fullPrecisionPrice,
+					"uatom": smallPrice,
+				},
+				"uatom": {
+					"uusdc": osmomath.OneBigDec(),
+				},
+			},
+
+			expectedJSON: `{"uatom":{"uusdc":"1.000000000000000000000000000000000000"},"uosmo":{"uatom":"0.000000000000000000000000000000000001","uusdc":"1234567.123456789012345678901234567890123456"}}`,
+		},
+		{
+			name: "denoms requiring escaping",
+
+			pricesResult: domain.PricesResult{
+				"ibc/\"quoted\"": {
+					"factory/osmo1/denom": osmomath.OneBigDec(),
+				},
+			},
+
+			expectedJSON: `{"ibc/\"quoted\"":{"factory/osmo1/denom":"1.000000000000000000000000000000000000"}}`,
+		},
+		{
+			name: "empty",
+
+			pricesResult: domain.PricesResult{},
+
+			expectedJSON: `{}`,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			bz, err := json.Marshal(tc.pricesResult)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedJSON, string(bz))
+
+			// Marshaling again results in the same output.
+			bz2, err := json.Marshal(tc.pricesResult)
+			require.NoError(t, err)
+			require.Equal(t, bz, bz2)
+
+			var actual domain.PricesResult
+			err = json.Unmarshal(bz, &actual)
+			require.NoError(t, err)
+
+			require.Equal(t, len(tc.pricesResult), len(actual))
+			for baseDenom, quotePrices := range tc.pricesResult {
+				require.Equal(t, len(quotePrices), len(actual[baseDenom]))
+				for quoteDenom, price := range quotePrices {
+					require.True(t, price.Equal(actual.GetPriceForDenom(baseDenom, quoteDenom)), "base (%s), quote (%s)", baseDenom, quoteDenom)
+				}
+			}
+		})
+	}
+}
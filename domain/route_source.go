@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"sync"
+)
+
+// RouteSourceKeyType is a custom type for the route source key.
+type RouteSourceKeyType string
+
+const (
+	// RouteSourceCtxKey is the key used to store the route source holder in the request context
+	RouteSourceCtxKey RouteSourceKeyType = "route_source"
+
+	// RouteSourceCache indicates that the quote routes were read from the ranked route cache.
+	RouteSourceCache = "cache"
+	// RouteSourceComputed indicates that the quote routes were computed for the request.
+	RouteSourceComputed = "computed"
+)
+
+// RouteSource holds the source of the routes used to compute the quote of a request.
+// It is placed on the request context before the request is handled so that
+// the router can record the source and the request logging can read it afterwards.
+type RouteSource struct {
+	mu     sync.Mutex
+	source string
+}
+
+// Get returns the recorded route source. Returns empty string if none was recorded.
+func (r *RouteSource) Get() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.source
+}
+
+// ContextWithRouteSource returns a copy of the given context holding a new route source
+// along with the route source itself.
+func ContextWithRouteSource(ctx context.Context) (context.Context, *RouteSource) {
+	routeSource := &RouteSource{}
+	return context.WithValue(ctx, RouteSourceCtxKey, routeSource), routeSource
+}
+
+// SetRouteSourceInContext records the given route source in the route source held by the context.
+// No-op if the context holds no route source.
+func SetRouteSourceInContext(ctx context.Context, source string) {
+	routeSource, ok := ctx.Value(RouteSourceCtxKey).(*RouteSource)
+	if !ok {
+		return
+	}
+
+	routeSource.mu.Lock()
+	defer routeSource.mu.Unlock()
+	routeSource.source = source
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/sqs/log"
@@ -46,7 +47,9 @@ type Route interface {
 	// Computes the spot price of the route.
 	// Returns the spot price before swap and effective spot price.
 	// The token in is the base token and the token out is the quote token.
-	PrepareResultPools(ctx context.Context, tokenIn sdk.Coin, logger log.Logger) ([]RoutablePool, osmomath.Dec, osmomath.Dec, error)
+	// Additionally, returns the intermediate token amounts output by each pool
+	// in the route except the last one.
+	PrepareResultPools(ctx context.Context, tokenIn sdk.Coin, logger log.Logger) ([]RoutablePool, osmomath.Dec, osmomath.Dec, []sdk.Coin, error)
 
 	String() string
 }
@@ -55,6 +58,17 @@ type SplitRoute interface {
 	Route
 	GetAmountIn() osmomath.Int
 	GetAmountOut() osmomath.Int
+
+	// GetInPortion returns the fraction of the quote's total token in amount
+	// that is swapped over this route. It is computed during quote result preparation.
+	GetInPortion() osmomath.Dec
+
+	// GetIntermediateAmounts returns the token amounts between each hop of the route,
+	// i.e. the amounts output by each pool except the last one to swap.
+	// Ordered from the token in for both swap methods. Note that the pools of exact out
+	// routes are ordered from the token out, hence in the reverse order of these amounts.
+	// It is computed during quote result preparation.
+	GetIntermediateAmounts() []sdk.Coin
 }
 
 type Quote interface {
@@ -65,6 +79,52 @@ type Quote interface {
 	GetPriceImpact() osmomath.Dec
 	GetInBaseOutQuoteSpotPrice() osmomath.Dec
 
+	// GetEffectiveRate returns the amount out per amount in of the quote,
+	// converted to human precision with the scaling factor passed to PrepareResult.
+	// It is computed during PrepareResult.
+	// Returns zero if the amount in or the scaling factor is zero.
+	GetEffectiveRate() osmomath.Dec
+
+	// GetReferencePriceImpact returns the price impact of the quote relative to the
+	// reference price supplied via WithReferencePrice rather than the internal spot price.
+	// It is computed during PrepareResult.
+	// Returns nil Dec if no reference price was supplied.
+	GetReferencePriceImpact() osmomath.Dec
+
+	// GetExpiresAt returns the time until which the quote is considered fresh.
+	// It is derived from the TTL of the route cache used to produce the quote.
+	// Returns zero time if the quote was produced with the route cache disabled.
+	GetExpiresAt() time.Time
+
+	// GetRouteTree returns the tree representation of the quote split routes
+	// where the routes sharing a common prefix of pools are merged.
+	// GetRoute remains the default flat representation.
+	GetRouteTree() []*RouteTreeNode
+
+	// GetRouteComplexity returns the complexity of executing the quote route.
+	// It is computed during PrepareResult.
+	GetRouteComplexity() RouteComplexity
+
+	// GetAlternatives returns the single route quotes over the best ranked routes
+	// other than the route of this quote, in decreasing order of amount out.
+	// Empty unless requested via WithAlternatives.
+	GetAlternatives() []Quote
+
+	// GetFilteredRoutes returns the ranked routes that were filtered out
+	// when computing the quote, each with a reason.
+	// Empty unless requested via WithDiagnostics.
+	GetFilteredRoutes() []FilteredRoute
+
+	// TruncateRoutes clamps the routes of the quote to at most maxRoutes routes
+	// and maxPools pools across routes, dropping the trailing routes as a whole.
+	// A non-positive limit disables the corresponding clamp.
+	// Must be called after PrepareResult. Note that the amounts of the quote
+	// are not updated to reflect the dropped routes.
+	TruncateRoutes(maxRoutes, maxPools int)
+
+	// IsTruncated returns true if any route was dropped by TruncateRoutes.
+	IsTruncated() bool
+
 	// PrepareResult mutates the quote to prepare
 	// it with the data formatted for output to the client.
 	// scalingFactor is the spot price scaling factor according to chain precision.
@@ -75,6 +135,55 @@ type Quote interface {
 	String() string
 }
 
+// RouteTreeNode is a node in the tree representation of split routes
+// where the routes sharing a common prefix of pools are merged into a single branch.
+type RouteTreeNode struct {
+	// PoolID is the ID of the pool swapped over at this node.
+	PoolID uint64 `json:"pool_id"`
+	// TokenOutDenom is the denom swapped out of the pool at this node.
+	TokenOutDenom string `json:"token_out_denom"`
+	// AmountIn is the total amount in of the split routes going through this node.
+	AmountIn osmomath.Int `json:"amount_in"`
+	// AmountOut is the total amount out of the split routes ending at this node.
+	// Zero if no split route ends at this node.
+	AmountOut osmomath.Int `json:"amount_out"`
+	// Children are the nodes of the next pools in the split routes going through this node.
+	Children []*RouteTreeNode `json:"children,omitempty"`
+}
+
+// RouteComplexity describes the complexity of executing a quote route across all of its split routes.
+// Clients may use it to predict the transaction gas before submitting.
+type RouteComplexity struct {
+	// HopCount is the total number of pools swapped over across all split routes.
+	HopCount int `json:"hop_count"`
+	// GeneralizedCosmWasmPoolCount is the number of generalized CosmWasm pools across all split routes.
+	// These pools are the most expensive to execute since they make network requests to chain.
+	GeneralizedCosmWasmPoolCount int `json:"generalized_cosmwasm_pool_count"`
+	// ConcentratedPoolCount is the number of concentrated pools across all split routes.
+	ConcentratedPoolCount int `json:"concentrated_pool_count"`
+}
+
+// FilteredRouteReason is the reason a ranked route was filtered out when computing the optimal quote.
+type FilteredRouteReason string
+
+const (
+	// FilteredRouteReasonDuplicatePoolID is reported for routes sharing a pool with a higher ranked route.
+	FilteredRouteReasonDuplicatePoolID FilteredRouteReason = "duplicate-pool-id"
+	// FilteredRouteReasonGeneralizedCosmWasmPool is reported for routes excluded from split quotes
+	// due to containing a generalized CosmWasm pool.
+	FilteredRouteReasonGeneralizedCosmWasmPool FilteredRouteReason = "generalized-cosmwasm-pool"
+	// FilteredRouteReasonMaxSplitRoutes is reported for routes ranked below the max split routes.
+	FilteredRouteReasonMaxSplitRoutes FilteredRouteReason = "max-split-routes"
+)
+
+// FilteredRoute describes a ranked route that was filtered out when computing the optimal quote.
+type FilteredRoute struct {
+	// PoolIDs are the IDs of the pools in the route in swap order.
+	PoolIDs []uint64 `json:"pool_ids"`
+	// Reason is the reason the route was filtered out.
+	Reason FilteredRouteReason `json:"reason"`
+}
+
 type DynamicMinLiquidityCapFilterEntry struct {
 	MinTokensCap uint64 `mapstructure:"min-tokens-capitalization"`
 	FilterValue  uint64 `mapstructure:"filter-value"`
@@ -83,8 +192,16 @@ type DynamicMinLiquidityCapFilterEntry struct {
 // Router-specific configuration
 type RouterConfig struct {
 	// Pool IDs that are prioritized in the router.
+	// The pools are boosted in the candidate route search. Additionally, a route through these pools
+	// is preferred as the top single route if its amount out is within preferred-pool-tie-tolerance-bps of the best route.
 	PreferredPoolIDs []uint64 `mapstructure:"preferred-pool-ids"`
 
+	// Tolerance in basis points of the best route amount out within which a route through
+	// the preferred pools is preferred as the top single route over the other routes.
+	// The routes considered for splits remain ranked by amount out.
+	// Zero disables the prioritization.
+	PreferredPoolTieToleranceBps uint64 `mapstructure:"preferred-pool-tie-tolerance-bps"`
+
 	// Maximum number of pools in one route.
 	MaxPoolsPerRoute int `mapstructure:"max-pools-per-route"`
 
@@ -94,6 +211,15 @@ type RouterConfig struct {
 	// Maximum number of routes to split across.
 	MaxSplitRoutes int `mapstructure:"max-split-routes"`
 
+	// Maximum number of the highest ranked pools considered for each denom during the candidate route search.
+	// Bounds the worst-case latency of the search for denoms with many pools.
+	// Zero disables the limit.
+	MaxPoolsConsidered int `mapstructure:"max-pools-considered"`
+
+	// Algorithm used by the candidate route search. Either "bfs" or "greedy".
+	// Defaults to "bfs" if empty.
+	CandidateRouteSearchAlgorithm CandidateRouteSearchAlgorithm `mapstructure:"candidate-route-search-algorithm"`
+
 	// Minimum liquidity capitalization for a pool to be considered in the router.
 	// The denomination assumed is pricing.default-quote-human-denom.
 	MinPoolLiquidityCap uint64 `mapstructure:"min-pool-liquidity-cap"`
@@ -109,6 +235,46 @@ type RouterConfig struct {
 
 	// DynamicMinLiquidityCapFiltersAsc is a list of dynamic min liquidity cap filters in descending order.
 	DynamicMinLiquidityCapFiltersDesc []DynamicMinLiquidityCapFilterEntry `mapstructure:"dynamic-min-liquidity-cap-filters-desc"`
+
+	// Maximum number of blocks since the pool liquidity data was last repriced for a pool to be considered in the router.
+	// Zero disables the filter.
+	MaxLiquidityDataBlockAge uint64 `mapstructure:"max-liquidity-data-block-age"`
+
+	// Denoms that routes may traverse. If non-empty, candidate routes through pools containing
+	// any other denom are filtered out, except for the token in and token out denoms of the swap.
+	// Empty disables the allowlist.
+	AllowedDenoms []string `mapstructure:"allowed-denoms"`
+
+	// Denoms that routes may never traverse. Candidate routes through pools containing
+	// any of these denoms are filtered out.
+	DeniedDenoms []string `mapstructure:"denied-denoms"`
+
+	// Minimum fraction of the total amount out that a route must produce to be kept in a split quote.
+	// Routes producing less are dropped and their allocation is redistributed across the remaining routes.
+	// This avoids dust outputs from marginal routes that cost disproportionate gas to execute.
+	// Zero disables the filter.
+	MinSplitRouteOutPortion float64 `mapstructure:"min-split-route-out-portion"`
+
+	// DefaultTakerFeeFallback defines whether the default taker fee is returned for the pool denom pairs
+	// with a missing taker fee when querying pool taker fees. Otherwise, an error is returned.
+	DefaultTakerFeeFallback bool `mapstructure:"default-taker-fee-fallback"`
+
+	// Maximum number of routes returned by the quote API. Lower ranked routes are truncated
+	// and the quote is flagged as truncated. Zero disables the clamp.
+	MaxResponseRoutes int `mapstructure:"max-response-routes"`
+
+	// Maximum number of pools across all routes returned by the quote API. Routes are truncated as a whole
+	// once the limit would be exceeded and the quote is flagged as truncated. Zero disables the clamp.
+	MaxResponsePools int `mapstructure:"max-response-pools"`
+}
+
+// TakerFeeForPair represents the taker fee for a pair of tokens in a pool.
+type TakerFeeForPair struct {
+	sqsdomain.TakerFeeForPair
+
+	// IsDefault is true if the taker fee for the pair is missing and
+	// sqsdomain.DefaultTakerFee is returned instead.
+	IsDefault bool
 }
 
 type PoolsConfig struct {
@@ -125,10 +291,25 @@ type PoolsConfig struct {
 	// NOTE: that these pools make network requests to chain for quote estimation.
 	// As a result, they are excluded from split routes.
 	GeneralCosmWasmCodeIDs []uint64 `mapstructure:"general-cosmwasm-code-ids"`
+
+	// IDs of orderbook pools that are always treated as canonical for their base/quote pair,
+	// regardless of the liquidity capitalization of the other orderbooks for the same pair.
+	CanonicalOrderbookPoolIDs []uint64 `mapstructure:"canonical-orderbook-pool-ids"`
+
+	// Number of consecutive failed network calls after which a generalized CosmWasm pool
+	// is temporarily excluded from routing. Zero disables the circuit breaker.
+	CosmWasmCircuitBreakerFailureThreshold int `mapstructure:"cosmwasm-circuit-breaker-failure-threshold"`
+
+	// Number of seconds a generalized CosmWasm pool is excluded from routing once its circuit opens
+	// before it is probed again.
+	CosmWasmCircuitBreakerCooldownSeconds int `mapstructure:"cosmwasm-circuit-breaker-cooldown-seconds"`
 }
 
 const DisableSplitRoutes = 0
 
+// BasisPointsDenominator is the number of basis points in one.
+const BasisPointsDenominator uint64 = 10_000
+
 type RouterState struct {
 	Pools                    []sqsdomain.PoolI
 	TakerFees                sqsdomain.TakerFeeMap
@@ -146,6 +327,9 @@ type RouterOptions struct {
 	MaxPoolsPerRoute int
 	MaxRoutes        int
 	MaxSplitRoutes   int
+	// MaxPoolsConsidered is the maximum number of the highest ranked pools considered for each denom
+	// in the candidate route search. Zero if unlimited.
+	MaxPoolsConsidered int
 	// MinPoolLiquidityCap is the minimum liquidity capitalization required for a pool to be considered in the route.
 	MinPoolLiquidityCap uint64
 	// The number of milliseconds to cache candidate routes for before expiry.
@@ -159,8 +343,77 @@ type RouterOptions struct {
 	// If at least one of the callbacks in-slice returns true, the ShouldSkipPool function will
 	// also return true.
 	CandidateRoutesPoolFiltersAnyOf []CandidateRoutePoolFiltrerCb
+	// RequiredPoolID is the ID of the pool that all routes of the optimal quote must go through.
+	// Zero if no pool is required.
+	RequiredPoolID uint64
+	// TakerFeeMode defines how the taker fees are charged when estimating the quote.
+	TakerFeeMode TakerFeeMode
+	// Alternatives is the max number of alternative single route quotes to return
+	// alongside the optimal quote. Zero if no alternatives are requested.
+	Alternatives int
+	// RoundingMode defines how the fractional amounts of the quote are rounded when preparing the result.
+	RoundingMode RoundingMode
+	// ReferencePrice is the external price of the token in denominated in the token out,
+	// in the same units as the quote spot price, that the reference price impact is computed against.
+	// Nil if no reference price is supplied.
+	ReferencePrice osmomath.BigDec
+	// Diagnostics flag controlling whether the ranked routes filtered out
+	// when computing the quote are returned alongside it.
+	Diagnostics bool
+}
+
+// RoundingMode defines how the fractional amounts of a quote are rounded to integers
+// when preparing the result for the client.
+type RoundingMode int
+
+const (
+	// RoundingModeDefault rounds in the user's favor: truncates for exact in quotes
+	// and rounds up for exact out quotes.
+	RoundingModeDefault RoundingMode = iota
+	// RoundingModeTruncate rounds towards zero.
+	RoundingModeTruncate
+	// RoundingModeCeil rounds up.
+	RoundingModeCeil
+	// RoundingModeNearest rounds to the nearest integer, with halves rounded to even.
+	RoundingModeNearest
+)
+
+// Round rounds the given amount to an integer according to the rounding mode.
+// isExactOut determines the rounding of RoundingModeDefault.
+func (m RoundingMode) Round(amount osmomath.Dec, isExactOut bool) osmomath.Int {
+	switch m {
+	case RoundingModeTruncate:
+		return amount.TruncateInt()
+	case RoundingModeCeil:
+		return amount.Ceil().TruncateInt()
+	case RoundingModeNearest:
+		return amount.RoundInt()
+	default:
+		if isExactOut {
+			return amount.Ceil().TruncateInt()
+		}
+		return amount.TruncateInt()
+	}
 }
 
+// TakerFeeMode defines how the taker fees of the pools in a route are charged
+// when estimating a quote.
+type TakerFeeMode int
+
+const (
+	// TakerFeeModePerHop charges the taker fee of each pool on the amount swapped into that pool.
+	// This matches the on-chain swap behavior and is the default.
+	TakerFeeModePerHop TakerFeeMode = iota
+	// TakerFeeModeInputDeduct deducts the taker fees of all pools in a route from the
+	// input amount once, before the first hop. The pools are then swapped over without charging taker fees.
+	// The deducted fee compounds the per-pool taker fees, i.e. 1 - (1 - takerFee_1) * ... * (1 - takerFee_n).
+	// As a result, the nominal fee rate is the same as with TakerFeeModePerHop. However, the amounts
+	// differ for multi-hop routes since every pool observes the full (fee-deducted) amount in advance
+	// rather than having the fee charged in the intermediate denoms.
+	// This is useful for integrators that charge the fees on transfer of the input token.
+	TakerFeeModeInputDeduct
+)
+
 // DefaultRouterOptions defines the default options for the router
 var DefaultRouterOptions = RouterOptions{}
 
@@ -189,11 +442,27 @@ func WithMaxRoutes(maxRoutes int) RouterOption {
 	}
 }
 
+// WithMaxPoolsConsidered configures the router options with the max pools considered
+// for each denom in the candidate route search.
+func WithMaxPoolsConsidered(maxPoolsConsidered int) RouterOption {
+	return func(o *RouterOptions) {
+		o.MaxPoolsConsidered = maxPoolsConsidered
+	}
+}
+
 // WithDisableSplitRoutes configures the router options with the disabled split routes.
 func WithDisableSplitRoutes() RouterOption {
 	return WithMaxSplitRoutes(DisableSplitRoutes)
 }
 
+// WithSingleRouteOnly configures the router options to return the best single route quote
+// for the request, disabling split routes even if they are enabled server-side.
+// This is useful for latency-sensitive callers as split quote computation is skipped.
+// It is an alias of WithDisableSplitRoutes named after the single route request parameter.
+func WithSingleRouteOnly() RouterOption {
+	return WithMaxSplitRoutes(DisableSplitRoutes)
+}
+
 // WithMaxSplitRoutes configures the router options with the max split routes.
 func WithMaxSplitRoutes(maxSplitRoutes int) RouterOption {
 	return func(o *RouterOptions) {
@@ -217,6 +486,66 @@ func WithCandidateRoutesPoolFiltersAnyOf(filters ...CandidateRoutePoolFiltrerCb)
 	}
 }
 
+// WithRequiredPoolID configures the router options so that the optimal quote
+// is only searched over the routes going through the pool with the given ID.
+// A direct route through the pool is considered in addition to the candidate routes.
+// Caching is disabled so that the restricted routes do not interfere with the unrestricted quotes.
+func WithRequiredPoolID(poolID uint64) RouterOption {
+	return func(o *RouterOptions) {
+		o.RequiredPoolID = poolID
+		o.DisableCache = true
+	}
+}
+
+// WithTakerFeeMode configures the router options with the given taker fee mode.
+// Caching is disabled for any mode other than TakerFeeModePerHop so that the routes
+// ranked under a different fee model do not interfere with the default quotes.
+func WithTakerFeeMode(mode TakerFeeMode) RouterOption {
+	return func(o *RouterOptions) {
+		o.TakerFeeMode = mode
+		if mode != TakerFeeModePerHop {
+			o.DisableCache = true
+		}
+	}
+}
+
+// WithAlternatives configures the router options to return up to n alternative
+// single route quotes alongside the optimal quote.
+// The alternatives are constructed from the routes that are already ranked for the optimal quote,
+// reusing their estimated amounts out.
+func WithAlternatives(n int) RouterOption {
+	return func(o *RouterOptions) {
+		o.Alternatives = n
+	}
+}
+
+// WithRoundingMode configures the router options with the given rounding mode
+// of the fractional quote amounts.
+func WithRoundingMode(mode RoundingMode) RouterOption {
+	return func(o *RouterOptions) {
+		o.RoundingMode = mode
+	}
+}
+
+// WithReferencePrice configures the router options with an external reference price (e.g. from CoinGecko)
+// of the token in denominated in the token out. The quote then reports its price impact relative to
+// the reference price alongside the price impact relative to the internal spot price,
+// surfacing the divergence between the AMM and the market.
+func WithReferencePrice(price osmomath.BigDec) RouterOption {
+	return func(o *RouterOptions) {
+		o.ReferencePrice = price
+	}
+}
+
+// WithDiagnostics configures the router options to return the ranked routes that were filtered out
+// when computing the optimal quote alongside it, each with a reason.
+// Note that on a ranked route cache hit, the routes filtered when the cache entry was written are not reported.
+func WithDiagnostics() RouterOption {
+	return func(o *RouterOptions) {
+		o.Diagnostics = true
+	}
+}
+
 // CandidateRouteSearchDataWorker defines the interface for the candidate route search data worker.
 // It pre-computes data necessary for efficiently computing candidate routes.
 type CandidateRouteSearchDataWorker interface {
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// Tests that each rounding mode rounds the fractional amounts as expected.
+func TestRoundingModeRound(t *testing.T) {
+	var (
+		twoAndHalf   = osmomath.MustNewDecFromStr("2.5")
+		threeAndHalf = osmomath.MustNewDecFromStr("3.5")
+		// The result of splitting 3 into thirds and multiplying one back by 3.
+		almostOne = osmomath.OneDec().QuoInt64(3).MulInt64(3)
+		integer   = osmomath.NewDec(7)
+	)
+
+	tests := []struct {
+		name       string
+		mode       domain.RoundingMode
+		amount     osmomath.Dec
+		isExactOut bool
+
+		expected osmomath.Int
+	}{
+		{"truncate", domain.RoundingModeTruncate, twoAndHalf, false, osmomath.NewInt(2)},
+		{"truncate - exact out", domain.RoundingModeTruncate, twoAndHalf, true, osmomath.NewInt(2)},
+		{"truncate - almost one", domain.RoundingModeTruncate, almostOne, false, osmomath.ZeroInt()},
+		{"ceil", domain.RoundingModeCeil, twoAndHalf, false, osmomath.NewInt(3)},
+		{"ceil - almost one", domain.RoundingModeCeil, almostOne, false, osmomath.OneInt()},
+		{"nearest - half to even down", domain.RoundingModeNearest, twoAndHalf, false, osmomath.NewInt(2)},
+		{"nearest - half to even up", domain.RoundingModeNearest, threeAndHalf, false, osmomath.NewInt(4)},
+		{"nearest - almost one", domain.RoundingModeNearest, almostOne, false, osmomath.OneInt()},
+		{"default - exact in truncates", domain.RoundingModeDefault, twoAndHalf, false, osmomath.NewInt(2)},
+		{"default - exact out rounds up", domain.RoundingModeDefault, twoAndHalf, true, osmomath.NewInt(3)},
+		{"integer is unchanged", domain.RoundingModeCeil, integer, false, osmomath.NewInt(7)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// System under test
+			actual := tt.mode.Round(tt.amount, tt.isExactOut)
+
+			require.Equal(t, tt.expected.String(), actual.String())
+		})
+	}
+}
+
+// Tests that WithRoundingMode configures the rounding mode of the router options.
+func TestWithRoundingMode(t *testing.T) {
+	options := domain.RouterOptions{}
+
+	domain.WithRoundingMode(domain.RoundingModeNearest)(&options)
+
+	require.Equal(t, domain.RoundingModeNearest, options.RoundingMode)
+}
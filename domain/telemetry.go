@@ -131,6 +131,23 @@ var (
 	// counter that measures the number of pricing cache misses
 	SQSPricingCacheMissesCounterMetricName = "sqs_pricing_cache_misses_total"
 
+	// sqs_taker_fees_cache_hits_total
+	//
+	// counter that measures the number of pool taker fees cache hits
+	SQSTakerFeesCacheHitsCounterMetricName = "sqs_taker_fees_cache_hits_total"
+
+	// sqs_taker_fees_cache_misses_total
+	//
+	// counter that measures the number of pool taker fees cache misses
+	SQSTakerFeesCacheMissesCounterMetricName = "sqs_taker_fees_cache_misses_total"
+
+	// sqs_split_considered_total
+	//
+	// counter that measures the number of split quotes computed when estimating the optimal quote
+	// Has the following labels:
+	// * chosen - whether the split quote was chosen over the single route quote
+	SQSSplitConsideredTotalMetricName = "sqs_split_considered_total"
+
 	// sqs_pricing_truncation_total
 	//
 	// counter that measures the number of pricing truncation
@@ -151,6 +168,42 @@ var (
 	// counter that measures the number of pricing coingecko cache misses
 	SQSPricingCoingeckoCacheMissesCounterMetricName = "sqs_pricing_coingecko_cache_misses_total"
 
+	// sqs_pricing_requests_total
+	//
+	// counter that measures the number of price requests made to a pricing source, including fallbacks
+	// Has the following labels:
+	// * source - the pricing source (chain or coingecko)
+	// * quote - the quote denom
+	SQSPricingRequestsTotalMetricName = "sqs_pricing_requests_total"
+
+	// sqs_pricing_failures_total
+	//
+	// counter that measures the number of price requests that failed for a pricing source
+	// Has the following labels:
+	// * source - the pricing source (chain or coingecko)
+	// * quote - the quote denom
+	SQSPricingFailuresTotalMetricName = "sqs_pricing_failures_total"
+
+	// sqs_pricing_precompute_worker_priced_denoms
+	//
+	// gauge that tracks the number of denoms priced in the latest run of the prices precompute worker
+	SQSPricingPrecomputeWorkerPricedDenomsMetricName = "sqs_pricing_precompute_worker_priced_denoms"
+
+	// sqs_pricing_precompute_worker_failed_denoms
+	//
+	// gauge that tracks the number of denoms that failed to be priced in the latest run of the prices precompute worker
+	SQSPricingPrecomputeWorkerFailedDenomsMetricName = "sqs_pricing_precompute_worker_failed_denoms"
+
+	// sqs_routing_nil_cosmwasm_pool_model_total
+	//
+	// counter that measures the number of cosmwasm pools skipped during routing due to a nil cosmwasm pool model
+	SQSRoutingNilCosmWasmPoolModelCounterMetricName = "sqs_routing_nil_cosmwasm_pool_model_total"
+
+	// sqs_cosmwasm_circuit_breaker_opened_total
+	//
+	// counter that measures the number of times the circuit of a generalized cosmwasm pool was opened
+	SQSCosmWasmCircuitBreakerOpenedCounterMetricName = "sqs_cosmwasm_circuit_breaker_opened_total"
+
 	SQSIngestHandlerProcessBlockHeightGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Name: SQSIngestUsecaseProcessBlockHeightMetricName,
@@ -278,6 +331,27 @@ var (
 		},
 	)
 
+	SQSTakerFeesCacheHitsCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSTakerFeesCacheHitsCounterMetricName,
+			Help: "Total number of pool taker fees cache hits",
+		},
+	)
+	SQSTakerFeesCacheMissesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSTakerFeesCacheMissesCounterMetricName,
+			Help: "Total number of pool taker fees cache misses",
+		},
+	)
+
+	SQSSplitConsideredTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSSplitConsideredTotalMetricName,
+			Help: "Total number of split quotes computed when estimating the optimal quote",
+		},
+		[]string{"chosen"},
+	)
+
 	SQSPricingTruncationCounter = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: SQSPricingTruncationCounterMetricName,
@@ -305,6 +379,50 @@ var (
 			Help: "Total number of pricing coingecko cache misses",
 		},
 	)
+
+	SQSPricingRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSPricingRequestsTotalMetricName,
+			Help: "Total number of price requests made to a pricing source",
+		},
+		[]string{"source", "quote"},
+	)
+
+	SQSPricingFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: SQSPricingFailuresTotalMetricName,
+			Help: "Total number of failed price requests made to a pricing source",
+		},
+		[]string{"source", "quote"},
+	)
+
+	SQSPricingPrecomputeWorkerPricedDenomsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSPricingPrecomputeWorkerPricedDenomsMetricName,
+			Help: "gauge that tracks the number of denoms priced in the latest run of the prices precompute worker",
+		},
+	)
+
+	SQSPricingPrecomputeWorkerFailedDenomsGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: SQSPricingPrecomputeWorkerFailedDenomsMetricName,
+			Help: "gauge that tracks the number of denoms that failed to be priced in the latest run of the prices precompute worker",
+		},
+	)
+
+	SQSRoutingNilCosmWasmPoolModelCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSRoutingNilCosmWasmPoolModelCounterMetricName,
+			Help: "Total number of cosmwasm pools skipped during routing due to a nil cosmwasm pool model",
+		},
+	)
+
+	SQSCosmWasmCircuitBreakerOpenedCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: SQSCosmWasmCircuitBreakerOpenedCounterMetricName,
+			Help: "Total number of times the circuit of a generalized cosmwasm pool was opened due to consecutive failures",
+		},
+	)
 )
 
 func init() {
@@ -326,8 +444,17 @@ func init() {
 	prometheus.MustRegister(SQSRoutesCacheWritesCounter)
 	prometheus.MustRegister(SQSPricingCacheHitsCounter)
 	prometheus.MustRegister(SQSPricingCacheMissesCounter)
+	prometheus.MustRegister(SQSTakerFeesCacheHitsCounter)
+	prometheus.MustRegister(SQSTakerFeesCacheMissesCounter)
+	prometheus.MustRegister(SQSSplitConsideredTotal)
 	prometheus.MustRegister(SQSPricingTruncationCounter)
 	prometheus.MustRegister(SQSPricingSpotPriceError)
 	prometheus.MustRegister(SQSPricingCoingeckoCacheHitsCounter)
 	prometheus.MustRegister(SQSPricingCoingeckoCacheMissesCounter)
+	prometheus.MustRegister(SQSPricingRequestsTotal)
+	prometheus.MustRegister(SQSPricingFailuresTotal)
+	prometheus.MustRegister(SQSPricingPrecomputeWorkerPricedDenomsGauge)
+	prometheus.MustRegister(SQSPricingPrecomputeWorkerFailedDenomsGauge)
+	prometheus.MustRegister(SQSRoutingNilCosmWasmPoolModelCounter)
+	prometheus.MustRegister(SQSCosmWasmCircuitBreakerOpenedCounter)
 }
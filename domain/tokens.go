@@ -33,6 +33,36 @@ type PoolDenomMetaData struct {
 	Price osmomath.BigDec `json:"price"`
 }
 
+// LiquidityCapHistoryEntry is the total liquidity capitalization of a denom across all pools
+// as repriced at the given height.
+type LiquidityCapHistoryEntry struct {
+	// Height is the height at which the liquidity capitalization was repriced.
+	Height uint64 `json:"height"`
+	// TotalLiquidityCap represents the total liquidity capitalization across all pools.
+	// @Type string
+	TotalLiquidityCap osmomath.Int `json:"total_liquidity_cap"`
+}
+
+// MarketCap is the market capitalization estimate of a denom.
+type MarketCap struct {
+	// Denom is the chain denom of the token.
+	Denom string `json:"denom"`
+	// QuoteDenom is the chain denom in which the price and the market cap are quoted.
+	QuoteDenom string `json:"quote_denom"`
+	// Price is the price of the token in the quote denom.
+	// @Type string
+	Price osmomath.BigDec `json:"price"`
+	// CirculatingSupply is the circulating supply of the token in human units.
+	// @Type string
+	CirculatingSupply osmomath.BigDec `json:"circulating_supply"`
+	// MarketCap is the price multiplied by the circulating supply.
+	// @Type string
+	MarketCap osmomath.BigDec `json:"market_cap"`
+	// IsBestEffort is true if the circulating supply is not known and the total
+	// liquidity across all pools is used in its place, underestimating the market cap.
+	IsBestEffort bool `json:"is_best_effort"`
+}
+
 // DenomPoolLiquidityMap is a map of denoms to their pool liquidity data.
 type DenomPoolLiquidityMap map[string]DenomPoolLiquidityData
 
@@ -71,3 +101,14 @@ const (
 	// Unknown swap method, used for error handling.
 	TokenSwapMethodInvalid
 )
+
+// TokenMetadataPage is a page of token metadata ordered by human denom.
+type TokenMetadataPage struct {
+	// Tokens are the token metadata within the page.
+	Tokens []Token `json:"tokens"`
+	// Total is the number of tokens matching the filters across all pages.
+	Total int `json:"total"`
+	// NextOffset is the offset to fetch the next page with.
+	// Zero if there are no more tokens.
+	NextOffset int `json:"next_offset"`
+}
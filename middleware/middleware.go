@@ -3,7 +3,9 @@ package middleware
 import (
 	"bytes"
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
 	"sync"
 
 	"time"
@@ -11,6 +13,7 @@ import (
 	"go.uber.org/zap"
 	gotrace "golang.org/x/exp/trace"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/labstack/echo/v4"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/log"
@@ -20,15 +23,25 @@ import (
 
 // GoMiddleware represent the data-struct for middleware
 type GoMiddleware struct {
-	corsConfig         domain.CORSConfig
-	flightRecordConfig domain.FlightRecordConfig
-	logger             log.Logger
+	corsConfig           domain.CORSConfig
+	flightRecordConfig   domain.FlightRecordConfig
+	requestLoggingConfig domain.RequestLoggingConfig
+	logger               log.Logger
 }
 
+const redactedPlaceholder = "[redacted]"
+
 var (
 
 	// flight recorder
 	recordFlightOnce sync.Once
+
+	// addressParamNames are the names of the path and query parameters
+	// containing user addresses that are redacted from the request logs.
+	addressParamNames = map[string]struct{}{
+		"address":         {},
+		"userOsmoAddress": {},
+	}
 )
 
 // CORS will handle the CORS middleware
@@ -42,11 +55,12 @@ func (m *GoMiddleware) CORS(next echo.HandlerFunc) echo.HandlerFunc {
 }
 
 // InitMiddleware initialize the middleware
-func InitMiddleware(corsConfig *domain.CORSConfig, flightRecordConfig *domain.FlightRecordConfig, logger log.Logger) *GoMiddleware {
+func InitMiddleware(corsConfig *domain.CORSConfig, flightRecordConfig *domain.FlightRecordConfig, requestLoggingConfig *domain.RequestLoggingConfig, logger log.Logger) *GoMiddleware {
 	return &GoMiddleware{
-		corsConfig:         *corsConfig,
-		flightRecordConfig: *flightRecordConfig,
-		logger:             logger,
+		corsConfig:           *corsConfig,
+		flightRecordConfig:   *flightRecordConfig,
+		requestLoggingConfig: *requestLoggingConfig,
+		logger:               logger,
 	}
 }
 
@@ -125,3 +139,115 @@ func (m *GoMiddleware) TraceWithParamsMiddleware() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// RequestLoggingMiddleware logs each request with its route, status and latency at the configured level.
+// For quote requests, it additionally logs the token in and out denoms, the order of magnitude
+// of the amount and whether the routes came from cache or were computed. User addresses are redacted from the logged URI.
+func (m *GoMiddleware) RequestLoggingMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	if !m.requestLoggingConfig.Enabled {
+		return next
+	}
+
+	logFn := m.getRequestLogFn()
+
+	return func(c echo.Context) error {
+		start := time.Now()
+
+		// Let the router record whether the quote routes came from cache or were computed.
+		ctx, routeSource := domain.ContextWithRouteSource(c.Request().Context())
+		c.SetRequest(c.Request().WithContext(ctx))
+
+		err := next(c)
+
+		fields := []zap.Field{
+			zap.String("method", c.Request().Method),
+			zap.String("route", c.Path()),
+			zap.String("uri", redactAddresses(c)),
+			zap.Int("status", c.Response().Status),
+			zap.Duration("latency", time.Since(start)),
+		}
+
+		fields = append(fields, getQuoteFields(c)...)
+
+		if source := routeSource.Get(); source != "" {
+			fields = append(fields, zap.String("route_source", source))
+		}
+
+		logFn("request", fields...)
+
+		return err
+	}
+}
+
+// getRequestLogFn returns the logger method corresponding to the configured request logging level.
+// Defaults to debug.
+func (m *GoMiddleware) getRequestLogFn() func(msg string, fields ...zap.Field) {
+	switch m.requestLoggingConfig.Level {
+	case "info":
+		return m.logger.Info
+	case "warn":
+		return m.logger.Warn
+	case "error":
+		return m.logger.Error
+	default:
+		return m.logger.Debug
+	}
+}
+
+// getQuoteFields returns the log fields for the quote parameters of the request, if any.
+// The token in and out denoms are logged for both the exact in and exact out swap methods.
+// The amount is logged as its order of magnitude.
+func getQuoteFields(c echo.Context) []zap.Field {
+	fields := []zap.Field{}
+
+	tokenInStr, tokenOutStr := c.QueryParam("tokenIn"), c.QueryParam("tokenOut")
+
+	// Exact amount in
+	if tokenIn, err := sdk.ParseCoinNormalized(tokenInStr); err == nil {
+		fields = append(fields,
+			zap.String("token_in", tokenIn.Denom),
+			zap.String("token_out", c.QueryParam("tokenOutDenom")),
+			zap.Int("amount_order_of_magnitude", domain.GetPrecomputeOrderOfMagnitude(tokenIn.Amount)),
+		)
+	} else if tokenOut, err := sdk.ParseCoinNormalized(tokenOutStr); err == nil {
+		// Exact amount out
+		fields = append(fields,
+			zap.String("token_in", c.QueryParam("tokenInDenom")),
+			zap.String("token_out", tokenOut.Denom),
+			zap.Int("amount_order_of_magnitude", domain.GetPrecomputeOrderOfMagnitude(tokenOut.Amount)),
+		)
+	}
+
+	return fields
+}
+
+// redactAddresses returns the request URI with the values of the address path and query parameters redacted.
+func redactAddresses(c echo.Context) string {
+	requestURL := c.Request().URL
+
+	path := requestURL.Path
+	for i, paramName := range c.ParamNames() {
+		if _, ok := addressParamNames[paramName]; ok && i < len(c.ParamValues()) && c.ParamValues()[i] != "" {
+			path = strings.ReplaceAll(path, c.ParamValues()[i], redactedPlaceholder)
+		}
+	}
+
+	query := requestURL.Query()
+	for paramName := range addressParamNames {
+		if query.Has(paramName) {
+			query.Set(paramName, redactedPlaceholder)
+		}
+	}
+
+	if len(query) == 0 {
+		return path
+	}
+
+	// Note: the query is unescaped for readability of the logs.
+	rawQuery, err := url.QueryUnescape(query.Encode())
+	if err != nil {
+		rawQuery = query.Encode()
+	}
+
+	return path + "?" + rawQuery
+}
@@ -0,0 +1,207 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/middleware"
+)
+
+// loggedEntry is a log entry recorded by the recordingLogger.
+type loggedEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+// recordingLogger is a logger that records the logged entries.
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []loggedEntry
+}
+
+var _ log.Logger = &recordingLogger{}
+
+func (l *recordingLogger) record(level string, msg string, fields ...zap.Field) {
+	encoder := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		field.AddTo(encoder)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, loggedEntry{level: level, msg: msg, fields: encoder.Fields})
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...zap.Field) { l.record("debug", msg, fields...) }
+func (l *recordingLogger) Info(msg string, fields ...zap.Field)  { l.record("info", msg, fields...) }
+func (l *recordingLogger) Warn(msg string, fields ...zap.Field)  { l.record("warn", msg, fields...) }
+func (l *recordingLogger) Error(msg string, fields ...zap.Field) { l.record("error", msg, fields...) }
+
+func TestRequestLoggingMiddleware(t *testing.T) {
+	const (
+		uosmo = "uosmo"
+		uion  = "uion"
+
+		address = "osmo1ugku28hwyexpljrrmtet05nd6kjlrvr9jz6z00"
+	)
+
+	defaultConfig := domain.RequestLoggingConfig{
+		Enabled: true,
+		Level:   "debug",
+	}
+
+	tests := []struct {
+		name string
+
+		config     domain.RequestLoggingConfig
+		route      string
+		requestURI string
+		// route source recorded by the handler, if any.
+		routeSource string
+
+		expectedLogged bool
+		expectedLevel  string
+		expectedFields map[string]interface{}
+	}{
+		{
+			name: "exact amount in quote",
+
+			config:      defaultConfig,
+			route:       "/router/quote",
+			requestURI:  "/router/quote?tokenIn=12345" + uosmo + "&tokenOutDenom=" + uion,
+			routeSource: domain.RouteSourceCache,
+
+			expectedLogged: true,
+			expectedLevel:  "debug",
+			expectedFields: map[string]interface{}{
+				"method":                    http.MethodGet,
+				"route":                     "/router/quote",
+				"uri":                       "/router/quote?tokenIn=12345" + uosmo + "&tokenOutDenom=" + uion,
+				"status":                    int64(http.StatusOK),
+				"token_in":                  uosmo,
+				"token_out":                 uion,
+				"amount_order_of_magnitude": int64(4),
+				"route_source":              domain.RouteSourceCache,
+			},
+		},
+		{
+			name: "exact amount out quote at info level",
+
+			config: domain.RequestLoggingConfig{
+				Enabled: true,
+				Level:   "info",
+			},
+			route:       "/router/quote",
+			requestURI:  "/router/quote?tokenOut=1000000" + uion + "&tokenInDenom=" + uosmo,
+			routeSource: domain.RouteSourceComputed,
+
+			expectedLogged: true,
+			expectedLevel:  "info",
+			expectedFields: map[string]interface{}{
+				"method":                    http.MethodGet,
+				"route":                     "/router/quote",
+				"uri":                       "/router/quote?tokenInDenom=" + uosmo + "&tokenOut=1000000" + uion,
+				"status":                    int64(http.StatusOK),
+				"token_in":                  uosmo,
+				"token_out":                 uion,
+				"amount_order_of_magnitude": int64(6),
+				"route_source":              domain.RouteSourceComputed,
+			},
+		},
+		{
+			name: "portfolio address is redacted",
+
+			config:     defaultConfig,
+			route:      "/passthrough/portfolio-assets/:address",
+			requestURI: "/passthrough/portfolio-assets/" + address,
+
+			expectedLogged: true,
+			expectedLevel:  "debug",
+			expectedFields: map[string]interface{}{
+				"method": http.MethodGet,
+				"route":  "/passthrough/portfolio-assets/:address",
+				"uri":    "/passthrough/portfolio-assets/[redacted]",
+				"status": int64(http.StatusOK),
+			},
+		},
+		{
+			name: "address query param is redacted",
+
+			config:     defaultConfig,
+			route:      "/passthrough/active-orders",
+			requestURI: "/passthrough/active-orders?userOsmoAddress=" + address,
+
+			expectedLogged: true,
+			expectedLevel:  "debug",
+			expectedFields: map[string]interface{}{
+				"method": http.MethodGet,
+				"route":  "/passthrough/active-orders",
+				"uri":    "/passthrough/active-orders?userOsmoAddress=[redacted]",
+				"status": int64(http.StatusOK),
+			},
+		},
+		{
+			name: "disabled",
+
+			config: domain.RequestLoggingConfig{
+				Enabled: false,
+			},
+			route:      "/router/quote",
+			requestURI: "/router/quote?tokenIn=12345" + uosmo + "&tokenOutDenom=" + uion,
+
+			expectedLogged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &recordingLogger{}
+
+			m := middleware.InitMiddleware(&domain.CORSConfig{}, &domain.FlightRecordConfig{}, &tt.config, logger)
+
+			e := echo.New()
+			e.Use(m.RequestLoggingMiddleware)
+			e.GET(tt.route, func(c echo.Context) error {
+				if tt.routeSource != "" {
+					domain.SetRouteSourceInContext(c.Request().Context(), tt.routeSource)
+				}
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.requestURI, nil)
+			rec := httptest.NewRecorder()
+
+			// System under test
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			if !tt.expectedLogged {
+				require.Empty(t, logger.entries)
+				return
+			}
+
+			require.Len(t, logger.entries, 1)
+			entry := logger.entries[0]
+
+			require.Equal(t, tt.expectedLevel, entry.level)
+			require.Equal(t, "request", entry.msg)
+
+			// Latency is non-deterministic
+			require.Contains(t, entry.fields, "latency")
+			delete(entry.fields, "latency")
+
+			require.Equal(t, tt.expectedFields, entry.fields)
+		})
+	}
+}
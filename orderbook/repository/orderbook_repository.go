@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	orderbookdomain "github.com/osmosis-labs/sqs/domain/orderbook"
+	"github.com/osmosis-labs/sqs/orderbook/telemetry"
 )
 
 type orderbookRepositoryImpl struct {
@@ -52,6 +53,7 @@ func (o *orderbookRepositoryImpl) GetTicks(poolID uint64, tickIDs []int64) (map[
 	o.tickMapByPoolIDLock.RUnlock()
 
 	if !ok {
+		telemetry.TickCacheMissCounter.Add(float64(len(tickIDs)))
 		return nil, fmt.Errorf("ticks for pool %d not found", poolID)
 	}
 
@@ -59,8 +61,10 @@ func (o *orderbookRepositoryImpl) GetTicks(poolID uint64, tickIDs []int64) (map[
 	for _, tickID := range tickIDs {
 		tick, ok := tickMap.Load(tickID)
 		if !ok {
+			telemetry.TickCacheMissCounter.Inc()
 			return nil, fmt.Errorf("tick %d not found", tickID)
 		}
+		telemetry.TickCacheHitCounter.Inc()
 
 		ticksMap[tickID], ok = tick.(orderbookdomain.OrderbookTick)
 		if !ok {
@@ -78,19 +82,24 @@ func (o *orderbookRepositoryImpl) GetTickByID(poolID uint64, tickID int64) (orde
 	o.tickMapByPoolIDLock.RUnlock()
 
 	if !ok {
+		telemetry.TickCacheMissCounter.Inc()
 		return orderbookdomain.OrderbookTick{}, false
 	}
 
 	tickData, ok := tickMap.Load(tickID)
 	if !ok {
+		telemetry.TickCacheMissCounter.Inc()
 		return orderbookdomain.OrderbookTick{}, false
 	}
 
 	tick, ok := tickData.(orderbookdomain.OrderbookTick)
 	if !ok {
+		telemetry.TickCacheMissCounter.Inc()
 		return orderbookdomain.OrderbookTick{}, false
 	}
 
+	telemetry.TickCacheHitCounter.Inc()
+
 	return tick, true
 }
 
@@ -112,3 +121,18 @@ func (o *orderbookRepositoryImpl) StoreTicks(poolID uint64, ticksMap map[int64]o
 	o.tickMapByPoolID[poolID] = tickMap
 	o.tickMapByPoolIDLock.Unlock()
 }
+
+// InvalidateTicks implements orderbookdomain.OrderBookRepository.
+func (o *orderbookRepositoryImpl) InvalidateTicks(poolID uint64, tickIDs []int64) {
+	o.tickMapByPoolIDLock.RLock()
+	tickMap, ok := o.tickMapByPoolID[poolID]
+	o.tickMapByPoolIDLock.RUnlock()
+
+	if !ok {
+		return
+	}
+
+	for _, tickID := range tickIDs {
+		tickMap.Delete(tickID)
+	}
+}
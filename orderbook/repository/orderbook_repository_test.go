@@ -6,8 +6,10 @@ import (
 	"github.com/osmosis-labs/osmosis/osmomath"
 	orderbookdomain "github.com/osmosis-labs/sqs/domain/orderbook"
 	orderbookrepository "github.com/osmosis-labs/sqs/orderbook/repository"
+	"github.com/osmosis-labs/sqs/orderbook/telemetry"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
 	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -132,3 +134,46 @@ func (s *OrderBookUseCaseTestSuite) TestStoreTicks() {
 		})
 	}
 }
+
+func (s *OrderBookUseCaseTestSuite) TestGetTickByID_Cache() {
+	repo := orderbookrepository.New()
+
+	initialHits := testutil.ToFloat64(telemetry.TickCacheHitCounter)
+	initialMisses := testutil.ToFloat64(telemetry.TickCacheMissCounter)
+
+	// Tick is not stored yet
+	_, ok := repo.GetTickByID(defaultPoolID, 1)
+	s.Require().False(ok)
+	s.Require().Equal(initialMisses+1, testutil.ToFloat64(telemetry.TickCacheMissCounter))
+
+	// Ticks updated in the block are stored
+	repo.StoreTicks(defaultPoolID, defaultTicks)
+
+	// Both lookups within the same block hit the cache
+	for i := 0; i < 2; i++ {
+		tick, ok := repo.GetTickByID(defaultPoolID, 1)
+		s.Require().True(ok)
+		s.Require().Equal(defaultTicks[1], tick)
+	}
+	s.Require().Equal(initialHits+2, testutil.ToFloat64(telemetry.TickCacheHitCounter))
+
+	// New block updates tick 1, invalidating it
+	repo.InvalidateTicks(defaultPoolID, []int64{1})
+
+	_, ok = repo.GetTickByID(defaultPoolID, 1)
+	s.Require().False(ok)
+	s.Require().Equal(initialMisses+2, testutil.ToFloat64(telemetry.TickCacheMissCounter))
+
+	// Tick 2 was not updated and is still cached
+	tick, ok := repo.GetTickByID(defaultPoolID, 2)
+	s.Require().True(ok)
+	s.Require().Equal(defaultTicks[2], tick)
+	s.Require().Equal(initialHits+3, testutil.ToFloat64(telemetry.TickCacheHitCounter))
+
+	// Storing the latest state makes the tick available again
+	repo.StoreTicks(defaultPoolID, map[int64]orderbookdomain.OrderbookTick{1: {Tick: withTickID(1)}})
+
+	_, ok = repo.GetTickByID(defaultPoolID, 1)
+	s.Require().True(ok)
+	s.Require().Equal(initialHits+4, testutil.ToFloat64(telemetry.TickCacheHitCounter))
+}
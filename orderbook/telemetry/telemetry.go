@@ -27,6 +27,17 @@ var (
 	// * err - the error message occurred
 	CreateLimitOrderErrorMetricName = "sqs_orderbook_usecase_create_limit_order_error_total"
 
+	// sqs_orderbook_repository_tick_cache_hit_total
+	//
+	// counter that measures the number of ticks found in the orderbook repository tick cache
+	TickCacheHitMetricName = "sqs_orderbook_repository_tick_cache_hit_total"
+
+	// sqs_orderbook_repository_tick_cache_miss_total
+	//
+	// counter that measures the number of ticks not found in the orderbook repository tick cache
+	// either because they were never stored or because they were invalidated.
+	TickCacheMissMetricName = "sqs_orderbook_repository_tick_cache_miss_total"
+
 	ProcessingOrderbookActiveOrdersErrorCounter = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: ProcessingOrderbookActiveOrdersErrorMetricName,
@@ -47,10 +58,25 @@ var (
 			Help: "counter that measures the number errors that occur during creating a limit order orderbook from orderbook order",
 		},
 	)
+	TickCacheHitCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: TickCacheHitMetricName,
+			Help: "counter that measures the number of ticks found in the orderbook repository tick cache",
+		},
+	)
+
+	TickCacheMissCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: TickCacheMissMetricName,
+			Help: "counter that measures the number of ticks not found in the orderbook repository tick cache",
+		},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(ProcessingOrderbookActiveOrdersErrorCounter)
 	prometheus.MustRegister(GetTickByIDNotFoundCounter)
 	prometheus.MustRegister(CreateLimitOrderErrorCounter)
+	prometheus.MustRegister(TickCacheHitCounter)
+	prometheus.MustRegister(TickCacheMissCounter)
 }
@@ -218,3 +218,70 @@ type FailedToGetMetadataError struct {
 func (e FailedToGetMetadataError) Error() string {
 	return fmt.Sprintf("failed to get metadata for token denom: %s: %v", e.TokenDenom, e.Err)
 }
+
+// InvalidActiveOrdersCursorError is returned when the active orders pagination cursor cannot be decoded.
+type InvalidActiveOrdersCursorError struct {
+	Cursor string
+	Err    error
+}
+
+// Error implements the error interface.
+func (e InvalidActiveOrdersCursorError) Error() string {
+	return fmt.Sprintf("invalid active orders cursor %s: %v", e.Cursor, e.Err)
+}
+
+// CanonicalOrderbookNotFoundError is returned when there is no canonical orderbook for the given contract address.
+type CanonicalOrderbookNotFoundError struct {
+	ContractAddress string
+}
+
+// Error implements the error interface.
+func (e CanonicalOrderbookNotFoundError) Error() string {
+	return fmt.Sprintf("canonical orderbook not found for contract: %s", e.ContractAddress)
+}
+
+// OrderNotFoundError is returned when the active order is not found for the given contract, owner and order ID.
+type OrderNotFoundError struct {
+	ContractAddress string
+	OwnerAddress    string
+	OrderID         int64
+}
+
+// Error implements the error interface.
+func (e OrderNotFoundError) Error() string {
+	return fmt.Sprintf("order %d not found for contract: %s and owner: %s", e.OrderID, e.ContractAddress, e.OwnerAddress)
+}
+
+// FailedToGetOrderHistoryError is returned when the retrieval of order history fails.
+type FailedToGetOrderHistoryError struct {
+	ContractAddress string
+	OwnerAddress    string
+	Err             error
+}
+
+// Error implements the error interface.
+func (e FailedToGetOrderHistoryError) Error() string {
+	return fmt.Sprintf("failed to get order history for contract: %s and owner: %s: %v", e.ContractAddress, e.OwnerAddress, e.Err)
+}
+
+// CanonicalOrderbookNotFoundForDenomsError is returned when there is no canonical orderbook for the given base and quote denoms.
+type CanonicalOrderbookNotFoundForDenomsError struct {
+	BaseDenom  string
+	QuoteDenom string
+	Err        error
+}
+
+// Error implements the error interface.
+func (e CanonicalOrderbookNotFoundForDenomsError) Error() string {
+	return fmt.Sprintf("canonical orderbook not found for base %s and quote %s: %v", e.BaseDenom, e.QuoteDenom, e.Err)
+}
+
+// OrderbookEmptyError is returned when the orderbook has no bid or no ask liquidity.
+type OrderbookEmptyError struct {
+	PoolID uint64
+}
+
+// Error implements the error interface.
+func (e OrderbookEmptyError) Error() string {
+	return fmt.Sprintf("orderbook %d has no bid or ask liquidity", e.PoolID)
+}
@@ -13,7 +13,12 @@ func (o *OrderbookUseCaseImpl) SetFetchActiveOrdersEveryDuration(duration time.D
 	fetchActiveOrdersDuration = duration
 }
 
+// SetMaxOrderbookWorkers overrides the maxOrderbookWorkers for testing purposes
+func (o *OrderbookUseCaseImpl) SetMaxOrderbookWorkers(workers int) {
+	maxOrderbookWorkers = workers
+}
+
 // ProcessOrderBookActiveOrders is an alias of processOrderBookActiveOrders for testing purposes
-func (o *OrderbookUseCaseImpl) ProcessOrderBookActiveOrders(ctx context.Context, orderBook domain.CanonicalOrderBooksResult, ownerAddress string) ([]orderbookdomain.LimitOrder, bool, error) {
-	return o.processOrderBookActiveOrders(ctx, orderBook, ownerAddress)
+func (o *OrderbookUseCaseImpl) ProcessOrderBookActiveOrders(ctx context.Context, orderBook domain.CanonicalOrderBooksResult, ownerAddress string, opts ...orderbookdomain.ActiveOrdersOption) ([]orderbookdomain.LimitOrder, bool, error) {
+	return o.processOrderBookActiveOrders(ctx, orderBook, ownerAddress, orderbookdomain.NewActiveOrdersFilter(opts...))
 }
@@ -3,6 +3,7 @@ package orderbookusecase
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -16,9 +17,8 @@ import (
 	"github.com/osmosis-labs/sqs/orderbook/telemetry"
 	"github.com/osmosis-labs/sqs/orderbook/types"
 	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
 	"go.uber.org/zap"
-
-	clmath "github.com/osmosis-labs/osmosis/v26/x/concentrated-liquidity/math"
 )
 
 type OrderbookUseCaseImpl struct {
@@ -60,6 +60,196 @@ func (o *OrderbookUseCaseImpl) GetAllTicks(poolID uint64) (map[int64]orderbookdo
 	return o.orderbookRepository.GetAllTicks(poolID)
 }
 
+// GetOrderbookSpotPrice implements mvc.OrderBookUsecase.
+func (o *OrderbookUseCaseImpl) GetOrderbookSpotPrice(base, quote string) (osmomath.BigDec, error) {
+	// The canonical orderbook may have the given base and quote denoms reversed.
+	bookBase, bookQuote, isReversed := base, quote, false
+
+	poolID, _, err := o.poolsUsecease.GetCanonicalOrderbookPool(bookBase, bookQuote)
+	if err != nil {
+		var reversedErr error
+		poolID, _, reversedErr = o.poolsUsecease.GetCanonicalOrderbookPool(quote, base)
+		if reversedErr != nil {
+			return osmomath.BigDec{}, types.CanonicalOrderbookNotFoundForDenomsError{BaseDenom: base, QuoteDenom: quote, Err: err}
+		}
+
+		bookBase, bookQuote, isReversed = quote, base, true
+	}
+
+	ticks, ok := o.orderbookRepository.GetAllTicks(poolID)
+	if !ok {
+		return osmomath.BigDec{}, types.OrderbookEmptyError{PoolID: poolID}
+	}
+
+	var (
+		bestBidTickID int64
+		bestAskTickID int64
+		hasBid        bool
+		hasAsk        bool
+	)
+
+	for tickID, tick := range ticks {
+		bidLiquidity, err := parseTickLiquidity(tick.TickState.BidValues, "bid_values")
+		if err != nil {
+			return osmomath.BigDec{}, err
+		}
+
+		// Best bid is the highest tick with bid liquidity
+		if bidLiquidity.IsPositive() && (!hasBid || tickID > bestBidTickID) {
+			bestBidTickID = tickID
+			hasBid = true
+		}
+
+		askLiquidity, err := parseTickLiquidity(tick.TickState.AskValues, "ask_values")
+		if err != nil {
+			return osmomath.BigDec{}, err
+		}
+
+		// Best ask is the lowest tick with ask liquidity
+		if askLiquidity.IsPositive() && (!hasAsk || tickID < bestAskTickID) {
+			bestAskTickID = tickID
+			hasAsk = true
+		}
+	}
+
+	if !hasBid || !hasAsk {
+		return osmomath.BigDec{}, types.OrderbookEmptyError{PoolID: poolID}
+	}
+
+	bidPrice, err := orderbookdomain.TickToPrice(bestBidTickID)
+	if err != nil {
+		return osmomath.BigDec{}, types.ConvertingTickToPriceError{TickID: bestBidTickID, Err: err}
+	}
+
+	askPrice, err := orderbookdomain.TickToPrice(bestAskTickID)
+	if err != nil {
+		return osmomath.BigDec{}, types.ConvertingTickToPriceError{TickID: bestAskTickID, Err: err}
+	}
+
+	// Normalize the price by the precision of the orderbook base and quote denoms.
+	scalingFactor, err := o.tokensUsecease.GetSpotPriceScalingFactorByDenom(bookBase, bookQuote)
+	if err != nil {
+		return osmomath.BigDec{}, types.GettingSpotPriceScalingFactorError{BaseDenom: bookBase, QuoteDenom: bookQuote, Err: err}
+	}
+
+	spotPrice := bidPrice.Add(askPrice).QuoInt64(2).MulMut(osmomath.BigDecFromDec(scalingFactor))
+
+	if isReversed {
+		return osmomath.OneBigDec().QuoMut(spotPrice), nil
+	}
+
+	return spotPrice, nil
+}
+
+// GetOrderbookDepth implements mvc.OrderBookUsecase.
+func (o *OrderbookUseCaseImpl) GetOrderbookDepth(ctx context.Context, base, quote string, levels int) (orderbookdomain.OrderbookDepth, bool, error) {
+	poolID, _, err := o.poolsUsecease.GetCanonicalOrderbookPool(base, quote)
+	if err != nil {
+		return orderbookdomain.OrderbookDepth{}, false, types.CanonicalOrderbookNotFoundForDenomsError{BaseDenom: base, QuoteDenom: quote, Err: err}
+	}
+
+	pool, err := o.poolsUsecease.GetPool(poolID)
+	if err != nil {
+		return orderbookdomain.OrderbookDepth{}, false, err
+	}
+
+	cosmWasmPoolModel := pool.GetSQSPoolModel().CosmWasmPoolModel
+	if cosmWasmPoolModel == nil || !cosmWasmPoolModel.IsOrderbook() || cosmWasmPoolModel.Data.Orderbook == nil {
+		return orderbookdomain.OrderbookDepth{}, false, types.NotAnOrderbookPoolError{PoolID: poolID}
+	}
+
+	var (
+		bids         []orderbookdomain.OrderbookDepthLevel
+		asks         []orderbookdomain.OrderbookDepthLevel
+		isBestEffort bool
+	)
+
+	seenTickIDs := make(map[int64]struct{}, len(cosmWasmPoolModel.Data.Orderbook.Ticks))
+	for _, poolTick := range cosmWasmPoolModel.Data.Orderbook.Ticks {
+		tickID := poolTick.TickId
+
+		// Each tick is a single price level. Skip duplicates so that its liquidity is not counted twice.
+		if _, ok := seenTickIDs[tickID]; ok {
+			continue
+		}
+		seenTickIDs[tickID] = struct{}{}
+
+		tick, ok := o.orderbookRepository.GetTickByID(poolID, tickID)
+		if !ok {
+			// The tick state might not be stored yet or was invalidated, skip it.
+			o.logger.Debug("tick not found when computing orderbook depth", zap.Uint64("pool_id", poolID), zap.Int64("tick_id", tickID))
+			isBestEffort = true
+			continue
+		}
+
+		bidLiquidity, err := parseTickLiquidity(tick.TickState.BidValues, "bid_values")
+		if err != nil {
+			return orderbookdomain.OrderbookDepth{}, false, err
+		}
+
+		askLiquidity, err := parseTickLiquidity(tick.TickState.AskValues, "ask_values")
+		if err != nil {
+			return orderbookdomain.OrderbookDepth{}, false, err
+		}
+
+		if !bidLiquidity.IsPositive() && !askLiquidity.IsPositive() {
+			continue
+		}
+
+		price, err := orderbookdomain.TickToPrice(tickID)
+		if err != nil {
+			return orderbookdomain.OrderbookDepth{}, false, types.ConvertingTickToPriceError{TickID: tickID, Err: err}
+		}
+
+		if bidLiquidity.IsPositive() {
+			bids = append(bids, orderbookdomain.OrderbookDepthLevel{TickID: tickID, Price: price, Liquidity: bidLiquidity})
+		}
+
+		if askLiquidity.IsPositive() {
+			asks = append(asks, orderbookdomain.OrderbookDepthLevel{TickID: tickID, Price: price, Liquidity: askLiquidity})
+		}
+	}
+
+	// Best bid is the highest price, best ask is the lowest price.
+	sort.Slice(bids, func(i, j int) bool {
+		return bids[i].TickID > bids[j].TickID
+	})
+	sort.Slice(asks, func(i, j int) bool {
+		return asks[i].TickID < asks[j].TickID
+	})
+
+	return orderbookdomain.OrderbookDepth{
+		PoolID: poolID,
+		Bids:   truncateDepthLevels(bids, levels),
+		Asks:   truncateDepthLevels(asks, levels),
+	}, isBestEffort, nil
+}
+
+// truncateDepthLevels returns up to the given number of the sorted depth levels.
+// Levels of zero or less returns all price levels.
+func truncateDepthLevels(sorted []orderbookdomain.OrderbookDepthLevel, levels int) []orderbookdomain.OrderbookDepthLevel {
+	if levels > 0 && len(sorted) > levels {
+		return sorted[:levels]
+	}
+
+	return sorted
+}
+
+// parseTickLiquidity returns the total amount of liquidity of the given tick values.
+// Empty total amount of liquidity is treated as zero.
+func parseTickLiquidity(values orderbookdomain.TickValues, field string) (osmomath.Dec, error) {
+	if values.TotalAmountOfLiquidity == "" {
+		return osmomath.ZeroDec(), nil
+	}
+
+	liquidity, err := osmomath.NewDecFromStr(values.TotalAmountOfLiquidity)
+	if err != nil {
+		return osmomath.Dec{}, types.ParsingTickValuesError{Field: field, Err: err}
+	}
+
+	return liquidity, nil
+}
+
 // ProcessPool implements mvc.OrderBookUsecase.
 func (o *OrderbookUseCaseImpl) ProcessPool(ctx context.Context, pool sqsdomain.PoolI) error {
 	if pool == nil {
@@ -82,7 +272,36 @@ func (o *OrderbookUseCaseImpl) ProcessPool(ctx context.Context, pool sqsdomain.P
 
 	// Update the orderbook client with the orderbook pool ID.
 	ticks := cosmWasmPoolModel.Data.Orderbook.Ticks
-	if len(ticks) == 0 {
+
+	// The pool is processed once per block that updates it.
+	// Only the ticks updated in this block are fetched, the cached ticks with unchanged liquidity are kept.
+	cachedTicks, _ := o.orderbookRepository.GetAllTicks(poolID)
+
+	updatedTicks := make([]cosmwasmpool.OrderbookTick, 0, len(ticks))
+	currentTickIDs := make(map[int64]struct{}, len(ticks))
+	for _, tick := range ticks {
+		currentTickIDs[tick.TickId] = struct{}{}
+
+		if cachedTick, ok := cachedTicks[tick.TickId]; ok && isTickLiquidityUnchanged(cachedTick, tick) {
+			continue
+		}
+
+		updatedTicks = append(updatedTicks, tick)
+	}
+
+	// Invalidate the cached ticks that were removed from the orderbook in this block.
+	removedTickIDs := make([]int64, 0)
+	for tickID := range cachedTicks {
+		if _, ok := currentTickIDs[tickID]; !ok {
+			removedTickIDs = append(removedTickIDs, tickID)
+		}
+	}
+
+	if len(removedTickIDs) > 0 {
+		o.orderbookRepository.InvalidateTicks(poolID, removedTickIDs)
+	}
+
+	if len(updatedTicks) == 0 {
 		return nil // early return, nothing do
 	}
 
@@ -92,21 +311,54 @@ func (o *OrderbookUseCaseImpl) ProcessPool(ctx context.Context, pool sqsdomain.P
 	}
 
 	// Get tick IDs
-	tickIDs := make([]int64, 0, len(ticks))
-	for _, tick := range ticks {
+	tickIDs := make([]int64, 0, len(updatedTicks))
+	for _, tick := range updatedTicks {
 		tickIDs = append(tickIDs, tick.TickId)
 	}
 
+	tickDataMap, err := o.fetchTickData(ctx, cwModel.ContractAddress, updatedTicks, tickIDs)
+	if err != nil {
+		// The ticks were updated in the new block but their latest state failed to be fetched.
+		// Invalidate them to avoid serving stale data.
+		o.orderbookRepository.InvalidateTicks(poolID, tickIDs)
+		return err
+	}
+
+	// Store the ticks
+	o.orderbookRepository.StoreTicks(poolID, tickDataMap)
+
+	return nil
+}
+
+// isTickLiquidityUnchanged returns true if the liquidity of the given cached tick is equal to the given tick.
+// The tick state and unrealized cancels of a tick with unchanged liquidity do not need to be refetched.
+func isTickLiquidityUnchanged(cachedTick orderbookdomain.OrderbookTick, tick cosmwasmpool.OrderbookTick) bool {
+	if cachedTick.Tick == nil {
+		return false
+	}
+
+	cachedLiquidity, liquidity := cachedTick.Tick.TickLiquidity, tick.TickLiquidity
+	if cachedLiquidity.BidLiquidity.IsNil() || cachedLiquidity.AskLiquidity.IsNil() || liquidity.BidLiquidity.IsNil() || liquidity.AskLiquidity.IsNil() {
+		return false
+	}
+
+	return cachedLiquidity.BidLiquidity.Equal(liquidity.BidLiquidity) && cachedLiquidity.AskLiquidity.Equal(liquidity.AskLiquidity)
+}
+
+// fetchTickData fetches the tick states and unrealized cancels for the given ticks of the orderbook contract.
+// Returns the tick data keyed by tick ID.
+// Errors if fails to fetch tick states or unrealized cancels, or if the fetched tick IDs do not match.
+func (o *OrderbookUseCaseImpl) fetchTickData(ctx context.Context, contractAddress string, ticks []cosmwasmpool.OrderbookTick, tickIDs []int64) (map[int64]orderbookdomain.OrderbookTick, error) {
 	// Fetch tick states
-	tickStates, err := o.orderBookClient.FetchTicks(ctx, maxQueryTicks, cwModel.ContractAddress, tickIDs)
+	tickStates, err := o.orderBookClient.FetchTicks(ctx, maxQueryTicks, contractAddress, tickIDs)
 	if err != nil {
-		return types.FetchTicksError{ContractAddress: cwModel.ContractAddress, Err: err}
+		return nil, types.FetchTicksError{ContractAddress: contractAddress, Err: err}
 	}
 
 	// Fetch unrealized cancels
-	unrealizedCancels, err := o.orderBookClient.FetchTickUnrealizedCancels(ctx, maxQueryTicksCancels, cwModel.ContractAddress, tickIDs)
+	unrealizedCancels, err := o.orderBookClient.FetchTickUnrealizedCancels(ctx, maxQueryTicksCancels, contractAddress, tickIDs)
 	if err != nil {
-		return types.FetchUnrealizedCancelsError{ContractAddress: cwModel.ContractAddress, Err: err}
+		return nil, types.FetchUnrealizedCancelsError{ContractAddress: contractAddress, Err: err}
 	}
 
 	tickDataMap := make(map[int64]orderbookdomain.OrderbookTick, len(ticks))
@@ -115,12 +367,12 @@ func (o *OrderbookUseCaseImpl) ProcessPool(ctx context.Context, pool sqsdomain.P
 
 		// Validate the tick IDs match between the tick and the unrealized cancel
 		if unrealizedCancel.TickID != tick.TickId {
-			return types.TickIDMismatchError{ExpectedID: tick.TickId, ActualID: unrealizedCancel.TickID}
+			return nil, types.TickIDMismatchError{ExpectedID: tick.TickId, ActualID: unrealizedCancel.TickID}
 		}
 
 		tickState := tickStates[i]
 		if tickState.TickID != tick.TickId {
-			return types.TickIDMismatchError{ExpectedID: tick.TickId, ActualID: tickState.TickID}
+			return nil, types.TickIDMismatchError{ExpectedID: tick.TickId, ActualID: tickState.TickID}
 		}
 
 		// Update tick map for the pool
@@ -131,10 +383,7 @@ func (o *OrderbookUseCaseImpl) ProcessPool(ctx context.Context, pool sqsdomain.P
 		}
 	}
 
-	// Store the ticks
-	o.orderbookRepository.StoreTicks(poolID, tickDataMap)
-
-	return nil
+	return tickDataMap, nil
 }
 
 var (
@@ -145,6 +394,10 @@ var (
 	// getActiveOrdersStreamChanLen is the length of the channel for active orders stream
 	// length is arbitrary number selected to avoid blocking
 	getActiveOrdersStreamChanLen = 50
+
+	// maxOrderbookWorkers is the maximum number of canonical orderbooks processed concurrently
+	// when fetching orders for a given address. It bounds the number of concurrent queries to the node.
+	maxOrderbookWorkers = 10
 )
 
 // GetActiveOrdersStream implements mvc.OrderBookUsecase.
@@ -162,30 +415,28 @@ func (o *OrderbookUseCaseImpl) GetActiveOrdersStream(ctx context.Context, addres
 			return
 		}
 
-		for _, orderbook := range orderbooks {
-			go func(orderbook domain.CanonicalOrderBooksResult) {
-				limitOrders, isBestEffort, err := o.processOrderBookActiveOrders(ctx, orderbook, address)
-				if len(limitOrders) == 0 && err == nil {
-					return // skip empty orders
-				}
+		o.processOrderbooksConcurrently(ctx, orderbooks, func(_ int, orderbook domain.CanonicalOrderBooksResult) {
+			limitOrders, isBestEffort, err := o.processOrderBookActiveOrders(ctx, orderbook, address, orderbookdomain.ActiveOrdersFilter{})
+			if len(limitOrders) == 0 && err == nil {
+				return // skip empty orders
+			}
 
-				if err != nil {
-					telemetry.ProcessingOrderbookActiveOrdersErrorCounter.Inc()
-					o.logger.Error(telemetry.ProcessingOrderbookActiveOrdersErrorMetricName, zap.Any("pool_id", orderbook.PoolID), zap.Any("err", err))
-				}
+			if err != nil {
+				telemetry.ProcessingOrderbookActiveOrdersErrorCounter.Inc()
+				o.logger.Error(telemetry.ProcessingOrderbookActiveOrdersErrorMetricName, zap.Any("pool_id", orderbook.PoolID), zap.Any("err", err))
+			}
 
-				select {
-				case c <- orderbookdomain.OrderbookResult{
-					PoolID:       orderbook.PoolID,
-					IsBestEffort: isBestEffort,
-					LimitOrders:  limitOrders,
-					Error:        err,
-				}:
-				case <-ctx.Done():
-					return
-				}
-			}(orderbook)
-		}
+			select {
+			case c <- orderbookdomain.OrderbookResult{
+				PoolID:       orderbook.PoolID,
+				IsBestEffort: isBestEffort,
+				LimitOrders:  limitOrders,
+				Error:        err,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		})
 	}
 
 	// Fetch orders immediately on start
@@ -209,34 +460,198 @@ func (o *OrderbookUseCaseImpl) GetActiveOrdersStream(ctx context.Context, addres
 }
 
 // GetActiveOrders implements mvc.OrderBookUsecase.
-func (o *OrderbookUseCaseImpl) GetActiveOrders(ctx context.Context, address string) ([]orderbookdomain.LimitOrder, bool, error) {
+func (o *OrderbookUseCaseImpl) GetActiveOrders(ctx context.Context, address string, opts ...orderbookdomain.ActiveOrdersOption) (orderbookdomain.ActiveOrdersPage, error) {
+	filter := orderbookdomain.NewActiveOrdersFilter(opts...)
+
+	var after *orderbookdomain.ActiveOrdersCursor
+	if filter.Cursor != "" {
+		decodedCursor, err := orderbookdomain.DecodeActiveOrdersCursor(filter.Cursor)
+		if err != nil {
+			return orderbookdomain.ActiveOrdersPage{}, types.InvalidActiveOrdersCursorError{Cursor: filter.Cursor, Err: err}
+		}
+		after = &decodedCursor
+	}
+
+	orderbookResults, isBestEffort, err := o.getActiveOrdersByOrderbook(ctx, address, filter)
+	if err != nil {
+		return orderbookdomain.ActiveOrdersPage{}, err
+	}
+
+	type poolOrder struct {
+		poolID uint64
+		order  orderbookdomain.LimitOrder
+	}
+
+	orders := []poolOrder{}
+	for _, result := range orderbookResults {
+		for _, order := range result.LimitOrders {
+			if after != nil && !after.IsAfter(result.PoolID, order.OrderId) {
+				continue
+			}
+			orders = append(orders, poolOrder{poolID: result.PoolID, order: order})
+		}
+	}
+
+	// Sort by order ID and then by pool ID for deterministic pagination
+	sort.Slice(orders, func(i, j int) bool {
+		if orders[i].order.OrderId != orders[j].order.OrderId {
+			return orders[i].order.OrderId < orders[j].order.OrderId
+		}
+		return orders[i].poolID < orders[j].poolID
+	})
+
+	var nextCursor string
+	if filter.Limit > 0 && len(orders) > filter.Limit {
+		orders = orders[:filter.Limit]
+
+		last := orders[filter.Limit-1]
+		nextCursor = orderbookdomain.ActiveOrdersCursor{PoolID: last.poolID, OrderID: last.order.OrderId}.Encode()
+	}
+
+	limitOrders := make([]orderbookdomain.LimitOrder, 0, len(orders))
+	for _, order := range orders {
+		limitOrders = append(limitOrders, order.order)
+	}
+
+	return orderbookdomain.ActiveOrdersPage{
+		LimitOrders:  limitOrders,
+		NextCursor:   nextCursor,
+		IsBestEffort: isBestEffort,
+	}, nil
+}
+
+// GetActiveOrder implements mvc.OrderBookUsecase.
+func (o *OrderbookUseCaseImpl) GetActiveOrder(ctx context.Context, ownerAddress, contractAddress string, orderID int64) (orderbookdomain.LimitOrder, error) {
+	orderbooks, err := o.poolsUsecease.GetAllCanonicalOrderbookPoolIDs()
+	if err != nil {
+		return orderbookdomain.LimitOrder{}, types.FailedGetAllCanonicalOrderbookPoolIDsError{Err: err}
+	}
+
+	var orderbook *domain.CanonicalOrderBooksResult
+	for i := range orderbooks {
+		if orderbooks[i].ContractAddress == contractAddress {
+			orderbook = &orderbooks[i]
+			break
+		}
+	}
+
+	if orderbook == nil {
+		return orderbookdomain.LimitOrder{}, types.CanonicalOrderbookNotFoundError{ContractAddress: contractAddress}
+	}
+
+	if err := orderbook.Validate(); err != nil {
+		return orderbookdomain.LimitOrder{}, err
+	}
+
+	orders, _, err := o.orderBookClient.GetActiveOrders(ctx, contractAddress, ownerAddress)
+	if err != nil {
+		return orderbookdomain.LimitOrder{}, types.FailedToGetActiveOrdersError{
+			ContractAddress: contractAddress,
+			OwnerAddress:    ownerAddress,
+			Err:             err,
+		}
+	}
+
+	for _, order := range orders {
+		if order.OrderId != orderID {
+			continue
+		}
+
+		limitOrder, err := o.CreateFormattedLimitOrder(*orderbook, order)
+		if err != nil {
+			telemetry.CreateLimitOrderErrorCounter.Inc()
+			o.logger.Error(telemetry.CreateLimitOrderErrorMetricName, zap.Any("order", order), zap.Any("err", err))
+			return orderbookdomain.LimitOrder{}, err
+		}
+
+		return limitOrder, nil
+	}
+
+	return orderbookdomain.LimitOrder{}, types.OrderNotFoundError{
+		ContractAddress: contractAddress,
+		OwnerAddress:    ownerAddress,
+		OrderID:         orderID,
+	}
+}
+
+// getActiveOrdersByOrderbook concurrently fetches and processes the active orders for the given address
+// across all canonical orderbooks. Only the orders matching the filter are returned.
+// See getOrdersByOrderbook for details.
+func (o *OrderbookUseCaseImpl) getActiveOrdersByOrderbook(ctx context.Context, address string, filter orderbookdomain.ActiveOrdersFilter) ([]orderbookdomain.OrderbookResult, bool, error) {
+	return o.getOrdersByOrderbook(ctx, func(ctx context.Context, orderbook domain.CanonicalOrderBooksResult) ([]orderbookdomain.LimitOrder, bool, error) {
+		return o.processOrderBookActiveOrders(ctx, orderbook, address, filter)
+	})
+}
+
+// processOrderbooksConcurrently calls process for every given orderbook in the background
+// with at most maxOrderbookWorkers orderbooks processed at a time.
+// It returns immediately. No further orderbooks are dispatched once the context is done.
+func (o *OrderbookUseCaseImpl) processOrderbooksConcurrently(ctx context.Context, orderbooks []domain.CanonicalOrderBooksResult, process func(i int, orderbook domain.CanonicalOrderBooksResult)) {
+	go func() {
+		workers := make(chan struct{}, maxOrderbookWorkers)
+		for i, orderbook := range orderbooks {
+			select {
+			case workers <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(i int, orderbook domain.CanonicalOrderBooksResult) {
+				defer func() { <-workers }()
+
+				process(i, orderbook)
+			}(i, orderbook)
+		}
+	}()
+}
+
+// getOrdersByOrderbook concurrently processes the orders across all canonical orderbooks
+// using the given processing function with at most maxOrderbookWorkers orderbooks processed at a time.
+// Returns the results per orderbook in the order of canonical orderbooks and whether any of the orderbooks was processed with best effort.
+// Errors from processing individual orderbooks are logged and skipped.
+// Returns error if fails to get canonical orderbooks or if context is done before all orderbooks are processed.
+func (o *OrderbookUseCaseImpl) getOrdersByOrderbook(ctx context.Context, processOrderbook func(ctx context.Context, orderbook domain.CanonicalOrderBooksResult) ([]orderbookdomain.LimitOrder, bool, error)) ([]orderbookdomain.OrderbookResult, bool, error) {
 	orderbooks, err := o.poolsUsecease.GetAllCanonicalOrderbookPoolIDs()
 	if err != nil {
 		return nil, false, types.FailedGetAllCanonicalOrderbookPoolIDsError{Err: err}
 	}
 
-	results := make(chan orderbookdomain.OrderbookResult, len(orderbooks))
+	type indexedOrderbookResult struct {
+		index  int
+		result orderbookdomain.OrderbookResult
+	}
+
+	// Buffered to never block the workers, even if the results are no longer collected
+	// due to context cancellation.
+	results := make(chan indexedOrderbookResult, len(orderbooks))
 
-	// Process orderbooks concurrently
-	for _, orderbook := range orderbooks {
-		go func(orderbook domain.CanonicalOrderBooksResult) {
-			limitOrders, isBestEffort, err := o.processOrderBookActiveOrders(ctx, orderbook, address)
-			results <- orderbookdomain.OrderbookResult{
+	o.processOrderbooksConcurrently(ctx, orderbooks, func(i int, orderbook domain.CanonicalOrderBooksResult) {
+		limitOrders, isBestEffort, err := processOrderbook(ctx, orderbook)
+		results <- indexedOrderbookResult{
+			index: i,
+			result: orderbookdomain.OrderbookResult{
 				IsBestEffort: isBestEffort,
 				PoolID:       orderbook.PoolID,
 				LimitOrders:  limitOrders,
 				Error:        err,
-			}
-		}(orderbook)
-	}
+			},
+		}
+	})
 
-	// Collect results
-	finalResults := []orderbookdomain.LimitOrder{}
+	// Collect results in the order of canonical orderbooks
+	// so that the final result is deterministic regardless of the completion order.
+	finalResults := make([]orderbookdomain.OrderbookResult, len(orderbooks))
 	isBestEffort := false
 
 	for i := 0; i < len(orderbooks); i++ {
+		// Prioritize cancellation over the results that might be ready
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
 		select {
-		case result := <-results:
+		case indexedResult := <-results:
+			result := indexedResult.result
 			if result.Error != nil {
 				telemetry.ProcessingOrderbookActiveOrdersErrorCounter.Inc()
 				o.logger.Error(telemetry.ProcessingOrderbookActiveOrdersErrorMetricName, zap.Any("pool_id", result.PoolID), zap.Any("err", result.Error))
@@ -244,7 +659,7 @@ func (o *OrderbookUseCaseImpl) GetActiveOrders(ctx context.Context, address stri
 
 			isBestEffort = isBestEffort || result.IsBestEffort
 
-			finalResults = append(finalResults, result.LimitOrders...)
+			finalResults[indexedResult.index] = result
 		case <-ctx.Done():
 			return nil, false, ctx.Err()
 		}
@@ -253,8 +668,83 @@ func (o *OrderbookUseCaseImpl) GetActiveOrders(ctx context.Context, address stri
 	return finalResults, isBestEffort, nil
 }
 
+// GetOrderHistory implements mvc.OrderBookUsecase.
+func (o *OrderbookUseCaseImpl) GetOrderHistory(ctx context.Context, ownerAddress string) ([]orderbookdomain.LimitOrder, bool, error) {
+	orderbookResults, isBestEffort, err := o.getOrdersByOrderbook(ctx, func(ctx context.Context, orderbook domain.CanonicalOrderBooksResult) ([]orderbookdomain.LimitOrder, bool, error) {
+		return o.processOrderBookOrderHistory(ctx, orderbook, ownerAddress)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	finalResults := []orderbookdomain.LimitOrder{}
+	for _, result := range orderbookResults {
+		finalResults = append(finalResults, result.LimitOrders...)
+	}
+
+	return finalResults, isBestEffort, nil
+}
+
+// processOrderBookOrderHistory fetches and processes the claimed or cancelled orders for a given orderbook.
+// It returns the formatted limit orders with the status set to their terminal state and an error if any.
+// Errors if:
+// - failed to fetch order history
+//
+// For every order, if an error occurs processing the order, it is skipped rather than failing the entire process.
+// This is a best-effort process.
+func (o *OrderbookUseCaseImpl) processOrderBookOrderHistory(ctx context.Context, orderbook domain.CanonicalOrderBooksResult, ownerAddress string) ([]orderbookdomain.LimitOrder, bool, error) {
+	if err := orderbook.Validate(); err != nil {
+		return nil, false, err
+	}
+
+	orders, err := o.orderBookClient.GetOrderHistory(ctx, orderbook.ContractAddress, ownerAddress)
+	if err != nil {
+		return nil, false, types.FailedToGetOrderHistoryError{
+			ContractAddress: orderbook.ContractAddress,
+			OwnerAddress:    ownerAddress,
+			Err:             err,
+		}
+	}
+
+	results := make([]orderbookdomain.LimitOrder, 0, len(orders))
+
+	isBestEffort := false
+
+	// All orders share the same base/quote pair, fetch the scaling factor once
+	getScalingFactor := o.newMemoizedScalingFactorGetter()
+
+	for _, order := range orders {
+		status, err := order.Status()
+		if err != nil {
+			telemetry.CreateLimitOrderErrorCounter.Inc()
+			o.logger.Error(telemetry.CreateLimitOrderErrorMetricName, zap.Any("order", order), zap.Any("err", err))
+
+			isBestEffort = true
+
+			continue
+		}
+
+		result, err := o.createFormattedLimitOrder(orderbook, order.Order, getScalingFactor)
+		if err != nil {
+			telemetry.CreateLimitOrderErrorCounter.Inc()
+			o.logger.Error(telemetry.CreateLimitOrderErrorMetricName, zap.Any("order", order), zap.Any("err", err))
+
+			isBestEffort = true
+
+			continue
+		}
+
+		// Historical orders are in terminal state regardless of the computed fill progress
+		result.Status = status
+
+		results = append(results, result)
+	}
+
+	return results, isBestEffort, nil
+}
+
 // processOrderBookActiveOrders fetches and processes the active orders for a given orderbook.
-// It returns the active formatted limit orders and an error if any.
+// It returns the active formatted limit orders matching the filter and an error if any.
 // Errors if:
 // - failed to fetch active orders
 // - failed to fetch metadata by chain denom
@@ -262,14 +752,30 @@ func (o *OrderbookUseCaseImpl) GetActiveOrders(ctx context.Context, address stri
 //
 // For every order, if an error occurs processing the order, it is skipped rather than failing the entire process.
 // This is a best-effort process.
-func (o *OrderbookUseCaseImpl) processOrderBookActiveOrders(ctx context.Context, orderbook domain.CanonicalOrderBooksResult, ownerAddress string) ([]orderbookdomain.LimitOrder, bool, error) {
-	if err := orderbook.Validate(); err != nil {
+func (o *OrderbookUseCaseImpl) processOrderBookActiveOrders(ctx context.Context, orderbook domain.CanonicalOrderBooksResult, ownerAddress string, filter orderbookdomain.ActiveOrdersFilter) ([]orderbookdomain.LimitOrder, bool, error) {
+	results, orderErrors, err := o.processOrderBookActiveOrdersVerbose(ctx, orderbook, ownerAddress, filter)
+	if err != nil {
 		return nil, false, err
 	}
 
+	return results, len(orderErrors) > 0, nil
+}
+
+// ProcessOrderBookActiveOrdersVerbose implements mvc.OrderBookUsecase.
+func (o *OrderbookUseCaseImpl) ProcessOrderBookActiveOrdersVerbose(ctx context.Context, orderbook domain.CanonicalOrderBooksResult, ownerAddress string) ([]orderbookdomain.LimitOrder, []orderbookdomain.OrderProcessingError, error) {
+	return o.processOrderBookActiveOrdersVerbose(ctx, orderbook, ownerAddress, orderbookdomain.ActiveOrdersFilter{})
+}
+
+// processOrderBookActiveOrdersVerbose is the same as processOrderBookActiveOrders but instead of
+// the best effort flag, it returns the errors for every order that was skipped.
+func (o *OrderbookUseCaseImpl) processOrderBookActiveOrdersVerbose(ctx context.Context, orderbook domain.CanonicalOrderBooksResult, ownerAddress string, filter orderbookdomain.ActiveOrdersFilter) ([]orderbookdomain.LimitOrder, []orderbookdomain.OrderProcessingError, error) {
+	if err := orderbook.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	orders, count, err := o.orderBookClient.GetActiveOrders(ctx, orderbook.ContractAddress, ownerAddress)
 	if err != nil {
-		return nil, false, types.FailedToGetActiveOrdersError{
+		return nil, nil, types.FailedToGetActiveOrdersError{
 			ContractAddress: orderbook.ContractAddress,
 			OwnerAddress:    ownerAddress,
 			Err:             err,
@@ -278,43 +784,89 @@ func (o *OrderbookUseCaseImpl) processOrderBookActiveOrders(ctx context.Context,
 
 	// There are orders to process for given orderbook
 	if count == 0 {
-		return nil, false, nil
+		return nil, nil, nil
 	}
 
 	// Create a slice to store the results
 	results := make([]orderbookdomain.LimitOrder, 0, len(orders))
 
-	// If we encounter
-	isBestEffort := false
+	// Errors of the orders that were skipped
+	var orderErrors []orderbookdomain.OrderProcessingError
+
+	// All orders share the same base/quote pair, fetch the scaling factor once
+	getScalingFactor := o.newMemoizedScalingFactorGetter()
 
 	// For each order, create a formatted limit order
 	for _, order := range orders {
 		// create limit order
-		result, err := o.CreateFormattedLimitOrder(
+		result, err := o.createFormattedLimitOrder(
 			orderbook,
 			order,
+			getScalingFactor,
 		)
 		if err != nil {
 			telemetry.CreateLimitOrderErrorCounter.Inc()
 			o.logger.Error(telemetry.CreateLimitOrderErrorMetricName, zap.Any("order", order), zap.Any("err", err))
 
-			isBestEffort = true
+			orderErrors = append(orderErrors, orderbookdomain.OrderProcessingError{
+				OrderID: order.OrderId,
+				Err:     err,
+			})
+
+			continue
+		}
 
+		// filter is applied on the formatted order since
+		// the status is derived from the computed fill percentage
+		if !filter.Matches(result) {
 			continue
 		}
 
 		results = append(results, result)
 	}
 
-	return results, isBestEffort, nil
+	return results, orderErrors, nil
 }
 
 // ZeroDec is a zero decimal value.
 // It is defined in a global space to avoid creating a new instance every time.
 var zeroDec = osmomath.ZeroDec()
 
+// scalingFactorGetter returns the spot price scaling factor for the given base and quote denoms.
+type scalingFactorGetter func(baseDenom, quoteDenom string) (osmomath.Dec, error)
+
+// newMemoizedScalingFactorGetter returns a scalingFactorGetter that fetches the scaling factor
+// once per base/quote pair and returns the memoized result, including the error, on subsequent calls.
+// It is meant to be used within a single request and is not safe for concurrent use.
+func (o *OrderbookUseCaseImpl) newMemoizedScalingFactorGetter() scalingFactorGetter {
+	type scalingFactorResult struct {
+		scalingFactor osmomath.Dec
+		err           error
+	}
+
+	memo := map[string]scalingFactorResult{}
+
+	return func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+		key := baseDenom + "/" + quoteDenom
+		if result, ok := memo[key]; ok {
+			return result.scalingFactor, result.err
+		}
+
+		scalingFactor, err := o.tokensUsecease.GetSpotPriceScalingFactorByDenom(baseDenom, quoteDenom)
+		memo[key] = scalingFactorResult{scalingFactor: scalingFactor, err: err}
+
+		return scalingFactor, err
+	}
+}
+
 // CreateFormattedLimitOrder creates a limit order from the orderbook order.
 func (o *OrderbookUseCaseImpl) CreateFormattedLimitOrder(orderbook domain.CanonicalOrderBooksResult, order orderbookdomain.Order) (orderbookdomain.LimitOrder, error) {
+	return o.createFormattedLimitOrder(orderbook, order, o.tokensUsecease.GetSpotPriceScalingFactorByDenom)
+}
+
+// createFormattedLimitOrder creates a limit order from the orderbook order
+// using the given getter for the spot price scaling factor.
+func (o *OrderbookUseCaseImpl) createFormattedLimitOrder(orderbook domain.CanonicalOrderBooksResult, order orderbookdomain.Order, getScalingFactor scalingFactorGetter) (orderbookdomain.LimitOrder, error) {
 	quoteToken, err := o.tokensUsecease.GetMetadataByChainDenom(orderbook.Quote)
 	if err != nil {
 		return orderbookdomain.LimitOrder{}, types.FailedToGetMetadataError{
@@ -377,7 +929,7 @@ func (o *OrderbookUseCaseImpl) CreateFormattedLimitOrder(orderbook domain.Canoni
 	percentClaimed := placedQuantity.Sub(quantity).Quo(placedQuantity)
 
 	// Calculate normalization factor for price
-	normalizationFactor, err := o.tokensUsecease.GetSpotPriceScalingFactorByDenom(baseAsset.Symbol, quoteAsset.Symbol)
+	normalizationFactor, err := getScalingFactor(baseAsset.Symbol, quoteAsset.Symbol)
 	if err != nil {
 		return orderbookdomain.LimitOrder{}, types.GettingSpotPriceScalingFactorError{
 			BaseDenom:  baseAsset.Symbol,
@@ -453,7 +1005,7 @@ func (o *OrderbookUseCaseImpl) CreateFormattedLimitOrder(orderbook domain.Canoni
 	}
 
 	// Calculate price based on tick ID
-	price, err := clmath.TickToPrice(order.TickId)
+	price, err := orderbookdomain.TickToPrice(order.TickId)
 	if err != nil {
 		return orderbookdomain.LimitOrder{}, types.ConvertingTickToPriceError{TickID: order.TickId, Err: err}
 	}
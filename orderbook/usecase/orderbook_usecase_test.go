@@ -3,8 +3,10 @@ package orderbookusecase_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -21,6 +23,7 @@ import (
 	"github.com/osmosis-labs/sqs/domain/mocks"
 	orderbookdomain "github.com/osmosis-labs/sqs/domain/orderbook"
 	orderbookgrpcclientdomain "github.com/osmosis-labs/sqs/domain/orderbook/grpcclient"
+	orderbookrepository "github.com/osmosis-labs/sqs/orderbook/repository"
 	"github.com/osmosis-labs/sqs/orderbook/types"
 	orderbookusecase "github.com/osmosis-labs/sqs/orderbook/usecase"
 	"github.com/osmosis-labs/sqs/orderbook/usecase/orderbooktesting"
@@ -51,6 +54,12 @@ func (s *OrderbookUsecaseTestSuite) getTickByIDFunc(tick orderbookdomain.Orderbo
 	}
 }
 
+func (s *OrderbookUsecaseTestSuite) getOrderHistoryFunc(orders ...orderbookdomain.HistoricalOrder) func(ctx context.Context, contractAddress string, ownerAddress string) ([]orderbookdomain.HistoricalOrder, error) {
+	return func(ctx context.Context, contractAddress string, ownerAddress string) ([]orderbookdomain.HistoricalOrder, error) {
+		return orders, nil
+	}
+}
+
 func (s *OrderbookUsecaseTestSuite) TestProcessPool() {
 	withContractInfo := func(pool *mocks.MockRoutablePool) *mocks.MockRoutablePool {
 		pool.CosmWasmPoolModel.ContractInfo = cosmwasmpool.ContractInfo{
@@ -94,6 +103,8 @@ func (s *OrderbookUsecaseTestSuite) TestProcessPool() {
 		pool          sqsdomain.PoolI
 		setupMocks    func(usecase *orderbookusecase.OrderbookUseCaseImpl, client *mocks.OrderbookGRPCClientMock, repository *mocks.OrderbookRepositoryMock)
 		expectedError error
+
+		expectedInvalidatedTickIDs []int64
 	}{
 		{
 			name:          "pool is nil",
@@ -137,6 +148,8 @@ func (s *OrderbookUsecaseTestSuite) TestProcessPool() {
 				}
 			},
 			expectedError: &types.FetchTicksError{},
+
+			expectedInvalidatedTickIDs: []int64{1},
 		},
 		{
 			name: "failed to fetch unrealized cancels for pool",
@@ -147,6 +160,8 @@ func (s *OrderbookUsecaseTestSuite) TestProcessPool() {
 				}
 			},
 			expectedError: &types.FetchUnrealizedCancelsError{},
+
+			expectedInvalidatedTickIDs: []int64{1},
 		},
 		{
 			name: "tick ID mismatch when fetching unrealized ticks",
@@ -164,6 +179,8 @@ func (s *OrderbookUsecaseTestSuite) TestProcessPool() {
 				}
 			},
 			expectedError: &types.TickIDMismatchError{},
+
+			expectedInvalidatedTickIDs: []int64{1},
 		},
 		{
 			name: "tick ID mismatch when fetching tick states",
@@ -181,6 +198,8 @@ func (s *OrderbookUsecaseTestSuite) TestProcessPool() {
 				}
 			},
 			expectedError: &types.TickIDMismatchError{},
+
+			expectedInvalidatedTickIDs: []int64{1},
 		},
 		{
 			name: "successful pool processing",
@@ -220,6 +239,17 @@ func (s *OrderbookUsecaseTestSuite) TestProcessPool() {
 			tokensusecase := mocks.TokensUsecaseMock{}
 			client := mocks.OrderbookGRPCClientMock{}
 
+			// No ticks are cached by default
+			repository.GetAllTicksFunc = func(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool) {
+				return nil, false
+			}
+
+			// Track the invalidated ticks
+			var invalidatedTickIDs []int64
+			repository.InvalidateTicksFunc = func(poolID uint64, tickIDs []int64) {
+				invalidatedTickIDs = append(invalidatedTickIDs, tickIDs...)
+			}
+
 			// Setup the mocks according to the test case
 			usecase := orderbookusecase.New(&repository, &client, nil, &tokensusecase, nil)
 			if tc.setupMocks != nil {
@@ -236,9 +266,109 @@ func (s *OrderbookUsecaseTestSuite) TestProcessPool() {
 			} else {
 				s.Assert().NoError(err)
 			}
+
+			// Ticks that failed to be fetched must be invalidated
+			s.Assert().Equal(tc.expectedInvalidatedTickIDs, invalidatedTickIDs)
 		})
 	}
 }
+
+// TestProcessPool_TickCache validates that processing the pool in a new block only fetches the updated ticks
+// and invalidates the removed ones while the unchanged ticks are served from the cache.
+func (s *OrderbookUsecaseTestSuite) TestProcessPool_TickCache() {
+	const poolID = uint64(1)
+
+	newTick := func(tickID int64, bidLiquidity int64) cosmwasmpool.OrderbookTick {
+		return cosmwasmpool.OrderbookTick{
+			TickId: tickID,
+			TickLiquidity: cosmwasmpool.OrderbookTickLiquidity{
+				BidLiquidity: osmomath.NewBigDec(bidLiquidity),
+				AskLiquidity: osmomath.ZeroBigDec(),
+			},
+		}
+	}
+
+	newPool := func(ticks ...cosmwasmpool.OrderbookTick) *mocks.MockRoutablePool {
+		return &mocks.MockRoutablePool{
+			ID:             poolID,
+			ChainPoolModel: &cwpoolmodel.CosmWasmPool{},
+			CosmWasmPoolModel: &cosmwasmpool.CosmWasmPoolModel{
+				ContractInfo: cosmwasmpool.ContractInfo{
+					Contract: cosmwasmpool.ORDERBOOK_CONTRACT_NAME,
+					Version:  cosmwasmpool.ORDERBOOK_MIN_CONTRACT_VERSION,
+				},
+				Data: cosmwasmpool.CosmWasmPoolData{
+					Orderbook: &cosmwasmpool.OrderbookData{
+						Ticks: ticks,
+					},
+				},
+			},
+		}
+	}
+
+	// The fetched tick state records the block height it was fetched at.
+	var (
+		blockHeight    int
+		fetchedTickIDs []int64
+	)
+
+	client := mocks.OrderbookGRPCClientMock{
+		FetchTicksCb: func(ctx context.Context, chunkSize int, contractAddress string, tickIDs []int64) ([]orderbookdomain.Tick, error) {
+			fetchedTickIDs = append(fetchedTickIDs, tickIDs...)
+
+			ticks := make([]orderbookdomain.Tick, 0, len(tickIDs))
+			for _, tickID := range tickIDs {
+				ticks = append(ticks, orderbookdomain.Tick{TickID: tickID, TickState: orderbookdomain.TickState{
+					BidValues: orderbookdomain.TickValues{
+						EffectiveTotalAmountSwapped: fmt.Sprint(blockHeight),
+					},
+				}})
+			}
+			return ticks, nil
+		},
+		FetchTickUnrealizedCancelsCb: func(ctx context.Context, chunkSize int, contractAddress string, tickIDs []int64) ([]orderbookgrpcclientdomain.UnrealizedTickCancels, error) {
+			cancels := make([]orderbookgrpcclientdomain.UnrealizedTickCancels, 0, len(tickIDs))
+			for _, tickID := range tickIDs {
+				cancels = append(cancels, orderbookgrpcclientdomain.UnrealizedTickCancels{TickID: tickID})
+			}
+			return cancels, nil
+		},
+	}
+
+	repository := orderbookrepository.New()
+	usecase := orderbookusecase.New(repository, &client, nil, &mocks.TokensUsecaseMock{}, nil)
+
+	// Block 1: all ticks are fetched.
+	blockHeight = 1
+	err := usecase.ProcessPool(context.Background(), newPool(newTick(1, 100), newTick(2, 200), newTick(3, 300)))
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]int64{1, 2, 3}, fetchedTickIDs)
+
+	// Block 2: tick 1 is unchanged, tick 2 is updated, tick 3 is removed and tick 4 is added.
+	blockHeight = 2
+	fetchedTickIDs = nil
+	err = usecase.ProcessPool(context.Background(), newPool(newTick(1, 100), newTick(2, 150), newTick(4, 400)))
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]int64{2, 4}, fetchedTickIDs)
+
+	expectedFetchedAtHeight := map[int64]string{1: "1", 2: "2", 4: "2"}
+	for tickID, expectedHeight := range expectedFetchedAtHeight {
+		tick, ok := repository.GetTickByID(poolID, tickID)
+		s.Require().True(ok)
+		s.Require().Equal(expectedHeight, tick.TickState.BidValues.EffectiveTotalAmountSwapped)
+	}
+
+	_, ok := repository.GetTickByID(poolID, 3)
+	s.Require().False(ok)
+
+	// Block 3: nothing changed, no ticks are fetched.
+	blockHeight = 3
+	fetchedTickIDs = nil
+	err = usecase.ProcessPool(context.Background(), newPool(newTick(1, 100), newTick(2, 150), newTick(4, 400)))
+	s.Require().NoError(err)
+	s.Require().Empty(fetchedTickIDs)
+}
+
 func (s *OrderbookUsecaseTestSuite) TestGetActiveOrdersStream() {
 	testCases := []struct {
 		name               string
@@ -255,7 +385,7 @@ func (s *OrderbookUsecaseTestSuite) TestGetActiveOrdersStream() {
 			name:    "failed to get all canonical orderbook pool IDs",
 			address: "osmo1glq2duq5f4x3m88fqwecfrfcuauy8343amy5fm",
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock, callcount *int) {
-				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = func() ([]domain.CanonicalOrderBooksResult, error) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = func(opts ...domain.CanonicalOrderbooksOption) ([]domain.CanonicalOrderBooksResult, error) {
 					return nil, assert.AnError
 				}
 			},
@@ -449,6 +579,7 @@ func (s *OrderbookUsecaseTestSuite) TestGetActiveOrders() {
 		setupContext         func() context.Context
 		setupMocks           func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock)
 		address              string
+		opts                 []orderbookdomain.ActiveOrdersOption
 		expectedError        error
 		expectedOrders       []orderbookdomain.LimitOrder
 		expectedIsBestEffort bool
@@ -459,7 +590,7 @@ func (s *OrderbookUsecaseTestSuite) TestGetActiveOrders() {
 				return context.Background()
 			},
 			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
-				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = func() ([]domain.CanonicalOrderBooksResult, error) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = func(opts ...domain.CanonicalOrderbooksOption) ([]domain.CanonicalOrderBooksResult, error) {
 					return nil, assert.AnError
 				}
 			},
@@ -474,7 +605,7 @@ func (s *OrderbookUsecaseTestSuite) TestGetActiveOrders() {
 				return ctx
 			},
 			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
-				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = func() ([]domain.CanonicalOrderBooksResult, error) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = func(opts ...domain.CanonicalOrderbooksOption) ([]domain.CanonicalOrderBooksResult, error) {
 					return []domain.CanonicalOrderBooksResult{
 						{PoolID: 1},
 					}, nil
@@ -567,6 +698,115 @@ func (s *OrderbookUsecaseTestSuite) TestGetActiveOrders() {
 			},
 			expectedIsBestEffort: false,
 		},
+		{
+			name: "direction filter: bid orders match the bid filter -> all orders are returned",
+			setupContext: func() context.Context {
+				return context.Background()
+			},
+			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, s.NewCanonicalOrderBooksResult(1, "A"))
+
+				grpcclient.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
+					s.NewOrder().WithOrderID(1).Order,
+					s.NewOrder().WithOrderID(2).Order,
+				}, 2, nil)
+
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+			},
+			address:       "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299",
+			opts:          []orderbookdomain.ActiveOrdersOption{orderbookdomain.WithOrderDirection("bid")},
+			expectedError: nil,
+			expectedOrders: []orderbookdomain.LimitOrder{
+				s.NewLimitOrder().WithOrderID(1).WithOrderbookAddress("A").LimitOrder,
+				s.NewLimitOrder().WithOrderID(2).WithOrderbookAddress("A").LimitOrder,
+			},
+			expectedIsBestEffort: false,
+		},
+		{
+			name: "direction filter: bid orders do not match the ask filter -> no orders are returned",
+			setupContext: func() context.Context {
+				return context.Background()
+			},
+			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, s.NewCanonicalOrderBooksResult(1, "A"))
+
+				grpcclient.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
+					s.NewOrder().WithOrderID(1).Order,
+					s.NewOrder().WithOrderID(2).Order,
+				}, 2, nil)
+
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+			},
+			address:              "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299",
+			opts:                 []orderbookdomain.ActiveOrdersOption{orderbookdomain.WithOrderDirection("ask")},
+			expectedError:        nil,
+			expectedOrders:       []orderbookdomain.LimitOrder{},
+			expectedIsBestEffort: false,
+		},
+		{
+			name: "status filter: partially filled orders do not match the open filter -> no orders are returned",
+			setupContext: func() context.Context {
+				return context.Background()
+			},
+			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, s.NewCanonicalOrderBooksResult(1, "A"))
+
+				grpcclient.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
+					s.NewOrder().WithOrderID(1).Order,
+					s.NewOrder().WithOrderID(2).Order,
+				}, 2, nil)
+
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+			},
+			address:              "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299",
+			opts:                 []orderbookdomain.ActiveOrdersOption{orderbookdomain.WithOrderStatuses(orderbookdomain.StatusOpen)},
+			expectedError:        nil,
+			expectedOrders:       []orderbookdomain.LimitOrder{},
+			expectedIsBestEffort: false,
+		},
+		{
+			name: "direction and status filter: bid partially filled orders -> all orders are returned",
+			setupContext: func() context.Context {
+				return context.Background()
+			},
+			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, s.NewCanonicalOrderBooksResult(1, "A"))
+
+				grpcclient.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
+					s.NewOrder().WithOrderID(1).Order,
+					s.NewOrder().WithOrderID(2).Order,
+				}, 2, nil)
+
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+			},
+			address: "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299",
+			opts: []orderbookdomain.ActiveOrdersOption{
+				orderbookdomain.WithOrderDirection("bid"),
+				orderbookdomain.WithOrderStatuses(orderbookdomain.StatusOpen, orderbookdomain.StatusPartiallyFilled),
+			},
+			expectedError: nil,
+			expectedOrders: []orderbookdomain.LimitOrder{
+				s.NewLimitOrder().WithOrderID(1).WithOrderbookAddress("A").LimitOrder,
+				s.NewLimitOrder().WithOrderID(2).WithOrderbookAddress("A").LimitOrder,
+			},
+			expectedIsBestEffort: false,
+		},
 		{
 			name: "successful retrieval of active orders: 2 orders returned. 1 from orderbook A, 1 from order book B. Orderbook B is not canonical -> only 1 order is returned",
 			setupContext: func() context.Context {
@@ -626,12 +866,7 @@ func (s *OrderbookUsecaseTestSuite) TestGetActiveOrders() {
 			// Call the method under test
 			// We are not interested in the orders returned, it's tested
 			// in the TestCreateFormattedLimitOrder.
-			orders, isBestEffort, err := usecase.GetActiveOrders(ctx, tc.address)
-
-			// Sort the results by order ID to make the output more deterministic
-			sort.SliceStable(orders, func(i, j int) bool {
-				return orders[i].OrderId < orders[j].OrderId
-			})
+			page, err := usecase.GetActiveOrders(ctx, tc.address, tc.opts...)
 
 			// Assert the results
 			if tc.expectedError != nil {
@@ -639,117 +874,812 @@ func (s *OrderbookUsecaseTestSuite) TestGetActiveOrders() {
 				s.ErrorIsAs(err, tc.expectedError)
 			} else {
 				s.Assert().NoError(err)
-				s.Assert().Equal(tc.expectedIsBestEffort, isBestEffort)
-				s.Assert().Equal(tc.expectedOrders, orders)
+				s.Assert().Equal(tc.expectedIsBestEffort, page.IsBestEffort)
+				s.Assert().Equal(tc.expectedOrders, page.LimitOrders)
+				s.Assert().Empty(page.NextCursor)
 			}
 		})
 	}
 }
 
-func (s *OrderbookUsecaseTestSuite) TestProcessOrderBookActiveOrders() {
-	newLimitOrder := func() orderbooktesting.LimitOrder {
-		order := s.NewLimitOrder()
-		order = order.WithQuoteAsset(orderbookdomain.Asset{Symbol: "ATOM", Decimals: 6})
-		order = order.WithBaseAsset(orderbookdomain.Asset{Symbol: "OSMO", Decimals: 6})
-		return order
+func (s *OrderbookUsecaseTestSuite) TestGetActiveOrders_Concurrency() {
+	const (
+		numOrderbooks = 5
+
+		// defaultMaxOrderbookWorkers is restored after every test case
+		defaultMaxOrderbookWorkers = 10
+	)
+
+	orderbooks := make([]domain.CanonicalOrderBooksResult, 0, numOrderbooks)
+	expectedOrders := make([]orderbookdomain.LimitOrder, 0, numOrderbooks)
+	for i := 1; i <= numOrderbooks; i++ {
+		contractAddress := fmt.Sprintf("contract%d", i)
+		orderbooks = append(orderbooks, s.NewCanonicalOrderBooksResult(uint64(i), contractAddress))
+
+		// Orders are expected in the order of canonical orderbooks
+		expectedOrders = append(expectedOrders, s.NewLimitOrder().WithOrderID(int64(i)).WithOrderbookAddress(contractAddress).LimitOrder)
+	}
+
+	// setupUsecase creates the usecase where every orderbook returns a single order
+	// with the order ID equal to the pool ID.
+	// The in flight and max in flight counters are updated on every active orders query.
+	setupUsecase := func(onGetActiveOrders func(callCount int32)) (*orderbookusecase.OrderbookUseCaseImpl, *atomic.Int32, *atomic.Int32) {
+		poolsUsecase := mocks.PoolsUsecaseMock{}
+		orderbookrepository := mocks.OrderbookRepositoryMock{}
+		client := mocks.OrderbookGRPCClientMock{}
+		tokensusecase := mocks.TokensUsecaseMock{}
+
+		poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, orderbooks...)
+
+		var callCount, inFlight, maxInFlight atomic.Int32
+		client.GetActiveOrdersCb = func(ctx context.Context, contractAddress string, ownerAddress string) (orderbookdomain.Orders, uint64, error) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+
+			for {
+				max := maxInFlight.Load()
+				if current <= max || maxInFlight.CompareAndSwap(max, current) {
+					break
+				}
+			}
+
+			if onGetActiveOrders != nil {
+				onGetActiveOrders(callCount.Add(1))
+			}
+
+			// Give other workers a chance to run concurrently
+			time.Sleep(10 * time.Millisecond)
+
+			var orderID int64
+			_, err := fmt.Sscanf(contractAddress, "contract%d", &orderID)
+			s.Require().NoError(err)
+
+			return orderbookdomain.Orders{s.NewOrder().WithOrderID(orderID).Order}, 1, nil
+		}
+
+		tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+		tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+		orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+
+		usecase := orderbookusecase.New(&orderbookrepository, &client, &poolsUsecase, &tokensusecase, &log.NoOpLogger{})
+
+		return usecase, &callCount, &maxInFlight
+	}
+
+	for _, workers := range []int{1, 2, numOrderbooks} {
+		s.Run(fmt.Sprintf("%d workers match sequential results", workers), func() {
+			usecase, _, maxInFlight := setupUsecase(nil)
+			usecase.SetMaxOrderbookWorkers(workers)
+			defer usecase.SetMaxOrderbookWorkers(defaultMaxOrderbookWorkers)
+
+			page, err := usecase.GetActiveOrders(context.Background(), "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299")
+			s.Require().NoError(err)
+			s.Require().False(page.IsBestEffort)
+
+			// Order IDs match the pool IDs, so the sorted results are in the order of canonical orderbooks
+			s.Require().Equal(expectedOrders, page.LimitOrders)
+
+			// The number of concurrently processed orderbooks is bounded
+			s.Require().LessOrEqual(int(maxInFlight.Load()), workers)
+		})
 	}
 
+	s.Run("stream is bounded by the maximum number of workers", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		const workers = 2
+
+		usecase, _, maxInFlight := setupUsecase(nil)
+		usecase.SetMaxOrderbookWorkers(workers)
+		defer usecase.SetMaxOrderbookWorkers(defaultMaxOrderbookWorkers)
+
+		stream := usecase.GetActiveOrdersStream(ctx, "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299")
+
+		// Results are pushed in the completion order
+		actualOrders := make([]orderbookdomain.LimitOrder, 0, numOrderbooks)
+		for i := 0; i < numOrderbooks; i++ {
+			result := <-stream
+			s.Require().NoError(result.Error)
+			actualOrders = append(actualOrders, result.LimitOrders...)
+		}
+		s.Require().ElementsMatch(expectedOrders, actualOrders)
+
+		// The number of concurrently processed orderbooks is bounded
+		s.Require().LessOrEqual(int(maxInFlight.Load()), workers)
+	})
+
+	s.Run("cancellation propagates", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Cancel the context while processing the first orderbook
+		usecase, callCount, _ := setupUsecase(func(callCount int32) {
+			if callCount == 1 {
+				cancel()
+			}
+		})
+		usecase.SetMaxOrderbookWorkers(1)
+		defer usecase.SetMaxOrderbookWorkers(defaultMaxOrderbookWorkers)
+
+		_, err := usecase.GetActiveOrders(ctx, "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299")
+		s.Require().ErrorIs(err, context.Canceled)
+
+		// No new orderbooks are processed after cancellation
+		s.Require().Less(int(callCount.Load()), numOrderbooks)
+	})
+}
+
+func (s *OrderbookUsecaseTestSuite) TestGetActiveOrder() {
+	const (
+		ownerAddress    = "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299"
+		contractAddress = "A"
+	)
+
 	testCases := []struct {
-		name                 string
-		setupMocks           func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock)
-		poolID               uint64
-		order                orderbooktesting.LimitOrder
-		ownerAddress         string
-		expectedError        error
-		expectedOrders       []orderbookdomain.LimitOrder
-		expectedIsBestEffort bool
+		name            string
+		setupMocks      func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock)
+		contractAddress string
+		orderID         int64
+		expectedError   error
+		expectedOrder   orderbookdomain.LimitOrder
 	}{
 		{
-			name: "failed to get active orders",
-			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
-				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(nil, 0, assert.AnError)
+			name: "failed to get all canonical orderbook pool IDs",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(assert.AnError)
 			},
-			poolID:        1,
-			order:         newLimitOrder().WithOrderID(5),
-			ownerAddress:  "osmo1epp52vecttkkvs3s84c9m8s2v2jrf7gtm3jzhg",
-			expectedError: &types.FailedToGetActiveOrdersError{},
+			contractAddress: contractAddress,
+			orderID:         1,
+			expectedError:   &types.FailedGetAllCanonicalOrderbookPoolIDsError{},
 		},
 		{
-			name: "no active orders to process",
-			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
-				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(nil, 0, nil)
+			name: "orderbook is not canonical",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, s.NewCanonicalOrderBooksResult(1, "B"))
 			},
-			poolID:               83,
-			order:                newLimitOrder().WithOrderbookAddress("A"),
-			ownerAddress:         "osmo1h5la3t4y8cljl34lsqdszklvcn053u4ryz9qr78v64rsxezyxwlsdelsdr",
-			expectedError:        nil,
-			expectedOrders:       nil,
-			expectedIsBestEffort: false,
+			contractAddress: contractAddress,
+			orderID:         1,
+			expectedError:   &types.CanonicalOrderbookNotFoundError{},
 		},
 		{
-			name: "error on creating formatted limit order ( no error - best effort )",
-			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
-				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
-					s.NewOrder().WithOrderID(1).WithTickID(1).Order,
-					s.NewOrder().WithOrderID(2).WithTickID(2).Order,
-				}, 1, nil)
-				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFunc(newLimitOrder(), "")
-				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
-				orderbookrepository.GetTickByIDFunc = func(poolID uint64, tickID int64) (orderbookdomain.OrderbookTick, bool) {
-					tick := s.NewTick("500", 100, "bid")
-					if tickID == 1 {
-						return tick, true
-					}
-					return tick, false
-				}
-			},
-			poolID:        5,
-			order:         newLimitOrder().WithOrderID(2),
-			ownerAddress:  "osmo1c8udna9h9zsm44jav39g20dmtf7xjnrclpn5fw",
-			expectedError: nil,
-			expectedOrders: []orderbookdomain.LimitOrder{
-				newLimitOrder().WithOrderID(1).LimitOrder,
+			name: "failed to get active orders",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, s.NewCanonicalOrderBooksResult(1, contractAddress))
+
+				grpcclient.GetActiveOrdersCb = s.GetActiveOrdersFunc(nil, 0, assert.AnError)
 			},
-			expectedIsBestEffort: true,
+			contractAddress: contractAddress,
+			orderID:         1,
+			expectedError:   &types.FailedToGetActiveOrdersError{},
 		},
 		{
-			name: "successful processing of 1 active order",
-			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
-				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{s.NewOrder().Order}, 1, nil)
-				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFunc(newLimitOrder(), "")
-				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
-				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
-			},
+			name: "order not found",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, s.NewCanonicalOrderBooksResult(1, contractAddress))
 
-			poolID:        39,
-			order:         newLimitOrder().WithOrderbookAddress("B"),
-			ownerAddress:  "osmo1xhkvmfyfll0303s7xm9hh8uzzwehd98tuyjpga",
-			expectedError: nil,
-			expectedOrders: []orderbookdomain.LimitOrder{
-				newLimitOrder().WithOrderbookAddress("B").LimitOrder,
+				grpcclient.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
+					s.NewOrder().WithOrderID(1).Order,
+					s.NewOrder().WithOrderID(2).Order,
+				}, 2, nil)
 			},
-			expectedIsBestEffort: false,
+			contractAddress: contractAddress,
+			orderID:         3,
+			expectedError:   &types.OrderNotFoundError{},
 		},
 		{
-			name: "successful processing of 2 active orders",
-			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
+			name: "tick for the order is missing",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, s.NewCanonicalOrderBooksResult(1, contractAddress))
+
+				grpcclient.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{s.NewOrder().Order}, 1, nil)
+
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(orderbookdomain.OrderbookTick{}, false)
+			},
+			contractAddress: contractAddress,
+			orderID:         1,
+			expectedError:   &types.TickForOrderbookNotFoundError{},
+		},
+		{
+			name: "order found",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(
+					nil,
+					s.NewCanonicalOrderBooksResult(1, "B"),
+					s.NewCanonicalOrderBooksResult(2, contractAddress),
+				)
+
+				grpcclient.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
+					s.NewOrder().WithOrderID(1).Order,
+					s.NewOrder().WithOrderID(2).Order,
+				}, 2, nil)
+
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+			},
+			contractAddress: contractAddress,
+			orderID:         2,
+			expectedOrder:   s.NewLimitOrder().WithOrderID(2).WithOrderbookAddress(contractAddress).LimitOrder,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			// Create instances of the mocks
+			poolsUsecase := mocks.PoolsUsecaseMock{}
+			orderbookrepository := mocks.OrderbookRepositoryMock{}
+			client := mocks.OrderbookGRPCClientMock{}
+			tokensusecase := mocks.TokensUsecaseMock{}
+
+			// Setup the mocks according to the test case
+			usecase := orderbookusecase.New(&orderbookrepository, &client, &poolsUsecase, &tokensusecase, &log.NoOpLogger{})
+			if tc.setupMocks != nil {
+				tc.setupMocks(&orderbookrepository, &client, &poolsUsecase, &tokensusecase)
+			}
+
+			// Call the method under test
+			order, err := usecase.GetActiveOrder(context.Background(), ownerAddress, tc.contractAddress, tc.orderID)
+
+			// Assert the results
+			if tc.expectedError != nil {
+				s.Assert().Error(err)
+				s.ErrorIsAs(err, tc.expectedError)
+			} else {
+				s.Assert().NoError(err)
+				s.Assert().Equal(tc.expectedOrder, order)
+			}
+		})
+	}
+}
+
+func (s *OrderbookUsecaseTestSuite) TestGetOrderHistory() {
+	historicalOrder := func(orderID int64, state string) orderbookdomain.HistoricalOrder {
+		return orderbookdomain.HistoricalOrder{
+			Order: s.NewOrder().WithOrderID(orderID).Order,
+			State: state,
+		}
+	}
+
+	testCases := []struct {
+		name                 string
+		setupMocks           func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock)
+		expectedError        error
+		expectedOrders       []orderbookdomain.LimitOrder
+		expectedIsBestEffort bool
+	}{
+		{
+			name: "failed to get all canonical orderbook pool IDs",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(assert.AnError)
+			},
+			expectedError: &types.FailedGetAllCanonicalOrderbookPoolIDsError{},
+		},
+		{
+			name: "claimed and cancelled orders across orderbooks are returned with terminal status",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(
+					nil,
+					s.NewCanonicalOrderBooksResult(1, "A"),
+					s.NewCanonicalOrderBooksResult(2, "B"),
+				)
+
+				grpcclient.GetOrderHistoryCb = func(ctx context.Context, contractAddress string, ownerAddress string) ([]orderbookdomain.HistoricalOrder, error) {
+					if contractAddress == "A" {
+						return []orderbookdomain.HistoricalOrder{
+							historicalOrder(1, orderbookdomain.HistoricalOrderStateClaimed),
+						}, nil
+					}
+					return []orderbookdomain.HistoricalOrder{
+						historicalOrder(2, orderbookdomain.HistoricalOrderStateCancelled),
+					}, nil
+				}
+
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+			},
+			expectedOrders: []orderbookdomain.LimitOrder{
+				s.NewLimitOrder().WithOrderID(1).WithOrderbookAddress("A").WithStatus(orderbookdomain.StatusFullyClaimed).LimitOrder,
+				s.NewLimitOrder().WithOrderID(2).WithOrderbookAddress("B").WithStatus(orderbookdomain.StatusCancelled).LimitOrder,
+			},
+		},
+		{
+			name: "failed to get order history for one orderbook -> orders from the other orderbook are returned",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(
+					nil,
+					s.NewCanonicalOrderBooksResult(1, "A"),
+					s.NewCanonicalOrderBooksResult(2, "B"),
+				)
+
+				grpcclient.GetOrderHistoryCb = func(ctx context.Context, contractAddress string, ownerAddress string) ([]orderbookdomain.HistoricalOrder, error) {
+					if contractAddress == "A" {
+						return nil, assert.AnError
+					}
+					return []orderbookdomain.HistoricalOrder{
+						historicalOrder(2, orderbookdomain.HistoricalOrderStateClaimed),
+					}, nil
+				}
+
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+			},
+			expectedOrders: []orderbookdomain.LimitOrder{
+				s.NewLimitOrder().WithOrderID(2).WithOrderbookAddress("B").WithStatus(orderbookdomain.StatusFullyClaimed).LimitOrder,
+			},
+		},
+		{
+			name: "unknown state and missing tick are skipped with best effort",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(nil, s.NewCanonicalOrderBooksResult(1, "A"))
+
+				grpcclient.GetOrderHistoryCb = s.getOrderHistoryFunc(
+					historicalOrder(1, orderbookdomain.HistoricalOrderStateClaimed),
+					historicalOrder(2, "unknown"),
+					orderbookdomain.HistoricalOrder{
+						Order: s.NewOrder().WithOrderID(3).WithTickID(99).Order,
+						State: orderbookdomain.HistoricalOrderStateCancelled,
+					},
+				)
+
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+
+				orderbookrepository.GetTickByIDFunc = func(poolID uint64, tickID int64) (orderbookdomain.OrderbookTick, bool) {
+					if tickID == 99 {
+						return orderbookdomain.OrderbookTick{}, false
+					}
+					return s.NewTick("500", 100, "bid"), true
+				}
+			},
+			expectedOrders: []orderbookdomain.LimitOrder{
+				s.NewLimitOrder().WithOrderID(1).WithOrderbookAddress("A").WithStatus(orderbookdomain.StatusFullyClaimed).LimitOrder,
+			},
+			expectedIsBestEffort: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			// Create instances of the mocks
+			poolsUsecase := mocks.PoolsUsecaseMock{}
+			orderbookrepository := mocks.OrderbookRepositoryMock{}
+			client := mocks.OrderbookGRPCClientMock{}
+			tokensusecase := mocks.TokensUsecaseMock{}
+
+			// Setup the mocks according to the test case
+			usecase := orderbookusecase.New(&orderbookrepository, &client, &poolsUsecase, &tokensusecase, &log.NoOpLogger{})
+			if tc.setupMocks != nil {
+				tc.setupMocks(&orderbookrepository, &client, &poolsUsecase, &tokensusecase)
+			}
+
+			// Call the method under test
+			orders, isBestEffort, err := usecase.GetOrderHistory(context.Background(), "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299")
+
+			// Sort the results by order ID to make the output more deterministic
+			sort.SliceStable(orders, func(i, j int) bool {
+				return orders[i].OrderId < orders[j].OrderId
+			})
+
+			// Assert the results
+			if tc.expectedError != nil {
+				s.Assert().Error(err)
+				s.ErrorIsAs(err, tc.expectedError)
+			} else {
+				s.Assert().NoError(err)
+				s.Assert().Equal(tc.expectedIsBestEffort, isBestEffort)
+				s.Assert().Equal(tc.expectedOrders, orders)
+			}
+		})
+	}
+}
+
+func (s *OrderbookUsecaseTestSuite) TestGetActiveOrders_Pagination() {
+	const address = "osmo1p2pq3dt5xkj39p0420p4mm9l45394xecr00299"
+
+	// Orderbook A (pool 1) has orders 1 and 3, orderbook B (pool 2) has orders 1 and 2.
+	// Sorted by order ID and then pool ID: (1, A), (1, B), (2, B), (3, A)
+	var (
+		orderOneA   = s.NewLimitOrder().WithOrderID(1).WithOrderbookAddress("A").LimitOrder
+		orderOneB   = s.NewLimitOrder().WithOrderID(1).WithOrderbookAddress("B").LimitOrder
+		orderTwoB   = s.NewLimitOrder().WithOrderID(2).WithOrderbookAddress("B").LimitOrder
+		orderThreeA = s.NewLimitOrder().WithOrderID(3).WithOrderbookAddress("A").LimitOrder
+	)
+
+	setupMocks := func(withMissingTick bool) func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+		return func(orderbookrepository *mocks.OrderbookRepositoryMock, grpcclient *mocks.OrderbookGRPCClientMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensusecase *mocks.TokensUsecaseMock) {
+			poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc = s.GetAllCanonicalOrderbookPoolIDsFunc(
+				nil,
+				s.NewCanonicalOrderBooksResult(1, "A"),
+				s.NewCanonicalOrderBooksResult(2, "B"),
+			)
+
+			grpcclient.GetActiveOrdersCb = func(ctx context.Context, contractAddress string, ownerAddress string) (orderbookdomain.Orders, uint64, error) {
+				if contractAddress == "A" {
+					return orderbookdomain.Orders{
+						s.NewOrder().WithOrderID(3).Order,
+						s.NewOrder().WithOrderID(1).Order,
+					}, 2, nil
+				}
+
+				orders := orderbookdomain.Orders{
+					s.NewOrder().WithOrderID(2).Order,
+					s.NewOrder().WithOrderID(1).Order,
+				}
+				if withMissingTick {
+					orders = append(orders, s.NewOrder().WithOrderID(4).WithTickID(99).Order)
+				}
+				return orders, uint64(len(orders)), nil
+			}
+
+			tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+			tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+
+			orderbookrepository.GetTickByIDFunc = func(poolID uint64, tickID int64) (orderbookdomain.OrderbookTick, bool) {
+				return s.NewTick("500", 100, "bid"), tickID != 99
+			}
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		withMissingTick bool
+		limit           int
+		cursor          string
+
+		expectedError        error
+		expectedOrders       []orderbookdomain.LimitOrder
+		expectedNextCursor   string
+		expectedIsBestEffort bool
+	}{
+		{
+			name:           "no limit, no cursor -> all orders sorted",
+			expectedOrders: []orderbookdomain.LimitOrder{orderOneA, orderOneB, orderTwoB, orderThreeA},
+		},
+		{
+			name:               "first page spanning two orderbooks with the same order ID",
+			limit:              2,
+			expectedOrders:     []orderbookdomain.LimitOrder{orderOneA, orderOneB},
+			expectedNextCursor: orderbookdomain.ActiveOrdersCursor{PoolID: 2, OrderID: 1}.Encode(),
+		},
+		{
+			name:           "last page ending exactly at the limit -> no next cursor",
+			limit:          2,
+			cursor:         orderbookdomain.ActiveOrdersCursor{PoolID: 2, OrderID: 1}.Encode(),
+			expectedOrders: []orderbookdomain.LimitOrder{orderTwoB, orderThreeA},
+		},
+		{
+			name:               "cursor between orderbooks with the same order ID",
+			limit:              1,
+			cursor:             orderbookdomain.ActiveOrdersCursor{PoolID: 1, OrderID: 1}.Encode(),
+			expectedOrders:     []orderbookdomain.LimitOrder{orderOneB},
+			expectedNextCursor: orderbookdomain.ActiveOrdersCursor{PoolID: 2, OrderID: 1}.Encode(),
+		},
+		{
+			name:           "cursor past the last order -> empty page",
+			limit:          2,
+			cursor:         orderbookdomain.ActiveOrdersCursor{PoolID: 1, OrderID: 3}.Encode(),
+			expectedOrders: []orderbookdomain.LimitOrder{},
+		},
+		{
+			name:                 "best effort is preserved on a page not containing the failed order",
+			withMissingTick:      true,
+			limit:                2,
+			expectedOrders:       []orderbookdomain.LimitOrder{orderOneA, orderOneB},
+			expectedNextCursor:   orderbookdomain.ActiveOrdersCursor{PoolID: 2, OrderID: 1}.Encode(),
+			expectedIsBestEffort: true,
+		},
+		{
+			name:          "invalid cursor",
+			cursor:        "invalid-cursor",
+			expectedError: &types.InvalidActiveOrdersCursorError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			// Create instances of the mocks
+			poolsUsecase := mocks.PoolsUsecaseMock{}
+			orderbookrepository := mocks.OrderbookRepositoryMock{}
+			client := mocks.OrderbookGRPCClientMock{}
+			tokensusecase := mocks.TokensUsecaseMock{}
+
+			setupMocks(tc.withMissingTick)(&orderbookrepository, &client, &poolsUsecase, &tokensusecase)
+
+			usecase := orderbookusecase.New(&orderbookrepository, &client, &poolsUsecase, &tokensusecase, &log.NoOpLogger{})
+
+			// Call the method under test
+			page, err := usecase.GetActiveOrders(context.Background(), address, orderbookdomain.WithPagination(tc.limit, tc.cursor))
+
+			// Assert the results
+			if tc.expectedError != nil {
+				s.Assert().Error(err)
+				s.ErrorIsAs(err, tc.expectedError)
+				return
+			}
+
+			s.Assert().NoError(err)
+			s.Assert().Equal(tc.expectedOrders, page.LimitOrders)
+			s.Assert().Equal(tc.expectedNextCursor, page.NextCursor)
+			s.Assert().Equal(tc.expectedIsBestEffort, page.IsBestEffort)
+		})
+	}
+}
+
+func (s *OrderbookUsecaseTestSuite) TestProcessOrderBookActiveOrders() {
+	newLimitOrder := func() orderbooktesting.LimitOrder {
+		order := s.NewLimitOrder()
+		order = order.WithQuoteAsset(orderbookdomain.Asset{Symbol: "ATOM", Decimals: 6})
+		order = order.WithBaseAsset(orderbookdomain.Asset{Symbol: "OSMO", Decimals: 6})
+		return order
+	}
+
+	testCases := []struct {
+		name                 string
+		setupMocks           func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock)
+		poolID               uint64
+		order                orderbooktesting.LimitOrder
+		ownerAddress         string
+		expectedError        error
+		expectedOrders       []orderbookdomain.LimitOrder
+		expectedIsBestEffort bool
+	}{
+		{
+			name: "failed to get active orders",
+			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
+				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(nil, 0, assert.AnError)
+			},
+			poolID:        1,
+			order:         newLimitOrder().WithOrderID(5),
+			ownerAddress:  "osmo1epp52vecttkkvs3s84c9m8s2v2jrf7gtm3jzhg",
+			expectedError: &types.FailedToGetActiveOrdersError{},
+		},
+		{
+			name: "no active orders to process",
+			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
+				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(nil, 0, nil)
+			},
+			poolID:               83,
+			order:                newLimitOrder().WithOrderbookAddress("A"),
+			ownerAddress:         "osmo1h5la3t4y8cljl34lsqdszklvcn053u4ryz9qr78v64rsxezyxwlsdelsdr",
+			expectedError:        nil,
+			expectedOrders:       nil,
+			expectedIsBestEffort: false,
+		},
+		{
+			name: "error on creating formatted limit order ( no error - best effort )",
+			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
+				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
+					s.NewOrder().WithOrderID(1).WithTickID(1).Order,
+					s.NewOrder().WithOrderID(2).WithTickID(2).Order,
+				}, 1, nil)
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFunc(newLimitOrder(), "")
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+				orderbookrepository.GetTickByIDFunc = func(poolID uint64, tickID int64) (orderbookdomain.OrderbookTick, bool) {
+					tick := s.NewTick("500", 100, "bid")
+					if tickID == 1 {
+						return tick, true
+					}
+					return tick, false
+				}
+			},
+			poolID:        5,
+			order:         newLimitOrder().WithOrderID(2),
+			ownerAddress:  "osmo1c8udna9h9zsm44jav39g20dmtf7xjnrclpn5fw",
+			expectedError: nil,
+			expectedOrders: []orderbookdomain.LimitOrder{
+				newLimitOrder().WithOrderID(1).LimitOrder,
+			},
+			expectedIsBestEffort: true,
+		},
+		{
+			name: "successful processing of 1 active order",
+			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
+				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{s.NewOrder().Order}, 1, nil)
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFunc(newLimitOrder(), "")
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+			},
+
+			poolID:        39,
+			order:         newLimitOrder().WithOrderbookAddress("B"),
+			ownerAddress:  "osmo1xhkvmfyfll0303s7xm9hh8uzzwehd98tuyjpga",
+			expectedError: nil,
+			expectedOrders: []orderbookdomain.LimitOrder{
+				newLimitOrder().WithOrderbookAddress("B").LimitOrder,
+			},
+			expectedIsBestEffort: false,
+		},
+		{
+			name: "successful processing of 2 active orders",
+			setupMocks: func(usecase *orderbookusecase.OrderbookUseCaseImpl, orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
+				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
+					s.NewOrder().WithOrderID(1).Order,
+					s.NewOrder().WithOrderID(2).Order,
+				}, 1, nil)
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFunc(newLimitOrder().WithBaseAsset(orderbookdomain.Asset{Symbol: "USDC", Decimals: 6}), "")
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+			},
+			poolID:        93,
+			order:         newLimitOrder().WithBaseAsset(orderbookdomain.Asset{Symbol: "USDC", Decimals: 6}),
+			ownerAddress:  "osmo1xhkvmfyfll0303s7xm9hh8uzzwehd98tuyjpga",
+			expectedError: nil,
+			expectedOrders: []orderbookdomain.LimitOrder{
+				newLimitOrder().WithOrderID(1).WithBaseAsset(orderbookdomain.Asset{Symbol: "USDC", Decimals: 6}).LimitOrder,
+				newLimitOrder().WithOrderID(2).WithBaseAsset(orderbookdomain.Asset{Symbol: "USDC", Decimals: 6}).LimitOrder,
+			},
+			expectedIsBestEffort: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			// Create instances of the mocks
+			client := mocks.OrderbookGRPCClientMock{}
+			tokensusecase := mocks.TokensUsecaseMock{}
+			orderbookrepository := mocks.OrderbookRepositoryMock{}
+
+			// Setup the mocks according to the test case
+			usecase := orderbookusecase.New(&orderbookrepository, &client, nil, &tokensusecase, &log.NoOpLogger{})
+			if tc.setupMocks != nil {
+				tc.setupMocks(usecase, &orderbookrepository, &client, &tokensusecase)
+			}
+
+			// Call the method under test
+			orders, isBestEffort, err := usecase.ProcessOrderBookActiveOrders(context.Background(), domain.CanonicalOrderBooksResult{
+				ContractAddress: tc.order.OrderbookAddress,
+				PoolID:          tc.poolID,
+				Quote:           tc.order.QuoteAsset.Symbol,
+				Base:            tc.order.BaseAsset.Symbol,
+			}, tc.ownerAddress)
+
+			// Assert the results
+			if tc.expectedError != nil {
+				s.Assert().Error(err)
+				if errors.Is(err, tc.expectedError) {
+					s.Assert().ErrorIs(err, tc.expectedError)
+				} else {
+					s.Assert().ErrorAs(err, tc.expectedError)
+				}
+			} else {
+				s.Assert().NoError(err)
+				s.Assert().Equal(tc.expectedOrders, orders)
+				s.Assert().Equal(tc.expectedIsBestEffort, isBestEffort)
+			}
+		})
+	}
+}
+
+func (s *OrderbookUsecaseTestSuite) TestProcessOrderBookActiveOrders_ScalingFactorMemoization() {
+	testCases := []struct {
+		name                 string
+		scalingFactorErr     error
+		expectedOrders       []orderbookdomain.LimitOrder
+		expectedIsBestEffort bool
+	}{
+		{
+			name: "scaling factor is fetched once for multiple same-pair orders",
+			expectedOrders: []orderbookdomain.LimitOrder{
+				s.NewLimitOrder().WithOrderID(1).WithOrderbookAddress("A").LimitOrder,
+				s.NewLimitOrder().WithOrderID(2).WithOrderbookAddress("A").LimitOrder,
+				s.NewLimitOrder().WithOrderID(3).WithOrderbookAddress("A").LimitOrder,
+			},
+		},
+		{
+			name:                 "error getting spot price scaling factor is fetched once and surfaced for every order",
+			scalingFactorErr:     assert.AnError,
+			expectedOrders:       []orderbookdomain.LimitOrder{},
+			expectedIsBestEffort: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			// Create instances of the mocks
+			client := mocks.OrderbookGRPCClientMock{}
+			tokensusecase := mocks.TokensUsecaseMock{}
+			orderbookrepository := mocks.OrderbookRepositoryMock{}
+
+			client.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
+				s.NewOrder().WithOrderID(1).Order,
+				s.NewOrder().WithOrderID(2).Order,
+				s.NewOrder().WithOrderID(3).Order,
+			}, 3, nil)
+			tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+			orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+
+			// Track the number of scaling factor calls
+			var callCount int
+			tokensusecase.GetSpotPriceScalingFactorByDenomFunc = func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+				callCount++
+				return osmomath.OneDec(), tc.scalingFactorErr
+			}
+
+			usecase := orderbookusecase.New(&orderbookrepository, &client, nil, &tokensusecase, &log.NoOpLogger{})
+
+			// Call the method under test
+			orders, isBestEffort, err := usecase.ProcessOrderBookActiveOrders(context.Background(), s.NewCanonicalOrderBooksResult(1, "A"), "osmo1xhkvmfyfll0303s7xm9hh8uzzwehd98tuyjpga")
+
+			// Assert the results
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedOrders, orders)
+			s.Require().Equal(tc.expectedIsBestEffort, isBestEffort)
+			s.Require().Equal(1, callCount)
+		})
+	}
+}
+
+func (s *OrderbookUsecaseTestSuite) TestProcessOrderBookActiveOrdersVerbose() {
+	orderbook := s.NewCanonicalOrderBooksResult(1, "A")
+
+	// orderWithZeroPlacedQuantity fails to be formatted
+	orderWithZeroPlacedQuantity := s.NewOrder().WithOrderID(3).Order
+	orderWithZeroPlacedQuantity.PlacedQuantity = "0"
+
+	testCases := []struct {
+		name                string
+		setupMocks          func(orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock)
+		expectedError       error
+		expectedOrders      []orderbookdomain.LimitOrder
+		expectedOrderIDs    []int64
+		expectedOrderErrors []error
+	}{
+		{
+			name: "failed to get active orders",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
+				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(nil, 0, assert.AnError)
+			},
+			expectedError: &types.FailedToGetActiveOrdersError{},
+		},
+		{
+			name: "all orders processed -> no order errors",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
+				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{s.NewOrder().Order}, 1, nil)
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+			},
+			expectedOrders: []orderbookdomain.LimitOrder{
+				s.NewLimitOrder().WithOrderbookAddress("A").LimitOrder,
+			},
+		},
+		{
+			name: "skipped orders are reported with order ID and concrete error",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, client *mocks.OrderbookGRPCClientMock, tokensusecase *mocks.TokensUsecaseMock) {
 				client.GetActiveOrdersCb = s.GetActiveOrdersFunc(orderbookdomain.Orders{
 					s.NewOrder().WithOrderID(1).Order,
-					s.NewOrder().WithOrderID(2).Order,
-				}, 1, nil)
-				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFunc(newLimitOrder().WithBaseAsset(orderbookdomain.Asset{Symbol: "USDC", Decimals: 6}), "")
-				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("500", 100, "bid"), true)
+					s.NewOrder().WithOrderID(2).WithTickID(99).Order,
+					orderWithZeroPlacedQuantity,
+				}, 3, nil)
+				tokensusecase.GetMetadataByChainDenomFunc = s.GetMetadataByChainDenomFuncEmptyToken()
 				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+				orderbookrepository.GetTickByIDFunc = func(poolID uint64, tickID int64) (orderbookdomain.OrderbookTick, bool) {
+					if tickID == 99 {
+						return orderbookdomain.OrderbookTick{}, false
+					}
+					return s.NewTick("500", 100, "bid"), true
+				}
 			},
-			poolID:        93,
-			order:         newLimitOrder().WithBaseAsset(orderbookdomain.Asset{Symbol: "USDC", Decimals: 6}),
-			ownerAddress:  "osmo1xhkvmfyfll0303s7xm9hh8uzzwehd98tuyjpga",
-			expectedError: nil,
 			expectedOrders: []orderbookdomain.LimitOrder{
-				newLimitOrder().WithOrderID(1).WithBaseAsset(orderbookdomain.Asset{Symbol: "USDC", Decimals: 6}).LimitOrder,
-				newLimitOrder().WithOrderID(2).WithBaseAsset(orderbookdomain.Asset{Symbol: "USDC", Decimals: 6}).LimitOrder,
+				s.NewLimitOrder().WithOrderID(1).WithOrderbookAddress("A").LimitOrder,
+			},
+			expectedOrderIDs: []int64{2, 3},
+			expectedOrderErrors: []error{
+				&types.TickForOrderbookNotFoundError{},
+				&types.InvalidPlacedQuantityError{},
 			},
-			expectedIsBestEffort: false,
 		},
 	}
 
@@ -763,29 +1693,26 @@ func (s *OrderbookUsecaseTestSuite) TestProcessOrderBookActiveOrders() {
 			// Setup the mocks according to the test case
 			usecase := orderbookusecase.New(&orderbookrepository, &client, nil, &tokensusecase, &log.NoOpLogger{})
 			if tc.setupMocks != nil {
-				tc.setupMocks(usecase, &orderbookrepository, &client, &tokensusecase)
+				tc.setupMocks(&orderbookrepository, &client, &tokensusecase)
 			}
 
 			// Call the method under test
-			orders, isBestEffort, err := usecase.ProcessOrderBookActiveOrders(context.Background(), domain.CanonicalOrderBooksResult{
-				ContractAddress: tc.order.OrderbookAddress,
-				PoolID:          tc.poolID,
-				Quote:           tc.order.QuoteAsset.Symbol,
-				Base:            tc.order.BaseAsset.Symbol,
-			}, tc.ownerAddress)
+			orders, orderErrors, err := usecase.ProcessOrderBookActiveOrdersVerbose(context.Background(), orderbook, "osmo1xhkvmfyfll0303s7xm9hh8uzzwehd98tuyjpga")
 
 			// Assert the results
 			if tc.expectedError != nil {
 				s.Assert().Error(err)
-				if errors.Is(err, tc.expectedError) {
-					s.Assert().ErrorIs(err, tc.expectedError)
-				} else {
-					s.Assert().ErrorAs(err, tc.expectedError)
-				}
-			} else {
-				s.Assert().NoError(err)
-				s.Assert().Equal(tc.expectedOrders, orders)
-				s.Assert().Equal(tc.expectedIsBestEffort, isBestEffort)
+				s.ErrorIsAs(err, tc.expectedError)
+				return
+			}
+
+			s.Assert().NoError(err)
+			s.Assert().Equal(tc.expectedOrders, orders)
+
+			s.Require().Len(orderErrors, len(tc.expectedOrderErrors))
+			for i, orderError := range orderErrors {
+				s.Assert().Equal(tc.expectedOrderIDs[i], orderError.OrderID)
+				s.Assert().ErrorAs(orderError, tc.expectedOrderErrors[i])
 			}
 		})
 	}
@@ -885,6 +1812,18 @@ func (s *OrderbookUsecaseTestSuite) TestCreateFormattedLimitOrder() {
 				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("813", 1331, "bid"), true)
 			},
 		},
+		{
+			name: "placed quantity is negative",
+			order: orderbookdomain.Order{
+				Quantity:       "1000",
+				PlacedQuantity: "-1500",
+			},
+			orderbook:     newOrderbook("osmo1w8jm03vws7h448yvh83utd8p43j02npydy2jll0r0k7f6w7hjspsvw2u42"),
+			expectedError: &types.InvalidPlacedQuantityError{},
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, tokensusecase *mocks.TokensUsecaseMock) {
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("813", 1331, "bid"), true)
+			},
+		},
 		{
 			name: "error getting spot price scaling factor",
 			order: orderbookdomain.Order{
@@ -1040,6 +1979,35 @@ func (s *OrderbookUsecaseTestSuite) TestCreateFormattedLimitOrder() {
 			expectedError: nil,
 			expectedOrder: s.NewLimitOrder().WithOrderbookAddress("osmo1kfct7fcu3qqc9jlxeku873p7t5vucfzw5ujn0dh97hypg24t2w6qe9q5zs").LimitOrder,
 		},
+		{
+			name:  "successful order processing: nothing filled -> percent filled is zero",
+			order: s.NewOrder().WithEtas("1100").Order,
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, tokensusecase *mocks.TokensUsecaseMock) {
+				// tick total ETAs (400 + 100) are below order ETAs (1100) minus claimed amount (500), so filled amount is clamped to zero
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("400", 100, "bid"), true)
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+			},
+			orderbook:     newOrderbook("osmo1kfct7fcu3qqc9jlxeku873p7t5vucfzw5ujn0dh97hypg24t2w6qe9q5zs"),
+			expectedError: nil,
+			expectedOrder: s.NewLimitOrder().
+				WithOrderbookAddress("osmo1kfct7fcu3qqc9jlxeku873p7t5vucfzw5ujn0dh97hypg24t2w6qe9q5zs").
+				WithEtas("1100").
+				WithFillProgress("0", "0", orderbookdomain.StatusOpen).LimitOrder,
+		},
+		{
+			name:  "successful order processing: filled amount exceeds placed quantity -> percent filled is capped at one",
+			order: s.NewOrder().Order,
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, tokensusecase *mocks.TokensUsecaseMock) {
+				// tick total ETAs (1900 + 100) minus order ETAs (500) plus claimed amount (500) is 2000, more than placed quantity of 1500
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(s.NewTick("1900", 100, "bid"), true)
+				tokensusecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(1, nil)
+			},
+			orderbook:     newOrderbook("osmo1kfct7fcu3qqc9jlxeku873p7t5vucfzw5ujn0dh97hypg24t2w6qe9q5zs"),
+			expectedError: nil,
+			expectedOrder: s.NewLimitOrder().
+				WithOrderbookAddress("osmo1kfct7fcu3qqc9jlxeku873p7t5vucfzw5ujn0dh97hypg24t2w6qe9q5zs").
+				WithFillProgress("2000", "1", orderbookdomain.StatusFilled).LimitOrder,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1074,3 +2042,370 @@ func (s *OrderbookUsecaseTestSuite) TestCreateFormattedLimitOrder() {
 		})
 	}
 }
+
+func (s *OrderbookUsecaseTestSuite) TestGetOrderbookSpotPrice() {
+	const (
+		baseDenomTest  = "base"
+		quoteDenomTest = "quote"
+		poolID         = 1
+	)
+
+	newTick := func(bidLiquidity, askLiquidity string) orderbookdomain.OrderbookTick {
+		return orderbookdomain.OrderbookTick{
+			TickState: orderbookdomain.TickState{
+				BidValues: orderbookdomain.TickValues{TotalAmountOfLiquidity: bidLiquidity},
+				AskValues: orderbookdomain.TickValues{TotalAmountOfLiquidity: askLiquidity},
+			},
+		}
+	}
+
+	// populatedTicks has the best bid at price 1 and the best ask at price 10.
+	populatedTicks := func(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool) {
+		return map[int64]orderbookdomain.OrderbookTick{
+			-100000: newTick("500", "0"),
+			// best bid, price 1
+			0: newTick("100", "0"),
+			// drained bid tick is ignored
+			100000: newTick("0", "0"),
+			// best ask, price 10
+			9000000: newTick("0", "200"),
+			9100000: newTick("0", "300"),
+		}, true
+	}
+
+	testCases := []struct {
+		name          string
+		setupMocks    func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock)
+		expectedError error
+		expectedPrice osmomath.BigDec
+	}{
+		{
+			name: "canonical orderbook not found",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) {
+				poolsUsecase.GetCanonicalOrderbookPoolFunc = func(baseDenom, quoteDenom string) (uint64, string, error) {
+					return 0, "", assert.AnError
+				}
+			},
+			expectedError: &types.CanonicalOrderbookNotFoundForDenomsError{},
+		},
+		{
+			name: "ticks not found",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) {
+				orderbookrepository.GetAllTicksFunc = func(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool) {
+					return nil, false
+				}
+			},
+			expectedError: &types.OrderbookEmptyError{},
+		},
+		{
+			name: "empty orderbook",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) {
+				orderbookrepository.GetAllTicksFunc = func(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool) {
+					return map[int64]orderbookdomain.OrderbookTick{
+						0:      newTick("0", "0"),
+						100000: newTick("", "0"),
+					}, true
+				}
+			},
+			expectedError: &types.OrderbookEmptyError{},
+		},
+		{
+			name: "orderbook with bids only",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) {
+				orderbookrepository.GetAllTicksFunc = func(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool) {
+					return map[int64]orderbookdomain.OrderbookTick{
+						0: newTick("100", "0"),
+					}, true
+				}
+			},
+			expectedError: &types.OrderbookEmptyError{},
+		},
+		{
+			name: "invalid tick liquidity",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) {
+				orderbookrepository.GetAllTicksFunc = func(poolID uint64) (map[int64]orderbookdomain.OrderbookTick, bool) {
+					return map[int64]orderbookdomain.OrderbookTick{
+						0: newTick("invalid", "0"),
+					}, true
+				}
+			},
+			expectedError: &types.ParsingTickValuesError{},
+		},
+		{
+			name: "populated orderbook",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) {
+				orderbookrepository.GetAllTicksFunc = populatedTicks
+			},
+			expectedPrice: osmomath.MustNewBigDecFromStr("5.5"),
+		},
+		{
+			name: "failed to get spot price scaling factor",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) {
+				orderbookrepository.GetAllTicksFunc = populatedTicks
+				tokensUsecase.GetSpotPriceScalingFactorByDenomFunc = s.GetSpotPriceScalingFactorByDenomFunc(0, assert.AnError)
+			},
+			expectedError: &types.GettingSpotPriceScalingFactorError{},
+		},
+		{
+			name: "populated orderbook with spot price scaling factor",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) {
+				orderbookrepository.GetAllTicksFunc = populatedTicks
+				tokensUsecase.GetSpotPriceScalingFactorByDenomFunc = func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+					s.Require().Equal(baseDenomTest, baseDenom)
+					s.Require().Equal(quoteDenomTest, quoteDenom)
+					return osmomath.NewDec(100), nil
+				}
+			},
+			expectedPrice: osmomath.MustNewBigDecFromStr("550"),
+		},
+		{
+			name: "populated orderbook with reversed base and quote denoms",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock, tokensUsecase *mocks.TokensUsecaseMock) {
+				// The canonical orderbook is oriented as quote/base.
+				poolsUsecase.GetCanonicalOrderbookPoolFunc = func(baseDenom, quoteDenom string) (uint64, string, error) {
+					if baseDenom != quoteDenomTest || quoteDenom != baseDenomTest {
+						return 0, "", assert.AnError
+					}
+					return poolID, "A", nil
+				}
+				orderbookrepository.GetAllTicksFunc = populatedTicks
+				tokensUsecase.GetSpotPriceScalingFactorByDenomFunc = func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+					// Scaling factor is requested in the orderbook orientation.
+					s.Require().Equal(quoteDenomTest, baseDenom)
+					s.Require().Equal(baseDenomTest, quoteDenom)
+					return osmomath.NewDec(2), nil
+				}
+			},
+			// 1 / (5.5 * 2)
+			expectedPrice: osmomath.OneBigDec().Quo(osmomath.MustNewBigDecFromStr("11")),
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			poolsUsecase := mocks.PoolsUsecaseMock{
+				GetCanonicalOrderbookPoolFunc: func(baseDenom, quoteDenom string) (uint64, string, error) {
+					return poolID, "A", nil
+				},
+			}
+			orderbookrepository := mocks.OrderbookRepositoryMock{}
+			tokensUsecase := mocks.TokensUsecaseMock{
+				GetSpotPriceScalingFactorByDenomFunc: s.GetSpotPriceScalingFactorByDenomFunc(1, nil),
+			}
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(&orderbookrepository, &poolsUsecase, &tokensUsecase)
+			}
+
+			usecase := orderbookusecase.New(&orderbookrepository, nil, &poolsUsecase, &tokensUsecase, &log.NoOpLogger{})
+
+			price, err := usecase.GetOrderbookSpotPrice(baseDenomTest, quoteDenomTest)
+
+			if tc.expectedError != nil {
+				s.Assert().Error(err)
+				s.ErrorIsAs(err, tc.expectedError)
+				return
+			}
+
+			s.Assert().NoError(err)
+			s.Assert().Equal(tc.expectedPrice.String(), price.String())
+		})
+	}
+}
+
+func (s *OrderbookUsecaseTestSuite) TestGetOrderbookDepth() {
+	const (
+		baseDenom  = "base"
+		quoteDenom = "quote"
+		poolID     = 1
+	)
+
+	newTick := func(bidLiquidity, askLiquidity string) orderbookdomain.OrderbookTick {
+		return orderbookdomain.OrderbookTick{
+			TickState: orderbookdomain.TickState{
+				BidValues: orderbookdomain.TickValues{TotalAmountOfLiquidity: bidLiquidity},
+				AskValues: orderbookdomain.TickValues{TotalAmountOfLiquidity: askLiquidity},
+			},
+		}
+	}
+
+	newPool := func(tickIDs ...int64) *mocks.MockRoutablePool {
+		ticks := make([]cosmwasmpool.OrderbookTick, 0, len(tickIDs))
+		for _, tickID := range tickIDs {
+			ticks = append(ticks, cosmwasmpool.OrderbookTick{TickId: tickID})
+		}
+
+		return &mocks.MockRoutablePool{
+			ID: poolID,
+			CosmWasmPoolModel: &cosmwasmpool.CosmWasmPoolModel{
+				ContractInfo: cosmwasmpool.ContractInfo{
+					Contract: cosmwasmpool.ORDERBOOK_CONTRACT_NAME,
+					Version:  cosmwasmpool.ORDERBOOK_MIN_CONTRACT_VERSION,
+				},
+				Data: cosmwasmpool.CosmWasmPoolData{
+					Orderbook: &cosmwasmpool.OrderbookData{
+						Ticks: ticks,
+					},
+				},
+			},
+		}
+	}
+
+	newLevel := func(tickID int64, price, liquidity string) orderbookdomain.OrderbookDepthLevel {
+		return orderbookdomain.OrderbookDepthLevel{
+			TickID:    tickID,
+			Price:     osmomath.MustNewBigDecFromStr(price),
+			Liquidity: osmomath.MustNewDecFromStr(liquidity),
+		}
+	}
+
+	// Ticks stored in the repository, tick -9000000 is deliberately missing.
+	storedTicks := map[int64]orderbookdomain.OrderbookTick{
+		-1000000: newTick("300", "0"),
+		-100000:  newTick("200", "0"),
+		0:        newTick("100", "50"),
+		100000:   newTick("0", "0"),
+		9000000:  newTick("0", "400"),
+		9100000:  newTick("", "500"),
+	}
+
+	orderbookPool := newPool(-9000000, -1000000, -100000, 0, 0, 100000, 9000000, 9100000)
+
+	testCases := []struct {
+		name          string
+		levels        int
+		setupMocks    func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock)
+		expectedError error
+
+		expectedDepth        orderbookdomain.OrderbookDepth
+		expectedIsBestEffort bool
+	}{
+		{
+			name: "canonical orderbook not found",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock) {
+				poolsUsecase.GetCanonicalOrderbookPoolFunc = func(baseDenom, quoteDenom string) (uint64, string, error) {
+					return 0, "", assert.AnError
+				}
+			},
+			expectedError: &types.CanonicalOrderbookNotFoundForDenomsError{},
+		},
+		{
+			name: "pool is not an orderbook",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock) {
+				poolsUsecase.GetPoolFunc = func(poolID uint64) (sqsdomain.PoolI, error) {
+					return &mocks.MockRoutablePool{ID: poolID, CosmWasmPoolModel: &cosmwasmpool.CosmWasmPoolModel{}}, nil
+				}
+			},
+			expectedError: &types.NotAnOrderbookPoolError{},
+		},
+		{
+			name: "invalid tick liquidity",
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock) {
+				orderbookrepository.GetTickByIDFunc = s.GetTickByIDFunc(newTick("invalid", "0"), true)
+			},
+			expectedError: &types.ParsingTickValuesError{},
+		},
+		{
+			name:   "all levels, best effort due to missing tick",
+			levels: 0,
+			expectedDepth: orderbookdomain.OrderbookDepth{
+				PoolID: poolID,
+				Bids: []orderbookdomain.OrderbookDepthLevel{
+					// duplicated tick 0 is counted once
+					newLevel(0, "1", "100"),
+					newLevel(-100000, "0.99", "200"),
+					newLevel(-1000000, "0.9", "300"),
+				},
+				Asks: []orderbookdomain.OrderbookDepthLevel{
+					newLevel(0, "1", "50"),
+					newLevel(9000000, "10", "400"),
+					newLevel(9100000, "11", "500"),
+				},
+			},
+			expectedIsBestEffort: true,
+		},
+		{
+			name:   "limited levels",
+			levels: 2,
+			expectedDepth: orderbookdomain.OrderbookDepth{
+				PoolID: poolID,
+				Bids: []orderbookdomain.OrderbookDepthLevel{
+					newLevel(0, "1", "100"),
+					newLevel(-100000, "0.99", "200"),
+				},
+				Asks: []orderbookdomain.OrderbookDepthLevel{
+					newLevel(0, "1", "50"),
+					newLevel(9000000, "10", "400"),
+				},
+			},
+			expectedIsBestEffort: true,
+		},
+		{
+			name:   "all ticks found",
+			levels: 1,
+			setupMocks: func(orderbookrepository *mocks.OrderbookRepositoryMock, poolsUsecase *mocks.PoolsUsecaseMock) {
+				poolsUsecase.GetPoolFunc = func(poolID uint64) (sqsdomain.PoolI, error) {
+					return newPool(-100000, 9000000), nil
+				}
+			},
+			expectedDepth: orderbookdomain.OrderbookDepth{
+				PoolID: poolID,
+				Bids: []orderbookdomain.OrderbookDepthLevel{
+					newLevel(-100000, "0.99", "200"),
+				},
+				Asks: []orderbookdomain.OrderbookDepthLevel{
+					newLevel(9000000, "10", "400"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			poolsUsecase := mocks.PoolsUsecaseMock{
+				GetCanonicalOrderbookPoolFunc: func(baseDenom, quoteDenom string) (uint64, string, error) {
+					return poolID, "A", nil
+				},
+				GetPoolFunc: func(poolID uint64) (sqsdomain.PoolI, error) {
+					return orderbookPool, nil
+				},
+			}
+			orderbookrepository := mocks.OrderbookRepositoryMock{
+				GetTickByIDFunc: func(poolID uint64, tickID int64) (orderbookdomain.OrderbookTick, bool) {
+					tick, ok := storedTicks[tickID]
+					return tick, ok
+				},
+			}
+
+			if tc.setupMocks != nil {
+				tc.setupMocks(&orderbookrepository, &poolsUsecase)
+			}
+
+			usecase := orderbookusecase.New(&orderbookrepository, nil, &poolsUsecase, nil, &log.NoOpLogger{})
+
+			depth, isBestEffort, err := usecase.GetOrderbookDepth(context.Background(), baseDenom, quoteDenom, tc.levels)
+
+			if tc.expectedError != nil {
+				s.Assert().Error(err)
+				s.ErrorIsAs(err, tc.expectedError)
+				return
+			}
+
+			s.Assert().NoError(err)
+			s.Assert().Equal(tc.expectedIsBestEffort, isBestEffort)
+			s.Assert().Equal(tc.expectedDepth.PoolID, depth.PoolID)
+			s.assertDepthLevels(tc.expectedDepth.Bids, depth.Bids)
+			s.assertDepthLevels(tc.expectedDepth.Asks, depth.Asks)
+		})
+	}
+}
+
+// assertDepthLevels asserts that the depth levels are equal, comparing prices and liquidity by value.
+func (s *OrderbookUsecaseTestSuite) assertDepthLevels(expected, actual []orderbookdomain.OrderbookDepthLevel) {
+	s.Require().Len(actual, len(expected))
+	for i := range expected {
+		s.Assert().Equal(expected[i].TickID, actual[i].TickID)
+		s.Assert().Equal(expected[i].Price.String(), actual[i].Price.String())
+		s.Assert().Equal(expected[i].Liquidity.String(), actual[i].Liquidity.String())
+	}
+}
@@ -63,6 +63,12 @@ func (o Order) WithTickID(id int64) Order {
 	return o
 }
 
+// WithEtas sets the ETAs for the order
+func (o Order) WithEtas(etas string) Order {
+	o.Etas = etas
+	return o
+}
+
 // LimitOrder wraps additional helper methods for testing
 type LimitOrder struct {
 	orderbookdomain.LimitOrder
@@ -80,6 +86,26 @@ func (o LimitOrder) WithOrderbookAddress(address string) LimitOrder {
 	return o
 }
 
+// WithEtas sets the ETAs for the order
+func (o LimitOrder) WithEtas(etas string) LimitOrder {
+	o.Etas = etas
+	return o
+}
+
+// WithFillProgress sets the total filled amount, percent filled and the resulting status for the order
+func (o LimitOrder) WithFillProgress(totalFilled, percentFilled string, status orderbookdomain.OrderStatus) LimitOrder {
+	o.TotalFilled = osmomath.MustNewDecFromStr(totalFilled)
+	o.PercentFilled = osmomath.MustNewDecFromStr(percentFilled)
+	o.Status = status
+	return o
+}
+
+// WithStatus sets the status for the order
+func (o LimitOrder) WithStatus(status orderbookdomain.OrderStatus) LimitOrder {
+	o.Status = status
+	return o
+}
+
 // WithQuoteAsset sets the quote asset for the order
 func (o LimitOrder) WithQuoteAsset(asset orderbookdomain.Asset) LimitOrder {
 	o.QuoteAsset = asset
@@ -156,8 +182,8 @@ func (s *OrderbookTestHelper) NewCanonicalOrderBooksResult(poolID uint64, contra
 
 // GetAllCanonicalOrderbookPoolIDsFunc returns a function that returns all canonical orderbook pool IDs
 // it is useful for mocking the poolsUsecase.GetAllCanonicalOrderbookPoolIDsFunc.
-func (s *OrderbookTestHelper) GetAllCanonicalOrderbookPoolIDsFunc(err error, orderbooks ...domain.CanonicalOrderBooksResult) func() ([]domain.CanonicalOrderBooksResult, error) {
-	return func() ([]domain.CanonicalOrderBooksResult, error) {
+func (s *OrderbookTestHelper) GetAllCanonicalOrderbookPoolIDsFunc(err error, orderbooks ...domain.CanonicalOrderBooksResult) func(opts ...domain.CanonicalOrderbooksOption) ([]domain.CanonicalOrderBooksResult, error) {
+	return func(opts ...domain.CanonicalOrderbooksOption) ([]domain.CanonicalOrderBooksResult, error) {
 		return orderbooks, err
 	}
 }
@@ -1,12 +1,13 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 
 	deliveryhttp "github.com/osmosis-labs/sqs/delivery/http"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
-	_ "github.com/osmosis-labs/sqs/domain/passthrough"
+	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
 	"github.com/osmosis-labs/sqs/log"
 	"github.com/osmosis-labs/sqs/orderbook/types"
 
@@ -37,6 +38,7 @@ func NewPassthroughHandler(e *echo.Echo, ptu mvc.PassthroughUsecase, ou mvc.Orde
 	}
 
 	e.GET(formatPassthroughResource("/portfolio-assets/:address"), handler.GetPortfolioAssetsByAddress)
+	e.GET(formatPassthroughResource("/account-balances/:address"), handler.GetAccountBalancesByAddress)
 	e.GET(formatPassthroughResource("/active-orders"), handler.GetActiveOrders)
 	e.GET(formatPassthroughResource("/active-orders"), func(c echo.Context) error {
 		if c.QueryParam("sse") != "" {
@@ -48,7 +50,7 @@ func NewPassthroughHandler(e *echo.Echo, ptu mvc.PassthroughUsecase, ou mvc.Orde
 
 // @Summary Returns portfolio assets associated with the given address by category.
 // @Description The returned data represents the potfolio asset breakdown by category for the specified address.
-// The categories include user balances, unstaking, staked, in-locks, pooled, unclaimed rewards, and total.
+// The categories include user balances, unstaking, staked, in-locks, pooled, concentrated positions, unclaimed rewards, and total.
 // The user balances and total assets are brokend down by-coin with the capitalization of the entire account value.
 //
 // @Produce  json
@@ -71,6 +73,44 @@ func (a *PassthroughHandler) GetPortfolioAssetsByAddress(c echo.Context) error {
 	return c.JSON(http.StatusOK, portfolioAssetsResult)
 }
 
+// @Summary Returns priced spendable balances associated with the given address.
+// @Description The returned data represents the spendable balances of the specified address broken down by-coin
+// with their capitalization as well as the total capitalization.
+// Contrary to the portfolio assets, locks, staking and pooled assets are not fetched.
+//
+// @Produce  json
+// @Success 200  {object}  passthroughdomain.PortfolioAssetsCategoryResult  "Spendable balances by-coin and their total capitalization"
+// @Success 206  {object}  passthroughdomain.PortfolioAssetsCategoryResult  "Best-effort balances if failed to price them"
+// @Failure 500  {object}  domain.ResponseError  "Response error"
+// @Param address path string true "Wallet Address"
+// @Router /passthrough/account-balances/{address} [get]
+func (a *PassthroughHandler) GetAccountBalancesByAddress(c echo.Context) error {
+	address := c.Param("address")
+
+	if address == "" {
+		return c.JSON(http.StatusInternalServerError, domain.ResponseError{Message: "invalid address: cannot be empty"})
+	}
+
+	accountCoinsResult, capitalization, err := a.PUsecase.GetPricedBalances(c.Request().Context(), address)
+	if err != nil {
+		// Balances were fetched but failed to be priced. Return the best-effort result.
+		if errors.As(err, &domain.BalancesPricingError{}) {
+			return c.JSON(http.StatusPartialContent, passthroughdomain.PortfolioAssetsCategoryResult{
+				Capitalization:     capitalization,
+				AccountCoinsResult: accountCoinsResult,
+				IsBestEffort:       true,
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, domain.ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, passthroughdomain.PortfolioAssetsCategoryResult{
+		Capitalization:     capitalization,
+		AccountCoinsResult: accountCoinsResult,
+	})
+}
+
 func (a *PassthroughHandler) GetActiveOrdersStream(c echo.Context) error {
 	var (
 		req types.GetActiveOrdersRequest
@@ -144,12 +184,12 @@ func (a *PassthroughHandler) GetActiveOrders(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
 	}
 
-	orders, isBestEffort, err := a.OUsecase.GetActiveOrders(ctx, req.UserOsmoAddress)
+	page, err := a.OUsecase.GetActiveOrders(ctx, req.UserOsmoAddress)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, domain.ResponseError{Message: types.ErrInternalError.Error()})
 	}
 
-	resp := types.NewGetAllOrderResponse(orders, isBestEffort)
+	resp := types.NewGetAllOrderResponse(page.LimitOrders, page.IsBestEffort)
 
 	return c.JSON(http.StatusOK, resp)
 }
@@ -9,9 +9,13 @@ import (
 	"strings"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/osmosis/osmomath"
 	deliveryhttp "github.com/osmosis-labs/sqs/delivery/http"
+	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mocks"
 	orderbookdomain "github.com/osmosis-labs/sqs/domain/orderbook"
+	passthroughdomain "github.com/osmosis-labs/sqs/domain/passthrough"
 	"github.com/osmosis-labs/sqs/log"
 	"github.com/osmosis-labs/sqs/orderbook/types"
 	"github.com/osmosis-labs/sqs/orderbook/usecase/orderbooktesting"
@@ -53,11 +57,13 @@ func (s *PassthroughHandlerTestSuite) TestGetActiveOrders() {
 				"userOsmoAddress": "osmo1ugku28hwyexpljrrmtet05nd6kjlrvr9jz6z00",
 			},
 			setupMocks: func(usecase *mocks.OrderbookUsecaseMock) {
-				usecase.GetActiveOrdersFunc = func(ctx context.Context, address string) ([]orderbookdomain.LimitOrder, bool, error) {
-					return []orderbookdomain.LimitOrder{
-						s.NewLimitOrder().WithOrderID(1).LimitOrder,
-						s.NewLimitOrder().WithOrderID(2).LimitOrder,
-					}, false, nil
+				usecase.GetActiveOrdersFunc = func(ctx context.Context, address string, opts ...orderbookdomain.ActiveOrdersOption) (orderbookdomain.ActiveOrdersPage, error) {
+					return orderbookdomain.ActiveOrdersPage{
+						LimitOrders: []orderbookdomain.LimitOrder{
+							s.NewLimitOrder().WithOrderID(1).LimitOrder,
+							s.NewLimitOrder().WithOrderID(2).LimitOrder,
+						},
+					}, nil
 				}
 			},
 			expectedStatusCode: http.StatusOK,
@@ -70,8 +76,8 @@ func (s *PassthroughHandlerTestSuite) TestGetActiveOrders() {
 				"userOsmoAddress": "osmo1ev0vtddkl7jlwfawlk06yzncapw2x9quva4wzw",
 			},
 			setupMocks: func(usecase *mocks.OrderbookUsecaseMock) {
-				usecase.GetActiveOrdersFunc = func(ctx context.Context, address string) ([]orderbookdomain.LimitOrder, bool, error) {
-					return nil, false, assert.AnError
+				usecase.GetActiveOrdersFunc = func(ctx context.Context, address string, opts ...orderbookdomain.ActiveOrdersOption) (orderbookdomain.ActiveOrdersPage, error) {
+					return orderbookdomain.ActiveOrdersPage{}, assert.AnError
 				}
 			},
 			expectedStatusCode: http.StatusInternalServerError,
@@ -242,3 +248,138 @@ func (s *PassthroughHandlerTestSuite) TestGetActiveOrdersStream() {
 		})
 	}
 }
+
+func (s *PassthroughHandlerTestSuite) TestGetPortfolioAssetsByAddress() {
+	const address = "osmo1ugku28hwyexpljrrmtet05nd6kjlrvr9jz6z00"
+
+	result := passthroughdomain.PortfolioAssetsResult{
+		Categories: map[string]passthroughdomain.PortfolioAssetsCategoryResult{
+			"total-assets": {
+				Capitalization: osmomath.NewDec(10),
+				IsBestEffort:   true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		address            string
+		err                error
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:               "empty address",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"message":"invalid address: cannot be empty"}`,
+		},
+		{
+			name:               "full result",
+			address:            address,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"categories":{"total-assets":{"capitalization":"10.000000000000000000","is_best_effort":true}}}`,
+		},
+		{
+			name:               "error -> partial content",
+			address:            address,
+			err:                assert.AnError,
+			expectedStatusCode: http.StatusPartialContent,
+			expectedResponse:   fmt.Sprintf(`{"message":"%s"}`, assert.AnError.Error()),
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("address")
+			c.SetParamValues(tc.address)
+
+			usecase := mocks.PassthroughUsecaseMock{
+				GetPortfolioAssetsFunc: func(ctx context.Context, address string, opts ...domain.PortfolioAssetsOption) (passthroughdomain.PortfolioAssetsResult, error) {
+					return result, tc.err
+				},
+			}
+
+			handler := passthroughdelivery.PassthroughHandler{PUsecase: &usecase}
+
+			err := handler.GetPortfolioAssetsByAddress(c)
+			s.Assert().NoError(err)
+
+			s.Assert().Equal(tc.expectedStatusCode, rec.Code)
+			s.Assert().JSONEq(tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
+
+func (s *PassthroughHandlerTestSuite) TestGetAccountBalancesByAddress() {
+	const address = "osmo1ugku28hwyexpljrrmtet05nd6kjlrvr9jz6z00"
+
+	coinsResult := []passthroughdomain.AccountCoinsResult{
+		{
+			Coin:                sdk.NewCoin("uosmo", osmomath.NewInt(5)),
+			CapitalizationValue: osmomath.NewDec(10),
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		address            string
+		err                error
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:               "empty address",
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"message":"invalid address: cannot be empty"}`,
+		},
+		{
+			name:               "full result",
+			address:            address,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"capitalization":"10.000000000000000000","account_coins_result":[{"coin":{"denom":"uosmo","amount":"5"},"cap_value":"10.000000000000000000"}],"is_best_effort":false}`,
+		},
+		{
+			name:               "pricing error -> best-effort result",
+			address:            address,
+			err:                domain.BalancesPricingError{Err: assert.AnError},
+			expectedStatusCode: http.StatusPartialContent,
+			expectedResponse:   `{"capitalization":"10.000000000000000000","account_coins_result":[{"coin":{"denom":"uosmo","amount":"5"},"cap_value":"10.000000000000000000"}],"is_best_effort":true}`,
+		},
+		{
+			name:               "balances error",
+			address:            address,
+			err:                assert.AnError,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   fmt.Sprintf(`{"message":"%s"}`, assert.AnError.Error()),
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("address")
+			c.SetParamValues(tc.address)
+
+			usecase := mocks.PassthroughUsecaseMock{
+				GetPricedBalancesFunc: func(ctx context.Context, address string) ([]passthroughdomain.AccountCoinsResult, osmomath.Dec, error) {
+					return coinsResult, osmomath.NewDec(10), tc.err
+				},
+			}
+
+			handler := passthroughdelivery.PassthroughHandler{PUsecase: &usecase}
+
+			err := handler.GetAccountBalancesByAddress(c)
+			s.Assert().NoError(err)
+
+			s.Assert().Equal(tc.expectedStatusCode, rec.Code)
+			s.Assert().JSONEq(tc.expectedResponse, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
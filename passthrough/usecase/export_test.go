@@ -9,13 +9,14 @@ import (
 )
 
 const (
-	UserBalancesAssetsCategoryName     = userBalancesAssetsCategoryName
-	UnstakingAssetsCategoryName        = unstakingAssetsCategoryName
-	StakedAssetsCategoryName           = stakedAssetsCategoryName
-	InLocksAssetsCategoryName          = inLocksAssetsCategoryName
-	PooledAssetsCategoryName           = pooledAssetsCategoryName
-	UnclaimedRewardsAssetsCategoryName = unclaimedRewardsAssetsCategoryName
-	TotalAssetsCategoryName            = totalAssetsCategoryName
+	UserBalancesAssetsCategoryName          = userBalancesAssetsCategoryName
+	UnstakingAssetsCategoryName             = unstakingAssetsCategoryName
+	StakedAssetsCategoryName                = stakedAssetsCategoryName
+	InLocksAssetsCategoryName               = inLocksAssetsCategoryName
+	PooledAssetsCategoryName                = pooledAssetsCategoryName
+	ConcentratedPositionsAssetsCategoryName = concentratedPositionsAssetsCategoryName
+	UnclaimedRewardsAssetsCategoryName      = unclaimedRewardsAssetsCategoryName
+	TotalAssetsCategoryName                 = totalAssetsCategoryName
 )
 
 var (
@@ -36,6 +37,6 @@ func (p *passthroughUseCase) HandleGammShares(balance sdk.Coin) (sdk.Coins, erro
 	return p.handleGammShares(balance)
 }
 
-func (p *passthroughUseCase) ComputeCapitalizationForCoins(ctx context.Context, coins sdk.Coins) ([]passthroughdomain.AccountCoinsResult, osmomath.Dec, error) {
-	return p.computeCapitalizationForCoins(ctx, coins)
+func (p *passthroughUseCase) ComputeCapitalizationForCoins(ctx context.Context, coins sdk.Coins, quoteDenom string) ([]passthroughdomain.AccountCoinsResult, osmomath.Dec, error) {
+	return p.computeCapitalizationForCoins(ctx, coins, quoteDenom)
 }
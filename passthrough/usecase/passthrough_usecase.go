@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"go.uber.org/zap"
@@ -28,53 +29,49 @@ type passthroughUseCase struct {
 }
 
 const (
-	userBalancesAssetsCategoryName     string = "user-balances"
-	unstakingAssetsCategoryName        string = "unstaking"
-	stakedAssetsCategoryName           string = "staked"
-	inLocksAssetsCategoryName          string = "in-locks"
-	pooledAssetsCategoryName           string = "pooled"
-	unclaimedRewardsAssetsCategoryName string = "unclaimed-rewards"
-	totalAssetsCategoryName            string = "total-assets"
+	userBalancesAssetsCategoryName          string = "user-balances"
+	unstakingAssetsCategoryName             string = "unstaking"
+	stakedAssetsCategoryName                string = "staked"
+	inLocksAssetsCategoryName               string = "in-locks"
+	pooledAssetsCategoryName                string = "pooled"
+	concentratedPositionsAssetsCategoryName string = "concentrated-positions"
+	unclaimedRewardsAssetsCategoryName      string = "unclaimed-rewards"
+	totalAssetsCategoryName                 string = "total-assets"
 )
 
-// fetchBalancesPortfolioAssetsJob represents a job to fetch balances for a given category
-// in a portfolio assets query.
-type fetchBalancesPortfolioAssetsJob struct {
+// portfolioAssetsCategoryJob represents a job to compute the capitalization
+// of a given category in a portfolio assets query from the fetched coins.
+type portfolioAssetsCategoryJob struct {
 	// name of the category
 	name string
 	// whether to breakdown the capitalization of the category
 	shouldBreakdownCapitalization bool
-	// fetchFn is the function to fetch the balances for the category
-	fetchFn passthroughdomain.PassthroughFetchFn
+	// fetched coins for the category
+	fetched coinsResult
 }
 
-// coinsResult represents the result of fetching coins
-type coinsResult struct {
-	// coins fetched
-	coins sdk.Coins
-	// error encountered during fetching
-	err error
+// gammShareExpansionCache caches the underlying coins of gamm shares for the lifetime
+// of a single request so that identical shares (e.g. across locked and unlocking coins)
+// are expanded once. Errors are cached as well.
+// A nil cache expands the shares on every call.
+type gammShareExpansionCache struct {
+	mu      sync.Mutex
+	entries map[string]*gammShareExpansion
 }
 
-// totalAssetsCompositionPortfolioAssetsJob represents a job to compose the total portfolio assets
-// from the fetched balances.
-// Total assets = user balances + staked + unstaking + (pooled - in-locks) + unclaimed-rewards
-type totalAssetsCompositionPortfolioAssetsJob struct {
-	// name of the category
-	name string
-	// coins fetched
+// gammShareExpansion represents the result of expanding a gamm share
+// into its underlying coins.
+type gammShareExpansion struct {
+	once  sync.Once
 	coins sdk.Coins
-	// any error encountered during the pipiline for any of the categories.
-	err error
+	err   error
 }
 
-// finalResultPortfolioAssetsJob represents a job to finalize the portfolio assets categories.
-type finalResultPortfolioAssetsJob struct {
-	// name of the category
-	name string
-	// result of the category
-	result passthroughdomain.PortfolioAssetsCategoryResult
-	// any error encountered during the pipiline for constructing the category.
+// coinsResult represents the result of fetching coins
+type coinsResult struct {
+	// coins fetched
+	coins sdk.Coins
+	// error encountered during fetching
 	err error
 }
 
@@ -85,23 +82,6 @@ const (
 	concentratedSharePrefix = "cl"
 	denomShareSeparator     = "/"
 	denomShareSeparatorByte = '/'
-
-	numFinalResultJobs = 7
-
-	totalAssetCompositionNumJobs = 6
-
-	// Number of pooled balance jobs to fetch concurrently.
-	// 1. Gamm shares from user balances
-	// 2. Concentrated positions
-	pooledBalancedNumJobs = 2
-
-	// Number of unclaimed rewards jobs to fetch concurrently.
-	// 1. Unclaimed rewards from concentrated positions
-	// 2. Unclaimed rewards from staking rewards
-	unclaimedRewardsNumJobs = 2
-
-	// locked + unlocking
-	numInLocksQueries = 2
 )
 
 // NewPassThroughUsecase Creates a passthrough use case
@@ -120,278 +100,298 @@ func NewPassThroughUsecase(passthroughGRPCClient passthroughdomain.PassthroughGR
 }
 
 // GetPortfolioBalances implements mvc.PassthroughUsecase.
-func (p *passthroughUseCase) GetPortfolioAssets(ctx context.Context, address string) (passthroughdomain.PortfolioAssetsResult, error) {
-	// Channel to fetch bank balances concurrently.
-	bankBalancesChan := make(chan coinsResult)
-	defer close(bankBalancesChan)
+func (p *passthroughUseCase) GetPortfolioAssets(ctx context.Context, address string, opts ...domain.PortfolioAssetsOption) (passthroughdomain.PortfolioAssetsResult, error) {
+	options := domain.PortfolioAssetsOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	// Fall back to the default quote denom if unset
+	if options.QuoteDenom == "" {
+		options.QuoteDenom = p.defaultQuoteDenom
+	}
 
-	// Channel to fetch pooled balances concurrently.
-	// Pool balances arrive from gamm shares and concentrated positions.
-	pooledBalancesChan := make(chan coinsResult, pooledBalancedNumJobs)
-	defer close(pooledBalancesChan)
+	var (
+		bankBalancesResult     coinsResult
+		gammSharesResult       coinsResult
+		positionBalancesResult coinsResult
+		positionRewardsResult  coinsResult
+		stakingRewardsResult   coinsResult
+		unbondingResult        coinsResult
+		delegationsResult      coinsResult
+		inLocksResult          coinsResult
+	)
 
-	// Channel to fetch unclaimed rewards concurrently.
-	unclaimedRewardsChan := make(chan coinsResult, unclaimedRewardsNumJobs)
-	defer close(unclaimedRewardsChan)
+	// Fetch all balances concurrently.
+	// A failing query does not affect the others. Instead, its error is persisted
+	// and propagated as the best-effort result of the relevant categories.
+	var fetchWg sync.WaitGroup
 
+	fetchWg.Add(1)
 	go func() {
-		// Fetch bank balances and gamm shares concurrently
+		defer fetchWg.Done()
+		// Bank balances and gamm shares from the bank balances
 		bankBalances, gammShareCoins, err := p.getBankBalances(ctx, address)
-
-		// Send the results to the user balances channel
-		bankBalancesChan <- coinsResult{
-			coins: bankBalances,
-			err:   err,
-		}
-
-		// Send gamm shares to the pooled balances channel
-		pooledBalancesChan <- coinsResult{
-			coins: gammShareCoins,
-			err:   err,
-		}
+		bankBalancesResult = coinsResult{coins: bankBalances, err: err}
+		gammSharesResult = coinsResult{coins: gammShareCoins, err: err}
 	}()
 
+	fetchWg.Add(1)
 	go func() {
-		// Fetch concentrated positions and unclaimed rewards concurrently
-		positionBalances, unclaimedRewads, err := p.passthroughGRPCClient.UserPositionsBalances(ctx, address)
-
-		// Send the position balances to the pooled balances channel
-		pooledBalancesChan <- coinsResult{
-			coins: positionBalances,
-			err:   err,
-		}
-
-		// Send unclaimed rewards to the unclaimed rewards channel
-		unclaimedRewardsChan <- coinsResult{
-			coins: unclaimedRewads,
-			err:   err,
-		}
+		defer fetchWg.Done()
+		// Concentrated positions and their unclaimed rewards
+		positionBalances, unclaimedRewards, err := p.passthroughGRPCClient.UserPositionsBalances(ctx, address)
+		positionBalancesResult = coinsResult{coins: positionBalances, err: err}
+		positionRewardsResult = coinsResult{coins: unclaimedRewards, err: err}
 	}()
 
+	fetchWg.Add(1)
 	go func() {
-		// Fetch unclaimed staking rewards concurrently
+		defer fetchWg.Done()
 		unclaimedStakingRewards, err := p.passthroughGRPCClient.DelegationRewards(ctx, address)
-
-		// Send unclaimed rewards to the unclaimed rewards channel
-		unclaimedRewardsChan <- coinsResult{
-			coins: unclaimedStakingRewards,
-			err:   err,
-		}
+		stakingRewardsResult = coinsResult{coins: unclaimedStakingRewards, err: err}
 	}()
 
-	// Aggregate poold coins callback
-	getPooledCoins := func(ctx context.Context, address string) (sdk.Coins, error) {
-		pooledCoins := sdk.Coins{}
-
-		var finalErr error
-		for i := 0; i < pooledBalancedNumJobs; i++ {
-			pooledCoinsResult := <-pooledBalancesChan
-			if pooledCoinsResult.err != nil {
-				// Rather than returning the error, log it and continue
-				finalErr = pooledCoinsResult.err
-
-				// Ensure that coins are valid to be added and avoid panic.
-				if len(pooledCoinsResult.coins) > 0 && !pooledCoinsResult.coins.IsAnyNil() {
-					pooledCoins = pooledCoins.Add(pooledCoinsResult.coins...)
-				}
-
-				continue
-			}
-
-			pooledCoins = pooledCoins.Add(pooledCoinsResult.coins...)
-		}
-
-		// Return error and best-effort result
-		return pooledCoins, finalErr
-	}
-
-	// Callback to fetch bank balances concurrently.
-	getBankBalances := func(ctx context.Context, address string) (sdk.Coins, error) {
-		bankBalancesResult := <-bankBalancesChan
-		return bankBalancesResult.coins, bankBalancesResult.err
-	}
-
-	// Callback to fetch unclaimed rewards concurrently.
-	getUnclaimedRewards := func(ctx context.Context, address string) (sdk.Coins, error) {
-		unclaimedCoins := sdk.Coins{}
-
-		var finalErr error
-		for i := 0; i < unclaimedRewardsNumJobs; i++ {
-			unclaimedRewardsResult := <-unclaimedRewardsChan
-
-			if unclaimedRewardsResult.err != nil {
-				// Rather than returning the error, log it and continue
-				finalErr = unclaimedRewardsResult.err
-
-				// Ensure that coins are valid to be added and avoid panic.
-				if len(unclaimedRewardsResult.coins) > 0 && !unclaimedRewardsResult.coins.IsAnyNil() {
-					unclaimedCoins = unclaimedCoins.Add(unclaimedRewardsResult.coins...)
-				}
+	fetchWg.Add(1)
+	go func() {
+		defer fetchWg.Done()
+		unbonding, err := p.passthroughGRPCClient.DelegatorUnbondingDelegations(ctx, address)
+		unbondingResult = coinsResult{coins: unbonding, err: err}
+	}()
 
-				continue
-			}
+	fetchWg.Add(1)
+	go func() {
+		defer fetchWg.Done()
+		delegations, err := p.passthroughGRPCClient.DelegatorDelegations(ctx, address)
+		delegationsResult = coinsResult{coins: delegations, err: err}
+	}()
 
-			unclaimedCoins = unclaimedCoins.Add(unclaimedRewardsResult.coins...)
-		}
+	fetchWg.Add(1)
+	go func() {
+		defer fetchWg.Done()
+		inLocks, err := p.getCoinsFromLocks(ctx, address)
+		inLocksResult = coinsResult{coins: inLocks, err: err}
+	}()
 
-		// Return error and best-effort result
-		return unclaimedCoins, finalErr
-	}
+	fetchWg.Wait()
 
-	// Fetch jobs to fetch the portfolio assets concurrently in separate gorooutines.
-	fetchJobs := []fetchBalancesPortfolioAssetsJob{
+	categoryJobs := []portfolioAssetsCategoryJob{
 		{
 			name: userBalancesAssetsCategoryName,
 			// User balances should be broken down by asset capitalization for each
 			// individual coin.
 			shouldBreakdownCapitalization: true,
-			fetchFn:                       getBankBalances,
+			fetched:                       bankBalancesResult,
 		},
 		{
-			name:    unstakingAssetsCategoryName,
-			fetchFn: p.passthroughGRPCClient.DelegatorUnbondingDelegations,
+			name:                          unstakingAssetsCategoryName,
+			shouldBreakdownCapitalization: options.IsDetailed,
+			fetched:                       unbondingResult,
 		},
 		{
-			name:    stakedAssetsCategoryName,
-			fetchFn: p.passthroughGRPCClient.DelegatorDelegations,
+			name:                          stakedAssetsCategoryName,
+			shouldBreakdownCapitalization: options.IsDetailed,
+			fetched:                       delegationsResult,
 		},
 		{
-			name:    inLocksAssetsCategoryName,
-			fetchFn: p.getCoinsFromLocks,
+			name:                          inLocksAssetsCategoryName,
+			shouldBreakdownCapitalization: options.IsDetailed,
+			fetched:                       inLocksResult,
 		},
 		{
 			name:    unclaimedRewardsAssetsCategoryName,
-			fetchFn: getUnclaimedRewards,
+			fetched: mergeCoinsResults(positionRewardsResult, stakingRewardsResult),
+		},
+		{
+			// Classic pool shares only. Concentrated positions are reported
+			// in their own category to avoid double counting.
+			name:                          pooledAssetsCategoryName,
+			shouldBreakdownCapitalization: options.IsDetailed,
+			fetched:                       gammSharesResult,
 		},
 		{
-			name:    pooledAssetsCategoryName,
-			fetchFn: getPooledCoins,
+			name:                          concentratedPositionsAssetsCategoryName,
+			shouldBreakdownCapitalization: options.IsDetailed,
+			fetched:                       positionBalancesResult,
 		},
 	}
 
-	totalAssetsCompositionJobs := make(chan totalAssetsCompositionPortfolioAssetsJob, totalAssetCompositionNumJobs)
+	// Total assets = user balances + staked + unstaking + (pooled - in-locks) + concentrated-positions + unclaimed-rewards
+	totalAssetsComposition := make([]coinsResult, 0, len(categoryJobs))
+	for _, job := range categoryJobs {
+		if job.fetched.err != nil {
+			p.logger.Error("error fetching balances for category", zap.Error(job.fetched.err), zap.String("category", job.name), zap.String("address", address))
+		}
+
+		totalAssetsComposition = append(totalAssetsComposition, job.fetched)
+	}
 
-	finalResultsJobs := make(chan finalResultPortfolioAssetsJob, numFinalResultJobs)
-	defer close(finalResultsJobs)
+	categoryJobs = append(categoryJobs, portfolioAssetsCategoryJob{
+		name: totalAssetsCategoryName,
+		// Total assets should be broken down by asset capitalization for each
+		// individual coin.
+		shouldBreakdownCapitalization: true,
+		fetched:                       mergeCoinsResults(totalAssetsComposition...),
+	})
+
+	// Compute the capitalization of all categories concurrently.
+	// Results are indexed by job to aggregate them deterministically.
+	categoryResults := make([]passthroughdomain.PortfolioAssetsCategoryResult, len(categoryJobs))
+
+	var capitalizationWg sync.WaitGroup
+	for i, job := range categoryJobs {
+		capitalizationWg.Add(1)
+		go func() {
+			defer capitalizationWg.Done()
+			categoryResults[i] = p.computePortfolioAssetsCategory(ctx, address, options, job)
+		}()
+	}
+
+	capitalizationWg.Wait()
 
 	finalResult := passthroughdomain.PortfolioAssetsResult{
-		Categories: make(map[string]passthroughdomain.PortfolioAssetsCategoryResult, numFinalResultJobs),
+		Categories: make(map[string]passthroughdomain.PortfolioAssetsCategoryResult, len(categoryJobs)),
 	}
 
-	for _, fetchJob := range fetchJobs {
-		go func(job fetchBalancesPortfolioAssetsJob) {
-			// Fetch the balances for the category
-			result, finalErr := job.fetchFn(ctx, address)
+	for i, job := range categoryJobs {
+		finalResult.Categories[job.name] = categoryResults[i]
+	}
 
-			if finalErr != nil {
-				p.logger.Error("error fetching balances for category", zap.Error(finalErr), zap.String("category", job.name), zap.String("address", address))
-			}
+	return finalResult, nil
+}
 
-			// Send the result to the total assets composition channel
-			totalAssetsCompositionJobs <- totalAssetsCompositionPortfolioAssetsJob{
-				name:  job.name,
-				coins: result,
-				err:   finalErr,
-			}
+// GetPortfolioWithDelta implements mvc.PassthroughUsecase.
+func (p *passthroughUseCase) GetPortfolioWithDelta(ctx context.Context, address string, previous osmomath.Dec) (passthroughdomain.PortfolioDeltaResult, error) {
+	portfolioAssets, err := p.GetPortfolioAssets(ctx, address)
+	if err != nil {
+		return passthroughdomain.PortfolioDeltaResult{}, err
+	}
 
-			// Skip the category if it is excluded from the final result.
-			byAssetCapBreakdown, totalCap, err := p.computeCapitalizationForCoins(ctx, result)
-			// Rather than returning the error, persist it and propagate in the pipeline
-			// to compute final result.
-			if err != nil {
-				finalErr = fmt.Errorf("%v, %v", finalErr, err)
+	totalAssets := portfolioAssets.Categories[totalAssetsCategoryName]
 
-				p.logger.Error("error computing capitalization for category", zap.Error(err), zap.String("category", job.name), zap.String("address", address))
-			}
+	// Treat unset previous value as zero
+	if previous.IsNil() {
+		previous = osmomath.ZeroDec()
+	}
 
-			finalJob := finalResultPortfolioAssetsJob{
-				name: job.name,
-				result: passthroughdomain.PortfolioAssetsCategoryResult{
-					Capitalization: totalCap,
-					IsBestEffort:   finalErr != nil,
-				},
-				err: finalErr,
-			}
+	absoluteDelta := totalAssets.Capitalization.Sub(previous)
 
-			// Breakdown the capitalization of the category by asset.
-			if job.shouldBreakdownCapitalization {
-				finalJob.result.AccountCoinsResult = byAssetCapBreakdown
-			}
+	// Guard against division by zero
+	percentageDelta := osmomath.ZeroDec()
+	if !previous.IsZero() {
+		percentageDelta = absoluteDelta.Quo(previous).MulInt64(100)
+	}
 
-			// Send the final result to the final results channel
-			finalResultsJobs <- finalJob
-		}(fetchJob)
+	return passthroughdomain.PortfolioDeltaResult{
+		TotalValueCap:   totalAssets.Capitalization,
+		AbsoluteDelta:   absoluteDelta,
+		PercentageDelta: percentageDelta,
+		IsBestEffort:    totalAssets.IsBestEffort,
+	}, nil
+}
+
+// GetPricedBalances implements mvc.PassthroughUsecase.
+func (p *passthroughUseCase) GetPricedBalances(ctx context.Context, address string) ([]passthroughdomain.AccountCoinsResult, osmomath.Dec, error) {
+	// Note that gamm shares are dropped as they are not considered spendable balances
+	// but rather pooled assets.
+	bankBalances, _, err := p.getBankBalances(ctx, address)
+	if err != nil {
+		return nil, osmomath.Dec{}, err
 	}
 
-	go func() {
-		totalAssetsCompositionCoins := sdk.Coins{}
-		var finalErr error
-		for i := 0; i < totalAssetCompositionNumJobs; i++ {
-			job := <-totalAssetsCompositionJobs
-			if job.err != nil {
-				// Attempt to add the coins to the total assets composition
-				// even if an error occurred.
-				if len(job.coins) > 0 && !job.coins.IsAnyNil() {
-					totalAssetsCompositionCoins = totalAssetsCompositionCoins.Add(job.coins...)
-				}
-
-				// Rather than returning the error, persist it
-				if finalErr == nil {
-					finalErr = job.err
-				} else {
-					finalErr = fmt.Errorf("%v, %v", finalErr, job.err)
-				}
-				continue
-			}
+	coinsWithPrices, capitalization, err := p.computeCapitalizationForCoins(ctx, bankBalances, p.defaultQuoteDenom)
+	if err != nil {
+		// The balances are returned as a best-effort result.
+		return coinsWithPrices, capitalization, domain.BalancesPricingError{Err: err}
+	}
 
-			totalAssetsCompositionCoins = totalAssetsCompositionCoins.Add(job.coins...)
-		}
+	return coinsWithPrices, capitalization, nil
+}
 
-		totalAssetsResult, totalAssetsCap, err := p.computeCapitalizationForCoins(ctx, totalAssetsCompositionCoins)
-		if err != nil {
-			// Rather than returning the error, persist it
-			finalErr = fmt.Errorf("%v, %v", finalErr, err)
+// computePortfolioAssetsCategory computes the capitalization of the given category job in the quote denom
+// from the given options.
+// Any error encountered during fetching or computing capitalization is not returned but rather reflected
+// in the best-effort flag of the result.
+func (p *passthroughUseCase) computePortfolioAssetsCategory(ctx context.Context, address string, options domain.PortfolioAssetsOptions, job portfolioAssetsCategoryJob) passthroughdomain.PortfolioAssetsCategoryResult {
+	finalErr := job.fetched.err
+
+	byAssetCapBreakdown, totalCap, err := p.computeCapitalizationForCoins(ctx, job.fetched.coins, options.QuoteDenom)
+	if err != nil {
+		finalErr = fmt.Errorf("%v, %v", finalErr, err)
+
+		p.logger.Error("error computing capitalization for category", zap.Error(err), zap.String("category", job.name), zap.String("address", address))
+	}
+
+	result := passthroughdomain.PortfolioAssetsCategoryResult{
+		Capitalization: totalCap,
+		IsBestEffort:   finalErr != nil,
+	}
+
+	// Breakdown the capitalization of the category by asset.
+	if job.shouldBreakdownCapitalization {
+		result.AccountCoinsResult = byAssetCapBreakdown
 
-			p.logger.Error("error computing total assets capitalization for total assets composition", zap.Error(err), zap.String("address", address))
+		if options.HideZeroValue {
+			result.AccountCoinsResult, result.HiddenCount = filterZeroValueCoins(byAssetCapBreakdown)
 		}
+	}
 
-		finalResultsJobs <- finalResultPortfolioAssetsJob{
-			name: totalAssetsCategoryName,
-			result: passthroughdomain.PortfolioAssetsCategoryResult{
-				Capitalization:     totalAssetsCap,
-				AccountCoinsResult: totalAssetsResult,
-				IsBestEffort:       finalErr != nil,
-			},
-			err: finalErr,
+	return result
+}
+
+// filterZeroValueCoins returns the given account coins without the ones with zero capitalization
+// as well as the number of coins filtered out.
+// Note that the total capitalization is unaffected since zero-value coins do not contribute to it.
+func filterZeroValueCoins(accountCoins []passthroughdomain.AccountCoinsResult) ([]passthroughdomain.AccountCoinsResult, int) {
+	nonZeroCoins := make([]passthroughdomain.AccountCoinsResult, 0, len(accountCoins))
+	for _, accountCoin := range accountCoins {
+		if accountCoin.CapitalizationValue.IsZero() {
+			continue
 		}
-	}()
 
-	// Aggregate all results
-	// 1. User balances (available) - broken down by asset capitalization
-	// 2. Total assets - broken down by asset capitalization
-	// 3. Unstaking
-	// 4. Staked
-	// 5. Unclaimed rewards
-	// 6. Pooled
-	// 7. In-locks
-	for i := 0; i < numFinalResultJobs; i++ {
-		job := <-finalResultsJobs
-		isBestEffort := job.err != nil
-		finalResult.Categories[job.name] = passthroughdomain.PortfolioAssetsCategoryResult{
-			IsBestEffort:       isBestEffort,
-			AccountCoinsResult: job.result.AccountCoinsResult,
-			Capitalization:     job.result.Capitalization,
+		nonZeroCoins = append(nonZeroCoins, accountCoin)
+	}
+
+	return nonZeroCoins, len(accountCoins) - len(nonZeroCoins)
+}
+
+// mergeCoinsResults merges the given coins results into one.
+// If any of the results has an error, its coins are still added on a best-effort basis
+// given that they are valid. All errors are combined into the final error.
+func mergeCoinsResults(results ...coinsResult) coinsResult {
+	merged := coinsResult{
+		coins: sdk.Coins{},
+	}
+
+	for _, result := range results {
+		if result.err != nil {
+			// Ensure that coins are valid to be added and avoid panic.
+			if len(result.coins) > 0 && !result.coins.IsAnyNil() {
+				merged.coins = merged.coins.Add(result.coins...)
+			}
+
+			// Rather than returning the error, persist it
+			if merged.err == nil {
+				merged.err = result.err
+			} else {
+				merged.err = fmt.Errorf("%v, %v", merged.err, result.err)
+			}
+
+			continue
 		}
+
+		merged.coins = merged.coins.Add(result.coins...)
 	}
 
-	return finalResult, nil
+	return merged
 }
 
-// computeCapitalizationForCoins instruments the coins with their liquiditiy capitalization values.
+// computeCapitalizationForCoins instruments the coins with their liquiditiy capitalization values
+// denominated in the given quote denom.
 // Returns a slice of entries containing each coin and their capialization values. Additionally, returns the capitalization total.
 // If coin is not valid, it is skipped from pricing and its capitalization is set to zero.
 // Returns error if fails to get prices for the coins. However, a best-effort account coins result is returned even if prices fail to be computed.
-func (p *passthroughUseCase) computeCapitalizationForCoins(ctx context.Context, coins sdk.Coins) ([]passthroughdomain.AccountCoinsResult, osmomath.Dec, error) {
+func (p *passthroughUseCase) computeCapitalizationForCoins(ctx context.Context, coins sdk.Coins, quoteDenom string) ([]passthroughdomain.AccountCoinsResult, osmomath.Dec, error) {
 	coinDenomsToPrice := make([]string, 0, len(coins))
 	for _, coin := range coins {
 		if p.tokensUseCase.IsValidChainDenom(coin.Denom) {
@@ -402,7 +402,7 @@ func (p *passthroughUseCase) computeCapitalizationForCoins(ctx context.Context,
 	}
 
 	// Compute prices for the final coins
-	priceResult, err := p.tokensUseCase.GetPrices(ctx, coinDenomsToPrice, []string{p.defaultQuoteDenom}, domain.ChainPricingSourceType)
+	priceResult, err := p.tokensUseCase.GetPrices(ctx, coinDenomsToPrice, []string{quoteDenom}, domain.ChainPricingSourceType)
 	if err != nil {
 		// Instead of returning an error, attempt to return a best-effort result
 		// where all prices are zero.
@@ -414,7 +414,7 @@ func (p *passthroughUseCase) computeCapitalizationForCoins(ctx context.Context,
 	capitalizationTotal := osmomath.ZeroDec()
 
 	for _, coin := range coins {
-		price := priceResult.GetPriceForDenom(coin.Denom, p.defaultQuoteDenom)
+		price := priceResult.GetPriceForDenom(coin.Denom, quoteDenom)
 
 		coinCapitalization := p.liquidityPricer.PriceCoin(coin, price)
 
@@ -436,7 +436,7 @@ func (p *passthroughUseCase) computeCapitalizationForCoins(ctx context.Context,
 // If encountering concentrated shares, it will skip them
 // For every coin, adds the underlying coins to the total coins.
 // Returns error if fails to get locked coins.
-func (p *passthroughUseCase) getLockedCoins(ctx context.Context, address string, fetchLocksFn passthroughdomain.PassthroughFetchFn) (sdk.Coins, error) {
+func (p *passthroughUseCase) getLockedCoins(ctx context.Context, address string, fetchLocksFn passthroughdomain.PassthroughFetchFn, gammShareCache *gammShareExpansionCache) (sdk.Coins, error) {
 	// User locked/unlocking assets including GAMM shares
 	lockedCoins, err := fetchLocksFn(ctx, address)
 	if err != nil {
@@ -447,7 +447,7 @@ func (p *passthroughUseCase) getLockedCoins(ctx context.Context, address string,
 
 	for _, lockedCoin := range lockedCoins {
 		// calc underlying coins from GAMM shares, only expect gamm shares
-		accumulated, err := p.tryAccumulateGammShares(&coins, lockedCoin)
+		accumulated, err := p.tryAccumulateGammShares(&coins, lockedCoin, gammShareCache)
 		if accumulated || err != nil {
 			continue
 		}
@@ -466,31 +466,40 @@ func (p *passthroughUseCase) getLockedCoins(ctx context.Context, address string,
 // If encountering GAMM shares, it will convert them to underlying coins
 // If encountering concentrated shares, it will skip them
 // For every coin, adds the underlying coins to the total coins.
+// Identical GAMM shares are converted once per call.
 // Returns error if fails to get locked coins but the best-effort result is returned still
 func (p *passthroughUseCase) getCoinsFromLocks(ctx context.Context, address string) (sdk.Coins, error) {
-	result := make(chan coinsResult, numInLocksQueries)
-	defer close(result)
+	// Locked and unlocking coins commonly contain the same GAMM shares.
+	gammShareCache := newGammShareExpansionCache()
 
-	for _, fetchLocksFn := range []passthroughdomain.PassthroughFetchFn{
+	fetchLocksFns := []passthroughdomain.PassthroughFetchFn{
 		p.passthroughGRPCClient.AccountLockedCoins,
 		p.passthroughGRPCClient.AccountUnlockingCoins,
-	} {
-		go func(fetchLocksFn passthroughdomain.PassthroughFetchFn) {
-			lockedCoins, err := p.getLockedCoins(ctx, address, fetchLocksFn)
-			result <- coinsResult{
+	}
+
+	results := make([]coinsResult, len(fetchLocksFns))
+
+	var fetchWg sync.WaitGroup
+	for i, fetchLocksFn := range fetchLocksFns {
+		fetchWg.Add(1)
+		go func() {
+			defer fetchWg.Done()
+			lockedCoins, err := p.getLockedCoins(ctx, address, fetchLocksFn, gammShareCache)
+			results[i] = coinsResult{
 				coins: lockedCoins,
 				err:   err,
 			}
-		}(fetchLocksFn)
+		}()
 	}
 
+	fetchWg.Wait()
+
 	var (
 		coinsResult = sdk.Coins{}
 		finalErr    error
 	)
 
-	for i := 0; i < numInLocksQueries; i++ {
-		res := <-result
+	for _, res := range results {
 		if res.err != nil {
 			// Skip silently and continue
 			finalErr = res.err
@@ -524,7 +533,7 @@ func (p *passthroughUseCase) getBankBalances(ctx context.Context, address string
 
 	for _, balance := range allBalances {
 		// calc underlying coins from GAMM shares, only expect gamm shares
-		accumulated, err := p.tryAccumulateGammShares(&gammShareCoins, balance)
+		accumulated, err := p.tryAccumulateGammShares(&gammShareCoins, balance, nil)
 		if accumulated || err != nil {
 			continue
 		}
@@ -555,9 +564,12 @@ func (p *passthroughUseCase) handleGammShares(coin sdk.Coin) (sdk.Coins, error)
 	return exitCoins, nil
 }
 
-func (p *passthroughUseCase) tryAccumulateGammShares(coinsTarget *sdk.Coins, coin sdk.Coin) (isGammShare bool, err error) {
+// tryAccumulateGammShares converts the coin to underlying coins and adds them to the target
+// if the coin is a GAMM share. The conversion is looked up in the given cache if non-nil.
+// Returns true if the coin is a GAMM share, and error if the conversion fails.
+func (p *passthroughUseCase) tryAccumulateGammShares(coinsTarget *sdk.Coins, coin sdk.Coin, gammShareCache *gammShareExpansionCache) (isGammShare bool, err error) {
 	if strings.HasPrefix(coin.Denom, gammSharePrefix) {
-		exitCoins, err := p.handleGammShares(coin)
+		exitCoins, err := gammShareCache.getOrExpand(coin, p.handleGammShares)
 		if err != nil {
 			p.logger.Error("error converting gamm share from balances to underlying coins", zap.Error(err))
 			return true, err
@@ -570,3 +582,36 @@ func (p *passthroughUseCase) tryAccumulateGammShares(coinsTarget *sdk.Coins, coi
 	}
 	return false, nil
 }
+
+// newGammShareExpansionCache returns a new empty gamm share expansion cache.
+func newGammShareExpansionCache() *gammShareExpansionCache {
+	return &gammShareExpansionCache{
+		entries: make(map[string]*gammShareExpansion),
+	}
+}
+
+// getOrExpand returns the underlying coins of the given gamm share, expanding it
+// with the given function only once per pool ID and share amount.
+// Concurrent callers for the same share wait for the first expansion to complete.
+func (c *gammShareExpansionCache) getOrExpand(coin sdk.Coin, expandFn func(coin sdk.Coin) (sdk.Coins, error)) (sdk.Coins, error) {
+	if c == nil {
+		return expandFn(coin)
+	}
+
+	// Denom contains the pool ID
+	key := coin.String()
+
+	c.mu.Lock()
+	expansion, ok := c.entries[key]
+	if !ok {
+		expansion = &gammShareExpansion{}
+		c.entries[key] = expansion
+	}
+	c.mu.Unlock()
+
+	expansion.once.Do(func() {
+		expansion.coins, expansion.err = expandFn(coin)
+	})
+
+	return expansion.coins, expansion.err
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
@@ -97,6 +99,20 @@ var (
 
 	emptyPrices = domain.PricesResult{}
 
+	// Prices quoted in ATOM such that every non-ATOM capitalization
+	// is half of the one quoted in USDC.
+	atomQuotePriceResult = domain.PricesResult{
+		UOSMO: {
+			ATOM: osmoPrice.QuoInt64(2),
+		},
+		ATOM: {
+			ATOM: osmomath.OneBigDec(),
+		},
+		WBTC: {
+			ATOM: wbtcPrice.QuoInt64(2),
+		},
+	}
+
 	////////////////////////////
 	// Mocks
 
@@ -213,6 +229,9 @@ func (s *PassthroughUseCaseTestSuite) TestGetPotrfolioAssets_HappyPath() {
 				IsBestEffort:   true,
 			},
 			usecase.PooledAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.ConcentratedPositionsAssetsCategoryName: {
 				Capitalization: wbtcCapitalization,
 				IsBestEffort:   true,
 			},
@@ -247,6 +266,594 @@ func (s *PassthroughUseCaseTestSuite) TestGetPotrfolioAssets_HappyPath() {
 
 	// Assert the results are correct.
 	s.validatePortfolioAssetsResult(expectedResult, actualPortfolioAssets)
+
+	// System under test: detailed portfolio
+	actualPortfolioAssets, err = pu.GetPortfolioAssets(context.TODO(), defaultAddress, domain.WithDetailedPortfolio())
+	s.Require().NoError(err)
+
+	// Staked, unstaking, in-locks, pooled and concentrated positions categories are additionally broken down by coin.
+	expectedDetailedCategories := map[string][]passthroughdomain.AccountCoinsResult{
+		usecase.UnstakingAssetsCategoryName: {
+			{
+				Coin:                atomCoin,
+				CapitalizationValue: atomCapitalization,
+			},
+			{
+				Coin:                osmoCoin,
+				CapitalizationValue: osmoCapitalization,
+			},
+		},
+		usecase.StakedAssetsCategoryName: {
+			{
+				Coin:                osmoCoin,
+				CapitalizationValue: osmoCapitalization,
+			},
+		},
+		usecase.InLocksAssetsCategoryName: {},
+		usecase.PooledAssetsCategoryName:  {},
+		usecase.ConcentratedPositionsAssetsCategoryName: {
+			{
+				Coin:                wbtcCoin,
+				CapitalizationValue: wbtcCapitalization,
+			},
+		},
+	}
+
+	for categoryName, accountCoinsResult := range expectedDetailedCategories {
+		category := expectedResult.Categories[categoryName]
+		category.AccountCoinsResult = accountCoinsResult
+		expectedResult.Categories[categoryName] = category
+	}
+
+	s.validatePortfolioAssetsResult(expectedResult, actualPortfolioAssets)
+}
+
+// Tests that get portfolio assets computes capitalization of every category
+// and the total assets in the quote denom configured via options.
+// Prices are mocked such that each non-ATOM capitalization is half of the one quoted in USDC.
+func (s *PassthroughUseCaseTestSuite) TestGetPortfolioAssets_QuoteDenom() {
+	tokensUsecaseMock := mocks.TokensUsecaseMock{
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			// Validate that the prices are requested in ATOM
+			s.Require().Equal([]string{ATOM}, quoteDenoms)
+
+			return atomQuotePriceResult, nil
+		},
+
+		IsValidChainDenomFunc: isValidChainDenomFuncMock,
+	}
+
+	grpcClientMock := mocks.PassthroughGRPCClientMock{
+		MockAllBalancesCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.NewCoins(osmoCoin), nil
+		},
+		MockAccountLockedCoinsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockAccountUnlockingCoinsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockDelegatorDelegationsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.NewCoins(atomCoin), nil
+		},
+		MockDelegatorUnbondingDelegationsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockUserPositionsBalancesCb: func(ctx context.Context, address string) (sdk.Coins, sdk.Coins, error) {
+			return sdk.NewCoins(wbtcCoin), sdk.Coins{}, nil
+		},
+		MockDelegationRewardsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+	}
+
+	pu := usecase.NewPassThroughUsecase(&grpcClientMock, &mocks.PoolsUsecaseMock{}, &tokensUsecaseMock, liquidityPricerMock, USDC, &log.NoOpLogger{})
+
+	// System under test
+	actualPortfolioAssets, err := pu.GetPortfolioAssets(context.TODO(), defaultAddress, domain.WithPortfolioQuoteDenom(ATOM))
+	s.Require().NoError(err)
+
+	var (
+		osmoCapitalizationInAtom = osmoCapitalization.QuoInt64(2)
+		atomCapitalizationInAtom = atomCoin.Amount.ToLegacyDec()
+		wbtcCapitalizationInAtom = wbtcCapitalization.QuoInt64(2)
+	)
+
+	expectedResult := passthroughdomain.PortfolioAssetsResult{
+		Categories: map[string]passthroughdomain.PortfolioAssetsCategoryResult{
+			usecase.UserBalancesAssetsCategoryName: {
+				Capitalization: osmoCapitalizationInAtom,
+				AccountCoinsResult: []passthroughdomain.AccountCoinsResult{
+					{
+						Coin:                osmoCoin,
+						CapitalizationValue: osmoCapitalizationInAtom,
+					},
+				},
+			},
+			usecase.UnstakingAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.StakedAssetsCategoryName: {
+				Capitalization: atomCapitalizationInAtom,
+			},
+			usecase.InLocksAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.PooledAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.ConcentratedPositionsAssetsCategoryName: {
+				Capitalization: wbtcCapitalizationInAtom,
+			},
+			usecase.UnclaimedRewardsAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.TotalAssetsCategoryName: {
+				Capitalization: osmoCapitalizationInAtom.Add(atomCapitalizationInAtom).Add(wbtcCapitalizationInAtom),
+				AccountCoinsResult: []passthroughdomain.AccountCoinsResult{
+					{
+						Coin:                atomCoin,
+						CapitalizationValue: atomCapitalizationInAtom,
+					},
+					{
+						Coin:                wbtcCoin,
+						CapitalizationValue: wbtcCapitalizationInAtom,
+					},
+					{
+						Coin:                osmoCoin,
+						CapitalizationValue: osmoCapitalizationInAtom,
+					},
+				},
+			},
+		},
+	}
+
+	s.validatePortfolioAssetsResult(expectedResult, actualPortfolioAssets)
+}
+
+// Tests that concentrated positions are reported in their own category
+// and are not double counted in the pooled assets which only include classic pool shares.
+func (s *PassthroughUseCaseTestSuite) TestGetPortfolioAssets_ConcentratedPositions() {
+	tokensUsecaseMock := mocks.TokensUsecaseMock{
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			return defaultPriceResult, nil
+		},
+
+		IsValidChainDenomFunc: isValidChainDenomFuncMock,
+	}
+
+	grpcClientMock := mocks.PassthroughGRPCClientMock{
+		MockAllBalancesCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			// Only classic pool shares in balances
+			return sdk.NewCoins(defaultGammShareCoin), nil
+		},
+		MockAccountLockedCoinsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockAccountUnlockingCoinsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockDelegatorDelegationsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockDelegatorUnbondingDelegationsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockUserPositionsBalancesCb: func(ctx context.Context, address string) (sdk.Coins, sdk.Coins, error) {
+			return sdk.NewCoins(wbtcCoin), sdk.Coins{}, nil
+		},
+		MockDelegationRewardsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+	}
+
+	poolsUseCaseMock := mocks.PoolsUsecaseMock{
+		CalcExitCFMMPoolFunc: func(poolID uint64, exitingShares osmomath.Int) (sdk.Coins, error) {
+			return sdk.NewCoins(atomCoin), nil
+		},
+	}
+
+	pu := usecase.NewPassThroughUsecase(&grpcClientMock, &poolsUseCaseMock, &tokensUsecaseMock, liquidityPricerMock, USDC, &log.NoOpLogger{})
+
+	// System under test
+	actualPortfolioAssets, err := pu.GetPortfolioAssets(context.TODO(), defaultAddress)
+	s.Require().NoError(err)
+
+	expectedResult := passthroughdomain.PortfolioAssetsResult{
+		Categories: map[string]passthroughdomain.PortfolioAssetsCategoryResult{
+			usecase.UserBalancesAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.UnstakingAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.StakedAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.InLocksAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.PooledAssetsCategoryName: {
+				Capitalization: atomCapitalization,
+			},
+			usecase.ConcentratedPositionsAssetsCategoryName: {
+				Capitalization: wbtcCapitalization,
+			},
+			usecase.UnclaimedRewardsAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.TotalAssetsCategoryName: {
+				Capitalization: atomCapitalization.Add(wbtcCapitalization),
+				AccountCoinsResult: []passthroughdomain.AccountCoinsResult{
+					{
+						Coin:                atomCoin,
+						CapitalizationValue: atomCapitalization,
+					},
+					{
+						Coin:                wbtcCoin,
+						CapitalizationValue: wbtcCapitalization,
+					},
+				},
+			},
+		},
+	}
+
+	s.validatePortfolioAssetsResult(expectedResult, actualPortfolioAssets)
+}
+
+// Tests that zero-value coins are excluded from the per-coin breakdown when configured
+// while still being counted and not affecting the capitalization.
+func (s *PassthroughUseCaseTestSuite) TestGetPortfolioAssets_HideZeroValue() {
+	tokensUsecaseMock := mocks.TokensUsecaseMock{
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			return defaultPriceResult, nil
+		},
+
+		IsValidChainDenomFunc: isValidChainDenomFuncMock,
+	}
+
+	grpcClientMock := mocks.PassthroughGRPCClientMock{
+		MockAllBalancesCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			// Invalid coin is priced at zero.
+			return sdk.NewCoins(osmoCoin, invalidCoin), nil
+		},
+		MockAccountLockedCoinsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockAccountUnlockingCoinsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockDelegatorDelegationsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockDelegatorUnbondingDelegationsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			return sdk.Coins{}, nil
+		},
+		MockUserPositionsBalancesCb: func(ctx context.Context, address string) (sdk.Coins, sdk.Coins, error) {
+			return sdk.Coins{}, sdk.Coins{}, nil
+		},
+		MockDelegationRewardsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+			// Unclaimed rewards with invalid denom are priced at zero.
+			return sdk.NewCoins(invalidCoin), nil
+		},
+	}
+
+	pu := usecase.NewPassThroughUsecase(&grpcClientMock, &mocks.PoolsUsecaseMock{}, &tokensUsecaseMock, liquidityPricerMock, USDC, &log.NoOpLogger{})
+
+	// System under test
+	actualPortfolioAssets, err := pu.GetPortfolioAssets(context.TODO(), defaultAddress, domain.WithHideZeroValue())
+	s.Require().NoError(err)
+
+	expectedResult := passthroughdomain.PortfolioAssetsResult{
+		Categories: map[string]passthroughdomain.PortfolioAssetsCategoryResult{
+			usecase.UserBalancesAssetsCategoryName: {
+				Capitalization: osmoCapitalization,
+				AccountCoinsResult: []passthroughdomain.AccountCoinsResult{
+					{
+						Coin:                osmoCoin,
+						CapitalizationValue: osmoCapitalization,
+					},
+				},
+				HiddenCount: 1,
+			},
+			usecase.UnstakingAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.StakedAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.InLocksAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.PooledAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.ConcentratedPositionsAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			// Not broken down by coin, so nothing is hidden.
+			usecase.UnclaimedRewardsAssetsCategoryName: {
+				Capitalization: zero,
+			},
+			usecase.TotalAssetsCategoryName: {
+				Capitalization: osmoCapitalization,
+				AccountCoinsResult: []passthroughdomain.AccountCoinsResult{
+					{
+						Coin:                osmoCoin,
+						CapitalizationValue: osmoCapitalization,
+					},
+				},
+				// Invalid coins from balances and rewards are merged into one.
+				HiddenCount: 1,
+			},
+		},
+	}
+
+	s.validatePortfolioAssetsResult(expectedResult, actualPortfolioAssets)
+}
+
+// Tests that get portfolio assets fetches balances concurrently by injecting
+// artificial latency into every GRPC client mock and asserting that the total time
+// is bounded by the slowest call rather than the sum of all calls.
+func (s *PassthroughUseCaseTestSuite) TestGetPortfolioAssets_ConcurrentFetching() {
+	const (
+		latency = 100 * time.Millisecond
+		// AllBalances, AccountLockedCoins, AccountUnlockingCoins, DelegatorDelegations,
+		// DelegatorUnbondingDelegations, UserPositionsBalances, DelegationRewards
+		numGRPCCalls = 7
+	)
+
+	withLatency := func(coins sdk.Coins) func(ctx context.Context, address string) (sdk.Coins, error) {
+		return func(ctx context.Context, address string) (sdk.Coins, error) {
+			time.Sleep(latency)
+			return coins, nil
+		}
+	}
+
+	tokensUsecaseMock := mocks.TokensUsecaseMock{
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			return defaultPriceResult, nil
+		},
+
+		IsValidChainDenomFunc: isValidChainDenomFuncMock,
+	}
+
+	grpcClientMock := mocks.PassthroughGRPCClientMock{
+		MockAllBalancesCb:                   withLatency(sdk.NewCoins(osmoCoin)),
+		MockAccountLockedCoinsCb:            withLatency(sdk.Coins{}),
+		MockAccountUnlockingCoinsCb:         withLatency(sdk.Coins{}),
+		MockDelegatorDelegationsCb:          withLatency(sdk.NewCoins(atomCoin)),
+		MockDelegatorUnbondingDelegationsCb: withLatency(sdk.Coins{}),
+		MockUserPositionsBalancesCb: func(ctx context.Context, address string) (sdk.Coins, sdk.Coins, error) {
+			time.Sleep(latency)
+			return sdk.NewCoins(wbtcCoin), sdk.Coins{}, nil
+		},
+		MockDelegationRewardsCb: withLatency(sdk.Coins{}),
+	}
+
+	pu := usecase.NewPassThroughUsecase(&grpcClientMock, &mocks.PoolsUsecaseMock{}, &tokensUsecaseMock, liquidityPricerMock, USDC, &log.NoOpLogger{})
+
+	// System under test
+	start := time.Now()
+	actualPortfolioAssets, err := pu.GetPortfolioAssets(context.TODO(), defaultAddress)
+	elapsed := time.Since(start)
+	s.Require().NoError(err)
+
+	// Bounded by the slowest call rather than the sum of all calls.
+	s.Require().GreaterOrEqual(elapsed, latency)
+	s.Require().Less(elapsed, numGRPCCalls*latency/2)
+
+	// Validate that the results are aggregated correctly.
+	s.Require().Equal(osmoCapitalization.Add(atomCapitalization).Add(wbtcCapitalization), actualPortfolioAssets.Categories[usecase.TotalAssetsCategoryName].Capitalization)
+	for _, category := range actualPortfolioAssets.Categories {
+		s.Require().False(category.IsBestEffort)
+	}
+}
+
+// Tests that get portfolio with delta computes the change of the total value
+// relative to the previous value.
+func (s *PassthroughUseCaseTestSuite) TestGetPortfolioWithDelta() {
+	tests := []struct {
+		name string
+
+		previous osmomath.Dec
+
+		expectedAbsoluteDelta   osmomath.Dec
+		expectedPercentageDelta osmomath.Dec
+	}{
+		{
+			name: "positive delta",
+
+			// 80% of the current total value
+			previous: osmoCapitalization.MulInt64(4).QuoInt64(5),
+
+			expectedAbsoluteDelta:   osmoCapitalization.QuoInt64(5),
+			expectedPercentageDelta: osmomath.NewDec(25),
+		},
+		{
+			name: "negative delta",
+
+			// 125% of the current total value
+			previous: osmoCapitalization.MulInt64(5).QuoInt64(4),
+
+			expectedAbsoluteDelta:   osmoCapitalization.QuoInt64(4).Neg(),
+			expectedPercentageDelta: osmomath.NewDec(-20),
+		},
+		{
+			name: "zero previous -> zero percentage delta",
+
+			previous: zero,
+
+			expectedAbsoluteDelta:   osmoCapitalization,
+			expectedPercentageDelta: zero,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tokensUsecaseMock := mocks.TokensUsecaseMock{
+				GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+					return defaultPriceResult, nil
+				},
+
+				IsValidChainDenomFunc: isValidChainDenomFuncMock,
+			}
+
+			grpcClientMock := mocks.PassthroughGRPCClientMock{
+				MockAllBalancesCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+					return sdk.NewCoins(osmoCoin), nil
+				},
+				MockAccountLockedCoinsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+					return sdk.Coins{}, nil
+				},
+				MockAccountUnlockingCoinsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+					return sdk.Coins{}, nil
+				},
+				MockDelegatorDelegationsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+					return sdk.Coins{}, nil
+				},
+				MockDelegatorUnbondingDelegationsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+					return sdk.Coins{}, nil
+				},
+				MockUserPositionsBalancesCb: func(ctx context.Context, address string) (sdk.Coins, sdk.Coins, error) {
+					return sdk.Coins{}, sdk.Coins{}, nil
+				},
+				MockDelegationRewardsCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+					return sdk.Coins{}, nil
+				},
+			}
+
+			pu := usecase.NewPassThroughUsecase(&grpcClientMock, &mocks.PoolsUsecaseMock{}, &tokensUsecaseMock, liquidityPricerMock, USDC, &log.NoOpLogger{})
+
+			// System under test
+			result, err := pu.GetPortfolioWithDelta(context.TODO(), defaultAddress, tt.previous)
+			s.Require().NoError(err)
+
+			s.Require().Equal(osmoCapitalization.String(), result.TotalValueCap.String())
+			s.Require().Equal(tt.expectedAbsoluteDelta.String(), result.AbsoluteDelta.String())
+			s.Require().Equal(tt.expectedPercentageDelta.String(), result.PercentageDelta.String())
+			s.Require().False(result.IsBestEffort)
+		})
+	}
+}
+
+// Tests that get priced balances only fetches spendable balances and prices them.
+func (s *PassthroughUseCaseTestSuite) TestGetPricedBalances() {
+	tests := []struct {
+		name string
+
+		mockedBalances      sdk.Coins
+		mockedBalancesError error
+		mockedPricesError   error
+
+		expectedError               error
+		expectedPricingError        bool
+		expectedAccountCoinsResult  []passthroughdomain.AccountCoinsResult
+		expectedTotalCapitalization osmomath.Dec
+	}{
+		{
+			name: "empty balances",
+
+			mockedBalances: sdk.Coins{},
+
+			expectedAccountCoinsResult:  []passthroughdomain.AccountCoinsResult{},
+			expectedTotalCapitalization: zero,
+		},
+		{
+			name: "single coin in balances",
+
+			mockedBalances: sdk.NewCoins(osmoCoin),
+
+			expectedAccountCoinsResult: []passthroughdomain.AccountCoinsResult{
+				{
+					Coin:                osmoCoin,
+					CapitalizationValue: osmoCapitalization,
+				},
+			},
+			expectedTotalCapitalization: osmoCapitalization,
+		},
+		{
+			name: "invalid denom in balances -> zero capitalization",
+
+			mockedBalances: sdk.NewCoins(invalidCoin, osmoCoin),
+
+			expectedAccountCoinsResult: []passthroughdomain.AccountCoinsResult{
+				{
+					Coin:                invalidCoin,
+					CapitalizationValue: zero,
+				},
+				{
+					Coin:                osmoCoin,
+					CapitalizationValue: osmoCapitalization,
+				},
+			},
+			expectedTotalCapitalization: osmoCapitalization,
+		},
+		{
+			name: "error: grpc client error",
+
+			mockedBalancesError: grpcClientError,
+
+			expectedError: grpcClientError,
+		},
+		{
+			name: "error: pricing error -> best-effort result",
+
+			mockedBalances:    sdk.NewCoins(osmoCoin),
+			mockedPricesError: miscError,
+
+			expectedPricingError: true,
+			expectedAccountCoinsResult: []passthroughdomain.AccountCoinsResult{
+				{
+					Coin:                osmoCoin,
+					CapitalizationValue: zero,
+				},
+			},
+			expectedTotalCapitalization: zero,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tokensUsecaseMock := mocks.TokensUsecaseMock{
+				GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+					if tt.mockedPricesError != nil {
+						return nil, tt.mockedPricesError
+					}
+					return defaultPriceResult, nil
+				},
+
+				IsValidChainDenomFunc: isValidChainDenomFuncMock,
+			}
+
+			// Note that the other GRPC client methods are not mocked
+			// and would return an error if called.
+			grpcClientMock := mocks.PassthroughGRPCClientMock{
+				MockAllBalancesCb: func(ctx context.Context, address string) (sdk.Coins, error) {
+					return tt.mockedBalances, tt.mockedBalancesError
+				},
+			}
+
+			pu := usecase.NewPassThroughUsecase(&grpcClientMock, &mocks.PoolsUsecaseMock{}, &tokensUsecaseMock, liquidityPricerMock, USDC, &log.NoOpLogger{})
+
+			// System under test
+			accountCoinsResult, totalCapitalization, err := pu.GetPricedBalances(context.TODO(), defaultAddress)
+
+			if tt.expectedError != nil {
+				s.Require().ErrorIs(err, tt.expectedError)
+				return
+			}
+
+			if tt.expectedPricingError {
+				s.Require().ErrorAs(err, &domain.BalancesPricingError{})
+			} else {
+				s.Require().NoError(err)
+			}
+
+			s.Require().Equal(tt.expectedAccountCoinsResult, accountCoinsResult)
+			s.Require().Equal(tt.expectedTotalCapitalization, totalCapitalization)
+		})
+	}
 }
 
 // Tests the compute capitalization for coins method using mocks.
@@ -255,6 +862,7 @@ func (s *PassthroughUseCaseTestSuite) TestComputeCapitalizationForCoins() {
 		name string
 
 		coins              sdk.Coins
+		quoteDenom         string
 		mockedPricesResult domain.PricesResult
 		mockedPricesError  error
 
@@ -344,6 +952,30 @@ func (s *PassthroughUseCaseTestSuite) TestComputeCapitalizationForCoins() {
 			},
 			expectedTotalCapitalization: osmoCapitalization.Add(atomCapitalization).Add(wbtcCapitalization),
 		},
+		{
+			name: "multiple coins, ATOM as quote denom",
+
+			coins:      sdk.Coins{osmoCoin, atomCoin, wbtcCoin},
+			quoteDenom: ATOM,
+
+			mockedPricesResult: atomQuotePriceResult,
+
+			expectedAccountCoinsResult: []passthroughdomain.AccountCoinsResult{
+				{
+					Coin:                osmoCoin,
+					CapitalizationValue: osmoCapitalization.QuoInt64(2),
+				},
+				{
+					Coin:                atomCoin,
+					CapitalizationValue: atomCoin.Amount.ToLegacyDec(),
+				},
+				{
+					Coin:                wbtcCoin,
+					CapitalizationValue: wbtcCapitalization.QuoInt64(2),
+				},
+			},
+			expectedTotalCapitalization: osmoCapitalization.QuoInt64(2).Add(atomCoin.Amount.ToLegacyDec()).Add(wbtcCapitalization.QuoInt64(2)),
+		},
 		{
 			name: "error in prices",
 
@@ -357,10 +989,17 @@ func (s *PassthroughUseCaseTestSuite) TestComputeCapitalizationForCoins() {
 
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
+			quoteDenom := tt.quoteDenom
+			if quoteDenom == "" {
+				quoteDenom = USDC
+			}
 
 			// Set up tokens use case mock with relevant methods
 			tokensUsecaseMock := mocks.TokensUsecaseMock{
 				GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+					// Validate that the prices are requested in the configured quote denom
+					s.Require().Equal([]string{quoteDenom}, quoteDenoms)
+
 					// Return the mocked out results
 					return tt.mockedPricesResult, tt.mockedPricesError
 				},
@@ -371,7 +1010,7 @@ func (s *PassthroughUseCaseTestSuite) TestComputeCapitalizationForCoins() {
 			pu := usecase.NewPassThroughUsecase(nil, nil, &tokensUsecaseMock, liquidityPricerMock, USDC, &log.NoOpLogger{})
 
 			// System under test
-			accountCoinsResult, totalCapitalization, err := pu.ComputeCapitalizationForCoins(context.TODO(), tt.coins)
+			accountCoinsResult, totalCapitalization, err := pu.ComputeCapitalizationForCoins(context.TODO(), tt.coins, quoteDenom)
 
 			if tt.expectedError {
 				s.Require().Error(err)
@@ -400,6 +1039,8 @@ func (s *PassthroughUseCaseTestSuite) TestGetCoinsFromLocks() {
 
 		expectedCoins sdk.Coins
 		expectedError error
+
+		expectedCalcExitCFMMPoolCalls int32
 	}{
 		{
 			name: "happy path",
@@ -409,6 +1050,8 @@ func (s *PassthroughUseCaseTestSuite) TestGetCoinsFromLocks() {
 			mockAccountLockedCoinsIfDefaultAddress: defaultBalances,
 
 			expectedCoins: dafultResult,
+
+			expectedCalcExitCFMMPoolCalls: 1,
 		},
 		{
 			name: "happy path with unlocking",
@@ -420,6 +1063,22 @@ func (s *PassthroughUseCaseTestSuite) TestGetCoinsFromLocks() {
 
 			// 2x for locked and unlocking.
 			expectedCoins: dafultResult.Add(dafultResult...),
+
+			// Identical share is expanded once.
+			expectedCalcExitCFMMPoolCalls: 1,
+		},
+		{
+			name: "different share amounts are expanded separately",
+
+			address: defaultAddress,
+
+			mockAccountLockedCoinsIfDefaultAddress:    defaultBalances,
+			mockAccountUnlockingCoinsIfDefaultAddress: sdk.NewCoins(sdk.NewCoin(validGammShareDenom, validGammShareAmount.AddRaw(1))),
+
+			// The share with a different amount fails to be converted and is skipped.
+			expectedCoins: dafultResult,
+
+			expectedCalcExitCFMMPoolCalls: 2,
 		},
 		{
 			name: "concentrated shares are skipped",
@@ -429,6 +1088,8 @@ func (s *PassthroughUseCaseTestSuite) TestGetCoinsFromLocks() {
 			mockAccountLockedCoinsIfDefaultAddress: defaultBalances.Add(defaultConcentratedShareCoin),
 
 			expectedCoins: nonShareDefaultBalances.Add(defaultExitPoolCoins...),
+
+			expectedCalcExitCFMMPoolCalls: 1,
 		},
 		{
 			name: "error: grpc client error",
@@ -448,6 +1109,22 @@ func (s *PassthroughUseCaseTestSuite) TestGetCoinsFromLocks() {
 			// Note that only non share balances are returned
 			// The share coins are skipped due to error.
 			expectedCoins: nonShareDefaultBalances,
+
+			expectedCalcExitCFMMPoolCalls: 1,
+		},
+		{
+			name: "skip error in converting duplicate gamm share in locked and unlocking",
+
+			address: defaultAddress,
+
+			mockAccountLockedCoinsIfDefaultAddress:    nonShareDefaultBalances.Add(sdk.NewCoin(formatValidGammShare(validGammSharePoolID+1), validGammShareAmount)),
+			mockAccountUnlockingCoinsIfDefaultAddress: nonShareDefaultBalances.Add(sdk.NewCoin(formatValidGammShare(validGammSharePoolID+1), validGammShareAmount)),
+
+			// The share coins are skipped due to error.
+			expectedCoins: nonShareDefaultBalances.Add(nonShareDefaultBalances...),
+
+			// Failed expansion is not retried for the identical share.
+			expectedCalcExitCFMMPoolCalls: 1,
 		},
 	}
 
@@ -481,9 +1158,13 @@ func (s *PassthroughUseCaseTestSuite) TestGetCoinsFromLocks() {
 				},
 			}
 
+			var calcExitCFMMPoolCalls atomic.Int32
+
 			// Initialize pools use case mock
 			poolsUseCaseMock := mocks.PoolsUsecaseMock{
 				CalcExitCFMMPoolFunc: func(poolID uint64, exitingShares osmomath.Int) (sdk.Coins, error) {
+					calcExitCFMMPoolCalls.Add(1)
+
 					// If the pool ID is valid and the exiting shares are valid, return default exit pool coins
 					if poolID == validGammSharePoolID && exitingShares.Equal(validGammShareAmount) {
 						return defaultExitPoolCoins, nil
@@ -502,6 +1183,7 @@ func (s *PassthroughUseCaseTestSuite) TestGetCoinsFromLocks() {
 			// Assert
 			s.Require().Equal(tt.expectedCoins, actualBalances)
 			s.Require().Equal(tt.expectedError, err)
+			s.Require().Equal(tt.expectedCalcExitCFMMPoolCalls, calcExitCFMMPoolCalls.Load())
 		})
 	}
 }
@@ -709,5 +1391,6 @@ func (s *PassthroughUseCaseTestSuite) validatePortfolioAssetsResult(expectedResu
 		}
 
 		s.Require().Equal(expectedCategory.IsBestEffort, actualCategory.IsBestEffort, categoryName)
+		s.Require().Equal(expectedCategory.HiddenCount, actualCategory.HiddenCount, categoryName)
 	}
 }
@@ -58,6 +58,7 @@ func NewPoolsHandler(e *echo.Echo, us mvc.PoolsUsecase) {
 	e.GET(formatPoolsResource("/ticks/:id"), handler.GetConcentratedPoolTicks)
 	e.GET(formatPoolsResource("/canonical-orderbook"), handler.GetCanonicalOrderbook)
 	e.GET(formatPoolsResource("/canonical-orderbooks"), handler.GetCanonicalOrderbooks)
+	e.GET(formatPoolsResource("/liquidity-errors"), handler.GetPoolsWithLiquidityErrors)
 	e.GET(formatPoolsResource(""), handler.GetPools)
 }
 
@@ -69,6 +70,8 @@ func NewPoolsHandler(e *echo.Echo, us mvc.PoolsUsecase) {
 // @Param  IDs  query  string  false  "Comma-separated list of pool IDs to fetch, e.g., '1,2,3'"
 // @Param  min_liquidity_cap  query  int  false  "Minimum pool liquidity cap"
 // @Param  with_market_incentives  query  bool  false  "Include market incentives data in the pool response"
+// @Param  sort_by  query  string  false  "Field to sort the pools by: id, liquidity_cap or spread_factor"
+// @Param  sort_desc  query  bool  false  "Sort the pools in descending order"
 // @Success 200  {array}  sqsdomain.PoolI  "List of pool(s) details"
 // @Router /pools [get]
 func (a *PoolsHandler) GetPools(c echo.Context) error {
@@ -79,6 +82,11 @@ func (a *PoolsHandler) GetPools(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, ResponseError{Message: err.Error()})
 	}
+	sortByStr := c.QueryParam("sort_by")
+	sortDesc, err := domain.ParseBooleanQueryParam(c, "sort_desc")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ResponseError{Message: err.Error()})
+	}
 
 	var (
 		pools []sqsdomain.PoolI
@@ -109,6 +117,15 @@ func (a *PoolsHandler) GetPools(c echo.Context) error {
 		filters = append(filters, domain.WithPoolIDFilter(poolIDs))
 	}
 
+	// Only add sorting if the sort field is provided.
+	if sortByStr != "" {
+		sortBy, err := domain.ParsePoolSortField(sortByStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ResponseError{Message: err.Error()})
+		}
+		filters = append(filters, domain.WithPoolsSortBy(sortBy, sortDesc))
+	}
+
 	// Get pools
 	pools, err = a.PUsecase.GetPools(
 		filters...,
@@ -208,6 +225,21 @@ func (a *PoolsHandler) GetCanonicalOrderbooks(c echo.Context) error {
 	return c.JSON(http.StatusOK, orderbookData)
 }
 
+// @Summary Get pools with liquidity capitalization errors.
+// @Description Returns the list of pools whose liquidity capitalization could not be computed, along with the reason.
+// @Description This is useful for investigating missing prices.
+// @Produce  json
+// @Success 200  {array}  domain.PoolLiquidityCapErrorResult  "List of pool IDs with the liquidity capitalization error"
+// @Router /pools/liquidity-errors [get]
+func (a *PoolsHandler) GetPoolsWithLiquidityErrors(c echo.Context) error {
+	poolErrors, err := a.PUsecase.GetPoolsWithLiquidityErrors()
+	if err != nil {
+		return c.JSON(getStatusCode(err), ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, poolErrors)
+}
+
 // convertPoolToResponse convertes a given pool to the appropriate response type.
 func convertPoolToResponse(pool sqsdomain.PoolI) PoolResponse {
 	return PoolResponse{
@@ -39,6 +39,11 @@ func (p *poolsUseCase) StoreInvalidOrderBookEntry(baseDenom, quoteDenom string)
 	p.canonicalOrderBookForBaseQuoteDenom.Store(formatBaseQuoteDenom(baseDenom, quoteDenom), invalidEntryType)
 }
 
+// WARNING: this method is only meant for setting up tests. Do not move out of export_test.go
+func (p *poolsUseCase) RecordCosmWasmPoolFailure(poolID uint64) {
+	p.cosmWasmPoolsParams.CircuitBreaker.RecordFailure(poolID)
+}
+
 func (p poolsUseCase) SetPoolAPRAndFeeDataIfConfigured(pool sqsdomain.PoolI, options domain.PoolsOptions) {
 	p.setPoolAPRAndFeeDataIfConfigured(pool, options)
 }
@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	sdkmath "math"
 
@@ -43,6 +45,8 @@ type orderBookEntry struct {
 	PoolID          uint64
 	LiquidityCap    osmomath.Int
 	ContractAddress string
+	// IsPinned is true if the pool is configured to always be canonical.
+	IsPinned bool
 }
 
 type poolsUseCase struct {
@@ -52,6 +56,17 @@ type poolsUseCase struct {
 	canonicalOrderBookForBaseQuoteDenom sync.Map
 	canonicalOrderbookPoolIDs           sync.Map
 
+	// pinnedCanonicalOrderbookPoolIDs are the orderbook pool IDs configured to always be
+	// canonical for their base/quote pair, bypassing the liquidity capitalization comparison.
+	pinnedCanonicalOrderbookPoolIDs map[uint64]struct{}
+
+	// denomPoolIDsIndex maps each denom to the set of IDs of the pools containing it.
+	denomPoolIDsIndex   map[string]map[uint64]struct{}
+	denomPoolIDsIndexMu sync.RWMutex
+
+	// poolsLoaded is set once the first non-empty set of pools is stored by ingest.
+	poolsLoaded atomic.Bool
+
 	cosmWasmPoolsParams cosmwasmdomain.CosmWasmPoolsParams
 
 	aprPrefetcher      datafetchers.MapFetcher[uint64, sqspassthroughdomain.PoolAPR]
@@ -89,15 +104,30 @@ func NewPoolsUsecase(poolsConfig *domain.PoolsConfig, chainGRPCGatewayEndpoint s
 		generalizedCosmWasmCodeIDsMap[codeID] = struct{}{}
 	}
 
+	pinnedCanonicalOrderbookPoolIDsMap := make(map[uint64]struct{}, len(poolsConfig.CanonicalOrderbookPoolIDs))
+	for _, poolID := range poolsConfig.CanonicalOrderbookPoolIDs {
+		pinnedCanonicalOrderbookPoolIDsMap[poolID] = struct{}{}
+	}
+
 	wasmClient, err := initializeWasmClient(chainGRPCGatewayEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
+	// Circuit breaker is disabled if the failure threshold is not configured.
+	var circuitBreaker *cosmwasmdomain.CircuitBreaker
+	if poolsConfig.CosmWasmCircuitBreakerFailureThreshold > 0 {
+		circuitBreaker = cosmwasmdomain.NewCircuitBreaker(poolsConfig.CosmWasmCircuitBreakerFailureThreshold, time.Duration(poolsConfig.CosmWasmCircuitBreakerCooldownSeconds)*time.Second)
+	}
+
 	return &poolsUseCase{
 		pools:            sync.Map{},
 		routerRepository: routerRepository,
 
+		denomPoolIDsIndex: make(map[string]map[uint64]struct{}),
+
+		pinnedCanonicalOrderbookPoolIDs: pinnedCanonicalOrderbookPoolIDsMap,
+
 		cosmWasmPoolsParams: cosmwasmdomain.CosmWasmPoolsParams{
 			Config: domain.CosmWasmPoolRouterConfig{
 				TransmuterCodeIDs:        transmuterCodeIDsMap,
@@ -110,6 +140,8 @@ func NewPoolsUsecase(poolsConfig *domain.PoolsConfig, chainGRPCGatewayEndpoint s
 			WasmClient: wasmClient,
 
 			ScalingFactorGetterCb: scalingFactorGetterCb,
+
+			CircuitBreaker: circuitBreaker,
 		},
 
 		logger: logger,
@@ -133,6 +165,8 @@ func (p *poolsUseCase) GetAllPools() (pools []sqsdomain.PoolI, err error) {
 }
 
 // GetRoutesFromCandidates implements mvc.PoolsUsecase.
+// Routes through generalized cosmwasm pools with an open circuit are skipped since the candidate routes
+// might be read from cache, bypassing the circuit breaker filter applied during the candidate route search.
 func (p *poolsUseCase) GetRoutesFromCandidates(candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string) ([]route.RouteImpl, error) {
 	// We track whether a route contains a generalized cosmwasm pool
 	// so that we can exclude it from split quote logic.
@@ -157,6 +191,13 @@ func (p *poolsUseCase) GetRoutesFromCandidates(candidateRoutes sqsdomain.Candida
 				return nil, err
 			}
 
+			if p.IsCosmWasmPoolCircuitOpen(candidatePool.ID) {
+				p.logger.Debug("pool circuit is open, skipping route", zap.Uint64("pool_id", candidatePool.ID))
+
+				skipErrorRoute = true
+				break
+			}
+
 			// Get taker fee
 			takerFee, exists := p.routerRepository.GetTakerFee(previousTokenOutDenom, candidatePool.TokenOutDenom)
 			if !exists {
@@ -165,6 +206,12 @@ func (p *poolsUseCase) GetRoutesFromCandidates(candidateRoutes sqsdomain.Candida
 
 			routablePool, err := pools.NewRoutablePool(pool, candidatePool.TokenOutDenom, takerFee, p.cosmWasmPoolsParams)
 			if err != nil {
+				p.logger.Warn("failed to create routable pool, skipping route", zap.Uint64("pool_id", candidatePool.ID), zap.Error(err))
+
+				if pool.GetType() == poolmanagertypes.CosmWasm && pool.GetSQSPoolModel().CosmWasmPoolModel == nil {
+					domain.SQSRoutingNilCosmWasmPoolModelCounter.Inc()
+				}
+
 				skipErrorRoute = true
 				break
 			}
@@ -178,7 +225,7 @@ func (p *poolsUseCase) GetRoutesFromCandidates(candidateRoutes sqsdomain.Candida
 			routablePools = append(routablePools, routablePool)
 		}
 
-		// Skip the route if there was an error
+		// Skip the route if there was an error or one of its pools has an open circuit
 		if skipErrorRoute {
 			continue
 		}
@@ -256,6 +303,54 @@ func (p *poolsUseCase) GetPoolSpotPrice(ctx context.Context, poolID uint64, take
 	return routablePool.CalcSpotPrice(ctx, baseAsset, quoteAsset)
 }
 
+// GetPoolWithPrices implements mvc.PoolsUsecase.
+// The pool is loaded and instrumented with the tick model once for all denom pairs.
+func (p *poolsUseCase) GetPoolWithPrices(ctx context.Context, poolID uint64) (domain.EnrichedPool, error) {
+	pool, err := p.GetPool(poolID)
+	if err != nil {
+		return domain.EnrichedPool{}, err
+	}
+
+	// Instrument pool with tick model data if concentrated
+	if err := p.getTicksAndSetTickModelIfConcentrated(pool); err != nil {
+		return domain.EnrichedPool{}, err
+	}
+
+	// N.B.: Empty string for token out denom and zero taker fee because they are irrelevant for calculating spot price.
+	// They are only relevant in the context of routing
+	routablePool, err := pools.NewRoutablePool(pool, "", osmomath.ZeroDec(), p.cosmWasmPoolsParams)
+	if err != nil {
+		return domain.EnrichedPool{}, err
+	}
+
+	poolDenoms := pool.GetPoolDenoms()
+
+	spotPrices := make(domain.PricesResult, len(poolDenoms))
+	for _, baseDenom := range poolDenoms {
+		quotePrices := make(map[string]osmomath.BigDec, len(poolDenoms)-1)
+
+		for _, quoteDenom := range poolDenoms {
+			if quoteDenom == baseDenom {
+				continue
+			}
+
+			spotPrice, err := routablePool.CalcSpotPrice(ctx, baseDenom, quoteDenom)
+			if err != nil {
+				return domain.EnrichedPool{}, fmt.Errorf("failed to compute spot price for pool %d, base (%s), quote (%s): %w", poolID, baseDenom, quoteDenom, err)
+			}
+
+			quotePrices[quoteDenom] = spotPrice
+		}
+
+		spotPrices[baseDenom] = quotePrices
+	}
+
+	return domain.EnrichedPool{
+		Pool:       pool,
+		SpotPrices: spotPrices,
+	}, nil
+}
+
 // IsGeneralCosmWasmCodeID implements mvc.PoolsUsecase.
 func (p *poolsUseCase) IsGeneralCosmWasmCodeID(codeId uint64) bool {
 	_, isGenneralCosmWasmCodeID := p.cosmWasmPoolsParams.Config.GeneralCosmWasmCodeIDs[codeId]
@@ -350,15 +445,177 @@ func (p *poolsUseCase) GetPools(opts ...domain.PoolsOption) ([]sqsdomain.PoolI,
 		})
 	}
 
+	if options.SortBy != nil {
+		sortPools(pools, *options.SortBy)
+	}
+
 	return pools, nil
 }
 
+// GetPoolsWithLiquidityErrors implements mvc.PoolsUsecase.
+func (p *poolsUseCase) GetPoolsWithLiquidityErrors() ([]domain.PoolLiquidityCapErrorResult, error) {
+	results := []domain.PoolLiquidityCapErrorResult{}
+
+	p.pools.Range(func(key, value interface{}) bool {
+		pool, ok := value.(sqsdomain.PoolI)
+		if !ok {
+			return true
+		}
+
+		liquidityCapError := strings.TrimSpace(pool.GetLiquidityCapError())
+		if liquidityCapError == "" {
+			return true
+		}
+
+		results = append(results, domain.PoolLiquidityCapErrorResult{
+			PoolID:            pool.GetId(),
+			LiquidityCapError: liquidityCapError,
+		})
+
+		return true
+	})
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].PoolID < results[j].PoolID
+	})
+
+	return results, nil
+}
+
+// GetPoolsForPair implements mvc.PoolsUsecase.
+func (p *poolsUseCase) GetPoolsForPair(denomA, denomB string, opts ...domain.PoolsOption) ([]sqsdomain.PoolI, error) {
+	p.denomPoolIDsIndexMu.RLock()
+	denomAPoolIDs := p.denomPoolIDsIndex[denomA]
+	denomBPoolIDs := p.denomPoolIDsIndex[denomB]
+
+	// Iterate over the smaller set for efficiency.
+	if len(denomAPoolIDs) > len(denomBPoolIDs) {
+		denomAPoolIDs, denomBPoolIDs = denomBPoolIDs, denomAPoolIDs
+	}
+
+	poolIDs := make([]uint64, 0, len(denomAPoolIDs))
+	for poolID := range denomAPoolIDs {
+		if _, ok := denomBPoolIDs[poolID]; ok {
+			poolIDs = append(poolIDs, poolID)
+		}
+	}
+	p.denomPoolIDsIndexMu.RUnlock()
+
+	// Sort for deterministic results when no sort option is given.
+	sort.Slice(poolIDs, func(i, j int) bool {
+		return poolIDs[i] < poolIDs[j]
+	})
+
+	// Note that the pool ID filter is applied last so that it overrides any given by the caller.
+	// Empty pool IDs results in no pools returned.
+	return p.GetPools(append(opts, domain.WithPoolIDFilter(poolIDs))...)
+}
+
+// indexPoolDenoms adds the given pool to the denom to pool IDs index for each of its denoms.
+// If the pool was previously stored, it is removed from the index entries of the denoms
+// that it no longer contains. Index entries with no pools left are deleted.
+func (p *poolsUseCase) indexPoolDenoms(previousPool, pool sqsdomain.PoolI) {
+	poolID := pool.GetId()
+	poolDenoms := pool.GetPoolDenoms()
+
+	p.denomPoolIDsIndexMu.Lock()
+	defer p.denomPoolIDsIndexMu.Unlock()
+
+	if previousPool != nil {
+		currentDenoms := make(map[string]struct{}, len(poolDenoms))
+		for _, denom := range poolDenoms {
+			currentDenoms[denom] = struct{}{}
+		}
+
+		for _, denom := range previousPool.GetPoolDenoms() {
+			if _, ok := currentDenoms[denom]; ok {
+				continue
+			}
+
+			poolIDs := p.denomPoolIDsIndex[denom]
+			delete(poolIDs, poolID)
+			if len(poolIDs) == 0 {
+				delete(p.denomPoolIDsIndex, denom)
+			}
+		}
+	}
+
+	for _, denom := range poolDenoms {
+		poolIDs, ok := p.denomPoolIDsIndex[denom]
+		if !ok {
+			poolIDs = make(map[uint64]struct{})
+			p.denomPoolIDsIndex[denom] = poolIDs
+		}
+		poolIDs[poolID] = struct{}{}
+	}
+}
+
+// sortPools sorts the given pools in place according to the given sort options.
+// Ties are broken by pool ID in ascending order for deterministic results.
+func sortPools(pools []sqsdomain.PoolI, sortOptions domain.PoolsSortOptions) {
+	// compare returns a negative value if pool i is ordered before pool j
+	// in ascending order of the sort field, a positive value if after and zero if equal.
+	compare := func(i, j int) int {
+		switch sortOptions.Field {
+		case domain.PoolSortFieldLiquidityCap:
+			return getLiquidityCapOrZero(pools[i]).BigIntMut().Cmp(getLiquidityCapOrZero(pools[j]).BigIntMut())
+		case domain.PoolSortFieldSpreadFactor:
+			return getSpreadFactorOrZero(pools[i]).BigIntMut().Cmp(getSpreadFactorOrZero(pools[j]).BigIntMut())
+		default:
+			return 0
+		}
+	}
+
+	sort.Slice(pools, func(i, j int) bool {
+		if result := compare(i, j); result != 0 {
+			if sortOptions.Desc {
+				return result > 0
+			}
+			return result < 0
+		}
+
+		// Sorting by ID or tie
+		if sortOptions.Field == domain.PoolSortFieldID && sortOptions.Desc {
+			return pools[i].GetId() > pools[j].GetId()
+		}
+		return pools[i].GetId() < pools[j].GetId()
+	})
+}
+
+// getLiquidityCapOrZero returns the liquidity cap of the pool or zero if unset.
+func getLiquidityCapOrZero(pool sqsdomain.PoolI) osmomath.Int {
+	liquidityCap := pool.GetLiquidityCap()
+	if liquidityCap.IsNil() {
+		return osmomath.ZeroInt()
+	}
+	return liquidityCap
+}
+
+// getSpreadFactorOrZero returns the spread factor of the pool or zero if unset.
+func getSpreadFactorOrZero(pool sqsdomain.PoolI) osmomath.Dec {
+	spreadFactor := pool.GetSQSPoolModel().SpreadFactor
+	if spreadFactor.IsNil() {
+		return osmomath.ZeroDec()
+	}
+	return spreadFactor
+}
+
 // StorePools implements mvc.PoolsUsecase.
 func (p *poolsUseCase) StorePools(pools []sqsdomain.PoolI) error {
 	for _, pool := range pools {
 		// Store pool
 		poolID := pool.GetId()
-		p.pools.Store(poolID, pool)
+		previousPoolObj, hadPreviousPool := p.pools.Swap(poolID, pool)
+
+		var previousPool sqsdomain.PoolI
+		if hadPreviousPool {
+			previousPool, _ = previousPoolObj.(sqsdomain.PoolI)
+		}
+
+		// Index pool by its denoms, pruning the denoms that the pool no longer contains
+		p.indexPoolDenoms(previousPool, pool)
+
+		p.poolsLoaded.Store(true)
 
 		// If orderbook, update top liquidity pool for base and quote denom if it has higher liquidity capitalization.
 		sqsModel := pool.GetSQSPoolModel()
@@ -389,9 +646,17 @@ func (p *poolsUseCase) StorePools(pools []sqsdomain.PoolI) error {
 	return nil
 }
 
+// ArePoolsLoaded implements mvc.PoolsUsecase.
+func (p *poolsUseCase) ArePoolsLoaded() bool {
+	return p.poolsLoaded.Load()
+}
+
 // processOrderbookPoolIDForBaseQuote processes the orderbook pool ID for the base and quote denom and pool liquidity
 // capitalization. If the current pool has higher liquidity capitalization than the top liquidity pool, update the top liquidity pool
 // for the given base and quote denom.
+// If the pool is configured as a pinned canonical orderbook, it always becomes the top pool for the given
+// base and quote denom, bypassing the liquidity capitalization comparison. Conversely, a pinned top pool
+// is never replaced by a pool that is not pinned.
 // Returns true if the top liquidity pool is updated, false otherwise.
 // Returns an error if the previous top orderbook entry cannot be casted to the right type.
 // CONTRACT: the given poolID is an orderbook pool.
@@ -399,6 +664,8 @@ func (p *poolsUseCase) processOrderbookPoolIDForBaseQuote(baseDenom, quoteDenom
 	// Format base and quote denom key.
 	baseQuoteKey := formatBaseQuoteDenom(baseDenom, quoteDenom)
 
+	_, isPinned := p.pinnedCanonicalOrderbookPoolIDs[poolID]
+
 	// Determine there is an existing top liquidity pool for the base and quote denom.
 	topLiquidityOrderBook, found := p.canonicalOrderBookForBaseQuoteDenom.Load(baseQuoteKey)
 	if found {
@@ -411,9 +678,14 @@ func (p *poolsUseCase) processOrderbookPoolIDForBaseQuote(baseDenom, quoteDenom
 			return false, err
 		}
 
+		// A pinned top liquidity pool can only be replaced by another pinned pool.
+		if topLiquidityOrderBookEntry.IsPinned && !isPinned {
+			return false, nil
+		}
+
 		// If the current pool has lower or equak liquidity capitalization than the top liquidity pool
 		// continue to the next pool
-		if poolLiquidityCapitalization.LTE(topLiquidityOrderBookEntry.LiquidityCap) {
+		if !isPinned && poolLiquidityCapitalization.LTE(topLiquidityOrderBookEntry.LiquidityCap) {
 			return false, nil
 		}
 
@@ -427,6 +699,7 @@ func (p *poolsUseCase) processOrderbookPoolIDForBaseQuote(baseDenom, quoteDenom
 		PoolID:          poolID,
 		LiquidityCap:    poolLiquidityCapitalization,
 		ContractAddress: contractAddress,
+		IsPinned:        isPinned,
 	})
 
 	// Store the pool ID in the canonical orderbook pool IDs
@@ -437,25 +710,40 @@ func (p *poolsUseCase) processOrderbookPoolIDForBaseQuote(baseDenom, quoteDenom
 
 // GetCanonicalOrderbookPool implements mvc.PoolsUsecase.
 func (p *poolsUseCase) GetCanonicalOrderbookPool(baseDenom, quoteDenom string) (uint64, string, error) {
+	poolID, contractAddress, _, err := p.GetCanonicalOrderbookPoolWithReason(baseDenom, quoteDenom)
+	return poolID, contractAddress, err
+}
+
+// GetCanonicalOrderbookPoolWithReason implements mvc.PoolsUsecase.
+func (p *poolsUseCase) GetCanonicalOrderbookPoolWithReason(baseDenom, quoteDenom string) (uint64, string, osmomath.Int, error) {
 	baseQuote := formatBaseQuoteDenom(baseDenom, quoteDenom)
 	topLiquidityOrderBook, found := p.canonicalOrderBookForBaseQuoteDenom.Load(baseQuote)
 	if !found {
-		return 0, "", fmt.Errorf("canonical orderbook not found for base %s and quote %s", baseDenom, quoteDenom)
+		return 0, "", osmomath.Int{}, fmt.Errorf("canonical orderbook not found for base %s and quote %s", baseDenom, quoteDenom)
 	}
 
 	topLiquidityOrderBookEntry, ok := topLiquidityOrderBook.(orderBookEntry)
 	if !ok {
-		return 0, "", fmt.Errorf("failed to cast orderbook entry with value %v", topLiquidityOrderBook)
+		return 0, "", osmomath.Int{}, fmt.Errorf("failed to cast orderbook entry with value %v", topLiquidityOrderBook)
 	}
 
-	return topLiquidityOrderBookEntry.PoolID, topLiquidityOrderBookEntry.ContractAddress, nil
+	return topLiquidityOrderBookEntry.PoolID, topLiquidityOrderBookEntry.ContractAddress, topLiquidityOrderBookEntry.LiquidityCap, nil
 }
 
 // GetAllCanonicalOrderbookPoolIDs implements mvc.PoolsUsecase.
-func (p *poolsUseCase) GetAllCanonicalOrderbookPoolIDs() ([]domain.CanonicalOrderBooksResult, error) {
+func (p *poolsUseCase) GetAllCanonicalOrderbookPoolIDs(opts ...domain.CanonicalOrderbooksOption) ([]domain.CanonicalOrderBooksResult, error) {
+	options := domain.CanonicalOrderbooksOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	filterByMinCap := !options.MinLiquidityCap.IsNil()
+
 	var (
 		results []domain.CanonicalOrderBooksResult
-		err     error
+		// liquidityCaps tracks the liquidity cap of each result by pool ID for sorting.
+		liquidityCaps = make(map[uint64]osmomath.Int)
+		err           error
 	)
 
 	p.canonicalOrderBookForBaseQuoteDenom.Range(func(key, value any) bool {
@@ -489,6 +777,13 @@ func (p *poolsUseCase) GetAllCanonicalOrderbookPoolIDs() ([]domain.CanonicalOrde
 			return false
 		}
 
+		// Skip orderbooks below the minimum liquidity cap, if configured
+		if filterByMinCap && topLiquidityOrderBook.LiquidityCap.LT(options.MinLiquidityCap) {
+			return true
+		}
+
+		liquidityCaps[topLiquidityOrderBook.PoolID] = topLiquidityOrderBook.LiquidityCap
+
 		results = append(results, domain.CanonicalOrderBooksResult{
 			Base:            baseDenom,
 			Quote:           quoteDenom,
@@ -499,8 +794,15 @@ func (p *poolsUseCase) GetAllCanonicalOrderbookPoolIDs() ([]domain.CanonicalOrde
 		return true
 	})
 
-	// Sort by pool ID for deterministic results
+	// Sort by liquidity cap descending when filtering by it, falling back to pool ID
+	// for deterministic results
 	sort.Slice(results, func(i, j int) bool {
+		if filterByMinCap {
+			capI, capJ := liquidityCaps[results[i].PoolID], liquidityCaps[results[j].PoolID]
+			if !capI.Equal(capJ) {
+				return capI.GT(capJ)
+			}
+		}
 		return results[i].PoolID < results[j].PoolID
 	})
 
@@ -523,6 +825,24 @@ func (p *poolsUseCase) IsCanonicalOrderbookPool(poolID uint64) bool {
 	return exists
 }
 
+// IsCosmWasmPoolCircuitOpen implements mvc.PoolsUsecase.
+// Returns false if the circuit breaker is disabled.
+func (p *poolsUseCase) IsCosmWasmPoolCircuitOpen(poolID uint64) bool {
+	if p.cosmWasmPoolsParams.CircuitBreaker == nil {
+		return false
+	}
+	return p.cosmWasmPoolsParams.CircuitBreaker.IsCosmWasmPoolCircuitOpen(poolID)
+}
+
+// GetCosmWasmCircuitBreakerStatuses implements mvc.PoolsUsecase.
+// Returns an empty slice if the circuit breaker is disabled.
+func (p *poolsUseCase) GetCosmWasmCircuitBreakerStatuses() []domain.CosmWasmCircuitBreakerPoolStatus {
+	if p.cosmWasmPoolsParams.CircuitBreaker == nil {
+		return []domain.CosmWasmCircuitBreakerPoolStatus{}
+	}
+	return p.cosmWasmPoolsParams.CircuitBreaker.GetPoolStatuses()
+}
+
 // GetCosmWasmPoolConfig implements mvc.PoolsUsecase.
 func (p *poolsUseCase) GetCosmWasmPoolConfig() domain.CosmWasmPoolRouterConfig {
 	return p.cosmWasmPoolsParams.Config
@@ -550,6 +870,21 @@ func (p *poolsUseCase) CalcExitCFMMPool(poolID uint64, exitingSharesIn osmomath.
 	return calcExitPool(sdk.Context{}, pool, exitingSharesIn, exitFee)
 }
 
+// CalcExitCFMMPoolBatch implements mvc.PoolsUsecase.
+func (p *poolsUseCase) CalcExitCFMMPoolBatch(requests []domain.CalcExitCFMMPoolRequest) ([]sdk.Coins, []error) {
+	coins := make([]sdk.Coins, len(requests))
+	errs := make([]error, len(requests))
+
+	for i, request := range requests {
+		coins[i], errs[i] = p.CalcExitCFMMPool(request.PoolID, request.ExitingShares)
+		if errs[i] != nil {
+			coins[i] = nil
+		}
+	}
+
+	return coins, errs
+}
+
 // errMsgFormatSharesLargerThanMax is the error message format for when the exiting shares are larger than the max allowed.
 const errMsgFormatSharesLargerThanMax = "cannot exit all shares in a pool. Attempted to exit %f shares, max allowed is %f"
 
@@ -630,6 +965,13 @@ func calcExitPool(ctx sdk.Context, pool types.CFMMPoolI, exitingSharesIn osmomat
 // The input poolsToUpdate parameter is mutated with the poolConsidered if it matches the options.
 func (p *poolsUseCase) retainPoolIfMatchesOptions(poolsToUpdate []sqsdomain.PoolI, poolConsidered sqsdomain.PoolI, options domain.PoolsOptions) []sqsdomain.PoolI {
 	if options.MinPoolLiquidityCap == 0 || poolConsidered.GetLiquidityCap().Uint64() >= options.MinPoolLiquidityCap {
+		// Skip pools of the types that are not retained.
+		if options.PoolTypeFilter != nil {
+			if _, ok := options.PoolTypeFilter[poolConsidered.GetType()]; !ok {
+				return poolsToUpdate
+			}
+		}
+
 		// Set APR and fee data if configured
 		p.setPoolAPRAndFeeDataIfConfigured(poolConsidered, options)
 
@@ -648,7 +990,7 @@ func (p *poolsUseCase) setPoolAPRAndFeeDataIfConfigured(pool sqsdomain.PoolI, op
 		poolID := pool.GetId()
 
 		// Get APR data
-		poolAPRData, _, isStale, err := p.aprPrefetcher.GetByKey(poolID)
+		poolAPRData, aprLastUpdated, isStale, err := p.aprPrefetcher.GetByKey(poolID)
 		if err != nil {
 			// Log error if fails to get APR data
 			p.logger.Error("failed to get APR data", zap.Uint64("poolID", poolID), zap.Error(err))
@@ -656,13 +998,14 @@ func (p *poolsUseCase) setPoolAPRAndFeeDataIfConfigured(pool sqsdomain.PoolI, op
 
 		// Set APR data
 		pool.SetAPRData(sqspassthroughdomain.PoolAPRDataStatusWrap{
-			PoolAPR: poolAPRData,
-			IsStale: isStale,
-			IsError: err != nil,
+			PoolAPR:     poolAPRData,
+			IsStale:     isStale,
+			IsError:     err != nil,
+			LastUpdated: timeOrNil(aprLastUpdated),
 		})
 
 		// Get pool fee data
-		poolFeeData, _, isStale, err := p.poolFeesPrefetcher.GetByKey(poolID)
+		poolFeeData, feesLastUpdated, isStale, err := p.poolFeesPrefetcher.GetByKey(poolID)
 		if err != nil {
 			// Log error if fails to get pool fee data
 			p.logger.Error("failed to get pool fee data", zap.Uint64("poolID", poolID), zap.Error(err))
@@ -670,13 +1013,22 @@ func (p *poolsUseCase) setPoolAPRAndFeeDataIfConfigured(pool sqsdomain.PoolI, op
 
 		// Set pool fee data
 		pool.SetFeesData(sqspassthroughdomain.PoolFeesDataStatusWrap{
-			PoolFee: poolFeeData,
-			IsStale: isStale,
-			IsError: err != nil,
+			PoolFee:     poolFeeData,
+			IsStale:     isStale,
+			IsError:     err != nil,
+			LastUpdated: timeOrNil(feesLastUpdated),
 		})
 	}
 }
 
+// timeOrNil returns a pointer to the given time or nil if it is the zero time.
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
 // formatBaseQuoteDenom formats the base and quote denom into a single string with a separator.
 func formatBaseQuoteDenom(baseDenom, quoteDenom string) string {
 	return baseDenom + baseQuoteKeySeparator + quoteDenom
@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -110,6 +111,19 @@ func (s *PoolsUsecaseTestSuite) TestGetRoutesFromCandidates() {
 	// Validate that it is indeed broken.
 	s.Require().Error(err)
 
+	// CosmWasm pool that is missing its cosmwasm pool model.
+	nilModelPoolID := defaultPoolID + 1
+	nilModelCosmWasmPool := &mocks.MockRoutablePool{
+		ChainPoolModel: &cosmwasmpoolmodel.CosmWasmPool{
+			PoolId: nilModelPoolID,
+		},
+		ID:       nilModelPoolID,
+		PoolType: poolmanagertypes.CosmWasm,
+	}
+	_, err = pools.NewRoutablePool(nilModelCosmWasmPool, denomTwo, defaultTakerFee, cosmWasmPoolsParams)
+	// Validate that it is indeed broken.
+	s.Require().Error(err)
+
 	validCandidateRoutes := sqsdomain.CandidateRoutes{
 		Routes: []sqsdomain.CandidateRoute{
 			{
@@ -139,6 +153,9 @@ func (s *PoolsUsecaseTestSuite) TestGetRoutesFromCandidates() {
 		tokenInDenom    string
 		tokenOutDenom   string
 
+		// IDs of the pools whose circuit is opened before the conversion.
+		openCircuitPoolIDs []uint64
+
 		expectedError error
 
 		expectedRoutes []route.RouteImpl
@@ -215,6 +232,50 @@ func (s *PoolsUsecaseTestSuite) TestGetRoutesFromCandidates() {
 				},
 			},
 		},
+		{
+			name:  "pool with nil cosmwasm pool model is skipped without failing the whole conversion",
+			pools: []sqsdomain.PoolI{nilModelCosmWasmPool, defaultPool},
+
+			candidateRoutes: sqsdomain.CandidateRoutes{
+				Routes: []sqsdomain.CandidateRoute{
+					{
+						Pools: []sqsdomain.CandidatePool{
+							{
+								ID:            nilModelPoolID,
+								TokenOutDenom: denomTwo,
+							},
+						},
+					},
+					validCandidateRoutes.Routes[0],
+				},
+			},
+			takerFeeMap: validTakerFeeMap,
+
+			tokenInDenom:  denomOne,
+			tokenOutDenom: denomTwo,
+
+			expectedRoutes: []route.RouteImpl{
+				{
+					Pools: []domain.RoutablePool{
+						s.newRoutablePool(defaultPool, denomTwo, defaultTakerFee),
+					},
+				},
+			},
+		},
+		{
+			name:  "route through pool with open circuit is skipped",
+			pools: validPools,
+
+			candidateRoutes: validCandidateRoutes,
+			takerFeeMap:     validTakerFeeMap,
+
+			tokenInDenom:  denomOne,
+			tokenOutDenom: denomTwo,
+
+			openCircuitPoolIDs: []uint64{defaultPoolID},
+
+			expectedRoutes: []route.RouteImpl{},
+		},
 
 		// TODO:
 		// Valid conversion of single multi-hop route
@@ -230,11 +291,18 @@ func (s *PoolsUsecaseTestSuite) TestGetRoutesFromCandidates() {
 			routerRepo.SetTakerFees(tc.takerFeeMap)
 
 			// Create pools use case
-			poolsUsecase, err := usecase.NewPoolsUsecase(&domain.PoolsConfig{}, "node-uri-placeholder", routerRepo, domain.UnsetScalingFactorGetterCb, logger)
+			poolsUsecase, err := usecase.NewPoolsUsecase(&domain.PoolsConfig{
+				CosmWasmCircuitBreakerFailureThreshold: 1,
+				CosmWasmCircuitBreakerCooldownSeconds:  60,
+			}, "node-uri-placeholder", routerRepo, domain.UnsetScalingFactorGetterCb, logger)
 			s.Require().NoError(err)
 
 			poolsUsecase.StorePools(tc.pools)
 
+			for _, poolID := range tc.openCircuitPoolIDs {
+				poolsUsecase.RecordCosmWasmPoolFailure(poolID)
+			}
+
 			// System under test
 			actualRoutes, err := poolsUsecase.GetRoutesFromCandidates(tc.candidateRoutes, tc.tokenInDenom, tc.tokenOutDenom)
 
@@ -255,9 +323,9 @@ func (s *PoolsUsecaseTestSuite) TestGetRoutesFromCandidates() {
 				// helper method for validation.
 				// Note token in is chosen arbitrarily since it is irrelevant for this test
 				tokenIn := sdk.NewCoin(tc.tokenInDenom, osmomath.NewInt(100))
-				actualPools, _, _, err := actualRoute.PrepareResultPools(context.TODO(), tokenIn, logger)
+				actualPools, _, _, _, err := actualRoute.PrepareResultPools(context.TODO(), tokenIn, logger)
 				s.Require().NoError(err)
-				expectedPools, _, _, err := expectedRoute.PrepareResultPools(context.TODO(), tokenIn, logger)
+				expectedPools, _, _, _, err := expectedRoute.PrepareResultPools(context.TODO(), tokenIn, logger)
 				s.Require().NoError(err)
 
 				// Validates:
@@ -388,6 +456,171 @@ func (s *PoolsUsecaseTestSuite) TestProcessOrderbookPoolIDForBaseQuote() {
 	}
 }
 
+// Tests that the orderbooks configured as canonical are always treated as canonical for their
+// base/quote pair, bypassing the liquidity capitalization comparison.
+func (s *PoolsUsecaseTestSuite) TestProcessOrderbookPoolIDForBaseQuote_PinnedCanonicalOrderbook() {
+	const (
+		pinnedPoolID           = defaultPoolID
+		otherPoolID            = defaultPoolID + 1
+		otherPinnedPoolID      = defaultPoolID + 2
+		defaultContractAddress = "default-address"
+	)
+
+	var (
+		lowerCap  = defaultPoolLiquidityCap
+		higherCap = defaultPoolLiquidityCap.Add(osmomath.OneInt())
+	)
+
+	type orderbookUpdate struct {
+		poolID                      uint64
+		poolLiquidityCapitalization osmomath.Int
+
+		expectedUpdated bool
+	}
+
+	testCases := []struct {
+		name string
+
+		pinnedPoolIDs []uint64
+		updates       []orderbookUpdate
+
+		expectedCanonicalOrderbookPoolID uint64
+	}{
+		{
+			name:          "pinned pool with lower cap overrides the higher cap book",
+			pinnedPoolIDs: []uint64{pinnedPoolID},
+			updates: []orderbookUpdate{
+				{poolID: otherPoolID, poolLiquidityCapitalization: higherCap, expectedUpdated: true},
+				{poolID: pinnedPoolID, poolLiquidityCapitalization: lowerCap, expectedUpdated: true},
+			},
+
+			expectedCanonicalOrderbookPoolID: pinnedPoolID,
+		},
+		{
+			name:          "higher cap book does not override the pinned pool",
+			pinnedPoolIDs: []uint64{pinnedPoolID},
+			updates: []orderbookUpdate{
+				{poolID: pinnedPoolID, poolLiquidityCapitalization: lowerCap, expectedUpdated: true},
+				{poolID: otherPoolID, poolLiquidityCapitalization: higherCap, expectedUpdated: false},
+			},
+
+			expectedCanonicalOrderbookPoolID: pinnedPoolID,
+		},
+		{
+			name:          "pinned pool is updated with a lower cap",
+			pinnedPoolIDs: []uint64{pinnedPoolID},
+			updates: []orderbookUpdate{
+				{poolID: pinnedPoolID, poolLiquidityCapitalization: higherCap, expectedUpdated: true},
+				{poolID: pinnedPoolID, poolLiquidityCapitalization: lowerCap, expectedUpdated: true},
+			},
+
+			expectedCanonicalOrderbookPoolID: pinnedPoolID,
+		},
+		{
+			name:          "latest pinned pool overrides the previous pinned pool",
+			pinnedPoolIDs: []uint64{pinnedPoolID, otherPinnedPoolID},
+			updates: []orderbookUpdate{
+				{poolID: pinnedPoolID, poolLiquidityCapitalization: higherCap, expectedUpdated: true},
+				{poolID: otherPinnedPoolID, poolLiquidityCapitalization: lowerCap, expectedUpdated: true},
+			},
+
+			expectedCanonicalOrderbookPoolID: otherPinnedPoolID,
+		},
+		{
+			name:          "no pinned pool for the pair - higher cap book is canonical",
+			pinnedPoolIDs: []uint64{otherPinnedPoolID},
+			updates: []orderbookUpdate{
+				{poolID: pinnedPoolID, poolLiquidityCapitalization: lowerCap, expectedUpdated: true},
+				{poolID: otherPoolID, poolLiquidityCapitalization: higherCap, expectedUpdated: true},
+			},
+
+			expectedCanonicalOrderbookPoolID: otherPoolID,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			routerRepo := routerrepo.New(&log.NoOpLogger{})
+			poolsUsecase, err := usecase.NewPoolsUsecase(&domain.PoolsConfig{
+				CanonicalOrderbookPoolIDs: tc.pinnedPoolIDs,
+			}, "node-uri-placeholder", routerRepo, domain.UnsetScalingFactorGetterCb, &log.NoOpLogger{})
+			s.Require().NoError(err)
+
+			for _, update := range tc.updates {
+				// System under test
+				updatedBool, err := poolsUsecase.ProcessOrderbookPoolIDForBaseQuote(denomOne, denomTwo, update.poolID, update.poolLiquidityCapitalization, defaultContractAddress)
+				s.Require().NoError(err)
+				s.Require().Equal(update.expectedUpdated, updatedBool)
+			}
+
+			canonicalPoolID, _, err := poolsUsecase.GetCanonicalOrderbookPool(denomOne, denomTwo)
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedCanonicalOrderbookPoolID, canonicalPoolID)
+
+			// Validate that only the canonical orderbook pool ID is marked as canonical
+			for _, poolID := range []uint64{pinnedPoolID, otherPoolID, otherPinnedPoolID} {
+				s.Require().Equal(tc.expectedCanonicalOrderbookPoolID == poolID, poolsUsecase.IsCanonicalOrderbookPool(poolID))
+			}
+		})
+	}
+}
+
+// Tests that GetCanonicalOrderbookPoolWithReason returns the liquidity capitalization
+// of the stored canonical orderbook entry alongside the pool ID and contract address.
+func (s *PoolsUsecaseTestSuite) TestGetCanonicalOrderbookPoolWithReason() {
+	testCases := []struct {
+		name string
+
+		preStoreValidEntryCap osmomath.Int
+		preStoreInvalidEntry  bool
+
+		expectedError bool
+	}{
+		{
+			name:                  "valid entry - cap returned",
+			preStoreValidEntryCap: defaultPoolLiquidityCap,
+		},
+		{
+			name:                 "invalid entry - error",
+			preStoreInvalidEntry: true,
+			expectedError:        true,
+		},
+		{
+			name:          "no entry - error",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			poolsUsecase := s.newDefaultPoolsUseCase()
+
+			if tc.preStoreInvalidEntry {
+				poolsUsecase.StoreInvalidOrderBookEntry(denomOne, denomTwo)
+			}
+
+			if !tc.preStoreValidEntryCap.IsNil() {
+				poolsUsecase.StoreValidOrdeBookEntry(denomOne, denomTwo, defaultPoolID, tc.preStoreValidEntryCap)
+			}
+
+			// System under test
+			poolID, contractAddress, liquidityCap, err := poolsUsecase.GetCanonicalOrderbookPoolWithReason(denomOne, denomTwo)
+
+			if tc.expectedError {
+				s.Require().Error(err)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(defaultPoolID, poolID)
+			s.Require().Equal(usecase.OriginalOrderbookAddress, contractAddress)
+			s.Require().Equal(tc.preStoreValidEntryCap.String(), liquidityCap.String())
+		})
+	}
+}
+
 // Happy path test for StorePools validating that
 // for orderbook pools, we also update the canonical orderbook pool ID.
 // We also validate that any errors stemming from orderbook handling logic are silently skipped
@@ -467,9 +700,15 @@ func (s *PoolsUsecaseTestSuite) TestStorePools() {
 	// Pre-set invalid data for the base/quote
 	poolsUsecase.StoreInvalidOrderBookEntry(invalidBaseDenom, orderBookQuoteDenom)
 
+	// Storing no pools does not mark the pools as loaded
+	poolsUsecase.StorePools([]sqsdomain.PoolI{})
+	s.Require().False(poolsUsecase.ArePoolsLoaded())
+
 	// System under test
 	poolsUsecase.StorePools(validPools)
 
+	s.Require().True(poolsUsecase.ArePoolsLoaded())
+
 	// Validate that the pools are stored
 	actualBalancerPool, err := poolsUsecase.GetPool(defaultPoolID)
 	s.Require().NoError(err)
@@ -532,6 +771,28 @@ func (s *PoolsUsecaseTestSuite) TestGetAllCanonicalOrderbooks_HappyPath() {
 	// Validate that the correct canonical orderbook pool IDs are returned
 	s.Require().Equal(expectedCanonicalOrderbookPoolIDs, canonicalOrderbooks)
 
+	// Denom one and denom three with the largest cap
+	poolsUseCase.StoreValidOrdeBookEntry(denomOne, denomThree, defaultPoolID+2, defaultPoolLiquidityCap.Add(osmomath.NewInt(2)))
+
+	// System under test: filter out the orderbook below the min cap
+	canonicalOrderbooks, err = poolsUseCase.GetAllCanonicalOrderbookPoolIDs(domain.WithMinCanonicalOrderbookLiquidityCap(defaultPoolLiquidityCap.Add(osmomath.OneInt())))
+	s.Require().NoError(err)
+
+	// Validate that the results are filtered and sorted by liquidity cap in descending order
+	s.Require().Equal([]domain.CanonicalOrderBooksResult{
+		{
+			Base:            denomOne,
+			Quote:           denomThree,
+			PoolID:          defaultPoolID + 2,
+			ContractAddress: usecase.OriginalOrderbookAddress,
+		},
+		{
+			Base:            denomThree,
+			Quote:           denomFour,
+			PoolID:          defaultPoolID + 1,
+			ContractAddress: usecase.OriginalOrderbookAddress,
+		},
+	}, canonicalOrderbooks)
 }
 
 // Happy path test to vaidate that no panics/errors occur and coins are returned
@@ -570,6 +831,79 @@ func (s *PoolsUsecaseTestSuite) TestCalcExitCFMMPool_HappyPath() {
 	s.Require().False(actualCoins.Empty())
 }
 
+// Validates that the batch variant computes the coins for valid requests
+// while surfacing per-entry errors for the invalid ones.
+func (s *PoolsUsecaseTestSuite) TestCalcExitCFMMPoolBatch() {
+	s.Setup()
+
+	// Create CFMM pools
+	validPoolID := s.PrepareBalancerPool()
+	tooManySharesPoolID := s.PrepareBalancerPool()
+
+	sqsPools := make([]sqsdomain.PoolI, 0, 3)
+	for _, poolID := range []uint64{validPoolID, tooManySharesPoolID} {
+		cfmmPool, err := s.App.GAMMKeeper.GetCFMMPool(s.Ctx, poolID)
+		s.Require().NoError(err)
+
+		poolBalances := s.App.BankKeeper.GetAllBalances(s.Ctx, cfmmPool.GetAddress())
+		sqsPools = append(sqsPools, sqsdomain.NewPool(cfmmPool, cfmmPool.GetSpreadFactor(s.Ctx), poolBalances))
+	}
+
+	// Non-CFMM pool
+	const concentratedPoolID = uint64(1000)
+	sqsPools = append(sqsPools, &mocks.MockRoutablePool{
+		ID:       concentratedPoolID,
+		PoolType: poolmanagertypes.Concentrated,
+	})
+
+	const nonExistentPoolID = uint64(2000)
+
+	// Create default use case
+	poolsUseCase := s.newDefaultPoolsUseCase()
+
+	// Store pools
+	err := poolsUseCase.StorePools(sqsPools)
+	s.Require().NoError(err)
+
+	// Arbitrary large number.
+	numSharesExiting := osmomath.NewInt(1_000_000_000_000_000_000)
+
+	requests := []domain.CalcExitCFMMPoolRequest{
+		{PoolID: validPoolID, ExitingShares: numSharesExiting},
+		{PoolID: nonExistentPoolID, ExitingShares: numSharesExiting},
+		{PoolID: tooManySharesPoolID, ExitingShares: sqsPools[1].GetUnderlyingPool().(gammtypes.CFMMPoolI).GetTotalShares()},
+		{PoolID: concentratedPoolID, ExitingShares: numSharesExiting},
+		// Duplicate pool ID with different exiting shares
+		{PoolID: validPoolID, ExitingShares: numSharesExiting.QuoRaw(2)},
+	}
+
+	// System under test
+	actualCoins, actualErrors := poolsUseCase.CalcExitCFMMPoolBatch(requests)
+
+	s.Require().Len(actualCoins, len(requests))
+	s.Require().Len(actualErrors, len(requests))
+
+	// Validate that the valid requests match the single pool variant
+	for _, i := range []int{0, 4} {
+		expectedCoins, err := poolsUseCase.CalcExitCFMMPool(requests[i].PoolID, requests[i].ExitingShares)
+		s.Require().NoError(err)
+		s.Require().NoError(actualErrors[i])
+		s.Require().False(actualCoins[i].Empty())
+		s.Require().Equal(expectedCoins, actualCoins[i])
+	}
+
+	// The requests for the same pool ID are computed independently
+	s.Require().NotEqual(actualCoins[0], actualCoins[4])
+
+	// Validate per-entry errors
+	s.Require().Equal(domain.PoolNotFoundError{PoolID: nonExistentPoolID}, actualErrors[1])
+	s.Require().ErrorIs(actualErrors[2], gammtypes.ErrLimitMaxAmount)
+	s.Require().ErrorContains(actualErrors[3], "expected CFMM pool")
+	for _, i := range []int{1, 2, 3} {
+		s.Require().Nil(actualCoins[i])
+	}
+}
+
 // TestCalcExitPool is a copy of node's TestCalcExitPool
 // This implementation includes several specific test cases to cover arithmetic on floats instead of sdk math data types.
 // @link https://github.com/osmosis-labs/osmosis/blob/fde1776476d9c2f849dcbfb30ca3ec64d0e12863/x/gamm/pool-models/internal/cfmm_common/lp_test.go#L31
@@ -755,6 +1089,272 @@ func (s *PoolsUsecaseTestSuite) TestGetPools() {
 	s.Require().Empty(pools)
 }
 
+// Validates that the pool type filter retains only the pools of the given types
+// and is applied after the other filters.
+func (s *PoolsUsecaseTestSuite) TestGetPools_PoolTypeFilter() {
+	mainnetState := s.SetupMainnetState()
+
+	usecase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	allPools, err := usecase.Pools.GetPools()
+	s.Require().NoError(err)
+
+	// Concentrated only
+	pools, err := usecase.Pools.GetPools(domain.WithPoolTypeFilter(poolmanagertypes.Concentrated))
+	s.Require().NoError(err)
+	s.Require().NotEmpty(pools)
+	s.Require().Less(len(pools), len(allPools))
+
+	expectedConcentratedPoolCount := 0
+	for _, pool := range allPools {
+		if pool.GetType() == poolmanagertypes.Concentrated {
+			expectedConcentratedPoolCount++
+		}
+	}
+	s.Require().Len(pools, expectedConcentratedPoolCount)
+
+	for _, pool := range pools {
+		s.Require().Equal(poolmanagertypes.Concentrated, pool.GetType())
+	}
+
+	// Pool 1 is balancer, pools 1066 and 1093 are concentrated and pool 1904 is CosmWasm.
+	poolsFilter := []uint64{1, 1066, 1093, 1904}
+
+	// Concentrated only combined with pool ID filter
+	pools, err = usecase.Pools.GetPools(domain.WithPoolIDFilter(poolsFilter), domain.WithPoolTypeFilter(poolmanagertypes.Concentrated))
+	s.Require().NoError(err)
+	s.Require().Equal([]uint64{1066, 1093}, poolIDs(pools))
+
+	// Multiple types combined with pool ID and min liquidity cap filters.
+	// Pool 1066 is excluded by the min liquidity cap filter.
+	pools, err = usecase.Pools.GetPools(domain.WithPoolIDFilter(poolsFilter), domain.WithMinPoolsLiquidityCap(100_000), domain.WithPoolTypeFilter(poolmanagertypes.Concentrated, poolmanagertypes.Balancer))
+	s.Require().NoError(err)
+	s.Require().Equal([]uint64{1, 1093}, poolIDs(pools))
+
+	// No types retains pools of all types
+	pools, err = usecase.Pools.GetPools(domain.WithPoolIDFilter(poolsFilter), domain.WithPoolTypeFilter())
+	s.Require().NoError(err)
+	s.Require().Equal(poolsFilter, poolIDs(pools))
+}
+
+// poolIDs returns the IDs of the given pools.
+func poolIDs(pools []sqsdomain.PoolI) []uint64 {
+	result := make([]uint64, 0, len(pools))
+	for _, pool := range pools {
+		result = append(result, pool.GetId())
+	}
+	return result
+}
+
+func (s *PoolsUsecaseTestSuite) TestGetPools_SortBy() {
+	newPool := func(id uint64, liquidityCap int64, spreadFactor string) sqsdomain.PoolI {
+		return &mocks.MockRoutablePool{
+			ID:               id,
+			PoolLiquidityCap: osmomath.NewInt(liquidityCap),
+			SpreadFactor:     osmomath.MustNewDecFromStr(spreadFactor),
+		}
+	}
+
+	// Pools 2 and 4 tie on liquidity cap, pools 1 and 4 tie on spread factor.
+	storedPools := []sqsdomain.PoolI{
+		newPool(3, 100, "0.003"),
+		newPool(1, 300, "0.001"),
+		newPool(4, 200, "0.001"),
+		newPool(2, 200, "0.002"),
+	}
+
+	testCases := []struct {
+		name string
+		opts []domain.PoolsOption
+
+		expectedPoolIDs []uint64
+	}{
+		{
+			name:            "sort by pool id ascending",
+			opts:            []domain.PoolsOption{domain.WithPoolsSortBy(domain.PoolSortFieldID, false)},
+			expectedPoolIDs: []uint64{1, 2, 3, 4},
+		},
+		{
+			name:            "sort by pool id descending",
+			opts:            []domain.PoolsOption{domain.WithPoolsSortBy(domain.PoolSortFieldID, true)},
+			expectedPoolIDs: []uint64{4, 3, 2, 1},
+		},
+		{
+			name:            "sort by liquidity cap ascending, ties broken by pool id",
+			opts:            []domain.PoolsOption{domain.WithPoolsSortBy(domain.PoolSortFieldLiquidityCap, false)},
+			expectedPoolIDs: []uint64{3, 2, 4, 1},
+		},
+		{
+			name:            "sort by liquidity cap descending, ties broken by pool id",
+			opts:            []domain.PoolsOption{domain.WithPoolsSortBy(domain.PoolSortFieldLiquidityCap, true)},
+			expectedPoolIDs: []uint64{1, 2, 4, 3},
+		},
+		{
+			name:            "sort by spread factor ascending, ties broken by pool id",
+			opts:            []domain.PoolsOption{domain.WithPoolsSortBy(domain.PoolSortFieldSpreadFactor, false)},
+			expectedPoolIDs: []uint64{1, 4, 2, 3},
+		},
+		{
+			name:            "sort by spread factor descending, ties broken by pool id",
+			opts:            []domain.PoolsOption{domain.WithPoolsSortBy(domain.PoolSortFieldSpreadFactor, true)},
+			expectedPoolIDs: []uint64{3, 2, 1, 4},
+		},
+		{
+			name: "sort applied after filtering",
+			opts: []domain.PoolsOption{
+				domain.WithMinPoolsLiquidityCap(150),
+				domain.WithPoolIDFilter([]uint64{1, 2, 3}),
+				domain.WithPoolsSortBy(domain.PoolSortFieldLiquidityCap, false),
+			},
+			expectedPoolIDs: []uint64{2, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			poolsUsecase := s.newDefaultPoolsUseCase()
+
+			err := poolsUsecase.StorePools(storedPools)
+			s.Require().NoError(err)
+
+			// System under test
+			actualPools, err := poolsUsecase.GetPools(tc.opts...)
+			s.Require().NoError(err)
+
+			actualPoolIDs := make([]uint64, 0, len(actualPools))
+			for _, pool := range actualPools {
+				actualPoolIDs = append(actualPoolIDs, pool.GetId())
+			}
+
+			s.Require().Equal(tc.expectedPoolIDs, actualPoolIDs)
+		})
+	}
+}
+
+func (s *PoolsUsecaseTestSuite) TestGetPoolsForPair() {
+	newPool := func(id uint64, liquidityCap int64, denoms ...string) sqsdomain.PoolI {
+		return &mocks.MockRoutablePool{
+			ID:               id,
+			PoolLiquidityCap: osmomath.NewInt(liquidityCap),
+			Denoms:           denoms,
+		}
+	}
+
+	storedPools := []sqsdomain.PoolI{
+		newPool(1, 100, denomOne, denomTwo),
+		newPool(2, 300, denomOne, denomThree),
+		newPool(3, 200, denomTwo, denomThree, denomOne),
+		newPool(4, 50, denomTwo, denomOne),
+		newPool(5, 400, denomTwo, denomThree),
+	}
+
+	testCases := []struct {
+		name   string
+		denomA string
+		denomB string
+		opts   []domain.PoolsOption
+		// pools stored on top of storedPools, simulating a subsequent ingest.
+		updatedPools []sqsdomain.PoolI
+
+		expectedPoolIDs []uint64
+	}{
+		{
+			name:            "pair present in multiple pools",
+			denomA:          denomOne,
+			denomB:          denomTwo,
+			expectedPoolIDs: []uint64{1, 3, 4},
+		},
+		{
+			name:            "pair present in multiple pools, reversed denoms",
+			denomA:          denomTwo,
+			denomB:          denomOne,
+			expectedPoolIDs: []uint64{1, 3, 4},
+		},
+		{
+			name:            "pair present in a single multi-asset pool and a two-asset pool",
+			denomA:          denomThree,
+			denomB:          denomTwo,
+			expectedPoolIDs: []uint64{3, 5},
+		},
+		{
+			name:            "pair with min liquidity cap",
+			denomA:          denomOne,
+			denomB:          denomTwo,
+			opts:            []domain.PoolsOption{domain.WithMinPoolsLiquidityCap(75)},
+			expectedPoolIDs: []uint64{1, 3},
+		},
+		{
+			name:            "pair sorted by liquidity cap descending",
+			denomA:          denomOne,
+			denomB:          denomTwo,
+			opts:            []domain.PoolsOption{domain.WithPoolsSortBy(domain.PoolSortFieldLiquidityCap, true)},
+			expectedPoolIDs: []uint64{3, 1, 4},
+		},
+		{
+			name:            "pool updated without one of the pair denoms is pruned",
+			denomA:          denomOne,
+			denomB:          denomTwo,
+			updatedPools:    []sqsdomain.PoolI{newPool(3, 200, denomTwo, denomThree)},
+			expectedPoolIDs: []uint64{1, 4},
+		},
+		{
+			name:            "pool updated with a new pair denom is indexed",
+			denomA:          denomOne,
+			denomB:          denomThree,
+			updatedPools:    []sqsdomain.PoolI{newPool(5, 400, denomTwo, denomThree, denomOne)},
+			expectedPoolIDs: []uint64{2, 3, 5},
+		},
+		{
+			name:         "denom with no pools left after update is absent",
+			denomA:       denomFour,
+			denomB:       denomTwo,
+			updatedPools: []sqsdomain.PoolI{newPool(6, 10, denomFour, denomTwo), newPool(6, 10, denomOne, denomTwo)},
+		},
+		{
+			name:   "absent pair",
+			denomA: denomOne,
+			denomB: "unknown",
+		},
+		{
+			name:   "both denoms absent",
+			denomA: "unknown",
+			denomB: "other",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			poolsUsecase := s.newDefaultPoolsUseCase()
+
+			err := poolsUsecase.StorePools(storedPools)
+			s.Require().NoError(err)
+
+			for _, pool := range tc.updatedPools {
+				err = poolsUsecase.StorePools([]sqsdomain.PoolI{pool})
+				s.Require().NoError(err)
+			}
+
+			// System under test
+			actualPools, err := poolsUsecase.GetPoolsForPair(tc.denomA, tc.denomB, tc.opts...)
+			s.Require().NoError(err)
+
+			if len(tc.expectedPoolIDs) == 0 {
+				s.Require().Empty(actualPools)
+				return
+			}
+
+			actualPoolIDs := make([]uint64, 0, len(actualPools))
+			for _, pool := range actualPools {
+				actualPoolIDs = append(actualPoolIDs, pool.GetId())
+			}
+
+			s.Require().Equal(tc.expectedPoolIDs, actualPoolIDs)
+		})
+	}
+}
+
 func (s *PoolsUsecaseTestSuite) TestSetPoolAPRAndFeeDataIfConfigured() {
 	var (
 		// Helper functions to modify the APR and fee data
@@ -774,6 +1374,15 @@ func (s *PoolsUsecaseTestSuite) TestSetPoolAPRAndFeeDataIfConfigured() {
 			feeData.IsError = true
 			return feeData
 		}
+		// Both mock fetchers return defaultTime as the last fetch time.
+		withAPRLastUpdated = func(aprData sqspassthroughdomain.PoolAPRDataStatusWrap) sqspassthroughdomain.PoolAPRDataStatusWrap {
+			aprData.LastUpdated = &defaultTime
+			return aprData
+		}
+		withFeeLastUpdated = func(feeData sqspassthroughdomain.PoolFeesDataStatusWrap) sqspassthroughdomain.PoolFeesDataStatusWrap {
+			feeData.LastUpdated = &defaultTime
+			return feeData
+		}
 
 		// Empty APR and fee data
 		emptyAPRData = sqspassthroughdomain.PoolAPRDataStatusWrap{}
@@ -818,8 +1427,8 @@ func (s *PoolsUsecaseTestSuite) TestSetPoolAPRAndFeeDataIfConfigured() {
 				WithMarketIncentives: true,
 			},
 
-			expectedAPRData:  defaultAPRData,
-			expectedFeesData: defaultFeeData,
+			expectedAPRData:  withAPRLastUpdated(defaultAPRData),
+			expectedFeesData: withFeeLastUpdated(defaultFeeData),
 		},
 		{
 			name: "APR and fees not confgiured due to different pool",
@@ -832,8 +1441,8 @@ func (s *PoolsUsecaseTestSuite) TestSetPoolAPRAndFeeDataIfConfigured() {
 				WithMarketIncentives: true,
 			},
 
-			expectedAPRData:  emptyAPRData,
-			expectedFeesData: emptyFeeData,
+			expectedAPRData:  withAPRLastUpdated(emptyAPRData),
+			expectedFeesData: withFeeLastUpdated(emptyFeeData),
 		},
 		{
 			name: "with apr and fee data both stale",
@@ -852,8 +1461,8 @@ func (s *PoolsUsecaseTestSuite) TestSetPoolAPRAndFeeDataIfConfigured() {
 			isAPRDataStale: true,
 			isFeeDataStale: true,
 
-			expectedAPRData:  withIsAPRError(withIsAPRStale(defaultAPRData)),
-			expectedFeesData: withIsFeeError(withIsFeeStale(defaultFeeData)),
+			expectedAPRData:  withAPRLastUpdated(withIsAPRError(withIsAPRStale(defaultAPRData))),
+			expectedFeesData: withFeeLastUpdated(withIsFeeError(withIsFeeStale(defaultFeeData))),
 		},
 	}
 
@@ -880,6 +1489,23 @@ func (s *PoolsUsecaseTestSuite) TestSetPoolAPRAndFeeDataIfConfigured() {
 	}
 }
 
+// Tests that the last updated time is only serialized if it is set.
+func (s *PoolsUsecaseTestSuite) TestPoolDataStatusWrap_LastUpdatedSerialization() {
+	lastUpdated := time.Unix(1700000000, 0).UTC()
+
+	unsetAPRData, err := json.Marshal(sqspassthroughdomain.PoolAPRDataStatusWrap{})
+	s.Require().NoError(err)
+	s.Require().NotContains(string(unsetAPRData), "last_updated")
+
+	unsetFeesData, err := json.Marshal(sqspassthroughdomain.PoolFeesDataStatusWrap{})
+	s.Require().NoError(err)
+	s.Require().NotContains(string(unsetFeesData), "last_updated")
+
+	setAPRData, err := json.Marshal(sqspassthroughdomain.PoolAPRDataStatusWrap{LastUpdated: &lastUpdated})
+	s.Require().NoError(err)
+	s.Require().Contains(string(setAPRData), `"last_updated":"2023-11-14T22:13:20Z"`)
+}
+
 func (s *PoolsUsecaseTestSuite) TestRetainPoolIfMatchesOptions() {
 	const shouldError = false
 	const isStale = false
@@ -1021,3 +1647,71 @@ func getMockFeesFetcher(shouldForceFeesFetcherError, isFeeDataStale bool) *mocks
 		},
 	}
 }
+
+// This test validates that the pool is returned along with the spot prices between all of its denoms
+// that match the spot prices computed for each pair individually.
+func (s *PoolsUsecaseTestSuite) TestGetPoolWithPrices() {
+	mainnetState := s.SetupMainnetState()
+
+	usecase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	testCases := []struct {
+		name string
+
+		poolID uint64
+
+		expectedDenomCount int
+	}{
+		{
+			name:   "two-denom balancer pool",
+			poolID: 1,
+
+			expectedDenomCount: 2,
+		},
+		{
+			name:   "multi-denom stableswap pool",
+			poolID: 908,
+
+			expectedDenomCount: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			// System under test
+			enrichedPool, err := usecase.Pools.GetPoolWithPrices(context.Background(), tc.poolID)
+			s.Require().NoError(err)
+
+			s.Require().Equal(tc.poolID, enrichedPool.Pool.GetId())
+
+			poolDenoms := enrichedPool.Pool.GetPoolDenoms()
+			s.Require().Len(poolDenoms, tc.expectedDenomCount)
+
+			// Spot prices are present for every ordered pair of distinct denoms.
+			s.Require().Len(enrichedPool.SpotPrices, tc.expectedDenomCount)
+			for _, baseDenom := range poolDenoms {
+				quotePrices := enrichedPool.SpotPrices[baseDenom]
+				s.Require().Len(quotePrices, tc.expectedDenomCount-1)
+
+				for _, quoteDenom := range poolDenoms {
+					if quoteDenom == baseDenom {
+						continue
+					}
+
+					expectedSpotPrice, err := usecase.Pools.GetPoolSpotPrice(context.Background(), tc.poolID, defaultTakerFee, quoteDenom, baseDenom)
+					s.Require().NoError(err)
+
+					actualSpotPrice, ok := quotePrices[quoteDenom]
+					s.Require().True(ok)
+					s.Require().True(actualSpotPrice.IsPositive())
+					s.Require().Equal(expectedSpotPrice.String(), actualSpotPrice.String())
+				}
+			}
+		})
+	}
+
+	s.Run("pool not found", func() {
+		_, err := usecase.Pools.GetPoolWithPrices(context.Background(), 1_000_000)
+		s.Require().ErrorIs(err, domain.PoolNotFoundError{PoolID: 1_000_000})
+	})
+}
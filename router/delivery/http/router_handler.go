@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
@@ -11,19 +12,66 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v26/x/poolmanager/types"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
 	"github.com/osmosis-labs/sqs/log"
 	"github.com/osmosis-labs/sqs/router/types"
+	"github.com/osmosis-labs/sqs/sqsdomain"
 )
 
 // RouterHandler  represent the httphandler for the router
 type RouterHandler struct {
 	RUsecase mvc.RouterUsecase
 	TUsecase mvc.TokensUsecase
+	PUsecase mvc.PoolsUsecase
 	logger   log.Logger
 }
 
+// CandidatePoolResponse is a structure for serializing a candidate route pool
+// enriched with its metadata.
+type CandidatePoolResponse struct {
+	ID                   uint64                    `json:"id"`
+	Type                 poolmanagertypes.PoolType `json:"type"`
+	Denoms               []string                  `json:"denoms"`
+	TokenOutDenom        string                    `json:"token_out_denom"`
+	LiquidityCap         osmomath.Int              `json:"liquidity_cap"`
+	IsCanonicalOrderbook bool                      `json:"is_canonical_orderbook"`
+}
+
+// CandidateRouteResponse is a structure for serializing a candidate route.
+type CandidateRouteResponse struct {
+	Pools                     []CandidatePoolResponse `json:"pools"`
+	IsCanonicalOrderbookRoute bool                    `json:"is_canonical_orderbook_route"`
+}
+
+// CandidateRoutesResponse is a structure for serializing the candidate routes
+// returned by the dry-run endpoint.
+type CandidateRoutesResponse struct {
+	Routes                     []CandidateRouteResponse `json:"routes"`
+	ContainsCanonicalOrderbook bool                     `json:"contains_canonical_orderbook"`
+}
+
+// RouterStateSummaryResponse is a structure for serializing a summary of the
+// current router state for live inspection.
+type RouterStateSummaryResponse struct {
+	PoolsCount               int                             `json:"pools_count"`
+	TakerFeesCount           int                             `json:"taker_fees_count"`
+	CandidateRouteSearchData CandidateRouteSearchDataSummary `json:"candidate_route_search_data"`
+	TickMap                  map[uint64]*sqsdomain.TickModel `json:"tick_map,omitempty"`
+}
+
+// CandidateRouteSearchDataSummary is a structure for serializing a summary
+// of the candidate route search data.
+type CandidateRouteSearchDataSummary struct {
+	// DenomsCount is the number of denoms with candidate route search data.
+	DenomsCount int `json:"denoms_count"`
+	// SortedPoolsCount is the total number of sorted pools across all denoms.
+	SortedPoolsCount int `json:"sorted_pools_count"`
+	// CanonicalOrderbooksCount is the total number of canonical orderbooks across all denoms.
+	CanonicalOrderbooksCount int `json:"canonical_orderbooks_count"`
+}
+
 const routerResource = "/router"
 
 var (
@@ -35,20 +83,25 @@ func formatRouterResource(resource string) string {
 }
 
 // NewRouterHandler will initialize the pools/ resources endpoint
-func NewRouterHandler(e *echo.Echo, us mvc.RouterUsecase, tu mvc.TokensUsecase, logger log.Logger) {
+func NewRouterHandler(e *echo.Echo, us mvc.RouterUsecase, tu mvc.TokensUsecase, pu mvc.PoolsUsecase, logger log.Logger) {
 	handler := &RouterHandler{
 		RUsecase: us,
 		TUsecase: tu,
+		PUsecase: pu,
 		logger:   logger,
 	}
 	e.GET(formatRouterResource("/quote"), handler.GetOptimalQuote)
 	e.GET(formatRouterResource("/routes"), handler.GetCandidateRoutes)
+	e.GET(formatRouterResource("/routes/dry-run"), handler.GetCandidateRoutesDryRun)
 	e.GET(formatRouterResource("/cached-routes"), handler.GetCachedCandidateRoutes)
 	e.GET(formatRouterResource("/spot-price-pool/:id"), handler.GetSpotPriceForPool)
 	e.GET(formatRouterResource("/custom-direct-quote"), handler.GetDirectCustomQuote)
 	e.GET(formatRouterResource("/taker-fee-pool/:id"), handler.GetTakerFee)
+	e.GET(formatRouterResource("/taker-fee-pair"), handler.GetTakerFeeForPair)
 	e.POST(formatRouterResource("/store-state"), handler.StoreRouterStateInFiles)
 	e.GET(formatRouterResource("/state"), handler.GetRouterState)
+	e.GET(formatRouterResource("/debug/state"), handler.GetRouterStateSummary)
+	e.GET(formatRouterResource("/debug/cosmwasm-circuit-breaker"), handler.GetCosmWasmCircuitBreakerStatuses)
 }
 
 // @Summary Optimal Quote
@@ -116,7 +169,7 @@ func (a *RouterHandler) GetOptimalQuote(c echo.Context) (err error) {
 
 	var routerOpts []domain.RouterOption
 	if req.SingleRoute {
-		routerOpts = append(routerOpts, domain.WithMaxSplitRoutes(domain.DisableSplitRoutes))
+		routerOpts = append(routerOpts, domain.WithSingleRouteOnly())
 	}
 
 	var quote domain.Quote
@@ -140,6 +193,10 @@ func (a *RouterHandler) GetOptimalQuote(c echo.Context) (err error) {
 		return c.JSON(domain.GetStatusCode(err), domain.ResponseError{Message: err.Error()})
 	}
 
+	// Clamp the response size if configured.
+	routerConfig := a.RUsecase.GetConfig()
+	quote.TruncateRoutes(routerConfig.MaxResponseRoutes, routerConfig.MaxResponsePools)
+
 	span.SetAttributes(attribute.Stringer("token_out", quote.GetAmountOut()))
 	span.SetAttributes(attribute.Stringer("price_impact", quote.GetPriceImpact()))
 
@@ -152,6 +209,9 @@ func (a *RouterHandler) GetOptimalQuote(c echo.Context) (err error) {
 // @Description
 // @Description For exact amount in swap method, the `tokenIn` and `tokenOutDenom` are required.
 // @Description For exact amount out swap method, the `tokenOut` and `tokenInDenom` are required.
+// @Description For exact amount out swap method, the `poolID` and `tokenInDenom` lists are ordered as in the chain's exact amount out swap routes,
+// @Description i.e. the i-th pool takes the i-th token in denom and the last pool produces the `tokenOut`.
+// @Description The legacy order of the exact amount out lists starting from the `tokenOut` is accepted when `tokenOutFirst` is set.
 // @Description Mixing swap method parameters in other way than specified will result in an error.
 // @Description
 // @ID get-direct-quote
@@ -163,6 +223,7 @@ func (a *RouterHandler) GetOptimalQuote(c echo.Context) (err error) {
 // @Param  poolID          query  string  true   "String representing list of the pool ID."                                                                                  example(1100)
 // @Param  humanDenoms     query  bool    true   "Boolean flag indicating whether the given denoms are human readable or not. Human denoms get converted to chain internally"
 // @Param  applyExponents  query  bool    false  "Boolean flag indicating whether to apply exponents to the spot price. False by default."
+// @Param  tokenOutFirst   query  bool    false  "Boolean flag indicating whether the exact amount out `poolID` and `tokenInDenom` lists are in the legacy order starting from the `tokenOut`. False by default."
 // @Success 200  {object}  domain.Quote  "The computed best route quote"
 // @Router /router/custom-direct-quote [get]
 func (a *RouterHandler) GetDirectCustomQuote(c echo.Context) (err error) {
@@ -197,6 +258,12 @@ func (a *RouterHandler) GetDirectCustomQuote(c echo.Context) (err error) {
 		tokenIn, tokenOutDenom = req.TokenIn, req.TokenOutDenom
 	} else {
 		tokenIn, tokenOutDenom = req.TokenOut, req.TokenInDenom
+
+		// Convert the legacy order starting from the token out to the order starting from the token in.
+		if req.TokenOutFirst {
+			slices.Reverse(tokenOutDenom)
+			slices.Reverse(req.PoolID)
+		}
 	}
 
 	// Apply human denoms conversion if required.
@@ -222,7 +289,13 @@ func (a *RouterHandler) GetDirectCustomQuote(c echo.Context) (err error) {
 
 	scalingFactor := oneDec
 	if req.ApplyExponents {
-		scalingFactor = a.getSpotPriceScalingFactor(tokenIn.Denom, tokenOutDenom[len(tokenOutDenom)-1])
+		// The final denom of the exact amount out lists is the first one as they are ordered from the token in.
+		finalDenom := tokenOutDenom[len(tokenOutDenom)-1]
+		if req.SwapMethod() == domain.TokenSwapMethodExactOut {
+			finalDenom = tokenOutDenom[0]
+		}
+
+		scalingFactor = a.getSpotPriceScalingFactor(tokenIn.Denom, finalDenom)
 	}
 
 	_, _, err = quote.PrepareResult(ctx, scalingFactor, a.logger)
@@ -270,6 +343,82 @@ func (a *RouterHandler) GetCandidateRoutes(c echo.Context) error {
 	return nil
 }
 
+// @Summary Candidate Routes Dry Run
+// @Description Returns all routes that can be used for routing from tokenIn to tokenOutDenom without estimating quotes.
+// @Description Each route pool is enriched with its type, denoms, liquidity capitalization and whether it is a canonical orderbook.
+// @Description This is useful for inspecting routing before requesting quotes.
+// @ID get-router-routes-dry-run
+// @Produce  json
+// @Param  tokenIn  query  string  true  "The string representation of the denom of the token in"
+// @Param  tokenOutDenom  query  string  true  "The string representation of the denom of the token out"
+// @Param humanDenoms query bool true "Boolean flag indicating whether the given denoms are human readable or not. Human denoms get converted to chain internally"
+// @Success 200  {object}  CandidateRoutesResponse  "The candidate routes with the pool metadata"
+// @Router /router/routes/dry-run [get]
+func (a *RouterHandler) GetCandidateRoutesDryRun(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	tokenOutDenom, tokenIn, err := getValidTokenInTokenOutStr(c)
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.ResponseError{Message: err.Error()})
+	}
+
+	chainDenoms, err := mvc.ValidateChainDenomsQueryParam(c, a.TUsecase, []string{tokenIn, tokenOutDenom})
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.ResponseError{Message: err.Error()})
+	}
+
+	// Update the tokenIn and tokenOutDenom with the chain denoms if they were translated from human to chain.
+	tokenIn = chainDenoms[0]
+	tokenOutDenom = chainDenoms[1]
+
+	routes, err := a.RUsecase.GetCandidateRoutes(ctx, sdk.NewCoin(tokenIn, osmomath.OneInt()), tokenOutDenom)
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.ResponseError{Message: err.Error()})
+	}
+
+	response, err := a.convertCandidateRoutesToResponse(routes)
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// convertCandidateRoutesToResponse converts the given candidate routes to the response type,
+// enriching each pool with its metadata from the pools use case.
+// Returns error if any of the route pools cannot be retrieved.
+func (a *RouterHandler) convertCandidateRoutesToResponse(routes sqsdomain.CandidateRoutes) (CandidateRoutesResponse, error) {
+	routesResponse := make([]CandidateRouteResponse, 0, len(routes.Routes))
+	for _, route := range routes.Routes {
+		poolsResponse := make([]CandidatePoolResponse, 0, len(route.Pools))
+		for _, candidatePool := range route.Pools {
+			pool, err := a.PUsecase.GetPool(candidatePool.ID)
+			if err != nil {
+				return CandidateRoutesResponse{}, err
+			}
+
+			poolsResponse = append(poolsResponse, CandidatePoolResponse{
+				ID:                   candidatePool.ID,
+				Type:                 pool.GetType(),
+				Denoms:               pool.GetPoolDenoms(),
+				TokenOutDenom:        candidatePool.TokenOutDenom,
+				LiquidityCap:         pool.GetLiquidityCap(),
+				IsCanonicalOrderbook: a.PUsecase.IsCanonicalOrderbookPool(candidatePool.ID),
+			})
+		}
+
+		routesResponse = append(routesResponse, CandidateRouteResponse{
+			Pools:                     poolsResponse,
+			IsCanonicalOrderbookRoute: route.IsCanonicalOrderboolRoute,
+		})
+	}
+
+	return CandidateRoutesResponse{
+		Routes:                     routesResponse,
+		ContainsCanonicalOrderbook: routes.ContainsCanonicalOrderbook,
+	}, nil
+}
+
 func (a *RouterHandler) GetTakerFee(c echo.Context) error {
 	idStr := c.Param("id")
 	poolID, err := strconv.ParseUint(idStr, 10, 64)
@@ -285,6 +434,41 @@ func (a *RouterHandler) GetTakerFee(c echo.Context) error {
 	return c.JSON(http.StatusOK, takerFees)
 }
 
+// @Summary Taker fee for a denom pair
+// @Description Returns the taker fee for the given denom pair without requiring a pool ID.
+// @Description This is useful for previewing fees before a pool is selected.
+// @ID get-taker-fee-pair
+// @Produce  json
+// @Param  denom0  query  string  true  "The first denom of the pair."   example(uosmo)
+// @Param  denom1  query  string  true  "The second denom of the pair."  example(uion)
+// @Success 200  {object}  sqsdomain.TakerFeeForPair  "The taker fee for the denom pair"
+// @Router /router/taker-fee-pair [get]
+func (a *RouterHandler) GetTakerFeeForPair(c echo.Context) error {
+	denom0 := c.QueryParam("denom0")
+	if len(denom0) == 0 {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: "denom0 is required"})
+	}
+	denom1 := c.QueryParam("denom1")
+	if len(denom1) == 0 {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: "denom1 is required"})
+	}
+
+	takerFee, found := a.RUsecase.GetTakerFeeForPair(denom0, denom1)
+	if !found {
+		err := domain.TakerFeeNotFoundForDenomPairError{
+			Denom0: denom0,
+			Denom1: denom1,
+		}
+		return c.JSON(http.StatusNotFound, domain.ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, sqsdomain.TakerFeeForPair{
+		Denom0:   denom0,
+		Denom1:   denom1,
+		TakerFee: takerFee,
+	})
+}
+
 // GetCandidateRoutes returns the candidate routes for a given tokenIn and tokenOutDenom from cache.
 // If no routes present in cache, it does not attempt to recompute them.
 func (a *RouterHandler) GetCachedCandidateRoutes(c echo.Context) error {
@@ -321,6 +505,58 @@ func (a *RouterHandler) GetRouterState(c echo.Context) error {
 	return c.JSON(http.StatusOK, routerState)
 }
 
+// @Summary Router state summary
+// @Description Returns a summary of the current router state for live inspection without writing any files.
+// @Description The tick models of the concentrated pools are omitted unless `includeTickMap` is set to true.
+// @ID get-router-state-summary
+// @Produce  json
+// @Param  includeTickMap  query  bool  false  "Boolean flag indicating whether to include the tick models of the concentrated pools. False by default."
+// @Success 200  {object}  RouterStateSummaryResponse  "The router state summary"
+// @Router /router/debug/state [get]
+func (a *RouterHandler) GetRouterStateSummary(c echo.Context) error {
+	includeTickMap, err := domain.ParseBooleanQueryParam(c, "includeTickMap")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+	}
+
+	routerState, err := a.RUsecase.GetRouterStateSnapshot(includeTickMap)
+	if err != nil {
+		return c.JSON(domain.GetStatusCode(err), domain.ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, convertRouterStateToSummaryResponse(routerState))
+}
+
+// @Summary CosmWasm circuit breaker statuses
+// @Description Returns the circuit breaker statuses of the generalized CosmWasm pools with at least one
+// @Description failed network call since the last success. Pools with an open circuit are excluded from routing.
+// @ID get-cosmwasm-circuit-breaker-statuses
+// @Produce  json
+// @Success 200  {array}  domain.CosmWasmCircuitBreakerPoolStatus  "The circuit breaker statuses sorted by pool ID"
+// @Router /router/debug/cosmwasm-circuit-breaker [get]
+func (a *RouterHandler) GetCosmWasmCircuitBreakerStatuses(c echo.Context) error {
+	return c.JSON(http.StatusOK, a.PUsecase.GetCosmWasmCircuitBreakerStatuses())
+}
+
+// convertRouterStateToSummaryResponse converts the given router state to the summary response.
+// The tick map is only included if present in the router state.
+func convertRouterStateToSummaryResponse(routerState domain.RouterState) RouterStateSummaryResponse {
+	candidateRouteSearchDataSummary := CandidateRouteSearchDataSummary{
+		DenomsCount: len(routerState.CandidateRouteSearchData),
+	}
+	for _, denomData := range routerState.CandidateRouteSearchData {
+		candidateRouteSearchDataSummary.SortedPoolsCount += len(denomData.SortedPools)
+		candidateRouteSearchDataSummary.CanonicalOrderbooksCount += len(denomData.CanonicalOrderbooks)
+	}
+
+	return RouterStateSummaryResponse{
+		PoolsCount:               len(routerState.Pools),
+		TakerFeesCount:           len(routerState.TakerFees),
+		CandidateRouteSearchData: candidateRouteSearchDataSummary,
+		TickMap:                  routerState.TickMap,
+	}
+}
+
 // GetSpotPrice returns the spot price for a given poolID, quoteAsset and baseAsset
 func (a *RouterHandler) GetSpotPriceForPool(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -2,6 +2,7 @@ package http_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,10 +10,13 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/labstack/echo/v4"
+	"github.com/osmosis-labs/osmosis/osmomath"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v26/x/poolmanager/types"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mocks"
 	routerdelivery "github.com/osmosis-labs/sqs/router/delivery/http"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
+	"github.com/osmosis-labs/sqs/sqsdomain"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -58,6 +62,9 @@ func (s *RouterHandlerSuite) TestGetOptimalQuote() {
 					IsValidChainDenomFunc: func(chainDenom string) bool {
 						return true
 					},
+					GetSpotPriceScalingFactorByDenomFunc: func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+						return osmomath.OneDec(), nil
+					},
 				},
 				RUsecase: &mocks.RouterUsecaseMock{
 					GetOptimalQuoteFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
@@ -81,6 +88,9 @@ func (s *RouterHandlerSuite) TestGetOptimalQuote() {
 					IsValidChainDenomFunc: func(chainDenom string) bool {
 						return true
 					},
+					GetSpotPriceScalingFactorByDenomFunc: func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+						return osmomath.OneDec(), nil
+					},
 				},
 				RUsecase: &mocks.RouterUsecaseMock{
 					GetOptimalQuoteInGivenOutFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
@@ -161,6 +171,482 @@ func (s *RouterHandlerSuite) TestGetOptimalQuote() {
 	}
 }
 
+// Tests that the quote routes are clamped according to the configured maximum
+// response routes and pools with the truncation flag set accordingly.
+// The amounts of the quote are computed over all routes prior to truncation.
+func (s *RouterHandlerSuite) TestGetOptimalQuote_TruncatedResponse() {
+	// Prepare 3 pools, we create once and reuse them in the test cases
+	_, poolOne := s.PoolOne()
+	_, poolTwo := s.PoolTwo()
+	_, poolThree := s.PoolThree()
+
+	testcases := []struct {
+		name              string
+		routerConfig      domain.RouterConfig
+		expectedNumRoutes int
+		expectedTruncated bool
+	}{
+		{
+			name:              "no clamp",
+			routerConfig:      domain.RouterConfig{},
+			expectedNumRoutes: 2,
+			expectedTruncated: false,
+		},
+		{
+			name:              "clamp above number of routes and pools",
+			routerConfig:      domain.RouterConfig{MaxResponseRoutes: 2, MaxResponsePools: 3},
+			expectedNumRoutes: 2,
+			expectedTruncated: false,
+		},
+		{
+			name:              "max response routes truncates",
+			routerConfig:      domain.RouterConfig{MaxResponseRoutes: 1},
+			expectedNumRoutes: 1,
+			expectedTruncated: true,
+		},
+		{
+			name:              "max response pools truncates",
+			routerConfig:      domain.RouterConfig{MaxResponsePools: 2},
+			expectedNumRoutes: 1,
+			expectedTruncated: true,
+		},
+	}
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/", nil)
+			q := req.URL.Query()
+			q.Add("tokenIn", "1000ibc/EA1D43981D5C9A1C4AAEA9C23BB1D4FA126BA9BC7020A25E0AE4AA841EA25DC5")
+			q.Add("tokenOutDenom", "ibc/498A0751C798A0D9A389AA3691123DADA57DAA4FE165D5C75894505B876BA6E4")
+			req.URL.RawQuery = q.Encode()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := &routerdelivery.RouterHandler{
+				TUsecase: &mocks.TokensUsecaseMock{
+					IsValidChainDenomFunc: func(chainDenom string) bool {
+						return true
+					},
+				},
+				RUsecase: &mocks.RouterUsecaseMock{
+					GetOptimalQuoteFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+						// 2 routes with 2 and 1 pools respectively
+						return s.NewExactAmountInQuote(poolOne, poolTwo, poolThree), nil
+					},
+					GetConfigFunc: func() domain.RouterConfig {
+						return tc.routerConfig
+					},
+				},
+			}
+
+			// System under test
+			err := handler.GetOptimalQuote(c)
+			s.Require().NoError(err)
+			s.Require().Equal(http.StatusOK, rec.Code)
+
+			var response struct {
+				AmountIn struct {
+					Amount string `json:"amount"`
+				} `json:"amount_in"`
+				AmountOut string            `json:"amount_out"`
+				Route     []json.RawMessage `json:"route"`
+				Truncated bool              `json:"truncated"`
+			}
+			s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &response))
+
+			s.Require().Len(response.Route, tc.expectedNumRoutes)
+			s.Require().Equal(tc.expectedTruncated, response.Truncated)
+
+			// The totals are not affected by the truncation.
+			s.Require().Equal("10000000", response.AmountIn.Amount)
+			s.Require().Equal("40000000", response.AmountOut)
+		})
+	}
+}
+
+func (s *RouterHandlerSuite) TestGetCandidateRoutesDryRun() {
+	const (
+		balancerPoolID  uint64 = 1
+		orderbookPoolID uint64 = 2
+		missingPoolID   uint64 = 3
+	)
+
+	var (
+		pools = map[uint64]sqsdomain.PoolI{
+			balancerPoolID: &mocks.MockRoutablePool{
+				ID:               balancerPoolID,
+				PoolType:         poolmanagertypes.Balancer,
+				Denoms:           []string{UOSMO, USDC},
+				PoolLiquidityCap: osmomath.NewInt(1000),
+			},
+			orderbookPoolID: &mocks.MockRoutablePool{
+				ID:               orderbookPoolID,
+				PoolType:         poolmanagertypes.CosmWasm,
+				Denoms:           []string{UOSMO, USDC},
+				PoolLiquidityCap: osmomath.NewInt(500),
+			},
+		}
+
+		poolsUsecase = &mocks.PoolsUsecaseMock{
+			GetPoolFunc: func(poolID uint64) (sqsdomain.PoolI, error) {
+				pool, ok := pools[poolID]
+				if !ok {
+					return nil, domain.PoolNotFoundError{PoolID: poolID}
+				}
+				return pool, nil
+			},
+			IsCanonicalOrderbookPoolFunc: func(poolID uint64) bool {
+				return poolID == orderbookPoolID
+			},
+		}
+
+		tokensUsecase = &mocks.TokensUsecaseMock{
+			IsValidChainDenomFunc: func(chainDenom string) bool {
+				return true
+			},
+		}
+
+		defaultQueryParams = map[string]string{
+			"tokenIn":       UOSMO,
+			"tokenOutDenom": USDC,
+			"humanDenoms":   "false",
+		}
+	)
+
+	testcases := []struct {
+		name               string
+		queryParams        map[string]string
+		candidateRoutes    sqsdomain.CandidateRoutes
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:        "valid request with canonical orderbook route",
+			queryParams: defaultQueryParams,
+			candidateRoutes: sqsdomain.CandidateRoutes{
+				Routes: []sqsdomain.CandidateRoute{
+					{
+						Pools: []sqsdomain.CandidatePool{
+							{ID: balancerPoolID, TokenOutDenom: USDC},
+						},
+					},
+					{
+						Pools: []sqsdomain.CandidatePool{
+							{ID: orderbookPoolID, TokenOutDenom: USDC},
+						},
+						IsCanonicalOrderboolRoute: true,
+					},
+				},
+				ContainsCanonicalOrderbook: true,
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"routes": [
+					{
+						"pools": [
+							{
+								"id": 1,
+								"type": 0,
+								"denoms": ["` + UOSMO + `", "` + USDC + `"],
+								"token_out_denom": "` + USDC + `",
+								"liquidity_cap": "1000",
+								"is_canonical_orderbook": false
+							}
+						],
+						"is_canonical_orderbook_route": false
+					},
+					{
+						"pools": [
+							{
+								"id": 2,
+								"type": 3,
+								"denoms": ["` + UOSMO + `", "` + USDC + `"],
+								"token_out_denom": "` + USDC + `",
+								"liquidity_cap": "500",
+								"is_canonical_orderbook": true
+							}
+						],
+						"is_canonical_orderbook_route": true
+					}
+				],
+				"contains_canonical_orderbook": true
+			}`,
+		},
+		{
+			name:               "valid request with no routes",
+			queryParams:        defaultQueryParams,
+			candidateRoutes:    sqsdomain.CandidateRoutes{},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"routes": [], "contains_canonical_orderbook": false}`,
+		},
+		{
+			name:        "route pool not found",
+			queryParams: defaultQueryParams,
+			candidateRoutes: sqsdomain.CandidateRoutes{
+				Routes: []sqsdomain.CandidateRoute{
+					{
+						Pools: []sqsdomain.CandidatePool{
+							{ID: missingPoolID, TokenOutDenom: USDC},
+						},
+					},
+				},
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"message": "pool with ID (3) is not found"}`,
+		},
+		{
+			name: "missing tokenIn",
+			queryParams: map[string]string{
+				"tokenOutDenom": USDC,
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"message": "tokenIn is required"}`,
+		},
+	}
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			q := req.URL.Query()
+			for k, v := range tc.queryParams {
+				q.Add(k, v)
+			}
+			req.URL.RawQuery = q.Encode()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := &routerdelivery.RouterHandler{
+				TUsecase: tokensUsecase,
+				PUsecase: poolsUsecase,
+				RUsecase: &mocks.RouterUsecaseMock{
+					GetCandidateRoutesFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string) (sqsdomain.CandidateRoutes, error) {
+						return tc.candidateRoutes, nil
+					},
+				},
+			}
+
+			// System under test
+			err := handler.GetCandidateRoutesDryRun(c)
+
+			// Note: in case of error, we expect err to be nil but the status code to be non-200
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedStatusCode, rec.Code)
+			s.Require().JSONEq(tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func (s *RouterHandlerSuite) TestGetRouterStateSummary() {
+	const concentratedPoolID uint64 = 2
+
+	var (
+		balancerPool = &mocks.MockRoutablePool{
+			ID:       1,
+			PoolType: poolmanagertypes.Balancer,
+			Denoms:   []string{UOSMO, USDC},
+		}
+		concentratedPool = &mocks.MockRoutablePool{
+			ID:       concentratedPoolID,
+			PoolType: poolmanagertypes.Concentrated,
+			Denoms:   []string{UOSMO, UATOM},
+		}
+
+		routerState = domain.RouterState{
+			Pools: []sqsdomain.PoolI{balancerPool, concentratedPool},
+			TakerFees: sqsdomain.TakerFeeMap{
+				{Denom0: UOSMO, Denom1: USDC}: osmomath.MustNewDecFromStr("0.001"),
+			},
+			TickMap: map[uint64]*sqsdomain.TickModel{
+				concentratedPoolID: {
+					CurrentTickIndex: 1,
+				},
+			},
+			CandidateRouteSearchData: map[string]domain.CandidateRouteDenomData{
+				UOSMO: {
+					SortedPools: []sqsdomain.PoolI{balancerPool, concentratedPool},
+					CanonicalOrderbooks: map[string]sqsdomain.PoolI{
+						USDC: balancerPool,
+					},
+				},
+				USDC: {
+					SortedPools: []sqsdomain.PoolI{balancerPool},
+				},
+				UATOM: {
+					SortedPools: []sqsdomain.PoolI{concentratedPool},
+				},
+			},
+		}
+
+		expectedSummary = `
+			"pools_count": 2,
+			"taker_fees_count": 1,
+			"candidate_route_search_data": {
+				"denoms_count": 3,
+				"sorted_pools_count": 4,
+				"canonical_orderbooks_count": 1
+			}`
+	)
+
+	testcases := []struct {
+		name               string
+		queryParams        map[string]string
+		getRouterStateErr  error
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:               "tick map excluded by default",
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{` + expectedSummary + `}`,
+		},
+		{
+			name: "tick map included",
+			queryParams: map[string]string{
+				"includeTickMap": "true",
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{` + expectedSummary + `,
+				"tick_map": {
+					"2": {
+						"current_tick_index": 1
+					}
+				}
+			}`,
+		},
+		{
+			name: "invalid includeTickMap",
+			queryParams: map[string]string{
+				"includeTickMap": "invalid",
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "strconv.ParseBool: parsing \"invalid\": invalid syntax"}`,
+		},
+		{
+			name:               "router state error",
+			getRouterStateErr:  domain.PoolNotFoundError{PoolID: concentratedPoolID},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"message": "pool with ID (2) is not found"}`,
+		},
+	}
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/", nil)
+			q := req.URL.Query()
+			for k, v := range tc.queryParams {
+				q.Add(k, v)
+			}
+			req.URL.RawQuery = q.Encode()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := &routerdelivery.RouterHandler{
+				RUsecase: &mocks.RouterUsecaseMock{
+					GetRouterStateSnapshotFunc: func(includeTickMap bool) (domain.RouterState, error) {
+						if tc.getRouterStateErr != nil {
+							return domain.RouterState{}, tc.getRouterStateErr
+						}
+
+						if !includeTickMap {
+							routerStateWithoutTickMap := routerState
+							routerStateWithoutTickMap.TickMap = nil
+							return routerStateWithoutTickMap, nil
+						}
+						return routerState, nil
+					},
+				},
+			}
+
+			// System under test
+			err := handler.GetRouterStateSummary(c)
+
+			// Note: in case of error, we expect err to be nil but the status code to be non-200
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedStatusCode, rec.Code)
+			s.Require().JSONEq(tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func (s *RouterHandlerSuite) TestGetTakerFeeForPair() {
+	takerFee := osmomath.MustNewDecFromStr("0.001")
+
+	testcases := []struct {
+		name               string
+		queryParams        map[string]string
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "present pair",
+			queryParams: map[string]string{
+				"denom0": UOSMO,
+				"denom1": USDC,
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"Denom0": "` + UOSMO + `", "Denom1": "` + USDC + `", "TakerFee": "0.001000000000000000"}`,
+		},
+		{
+			name: "absent pair",
+			queryParams: map[string]string{
+				"denom0": UOSMO,
+				"denom1": UATOM,
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"message": "taker fee not found for denom pair (` + UOSMO + `, ` + UATOM + `)"}`,
+		},
+		{
+			name: "missing denom0",
+			queryParams: map[string]string{
+				"denom1": USDC,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "denom0 is required"}`,
+		},
+		{
+			name: "missing denom1",
+			queryParams: map[string]string{
+				"denom0": UOSMO,
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "denom1 is required"}`,
+		},
+	}
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/", nil)
+			q := req.URL.Query()
+			for k, v := range tc.queryParams {
+				q.Add(k, v)
+			}
+			req.URL.RawQuery = q.Encode()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := &routerdelivery.RouterHandler{
+				RUsecase: &mocks.RouterUsecaseMock{
+					GetTakerFeeForPairFunc: func(denom0, denom1 string) (osmomath.Dec, bool) {
+						if denom0 == UOSMO && denom1 == USDC {
+							return takerFee, true
+						}
+						return osmomath.Dec{}, false
+					},
+				},
+			}
+
+			// System under test
+			err := handler.GetTakerFeeForPair(c)
+
+			// Note: in case of error, we expect err to be nil but the status code to be non-200
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedStatusCode, rec.Code)
+			s.Require().JSONEq(tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
 func (s *RouterHandlerSuite) TestGetDirectCustomQuote() {
 	// Prepare 3 pools, we create once and reuse them in the test cases
 	// It's done to avoid creating them multiple times and increasing pool IDs counter.
@@ -186,6 +672,9 @@ func (s *RouterHandlerSuite) TestGetDirectCustomQuote() {
 			},
 			handler: &routerdelivery.RouterHandler{
 				TUsecase: &mocks.TokensUsecaseMock{
+					GetSpotPriceScalingFactorByDenomFunc: func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+						return osmomath.OneDec(), nil
+					},
 					IsValidChainDenomFunc: func(chainDenom string) bool {
 						return true
 					},
@@ -207,6 +696,31 @@ func (s *RouterHandlerSuite) TestGetDirectCustomQuote() {
 				"poolID":         "10",
 				"applyExponents": "true",
 			},
+			handler: &routerdelivery.RouterHandler{
+				TUsecase: &mocks.TokensUsecaseMock{
+					GetSpotPriceScalingFactorByDenomFunc: func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+						return osmomath.OneDec(), nil
+					},
+					IsValidChainDenomFunc: func(chainDenom string) bool {
+						return true
+					},
+				},
+				RUsecase: &mocks.RouterUsecaseMock{
+					GetCustomDirectQuoteMultiPoolInGivenOutFunc: func(ctx context.Context, tokenOut sdk.Coin, tokenInDenom []string, poolIDs []uint64) (domain.Quote, error) {
+						return s.NewExactAmountOutQuote(poolOne, poolTwo, poolThree), nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   s.MustReadFile("../../usecase/routertesting/parsing/quote_amount_out_response.json"),
+		},
+		{
+			name: "valid multi-hop exact out request: lists ordered from the token in",
+			queryParams: map[string]string{
+				"tokenOut":     "1000uion",
+				"tokenInDenom": "uosmo,uatom",
+				"poolID":       "1,2",
+			},
 			handler: &routerdelivery.RouterHandler{
 				TUsecase: &mocks.TokensUsecaseMock{
 					IsValidChainDenomFunc: func(chainDenom string) bool {
@@ -215,6 +729,33 @@ func (s *RouterHandlerSuite) TestGetDirectCustomQuote() {
 				},
 				RUsecase: &mocks.RouterUsecaseMock{
 					GetCustomDirectQuoteMultiPoolInGivenOutFunc: func(ctx context.Context, tokenOut sdk.Coin, tokenInDenom []string, poolIDs []uint64) (domain.Quote, error) {
+						s.Require().Equal([]string{"uosmo", "uatom"}, tokenInDenom)
+						s.Require().Equal([]uint64{1, 2}, poolIDs)
+						return s.NewExactAmountOutQuote(poolOne, poolTwo, poolThree), nil
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   s.MustReadFile("../../usecase/routertesting/parsing/quote_amount_out_response.json"),
+		},
+		{
+			name: "valid multi-hop exact out request: legacy lists ordered from the token out",
+			queryParams: map[string]string{
+				"tokenOut":      "1000uion",
+				"tokenInDenom":  "uatom,uosmo",
+				"poolID":        "2,1",
+				"tokenOutFirst": "true",
+			},
+			handler: &routerdelivery.RouterHandler{
+				TUsecase: &mocks.TokensUsecaseMock{
+					IsValidChainDenomFunc: func(chainDenom string) bool {
+						return true
+					},
+				},
+				RUsecase: &mocks.RouterUsecaseMock{
+					GetCustomDirectQuoteMultiPoolInGivenOutFunc: func(ctx context.Context, tokenOut sdk.Coin, tokenInDenom []string, poolIDs []uint64) (domain.Quote, error) {
+						s.Require().Equal([]string{"uosmo", "uatom"}, tokenInDenom)
+						s.Require().Equal([]uint64{1, 2}, poolIDs)
 						return s.NewExactAmountOutQuote(poolOne, poolTwo, poolThree), nil
 					},
 				},
@@ -233,6 +774,9 @@ func (s *RouterHandlerSuite) TestGetDirectCustomQuote() {
 			},
 			handler: &routerdelivery.RouterHandler{
 				TUsecase: &mocks.TokensUsecaseMock{
+					GetSpotPriceScalingFactorByDenomFunc: func(baseDenom, quoteDenom string) (osmomath.Dec, error) {
+						return osmomath.OneDec(), nil
+					},
 					IsValidChainDenomFunc: func(chainDenom string) bool {
 						// because we are applying human denoms
 						// test will fail with humanDenoms set to false
@@ -19,6 +19,7 @@ type GetDirectCustomQuoteRequest struct {
 	TokenInDenom   []string
 	PoolID         []uint64 // list of the pool ID
 	ApplyExponents bool     // Boolean flag indicating whether to apply exponents to the spot price. False by default.
+	TokenOutFirst  bool     // Boolean flag indicating whether the exact amount out lists are in the legacy order starting from the token out. False by default.
 }
 
 // UnmarshalHTTPRequest unmarshals the HTTP request to GetDirectCustomQuoteRequest.
@@ -30,6 +31,11 @@ func (r *GetDirectCustomQuoteRequest) UnmarshalHTTPRequest(c echo.Context) error
 		return err
 	}
 
+	r.TokenOutFirst, err = domain.ParseBooleanQueryParam(c, "tokenOutFirst")
+	if err != nil {
+		return err
+	}
+
 	if tokenIn := c.QueryParam("tokenIn"); tokenIn != "" {
 		tokenInCoin, err := sdk.ParseCoinNormalized(tokenIn)
 		if err != nil {
@@ -41,6 +41,22 @@ func TestGetDirectCustomQuoteRequestUnmarshal(t *testing.T) {
 				ApplyExponents: true,
 			},
 		},
+		{
+			name: "valid exact out request with legacy tokenOutFirst order",
+			queryParams: map[string]string{
+				"tokenOut":      "1000usdc",
+				"tokenInDenom":  "atom,uosmo",
+				"poolID":        "1,23",
+				"tokenOutFirst": "true",
+			},
+			expectedResult: &types.GetDirectCustomQuoteRequest{
+				TokenOut:      &sdk.Coin{Denom: "usdc", Amount: osmomath.NewInt(1000)},
+				TokenInDenom:  []string{"atom", "uosmo"},
+				TokenOutDenom: []string{""},
+				PoolID:        []uint64{1, 23},
+				TokenOutFirst: true,
+			},
+		},
 		{
 			name: "invalid poolID param",
 			queryParams: map[string]string{
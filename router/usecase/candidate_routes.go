@@ -36,6 +36,20 @@ func NewCandidateRouteFinder(candidateRouteDataHolder mvc.CandidateRouteSearchDa
 	}
 }
 
+// NewCandidateRouteSearcher returns the candidate route searcher implementing the given algorithm.
+// The empty algorithm defaults to domain.CandidateRouteSearchAlgorithmBFS.
+// Returns error if the algorithm is not supported.
+func NewCandidateRouteSearcher(algorithm domain.CandidateRouteSearchAlgorithm, candidateRouteDataHolder mvc.CandidateRouteSearchDataHolder, logger log.Logger) (domain.CandidateRouteSearcher, error) {
+	switch algorithm {
+	case "", domain.CandidateRouteSearchAlgorithmBFS:
+		return NewCandidateRouteFinder(candidateRouteDataHolder, logger), nil
+	case domain.CandidateRouteSearchAlgorithmGreedy:
+		return NewGreedyCandidateRouteFinder(candidateRouteDataHolder, logger), nil
+	default:
+		return nil, UnsupportedCandidateRouteSearchAlgorithmError{Algorithm: algorithm}
+	}
+}
+
 // FindCandidateRoutes implements domain.CandidateRouteFinder.
 func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDenom string, options domain.CandidateRouteSearchOptions) (sqsdomain.CandidateRoutes, error) {
 	routes := make([]candidateRouteWrapper, 0, options.MaxRoutes)
@@ -45,6 +59,9 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 	visited := make(map[uint64]struct{}, 100)
 	// visited := make([]bool, len(pools))
 
+	// filtered are the pools skipped by the pool filters or the min liquidity cap.
+	filtered := make(map[uint64]struct{}, 100)
+
 	// Preallocate constant queue size to avoid dynamic reallocations.
 	// TODO: choose the best size for the queue.
 	queue := make([][]candidatePoolWrapper, 0, 100)
@@ -55,38 +72,12 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 		return sqsdomain.CandidateRoutes{}, err
 	}
 
-	if len(denomData.CanonicalOrderbooks) > 0 {
-		canonicalOrderbook, ok := denomData.CanonicalOrderbooks[tokenOutDenom]
-		if ok {
-			shouldSkipCanonicalOrderbook := false
-			// Filter the canonical orderbook pool using the pool filters.
-			for _, filter := range options.PoolFiltersAnyOf {
-				// nolint: forcetypeassert
-				canonicalOrderbookPoolWrapper := (canonicalOrderbook).(*sqsdomain.PoolWrapper)
-				if filter(canonicalOrderbookPoolWrapper) {
-					shouldSkipCanonicalOrderbook = true
-					break
-				}
-			}
-
-			if !shouldSkipCanonicalOrderbook {
-				// Add the canonical orderbook as a route.
-				routes = append(routes, candidateRouteWrapper{
-					IsCanonicalOrderboolRoute: true,
-					Pools: []candidatePoolWrapper{
-						{
-							CandidatePool: sqsdomain.CandidatePool{
-								ID:            canonicalOrderbook.GetId(),
-								TokenOutDenom: tokenOutDenom,
-							},
-							PoolDenoms: canonicalOrderbook.GetSQSPoolModel().PoolDenoms,
-						},
-					},
-				})
-			}
-
-			visited[canonicalOrderbook.GetId()] = struct{}{}
+	if canonicalOrderbookID, route, ok := canonicalOrderbookCandidateRoute(denomData, tokenOutDenom, options); ok {
+		if route != nil {
+			routes = append(routes, *route)
 		}
+
+		visited[canonicalOrderbookID] = struct{}{}
 	}
 
 	for len(queue) > 0 && len(routes) < options.MaxRoutes {
@@ -113,48 +104,45 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 			c.logger.Debug("no pools found for denom in candidate route search", zap.String("denom", currenTokenInDenom))
 		}
 
+		// consideredPoolsCount is the number of pools that passed the filters.
+		consideredPoolsCount := 0
+
 		for i := 0; i < len(rankedPools) && len(routes) < options.MaxRoutes; i++ {
 			// Unsafe cast for performance reasons.
 			// nolint: forcetypeassert
 			pool := (rankedPools[i]).(*sqsdomain.PoolWrapper)
 			poolID := pool.ChainModel.GetId()
 
-			if _, ok := visited[poolID]; ok {
+			if _, ok := filtered[poolID]; ok {
 				continue
 			}
 
 			// If the option is configured to skip a given pool
-			// We mark it as visited and continue.
+			// We mark it as filtered and continue.
 			if options.ShouldSkipPool(pool) {
-				visited[poolID] = struct{}{}
+				filtered[poolID] = struct{}{}
 				continue
 			}
 
 			if pool.GetLiquidityCap().Uint64() < options.MinPoolLiquidityCap {
-				visited[poolID] = struct{}{}
+				filtered[poolID] = struct{}{}
 				// Skip pools that have less liquidity than the minimum required.
 				continue
 			}
 
-			poolDenoms := pool.SQSModel.PoolDenoms
-			hasTokenIn := false
-			hasTokenOut := false
-			shouldSkipPool := false
-			for _, denom := range poolDenoms {
-				if denom == currenTokenInDenom {
-					hasTokenIn = true
-				}
-				if denom == tokenOutDenom {
-					hasTokenOut = true
-				}
+			// Only consider the highest ranked pools that passed the filters if configured.
+			if options.MaxPoolsConsidered > 0 && consideredPoolsCount >= options.MaxPoolsConsidered {
+				break
+			}
+			consideredPoolsCount++
 
-				// Avoid going through pools that has the initial token in denom twice.
-				if len(currentRoute) > 0 && denom == tokenIn.Denom {
-					shouldSkipPool = true
-					break
-				}
+			if _, ok := visited[poolID]; ok {
+				continue
 			}
 
+			poolDenoms := pool.SQSModel.PoolDenoms
+			hasTokenIn, hasTokenOut, shouldSkipPool := inspectPoolDenoms(poolDenoms, currenTokenInDenom, tokenIn.Denom, tokenOutDenom, len(currentRoute) == 0)
+
 			if shouldSkipPool {
 				continue
 			}
@@ -164,20 +152,10 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 			}
 
 			// Microptimization for the first pool in the route.
-			if len(currentRoute) == 0 {
-				currentTokenInAmount := pool.SQSModel.Balances.AmountOf(currenTokenInDenom)
-
-				// HACK: alloyed LP share is not contained in balances.
-				// TODO: remove the hack and ingest the LP share balance on the Osmosis side.
-				// https://linear.app/osmosis/issue/DATA-236/bug-alloyed-lp-share-is-not-present-in-balances
-				cosmwasmModel := pool.SQSModel.CosmWasmPoolModel
-				isAlloyed := cosmwasmModel != nil && cosmwasmModel.IsAlloyTransmuter()
-
-				if currentTokenInAmount.LT(tokenIn.Amount) && !isAlloyed {
-					visited[poolID] = struct{}{}
-					// Not enough tokenIn to swap.
-					continue
-				}
+			if len(currentRoute) == 0 && !hasEnoughTokenIn(pool, tokenIn) {
+				visited[poolID] = struct{}{}
+				// Not enough tokenIn to swap.
+				continue
 			}
 
 			currentPoolID := poolID
@@ -189,6 +167,11 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 					continue
 				}
 
+				// Avoid cycles by never revisiting a denom within the route.
+				if routeContainsTokenOutDenom(currentRoute, denom) {
+					continue
+				}
+
 				denomData, err := c.candidateRouteDataHolder.GetDenomData(currenTokenInDenom)
 				if err != nil {
 					return sqsdomain.CandidateRoutes{}, err
@@ -236,6 +219,85 @@ func (c candidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDeno
 	return validateAndFilterRoutes(routes, tokenIn.Denom, c.logger)
 }
 
+// inspectPoolDenoms returns whether the given pool denoms contain the current token in denom
+// and the token out denom of the route.
+// shouldSkipPool is true if the pool is not the first in the route and contains the initial token in denom
+// so that the route does not go through the initial token in denom twice.
+func inspectPoolDenoms(poolDenoms []string, currentTokenInDenom, tokenInDenom, tokenOutDenom string, isFirstPool bool) (hasTokenIn, hasTokenOut, shouldSkipPool bool) {
+	for _, denom := range poolDenoms {
+		if denom == currentTokenInDenom {
+			hasTokenIn = true
+		}
+		if denom == tokenOutDenom {
+			hasTokenOut = true
+		}
+
+		// Avoid going through pools that has the initial token in denom twice.
+		if !isFirstPool && denom == tokenInDenom {
+			return hasTokenIn, hasTokenOut, true
+		}
+	}
+
+	return hasTokenIn, hasTokenOut, false
+}
+
+// hasEnoughTokenIn returns true if the given pool, being the first in the route,
+// has enough balance of the token in denom to swap the token in.
+func hasEnoughTokenIn(pool *sqsdomain.PoolWrapper, tokenIn sdk.Coin) bool {
+	currentTokenInAmount := pool.SQSModel.Balances.AmountOf(tokenIn.Denom)
+
+	// HACK: alloyed LP share is not contained in balances.
+	// TODO: remove the hack and ingest the LP share balance on the Osmosis side.
+	// https://linear.app/osmosis/issue/DATA-236/bug-alloyed-lp-share-is-not-present-in-balances
+	cosmwasmModel := pool.SQSModel.CosmWasmPoolModel
+	isAlloyed := cosmwasmModel != nil && cosmwasmModel.IsAlloyTransmuter()
+
+	return !currentTokenInAmount.LT(tokenIn.Amount) || isAlloyed
+}
+
+// canonicalOrderbookCandidateRoute returns the ID of the canonical orderbook between the denom of the given
+// denom data and the token out denom along with true if such an orderbook exists. Returns false otherwise.
+// The returned route through the canonical orderbook is nil if the orderbook is skipped by the pool filters.
+func canonicalOrderbookCandidateRoute(denomData domain.CandidateRouteDenomData, tokenOutDenom string, options domain.CandidateRouteSearchOptions) (uint64, *candidateRouteWrapper, bool) {
+	canonicalOrderbook, ok := denomData.CanonicalOrderbooks[tokenOutDenom]
+	if !ok {
+		return 0, nil, false
+	}
+
+	// Filter the canonical orderbook pool using the pool filters.
+	for _, filter := range options.PoolFiltersAnyOf {
+		// nolint: forcetypeassert
+		canonicalOrderbookPoolWrapper := (canonicalOrderbook).(*sqsdomain.PoolWrapper)
+		if filter(canonicalOrderbookPoolWrapper) {
+			return canonicalOrderbook.GetId(), nil, true
+		}
+	}
+
+	// Add the canonical orderbook as a route.
+	return canonicalOrderbook.GetId(), &candidateRouteWrapper{
+		IsCanonicalOrderboolRoute: true,
+		Pools: []candidatePoolWrapper{
+			{
+				CandidatePool: sqsdomain.CandidatePool{
+					ID:            canonicalOrderbook.GetId(),
+					TokenOutDenom: tokenOutDenom,
+				},
+				PoolDenoms: canonicalOrderbook.GetSQSPoolModel().PoolDenoms,
+			},
+		},
+	}, true
+}
+
+// routeContainsTokenOutDenom returns true if any pool in the route has the given token out denom.
+func routeContainsTokenOutDenom(route []candidatePoolWrapper, denom string) bool {
+	for _, pool := range route {
+		if pool.TokenOutDenom == denom {
+			return true
+		}
+	}
+	return false
+}
+
 // Pool represents a pool in the decentralized exchange.
 type Pool struct {
 	ID       int
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
+	"go.uber.org/zap"
+)
+
+// greedyCandidateRouteFinder finds candidate routes by searching depth-first
+// through the highest liquidity pools first.
+type greedyCandidateRouteFinder struct {
+	candidateRouteDataHolder mvc.CandidateRouteSearchDataHolder
+	logger                   log.Logger
+}
+
+var _ domain.CandidateRouteSearcher = greedyCandidateRouteFinder{}
+
+func NewGreedyCandidateRouteFinder(candidateRouteDataHolder mvc.CandidateRouteSearchDataHolder, logger log.Logger) greedyCandidateRouteFinder {
+	return greedyCandidateRouteFinder{
+		candidateRouteDataHolder: candidateRouteDataHolder,
+		logger:                   logger,
+	}
+}
+
+// FindCandidateRoutes implements domain.CandidateRouteSearcher.
+// At every denom starting from the token in, it first completes the current route with the highest ranked
+// pools containing the token out denom. Then, it extends the current route through the highest ranked
+// pools, backtracking once the pools of a denom are exhausted.
+// A pool is visited at most once per route while the pools that can never be part of a route
+// are excluded across the search.
+func (c greedyCandidateRouteFinder) FindCandidateRoutes(tokenIn sdk.Coin, tokenOutDenom string, options domain.CandidateRouteSearchOptions) (sqsdomain.CandidateRoutes, error) {
+	routes := make([]candidateRouteWrapper, 0, options.MaxRoutes)
+
+	// excluded are the pools skipped by the options or already routed through as the canonical orderbook.
+	excluded := make(map[uint64]struct{}, 100)
+
+	denomData, err := c.candidateRouteDataHolder.GetDenomData(tokenIn.Denom)
+	if err != nil {
+		return sqsdomain.CandidateRoutes{}, err
+	}
+
+	if canonicalOrderbookID, route, ok := canonicalOrderbookCandidateRoute(denomData, tokenOutDenom, options); ok {
+		if route != nil {
+			routes = append(routes, *route)
+		}
+
+		excluded[canonicalOrderbookID] = struct{}{}
+	}
+
+	currentRoute := make([]candidatePoolWrapper, 0, options.MaxPoolsPerRoute)
+	// visitedInRoute are the pools in the current route.
+	visitedInRoute := make(map[uint64]struct{}, options.MaxPoolsPerRoute)
+
+	var search func(currentTokenInDenom string) error
+	search = func(currentTokenInDenom string) error {
+		if len(currentRoute) >= options.MaxPoolsPerRoute {
+			return nil
+		}
+
+		denomData, err := c.candidateRouteDataHolder.GetDenomData(currentTokenInDenom)
+		if err != nil {
+			return err
+		}
+
+		rankedPools := denomData.SortedPools
+
+		if len(rankedPools) == 0 {
+			c.logger.Debug("no pools found for denom in greedy candidate route search", zap.String("denom", currentTokenInDenom))
+		}
+
+		// The first pass completes the route through the pools containing the token out denom.
+		// The second pass extends the route through the remaining pools.
+		for _, isCompletingPass := range []bool{true, false} {
+			// consideredPoolsCount is the number of pools that passed the filters in this pass.
+			consideredPoolsCount := 0
+
+			for i := 0; i < len(rankedPools) && len(routes) < options.MaxRoutes; i++ {
+				// Unsafe cast for performance reasons.
+				// nolint: forcetypeassert
+				pool := (rankedPools[i]).(*sqsdomain.PoolWrapper)
+				poolID := pool.ChainModel.GetId()
+
+				if _, ok := excluded[poolID]; ok {
+					continue
+				}
+
+				if options.ShouldSkipPool(pool) || pool.GetLiquidityCap().Uint64() < options.MinPoolLiquidityCap {
+					excluded[poolID] = struct{}{}
+					continue
+				}
+
+				// Only consider the highest ranked pools that passed the filters if configured.
+				if options.MaxPoolsConsidered > 0 && consideredPoolsCount >= options.MaxPoolsConsidered {
+					break
+				}
+				consideredPoolsCount++
+
+				if _, ok := visitedInRoute[poolID]; ok {
+					continue
+				}
+
+				poolDenoms := pool.SQSModel.PoolDenoms
+				hasTokenIn, hasTokenOut, shouldSkipPool := inspectPoolDenoms(poolDenoms, currentTokenInDenom, tokenIn.Denom, tokenOutDenom, len(currentRoute) == 0)
+
+				if shouldSkipPool || !hasTokenIn || hasTokenOut != isCompletingPass {
+					continue
+				}
+
+				// Validate that the first pool has enough token in to swap.
+				if len(currentRoute) == 0 && !hasEnoughTokenIn(pool, tokenIn) {
+					continue
+				}
+
+				if hasTokenOut {
+					route := make([]candidatePoolWrapper, len(currentRoute), len(currentRoute)+1)
+					copy(route, currentRoute)
+
+					routes = append(routes, candidateRouteWrapper{
+						Pools: append(route, candidatePoolWrapper{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            poolID,
+								TokenOutDenom: tokenOutDenom,
+							},
+							PoolDenoms: poolDenoms,
+						}),
+						IsCanonicalOrderboolRoute: false,
+					})
+					continue
+				}
+
+				visitedInRoute[poolID] = struct{}{}
+
+				for _, denom := range poolDenoms {
+					if len(routes) >= options.MaxRoutes {
+						break
+					}
+
+					if denom == currentTokenInDenom {
+						continue
+					}
+
+					// Avoid cycles by never revisiting a denom within the route.
+					if routeContainsTokenOutDenom(currentRoute, denom) {
+						continue
+					}
+
+					currentRoute = append(currentRoute, candidatePoolWrapper{
+						CandidatePool: sqsdomain.CandidatePool{
+							ID:            poolID,
+							TokenOutDenom: denom,
+						},
+						PoolDenoms: poolDenoms,
+					})
+
+					if err := search(denom); err != nil {
+						return err
+					}
+
+					currentRoute = currentRoute[:len(currentRoute)-1]
+				}
+
+				delete(visitedInRoute, poolID)
+			}
+		}
+
+		return nil
+	}
+
+	if err := search(tokenIn.Denom); err != nil {
+		return sqsdomain.CandidateRoutes{}, err
+	}
+
+	return validateAndFilterRoutes(routes, tokenIn.Denom, c.logger)
+}
@@ -1,13 +1,18 @@
 package usecase_test
 
 import (
+	"context"
+	"fmt"
 	"slices"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v26/x/poolmanager/types"
 	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
 	"github.com/osmosis-labs/sqs/log"
+	routerusecase "github.com/osmosis-labs/sqs/router/usecase"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
 	"github.com/osmosis-labs/sqs/sqsdomain"
 )
@@ -173,6 +178,313 @@ func (s *RouterTestSuite) TestCandidateRouteSearcher_SkipPoolOption() {
 	s.Require().False(didFindExpectedPoolID)
 }
 
+// This test validates that the candidate route search only considers the configured number
+// of the highest ranked pools for each denom.
+func (s *RouterTestSuite) TestCandidateRouteSearcher_MaxPoolsConsidered() {
+	mainnetState := s.SetupMainnetState()
+
+	usecase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	oneOSMOIn := sdk.NewCoin(UOSMO, defaultAmount)
+
+	routerConfig := usecase.Router.GetConfig()
+	candidateRouteOptions := domain.CandidateRouteSearchOptions{
+		MaxRoutes:           routerConfig.MaxRoutes,
+		MaxPoolsPerRoute:    routerConfig.MaxPoolsPerRoute,
+		MinPoolLiquidityCap: routerConfig.MinPoolLiquidityCap,
+	}
+
+	unlimitedCandidateRoutes, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(oneOSMOIn, ATOM, candidateRouteOptions)
+	s.Require().NoError(err)
+
+	for _, maxPoolsConsidered := range []int{3, 10} {
+		s.Run(fmt.Sprintf("max pools considered %d", maxPoolsConsidered), func() {
+			candidateRouteOptions.MaxPoolsConsidered = maxPoolsConsidered
+
+			// System under test
+			candidateRoutes, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(oneOSMOIn, ATOM, candidateRouteOptions)
+			s.Require().NoError(err)
+
+			s.Require().NotEmpty(candidateRoutes.Routes)
+			s.Require().LessOrEqual(len(candidateRoutes.Routes), len(unlimitedCandidateRoutes.Routes))
+
+			for _, route := range candidateRoutes.Routes {
+				// Canonical orderbooks are injected regardless of the pool ranking.
+				if route.IsCanonicalOrderboolRoute {
+					continue
+				}
+
+				curTokenInDenom := oneOSMOIn.Denom
+				for _, pool := range route.Pools {
+					denomData, ok := mainnetState.CandidateRouteSearchData[curTokenInDenom]
+					s.Require().True(ok)
+
+					// Validate that the pool is among the highest ranked pools for the token in denom
+					// that pass the min liquidity cap filter.
+					consideredPoolIDs := make([]uint64, 0, maxPoolsConsidered)
+					for i := 0; i < len(denomData.SortedPools) && len(consideredPoolIDs) < maxPoolsConsidered; i++ {
+						if denomData.SortedPools[i].GetLiquidityCap().Uint64() < candidateRouteOptions.MinPoolLiquidityCap {
+							continue
+						}
+						consideredPoolIDs = append(consideredPoolIDs, denomData.SortedPools[i].GetId())
+					}
+					s.Require().Contains(consideredPoolIDs, pool.ID)
+
+					curTokenInDenom = pool.TokenOutDenom
+				}
+			}
+		})
+	}
+
+	s.Run("cap is applied after the filters", func() {
+		const maxPoolsConsidered = 3
+		sortedPools := mainnetState.CandidateRouteSearchData[UOSMO].SortedPools
+
+		// Precondition: the top ranked pool is filtered out by the min liquidity cap.
+		s.Require().Less(sortedPools[0].GetLiquidityCap().Uint64(), candidateRouteOptions.MinPoolLiquidityCap)
+
+		filteredCandidateRouteOptions := candidateRouteOptions
+		filteredCandidateRouteOptions.MaxPoolsConsidered = maxPoolsConsidered
+
+		// System under test
+		candidateRoutes, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(oneOSMOIn, ATOM, filteredCandidateRouteOptions)
+		s.Require().NoError(err)
+
+		// The filtered out pool does not take up one of the considered pools.
+		// As a result, the pool ranked right after the cap is considered.
+		s.Require().True(foundExpectedPoolID(sortedPools[maxPoolsConsidered].GetId(), candidateRoutes.Routes))
+	})
+
+	// Zero does not limit the pools considered.
+	candidateRouteOptions.MaxPoolsConsidered = 0
+
+	candidateRoutes, err := usecase.CandidateRouteSearcher.FindCandidateRoutes(oneOSMOIn, ATOM, candidateRouteOptions)
+	s.Require().NoError(err)
+	s.Require().Equal(unlimitedCandidateRoutes, candidateRoutes)
+}
+
+// This test validates that the BFS and greedy candidate route search algorithms
+// find the same top single route for known pairs.
+func (s *RouterTestSuite) TestCandidateRouteSearcher_GreedySameTopRouteAsBFS() {
+	mainnetState := s.SetupMainnetState()
+
+	greedyRouterConfig := routertesting.DefaultRouterConfig
+	greedyRouterConfig.CandidateRouteSearchAlgorithm = domain.CandidateRouteSearchAlgorithmGreedy
+
+	bfsUsecase := s.SetupRouterAndPoolsUsecase(mainnetState)
+	greedyUsecase := s.SetupRouterAndPoolsUsecase(mainnetState, routertesting.WithRouterConfig(greedyRouterConfig))
+
+	routePoolIDs := func(quote domain.Quote) []uint64 {
+		s.Require().Len(quote.GetRoute(), 1)
+
+		pools := quote.GetRoute()[0].GetPools()
+		poolIDs := make([]uint64, 0, len(pools))
+		for _, pool := range pools {
+			poolIDs = append(poolIDs, pool.GetId())
+		}
+		return poolIDs
+	}
+
+	tests := []struct {
+		name          string
+		tokenIn       sdk.Coin
+		tokenOutDenom string
+	}{
+		{
+			name:          "UOSMO -> ATOM",
+			tokenIn:       sdk.NewCoin(UOSMO, defaultAmount),
+			tokenOutDenom: ATOM,
+		},
+		{
+			name:          "UOSMO -> USDC",
+			tokenIn:       sdk.NewCoin(UOSMO, defaultAmount),
+			tokenOutDenom: USDC,
+		},
+		{
+			name:          "USDC -> UOSMO",
+			tokenIn:       sdk.NewCoin(USDC, defaultAmount),
+			tokenOutDenom: UOSMO,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			bfsQuote, err := bfsUsecase.Router.GetOptimalQuote(context.Background(), tc.tokenIn, tc.tokenOutDenom, domain.WithSingleRouteOnly(), domain.WithDisableCache())
+			s.Require().NoError(err)
+
+			// System under test
+			greedyQuote, err := greedyUsecase.Router.GetOptimalQuote(context.Background(), tc.tokenIn, tc.tokenOutDenom, domain.WithSingleRouteOnly(), domain.WithDisableCache())
+			s.Require().NoError(err)
+
+			s.Require().Equal(routePoolIDs(bfsQuote), routePoolIDs(greedyQuote))
+			s.Require().Equal(bfsQuote.GetAmountOut(), greedyQuote.GetAmountOut())
+		})
+	}
+}
+
+// This test validates the order in which the greedy candidate route search finds the routes.
+// At every denom, the routes are first completed through the pools containing the token out denom
+// and then extended through the highest liquidity pools, backtracking from the dead ends.
+//
+// The pools are between the following denoms, ranked by liquidity:
+// 1: DenomOne/DenomTwo, 6: DenomTwo/DenomFive, 2: DenomOne/DenomThree, 8: DenomTwo/DenomThree,
+// 3: DenomTwo/DenomFour, 4: DenomThree/DenomFour, 7: DenomFive/DenomSix, 5: DenomOne/DenomFour.
+func (s *RouterTestSuite) TestGreedyCandidateRouteFinder_FindCandidateRoutes() {
+	newPool := func(id uint64, liquidityCap int64, denoms ...string) *sqsdomain.PoolWrapper {
+		balances := sdk.NewCoins()
+		for _, denom := range denoms {
+			balances = balances.Add(sdk.NewCoin(denom, defaultAmount))
+		}
+
+		return &sqsdomain.PoolWrapper{
+			ChainModel: &mocks.ChainPoolMock{ID: id, Type: poolmanagertypes.Balancer},
+			SQSModel: sqsdomain.SQSPool{
+				PoolLiquidityCap: osmomath.NewInt(liquidityCap),
+				PoolDenoms:       denoms,
+				Balances:         balances,
+			},
+		}
+	}
+
+	var (
+		poolOneTwo    = newPool(1, 100, DenomOne, DenomTwo)
+		poolOneThree  = newPool(2, 90, DenomOne, DenomThree)
+		poolTwoFour   = newPool(3, 80, DenomTwo, DenomFour)
+		poolThreeFour = newPool(4, 70, DenomThree, DenomFour)
+		poolOneFour   = newPool(5, 10, DenomOne, DenomFour)
+		poolTwoFive   = newPool(6, 95, DenomTwo, DenomFive)
+		poolFiveSix   = newPool(7, 60, DenomFive, DenomSix)
+		poolTwoThree  = newPool(8, 85, DenomTwo, DenomThree)
+
+		defaultPools = []*sqsdomain.PoolWrapper{poolOneTwo, poolOneThree, poolTwoFour, poolThreeFour, poolOneFour, poolTwoFive, poolFiveSix}
+	)
+
+	tests := []struct {
+		name             string
+		pools            []*sqsdomain.PoolWrapper
+		maxRoutes        int
+		maxPoolsPerRoute int
+
+		expectedRoutePoolIDs [][]uint64
+	}{
+		{
+			name:             "direct route first, then highest liquidity routes, backtracking from the dead end",
+			pools:            defaultPools,
+			maxRoutes:        10,
+			maxPoolsPerRoute: 3,
+
+			// The route through pool 6 reaches the DenomFive/DenomSix dead end before pool 2 is considered.
+			expectedRoutePoolIDs: [][]uint64{{5}, {1, 3}, {2, 4}},
+		},
+		{
+			name:             "stops at max routes",
+			pools:            defaultPools,
+			maxRoutes:        2,
+			maxPoolsPerRoute: 3,
+
+			expectedRoutePoolIDs: [][]uint64{{5}, {1, 3}},
+		},
+		{
+			name:             "pools are reused across routes",
+			pools:            append(defaultPools, poolTwoThree),
+			maxRoutes:        10,
+			maxPoolsPerRoute: 3,
+
+			expectedRoutePoolIDs: [][]uint64{{5}, {1, 3}, {1, 8, 4}, {2, 4}, {2, 8, 3}},
+		},
+		{
+			name: "denom without routes on one path is searched again on another",
+			pools: []*sqsdomain.PoolWrapper{
+				newPool(11, 100, DenomOne, DenomTwo),
+				newPool(12, 90, DenomTwo, DenomThree),
+				newPool(14, 80, DenomTwo, DenomFour),
+				newPool(15, 70, DenomOne, DenomFive),
+				newPool(16, 60, DenomFive, DenomThree),
+			},
+			maxRoutes:        10,
+			maxPoolsPerRoute: 4,
+
+			// DenomThree has no route when reached through DenomTwo since the route would revisit DenomTwo.
+			// Reached through DenomFive with the same number of remaining pools, it routes through DenomTwo.
+			expectedRoutePoolIDs: [][]uint64{{11, 14}, {15, 16, 12, 14}},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			// Rank the pools of each denom by liquidity.
+			sortedPools := slices.Clone(tc.pools)
+			slices.SortFunc(sortedPools, func(a, b *sqsdomain.PoolWrapper) int {
+				return b.GetLiquidityCap().BigInt().Cmp(a.GetLiquidityCap().BigInt())
+			})
+
+			candidateRouteSearchData := map[string]domain.CandidateRouteDenomData{}
+			for _, pool := range sortedPools {
+				for _, denom := range pool.SQSModel.PoolDenoms {
+					denomData := candidateRouteSearchData[denom]
+					denomData.SortedPools = append(denomData.SortedPools, pool)
+					candidateRouteSearchData[denom] = denomData
+				}
+			}
+
+			searcher := routerusecase.NewGreedyCandidateRouteFinder(&mocks.CandidateRouteSearchDataHolderMock{
+				CandidateRouteSearchData: candidateRouteSearchData,
+			}, noOpLogger)
+
+			// System under test
+			candidateRoutes, err := searcher.FindCandidateRoutes(sdk.NewCoin(DenomOne, one), DenomFour, domain.CandidateRouteSearchOptions{
+				MaxRoutes:        tc.maxRoutes,
+				MaxPoolsPerRoute: tc.maxPoolsPerRoute,
+			})
+			s.Require().NoError(err)
+
+			actualRoutePoolIDs := make([][]uint64, 0, len(candidateRoutes.Routes))
+			for _, route := range candidateRoutes.Routes {
+				poolIDs := make([]uint64, 0, len(route.Pools))
+				for _, pool := range route.Pools {
+					poolIDs = append(poolIDs, pool.ID)
+				}
+				actualRoutePoolIDs = append(actualRoutePoolIDs, poolIDs)
+			}
+
+			s.Require().Equal(tc.expectedRoutePoolIDs, actualRoutePoolIDs)
+		})
+	}
+}
+
+// This test validates that the candidate route searcher is constructed per the configured algorithm.
+func (s *RouterTestSuite) TestNewCandidateRouteSearcher() {
+	tests := []struct {
+		name        string
+		algorithm   domain.CandidateRouteSearchAlgorithm
+		expectError error
+	}{
+		{name: "empty defaults to bfs", algorithm: ""},
+		{name: "bfs", algorithm: domain.CandidateRouteSearchAlgorithmBFS},
+		{name: "greedy", algorithm: domain.CandidateRouteSearchAlgorithmGreedy},
+		{
+			name:        "unsupported",
+			algorithm:   "dfs",
+			expectError: routerusecase.UnsupportedCandidateRouteSearchAlgorithmError{Algorithm: "dfs"},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			// System under test
+			searcher, err := routerusecase.NewCandidateRouteSearcher(tc.algorithm, nil, noOpLogger)
+
+			if tc.expectError != nil {
+				s.Require().ErrorIs(err, tc.expectError)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().NotNil(searcher)
+		})
+	}
+}
+
 func (s *RouterTestSuite) validateExpectedPoolIDOneHopRoute(route sqsdomain.CandidateRoute, expectedPoolID uint64) {
 	routePools := route.Pools
 	s.Require().Equal(1, len(routePools))
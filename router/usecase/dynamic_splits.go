@@ -24,7 +24,11 @@ const totalIncrements = uint8(10)
 // The algorithm is based on the knapsack problem.
 // The time complexity is O(n * m), where n is the number of routes and m is the totalIncrements.
 // The space complexity is O(n * m).
-func getSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Coin) (domain.Quote, error) {
+//
+// If minSplitRouteOutPortion is positive, the routes producing less than that fraction of
+// the total amount out are dropped and the split is recomputed across the remaining routes,
+// redistributing their allocation. If all routes are dropped, an error is returned.
+func getSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Coin, minSplitRouteOutPortion osmomath.Dec) (domain.Quote, error) {
 	// Routes must be non-empty
 	if len(routes) == 0 {
 		return nil, errors.New("no routes")
@@ -126,6 +130,8 @@ func getSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Co
 	totalIncrementsInSplits := uint8(0)
 	resultRoutes := make([]domain.SplitRoute, 0, len(routes))
 	totalAmoutOutFromSplits := osmomath.ZeroInt()
+	isPrunedRoute := make([]bool, len(routes))
+	hasPrunedRoutes := false
 	for i, currentRouteIncrement := range bestSplit.routeIncrements {
 		currentRoute := routes[i]
 
@@ -150,6 +156,11 @@ func getSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Co
 			return nil, fmt.Errorf("out amount is zero when in is not (%s), route index (%d)", inAmount, i)
 		}
 
+		if minSplitRouteOutPortion.IsPositive() && outAmount.ToLegacyDec().QuoMut(bestSplit.amountOut.ToLegacyDec()).LT(minSplitRouteOutPortion) {
+			isPrunedRoute[i] = true
+			hasPrunedRoutes = true
+		}
+
 		resultRoutes = append(resultRoutes, &RouteWithOutAmount{
 			RouteImpl: currentRoute,
 			InAmount:  inAmount,
@@ -170,6 +181,19 @@ func getSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Co
 		return nil, fmt.Errorf("total increments (%d) does not match expected total increments (%d)", totalIncrementsInSplits, totalIncrements)
 	}
 
+	// Drop the routes below the min out portion and recompute the split across the remaining ones.
+	// Terminates since each recursion is over strictly fewer routes.
+	if hasPrunedRoutes {
+		remainingRoutes := make([]route.RouteImpl, 0, len(routes))
+		for i, currentRoute := range routes {
+			if !isPrunedRoute[i] {
+				remainingRoutes = append(remainingRoutes, currentRoute)
+			}
+		}
+
+		return getSplitQuote(ctx, remainingRoutes, tokenIn, minSplitRouteOutPortion)
+	}
+
 	quote := &quoteExactAmountIn{
 		AmountIn:  tokenIn,
 		AmountOut: bestSplit.amountOut,
@@ -3,6 +3,8 @@ package usecase
 import (
 	"errors"
 	"fmt"
+
+	"github.com/osmosis-labs/sqs/domain"
 )
 
 var (
@@ -91,3 +93,49 @@ type CurrentTokenOutDenomNotInPoolError struct {
 func (e CurrentTokenOutDenomNotInPoolError) Error() string {
 	return fmt.Sprintf("current token out denom (%s) not found in pool (%d), route index (%d)", e.CurrentTokenOutDenom, e.PoolId, e.RouteIndex)
 }
+
+// IntermediateDenomMismatchError is returned when the intermediate out denom
+// at the given index of a custom multi-hop quote is not produced by the pool at that index.
+// Actual contains the denoms that the pool produces for the given token in, separated by a comma.
+type IntermediateDenomMismatchError struct {
+	Index    int
+	Expected string
+	Actual   string
+}
+
+func (e IntermediateDenomMismatchError) Error() string {
+	return fmt.Sprintf("intermediate denom (%s) at index (%d) is not produced by the pool, pool produces (%s)", e.Expected, e.Index, e.Actual)
+}
+
+// RequiredPoolNotInRoutesError is returned when there is no valid route
+// through the required pool between the token in and token out denoms.
+type RequiredPoolNotInRoutesError struct {
+	PoolID        uint64
+	TokenInDenom  string
+	TokenOutDenom string
+}
+
+func (e RequiredPoolNotInRoutesError) Error() string {
+	return fmt.Sprintf("no valid route through required pool (%d) from (%s) to (%s)", e.PoolID, e.TokenInDenom, e.TokenOutDenom)
+}
+
+// CyclicRouteError describes a route that revisits a denom,
+// e.g. A -> B -> A -> C. Such routes are skipped.
+// It is returned only when no other routes remain.
+type CyclicRouteError struct {
+	RouteIndex int
+	Denom      string
+}
+
+func (e CyclicRouteError) Error() string {
+	return fmt.Sprintf("route %d revisits denom (%s)", e.RouteIndex, e.Denom)
+}
+
+// UnsupportedCandidateRouteSearchAlgorithmError is returned when the candidate route search algorithm is not supported.
+type UnsupportedCandidateRouteSearchAlgorithmError struct {
+	Algorithm domain.CandidateRouteSearchAlgorithm
+}
+
+func (e UnsupportedCandidateRouteSearchAlgorithmError) Error() string {
+	return fmt.Sprintf("unsupported candidate route search algorithm (%s)", e.Algorithm)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -15,7 +16,8 @@ import (
 type (
 	RouterUseCaseImpl = routerUseCaseImpl
 
-	QuoteImpl = quoteExactAmountIn
+	QuoteImpl               = quoteExactAmountIn
+	QuoteExactAmountOutImpl = quoteExactAmountOut
 
 	CandidatePoolWrapper  = candidatePoolWrapper
 	CandidateRouteWrapper = candidateRouteWrapper
@@ -25,6 +27,10 @@ const (
 	NoPoolLiquidityCapError = noPoolLiquidityCapError
 )
 
+func ComputeRouteComplexity(routes []domain.SplitRoute) domain.RouteComplexity {
+	return computeRouteComplexity(routes)
+}
+
 func ValidateAndFilterRoutes(candidateRoutes []candidateRouteWrapper, tokenInDenom string, logger log.Logger) (sqsdomain.CandidateRoutes, error) {
 	return validateAndFilterRoutes(candidateRoutes, tokenInDenom, logger)
 }
@@ -37,7 +43,11 @@ func (r *routerUseCaseImpl) EstimateAndRankSingleRouteQuote(ctx context.Context,
 	return r.estimateAndRankSingleRouteQuote(ctx, routes, tokenIn, logger)
 }
 
-func FilterDuplicatePoolIDRoutes(rankedRoutes []RouteWithOutAmount) []route.RouteImpl {
+func SelectPreferredPoolRoute(routesWithAmountOut []RouteWithOutAmount, preferredPoolIDs map[uint64]struct{}, toleranceBps uint64) RouteWithOutAmount {
+	return selectPreferredPoolRoute(routesWithAmountOut, preferredPoolIDs, toleranceBps)
+}
+
+func FilterDuplicatePoolIDRoutes(rankedRoutes []RouteWithOutAmount) ([]route.RouteImpl, []domain.FilteredRoute) {
 	return filterAndConvertDuplicatePoolIDRankedRoutes(rankedRoutes)
 }
 
@@ -61,18 +71,27 @@ func SortPools(pools []sqsdomain.PoolI, transmuterCodeIDs map[uint64]struct{}, t
 	return sortPools(pools, transmuterCodeIDs, totalTVL, preferredPoolIDsMap, logger)
 }
 
+func GetSplitQuoteWithMinOutPortion(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Coin, minSplitRouteOutPortion osmomath.Dec) (domain.Quote, error) {
+	return getSplitQuote(ctx, routes, tokenIn, minSplitRouteOutPortion)
+}
+
 func GetSplitQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Coin) (domain.Quote, error) {
-	return getSplitQuote(ctx, routes, tokenIn)
+	return getSplitQuote(ctx, routes, tokenIn, osmomath.ZeroDec())
 }
 
 func (r *routerUseCaseImpl) RankRoutesByDirectQuote(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenIn sdk.Coin, tokenOutDenom string, maxRoutes int) (domain.Quote, []route.RouteImpl, error) {
-	return r.rankRoutesByDirectQuote(ctx, candidateRoutes, tokenIn, tokenOutDenom, maxRoutes)
+	topQuote, routes, _, _, err := r.rankRoutesByDirectQuote(ctx, candidateRoutes, tokenIn, tokenOutDenom, maxRoutes, domain.TakerFeeModePerHop)
+	return topQuote, routes, err
 }
 
-func CutRoutesForSplits(maxSplitRoutes int, routes []route.RouteImpl) []route.RouteImpl {
+func CutRoutesForSplits(maxSplitRoutes int, routes []route.RouteImpl) ([]route.RouteImpl, []domain.FilteredRoute) {
 	return cutRoutesForSplits(maxSplitRoutes, routes)
 }
 
+func FilterOutGeneralizedCosmWasmPoolRoutes(rankedRoutes []route.RouteImpl) ([]route.RouteImpl, []domain.FilteredRoute) {
+	return filterOutGeneralizedCosmWasmPoolRoutes(rankedRoutes)
+}
+
 func (r *routerUseCaseImpl) SetCandidateRouteCacheToMock(tokenInDenom, tokenOutDenom string) {
 	r.candidateRouteCache.Set(formatCandidateRouteCacheKey(tokenInDenom, tokenOutDenom), sqsdomain.CandidateRoutes{
 		// Note: some mock dummy values
@@ -89,3 +108,20 @@ func (r *routerUseCaseImpl) SetRankedRouteCacheToMock(tokenInDenom, tokenOutDeno
 		}}, 0)
 
 }
+
+func (r *routerUseCaseImpl) GetRankedRouteCacheExpiresAt(tokenInDenom, tokenOutDenom string, orderOfMagnitude int) time.Time {
+	_, expiresAt, _ := r.rankedRouteCache.GetWithExpiration(formatRankedRouteCacheKey(tokenInDenom, tokenOutDenom, orderOfMagnitude))
+	return expiresAt
+}
+
+func ComputeQuoteExpiresAt(now time.Time, options domain.RouterOptions) time.Time {
+	return computeQuoteExpiresAt(now, options)
+}
+
+func ComputeAlternativeQuotes(rankedRoutes []RouteWithOutAmount, tokenIn sdk.Coin, maxAlternatives int, selectedQuote domain.Quote) []domain.Quote {
+	return computeAlternativeQuotes(rankedRoutes, tokenIn, maxAlternatives, selectedQuote)
+}
+
+func ValidateNonZeroAmountOut(quote domain.Quote, tokenInDenom, tokenOutDenom string) error {
+	return validateNonZeroAmountOut(quote, tokenInDenom, tokenOutDenom)
+}
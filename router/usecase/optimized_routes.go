@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"context"
-	"fmt"
 	"sort"
 
 	"cosmossdk.io/math"
@@ -22,7 +21,7 @@ import (
 // CONTRACT: pools reporitory must be set on the router
 func (r *routerUseCaseImpl) estimateAndRankSingleRouteQuote(ctx context.Context, routes []route.RouteImpl, tokenIn sdk.Coin, logger log.Logger) (quote domain.Quote, sortedRoutesByAmtOut []RouteWithOutAmount, err error) {
 	if len(routes) == 0 {
-		return nil, nil, fmt.Errorf("no routes were provided for token in (%s)", tokenIn.Denom)
+		return nil, nil, domain.NoRoutesError{TokenInDenom: tokenIn.Denom}
 	}
 
 	routesWithAmountOut := make([]RouteWithOutAmount, 0, len(routes))
@@ -60,7 +59,7 @@ func (r *routerUseCaseImpl) estimateAndRankSingleRouteQuote(ctx context.Context,
 		tokenOutDenom := routes[0].GetTokenOutDenom()
 
 		r.candidateRouteCache.Delete(formatCandidateRouteCacheKey(tokenIn.Denom, tokenOutDenom))
-		tokenInOrderOfMagnitude := GetPrecomputeOrderOfMagnitude(tokenIn.Amount)
+		tokenInOrderOfMagnitude := domain.GetPrecomputeOrderOfMagnitude(tokenIn.Amount)
 		r.rankedRouteCache.Delete(formatRankedRouteCacheKey(tokenIn.Denom, tokenOutDenom, tokenInOrderOfMagnitude))
 
 		return nil, nil, errors[0]
@@ -82,6 +81,39 @@ func (r *routerUseCaseImpl) estimateAndRankSingleRouteQuote(ctx context.Context,
 	return finalQuote, routesWithAmountOut, nil
 }
 
+// selectPreferredPoolRoute returns the first route through at least one of the preferred pools
+// if its amount out is within toleranceBps basis points of the best amount out. Returns the best route otherwise.
+// The given routes are not reordered so that they remain sorted by amount out for the split computation.
+// CONTRACT: routesWithAmountOut are non-empty and sorted in decreasing order by amount out.
+func selectPreferredPoolRoute(routesWithAmountOut []RouteWithOutAmount, preferredPoolIDs map[uint64]struct{}, toleranceBps uint64) RouteWithOutAmount {
+	bestRoute := routesWithAmountOut[0]
+
+	// bestAmountOut * (1 - toleranceBps / 10_000)
+	minAmountOut := bestRoute.OutAmount.Mul(osmomath.NewIntFromUint64(domain.BasisPointsDenominator - toleranceBps)).Quo(osmomath.NewIntFromUint64(domain.BasisPointsDenominator))
+
+	for _, route := range routesWithAmountOut {
+		if route.OutAmount.LT(minAmountOut) {
+			break
+		}
+
+		if containsPreferredPool(route, preferredPoolIDs) {
+			return route
+		}
+	}
+
+	return bestRoute
+}
+
+// containsPreferredPool returns true if at least one of the route pools is preferred.
+func containsPreferredPool(route RouteWithOutAmount, preferredPoolIDs map[uint64]struct{}) bool {
+	for _, pool := range route.GetPools() {
+		if _, ok := preferredPoolIDs[pool.GetId()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // validateAndFilterRoutes validates all routes. Specifically:
 // - all routes have at least one pool.
 // - all routes have the same final token out denom.
@@ -90,10 +122,16 @@ func (r *routerUseCaseImpl) estimateAndRankSingleRouteQuote(ctx context.Context,
 // - the previous pool token out denom is in the current pool.
 // - the current pool token out denom is in the current pool.
 // Returns error if not. Nil otherwise.
+// Filters out the routes that revisit a denom, contain the same pool more than once
+// or have an intermediary pool with the token in or token out denom.
+// Returns CyclicRouteError if routes revisiting a denom were filtered out and no routes remain.
 func validateAndFilterRoutes(candidateRoutes []candidateRouteWrapper, tokenInDenom string, logger log.Logger) (sqsdomain.CandidateRoutes, error) {
 	var (
 		tokenOutDenom  string
 		filteredRoutes []sqsdomain.CandidateRoute
+
+		// the error of the last route skipped for revisiting a denom
+		cyclicRouteErr error
 	)
 
 	uniquePoolIDs := make(map[uint64]struct{})
@@ -110,6 +148,13 @@ ROUTE_LOOP:
 			return sqsdomain.CandidateRoutes{}, NoPoolsInRouteError{RouteIndex: i}
 		}
 
+		// Skip cyclic routes, logging the revisited denom for diagnostics.
+		if cyclicDenom, isCyclic := findRouteCycle(candidateRoutePools, tokenInDenom); isCyclic {
+			cyclicRouteErr = CyclicRouteError{RouteIndex: i, Denom: cyclicDenom}
+			logger.Warn("route skipped - revisits denom", zap.Error(cyclicRouteErr))
+			continue ROUTE_LOOP
+		}
+
 		lastPool := candidateRoutePools[len(candidateRoutePools)-1]
 		currentRouteTokenOutDenom := lastPool.TokenOutDenom
 
@@ -174,7 +219,7 @@ ROUTE_LOOP:
 			previousTokenOut = currentPoolTokenOutDenom
 		}
 
-		if i > 0 {
+		if len(filteredRoutes) > 0 {
 			// Ensure that all routes have the same final token out denom
 			if currentRouteTokenOutDenom != tokenOutDenom {
 				return sqsdomain.CandidateRoutes{}, TokenOutMismatchBetweenRoutesError{TokenOutDenomRouteA: tokenOutDenom, TokenOutDenomRouteB: currentRouteTokenOutDenom}
@@ -200,6 +245,10 @@ ROUTE_LOOP:
 		filteredRoutes = append(filteredRoutes, filteredRoute)
 	}
 
+	if len(filteredRoutes) == 0 && cyclicRouteErr != nil {
+		return sqsdomain.CandidateRoutes{}, cyclicRouteErr
+	}
+
 	if tokenOutDenom == tokenInDenom {
 		return sqsdomain.CandidateRoutes{}, TokenOutDenomMatchesTokenInDenomError{Denom: tokenOutDenom}
 	}
@@ -211,10 +260,46 @@ ROUTE_LOOP:
 	}, nil
 }
 
+// findRouteCycle returns the first denom revisited by the route starting at the token in denom
+// and true if such a denom exists. Returns empty string and false otherwise.
+// The final token out matching the token in denom is not considered a cycle
+// since it is reported by TokenOutDenomMatchesTokenInDenomError.
+// A pool with the token out denom equal to the previous one does not swap into a new denom
+// and, as a result, is not considered a cycle either. Such routes are validated by the remaining checks.
+func findRouteCycle(routePools []candidatePoolWrapper, tokenInDenom string) (string, bool) {
+	visitedDenoms := make(map[string]struct{}, len(routePools)+1)
+	visitedDenoms[tokenInDenom] = struct{}{}
+
+	previousTokenOutDenom := tokenInDenom
+	for i, pool := range routePools {
+		if i == len(routePools)-1 && pool.TokenOutDenom == tokenInDenom {
+			break
+		}
+
+		if pool.TokenOutDenom == previousTokenOutDenom {
+			continue
+		}
+		previousTokenOutDenom = pool.TokenOutDenom
+
+		if _, ok := visitedDenoms[pool.TokenOutDenom]; ok {
+			return pool.TokenOutDenom, true
+		}
+		visitedDenoms[pool.TokenOutDenom] = struct{}{}
+	}
+
+	return "", false
+}
+
 type RouteWithOutAmount struct {
 	route.RouteImpl
 	OutAmount osmomath.Int "json:\"out_amount\""
 	InAmount  osmomath.Int "json:\"in_amount\""
+	// InPortion is the fraction of the quote's total token in amount swapped over this route.
+	// Only set on the routes of a prepared quote result.
+	InPortion osmomath.Dec "json:\"in_portion\""
+	// IntermediateAmounts are the token amounts between each hop of the route.
+	// Only set on the routes of a prepared quote result.
+	IntermediateAmounts []sdk.Coin "json:\"intermediate_amounts,omitempty\""
 }
 
 var _ domain.SplitRoute = &RouteWithOutAmount{}
@@ -229,6 +314,20 @@ func (r RouteWithOutAmount) GetAmountOut() math.Int {
 	return r.OutAmount
 }
 
+// GetInPortion implements domain.SplitRoute.
+// Returns zero if the portion has not been computed.
+func (r RouteWithOutAmount) GetInPortion() osmomath.Dec {
+	if r.InPortion.IsNil() {
+		return osmomath.ZeroDec()
+	}
+	return r.InPortion
+}
+
+// GetIntermediateAmounts implements domain.SplitRoute.
+func (r RouteWithOutAmount) GetIntermediateAmounts() []sdk.Coin {
+	return r.IntermediateAmounts
+}
+
 type Split struct {
 	Routes          []domain.SplitRoute
 	CurrentTotalOut osmomath.Int
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"sort"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/osmosis/osmoutils/coinutil"
@@ -263,6 +265,138 @@ func (s *RouterTestSuite) TestGetBestSplitRoutesQuote() {
 				s.Require().True(previousRouteAmountIn.GT(currentRouteAmountIn))
 				s.Require().True(previousRouteAmountOut.GT(currentRouteAmountOut))
 			}
+
+			// Prepare the result and validate that the in portions
+			// match the in amounts and sum up to one.
+			preparedRoutes, _, err := quote.PrepareResult(context.TODO(), defaultSpotPriceScalingFactor, &log.NoOpLogger{})
+			s.Require().NoError(err)
+
+			totalInPortion := osmomath.ZeroDec()
+			for _, splitRoute := range preparedRoutes {
+				expectedInPortion := splitRoute.GetAmountIn().ToLegacyDec().Quo(tc.tokenIn.Amount.ToLegacyDec())
+				s.Require().Equal(expectedInPortion, splitRoute.GetInPortion())
+
+				totalInPortion = totalInPortion.Add(splitRoute.GetInPortion())
+			}
+
+			// Error tolerance of one unit of token in to account for the rounding differences
+			portionErrTolerance := osmomath.ErrTolerance{
+				AdditiveTolerance: osmomath.OneDec().Quo(tc.tokenIn.Amount.ToLegacyDec()),
+			}
+			osmoassert.Equal(s.T(), portionErrTolerance, osmomath.OneDec(), totalInPortion)
+		})
+	}
+}
+
+// This test validates that the split routes producing less than the min out portion
+// are dropped and their allocation is redistributed across the remaining routes.
+// Three pools with X, 2X and 4X liquidity are set up so that the split is spread across all of them
+// without the min out portion.
+func (s *RouterTestSuite) TestGetSplitQuote_MinSplitRouteOutPortion() {
+	s.Setup()
+
+	xLiquidity := sdk.NewCoins(
+		sdk.NewCoin(DenomOne, osmomath.NewInt(1_000_000_000_000)),
+		sdk.NewCoin(DenomTwo, osmomath.NewInt(2_000_000_000_000)),
+	)
+
+	var (
+		poolIDOne   = s.PrepareBalancerPoolWithCoins(xLiquidity...)
+		poolIDTwo   = s.PrepareBalancerPoolWithCoins(coinutil.MulRaw(xLiquidity, 2)...)
+		poolIDThree = s.PrepareBalancerPoolWithCoins(coinutil.MulRaw(xLiquidity, 4)...)
+
+		tokenIn = sdk.NewCoin(DenomTwo, osmomath.NewInt(56_789_321_000))
+	)
+
+	routes := make([]route.RouteImpl, 0, 3)
+	for _, poolID := range []uint64{poolIDOne, poolIDTwo, poolIDThree} {
+		pool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolID)
+		s.Require().NoError(err)
+
+		routes = append(routes, WithRoutePools(route.RouteImpl{}, []domain.RoutablePool{
+			mocks.WithPoolID(mocks.WithChainPoolModel(mocks.WithTokenOutDenom(DefaultMockPool, DenomOne), pool), poolID),
+		}))
+	}
+
+	// Validate the preconditions: without the min out portion, all routes are used
+	// and the route with the least liquidity produces the smallest portion.
+	unprunedQuote, err := routerusecase.GetSplitQuote(context.TODO(), routes, tokenIn)
+	s.Require().NoError(err)
+	s.Require().Len(unprunedQuote.GetRoute(), 3)
+
+	minUnprunedOutPortion := unprunedQuote.GetRoute()[0].GetAmountOut().ToLegacyDec().Quo(unprunedQuote.GetAmountOut().ToLegacyDec())
+	for _, splitRoute := range unprunedQuote.GetRoute()[1:] {
+		outPortion := splitRoute.GetAmountOut().ToLegacyDec().Quo(unprunedQuote.GetAmountOut().ToLegacyDec())
+		s.Require().True(minUnprunedOutPortion.LT(outPortion))
+	}
+
+	tests := []struct {
+		name                    string
+		minSplitRouteOutPortion osmomath.Dec
+
+		expectedPoolIDs []uint64
+		expectErr       bool
+	}{
+		{
+			name:                    "zero min out portion -> no routes pruned",
+			minSplitRouteOutPortion: osmomath.ZeroDec(),
+
+			expectedPoolIDs: []uint64{poolIDOne, poolIDTwo, poolIDThree},
+		},
+		{
+			name:                    "min out portion at the smallest route portion -> no routes pruned",
+			minSplitRouteOutPortion: minUnprunedOutPortion,
+
+			expectedPoolIDs: []uint64{poolIDOne, poolIDTwo, poolIDThree},
+		},
+		{
+			name:                    "min out portion above the smallest route portion -> smallest route pruned",
+			minSplitRouteOutPortion: minUnprunedOutPortion.Add(osmomath.SmallestDec()),
+
+			expectedPoolIDs: []uint64{poolIDTwo, poolIDThree},
+		},
+		{
+			name:                    "min out portion above all route portions -> error",
+			minSplitRouteOutPortion: osmomath.MustNewDecFromStr("0.99"),
+
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			// System under test
+			quote, err := routerusecase.GetSplitQuoteWithMinOutPortion(context.TODO(), routes, tokenIn, tc.minSplitRouteOutPortion)
+
+			if tc.expectErr {
+				s.Require().Error(err)
+				return
+			}
+			s.Require().NoError(err)
+
+			splitRoutes := quote.GetRoute()
+			s.Require().Len(splitRoutes, len(tc.expectedPoolIDs))
+
+			actualTotalIn := osmomath.ZeroInt()
+			actualTotalOut := osmomath.ZeroInt()
+			for i, splitRoute := range splitRoutes {
+				s.Require().Equal(tc.expectedPoolIDs[i], splitRoute.GetPools()[0].GetId())
+
+				// No remaining route is below the min out portion.
+				outPortion := splitRoute.GetAmountOut().ToLegacyDec().Quo(quote.GetAmountOut().ToLegacyDec())
+				s.Require().True(outPortion.GTE(tc.minSplitRouteOutPortion))
+
+				actualTotalIn = actualTotalIn.Add(splitRoute.GetAmountIn())
+				actualTotalOut = actualTotalOut.Add(splitRoute.GetAmountOut())
+			}
+
+			// The totals still balance after the redistribution.
+			// Error tolerance of 1 to account for the rounding differences
+			errTolerance := osmomath.ErrTolerance{
+				AdditiveTolerance: osmomath.OneDec(),
+			}
+			osmoassert.Equal(s.T(), errTolerance, tokenIn.Amount, actualTotalIn)
+			s.Require().Equal(quote.GetAmountOut(), actualTotalOut)
 		})
 	}
 }
@@ -441,6 +575,119 @@ func (s *RouterTestSuite) TestValidateAndFilterRoutes() {
 		},
 
 		// Routes filtered
+		"error: the only route revisits token in denom": {
+			routes: []usecase.CandidateRouteWrapper{
+				{
+					// DenomOne -> DenomTwo -> DenomOne -> DenomThree
+					Pools: []usecase.CandidatePoolWrapper{
+						defaultDenomOneTwoOutTwoPool,
+						{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            defaultPoolID + 1,
+								TokenOutDenom: DenomOne,
+							},
+							PoolDenoms: []string{DenomOne, DenomTwo},
+						},
+						{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            defaultPoolID + 2,
+								TokenOutDenom: DenomThree,
+							},
+							PoolDenoms: []string{DenomOne, DenomThree},
+						},
+					},
+				},
+			},
+			tokenInDenom: DenomOne,
+
+			expectError: usecase.CyclicRouteError{RouteIndex: 0, Denom: DenomOne},
+		},
+		"filtered: second route revisits intermediate denom": {
+			routes: []usecase.CandidateRouteWrapper{
+				{
+					Pools: []usecase.CandidatePoolWrapper{
+						{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            defaultPoolID + 3,
+								TokenOutDenom: DenomFour,
+							},
+							PoolDenoms: []string{DenomOne, DenomFour},
+						},
+					},
+				},
+				{
+					// DenomOne -> DenomTwo -> DenomThree -> DenomTwo -> DenomFour
+					Pools: []usecase.CandidatePoolWrapper{
+						defaultDenomOneTwoOutTwoPool,
+						{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            defaultPoolID + 1,
+								TokenOutDenom: DenomThree,
+							},
+							PoolDenoms: []string{DenomTwo, DenomThree},
+						},
+						{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            defaultPoolID + 2,
+								TokenOutDenom: DenomTwo,
+							},
+							PoolDenoms: []string{DenomTwo, DenomThree},
+						},
+						{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            defaultPoolID + 4,
+								TokenOutDenom: DenomFour,
+							},
+							PoolDenoms: []string{DenomTwo, DenomFour},
+						},
+					},
+				},
+			},
+			tokenInDenom: DenomOne,
+
+			expectFiltered:            true,
+			expectFilteredRouteLength: 1,
+		},
+		"filtered: first route revisits token in denom, second route is valid": {
+			routes: []usecase.CandidateRouteWrapper{
+				{
+					// DenomOne -> DenomTwo -> DenomOne -> DenomThree
+					Pools: []usecase.CandidatePoolWrapper{
+						defaultDenomOneTwoOutTwoPool,
+						{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            defaultPoolID + 1,
+								TokenOutDenom: DenomOne,
+							},
+							PoolDenoms: []string{DenomOne, DenomTwo},
+						},
+						{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            defaultPoolID + 2,
+								TokenOutDenom: DenomThree,
+							},
+							PoolDenoms: []string{DenomOne, DenomThree},
+						},
+					},
+				},
+				{
+					// DenomOne -> DenomThree
+					Pools: []usecase.CandidatePoolWrapper{
+						{
+							CandidatePool: sqsdomain.CandidatePool{
+								ID:            defaultPoolID + 3,
+								TokenOutDenom: DenomThree,
+							},
+							PoolDenoms: []string{DenomOne, DenomThree},
+						},
+					},
+				},
+			},
+			tokenInDenom: DenomOne,
+
+			expectFiltered:            true,
+			expectFilteredRouteLength: 1,
+		},
 		"filtered: token in is in the route": {
 			routes: []usecase.CandidateRouteWrapper{
 				{
@@ -691,6 +938,227 @@ var optimalQuoteTestCases = map[string]struct {
 	},
 }
 
+// Validates that the optimal quote only goes through the required pool when configured.
+// Returns an error if there is no valid route through the required pool.
+func (s *RouterTestSuite) TestGetOptimalQuote_WithRequiredPoolID_Mainnet() {
+	const (
+		osmoAtomBalancerPoolID = uint64(1)
+		aktUSDCPoolID          = uint64(1301)
+	)
+
+	tests := []struct {
+		name           string
+		tokenIn        sdk.Coin
+		tokenOutDenom  string
+		requiredPoolID uint64
+
+		expectedErr error
+	}{
+		{
+			name:           "direct route through required pool",
+			tokenIn:        sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000)),
+			tokenOutDenom:  ATOM,
+			requiredPoolID: osmoAtomBalancerPoolID,
+		},
+		{
+			name:           "multi-hop route through required pool",
+			tokenIn:        sdk.NewCoin(USDC, osmomath.NewInt(1_000_000)),
+			tokenOutDenom:  ATOM,
+			requiredPoolID: osmoAtomBalancerPoolID,
+		},
+		{
+			name:           "no route through required pool",
+			tokenIn:        sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000)),
+			tokenOutDenom:  ATOM,
+			requiredPoolID: aktUSDCPoolID,
+
+			expectedErr: usecase.RequiredPoolNotInRoutesError{
+				PoolID:        aktUSDCPoolID,
+				TokenInDenom:  UOSMO,
+				TokenOutDenom: ATOM,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			// Setup mainnet router
+			mainnetState := s.SetupMainnetState()
+			mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+			// System under test
+			quote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tc.tokenIn, tc.tokenOutDenom, domain.WithRequiredPoolID(tc.requiredPoolID))
+
+			if tc.expectedErr != nil {
+				s.Require().Error(err)
+				s.Require().Equal(tc.expectedErr, err)
+				return
+			}
+			s.Require().NoError(err)
+
+			// Every route must go through the required pool.
+			routes := quote.GetRoute()
+			s.Require().NotEmpty(routes)
+			for _, splitRoute := range routes {
+				poolIDs := make([]uint64, 0, len(splitRoute.GetPools()))
+				for _, pool := range splitRoute.GetPools() {
+					poolIDs = append(poolIDs, pool.GetId())
+				}
+				s.Require().Contains(poolIDs, tc.requiredPoolID)
+			}
+		})
+	}
+}
+
+// Compares the optimal quotes for the per-hop and input-deduct taker fee modes
+// on a multi-hop route.
+func (s *RouterTestSuite) TestGetOptimalQuote_WithTakerFeeMode_Mainnet() {
+	const osmoAtomBalancerPoolID = uint64(1)
+
+	var (
+		tokenIn = sdk.NewCoin(USDC, osmomath.NewInt(1_000_000_000))
+
+		// Restrict to a single multi-hop route so that both modes quote over the same pools.
+		opts = []domain.RouterOption{
+			domain.WithRequiredPoolID(osmoAtomBalancerPoolID),
+			domain.WithDisableSplitRoutes(),
+		}
+	)
+
+	// Setup mainnet router
+	mainnetState := s.SetupMainnetState()
+	mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	defaultQuote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, ATOM, opts...)
+	s.Require().NoError(err)
+
+	// System under test
+	perHopQuote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, ATOM, append(opts, domain.WithTakerFeeMode(domain.TakerFeeModePerHop))...)
+	s.Require().NoError(err)
+
+	inputDeductQuote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, ATOM, append(opts, domain.WithTakerFeeMode(domain.TakerFeeModeInputDeduct))...)
+	s.Require().NoError(err)
+
+	// Per hop is the default mode.
+	s.Require().Equal(defaultQuote.GetAmountOut(), perHopQuote.GetAmountOut())
+
+	perHopRoutes := perHopQuote.GetRoute()
+	inputDeductRoutes := inputDeductQuote.GetRoute()
+	s.Require().Len(perHopRoutes, 1)
+	s.Require().Len(inputDeductRoutes, 1)
+
+	perHopPools := perHopRoutes[0].GetPools()
+	inputDeductPools := inputDeductRoutes[0].GetPools()
+	s.Require().Greater(len(perHopPools), 1)
+	s.Require().Len(inputDeductPools, len(perHopPools))
+
+	// Input deduct charges the compounded taker fee of the route on the first pool only.
+	remainingPortion := osmomath.OneDec()
+	for i, pool := range perHopPools {
+		s.Require().Equal(pool.GetId(), inputDeductPools[i].GetId())
+
+		remainingPortion.MulMut(osmomath.OneDec().Sub(pool.GetTakerFee()))
+
+		if i > 0 {
+			s.Require().True(inputDeductPools[i].GetTakerFee().IsZero())
+		}
+	}
+	s.Require().Equal(osmomath.OneDec().Sub(remainingPortion), inputDeductPools[0].GetTakerFee())
+
+	// The same nominal fee is charged, so the amounts out only differ by the price impact.
+	s.Require().False(perHopQuote.GetAmountOut().Equal(inputDeductQuote.GetAmountOut()))
+	s.Require().InEpsilon(perHopQuote.GetAmountOut().Int64(), inputDeductQuote.GetAmountOut().Int64(), 0.01)
+}
+
+// Validates that the alternative quotes returned alongside the optimal quote
+// are distinct single routes ranked in decreasing order of amount out.
+func (s *RouterTestSuite) TestGetOptimalQuote_WithAlternatives_Mainnet() {
+	const maxAlternatives = 2
+
+	var (
+		tokenIn = sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000_000))
+
+		routePoolIDs = func(splitRoute domain.SplitRoute) []uint64 {
+			poolIDs := make([]uint64, 0, len(splitRoute.GetPools()))
+			for _, pool := range splitRoute.GetPools() {
+				poolIDs = append(poolIDs, pool.GetId())
+			}
+			return poolIDs
+		}
+	)
+
+	// Setup mainnet router
+	mainnetState := s.SetupMainnetState()
+	mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	s.Run("no alternatives by default", func() {
+		quote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC)
+		s.Require().NoError(err)
+
+		s.Require().Empty(quote.GetAlternatives())
+	})
+
+	s.Run("alternatives are distinct and ranked", func() {
+		// Disable splits to compare the alternatives against the top single route.
+		singleRouteQuote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC, domain.WithDisableSplitRoutes())
+		s.Require().NoError(err)
+
+		// System under test
+		quote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC, domain.WithAlternatives(maxAlternatives))
+		s.Require().NoError(err)
+
+		alternatives := quote.GetAlternatives()
+		s.Require().NotEmpty(alternatives)
+		s.Require().LessOrEqual(len(alternatives), maxAlternatives)
+
+		// The route of the selected quote is not an alternative unless the split quote is selected.
+		seenRoutes := [][]uint64{}
+		if len(quote.GetRoute()) == 1 {
+			seenRoutes = append(seenRoutes, routePoolIDs(quote.GetRoute()[0]))
+		}
+
+		// No alternative is better than the top single route.
+		previousAmountOut := singleRouteQuote.GetAmountOut()
+
+		for _, alternative := range alternatives {
+			// Each alternative is a single route quote for the same amount in.
+			s.Require().Equal(tokenIn, alternative.GetAmountIn())
+			s.Require().Len(alternative.GetRoute(), 1)
+
+			poolIDs := routePoolIDs(alternative.GetRoute()[0])
+			s.Require().NotContains(seenRoutes, poolIDs)
+			seenRoutes = append(seenRoutes, poolIDs)
+
+			s.Require().True(alternative.GetAmountOut().IsPositive())
+			s.Require().True(alternative.GetAmountOut().LTE(previousAmountOut))
+			previousAmountOut = alternative.GetAmountOut()
+		}
+
+		// The alternatives are prepared alongside the quote.
+		_, _, err = quote.PrepareResult(context.Background(), osmomath.OneDec(), &log.NoOpLogger{})
+		s.Require().NoError(err)
+
+		for _, alternative := range quote.GetAlternatives() {
+			s.Require().True(alternative.GetRouteComplexity().HopCount > 0)
+		}
+	})
+
+	s.Run("alternatives for exact amount out", func() {
+		// System under test
+		quote, err := mainnetUseCase.Router.GetOptimalQuoteInGivenOut(context.Background(), tokenIn, USDC, domain.WithAlternatives(maxAlternatives))
+		s.Require().NoError(err)
+
+		s.Require().NotEmpty(quote.GetAlternatives())
+		for _, alternative := range quote.GetAlternatives() {
+			_, ok := alternative.(*usecase.QuoteExactAmountOut)
+			s.Require().True(ok)
+		}
+
+		_, _, err = quote.PrepareResult(context.Background(), osmomath.OneDec(), &log.NoOpLogger{})
+		s.Require().NoError(err)
+	})
+}
+
 // Validates that quotes constructed from mainnet state can be computed with no error
 // for selected pairs.
 func (s *RouterTestSuite) TestGetOptimalQuoteExactAmounIn_Mainnet() {
@@ -842,7 +1310,7 @@ func (s *RouterTestSuite) TestEstimateAndRankSingleRouteQuote() {
 
 	// Token in amount that is used as input to all tests
 	tokenInAmount := osmomath.NewInt(5000000)
-	tokenInOrderOfMagnitude := routerusecase.GetPrecomputeOrderOfMagnitude(tokenInAmount)
+	tokenInOrderOfMagnitude := domain.GetPrecomputeOrderOfMagnitude(tokenInAmount)
 	defaultTokenIn := sdk.NewCoin(UOSMO, tokenInAmount)
 	tokenOutDenom := UION
 
@@ -1049,3 +1517,356 @@ func (s *RouterTestSuite) validateExpectedPoolIDsMultiHopRoute(actualPools []dom
 
 	s.Require().Equal(expectedPoolID, pools)
 }
+
+// This test validates that the split considered metric is incremented with the chosen label
+// reflecting whether the split quote was chosen over the single route quote.
+func (s *RouterTestSuite) TestGetOptimalQuote_SplitConsideredMetric_Mainnet() {
+	// Setup mainnet router
+	mainnetState := s.SetupMainnetState()
+	mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	testCases := []struct {
+		name string
+
+		tokenIn sdk.Coin
+
+		expectedChosenLabel string
+	}{
+		{
+			name:    "small amount - single route chosen",
+			tokenIn: sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000)),
+
+			expectedChosenLabel: "false",
+		},
+		{
+			name:    "large amount - split chosen",
+			tokenIn: sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000_000_000)),
+
+			expectedChosenLabel: "true",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			chosenCounter := domain.SQSSplitConsideredTotal.WithLabelValues(tc.expectedChosenLabel)
+			initialCount := testutil.ToFloat64(chosenCounter)
+
+			// System under test
+			quote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tc.tokenIn, USDC, domain.WithDisableCache())
+			s.Require().NoError(err)
+
+			if tc.expectedChosenLabel == "true" {
+				s.Require().Greater(len(quote.GetRoute()), 1)
+			} else {
+				s.Require().Len(quote.GetRoute(), 1)
+			}
+
+			s.Require().Equal(initialCount+1, testutil.ToFloat64(chosenCounter))
+		})
+	}
+}
+
+// This test validates that WithSingleRouteOnly forces a single route quote
+// for an amount that would otherwise be split across multiple routes.
+func (s *RouterTestSuite) TestGetOptimalQuote_WithSingleRouteOnly_Mainnet() {
+	// Large enough amount for the split to be chosen by default.
+	tokenIn := sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000_000_000))
+
+	// Setup mainnet router
+	mainnetState := s.SetupMainnetState()
+	mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	splitQuote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC, domain.WithDisableCache())
+	s.Require().NoError(err)
+	s.Require().Greater(len(splitQuote.GetRoute()), 1)
+
+	// System under test
+	singleRouteQuote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC, domain.WithDisableCache(), domain.WithSingleRouteOnly())
+	s.Require().NoError(err)
+
+	s.Require().Len(singleRouteQuote.GetRoute(), 1)
+	s.Require().Equal(tokenIn, singleRouteQuote.GetAmountIn())
+
+	// The split was chosen because it yields more than the best single route.
+	s.Require().True(splitQuote.GetAmountOut().GT(singleRouteQuote.GetAmountOut()))
+}
+
+// This test validates that the reference price supplied via WithReferencePrice is threaded
+// to the quote so that the reference price impact is computed when preparing the result.
+func (s *RouterTestSuite) TestGetOptimalQuote_WithReferencePrice_Mainnet() {
+	tokenIn := sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000_000))
+
+	// Setup mainnet router
+	mainnetState := s.SetupMainnetState()
+	mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	quote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC, domain.WithDisableCache())
+	s.Require().NoError(err)
+
+	_, _, err = quote.PrepareResult(context.Background(), osmomath.OneDec(), &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	s.Require().True(quote.GetReferencePriceImpact().IsNil())
+
+	// Use the internal spot price as the reference price.
+	referencePrice := osmomath.BigDecFromDec(quote.GetInBaseOutQuoteSpotPrice())
+
+	// System under test
+	referenceQuote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC, domain.WithDisableCache(), domain.WithReferencePrice(referencePrice))
+	s.Require().NoError(err)
+
+	_, _, err = referenceQuote.PrepareResult(context.Background(), osmomath.OneDec(), &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	// The reference price impact matches the internal one given the same price.
+	s.Require().False(referenceQuote.GetReferencePriceImpact().IsNil())
+	s.Require().Equal(referenceQuote.GetPriceImpact(), referenceQuote.GetReferencePriceImpact())
+}
+
+// This test validates that the quote expiry reflects the configured route cache TTL
+// and that quotes over cached routes expire with the cache entry.
+func (s *RouterTestSuite) TestGetOptimalQuote_ExpiresAt_Mainnet() {
+	const rankedRouteCacheExpirySeconds = 30
+
+	tokenIn := sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000_000))
+
+	routerConfig := routertesting.DefaultRouterConfig
+	routerConfig.CandidateRouteCacheExpirySeconds = 10 * rankedRouteCacheExpirySeconds
+	routerConfig.RankedRouteCacheExpirySeconds = rankedRouteCacheExpirySeconds
+
+	// Setup mainnet router
+	mainnetState := s.SetupMainnetState()
+	mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState, routertesting.WithRouterConfig(routerConfig))
+
+	expectedTTL := time.Duration(rankedRouteCacheExpirySeconds) * time.Second
+
+	before := time.Now()
+
+	// System under test
+	quote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC)
+	s.Require().NoError(err)
+
+	after := time.Now()
+
+	expiresAt := quote.GetExpiresAt()
+	s.Require().False(expiresAt.Before(before.Add(expectedTTL)))
+	s.Require().False(expiresAt.After(after.Add(expectedTTL)))
+
+	routerUseCase, ok := mainnetUseCase.Router.(*usecase.RouterUseCaseImpl)
+	s.Require().True(ok)
+
+	cacheExpiresAt := routerUseCase.GetRankedRouteCacheExpiresAt(UOSMO, USDC, domain.GetPrecomputeOrderOfMagnitude(tokenIn.Amount))
+	s.Require().False(cacheExpiresAt.IsZero())
+
+	// The routes are now read from cache so the quote expires with the cache entry
+	// rather than a full TTL from now.
+	time.Sleep(10 * time.Millisecond)
+
+	quote, err = mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC)
+	s.Require().NoError(err)
+
+	s.Require().Equal(cacheExpiresAt, quote.GetExpiresAt())
+
+	// No expiry if the quote is produced with the route cache disabled.
+	quote, err = mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC, domain.WithDisableCache())
+	s.Require().NoError(err)
+
+	s.Require().True(quote.GetExpiresAt().IsZero())
+}
+
+// This test validates that the routes filtered out when computing the optimal quote
+// are only returned with diagnostics enabled.
+func (s *RouterTestSuite) TestGetOptimalQuote_Diagnostics_Mainnet() {
+	tokenIn := sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000_000))
+
+	// Setup mainnet router
+	mainnetState := s.SetupMainnetState()
+	mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	// No filtered routes without diagnostics.
+	quote, err := mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC, domain.WithDisableCache())
+	s.Require().NoError(err)
+	s.Require().Empty(quote.GetFilteredRoutes())
+
+	// System under test
+	quote, err = mainnetUseCase.Router.GetOptimalQuote(context.Background(), tokenIn, USDC, domain.WithDisableCache(), domain.WithDiagnostics(), domain.WithMaxSplitRoutes(1))
+	s.Require().NoError(err)
+
+	filteredRoutes := quote.GetFilteredRoutes()
+	s.Require().NotEmpty(filteredRoutes)
+	for _, filteredRoute := range filteredRoutes {
+		s.Require().NotEmpty(filteredRoute.PoolIDs)
+		s.Require().Contains([]domain.FilteredRouteReason{
+			domain.FilteredRouteReasonDuplicatePoolID,
+			domain.FilteredRouteReasonGeneralizedCosmWasmPool,
+			domain.FilteredRouteReasonMaxSplitRoutes,
+		}, filteredRoute.Reason)
+	}
+
+	// The routes are cut to the top one for max split routes of one.
+	s.Require().Equal(domain.FilteredRouteReasonMaxSplitRoutes, filteredRoutes[len(filteredRoutes)-1].Reason)
+}
+
+// Tests that the quote expiry is bounded by the shortest of the configured route cache TTLs.
+func (s *RouterTestSuite) TestComputeQuoteExpiresAt() {
+	now := time.Unix(1_000_000, 0)
+
+	tests := []struct {
+		name    string
+		options domain.RouterOptions
+
+		expectedExpiresAt time.Time
+	}{
+		{
+			name: "ranked route cache TTL is shorter",
+			options: domain.RouterOptions{
+				CandidateRouteCacheExpirySeconds: 600,
+				RankedRouteCacheExpirySeconds:    300,
+			},
+
+			expectedExpiresAt: now.Add(300 * time.Second),
+		},
+		{
+			name: "candidate route cache TTL is shorter",
+			options: domain.RouterOptions{
+				CandidateRouteCacheExpirySeconds: 60,
+				RankedRouteCacheExpirySeconds:    300,
+			},
+
+			expectedExpiresAt: now.Add(60 * time.Second),
+		},
+		{
+			name: "only candidate route cache TTL is set",
+			options: domain.RouterOptions{
+				CandidateRouteCacheExpirySeconds: 60,
+			},
+
+			expectedExpiresAt: now.Add(60 * time.Second),
+		},
+		{
+			name: "only ranked route cache TTL is set",
+			options: domain.RouterOptions{
+				RankedRouteCacheExpirySeconds: 300,
+			},
+
+			expectedExpiresAt: now.Add(300 * time.Second),
+		},
+		{
+			name:    "no TTL is set",
+			options: domain.RouterOptions{},
+		},
+		{
+			name: "cache disabled",
+			options: domain.RouterOptions{
+				CandidateRouteCacheExpirySeconds: 600,
+				RankedRouteCacheExpirySeconds:    300,
+				DisableCache:                     true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			// System under test
+			expiresAt := usecase.ComputeQuoteExpiresAt(now, tc.options)
+
+			s.Require().Equal(tc.expectedExpiresAt, expiresAt)
+		})
+	}
+}
+
+// This test validates that the route through the preferred pools is selected
+// only if its amount out is within the tolerance of the best amount out
+// and that the routes remain sorted by amount out.
+func (s *RouterTestSuite) TestSelectPreferredPoolRoute() {
+	const (
+		preferredPoolID = defaultPoolID + 1
+		// 1%
+		toleranceBps = uint64(100)
+	)
+
+	var (
+		preferredPoolIDs = map[uint64]struct{}{preferredPoolID: {}}
+
+		withAmountOut = func(amountOut int64, poolIDs ...uint64) usecase.RouteWithOutAmount {
+			pools := make([]domain.RoutablePool, 0, len(poolIDs))
+			for _, poolID := range poolIDs {
+				pools = append(pools, &mocks.MockRoutablePool{ID: poolID})
+			}
+
+			return usecase.RouteWithOutAmount{
+				RouteImpl: WithRoutePools(route.RouteImpl{}, pools),
+				OutAmount: osmomath.NewInt(amountOut),
+			}
+		}
+
+		bestRoute            = withAmountOut(1000, defaultPoolID)
+		nearTiePreferred     = withAmountOut(995, defaultPoolID+2, preferredPoolID)
+		nearTieNonPreferred  = withAmountOut(992, defaultPoolID+3)
+		clearLossPreferred   = withAmountOut(900, preferredPoolID)
+		atTolerancePreferred = withAmountOut(990, preferredPoolID)
+	)
+
+	tests := []struct {
+		name string
+
+		routes       []usecase.RouteWithOutAmount
+		toleranceBps uint64
+
+		expectedRoute usecase.RouteWithOutAmount
+	}{
+		{
+			name:   "preferred pool wins near-tie",
+			routes: []usecase.RouteWithOutAmount{bestRoute, nearTiePreferred, nearTieNonPreferred, clearLossPreferred},
+
+			toleranceBps: toleranceBps,
+
+			expectedRoute: nearTiePreferred,
+		},
+		{
+			name:   "preferred pool at the tolerance boundary wins",
+			routes: []usecase.RouteWithOutAmount{bestRoute, atTolerancePreferred},
+
+			toleranceBps: toleranceBps,
+
+			expectedRoute: atTolerancePreferred,
+		},
+		{
+			name:   "preferred pool loses clear tie",
+			routes: []usecase.RouteWithOutAmount{bestRoute, clearLossPreferred},
+
+			toleranceBps: toleranceBps,
+
+			expectedRoute: bestRoute,
+		},
+		{
+			name:   "zero tolerance selects the best route",
+			routes: []usecase.RouteWithOutAmount{bestRoute, nearTiePreferred},
+
+			toleranceBps: 0,
+
+			expectedRoute: bestRoute,
+		},
+		{
+			name:   "best route is preferred",
+			routes: []usecase.RouteWithOutAmount{nearTiePreferred, nearTieNonPreferred},
+
+			toleranceBps: toleranceBps,
+
+			expectedRoute: nearTiePreferred,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			routes := append([]usecase.RouteWithOutAmount{}, tc.routes...)
+
+			actualRoute := usecase.SelectPreferredPoolRoute(routes, preferredPoolIDs, tc.toleranceBps)
+
+			s.Require().Equal(tc.expectedRoute, actualRoute)
+
+			// The routes remain sorted by amount out.
+			s.Require().Equal(tc.routes, routes)
+		})
+	}
+}
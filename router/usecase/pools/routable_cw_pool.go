@@ -2,6 +2,7 @@ package pools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"cosmossdk.io/math"
@@ -37,6 +38,7 @@ type routableCosmWasmPoolImpl struct {
 	SpreadFactor             osmomath.Dec                    "json:\"spread_factor\""
 	wasmClient               wasmtypes.QueryClient           "json:\"-\""
 	spotPriceQuoteCalculator domain.SpotPriceQuoteCalculator "json:\"-\""
+	circuitBreaker           *cosmwasmdomain.CircuitBreaker  "json:\"-\""
 }
 
 // NewRoutableCosmWasmPool returns a new routable cosmwasm pool with the given parameters.
@@ -50,6 +52,8 @@ func NewRoutableCosmWasmPool(pool *cwpoolmodel.CosmWasmPool, balances sdk.Coins,
 		SpreadFactor:  spreadFactor,
 		wasmClient:    cosmWasmPoolsParams.WasmClient,
 
+		circuitBreaker: cosmWasmPoolsParams.CircuitBreaker,
+
 		// Note, that there is no calculator set
 		// since we need to wire quote calculation callback to it.
 		spotPriceQuoteCalculator: nil,
@@ -106,16 +110,38 @@ func (r *routableCosmWasmPoolImpl) calculateTokenOutByTokenIn(ctx context.Contex
 	// Configure the calc query message
 	calcMessage := msg.NewCalcOutAmtGivenInRequest(tokenIn, tokenOutDenom, r.SpreadFactor)
 
+	if r.circuitBreaker != nil && !r.circuitBreaker.AllowRequest(r.GetId()) {
+		return sdk.Coin{}, domain.CosmWasmPoolCircuitOpenError{PoolId: r.GetId()}
+	}
+
 	calcOutAmtGivenInResponse := msg.CalcOutAmtGivenInResponse{}
 	if err := cosmwasmdomain.QueryCosmwasmContract(ctx, r.wasmClient, r.ChainPool.ContractAddress, &calcMessage, &calcOutAmtGivenInResponse); err != nil {
+		if r.circuitBreaker != nil {
+			// Cancelled requests do not reflect the health of the pool.
+			// On the contrary, timeouts are counted as failures.
+			if isContextCanceled(ctx, err) {
+				r.circuitBreaker.ReleaseProbe(r.GetId())
+			} else {
+				r.circuitBreaker.RecordFailure(r.GetId())
+			}
+		}
 		return sdk.Coin{}, err
 	}
 
+	if r.circuitBreaker != nil {
+		r.circuitBreaker.RecordSuccess(r.GetId())
+	}
+
 	// No slippage swaps - just return the same amount of token out as token in
 	// as long as there is enough liquidity in the pool.
 	return calcOutAmtGivenInResponse.TokenOut, nil
 }
 
+// isContextCanceled returns true if the given error is caused by the request context being cancelled.
+func isContextCanceled(ctx context.Context, err error) bool {
+	return errors.Is(ctx.Err(), context.Canceled) || errors.Is(err, context.Canceled)
+}
+
 // SetTokenInDenom implements domain.RoutablePool.
 func (r *routableCosmWasmPoolImpl) SetTokenInDenom(tokenInDenom string) {
 	r.TokenInDenom = tokenInDenom
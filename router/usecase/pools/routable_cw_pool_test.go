@@ -0,0 +1,94 @@
+package pools_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	cwpoolmodel "github.com/osmosis-labs/osmosis/v26/x/cosmwasmpool/model"
+
+	cosmwasmdomain "github.com/osmosis-labs/sqs/domain/cosmwasm"
+	"github.com/osmosis-labs/sqs/router/usecase/pools"
+)
+
+// failingWasmClient is a wasm query client whose smart contract queries fail with the given error.
+type failingWasmClient struct {
+	wasmtypes.QueryClient
+
+	err error
+}
+
+func (c failingWasmClient) SmartContractState(ctx context.Context, in *wasmtypes.QuerySmartContractStateRequest, opts ...grpc.CallOption) (*wasmtypes.QuerySmartContractStateResponse, error) {
+	return nil, c.err
+}
+
+// Tests that only the failed network calls that are not caused by the request context
+// being cancelled are recorded by the circuit breaker.
+func TestCalculateTokenOutByTokenIn_CosmWasm_CircuitBreaker(t *testing.T) {
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+
+		ctx context.Context
+		err error
+
+		expectedConsecutiveFailures int
+	}{
+		{
+			name: "network failure is recorded",
+			ctx:  context.Background(),
+			err:  errors.New("connection refused"),
+
+			expectedConsecutiveFailures: 1,
+		},
+		{
+			name: "cancelled context is not recorded",
+			ctx:  cancelledCtx,
+			err:  errors.New("rpc error: code = Canceled desc = context canceled"),
+		},
+		{
+			name: "deadline exceeded error is recorded",
+			ctx:  context.Background(),
+			err:  context.DeadlineExceeded,
+
+			expectedConsecutiveFailures: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			circuitBreaker := cosmwasmdomain.NewCircuitBreaker(1, time.Minute)
+
+			routablePool := pools.NewRoutableCosmWasmPool(&cwpoolmodel.CosmWasmPool{
+				PoolId:          defaultPoolID,
+				ContractAddress: "contract-address",
+			}, sdk.NewCoins(), USDC, osmomath.ZeroDec(), osmomath.ZeroDec(), cosmwasmdomain.CosmWasmPoolsParams{
+				WasmClient:     failingWasmClient{err: tc.err},
+				CircuitBreaker: circuitBreaker,
+			})
+
+			// System under test
+			_, err := routablePool.CalculateTokenOutByTokenIn(tc.ctx, sdk.NewCoin(ETH, osmomath.NewInt(100)))
+			require.Error(t, err)
+
+			statuses := circuitBreaker.GetPoolStatuses()
+			if tc.expectedConsecutiveFailures == 0 {
+				require.Empty(t, statuses)
+				return
+			}
+
+			require.Len(t, statuses, 1)
+			require.Equal(t, tc.expectedConsecutiveFailures, statuses[0].ConsecutiveFailures)
+		})
+	}
+}
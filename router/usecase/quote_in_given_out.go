@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/log"
@@ -20,12 +22,19 @@ var (
 // Note that only the PrepareResult method is different from the quoteExactAmountIn.
 type quoteExactAmountOut struct {
 	*quoteExactAmountIn     "json:\"-\""
-	AmountIn                osmomath.Int        "json:\"amount_in\""
-	AmountOut               sdk.Coin            "json:\"amount_out\""
-	Route                   []domain.SplitRoute "json:\"route\""
-	EffectiveFee            osmomath.Dec        "json:\"effective_fee\""
-	PriceImpact             osmomath.Dec        "json:\"price_impact\""
-	InBaseOutQuoteSpotPrice osmomath.Dec        "json:\"in_base_out_quote_spot_price\""
+	AmountIn                osmomath.Int           "json:\"amount_in\""
+	AmountOut               sdk.Coin               "json:\"amount_out\""
+	Route                   []domain.SplitRoute    "json:\"route\""
+	EffectiveFee            osmomath.Dec           "json:\"effective_fee\""
+	PriceImpact             osmomath.Dec           "json:\"price_impact\""
+	InBaseOutQuoteSpotPrice osmomath.Dec           "json:\"in_base_out_quote_spot_price\""
+	EffectiveRate           osmomath.Dec           "json:\"effective_rate\""
+	RouteComplexity         domain.RouteComplexity "json:\"route_complexity\""
+	Alternatives            []domain.Quote         "json:\"alternatives,omitempty\""
+	FilteredRoutes          []domain.FilteredRoute "json:\"filtered_routes,omitempty\""
+	ReferencePriceImpact    *osmomath.Dec          "json:\"reference_price_impact,omitempty\""
+	ExpiresAt               *time.Time             "json:\"expires_at,omitempty\""
+	Truncated               bool                   "json:\"truncated,omitempty\""
 }
 
 // PrepareResult implements domain.Quote.
@@ -38,7 +47,7 @@ type quoteExactAmountOut struct {
 // Returns the updated route and the effective spread factor.
 func (q *quoteExactAmountOut) PrepareResult(ctx context.Context, scalingFactor osmomath.Dec, logger log.Logger) ([]domain.SplitRoute, osmomath.Dec, error) {
 	// Prepare exact out in the quote for inputs inversion
-	if _, _, err := q.quoteExactAmountIn.PrepareResult(ctx, scalingFactor, logger); err != nil {
+	if _, _, err := q.quoteExactAmountIn.prepareResult(ctx, scalingFactor, logger, true); err != nil {
 		return nil, osmomath.Dec{}, err
 	}
 
@@ -49,6 +58,14 @@ func (q *quoteExactAmountOut) PrepareResult(ctx context.Context, scalingFactor o
 	q.EffectiveFee = q.quoteExactAmountIn.EffectiveFee
 	q.PriceImpact = q.quoteExactAmountIn.PriceImpact
 	q.InBaseOutQuoteSpotPrice = q.quoteExactAmountIn.InBaseOutQuoteSpotPrice
+	q.EffectiveRate = q.quoteExactAmountIn.EffectiveRate
+	q.RouteComplexity = q.quoteExactAmountIn.RouteComplexity
+	q.Alternatives = q.quoteExactAmountIn.Alternatives
+	q.FilteredRoutes = q.quoteExactAmountIn.FilteredRoutes
+	q.ReferencePriceImpact = q.quoteExactAmountIn.ReferencePriceImpact
+	q.ExpiresAt = q.quoteExactAmountIn.ExpiresAt
+
+	totalAmountIn := osmomath.ZeroInt()
 
 	for i, route := range q.Route {
 		route, ok := route.(*RouteWithOutAmount)
@@ -59,6 +76,12 @@ func (q *quoteExactAmountOut) PrepareResult(ctx context.Context, scalingFactor o
 		// invert the in and out amounts
 		route.InAmount, route.OutAmount = route.OutAmount, route.InAmount
 
+		// the intermediate amounts are computed from the token out,
+		// reverse them to be ordered from the token in.
+		slices.Reverse(route.IntermediateAmounts)
+
+		totalAmountIn = totalAmountIn.Add(route.InAmount)
+
 		q.Route[i] = route
 
 		// invert the in and out amounts for each pool
@@ -68,5 +91,23 @@ func (q *quoteExactAmountOut) PrepareResult(ctx context.Context, scalingFactor o
 		}
 	}
 
+	// recompute the portions relative to the inverted in amounts
+	for _, route := range q.Route {
+		route := route.(*RouteWithOutAmount)
+
+		route.InPortion = osmomath.ZeroDec()
+		if !totalAmountIn.IsZero() {
+			route.InPortion = route.InAmount.ToLegacyDec().Quo(totalAmountIn.ToLegacyDec())
+		}
+	}
+
 	return q.Route, q.EffectiveFee, nil
 }
+
+// TruncateRoutes implements domain.Quote.
+func (q *quoteExactAmountOut) TruncateRoutes(maxRoutes, maxPools int) {
+	q.quoteExactAmountIn.TruncateRoutes(maxRoutes, maxPools)
+
+	q.Route = q.quoteExactAmountIn.Route
+	q.Truncated = q.quoteExactAmountIn.Truncated
+}
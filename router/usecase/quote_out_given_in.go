@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/log"
@@ -36,12 +37,27 @@ func NewQuoteExactAmountOut(q *QuoteExactAmountIn) *quoteExactAmountOut {
 
 // quoteExactAmountIn is a quote implementation for token swap method exact in.
 type quoteExactAmountIn struct {
-	AmountIn                sdk.Coin            "json:\"amount_in\""
-	AmountOut               osmomath.Int        "json:\"amount_out\""
-	Route                   []domain.SplitRoute "json:\"route\""
-	EffectiveFee            osmomath.Dec        "json:\"effective_fee\""
-	PriceImpact             osmomath.Dec        "json:\"price_impact\""
-	InBaseOutQuoteSpotPrice osmomath.Dec        "json:\"in_base_out_quote_spot_price\""
+	AmountIn                sdk.Coin               "json:\"amount_in\""
+	AmountOut               osmomath.Int           "json:\"amount_out\""
+	Route                   []domain.SplitRoute    "json:\"route\""
+	EffectiveFee            osmomath.Dec           "json:\"effective_fee\""
+	PriceImpact             osmomath.Dec           "json:\"price_impact\""
+	InBaseOutQuoteSpotPrice osmomath.Dec           "json:\"in_base_out_quote_spot_price\""
+	EffectiveRate           osmomath.Dec           "json:\"effective_rate\""
+	RouteComplexity         domain.RouteComplexity "json:\"route_complexity\""
+	Alternatives            []domain.Quote         "json:\"alternatives,omitempty\""
+	FilteredRoutes          []domain.FilteredRoute "json:\"filtered_routes,omitempty\""
+	// ReferencePriceImpact is nil unless a reference price is set.
+	ReferencePriceImpact *osmomath.Dec "json:\"reference_price_impact,omitempty\""
+	// ExpiresAt is nil unless the quote was produced with the route cache enabled.
+	ExpiresAt *time.Time "json:\"expires_at,omitempty\""
+	// Truncated is true if routes were dropped to clamp the response size.
+	Truncated bool "json:\"truncated,omitempty\""
+
+	// roundingMode defines how the fractional amounts are rounded when preparing the result.
+	roundingMode domain.RoundingMode
+	// referencePrice is the external price that the reference price impact is computed against.
+	referencePrice osmomath.BigDec
 }
 
 // PrepareResult implements domain.Quote.
@@ -50,9 +66,24 @@ type quoteExactAmountIn struct {
 // Specifically:
 // It strips away unnecessary fields from each pool in the route.
 // Computes an effective spread factor from all routes.
+// Computes the route complexity from all routes.
+// Computes the effective rate of amount out per amount in.
+// Computes the portion of the total amount in swapped over each route.
+// Rounds the amounts in and out of each route and the total amount out according to the rounding mode.
+// Prepares the alternative quotes, if any.
 //
 // Returns the updated route and the effective spread factor.
 func (q *quoteExactAmountIn) PrepareResult(ctx context.Context, scalingFactor osmomath.Dec, logger log.Logger) ([]domain.SplitRoute, osmomath.Dec, error) {
+	return q.prepareResult(ctx, scalingFactor, logger, false)
+}
+
+// prepareResult prepares the quote result as described in PrepareResult.
+// isExactOut determines the default rounding of the fractional amounts, see domain.RoundingModeDefault.
+func (q *quoteExactAmountIn) prepareResult(ctx context.Context, scalingFactor osmomath.Dec, logger log.Logger, isExactOut bool) ([]domain.SplitRoute, osmomath.Dec, error) {
+	// Note: computed before the pools are converted into result pools
+	// that do not retain the SQS pool type.
+	q.RouteComplexity = computeRouteComplexity(q.Route)
+
 	totalAmountIn := q.AmountIn.Amount.ToLegacyDec()
 	totalFeeAcrossRoutes := osmomath.ZeroDec()
 
@@ -61,6 +92,15 @@ func (q *quoteExactAmountIn) PrepareResult(ctx context.Context, scalingFactor os
 
 	resultRoutes := make([]domain.SplitRoute, 0, len(q.Route))
 
+	// The route amounts are reported as the shares of the quote amounts
+	// proportional to the route amounts, rounded according to the rounding mode.
+	totalRouteAmountIn, totalRouteAmountOut := osmomath.ZeroInt(), osmomath.ZeroInt()
+	for _, curRoute := range q.Route {
+		totalRouteAmountIn = totalRouteAmountIn.Add(curRoute.GetAmountIn())
+		totalRouteAmountOut = totalRouteAmountOut.Add(curRoute.GetAmountOut())
+	}
+	roundedAmountOut := osmomath.ZeroInt()
+
 	for _, curRoute := range q.Route {
 		routeTotalFee := osmomath.ZeroDec()
 		routeAmountInFraction := curRoute.GetAmountIn().ToLegacyDec().Quo(totalAmountIn)
@@ -78,8 +118,9 @@ func (q *quoteExactAmountIn) PrepareResult(ctx context.Context, scalingFactor os
 		// Update the spread factor pro-rated by the amount in
 		totalFeeAcrossRoutes.AddMut(routeTotalFee.MulMut(routeAmountInFraction))
 
+		// Note: the amount in used for the price impact is always truncated, regardless of the rounding mode.
 		amountInFraction := q.AmountIn.Amount.ToLegacyDec().MulMut(routeAmountInFraction).TruncateInt()
-		newPools, routeSpotPriceInBaseOutQuote, effectiveSpotPriceInBaseOutQuote, err := curRoute.PrepareResultPools(ctx, sdk.NewCoin(q.AmountIn.Denom, amountInFraction), logger)
+		newPools, routeSpotPriceInBaseOutQuote, effectiveSpotPriceInBaseOutQuote, intermediateAmounts, err := curRoute.PrepareResultPools(ctx, sdk.NewCoin(q.AmountIn.Denom, amountInFraction), logger)
 		if err != nil {
 			return nil, osmomath.Dec{}, err
 		}
@@ -87,13 +128,18 @@ func (q *quoteExactAmountIn) PrepareResult(ctx context.Context, scalingFactor os
 		totalSpotPriceInBaseOutQuote = totalSpotPriceInBaseOutQuote.AddMut(routeSpotPriceInBaseOutQuote.MulMut(routeAmountInFraction))
 		totalEffectiveSpotPriceInBaseOutQuote = totalEffectiveSpotPriceInBaseOutQuote.AddMut(effectiveSpotPriceInBaseOutQuote.MulMut(routeAmountInFraction))
 
+		routeAmountOut := q.roundingMode.Round(computeAmountShare(q.AmountOut, curRoute.GetAmountOut(), totalRouteAmountOut), isExactOut)
+		roundedAmountOut = roundedAmountOut.Add(routeAmountOut)
+
 		resultRoutes = append(resultRoutes, &RouteWithOutAmount{
 			RouteImpl: route.RouteImpl{
 				Pools:                      newPools,
 				HasGeneralizedCosmWasmPool: curRoute.ContainsGeneralizedCosmWasmPool(),
 			},
-			InAmount:  curRoute.GetAmountIn(),
-			OutAmount: curRoute.GetAmountOut(),
+			InAmount:            q.roundingMode.Round(computeAmountShare(q.AmountIn.Amount, curRoute.GetAmountIn(), totalRouteAmountIn), isExactOut),
+			OutAmount:           routeAmountOut,
+			InPortion:           routeAmountInFraction,
+			IntermediateAmounts: intermediateAmounts,
 		})
 	}
 
@@ -102,13 +148,77 @@ func (q *quoteExactAmountIn) PrepareResult(ctx context.Context, scalingFactor os
 		q.PriceImpact = totalEffectiveSpotPriceInBaseOutQuote.Quo(totalSpotPriceInBaseOutQuote).SubMut(one)
 	}
 
+	// Calculate price impact relative to the reference price
+	if !q.referencePrice.IsNil() && q.referencePrice.IsPositive() {
+		referencePriceImpact := osmomath.BigDecFromDec(totalEffectiveSpotPriceInBaseOutQuote).QuoMut(q.referencePrice).Dec().SubMut(one)
+		q.ReferencePriceImpact = &referencePriceImpact
+	}
+
 	q.EffectiveFee = totalFeeAcrossRoutes
 	q.Route = resultRoutes
+	if len(resultRoutes) > 0 {
+		q.AmountOut = roundedAmountOut
+	}
 	q.InBaseOutQuoteSpotPrice = totalSpotPriceInBaseOutQuote
 
+	// Note: for exact out, the amounts of the underlying quote are inverted.
+	amountIn, amountOut := q.AmountIn.Amount, q.AmountOut
+	if isExactOut {
+		amountIn, amountOut = amountOut, amountIn
+	}
+	q.EffectiveRate = computeEffectiveRate(amountIn, amountOut, scalingFactor, isExactOut)
+
+	// Prepare the alternative quotes for output as well.
+	for _, alternative := range q.Alternatives {
+		if _, _, err := alternative.PrepareResult(ctx, scalingFactor, logger); err != nil {
+			return nil, osmomath.Dec{}, err
+		}
+	}
+
 	return q.Route, q.EffectiveFee, nil
 }
 
+// computeAmountShare returns the share of the total amount proportional to the part out of the sum.
+// Returns the part if the sum is zero.
+func computeAmountShare(totalAmount, part, sum osmomath.Int) osmomath.Dec {
+	if sum.IsZero() {
+		return part.ToLegacyDec()
+	}
+	return totalAmount.Mul(part).ToLegacyDec().QuoMut(sum.ToLegacyDec())
+}
+
+// SetRoundingMode sets the rounding mode of the fractional amounts of the quote
+// and its alternatives, applied when preparing the result.
+func (q *quoteExactAmountIn) SetRoundingMode(mode domain.RoundingMode) {
+	q.roundingMode = mode
+	for _, alternative := range q.Alternatives {
+		if alternative, ok := alternative.(*quoteExactAmountIn); ok {
+			alternative.roundingMode = mode
+		}
+	}
+}
+
+// SetReferencePrice sets the reference price of the quote and its alternatives
+// that the reference price impact is computed against when preparing the result.
+func (q *quoteExactAmountIn) SetReferencePrice(price osmomath.BigDec) {
+	q.referencePrice = price
+	for _, alternative := range q.Alternatives {
+		if alternative, ok := alternative.(*quoteExactAmountIn); ok {
+			alternative.referencePrice = price
+		}
+	}
+}
+
+// SetExpiresAt sets the time until which the quote and its alternatives are considered fresh.
+func (q *quoteExactAmountIn) SetExpiresAt(expiresAt time.Time) {
+	q.ExpiresAt = &expiresAt
+	for _, alternative := range q.Alternatives {
+		if alternative, ok := alternative.(*quoteExactAmountIn); ok {
+			alternative.ExpiresAt = &expiresAt
+		}
+	}
+}
+
 // GetAmountIn implements Quote.
 func (q *quoteExactAmountIn) GetAmountIn() sdk.Coin {
 	return q.AmountIn
@@ -151,3 +261,131 @@ func (q *quoteExactAmountIn) GetPriceImpact() osmomath.Dec {
 func (q *quoteExactAmountIn) GetInBaseOutQuoteSpotPrice() osmomath.Dec {
 	return q.InBaseOutQuoteSpotPrice
 }
+
+// GetEffectiveRate implements domain.Quote.
+func (q *quoteExactAmountIn) GetEffectiveRate() osmomath.Dec {
+	return q.EffectiveRate
+}
+
+// GetReferencePriceImpact implements domain.Quote.
+func (q *quoteExactAmountIn) GetReferencePriceImpact() osmomath.Dec {
+	if q.ReferencePriceImpact == nil {
+		return osmomath.Dec{}
+	}
+	return *q.ReferencePriceImpact
+}
+
+// GetExpiresAt implements domain.Quote.
+func (q *quoteExactAmountIn) GetExpiresAt() time.Time {
+	if q.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return *q.ExpiresAt
+}
+
+// GetRouteComplexity implements domain.Quote.
+func (q *quoteExactAmountIn) GetRouteComplexity() domain.RouteComplexity {
+	return q.RouteComplexity
+}
+
+// GetAlternatives implements domain.Quote.
+func (q *quoteExactAmountIn) GetAlternatives() []domain.Quote {
+	return q.Alternatives
+}
+
+// TruncateRoutes implements domain.Quote.
+func (q *quoteExactAmountIn) TruncateRoutes(maxRoutes, maxPools int) {
+	var isTruncated bool
+	q.Route, isTruncated = truncateRoutes(q.Route, maxRoutes, maxPools)
+	q.Truncated = q.Truncated || isTruncated
+}
+
+// IsTruncated implements domain.Quote.
+func (q *quoteExactAmountIn) IsTruncated() bool {
+	return q.Truncated
+}
+
+// truncateRoutes returns the leading routes such that there are at most maxRoutes routes
+// and maxPools pools across them. Routes are never partially truncated.
+// A non-positive limit disables the corresponding clamp.
+// Returns true as the second value if any route was dropped.
+func truncateRoutes(routes []domain.SplitRoute, maxRoutes, maxPools int) ([]domain.SplitRoute, bool) {
+	numRoutes := len(routes)
+	if maxRoutes > 0 && numRoutes > maxRoutes {
+		numRoutes = maxRoutes
+	}
+
+	if maxPools > 0 {
+		totalPools := 0
+		for i := 0; i < numRoutes; i++ {
+			totalPools += len(routes[i].GetPools())
+			if totalPools > maxPools {
+				numRoutes = i
+				break
+			}
+		}
+	}
+
+	if numRoutes == len(routes) {
+		return routes, false
+	}
+
+	return routes[:numRoutes], true
+}
+
+// computeEffectiveRate returns the amount out per amount in converted to human precision
+// with the given spot price scaling factor. The scaling factor converts from human to chain
+// precision so it is divided by. For exact out, the scaling factor is computed with the
+// denoms inverted so it is multiplied by instead.
+// Returns zero if the amount in is zero or the scaling factor is unset or zero, invalidating the rate
+// similarly to the spot price.
+func computeEffectiveRate(amountIn, amountOut osmomath.Int, scalingFactor osmomath.Dec, isExactOut bool) osmomath.Dec {
+	if amountIn.IsNil() || amountIn.IsZero() || scalingFactor.IsNil() || scalingFactor.IsZero() {
+		return osmomath.ZeroDec()
+	}
+
+	effectiveRate := osmomath.BigDecFromSDKInt(amountOut).QuoMut(osmomath.BigDecFromSDKInt(amountIn))
+	if isExactOut {
+		return effectiveRate.MulMut(osmomath.BigDecFromDec(scalingFactor)).Dec()
+	}
+
+	return effectiveRate.QuoMut(osmomath.BigDecFromDec(scalingFactor)).Dec()
+}
+
+// GetFilteredRoutes implements domain.Quote.
+func (q *quoteExactAmountIn) GetFilteredRoutes() []domain.FilteredRoute {
+	return q.FilteredRoutes
+}
+
+// computeRouteComplexity computes the complexity of executing the given split routes.
+func computeRouteComplexity(routes []domain.SplitRoute) domain.RouteComplexity {
+	var complexity domain.RouteComplexity
+
+	for _, curRoute := range routes {
+		pools := curRoute.GetPools()
+
+		complexity.HopCount += len(pools)
+
+		containsGeneralizedCosmWasmPool := curRoute.ContainsGeneralizedCosmWasmPool()
+
+		for _, pool := range pools {
+			switch pool.GetSQSType() {
+			case domain.Concentrated:
+				complexity.ConcentratedPoolCount++
+			case domain.GeneralizedCosmWasm:
+				// Only routes flagged as containing a generalized CosmWasm pool
+				// are eligible.
+				if containsGeneralizedCosmWasmPool {
+					complexity.GeneralizedCosmWasmPoolCount++
+				}
+			}
+		}
+	}
+
+	return complexity
+}
+
+// GetRouteTree implements domain.Quote.
+func (q *quoteExactAmountIn) GetRouteTree() []*domain.RouteTreeNode {
+	return MergeCommonPrefixRoutes(q.Route)
+}
@@ -69,9 +69,10 @@ func (s *RouterTestSuite) TestPrepareResult() {
 		name  string
 		quote domain.Quote
 
-		expectedRoutes       []domain.SplitRoute
-		expectedEffectiveFee string
-		expectedJSON         string
+		expectedRoutes          []domain.SplitRoute
+		expectedEffectiveFee    string
+		expectedRouteComplexity domain.RouteComplexity
+		expectedJSON            string
 	}{
 		{
 			name:  "exact amount in",
@@ -124,8 +125,9 @@ func (s *RouterTestSuite) TestPrepareResult() {
 				},
 			},
 			// (0.02 + (1 - 0.02) * 0.0004) * 0.5 + 0.003 * 0.5
-			expectedEffectiveFee: "0.011696000000000000",
-			expectedJSON:         s.MustReadFile("./routertesting/parsing/quote_amount_in_response.json"),
+			expectedEffectiveFee:    "0.011696000000000000",
+			expectedRouteComplexity: domain.RouteComplexity{HopCount: 3},
+			expectedJSON:            s.MustReadFile("./routertesting/parsing/quote_amount_in_response.json"),
 		},
 		{
 			name:  "exact amount out",
@@ -153,8 +155,9 @@ func (s *RouterTestSuite) TestPrepareResult() {
 						},
 					},
 
-					InAmount:  totalOutAmount.QuoRaw(3),
-					OutAmount: totalInAmount.QuoRaw(2),
+					// Rounded up shares of the quote amounts proportional to the route amounts.
+					InAmount:  osmomath.NewInt(25_000_000),
+					OutAmount: osmomath.NewInt(6_666_667),
 				},
 				&usecase.RouteWithOutAmount{
 					RouteImpl: route.RouteImpl{
@@ -170,12 +173,13 @@ func (s *RouterTestSuite) TestPrepareResult() {
 						},
 					},
 
-					InAmount:  totalOutAmount.QuoRaw(5),
-					OutAmount: totalInAmount.QuoRaw(4),
+					InAmount:  osmomath.NewInt(15_000_001),
+					OutAmount: osmomath.NewInt(3_333_334),
 				},
 			},
-			expectedEffectiveFee: "0.010946000000000000",
-			expectedJSON:         s.MustReadFile("./routertesting/parsing/quote_amount_out_response.json"),
+			expectedEffectiveFee:    "0.010946000000000000",
+			expectedRouteComplexity: domain.RouteComplexity{HopCount: 3},
+			expectedJSON:            s.MustReadFile("./routertesting/parsing/quote_amount_out_response.json"),
 		},
 	}
 
@@ -198,6 +202,98 @@ func (s *RouterTestSuite) TestPrepareResult() {
 			// Validate effective spread factor.
 			s.Require().Equal(tc.expectedEffectiveFee, effectiveFee.String())
 			s.Require().Equal(tc.expectedEffectiveFee, tc.quote.GetEffectiveFee().String())
+
+			// Validate route complexity.
+			s.Require().Equal(tc.expectedRouteComplexity, tc.quote.GetRouteComplexity())
+		})
+	}
+}
+
+// This test validates that the route complexity is computed correctly
+// across single, multi-hop and split routes.
+func (s *RouterTestSuite) TestComputeRouteComplexity() {
+	var (
+		balancerPool      = &mocks.MockRoutablePool{ID: 1, SQSPoolType: domain.Balancer}
+		concentratedPool  = &mocks.MockRoutablePool{ID: 2, SQSPoolType: domain.Concentrated}
+		generalizedCWPool = &mocks.MockRoutablePool{ID: 3, SQSPoolType: domain.GeneralizedCosmWasm}
+		transmuterPool    = &mocks.MockRoutablePool{ID: 4, SQSPoolType: domain.TransmuterV1}
+	)
+
+	newSplitRoute := func(containsGeneralizedCosmWasmPool bool, pools ...domain.RoutablePool) domain.SplitRoute {
+		return &usecase.RouteWithOutAmount{
+			RouteImpl: route.RouteImpl{
+				Pools:                      pools,
+				HasGeneralizedCosmWasmPool: containsGeneralizedCosmWasmPool,
+			},
+		}
+	}
+
+	testcases := []struct {
+		name   string
+		routes []domain.SplitRoute
+
+		expectedComplexity domain.RouteComplexity
+	}{
+		{
+			name:   "no routes",
+			routes: []domain.SplitRoute{},
+
+			expectedComplexity: domain.RouteComplexity{},
+		},
+		{
+			name: "single route, single hop",
+			routes: []domain.SplitRoute{
+				newSplitRoute(false, concentratedPool),
+			},
+
+			expectedComplexity: domain.RouteComplexity{
+				HopCount:              1,
+				ConcentratedPoolCount: 1,
+			},
+		},
+		{
+			name: "single route, multi-hop",
+			routes: []domain.SplitRoute{
+				newSplitRoute(true, balancerPool, generalizedCWPool, concentratedPool),
+			},
+
+			expectedComplexity: domain.RouteComplexity{
+				HopCount:                     3,
+				GeneralizedCosmWasmPoolCount: 1,
+				ConcentratedPoolCount:        1,
+			},
+		},
+		{
+			name: "split routes",
+			routes: []domain.SplitRoute{
+				newSplitRoute(false, balancerPool, concentratedPool),
+				newSplitRoute(false, transmuterPool),
+				newSplitRoute(false, concentratedPool, balancerPool, concentratedPool),
+			},
+
+			expectedComplexity: domain.RouteComplexity{
+				HopCount:              6,
+				ConcentratedPoolCount: 3,
+			},
+		},
+		{
+			name: "generalized cosmwasm pool in route not flagged as containing it",
+			routes: []domain.SplitRoute{
+				newSplitRoute(false, generalizedCWPool),
+			},
+
+			expectedComplexity: domain.RouteComplexity{
+				HopCount: 1,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			// System under test
+			complexity := usecase.ComputeRouteComplexity(tc.routes)
+
+			s.Require().Equal(tc.expectedComplexity, complexity)
 		})
 	}
 }
@@ -271,6 +367,411 @@ func (s *RouterTestSuite) TestPrepareResult_PriceImpact() {
 	s.Require().Equal(expectedPriceImpact.String(), testQuote.GetPriceImpact().String())
 }
 
+// This test validates that the amounts in and out of each split route and the total
+// amount out are rounded according to the rounding mode of the quote when preparing the result.
+func (s *RouterTestSuite) TestPrepareResult_RoundingMode() {
+	var (
+		// 31 split pro-rata to route amounts of 10 and 20 yields fractional shares of 10.33 and 20.67.
+		amount       = osmomath.NewInt(31)
+		routeAmounts = []osmomath.Int{osmomath.NewInt(10), osmomath.NewInt(20)}
+	)
+
+	tests := []struct {
+		name         string
+		roundingMode domain.RoundingMode
+		isExactOut   bool
+
+		// expectedRouteAmounts are the amounts in and out of each route in the response.
+		expectedRouteAmounts []osmomath.Int
+		// expectedAmount is the amount out for exact in and the amount in for exact out in the response.
+		expectedAmount osmomath.Int
+	}{
+		{"default exact in truncates", domain.RoundingModeDefault, false, []osmomath.Int{osmomath.NewInt(10), osmomath.NewInt(20)}, osmomath.NewInt(30)},
+		{"default exact out rounds up", domain.RoundingModeDefault, true, []osmomath.Int{osmomath.NewInt(11), osmomath.NewInt(21)}, osmomath.NewInt(32)},
+		{"truncate", domain.RoundingModeTruncate, true, []osmomath.Int{osmomath.NewInt(10), osmomath.NewInt(20)}, osmomath.NewInt(30)},
+		{"ceil", domain.RoundingModeCeil, false, []osmomath.Int{osmomath.NewInt(11), osmomath.NewInt(21)}, osmomath.NewInt(32)},
+		{"nearest", domain.RoundingModeNearest, false, []osmomath.Int{osmomath.NewInt(10), osmomath.NewInt(21)}, osmomath.NewInt(31)},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			routes := make([]domain.SplitRoute, len(routeAmounts))
+			for i, routeAmount := range routeAmounts {
+				routes[i] = &usecase.RouteWithOutAmount{
+					RouteImpl: route.RouteImpl{
+						Pools: []domain.RoutablePool{
+							&mocks.MockRoutablePool{
+								ID:            uint64(i + 1),
+								PoolType:      poolmanagertypes.CosmWasm,
+								TokenOutDenom: USDC,
+								TakerFee:      osmomath.ZeroDec(),
+								CalculateTokenOutByTokenInFunc: func(ctx context.Context, tokenIn sdk.Coin) (sdk.Coin, error) {
+									return sdk.NewCoin(USDC, tokenIn.Amount), nil
+								},
+							},
+						},
+					},
+					InAmount:  routeAmount,
+					OutAmount: routeAmount,
+				}
+			}
+
+			quoteExactIn := &usecase.QuoteImpl{
+				AmountIn:     sdk.NewCoin(ETH, amount),
+				AmountOut:    amount,
+				Route:        routes,
+				EffectiveFee: osmomath.ZeroDec(),
+			}
+			quoteExactIn.SetRoundingMode(tt.roundingMode)
+
+			var (
+				quote         domain.Quote = quoteExactIn
+				quoteExactOut *usecase.QuoteExactAmountOut
+			)
+			if tt.isExactOut {
+				quoteExactOut = usecase.NewQuoteExactAmountOut(quoteExactIn)
+				quote = quoteExactOut
+			}
+
+			// System under test.
+			resultRoutes, _, err := quote.PrepareResult(context.TODO(), defaultSpotPriceScalingFactor, &log.NoOpLogger{})
+			s.Require().NoError(err)
+
+			s.Require().Len(resultRoutes, len(tt.expectedRouteAmounts))
+			for i, expectedRouteAmount := range tt.expectedRouteAmounts {
+				resultRoute, ok := resultRoutes[i].(*usecase.RouteWithOutAmount)
+				s.Require().True(ok)
+
+				s.Require().Equal(expectedRouteAmount.String(), resultRoute.InAmount.String())
+				s.Require().Equal(expectedRouteAmount.String(), resultRoute.OutAmount.String())
+			}
+
+			if tt.isExactOut {
+				s.Require().Equal(tt.expectedAmount.String(), quoteExactOut.AmountIn.String())
+				s.Require().Equal(amount.String(), quoteExactOut.AmountOut.Amount.String())
+			} else {
+				s.Require().Equal(tt.expectedAmount.String(), quoteExactIn.AmountOut.String())
+				s.Require().Equal(amount.String(), quoteExactIn.AmountIn.Amount.String())
+			}
+		})
+	}
+}
+
+// This test validates that the intermediate token amounts between each hop
+// are reported per split route when preparing the result.
+// The quote is split between a 3-hop route and a direct single-pool route.
+func (s *RouterTestSuite) TestPrepareResult_IntermediateAmounts() {
+	// withMultiplier returns a pool that outputs the token in amount multiplied by the given factor.
+	withMultiplier := func(id uint64, tokenOutDenom string, multiplier int64) domain.RoutablePool {
+		return &mocks.MockRoutablePool{
+			ID:            id,
+			PoolType:      poolmanagertypes.CosmWasm,
+			TokenOutDenom: tokenOutDenom,
+			TakerFee:      osmomath.ZeroDec(),
+			CalculateTokenOutByTokenInFunc: func(ctx context.Context, tokenIn sdk.Coin) (sdk.Coin, error) {
+				return sdk.NewCoin(tokenOutDenom, tokenIn.Amount.MulRaw(multiplier)), nil
+			},
+		}
+	}
+
+	quote := &usecase.QuoteImpl{
+		AmountIn:  sdk.NewCoin(ETH, osmomath.NewInt(200)),
+		AmountOut: osmomath.NewInt(2500),
+		Route: []domain.SplitRoute{
+			// ETH -> USDC -> ATOM -> UOSMO
+			&usecase.RouteWithOutAmount{
+				RouteImpl: route.RouteImpl{
+					Pools: []domain.RoutablePool{
+						withMultiplier(1, USDC, 2),
+						withMultiplier(2, ATOM, 3),
+						withMultiplier(3, UOSMO, 4),
+					},
+				},
+				InAmount:  osmomath.NewInt(100),
+				OutAmount: osmomath.NewInt(2400),
+			},
+			// ETH -> UOSMO
+			&usecase.RouteWithOutAmount{
+				RouteImpl: route.RouteImpl{
+					Pools: []domain.RoutablePool{
+						withMultiplier(4, UOSMO, 1),
+					},
+				},
+				InAmount:  osmomath.NewInt(100),
+				OutAmount: osmomath.NewInt(100),
+			},
+		},
+		EffectiveFee: osmomath.ZeroDec(),
+	}
+
+	// System under test.
+	routes, _, err := quote.PrepareResult(context.TODO(), defaultSpotPriceScalingFactor, &log.NoOpLogger{})
+	s.Require().NoError(err)
+	s.Require().Len(routes, 2)
+
+	// 100 ETH -> 200 USDC -> 600 ATOM -> 2400 UOSMO
+	s.Require().Equal([]sdk.Coin{
+		sdk.NewCoin(USDC, osmomath.NewInt(200)),
+		sdk.NewCoin(ATOM, osmomath.NewInt(600)),
+	}, routes[0].GetIntermediateAmounts())
+
+	// No intermediate amounts on a single hop.
+	s.Require().Empty(routes[1].GetIntermediateAmounts())
+}
+
+// This test validates that the intermediate token amounts of exact out quotes
+// are ordered from the token in even though they are computed from the token out.
+func (s *RouterTestSuite) TestPrepareResult_IntermediateAmounts_ExactOut() {
+	// withDivisor returns a pool that requires the token out amount divided by the given factor as token in.
+	// Note that the token in and token out are inverted for exact out quotes.
+	withDivisor := func(id uint64, tokenInDenom string, divisor int64) domain.RoutablePool {
+		return &mocks.MockRoutablePool{
+			ID:            id,
+			PoolType:      poolmanagertypes.CosmWasm,
+			TokenOutDenom: tokenInDenom,
+			TakerFee:      osmomath.ZeroDec(),
+			CalculateTokenOutByTokenInFunc: func(ctx context.Context, tokenOut sdk.Coin) (sdk.Coin, error) {
+				return sdk.NewCoin(tokenInDenom, tokenOut.Amount.QuoRaw(divisor)), nil
+			},
+		}
+	}
+
+	quote := usecase.NewQuoteExactAmountOut(&usecase.QuoteImpl{
+		AmountIn:  sdk.NewCoin(UOSMO, osmomath.NewInt(2400)),
+		AmountOut: osmomath.NewInt(100),
+		Route: []domain.SplitRoute{
+			// ETH -> USDC -> ATOM -> UOSMO, with the pools ordered from the token out.
+			&usecase.RouteWithOutAmount{
+				RouteImpl: route.RouteImpl{
+					Pools: []domain.RoutablePool{
+						withDivisor(3, ATOM, 4),
+						withDivisor(2, USDC, 3),
+						withDivisor(1, ETH, 2),
+					},
+				},
+				InAmount:  osmomath.NewInt(2400),
+				OutAmount: osmomath.NewInt(100),
+			},
+		},
+		EffectiveFee: osmomath.ZeroDec(),
+	})
+
+	// System under test.
+	routes, _, err := quote.PrepareResult(context.TODO(), defaultSpotPriceScalingFactor, &log.NoOpLogger{})
+	s.Require().NoError(err)
+	s.Require().Len(routes, 1)
+
+	// 100 ETH -> 200 USDC -> 600 ATOM -> 2400 UOSMO
+	s.Require().Equal([]sdk.Coin{
+		sdk.NewCoin(USDC, osmomath.NewInt(200)),
+		sdk.NewCoin(ATOM, osmomath.NewInt(600)),
+	}, routes[0].GetIntermediateAmounts())
+}
+
+// This test validates that the price impact relative to the reference price is computed
+// alongside the price impact relative to the internal spot price when preparing the result.
+func (s *RouterTestSuite) TestPrepareResult_ReferencePriceImpact() {
+	// The pool has a spot price of one and outputs 98% of the amount in,
+	// resulting in an effective price of 0.98.
+	expectedPriceImpact := osmomath.MustNewDecFromStr("-0.02")
+
+	tests := []struct {
+		name           string
+		referencePrice osmomath.BigDec
+
+		expectedReferencePriceImpact osmomath.Dec
+	}{
+		{
+			name:           "no reference price",
+			referencePrice: osmomath.BigDec{},
+
+			expectedReferencePriceImpact: osmomath.Dec{},
+		},
+		{
+			name:           "reference price equal to spot price",
+			referencePrice: osmomath.OneBigDec(),
+
+			expectedReferencePriceImpact: expectedPriceImpact,
+		},
+		{
+			name:           "market price lower than spot price",
+			referencePrice: osmomath.MustNewBigDecFromStr("0.5"),
+
+			// 0.98 / 0.5 - 1
+			expectedReferencePriceImpact: osmomath.MustNewDecFromStr("0.96"),
+		},
+		{
+			name:           "market price higher than spot price",
+			referencePrice: osmomath.MustNewBigDecFromStr("1.96"),
+
+			// 0.98 / 1.96 - 1
+			expectedReferencePriceImpact: osmomath.MustNewDecFromStr("-0.5"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			quote := &usecase.QuoteImpl{
+				AmountIn:  sdk.NewCoin(ETH, osmomath.NewInt(100)),
+				AmountOut: osmomath.NewInt(98),
+				Route: []domain.SplitRoute{
+					&usecase.RouteWithOutAmount{
+						RouteImpl: route.RouteImpl{
+							Pools: []domain.RoutablePool{
+								&mocks.MockRoutablePool{
+									ID:            1,
+									PoolType:      poolmanagertypes.CosmWasm,
+									TokenOutDenom: USDC,
+									TakerFee:      osmomath.ZeroDec(),
+									CalculateTokenOutByTokenInFunc: func(ctx context.Context, tokenIn sdk.Coin) (sdk.Coin, error) {
+										return sdk.NewCoin(USDC, tokenIn.Amount.MulRaw(98).QuoRaw(100)), nil
+									},
+								},
+							},
+						},
+						InAmount:  osmomath.NewInt(100),
+						OutAmount: osmomath.NewInt(98),
+					},
+				},
+				EffectiveFee: osmomath.ZeroDec(),
+			}
+			quote.SetReferencePrice(tt.referencePrice)
+
+			// System under test.
+			_, _, err := quote.PrepareResult(context.TODO(), defaultSpotPriceScalingFactor, &log.NoOpLogger{})
+			s.Require().NoError(err)
+
+			// The internal price impact is unaffected by the reference price.
+			s.Require().Equal(expectedPriceImpact, quote.GetPriceImpact())
+
+			s.Require().Equal(tt.expectedReferencePriceImpact, quote.GetReferencePriceImpact())
+		})
+	}
+}
+
+// This test validates that the effective rate of amount out per amount in is computed
+// across single and split routes when preparing the result.
+func (s *RouterTestSuite) TestPrepareResult_EffectiveRate() {
+	newRoute := func(poolID uint64, amountIn, amountOut osmomath.Int) domain.SplitRoute {
+		return &usecase.RouteWithOutAmount{
+			RouteImpl: route.RouteImpl{
+				Pools: []domain.RoutablePool{
+					&mocks.MockRoutablePool{
+						ID:            poolID,
+						PoolType:      poolmanagertypes.CosmWasm,
+						TokenOutDenom: USDC,
+						TakerFee:      osmomath.ZeroDec(),
+						CalculateTokenOutByTokenInFunc: func(ctx context.Context, tokenIn sdk.Coin) (sdk.Coin, error) {
+							return sdk.NewCoin(USDC, amountOut), nil
+						},
+					},
+				},
+			},
+			InAmount:  amountIn,
+			OutAmount: amountOut,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		amountIn      osmomath.Int
+		amountOut     osmomath.Int
+		routes        []domain.SplitRoute
+		scalingFactor osmomath.Dec
+		isExactOut    bool
+
+		expectedEffectiveRate osmomath.Dec
+	}{
+		{
+			name:          "single route",
+			amountIn:      osmomath.NewInt(100),
+			amountOut:     osmomath.NewInt(98),
+			routes:        []domain.SplitRoute{newRoute(1, osmomath.NewInt(100), osmomath.NewInt(98))},
+			scalingFactor: defaultSpotPriceScalingFactor,
+
+			expectedEffectiveRate: osmomath.MustNewDecFromStr("0.98"),
+		},
+		{
+			name:      "split route",
+			amountIn:  osmomath.NewInt(100),
+			amountOut: osmomath.NewInt(196),
+			routes: []domain.SplitRoute{
+				newRoute(1, osmomath.NewInt(50), osmomath.NewInt(100)),
+				newRoute(2, osmomath.NewInt(50), osmomath.NewInt(96)),
+			},
+			scalingFactor: defaultSpotPriceScalingFactor,
+
+			expectedEffectiveRate: osmomath.MustNewDecFromStr("1.96"),
+		},
+		{
+			name:      "scaled by precisions",
+			amountIn:  osmomath.NewInt(1_000_000),
+			amountOut: osmomath.NewIntWithDecimal(2, 18),
+			routes:    []domain.SplitRoute{newRoute(1, osmomath.NewInt(1_000_000), osmomath.NewIntWithDecimal(2, 18))},
+			// token in has precision 6 and token out has precision 18.
+			scalingFactor: osmomath.NewDec(1_000_000_000_000),
+
+			expectedEffectiveRate: osmomath.NewDec(2),
+		},
+		{
+			name:          "exact out",
+			amountIn:      osmomath.NewInt(200),
+			amountOut:     osmomath.NewInt(100),
+			routes:        []domain.SplitRoute{newRoute(1, osmomath.NewInt(100), osmomath.NewInt(200))},
+			scalingFactor: defaultSpotPriceScalingFactor,
+			isExactOut:    true,
+
+			expectedEffectiveRate: osmomath.MustNewDecFromStr("0.5"),
+		},
+		{
+			name:          "zero amount in",
+			amountIn:      osmomath.ZeroInt(),
+			amountOut:     osmomath.ZeroInt(),
+			routes:        []domain.SplitRoute{},
+			scalingFactor: defaultSpotPriceScalingFactor,
+
+			expectedEffectiveRate: osmomath.ZeroDec(),
+		},
+		{
+			name:          "zero scaling factor",
+			amountIn:      osmomath.NewInt(100),
+			amountOut:     osmomath.NewInt(98),
+			routes:        []domain.SplitRoute{newRoute(1, osmomath.NewInt(100), osmomath.NewInt(98))},
+			scalingFactor: osmomath.ZeroDec(),
+
+			expectedEffectiveRate: osmomath.ZeroDec(),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var quote domain.Quote
+			if tt.isExactOut {
+				// Note: the underlying quote is inverted for exact out.
+				quote = usecase.NewQuoteExactAmountOut(&usecase.QuoteExactAmountIn{
+					AmountIn:     sdk.NewCoin(USDC, tt.amountOut),
+					AmountOut:    tt.amountIn,
+					Route:        tt.routes,
+					EffectiveFee: osmomath.ZeroDec(),
+				})
+			} else {
+				quote = &usecase.QuoteImpl{
+					AmountIn:     sdk.NewCoin(ETH, tt.amountIn),
+					AmountOut:    tt.amountOut,
+					Route:        tt.routes,
+					EffectiveFee: osmomath.ZeroDec(),
+				}
+			}
+
+			// System under test.
+			_, _, err := quote.PrepareResult(context.TODO(), tt.scalingFactor, &log.NoOpLogger{})
+			s.Require().NoError(err)
+
+			s.Require().Equal(tt.expectedEffectiveRate, quote.GetEffectiveRate())
+		})
+	}
+}
+
 // validateRoutes validates that the given routes are equal.
 // Specifically, validates:
 // - Pools
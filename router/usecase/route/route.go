@@ -54,15 +54,17 @@ var (
 // Note that it mutates the route.
 // Returns spot price before swap and the effective spot price
 // with token in as base and token out as quote.
-func (r RouteImpl) PrepareResultPools(ctx context.Context, tokenIn sdk.Coin, logger log.Logger) ([]domain.RoutablePool, osmomath.Dec, osmomath.Dec, error) {
+// Also returns the intermediate amounts output by each pool except the last one.
+func (r RouteImpl) PrepareResultPools(ctx context.Context, tokenIn sdk.Coin, logger log.Logger) ([]domain.RoutablePool, osmomath.Dec, osmomath.Dec, []sdk.Coin, error) {
 	var (
 		routeSpotPriceInBaseOutQuote     = osmomath.OneDec()
 		effectiveSpotPriceInBaseOutQuote = osmomath.OneDec()
 	)
 
 	newPools := make([]domain.RoutablePool, 0, len(r.Pools))
+	intermediateAmounts := make([]sdk.Coin, 0, max(len(r.Pools)-1, 0))
 
-	for _, pool := range r.Pools {
+	for i, pool := range r.Pools {
 		// Compute spot price before swap.
 		spotPriceInBaseOutQuote, err := pool.CalcSpotPrice(ctx, tokenIn.Denom, pool.GetTokenOutDenom())
 		if err != nil {
@@ -85,7 +87,7 @@ func (r RouteImpl) PrepareResultPools(ctx context.Context, tokenIn sdk.Coin, log
 
 		tokenOut, err := pool.CalculateTokenOutByTokenIn(ctx, tokenIn)
 		if err != nil {
-			return nil, osmomath.Dec{}, osmomath.Dec{}, err
+			return nil, osmomath.Dec{}, osmomath.Dec{}, nil, err
 		}
 
 		// Update effective spot price
@@ -105,9 +107,13 @@ func (r RouteImpl) PrepareResultPools(ctx context.Context, tokenIn sdk.Coin, log
 
 		newPools = append(newPools, newPool)
 
+		if i < len(r.Pools)-1 {
+			intermediateAmounts = append(intermediateAmounts, tokenOut)
+		}
+
 		tokenIn = tokenOut
 	}
-	return newPools, routeSpotPriceInBaseOutQuote, effectiveSpotPriceInBaseOutQuote, nil
+	return newPools, routeSpotPriceInBaseOutQuote, effectiveSpotPriceInBaseOutQuote, intermediateAmounts, nil
 }
 
 // GetPools implements Route.
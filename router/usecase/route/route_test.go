@@ -16,6 +16,7 @@ import (
 
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/osmosis/v26/x/gamm/pool-models/balancer"
+	"github.com/osmosis-labs/osmosis/v26/x/poolmanager"
 	poolmanagertypes "github.com/osmosis-labs/osmosis/v26/x/poolmanager/types"
 )
 
@@ -207,7 +208,7 @@ func (s *RouterTestSuite) TestPrepareResultPools() {
 		s.Run(name, func() {
 
 			// Note: token in is chosen arbitrarily since it is irrelevant for this test
-			actualPools, spotPriceBeforeInBaseOutQuote, _, err := tc.route.PrepareResultPools(context.TODO(), tc.tokenIn, &log.NoOpLogger{})
+			actualPools, spotPriceBeforeInBaseOutQuote, _, _, err := tc.route.PrepareResultPools(context.TODO(), tc.tokenIn, &log.NoOpLogger{})
 			s.Require().NoError(err)
 
 			s.Require().Equal(tc.expectedSpotPriceInBaseOutQuote, spotPriceBeforeInBaseOutQuote)
@@ -220,3 +221,124 @@ func (s *RouterTestSuite) TestPrepareResultPools() {
 func WithRoutePools(r route.RouteImpl, pools []domain.RoutablePool) route.RouteImpl {
 	return routertesting.WithRoutePools(r, pools)
 }
+
+// This test validates that WithTakerFeeMode charges the taker fees according to the given mode
+// and compares the outputs of both modes on single and multi-hop routes.
+func (s *RouterTestSuite) TestWithTakerFeeMode() {
+	s.Setup()
+
+	var (
+		firstTakerFee  = osmomath.MustNewDecFromStr("0.01")
+		secondTakerFee = osmomath.MustNewDecFromStr("0.02")
+
+		// 1 - (1 - 0.01) * (1 - 0.02)
+		expectedRouteTakerFee = osmomath.MustNewDecFromStr("0.0298")
+
+		tokenIn = sdk.NewCoin(DenomTwo, osmomath.NewInt(100_000_000))
+	)
+
+	firstBalancerPoolID := s.PrepareBalancerPoolWithCoins(sdk.NewCoins(
+		sdk.NewCoin(DenomOne, osmomath.NewInt(2_000_000_000)),
+		sdk.NewCoin(DenomTwo, osmomath.NewInt(1_000_000_000)),
+	)...)
+	firstBalancerPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, firstBalancerPoolID)
+	s.Require().NoError(err)
+
+	secondBalancerPoolID := s.PrepareBalancerPoolWithCoins(sdk.NewCoins(
+		sdk.NewCoin(DenomOne, osmomath.NewInt(1_000_000_000)),
+		sdk.NewCoin(DenomThree, osmomath.NewInt(3_000_000_000)),
+	)...)
+	secondBalancerPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, secondBalancerPoolID)
+	s.Require().NoError(err)
+
+	newFirstPool := func() *mocks.MockRoutablePool {
+		return mocks.WithTakerFee(mocks.WithChainPoolModel(mocks.WithTokenOutDenom(DefaultPool, DenomOne), firstBalancerPool), firstTakerFee)
+	}
+	newSecondPool := func() *mocks.MockRoutablePool {
+		return mocks.WithTakerFee(mocks.WithChainPoolModel(mocks.WithTokenOutDenom(DefaultPool, DenomThree), secondBalancerPool), secondTakerFee)
+	}
+
+	// swapOverRoute swaps the token in over the pools, charging the taker fee
+	// of each pool on the amount swapped into it if chargeTakerFees is true.
+	swapOverRoute := func(tokenIn sdk.Coin, pools []*mocks.MockRoutablePool, chargeTakerFees bool) sdk.Coin {
+		for _, pool := range pools {
+			if chargeTakerFees {
+				tokenIn = pool.ChargeTakerFeeExactIn(tokenIn)
+			}
+
+			tokenOut, err := pool.CalculateTokenOutByTokenIn(context.TODO(), tokenIn)
+			s.Require().NoError(err)
+
+			tokenIn = tokenOut
+		}
+		return tokenIn
+	}
+
+	testcases := map[string]struct {
+		pools []*mocks.MockRoutablePool
+
+		expectedPerHopTakerFees      []osmomath.Dec
+		expectedInputDeductTakerFees []osmomath.Dec
+		expectSameOutForModes        bool
+	}{
+		"single hop route": {
+			pools: []*mocks.MockRoutablePool{newFirstPool()},
+
+			expectedPerHopTakerFees:      []osmomath.Dec{firstTakerFee},
+			expectedInputDeductTakerFees: []osmomath.Dec{firstTakerFee},
+			expectSameOutForModes:        true,
+		},
+		"multi hop route": {
+			pools: []*mocks.MockRoutablePool{newFirstPool(), newSecondPool()},
+
+			expectedPerHopTakerFees:      []osmomath.Dec{firstTakerFee, secondTakerFee},
+			expectedInputDeductTakerFees: []osmomath.Dec{expectedRouteTakerFee, osmomath.ZeroDec()},
+		},
+	}
+
+	for name, tc := range testcases {
+		tc := tc
+		s.Run(name, func() {
+			routablePools := make([]domain.RoutablePool, 0, len(tc.pools))
+			for _, pool := range tc.pools {
+				routablePools = append(routablePools, pool)
+			}
+			originalRoute := WithRoutePools(emptyRoute, routablePools)
+
+			perHopRoute := originalRoute.WithTakerFeeMode(domain.TakerFeeModePerHop)
+			inputDeductRoute := originalRoute.WithTakerFeeMode(domain.TakerFeeModeInputDeduct)
+
+			// Validate the taker fees charged by each pool.
+			for i := range tc.pools {
+				s.Require().Equal(tc.expectedPerHopTakerFees[i], perHopRoute.GetPools()[i].GetTakerFee())
+				s.Require().Equal(tc.expectedInputDeductTakerFees[i], inputDeductRoute.GetPools()[i].GetTakerFee())
+
+				// The original route must not be mutated.
+				s.Require().Equal(tc.expectedPerHopTakerFees[i], originalRoute.GetPools()[i].GetTakerFee())
+			}
+
+			perHopTokenOut, err := perHopRoute.CalculateTokenOutByTokenIn(context.TODO(), tokenIn)
+			s.Require().NoError(err)
+
+			inputDeductTokenOut, err := inputDeductRoute.CalculateTokenOutByTokenIn(context.TODO(), tokenIn)
+			s.Require().NoError(err)
+
+			// Per hop charges the taker fee of each pool.
+			s.Require().Equal(swapOverRoute(tokenIn, tc.pools, true), perHopTokenOut)
+
+			// Input deduct charges the route taker fee once on the input.
+			tokenInAfterRouteTakerFee, _ := poolmanager.CalcTakerFeeExactIn(tokenIn, tc.expectedInputDeductTakerFees[0])
+			s.Require().Equal(swapOverRoute(tokenInAfterRouteTakerFee, tc.pools, false), inputDeductTokenOut)
+
+			if tc.expectSameOutForModes {
+				s.Require().Equal(perHopTokenOut, inputDeductTokenOut)
+				return
+			}
+
+			// The same nominal fee is charged. However, deducting it from the input ahead of the
+			// first hop lowers the price impact of the first pool, resulting in a larger amount out.
+			s.Require().True(inputDeductTokenOut.Amount.GT(perHopTokenOut.Amount))
+			s.Require().InEpsilon(perHopTokenOut.Amount.Int64(), inputDeductTokenOut.Amount.Int64(), 0.01)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package route
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/sqs/domain"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/v26/x/poolmanager"
+)
+
+// inputDeductTakerFeePool wraps a routable pool, overriding the taker fee
+// that is charged on the amount swapped into the pool.
+type inputDeductTakerFeePool struct {
+	domain.RoutablePool
+	takerFee osmomath.Dec
+}
+
+var _ domain.RoutablePool = &inputDeductTakerFeePool{}
+
+// ChargeTakerFeeExactIn implements domain.RoutablePool.
+// Charges the overridden taker fee rather than the one of the underlying pool.
+func (p *inputDeductTakerFeePool) ChargeTakerFeeExactIn(tokenIn sdk.Coin) (tokenInAfterFee sdk.Coin) {
+	tokenInAfterTakerFee, _ := poolmanager.CalcTakerFeeExactIn(tokenIn, p.takerFee)
+	return tokenInAfterTakerFee
+}
+
+// GetTakerFee implements domain.RoutablePool.
+func (p *inputDeductTakerFeePool) GetTakerFee() osmomath.Dec {
+	return p.takerFee
+}
+
+// WithTakerFeeMode returns a copy of the route with the taker fees charged according to the given mode.
+// For domain.TakerFeeModePerHop, the route is returned as is.
+// For domain.TakerFeeModeInputDeduct, the first pool charges the compounded taker fee of all pools
+// in the route while the remaining pools charge no taker fee.
+// The original route and its pools are not mutated.
+func (r RouteImpl) WithTakerFeeMode(mode domain.TakerFeeMode) RouteImpl {
+	if mode != domain.TakerFeeModeInputDeduct || len(r.Pools) == 0 {
+		return r
+	}
+
+	// 1 - (1 - takerFee_1) * ... * (1 - takerFee_n)
+	remainingPortion := osmomath.OneDec()
+	for _, pool := range r.Pools {
+		remainingPortion.MulMut(osmomath.OneDec().Sub(pool.GetTakerFee()))
+	}
+	routeTakerFee := osmomath.OneDec().Sub(remainingPortion)
+
+	newPools := make([]domain.RoutablePool, 0, len(r.Pools))
+	for i, pool := range r.Pools {
+		takerFee := osmomath.ZeroDec()
+		if i == 0 {
+			takerFee = routeTakerFee
+		}
+
+		newPools = append(newPools, &inputDeductTakerFeePool{
+			RoutablePool: pool,
+			takerFee:     takerFee,
+		})
+	}
+
+	r.Pools = newPools
+
+	return r
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+// MergeCommonPrefixRoutes merges the given split routes that share a common prefix of pools
+// into a tree representation. Each root node corresponds to a distinct first pool across the routes.
+// Split routes going through the same node accumulate their amount in on it, while the amount out
+// is accumulated on the node where the split route ends.
+//
+// Since all split routes start from the same token in, the pools at the same position
+// of a common prefix are swapped into with the same denom. However, a pool with more than
+// two assets might be swapped out into different denoms by different routes. As a result,
+// the nodes are identified by pool ID and token out denom.
+//
+// The order of the nodes follows the order in which the pools first appear in the given routes.
+// Returns an empty slice if no routes are given.
+func MergeCommonPrefixRoutes(routes []domain.SplitRoute) []*domain.RouteTreeNode {
+	roots := []*domain.RouteTreeNode{}
+
+	for _, splitRoute := range routes {
+		pools := splitRoute.GetPools()
+		if len(pools) == 0 {
+			continue
+		}
+
+		amountIn := splitRoute.GetAmountIn()
+
+		curLevel := &roots
+		var curNode *domain.RouteTreeNode
+		for _, pool := range pools {
+			curNode = getOrAddRouteTreeNode(curLevel, pool.GetId(), pool.GetTokenOutDenom())
+			curNode.AmountIn = curNode.AmountIn.Add(amountIn)
+
+			curLevel = &curNode.Children
+		}
+
+		curNode.AmountOut = curNode.AmountOut.Add(splitRoute.GetAmountOut())
+	}
+
+	return roots
+}
+
+// getOrAddRouteTreeNode returns the node with the given pool ID and token out denom from the given level.
+// If there is no such node, a new one is appended to the level and returned.
+func getOrAddRouteTreeNode(level *[]*domain.RouteTreeNode, poolID uint64, tokenOutDenom string) *domain.RouteTreeNode {
+	for _, node := range *level {
+		if node.PoolID == poolID && node.TokenOutDenom == tokenOutDenom {
+			return node
+		}
+	}
+
+	node := &domain.RouteTreeNode{
+		PoolID:        poolID,
+		TokenOutDenom: tokenOutDenom,
+		AmountIn:      osmomath.ZeroInt(),
+		AmountOut:     osmomath.ZeroInt(),
+	}
+
+	*level = append(*level, node)
+
+	return node
+}
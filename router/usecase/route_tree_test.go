@@ -0,0 +1,163 @@
+package usecase_test
+
+import (
+	"github.com/osmosis-labs/osmosis/osmomath"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/router/usecase"
+	"github.com/osmosis-labs/sqs/router/usecase/route"
+)
+
+// This test validates that split routes sharing a common prefix of pools
+// are merged into a single branch of the route tree.
+func (s *RouterTestSuite) TestMergeCommonPrefixRoutes() {
+	newSplitRouteOverPools := func(amountIn, amountOut int64, routePools ...domain.RoutablePool) domain.SplitRoute {
+		return &usecase.RouteWithOutAmount{
+			RouteImpl: route.RouteImpl{
+				Pools: routePools,
+			},
+			InAmount:  osmomath.NewInt(amountIn),
+			OutAmount: osmomath.NewInt(amountOut),
+		}
+	}
+
+	newSplitRoute := func(amountIn, amountOut int64, poolIDs ...uint64) domain.SplitRoute {
+		routePools := make([]domain.RoutablePool, 0, len(poolIDs))
+		for _, poolID := range poolIDs {
+			routePools = append(routePools, &mocks.MockRoutablePool{ID: poolID})
+		}
+
+		return newSplitRouteOverPools(amountIn, amountOut, routePools...)
+	}
+
+	newPool := func(poolID uint64, tokenOutDenom string) domain.RoutablePool {
+		return &mocks.MockRoutablePool{ID: poolID, TokenOutDenom: tokenOutDenom}
+	}
+
+	newNodeWithTokenOut := func(poolID uint64, tokenOutDenom string, amountIn, amountOut int64, children ...*domain.RouteTreeNode) *domain.RouteTreeNode {
+		return &domain.RouteTreeNode{
+			PoolID:        poolID,
+			TokenOutDenom: tokenOutDenom,
+			AmountIn:      osmomath.NewInt(amountIn),
+			AmountOut:     osmomath.NewInt(amountOut),
+			Children:      children,
+		}
+	}
+
+	newNode := func(poolID uint64, amountIn, amountOut int64, children ...*domain.RouteTreeNode) *domain.RouteTreeNode {
+		return newNodeWithTokenOut(poolID, "", amountIn, amountOut, children...)
+	}
+
+	testcases := []struct {
+		name   string
+		routes []domain.SplitRoute
+
+		expectedTree []*domain.RouteTreeNode
+	}{
+		{
+			name:   "no routes",
+			routes: []domain.SplitRoute{},
+
+			expectedTree: []*domain.RouteTreeNode{},
+		},
+		{
+			name: "single multi-hop route",
+			routes: []domain.SplitRoute{
+				newSplitRoute(100, 200, 1, 2),
+			},
+
+			expectedTree: []*domain.RouteTreeNode{
+				newNode(1, 100, 0,
+					newNode(2, 100, 200),
+				),
+			},
+		},
+		{
+			name: "routes not sharing a prefix",
+			routes: []domain.SplitRoute{
+				newSplitRoute(60, 120, 1, 2),
+				newSplitRoute(40, 80, 3),
+			},
+
+			expectedTree: []*domain.RouteTreeNode{
+				newNode(1, 60, 0,
+					newNode(2, 60, 120),
+				),
+				newNode(3, 40, 80),
+			},
+		},
+		{
+			name: "routes sharing the first pool",
+			routes: []domain.SplitRoute{
+				newSplitRoute(50, 100, 1, 2),
+				newSplitRoute(30, 55, 1, 3),
+				newSplitRoute(20, 35, 4),
+			},
+
+			expectedTree: []*domain.RouteTreeNode{
+				newNode(1, 80, 0,
+					newNode(2, 50, 100),
+					newNode(3, 30, 55),
+				),
+				newNode(4, 20, 35),
+			},
+		},
+		{
+			name: "routes sharing a multi-pool prefix",
+			routes: []domain.SplitRoute{
+				newSplitRoute(50, 100, 1, 2, 3),
+				newSplitRoute(30, 55, 1, 2, 4),
+			},
+
+			expectedTree: []*domain.RouteTreeNode{
+				newNode(1, 80, 0,
+					newNode(2, 80, 0,
+						newNode(3, 50, 100),
+						newNode(4, 30, 55),
+					),
+				),
+			},
+		},
+		{
+			name: "route ending at the prefix of another route",
+			routes: []domain.SplitRoute{
+				newSplitRoute(50, 100, 1),
+				newSplitRoute(30, 55, 1, 2),
+			},
+
+			expectedTree: []*domain.RouteTreeNode{
+				newNode(1, 80, 100,
+					newNode(2, 30, 55),
+				),
+			},
+		},
+		{
+			name: "multi-asset pool swapped out into different denoms is not merged",
+			routes: []domain.SplitRoute{
+				newSplitRouteOverPools(50, 100, newPool(1, DenomTwo), newPool(2, DenomFour)),
+				newSplitRouteOverPools(30, 55, newPool(1, DenomThree), newPool(3, DenomFour)),
+				newSplitRouteOverPools(20, 35, newPool(1, DenomTwo), newPool(4, DenomFour)),
+			},
+
+			expectedTree: []*domain.RouteTreeNode{
+				newNodeWithTokenOut(1, DenomTwo, 70, 0,
+					newNodeWithTokenOut(2, DenomFour, 50, 100),
+					newNodeWithTokenOut(4, DenomFour, 20, 35),
+				),
+				newNodeWithTokenOut(1, DenomThree, 30, 0,
+					newNodeWithTokenOut(3, DenomFour, 30, 55),
+				),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			// System under test
+			tree := usecase.MergeCommonPrefixRoutes(tc.routes)
+
+			s.Require().Equal(tc.expectedTree, tree)
+		})
+	}
+}
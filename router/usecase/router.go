@@ -78,6 +78,15 @@ func ValidateAndSortPools(pools []sqsdomain.PoolI, cosmWasmPoolsConfig domain.Co
 				continue
 			}
 
+			// Alloyed transmuter and orderbook pools cannot be routed without the custom model.
+			if (isAlloyedTransmuterCodeID || isOrderbookCodeID) && pool.GetSQSPoolModel().CosmWasmPoolModel == nil {
+				logger.Warn("cw pool is missing cosmwasm pool model, skipping", zap.Uint64("pool_id", pool.GetId()), zap.Uint64("code_id", cosmWasmPool.GetCodeId()))
+
+				domain.SQSRoutingNilCosmWasmPoolModelCounter.Inc()
+
+				continue
+			}
+
 			if isOrderbookCodeID {
 				orderbookPools = append(orderbookPools, pool)
 			}
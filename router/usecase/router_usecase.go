@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -44,17 +47,51 @@ type routerUseCaseImpl struct {
 	sortedPools   []sqsdomain.PoolI
 
 	candidateRouteCache *cache.Cache
+
+	// defaultCandidateRoutePoolFilters are applied in addition to the
+	// candidate route pool filters configured via routing options.
+	defaultCandidateRoutePoolFiltersMu sync.RWMutex
+	defaultCandidateRoutePoolFilters   []domain.CandidateRoutePoolFiltrerCb
+
+	// takerFeesCache caches the pool taker fees returned by GetTakerFee by pool ID.
+	// It is invalidated whenever the taker fees are set. takerFeesCacheGeneration is
+	// incremented on every invalidation so that the taker fees computed concurrently
+	// with an invalidation are not cached.
+	takerFeesCacheMu         sync.RWMutex
+	takerFeesCache           map[uint64][]domain.TakerFeeForPair
+	takerFeesCacheGeneration uint64
+
+	// minSplitRouteOutPortion is the decimal representation of the
+	// min split route out portion from the default config.
+	minSplitRouteOutPortion osmomath.Dec
+
+	// allowedDenoms and deniedDenoms are the sets of the allowed and denied
+	// denoms from the default config used for filtering candidate routes.
+	allowedDenoms map[string]struct{}
+	deniedDenoms  map[string]struct{}
+
+	// preferredPoolIDs is the set of the preferred pool IDs from the default config
+	// used for prioritizing the ranked routes on near-ties.
+	preferredPoolIDs map[uint64]struct{}
 }
 
 const (
 	candidateRouteCacheLabel = "candidate_route"
 	rankedRouteCacheLabel    = "ranked_route"
 
+	splitChosenLabel    = "true"
+	splitNotChosenLabel = "false"
+
 	denomSeparatorChar = "|"
 )
 
 var (
 	zero = osmomath.ZeroInt()
+
+	// routedSpotPriceBaseAmount is the amount of base denom swapped in to derive the routed spot price.
+	// Small to minimize the price impact while large enough to avoid truncation
+	// for the majority of tokens.
+	routedSpotPriceBaseAmount = osmomath.NewInt(1_000_000)
 )
 
 // NewRouterUsecase will create a new pools use case object
@@ -73,9 +110,36 @@ func NewRouterUsecase(tokensRepository mvc.RouterRepository, poolsUsecase mvc.Po
 
 		sortedPools:   make([]sqsdomain.PoolI, 0),
 		sortedPoolsMu: sync.RWMutex{},
+
+		takerFeesCache: make(map[uint64][]domain.TakerFeeForPair),
+
+		minSplitRouteOutPortion: osmomath.MustNewDecFromStr(strconv.FormatFloat(config.MinSplitRouteOutPortion, 'f', osmomath.DecPrecision, 64)),
+
+		allowedDenoms: denomSet(config.AllowedDenoms),
+		deniedDenoms:  denomSet(config.DeniedDenoms),
+
+		preferredPoolIDs: poolIDSet(config.PreferredPoolIDs),
 	}
 }
 
+// poolIDSet returns the set of the given pool IDs.
+func poolIDSet(poolIDs []uint64) map[uint64]struct{} {
+	result := make(map[uint64]struct{}, len(poolIDs))
+	for _, poolID := range poolIDs {
+		result[poolID] = struct{}{}
+	}
+	return result
+}
+
+// denomSet returns the set of the given denoms.
+func denomSet(denoms []string) map[string]struct{} {
+	result := make(map[string]struct{}, len(denoms))
+	for _, denom := range denoms {
+		result[denom] = struct{}{}
+	}
+	return result
+}
+
 // GetOptimalQuote returns the optimal quote by estimating the optimal route(s) through pools
 // on the osmosis network.
 // Uses default router config if no options parameter is provided.
@@ -96,6 +160,7 @@ func (r *routerUseCaseImpl) GetOptimalQuote(ctx context.Context, tokenIn sdk.Coi
 		CandidateRouteCacheExpirySeconds: r.defaultConfig.CandidateRouteCacheExpirySeconds,
 		RankedRouteCacheExpirySeconds:    r.defaultConfig.RankedRouteCacheExpirySeconds,
 		MaxSplitRoutes:                   r.defaultConfig.MaxSplitRoutes,
+		MaxPoolsConsidered:               r.defaultConfig.MaxPoolsConsidered,
 		DisableCache:                     !r.defaultConfig.RouteCacheEnabled,
 		CandidateRoutesPoolFiltersAnyOf:  []domain.CandidateRoutePoolFiltrerCb{},
 	}
@@ -104,17 +169,23 @@ func (r *routerUseCaseImpl) GetOptimalQuote(ctx context.Context, tokenIn sdk.Coi
 		opt(&options)
 	}
 
+	// Apply default pool filters on top of the configured ones.
+	options.CandidateRoutesPoolFiltersAnyOf = r.withDefaultCandidateRoutePoolFilters(options.CandidateRoutesPoolFiltersAnyOf)
+	options.CandidateRoutesPoolFiltersAnyOf = r.withDenomCandidateRoutePoolFilter(options.CandidateRoutesPoolFiltersAnyOf, tokenIn.Denom, tokenOutDenom)
+
 	var (
 		candidateRankedRoutes sqsdomain.CandidateRoutes
+		// cachedRoutesExpiresAt is the expiry of the ranked route cache entry the routes were read from.
+		cachedRoutesExpiresAt time.Time
 		err                   error
 	)
 
 	if !options.DisableCache {
 		// Get an order of magnitude for the token in amount
 		// This is used for caching ranked routes as these might differ depending on the amount swapped in.
-		tokenInOrderOfMagnitude := GetPrecomputeOrderOfMagnitude(tokenIn.Amount)
+		tokenInOrderOfMagnitude := domain.GetPrecomputeOrderOfMagnitude(tokenIn.Amount)
 
-		candidateRankedRoutes, err = r.GetCachedRankedRoutes(ctx, tokenIn.Denom, tokenOutDenom, tokenInOrderOfMagnitude)
+		candidateRankedRoutes, cachedRoutesExpiresAt, err = r.getCachedRankedRoutes(ctx, tokenIn.Denom, tokenOutDenom, tokenInOrderOfMagnitude)
 		if err != nil {
 			return nil, err
 		}
@@ -123,11 +194,18 @@ func (r *routerUseCaseImpl) GetOptimalQuote(ctx context.Context, tokenIn sdk.Coi
 	var (
 		topSingleRouteQuote domain.Quote
 		rankedRoutes        []route.RouteImpl
+		// routesWithAmtOut are all the routes ranked by direct quote alongside their amounts out.
+		routesWithAmtOut []RouteWithOutAmount
+		filteredRoutes   []domain.FilteredRoute
+		// expiresAt is the time until which the quote is considered fresh.
+		expiresAt time.Time
 	)
 
 	// If no cached candidate routes are found, we attempt to
 	// compute them.
 	if len(candidateRankedRoutes.Routes) == 0 {
+		domain.SetRouteSourceInContext(ctx, domain.RouteSourceComputed)
+
 		// Get the dynamic min pool liquidity cap for the given token in and token out denoms.
 		dynamicMinPoolLiquidityCap, err := r.tokenMetadataHolder.GetMinPoolLiquidityCap(tokenIn.Denom, tokenOutDenom)
 		if err == nil {
@@ -137,56 +215,194 @@ func (r *routerUseCaseImpl) GetOptimalQuote(ctx context.Context, tokenIn sdk.Coi
 		}
 
 		// Find candidate routes and rank them by direct quotes.
-		topSingleRouteQuote, rankedRoutes, err = r.computeAndRankRoutesByDirectQuote(ctx, tokenIn, tokenOutDenom, options)
+		topSingleRouteQuote, rankedRoutes, routesWithAmtOut, filteredRoutes, err = r.computeAndRankRoutesByDirectQuote(ctx, tokenIn, tokenOutDenom, options)
 		if err != nil {
 			return nil, err
 		}
+
+		expiresAt = computeQuoteExpiresAt(time.Now(), options)
 	} else {
+		domain.SetRouteSourceInContext(ctx, domain.RouteSourceCache)
+
 		// Otherwise, simply compute quotes over cached ranked routes
-		topSingleRouteQuote, rankedRoutes, err = r.rankRoutesByDirectQuote(ctx, candidateRankedRoutes, tokenIn, tokenOutDenom, options.MaxSplitRoutes)
+		topSingleRouteQuote, rankedRoutes, routesWithAmtOut, filteredRoutes, err = r.rankRoutesByDirectQuote(ctx, candidateRankedRoutes, tokenIn, tokenOutDenom, options.MaxSplitRoutes, options.TakerFeeMode)
 		if err != nil {
 			return nil, err
 		}
+
+		// The cached routes are only fresh until their cache entry expires.
+		expiresAt = cachedRoutesExpiresAt
 	}
 
+	// Construct the alternative quotes from the ranked routes other than the top one.
+	// Used unless the split quote is selected.
+	alternatives := computeAlternativeQuotes(routesWithAmtOut, tokenIn, options.Alternatives, topSingleRouteQuote)
+
 	if len(rankedRoutes) == 1 || options.MaxSplitRoutes == domain.DisableSplitRoutes {
-		return topSingleRouteQuote, nil
+		return finalizeQuote(topSingleRouteQuote, alternatives, filteredRoutes, options, expiresAt)
 	}
 
 	// Filter out generalized cosmWasm pool routes
-	rankedRoutes = filterOutGeneralizedCosmWasmPoolRoutes(rankedRoutes)
+	rankedRoutes, generalizedCosmWasmPoolFilteredRoutes := filterOutGeneralizedCosmWasmPoolRoutes(rankedRoutes)
+	filteredRoutes = append(filteredRoutes, generalizedCosmWasmPoolFilteredRoutes...)
 
 	// If filtering leads to a single route left, return it.
 	if len(rankedRoutes) == 1 {
-		return topSingleRouteQuote, nil
+		return finalizeQuote(topSingleRouteQuote, alternatives, filteredRoutes, options, expiresAt)
 	}
 
 	// Compute split route quote
-	topSplitQuote, err := getSplitQuote(ctx, rankedRoutes, tokenIn)
+	topSplitQuote, err := getSplitQuote(ctx, rankedRoutes, tokenIn, r.minSplitRouteOutPortion)
 	if err != nil {
 		// If error occurs in splits, return the single route quote
 		// rather than failing.
-		return topSingleRouteQuote, nil
+		return finalizeQuote(topSingleRouteQuote, alternatives, filteredRoutes, options, expiresAt)
 	}
 
-	finalQuote := topSingleRouteQuote
-
 	// If the split route quote is better than the single route quote, return the split route quote
 	if topSplitQuote.GetAmountOut().GT(topSingleRouteQuote.GetAmountOut()) {
 		routes := topSplitQuote.GetRoute()
 
 		r.logger.Debug("split route selected", zap.Int("route_count", len(routes)))
 
-		finalQuote = topSplitQuote
+		domain.SQSSplitConsideredTotal.WithLabelValues(splitChosenLabel).Inc()
+
+		// The top single route is not part of the selected quote. As a result, it is an alternative as well.
+		splitAlternatives := computeAlternativeQuotes(routesWithAmtOut, tokenIn, options.Alternatives, topSplitQuote)
+
+		if err := validateNonZeroAmountOut(topSplitQuote, tokenIn.Denom, tokenOutDenom); err != nil {
+			return nil, err
+		}
+
+		return finalizeQuote(topSplitQuote, splitAlternatives, filteredRoutes, options, expiresAt)
+	}
+
+	r.logger.Debug("single route selected over split",
+		zap.Stringer("route", topSingleRouteQuote.GetRoute()[0]),
+		zap.Stringer("single_amount_out", topSingleRouteQuote.GetAmountOut()),
+		zap.Stringer("split_amount_out", topSplitQuote.GetAmountOut()),
+	)
+
+	domain.SQSSplitConsideredTotal.WithLabelValues(splitNotChosenLabel).Inc()
+
+	if err := validateNonZeroAmountOut(topSingleRouteQuote, tokenIn.Denom, tokenOutDenom); err != nil {
+		return nil, err
+	}
+
+	return finalizeQuote(topSingleRouteQuote, alternatives, filteredRoutes, options, expiresAt)
+}
+
+// finalizeQuote attaches the alternative quotes, the rounding mode, the reference price and the expiry to the given quote
+// and returns it. The filtered routes are attached only if diagnostics are requested.
+// The expiry is not attached if zero.
+func finalizeQuote(quote domain.Quote, alternatives []domain.Quote, filteredRoutes []domain.FilteredRoute, options domain.RouterOptions, expiresAt time.Time) (domain.Quote, error) {
+	if q, ok := quote.(*quoteExactAmountIn); ok {
+		q.Alternatives = alternatives
+		q.SetRoundingMode(options.RoundingMode)
+		q.SetReferencePrice(options.ReferencePrice)
+
+		if options.Diagnostics {
+			q.FilteredRoutes = filteredRoutes
+		}
+
+		if !expiresAt.IsZero() {
+			q.SetExpiresAt(expiresAt)
+		}
 	}
 
-	r.logger.Debug("single route selected", zap.Stringer("route", finalQuote.GetRoute()[0]))
+	return quote, nil
+}
 
-	if finalQuote.GetAmountOut().IsZero() {
-		return nil, errors.New("best we can do is no tokens out")
+// validateNonZeroAmountOut returns domain.ZeroAmountOutError if the amount out of the given quote is zero.
+// Returns nil otherwise.
+func validateNonZeroAmountOut(quote domain.Quote, tokenInDenom, tokenOutDenom string) error {
+	if quote.GetAmountOut().IsZero() {
+		return domain.ZeroAmountOutError{
+			TokenIn:  tokenInDenom,
+			TokenOut: tokenOutDenom,
+		}
 	}
 
-	return finalQuote, nil
+	return nil
+}
+
+// computeQuoteExpiresAt returns the time until which a quote over routes computed at now is considered fresh.
+// Quotes over cached ranked routes expire with the cache entry instead.
+// The quote is fresh for the shortest of the configured candidate and ranked route cache TTLs
+// since its routes might be recomputed once either of the cache entries expires.
+// Returns zero time if the route cache is disabled or no TTL is configured.
+func computeQuoteExpiresAt(now time.Time, options domain.RouterOptions) time.Time {
+	if options.DisableCache {
+		return time.Time{}
+	}
+
+	ttlSeconds := options.RankedRouteCacheExpirySeconds
+	if ttlSeconds <= 0 || (options.CandidateRouteCacheExpirySeconds > 0 && options.CandidateRouteCacheExpirySeconds < ttlSeconds) {
+		ttlSeconds = options.CandidateRouteCacheExpirySeconds
+	}
+
+	if ttlSeconds <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(time.Duration(ttlSeconds) * time.Second)
+}
+
+// computeAlternativeQuotes returns the single route quotes over up to maxAlternatives of the ranked routes,
+// skipping the route of the selected quote if it consists of a single route.
+// The amounts out estimated while ranking are reused so that no route is re-estimated. In particular,
+// the generalized cosmwasm pools that are filtered out of the split routes are not queried again.
+// Routes that produce no tokens out are skipped.
+// CONTRACT: rankedRoutes are sorted in decreasing order by amount out.
+func computeAlternativeQuotes(rankedRoutes []RouteWithOutAmount, tokenIn sdk.Coin, maxAlternatives int, selectedQuote domain.Quote) []domain.Quote {
+	if maxAlternatives <= 0 || len(rankedRoutes) == 0 {
+		return nil
+	}
+
+	var selectedRoute domain.SplitRoute
+	if selectedRoutes := selectedQuote.GetRoute(); len(selectedRoutes) == 1 {
+		selectedRoute = selectedRoutes[0]
+	}
+
+	alternatives := make([]domain.Quote, 0, maxAlternatives)
+
+	for _, rankedRoute := range rankedRoutes {
+		if len(alternatives) == maxAlternatives {
+			break
+		}
+
+		if rankedRoute.OutAmount.IsNil() || !rankedRoute.OutAmount.IsPositive() {
+			continue
+		}
+
+		if selectedRoute != nil && haveSamePools(selectedRoute.GetPools(), rankedRoute.GetPools()) {
+			continue
+		}
+
+		alternativeRoute := rankedRoute
+		alternatives = append(alternatives, &quoteExactAmountIn{
+			AmountIn:  tokenIn,
+			AmountOut: alternativeRoute.OutAmount,
+			Route:     []domain.SplitRoute{&alternativeRoute},
+		})
+	}
+
+	return alternatives
+}
+
+// haveSamePools returns true if the given routes swap over the same pools in the same order.
+func haveSamePools(a, b []domain.RoutablePool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].GetId() != b[i].GetId() || a[i].GetTokenOutDenom() != b[i].GetTokenOutDenom() {
+			return false
+		}
+	}
+
+	return true
 }
 
 // GetOptimalQuoteInGivenOut returns an optimal quote through the pools for the exact amount out token swap method.
@@ -212,6 +428,18 @@ func (r *routerUseCaseImpl) GetOptimalQuoteInGivenOut(ctx context.Context, token
 		return nil, errors.New("quote is not a quoteExactAmountIn")
 	}
 
+	// Wrap the alternatives so that they are also prepared for the exact out swap method.
+	for i, alternative := range q.Alternatives {
+		alternativeQuote, ok := alternative.(*quoteExactAmountIn)
+		if !ok {
+			return nil, errors.New("alternative quote is not a quoteExactAmountIn")
+		}
+
+		q.Alternatives[i] = &quoteExactAmountOut{
+			quoteExactAmountIn: alternativeQuote,
+		}
+	}
+
 	return &quoteExactAmountOut{
 		quoteExactAmountIn: q,
 	}, nil
@@ -225,6 +453,7 @@ func (r *routerUseCaseImpl) GetSimpleQuote(ctx context.Context, tokenIn sdk.Coin
 		MaxRoutes:           r.defaultConfig.MaxRoutes,
 		MinPoolLiquidityCap: r.defaultConfig.MinPoolLiquidityCap,
 		MaxSplitRoutes:      r.defaultConfig.MaxSplitRoutes,
+		MaxPoolsConsidered:  r.defaultConfig.MaxPoolsConsidered,
 	}
 	// Apply options
 	for _, opt := range opts {
@@ -247,7 +476,9 @@ func (r *routerUseCaseImpl) GetSimpleQuote(ctx context.Context, tokenIn sdk.Coin
 	candidateRouteSearchOptions := domain.CandidateRouteSearchOptions{
 		MaxRoutes:           options.MaxRoutes,
 		MaxPoolsPerRoute:    options.MaxPoolsPerRoute,
+		MaxPoolsConsidered:  options.MaxPoolsConsidered,
 		MinPoolLiquidityCap: options.MinPoolLiquidityCap,
+		PoolFiltersAnyOf:    r.withDenomCandidateRoutePoolFilter(nil, tokenIn.Denom, tokenOutDenom),
 	}
 	candidateRoutes, err := r.candidateRouteSearcher.FindCandidateRoutes(tokenIn, tokenOutDenom, candidateRouteSearchOptions)
 	if err != nil {
@@ -263,22 +494,60 @@ func (r *routerUseCaseImpl) GetSimpleQuote(ctx context.Context, tokenIn sdk.Coin
 
 	topQuote, _, err := r.estimateAndRankSingleRouteQuote(ctx, routes, tokenIn, r.logger)
 	if err != nil {
-		return nil, fmt.Errorf("%s, tokenOutDenom (%s)", err, tokenOutDenom)
+		return nil, fmt.Errorf("%w, tokenOutDenom (%s)", err, tokenOutDenom)
 	}
 
 	return topQuote, nil
 }
 
+// GetRoutedSpotPrice implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) GetRoutedSpotPrice(ctx context.Context, baseDenom, quoteDenom string) (osmomath.BigDec, error) {
+	if baseDenom == quoteDenom {
+		return osmomath.BigDec{}, domain.SameDenomError{
+			DenomA: baseDenom,
+			DenomB: quoteDenom,
+		}
+	}
+
+	baseCoin := sdk.NewCoin(baseDenom, routedSpotPriceBaseAmount)
+	quote, err := r.GetSimpleQuote(ctx, baseCoin, quoteDenom)
+	if err != nil {
+		if errors.As(err, &domain.NoRoutesError{}) {
+			return osmomath.BigDec{}, domain.RoutedSpotPriceNoRouteError{
+				BaseDenom:  baseDenom,
+				QuoteDenom: quoteDenom,
+			}
+		}
+		return osmomath.BigDec{}, err
+	}
+
+	// If failed to compute out amount due to illiquidity (or any other reason), fail.
+	out := quote.GetAmountOut()
+	if out.IsNil() || out.IsZero() {
+		return osmomath.BigDec{}, domain.SpotPriceQuoteCalculatorOutAmountZeroError{
+			QuoteCoinStr: baseCoin.String(),
+			BaseDenom:    quoteDenom,
+		}
+	}
+
+	// Price of base in terms of quote is the quote amount received per unit of base swapped in.
+	spotPrice := osmomath.BigDecFromSDKInt(out).QuoMut(osmomath.BigDecFromSDKInt(baseCoin.Amount))
+
+	return spotPrice, nil
+}
+
 // filterAndConvertDuplicatePoolIDRankedRoutes filters ranked routes that contain duplicate pool IDs.
 // Routes with overlapping Alloyed and transmuter pools are not filtered out.
 // Additionally, the routes are converted into route.Route.Impl type.
+// Returns the filtered out routes as the second value.
 // CONTRACT: rankedRoutes are sorted in decreasing order by amount out
 // from first to last.
-func filterAndConvertDuplicatePoolIDRankedRoutes(rankedRoutes []RouteWithOutAmount) []route.RouteImpl {
+func filterAndConvertDuplicatePoolIDRankedRoutes(rankedRoutes []RouteWithOutAmount) ([]route.RouteImpl, []domain.FilteredRoute) {
 	// We use two maps for all routes and for the current route.
 	// This is so that if a route ends up getting filtered, its pool IDs are not added to the combined map.
 	combinedPoolIDsMap := make(map[uint64]struct{})
 	filteredRankedRoutes := make([]route.RouteImpl, 0)
+	filteredOutRoutes := make([]domain.FilteredRoute, 0)
 
 	for _, route := range rankedRoutes {
 		pools := route.GetPools()
@@ -308,6 +577,7 @@ func filterAndConvertDuplicatePoolIDRankedRoutes(rankedRoutes []RouteWithOutAmou
 
 		// If pool ID exists, we skip this route
 		if existsPoolID {
+			filteredOutRoutes = append(filteredOutRoutes, newFilteredRoute(route.RouteImpl, domain.FilteredRouteReasonDuplicatePoolID))
 			continue
 		}
 
@@ -319,46 +589,80 @@ func filterAndConvertDuplicatePoolIDRankedRoutes(rankedRoutes []RouteWithOutAmou
 		// Add route to filtered ranked routes
 		filteredRankedRoutes = append(filteredRankedRoutes, route.RouteImpl)
 	}
-	return filteredRankedRoutes
+	return filteredRankedRoutes, filteredOutRoutes
+}
+
+// newFilteredRoute returns the filtered route description of the given route with the given reason.
+func newFilteredRoute(filteredRoute route.RouteImpl, reason domain.FilteredRouteReason) domain.FilteredRoute {
+	pools := filteredRoute.GetPools()
+	poolIDs := make([]uint64, 0, len(pools))
+	for _, pool := range pools {
+		poolIDs = append(poolIDs, pool.GetId())
+	}
+
+	return domain.FilteredRoute{
+		PoolIDs: poolIDs,
+		Reason:  reason,
+	}
 }
 
 // rankRoutesByDirectQuote ranks the given candidate routes by estimating direct quotes over each route.
 // Additionally, it fileters out routes with duplicate pool IDs and cuts them for splits
-// based on the value of maxSplitRoutes.
-// Returns the top quote as well as the ranked routes in decrease order of amount out.
+// based on the value of maxSplitRoutes. The taker fees are charged according to takerFeeMode.
+// Returns the top quote as well as the ranked routes in decrease order of amount out,
+// all the routes with their estimated amounts out before filtering and cutting
+// and the routes filtered out from the ranked routes.
 // Returns error if:
 // - fails to read taker fees
 // - fails to convert candidate routes to routes
 // - fails to estimate direct quotes
-func (r *routerUseCaseImpl) rankRoutesByDirectQuote(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenIn sdk.Coin, tokenOutDenom string, maxSplitRoutes int) (domain.Quote, []route.RouteImpl, error) {
+func (r *routerUseCaseImpl) rankRoutesByDirectQuote(ctx context.Context, candidateRoutes sqsdomain.CandidateRoutes, tokenIn sdk.Coin, tokenOutDenom string, maxSplitRoutes int, takerFeeMode domain.TakerFeeMode) (domain.Quote, []route.RouteImpl, []RouteWithOutAmount, []domain.FilteredRoute, error) {
 	// Note that retrieving pools and taker fees is done in separate transactions.
 	// This is fine because taker fees don't change often.
 	routes, err := r.poolsUsecase.GetRoutesFromCandidates(candidateRoutes, tokenIn.Denom, tokenOutDenom)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+
+	// Charge the taker fees according to the requested mode.
+	for i := range routes {
+		routes[i] = routes[i].WithTakerFeeMode(takerFeeMode)
 	}
 
 	topQuote, routesWithAmtOut, err := r.estimateAndRankSingleRouteQuote(ctx, routes, tokenIn, r.logger)
 	if err != nil {
-		return nil, nil, fmt.Errorf("%s, tokenOutDenom (%s)", err, tokenOutDenom)
+		return nil, nil, nil, nil, fmt.Errorf("%s, tokenOutDenom (%s)", err, tokenOutDenom)
+	}
+
+	// Prefer the single route through the preferred pools on near-ties.
+	// The ranked routes are kept sorted by amount out for the split computation.
+	if r.defaultConfig.PreferredPoolTieToleranceBps > 0 && len(r.preferredPoolIDs) > 0 {
+		bestRoute := selectPreferredPoolRoute(routesWithAmtOut, r.preferredPoolIDs, r.defaultConfig.PreferredPoolTieToleranceBps)
+		topQuote = &quoteExactAmountIn{
+			AmountIn:  tokenIn,
+			AmountOut: bestRoute.OutAmount,
+			Route:     []domain.SplitRoute{&bestRoute},
+		}
 	}
 
 	// Update ranked routes with filtered ranked routes
-	routes = filterAndConvertDuplicatePoolIDRankedRoutes(routesWithAmtOut)
+	routes, duplicatePoolIDFilteredRoutes := filterAndConvertDuplicatePoolIDRankedRoutes(routesWithAmtOut)
 
 	// Cut routes for splits
-	routes = cutRoutesForSplits(maxSplitRoutes, routes)
+	routes, maxSplitRoutesFilteredRoutes := cutRoutesForSplits(maxSplitRoutes, routes)
 
-	return topQuote, routes, nil
+	return topQuote, routes, routesWithAmtOut, append(duplicatePoolIDFilteredRoutes, maxSplitRoutesFilteredRoutes...), nil
 }
 
 // computeAndRankRoutesByDirectQuote computes candidate routes and ranks them by token out after estimating direct quotes.
-func (r *routerUseCaseImpl) computeAndRankRoutesByDirectQuote(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, routingOptions domain.RouterOptions) (domain.Quote, []route.RouteImpl, error) {
-	tokenInOrderOfMagnitude := GetPrecomputeOrderOfMagnitude(tokenIn.Amount)
+// Returns all the routes with their estimated amounts out and the routes filtered out from the ranked routes alongside them.
+func (r *routerUseCaseImpl) computeAndRankRoutesByDirectQuote(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, routingOptions domain.RouterOptions) (domain.Quote, []route.RouteImpl, []RouteWithOutAmount, []domain.FilteredRoute, error) {
+	tokenInOrderOfMagnitude := domain.GetPrecomputeOrderOfMagnitude(tokenIn.Amount)
 
 	candidateRouteSearchOptions := domain.CandidateRouteSearchOptions{
 		MaxRoutes:           routingOptions.MaxRoutes,
 		MaxPoolsPerRoute:    routingOptions.MaxPoolsPerRoute,
+		MaxPoolsConsidered:  routingOptions.MaxPoolsConsidered,
 		MinPoolLiquidityCap: routingOptions.MinPoolLiquidityCap,
 		DisableCache:        routingOptions.DisableCache,
 		PoolFiltersAnyOf:    routingOptions.CandidateRoutesPoolFiltersAnyOf,
@@ -368,13 +672,21 @@ func (r *routerUseCaseImpl) computeAndRankRoutesByDirectQuote(ctx context.Contex
 	candidateRoutes, err := r.handleCandidateRoutes(ctx, tokenIn, tokenOutDenom, candidateRouteSearchOptions)
 	if err != nil {
 		r.logger.Error("error handling routes", zap.Error(err))
-		return nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+
+	// Restrict the candidate routes to the ones going through the required pool.
+	if routingOptions.RequiredPoolID != 0 {
+		candidateRoutes, err = r.filterCandidateRoutesByRequiredPool(candidateRoutes, tokenIn.Denom, tokenOutDenom, routingOptions.RequiredPoolID)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
 	}
 
 	// Get request path for metrics
 	requestURLPath, err := domain.GetURLPathFromContext(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	if !routingOptions.DisableCache {
@@ -388,19 +700,22 @@ func (r *routerUseCaseImpl) computeAndRankRoutesByDirectQuote(ctx context.Contex
 
 			r.rankedRouteCache.Set(formatRankedRouteCacheKey(tokenIn.Denom, tokenOutDenom, tokenInOrderOfMagnitude), candidateRoutes, time.Duration(routingOptions.RankedRouteCacheExpirySeconds/4)*time.Second)
 
-			return nil, nil, fmt.Errorf("no candidate routes found")
+			return nil, nil, nil, nil, domain.NoCandidateRoutesError{
+				TokenIn:  tokenIn.Denom,
+				TokenOut: tokenOutDenom,
+			}
 		}
 	}
 
 	// Rank candidate routes by estimating direct quotes
-	topSingleRouteQuote, rankedRoutes, err := r.rankRoutesByDirectQuote(ctx, candidateRoutes, tokenIn, tokenOutDenom, routingOptions.MaxSplitRoutes)
+	topSingleRouteQuote, rankedRoutes, routesWithAmtOut, filteredRoutes, err := r.rankRoutesByDirectQuote(ctx, candidateRoutes, tokenIn, tokenOutDenom, routingOptions.MaxSplitRoutes, routingOptions.TakerFeeMode)
 	if err != nil {
 		r.logger.Error("error getting ranked routes", zap.Error(err))
-		return nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	if len(rankedRoutes) == 0 {
-		return nil, nil, fmt.Errorf("no ranked routes found")
+		return nil, nil, nil, nil, fmt.Errorf("no ranked routes found")
 	}
 
 	// Convert ranked routes back to candidate for caching
@@ -425,12 +740,14 @@ func (r *routerUseCaseImpl) computeAndRankRoutesByDirectQuote(ctx context.Contex
 		}
 	}
 
-	return topSingleRouteQuote, rankedRoutes, nil
+	return topSingleRouteQuote, rankedRoutes, routesWithAmtOut, filteredRoutes, nil
 }
 
 var (
 	ErrTokenInDenomPoolNotFound  = fmt.Errorf("token in denom not found in pool")
 	ErrTokenOutDenomPoolNotFound = fmt.Errorf("token out denom not found in pool")
+
+	ErrOrderbookPoolInGivenOutNotSupported = fmt.Errorf("orderbook pool does not support exact amount out swaps")
 )
 
 // GetCustomDirectQuote implements mvc.RouterUsecase.
@@ -467,6 +784,43 @@ func (r *routerUseCaseImpl) GetCustomDirectQuote(ctx context.Context, tokenIn sd
 	return bestSingleRouteQuote, nil
 }
 
+// GetCustomDirectQuoteInGivenOut implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) GetCustomDirectQuoteInGivenOut(ctx context.Context, tokenOut sdk.Coin, tokenInDenom string, poolID uint64) (domain.Quote, error) {
+	pool, err := r.poolsUsecase.GetPool(poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	poolDenoms := pool.GetPoolDenoms()
+
+	if !osmoutils.Contains(poolDenoms, tokenInDenom) {
+		return nil, fmt.Errorf("denom %s in pool %d: %w", tokenInDenom, poolID, ErrTokenInDenomPoolNotFound)
+	}
+	if !osmoutils.Contains(poolDenoms, tokenOut.Denom) {
+		return nil, fmt.Errorf("denom %s in pool %d: %w", tokenOut.Denom, poolID, ErrTokenOutDenomPoolNotFound)
+	}
+
+	// Orderbook contract does not implement the MsgSwapExactAmountOut API.
+	if cosmWasmPoolModel := pool.GetSQSPoolModel().CosmWasmPoolModel; cosmWasmPoolModel != nil && cosmWasmPoolModel.IsOrderbook() {
+		return nil, fmt.Errorf("pool %d: %w", poolID, ErrOrderbookPoolInGivenOutNotSupported)
+	}
+
+	// Estimate by swapping the token out through the pool in the reverse direction.
+	quote, err := r.GetCustomDirectQuote(ctx, tokenOut, tokenInDenom, poolID)
+	if err != nil {
+		return nil, err
+	}
+
+	q, ok := quote.(*quoteExactAmountIn)
+	if !ok {
+		return nil, errors.New("quote is not a quoteExactAmountIn")
+	}
+
+	return &quoteExactAmountOut{
+		quoteExactAmountIn: q,
+	}, nil
+}
+
 // GetCustomDirectQuoteMultiPool implements mvc.RouterUsecase.
 func (r *routerUseCaseImpl) GetCustomDirectQuoteMultiPool(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom []string, poolIDs []uint64) (domain.Quote, error) {
 	if len(poolIDs) == 0 {
@@ -490,6 +844,14 @@ func (r *routerUseCaseImpl) GetCustomDirectQuoteMultiPool(ctx context.Context, t
 	for i, v := range poolIDs {
 		tokenOutDenom := tokenOutDenom[i]
 
+		// Validate the intermediate out denoms separately so that the broken hop
+		// is not reported as the final token out denom missing from the pool.
+		if i < len(poolIDs)-1 {
+			if err := r.validateIntermediateDenom(i, v, tokenIn.Denom, tokenOutDenom); err != nil {
+				return nil, err
+			}
+		}
+
 		quote, err := r.GetCustomDirectQuote(ctx, tokenIn, tokenOutDenom, v)
 		if err != nil {
 			return nil, err
@@ -528,29 +890,156 @@ func (r *routerUseCaseImpl) GetCustomDirectQuoteMultiPool(ctx context.Context, t
 	return &result, nil
 }
 
-// GetCustomDirectQuoteMultiPool implements mvc.RouterUsecase.
-func (r *routerUseCaseImpl) GetCustomDirectQuoteMultiPoolInGivenOut(ctx context.Context, tokenOut sdk.Coin, tokenInDenom []string, poolIDs []uint64) (domain.Quote, error) {
-	quote, err := r.GetCustomDirectQuoteMultiPool(ctx, tokenOut, tokenInDenom, poolIDs)
+// validateIntermediateDenom validates that the pool with the given ID produces the intermediate denom
+// at the given index of a custom multi-hop quote when swapping the given token in denom.
+// Returns ErrTokenInDenomPoolNotFound if the token in denom is not in the pool.
+// Returns IntermediateDenomMismatchError if the intermediate denom is not in the pool.
+func (r *routerUseCaseImpl) validateIntermediateDenom(index int, poolID uint64, tokenInDenom, intermediateDenom string) error {
+	pool, err := r.poolsUsecase.GetPool(poolID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	q, ok := quote.(*quoteExactAmountIn)
-	if !ok {
-		return nil, errors.New("quote is not a quoteExactAmountIn")
+	poolDenoms := pool.GetPoolDenoms()
+
+	if !osmoutils.Contains(poolDenoms, tokenInDenom) {
+		return fmt.Errorf("denom %s in pool %d: %w", tokenInDenom, poolID, ErrTokenInDenomPoolNotFound)
+	}
+
+	if !osmoutils.Contains(poolDenoms, intermediateDenom) {
+		producedDenoms := make([]string, 0, len(poolDenoms))
+		for _, denom := range poolDenoms {
+			if denom != tokenInDenom {
+				producedDenoms = append(producedDenoms, denom)
+			}
+		}
+
+		return IntermediateDenomMismatchError{
+			Index:    index,
+			Expected: intermediateDenom,
+			Actual:   strings.Join(producedDenoms, ","),
+		}
+	}
+
+	return nil
+}
+
+// GetCustomDirectQuoteMultiPoolInGivenOut implements mvc.RouterUsecase.
+// The pools are traversed in reverse, starting from the last pool that produces the token out.
+func (r *routerUseCaseImpl) GetCustomDirectQuoteMultiPoolInGivenOut(ctx context.Context, tokenOut sdk.Coin, tokenInDenom []string, poolIDs []uint64) (domain.Quote, error) {
+	if len(poolIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one pool ID should be specified", types.ErrValidationFailed)
+	}
+
+	if len(tokenInDenom) == 0 {
+		return nil, fmt.Errorf("%w: at least one token in denom should be specified", types.ErrValidationFailed)
+	}
+
+	// for each given pool we expect to have provided token in denom
+	if len(poolIDs) != len(tokenInDenom) {
+		return nil, fmt.Errorf("%w: number of pool ID should match number of in denom", types.ErrValidationFailed)
+	}
+
+	// The exact out quote is estimated by swapping the token out in the reverse direction.
+	// Hence, AmountIn is the token out of the asset pair.
+	result := quoteExactAmountIn{AmountIn: tokenOut}
+
+	pools := make([]domain.RoutablePool, 0, len(poolIDs))
+
+	for i := len(poolIDs) - 1; i >= 0; i-- {
+		poolID := poolIDs[i]
+		tokenInDenom := tokenInDenom[i]
+
+		// Validate the intermediate in denoms separately so that the broken hop
+		// is not reported as the final token in denom missing from the pool.
+		if i > 0 {
+			if err := r.validateIntermediateInDenom(i, poolID, tokenOut.Denom, tokenInDenom); err != nil {
+				return nil, err
+			}
+		}
+
+		quote, err := r.GetCustomDirectQuoteInGivenOut(ctx, tokenOut, tokenInDenom, poolID)
+		if err != nil {
+			return nil, err
+		}
+
+		route := quote.GetRoute()
+		if len(route) != 1 {
+			return nil, fmt.Errorf("custom direct quote must have 1 route, had: %d", len(route))
+		}
+
+		poolsInRoute := route[0].GetPools()
+		if len(poolsInRoute) != 1 {
+			return nil, fmt.Errorf("custom direct quote route must have 1 pool, had: %d", len(poolsInRoute))
+		}
+
+		// the amountOut value is the amount of the token in denom of the first pool
+		result.AmountOut = quote.GetAmountOut()
+
+		// append each pool to the route in the order of traversal from the token out
+		pools = append(pools, poolsInRoute...)
+
+		tokenOut = sdk.NewCoin(tokenInDenom, quote.GetAmountOut())
+	}
+
+	// Construct the final multi-hop custom direct quote route.
+	result.Route = []domain.SplitRoute{
+		&RouteWithOutAmount{
+			RouteImpl: route.RouteImpl{
+				Pools: pools,
+			},
+			OutAmount: result.AmountOut,
+			InAmount:  result.AmountIn.Amount,
+		},
 	}
 
 	return &quoteExactAmountOut{
-		quoteExactAmountIn: q,
+		quoteExactAmountIn: &result,
 	}, nil
 }
 
+// validateIntermediateInDenom validates that the pool with the given ID takes the intermediate in denom
+// at the given index of a custom multi-hop exact out quote to produce the given token out denom.
+// Returns ErrTokenOutDenomPoolNotFound if the token out denom is not in the pool.
+// Returns IntermediateDenomMismatchError if the intermediate denom is not in the pool.
+func (r *routerUseCaseImpl) validateIntermediateInDenom(index int, poolID uint64, tokenOutDenom, intermediateDenom string) error {
+	pool, err := r.poolsUsecase.GetPool(poolID)
+	if err != nil {
+		return err
+	}
+
+	poolDenoms := pool.GetPoolDenoms()
+
+	if !osmoutils.Contains(poolDenoms, tokenOutDenom) {
+		return fmt.Errorf("denom %s in pool %d: %w", tokenOutDenom, poolID, ErrTokenOutDenomPoolNotFound)
+	}
+
+	if !osmoutils.Contains(poolDenoms, intermediateDenom) {
+		takenDenoms := make([]string, 0, len(poolDenoms))
+		for _, denom := range poolDenoms {
+			if denom != tokenOutDenom {
+				takenDenoms = append(takenDenoms, denom)
+			}
+		}
+
+		return IntermediateDenomMismatchError{
+			Index:    index,
+			Expected: intermediateDenom,
+			Actual:   strings.Join(takenDenoms, ","),
+		}
+	}
+
+	return nil
+}
+
 // GetCandidateRoutes implements domain.RouterUsecase.
 func (r *routerUseCaseImpl) GetCandidateRoutes(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string) (sqsdomain.CandidateRoutes, error) {
 	candidateRouteSearchOptions := domain.CandidateRouteSearchOptions{
 		MaxRoutes:           r.defaultConfig.MaxRoutes,
 		MaxPoolsPerRoute:    r.defaultConfig.MaxPoolsPerRoute,
+		MaxPoolsConsidered:  r.defaultConfig.MaxPoolsConsidered,
 		MinPoolLiquidityCap: r.defaultConfig.MinPoolLiquidityCap,
+		PoolFiltersAnyOf:    r.withDenomCandidateRoutePoolFilter(nil, tokenIn.Denom, tokenOutDenom),
 	}
 
 	// Get the dynamic min pool liquidity cap for the given token in and token out denoms.
@@ -570,30 +1059,129 @@ func (r *routerUseCaseImpl) GetCandidateRoutes(ctx context.Context, tokenIn sdk.
 }
 
 // GetTakerFee implements mvc.RouterUsecase.
-func (r *routerUseCaseImpl) GetTakerFee(poolID uint64) ([]sqsdomain.TakerFeeForPair, error) {
+// The results are cached by pool ID until the taker fees are set.
+func (r *routerUseCaseImpl) GetTakerFee(poolID uint64) ([]domain.TakerFeeForPair, error) {
+	r.takerFeesCacheMu.RLock()
+	cachedTakerFees, ok := r.takerFeesCache[poolID]
+	generation := r.takerFeesCacheGeneration
+	r.takerFeesCacheMu.RUnlock()
+
+	if ok {
+		domain.SQSTakerFeesCacheHitsCounter.Inc()
+		return slices.Clone(cachedTakerFees), nil
+	}
+
+	domain.SQSTakerFeesCacheMissesCounter.Inc()
+
 	pool, err := r.poolsUsecase.GetPool(poolID)
 	if err != nil {
-		return []sqsdomain.TakerFeeForPair{}, err
+		return []domain.TakerFeeForPair{}, err
 	}
 
-	poolDenoms := pool.GetPoolDenoms()
+	takerFees, err := getPoolTakerFees(poolID, pool.GetPoolDenoms(), r.routerRepository.GetTakerFee, r.defaultConfig.DefaultTakerFeeFallback)
+	if err != nil {
+		return takerFees, err
+	}
 
-	result := make([]sqsdomain.TakerFeeForPair, 0)
+	r.takerFeesCacheMu.Lock()
+	// Skip caching if the cache was invalidated while computing the taker fees.
+	if generation == r.takerFeesCacheGeneration {
+		r.takerFeesCache[poolID] = slices.Clone(takerFees)
+	}
+	r.takerFeesCacheMu.Unlock()
+
+	return takerFees, nil
+}
+
+// GetTakerFeeForPair implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) GetTakerFeeForPair(denom0, denom1 string) (osmomath.Dec, bool) {
+	return r.routerRepository.GetTakerFee(denom0, denom1)
+}
+
+// invalidateTakerFeesCache clears the pool taker fees cache.
+func (r *routerUseCaseImpl) invalidateTakerFeesCache() {
+	r.takerFeesCacheMu.Lock()
+	r.takerFeesCache = make(map[uint64][]domain.TakerFeeForPair)
+	r.takerFeesCacheGeneration++
+	r.takerFeesCacheMu.Unlock()
+}
+
+// GetTakerFees implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) GetTakerFees(poolIDs []uint64) (map[uint64][]domain.TakerFeeForPair, error) {
+	type takerFeeLookup struct {
+		takerFee osmomath.Dec
+		found    bool
+	}
+
+	// Share the repository lookups across the pools with common denom pairs.
+	takerFeeLookups := make(map[sqsdomain.DenomPair]takerFeeLookup)
+	getTakerFee := func(denom0, denom1 string) (osmomath.Dec, bool) {
+		denomPair := sqsdomain.DenomPair{Denom0: denom0, Denom1: denom1}
+
+		lookup, ok := takerFeeLookups[denomPair]
+		if !ok {
+			lookup.takerFee, lookup.found = r.routerRepository.GetTakerFee(denom0, denom1)
+			takerFeeLookups[denomPair] = lookup
+		}
+
+		return lookup.takerFee, lookup.found
+	}
+
+	result := make(map[uint64][]domain.TakerFeeForPair, len(poolIDs))
+	for _, poolID := range poolIDs {
+		// Skip duplicate pool IDs.
+		if _, ok := result[poolID]; ok {
+			continue
+		}
+
+		pool, err := r.poolsUsecase.GetPool(poolID)
+		if err != nil {
+			return nil, err
+		}
+
+		takerFees, err := getPoolTakerFees(poolID, pool.GetPoolDenoms(), getTakerFee, r.defaultConfig.DefaultTakerFeeFallback)
+		if err != nil {
+			return nil, err
+		}
+
+		result[poolID] = takerFees
+	}
+
+	return result, nil
+}
+
+// getPoolTakerFees returns the taker fees for all denom pairs of the pool with the given ID and denoms
+// using the given taker fee getter.
+// If useDefaultFallback is true, sqsdomain.DefaultTakerFee is returned for the pairs with a missing taker fee
+// and flagged as default. Otherwise, returns domain.TakerFeeNotFoundForPoolError for the first such pair.
+func getPoolTakerFees(poolID uint64, poolDenoms []string, getTakerFee func(denom0, denom1 string) (osmomath.Dec, bool), useDefaultFallback bool) ([]domain.TakerFeeForPair, error) {
+	result := make([]domain.TakerFeeForPair, 0)
 
 	for i := range poolDenoms {
 		for j := i + 1; j < len(poolDenoms); j++ {
 			denom0 := poolDenoms[i]
 			denom1 := poolDenoms[j]
 
-			takerFee, ok := r.routerRepository.GetTakerFee(denom0, denom1)
+			takerFee, ok := getTakerFee(denom0, denom1)
+			if !ok && !useDefaultFallback {
+				return []domain.TakerFeeForPair{}, domain.TakerFeeNotFoundForPoolError{
+					PoolID: poolID,
+					Denom0: denom0,
+					Denom1: denom1,
+				}
+			}
+
 			if !ok {
-				return []sqsdomain.TakerFeeForPair{}, fmt.Errorf("taker fee not found for pool %d, denom in (%s), denom out (%s)", poolID, denom0, denom1)
+				takerFee = sqsdomain.DefaultTakerFee
 			}
 
-			result = append(result, sqsdomain.TakerFeeForPair{
-				Denom0:   denom0,
-				Denom1:   denom1,
-				TakerFee: takerFee,
+			result = append(result, domain.TakerFeeForPair{
+				TakerFeeForPair: sqsdomain.TakerFeeForPair{
+					Denom0:   denom0,
+					Denom1:   denom1,
+					TakerFee: takerFee,
+				},
+				IsDefault: !ok,
 			})
 		}
 	}
@@ -624,44 +1212,115 @@ func (r *routerUseCaseImpl) GetCachedCandidateRoutes(ctx context.Context, tokenI
 		}, false, nil
 	}
 
-	domain.SQSRoutesCacheHitsCounter.WithLabelValues(requestURLPath, candidateRouteCacheLabel).Inc()
-
 	candidateRoutes, ok := cachedCandidateRoutes.(sqsdomain.CandidateRoutes)
 	if !ok {
 		return sqsdomain.CandidateRoutes{}, false, fmt.Errorf("error casting candidate routes from cache")
 	}
 
+	// Evict the cached routes if any of their pools were removed or changed denoms.
+	isValid, err := r.ValidateCachedRoute(ctx, candidateRoutes)
+	if err != nil {
+		return sqsdomain.CandidateRoutes{}, false, err
+	}
+
+	if !isValid {
+		r.candidateRouteCache.Delete(formatCandidateRouteCacheKey(tokenInDenom, tokenOutDenom))
+
+		domain.SQSRoutesCacheMissesCounter.WithLabelValues(requestURLPath, candidateRouteCacheLabel).Inc()
+
+		return sqsdomain.CandidateRoutes{
+			Routes:        []sqsdomain.CandidateRoute{},
+			UniquePoolIDs: map[uint64]struct{}{},
+		}, false, nil
+	}
+
+	domain.SQSRoutesCacheHitsCounter.WithLabelValues(requestURLPath, candidateRouteCacheLabel).Inc()
+
 	return candidateRoutes, true, nil
 }
 
 // GetCachedRankedRoutes implements mvc.RouterUsecase.
 func (r *routerUseCaseImpl) GetCachedRankedRoutes(ctx context.Context, tokenInDenom string, tokenOutDenom string, tokenInOrderOfMagnitude int) (sqsdomain.CandidateRoutes, error) {
+	rankedRoutes, _, err := r.getCachedRankedRoutes(ctx, tokenInDenom, tokenOutDenom, tokenInOrderOfMagnitude)
+	return rankedRoutes, err
+}
+
+// getCachedRankedRoutes returns the cached ranked routes alongside the time at which their cache entry expires.
+// The expiration is zero if no routes are found or the cache entry never expires.
+func (r *routerUseCaseImpl) getCachedRankedRoutes(ctx context.Context, tokenInDenom string, tokenOutDenom string, tokenInOrderOfMagnitude int) (sqsdomain.CandidateRoutes, time.Time, error) {
 	if !r.defaultConfig.RouteCacheEnabled {
-		return sqsdomain.CandidateRoutes{}, nil
+		return sqsdomain.CandidateRoutes{}, time.Time{}, nil
 	}
 
 	// Get request path for metrics
 	requestURLPath, err := domain.GetURLPathFromContext(ctx)
 	if err != nil {
-		return sqsdomain.CandidateRoutes{}, err
+		return sqsdomain.CandidateRoutes{}, time.Time{}, err
 	}
 
-	cachedRankedRoutes, found := r.rankedRouteCache.Get(formatRankedRouteCacheKey(tokenInDenom, tokenOutDenom, tokenInOrderOfMagnitude))
+	cachedRankedRoutes, expiresAt, found := r.rankedRouteCache.GetWithExpiration(formatRankedRouteCacheKey(tokenInDenom, tokenOutDenom, tokenInOrderOfMagnitude))
 	if !found {
 		// Increase cache misses
 		domain.SQSRoutesCacheMissesCounter.WithLabelValues(requestURLPath, rankedRouteCacheLabel).Inc()
 
-		return sqsdomain.CandidateRoutes{}, nil
+		return sqsdomain.CandidateRoutes{}, time.Time{}, nil
 	}
 
-	domain.SQSRoutesCacheHitsCounter.WithLabelValues(requestURLPath, rankedRouteCacheLabel).Inc()
-
 	rankedRoutes, ok := cachedRankedRoutes.(sqsdomain.CandidateRoutes)
 	if !ok {
-		return sqsdomain.CandidateRoutes{}, fmt.Errorf("error casting candidate routes from cache")
+		return sqsdomain.CandidateRoutes{}, time.Time{}, fmt.Errorf("error casting candidate routes from cache")
+	}
+
+	// Evict the cached routes if any of their pools were removed or changed denoms.
+	isValid, err := r.ValidateCachedRoute(ctx, rankedRoutes)
+	if err != nil {
+		return sqsdomain.CandidateRoutes{}, time.Time{}, err
+	}
+
+	if !isValid {
+		r.rankedRouteCache.Delete(formatRankedRouteCacheKey(tokenInDenom, tokenOutDenom, tokenInOrderOfMagnitude))
+
+		domain.SQSRoutesCacheMissesCounter.WithLabelValues(requestURLPath, rankedRouteCacheLabel).Inc()
+
+		return sqsdomain.CandidateRoutes{}, time.Time{}, nil
+	}
+
+	domain.SQSRoutesCacheHitsCounter.WithLabelValues(requestURLPath, rankedRouteCacheLabel).Inc()
+
+	return rankedRoutes, expiresAt, nil
+}
+
+// ValidateCachedRoute implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) ValidateCachedRoute(ctx context.Context, routes sqsdomain.CandidateRoutes) (bool, error) {
+	for _, candidateRoute := range routes.Routes {
+		// The token in denom of the route is unknown. As a result, the first pool
+		// is only validated to contain its token out denom.
+		previousTokenOutDenom := ""
+
+		for _, candidatePool := range candidateRoute.Pools {
+			pool, err := r.poolsUsecase.GetPool(candidatePool.ID)
+			if err != nil {
+				if errors.As(err, &domain.PoolNotFoundError{}) {
+					return false, nil
+				}
+				return false, err
+			}
+
+			poolDenoms := pool.GetPoolDenoms()
+
+			if !slices.Contains(poolDenoms, candidatePool.TokenOutDenom) {
+				return false, nil
+			}
+
+			if previousTokenOutDenom != "" && !slices.Contains(poolDenoms, previousTokenOutDenom) {
+				return false, nil
+			}
+
+			previousTokenOutDenom = candidatePool.TokenOutDenom
+		}
 	}
 
-	return rankedRoutes, nil
+	return true, nil
 }
 
 // handleCandidateRoutes attempts to retrieve candidate routes from the cache. If no routes are cached, it will
@@ -740,27 +1399,40 @@ func (r *routerUseCaseImpl) StoreRouterStateFiles() error {
 
 // GetRouterStateJSON implements mvc.RouterUsecase.
 func (r *routerUseCaseImpl) GetRouterState() (domain.RouterState, error) {
-	// These pools do not contain tick model
-	pools, err := r.poolsUsecase.GetAllPools()
-
+	routerState, err := r.GetRouterStateSnapshot(true)
 	if err != nil {
 		return domain.RouterState{}, err
 	}
 
-	concentratedpoolIDs := make([]uint64, 0, len(pools))
-	for _, pool := range pools {
-		if pool.GetType() == poolmanagertypes.Concentrated {
-			concentratedpoolIDs = append(concentratedpoolIDs, pool.GetId())
-		}
+	if err := parsing.StorePools(routerState.Pools, routerState.TickMap, "pools.json"); err != nil {
+		return domain.RouterState{}, err
 	}
 
-	tickModelMap, err := r.poolsUsecase.GetTickModelMap(concentratedpoolIDs)
+	return routerState, nil
+}
+
+// GetRouterStateSnapshot implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) GetRouterStateSnapshot(includeTickMap bool) (domain.RouterState, error) {
+	// These pools do not contain tick model
+	pools, err := r.poolsUsecase.GetAllPools()
+
 	if err != nil {
 		return domain.RouterState{}, err
 	}
 
-	if err := parsing.StorePools(pools, tickModelMap, "pools.json"); err != nil {
-		return domain.RouterState{}, err
+	var tickModelMap map[uint64]*sqsdomain.TickModel
+	if includeTickMap {
+		concentratedpoolIDs := make([]uint64, 0, len(pools))
+		for _, pool := range pools {
+			if pool.GetType() == poolmanagertypes.Concentrated {
+				concentratedpoolIDs = append(concentratedpoolIDs, pool.GetId())
+			}
+		}
+
+		tickModelMap, err = r.poolsUsecase.GetTickModelMap(concentratedpoolIDs)
+		if err != nil {
+			return domain.RouterState{}, err
+		}
 	}
 
 	takerFeesMap := r.routerRepository.GetAllTakerFees()
@@ -827,17 +1499,25 @@ func convertRankedToCandidateRoutes(rankedRoutes []route.RouteImpl) sqsdomain.Ca
 // If max split routes is set to DisableSplitRoutes, it will return the top route.
 // If the number of routes is greater than the max split routes, it will keep only the top routes.
 // If the number of routes is less than or equal to the max split routes, it will return all the routes.
-func cutRoutesForSplits(maxSplitRoutes int, routes []route.RouteImpl) []route.RouteImpl {
+// Returns the cut routes as the second value.
+func cutRoutesForSplits(maxSplitRoutes int, routes []route.RouteImpl) ([]route.RouteImpl, []domain.FilteredRoute) {
+	numRoutesKept := len(routes)
+
 	// If split routes are disabled, return a single the top route
 	if maxSplitRoutes == domain.DisableSplitRoutes && len(routes) > 0 {
 		// If there are more routes than the max split routes, keep only the top routes
-		routes = routes[:1]
+		numRoutesKept = 1
 	} else if len(routes) > maxSplitRoutes {
 		// Keep only top routes for splits
-		routes = routes[:maxSplitRoutes]
+		numRoutesKept = maxSplitRoutes
+	}
+
+	filteredRoutes := make([]domain.FilteredRoute, 0, len(routes)-numRoutesKept)
+	for _, cutRoute := range routes[numRoutesKept:] {
+		filteredRoutes = append(filteredRoutes, newFilteredRoute(cutRoute, domain.FilteredRouteReasonMaxSplitRoutes))
 	}
 
-	return routes
+	return routes[:numRoutesKept], filteredRoutes
 }
 
 // ConvertMinTokensPoolLiquidityCapToFilter implements mvc.RouterUsecase.
@@ -870,6 +1550,16 @@ func (r *routerUseCaseImpl) GetMinPoolLiquidityCapFilter(tokenInDenom, tokenOutD
 	return minPoolLiquidityCapFilter, nil
 }
 
+// PreviewDynamicFilter implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) PreviewDynamicFilter(baseDenom, quoteDenom string) (uint64, uint64, error) {
+	minPoolLiquidityCapBetweenTokens, err := r.tokenMetadataHolder.GetMinPoolLiquidityCap(baseDenom, quoteDenom)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return minPoolLiquidityCapBetweenTokens, r.ConvertMinTokensPoolLiquidityCapToFilter(minPoolLiquidityCapBetweenTokens), nil
+}
+
 // GetPoolSpotPrice implements mvc.RouterUsecase.
 func (r *routerUseCaseImpl) GetPoolSpotPrice(ctx context.Context, poolID uint64, quoteAsset, baseAsset string) (osmomath.BigDec, error) {
 	poolTakerFee, ok := r.routerRepository.GetTakerFee(quoteAsset, baseAsset)
@@ -885,6 +1575,51 @@ func (r *routerUseCaseImpl) GetPoolSpotPrice(ctx context.Context, poolID uint64,
 	return spotPrice, nil
 }
 
+// RegisterCandidateRoutePoolFilter implements mvc.RouterUsecase.
+func (r *routerUseCaseImpl) RegisterCandidateRoutePoolFilter(filter domain.CandidateRoutePoolFiltrerCb) {
+	r.defaultCandidateRoutePoolFiltersMu.Lock()
+	r.defaultCandidateRoutePoolFilters = append(r.defaultCandidateRoutePoolFilters, filter)
+	r.defaultCandidateRoutePoolFiltersMu.Unlock()
+}
+
+// withDefaultCandidateRoutePoolFilters returns a new slice with the given filters
+// followed by the registered default candidate route pool filters.
+func (r *routerUseCaseImpl) withDefaultCandidateRoutePoolFilters(filters []domain.CandidateRoutePoolFiltrerCb) []domain.CandidateRoutePoolFiltrerCb {
+	r.defaultCandidateRoutePoolFiltersMu.RLock()
+	defer r.defaultCandidateRoutePoolFiltersMu.RUnlock()
+
+	if len(r.defaultCandidateRoutePoolFilters) == 0 {
+		return filters
+	}
+
+	result := make([]domain.CandidateRoutePoolFiltrerCb, 0, len(filters)+len(r.defaultCandidateRoutePoolFilters))
+	result = append(result, filters...)
+	result = append(result, r.defaultCandidateRoutePoolFilters...)
+	return result
+}
+
+// withDenomCandidateRoutePoolFilter returns a new slice with the given filters
+// followed by the filter of the configured allowed and denied denoms for the swap
+// from tokenInDenom to tokenOutDenom.
+// Returns the given filters as is if no denoms are configured.
+func (r *routerUseCaseImpl) withDenomCandidateRoutePoolFilter(filters []domain.CandidateRoutePoolFiltrerCb, tokenInDenom, tokenOutDenom string) []domain.CandidateRoutePoolFiltrerCb {
+	if len(r.allowedDenoms) == 0 && len(r.deniedDenoms) == 0 {
+		return filters
+	}
+
+	denomFilter := domain.CandidateRouteDenomFilterOptionCb{
+		AllowedDenoms: r.allowedDenoms,
+		DeniedDenoms:  r.deniedDenoms,
+		TokenInDenom:  tokenInDenom,
+		TokenOutDenom: tokenOutDenom,
+	}
+
+	result := make([]domain.CandidateRoutePoolFiltrerCb, 0, len(filters)+1)
+	result = append(result, filters...)
+	result = append(result, denomFilter.ShouldSkipPool)
+	return result
+}
+
 // SetSortedPools implements mvc.RouterUsecase.
 func (r *routerUseCaseImpl) SetSortedPools(pools []sqsdomain.PoolI) {
 	r.sortedPoolsMu.Lock()
@@ -895,6 +1630,8 @@ func (r *routerUseCaseImpl) SetSortedPools(pools []sqsdomain.PoolI) {
 // SetTakerFees implements mvc.RouterUsecase.
 func (r *routerUseCaseImpl) SetTakerFees(takerFees sqsdomain.TakerFeeMap) {
 	r.routerRepository.SetTakerFees(takerFees)
+
+	r.invalidateTakerFeesCache()
 }
 
 // GetSortedPools implements mvc.RouterUsecase.
@@ -912,10 +1649,13 @@ func (r *routerUseCaseImpl) GetConfig() domain.RouterConfig {
 // The reason for this is that making network requests to chain is expensive. Generalized cosmwasm pools
 // make such network requests.
 // As a result, we want to minimize the number of requests we make by excluding such routes from split quotes.
-func filterOutGeneralizedCosmWasmPoolRoutes(rankedRoutes []route.RouteImpl) []route.RouteImpl {
+// Returns the filtered out routes as the second value.
+func filterOutGeneralizedCosmWasmPoolRoutes(rankedRoutes []route.RouteImpl) ([]route.RouteImpl, []domain.FilteredRoute) {
 	result := make([]route.RouteImpl, 0)
+	filteredRoutes := make([]domain.FilteredRoute, 0)
 	for _, route := range rankedRoutes {
 		if route.ContainsGeneralizedCosmWasmPool() {
+			filteredRoutes = append(filteredRoutes, newFilteredRoute(route, domain.FilteredRouteReasonGeneralizedCosmWasmPool))
 			continue
 		}
 		result = append(result, route)
@@ -925,9 +1665,73 @@ func filterOutGeneralizedCosmWasmPoolRoutes(rankedRoutes []route.RouteImpl) []ro
 		// If there are more than one routes and all of them are generalized cosmwasm pools,
 		// then we return the top route.
 		result = append(result, rankedRoutes[0])
+		filteredRoutes = filteredRoutes[1:]
 	}
 
-	return result
+	return result, filteredRoutes
+}
+
+// filterCandidateRoutesByRequiredPool returns the candidate routes that go through the required pool.
+// The direct route through the required pool is merged into the result if the pool contains both
+// the token in and token out denoms and the route is not already present.
+// Returns RequiredPoolNotInRoutesError if no route goes through the required pool.
+func (r *routerUseCaseImpl) filterCandidateRoutesByRequiredPool(candidateRoutes sqsdomain.CandidateRoutes, tokenInDenom, tokenOutDenom string, requiredPoolID uint64) (sqsdomain.CandidateRoutes, error) {
+	filteredRoutes := sqsdomain.CandidateRoutes{
+		Routes:        make([]sqsdomain.CandidateRoute, 0, len(candidateRoutes.Routes)),
+		UniquePoolIDs: make(map[uint64]struct{}),
+	}
+
+	hasDirectRoute := false
+	for _, candidateRoute := range candidateRoutes.Routes {
+		containsRequiredPool := false
+		for _, pool := range candidateRoute.Pools {
+			if pool.ID == requiredPoolID {
+				containsRequiredPool = true
+				break
+			}
+		}
+
+		if !containsRequiredPool {
+			continue
+		}
+
+		if len(candidateRoute.Pools) == 1 {
+			hasDirectRoute = true
+		}
+
+		filteredRoutes.Routes = append(filteredRoutes.Routes, candidateRoute)
+		for _, pool := range candidateRoute.Pools {
+			filteredRoutes.UniquePoolIDs[pool.ID] = struct{}{}
+		}
+		if candidateRoute.IsCanonicalOrderboolRoute {
+			filteredRoutes.ContainsCanonicalOrderbook = true
+		}
+	}
+
+	if !hasDirectRoute {
+		pool, err := r.poolsUsecase.GetPool(requiredPoolID)
+		if err != nil {
+			return sqsdomain.CandidateRoutes{}, err
+		}
+
+		poolDenoms := pool.GetPoolDenoms()
+		if osmoutils.Contains(poolDenoms, tokenInDenom) && osmoutils.Contains(poolDenoms, tokenOutDenom) {
+			directRoute := r.createCandidateRouteByPoolID(tokenOutDenom, requiredPoolID)
+
+			filteredRoutes.Routes = append(filteredRoutes.Routes, directRoute.Routes...)
+			filteredRoutes.UniquePoolIDs[requiredPoolID] = struct{}{}
+		}
+	}
+
+	if len(filteredRoutes.Routes) == 0 {
+		return sqsdomain.CandidateRoutes{}, RequiredPoolNotInRoutesError{
+			PoolID:        requiredPoolID,
+			TokenInDenom:  tokenInDenom,
+			TokenOutDenom: tokenOutDenom,
+		}
+	}
+
+	return filteredRoutes, nil
 }
 
 // createCandidateRouteByPoolID constructs a candidate route with the desired pool.
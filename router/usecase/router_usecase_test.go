@@ -2,12 +2,14 @@ package usecase_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/spf13/viper"
 
 	"github.com/osmosis-labs/sqs/domain"
@@ -22,9 +24,13 @@ import (
 	"github.com/osmosis-labs/sqs/router/usecase/route"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
 	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
 
 	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/osmosis/osmoutils/osmoassert"
+	cosmwasmpoolmodel "github.com/osmosis-labs/osmosis/v26/x/cosmwasmpool/model"
 	"github.com/osmosis-labs/osmosis/v26/x/gamm/pool-models/balancer"
+	poolmanagertypes "github.com/osmosis-labs/osmosis/v26/x/poolmanager/types"
 )
 
 const (
@@ -153,6 +159,26 @@ func (s *RouterTestSuite) TestHandleRoutes() {
 			},
 		}
 
+		// removedPoolID is the ID of a pool that is not present in the pools usecase.
+		removedPoolID = defaultPool.GetId() + 2
+
+		staleRoutes = sqsdomain.CandidateRoutes{
+			Routes: []sqsdomain.CandidateRoute{
+				WithCandidateRoutePools(
+					EmptyCandidateRoute,
+					[]sqsdomain.CandidatePool{
+						{
+							ID:            removedPoolID,
+							TokenOutDenom: tokenOutDenom,
+						},
+					},
+				),
+			},
+			UniquePoolIDs: map[uint64]struct{}{
+				removedPoolID: {},
+			},
+		}
+
 		emptyRoutes = sqsdomain.CandidateRoutes{}
 
 		defaultRouterConfig = domain.RouterConfig{
@@ -192,6 +218,14 @@ func (s *RouterTestSuite) TestHandleRoutes() {
 			expectedCandidateRoutes: singleDefaultRoutes,
 			expectedIsCached:        true,
 		},
+		{
+			name: "stale routes in cache referencing a removed pool -> evicts and recomputes routes",
+
+			repositoryRoutes: staleRoutes,
+
+			expectedCandidateRoutes: singeldRecomputedRoutes,
+			expectedIsCached:        true,
+		},
 		{
 			name: "routes in cache but cache is disabled via options -> use them",
 
@@ -286,7 +320,14 @@ func (s *RouterTestSuite) TestHandleRoutes() {
 				candidateRouteCache.Set(usecase.FormatCandidateRouteCacheKey(tokenInDenom, tokenOutDenom), tc.repositoryRoutes, time.Hour)
 			}
 
-			poolsUseCaseMock := &mocks.PoolsUsecaseMock{}
+			poolsUseCaseMock := &mocks.PoolsUsecaseMock{
+				GetPoolFunc: func(poolID uint64) (sqsdomain.PoolI, error) {
+					if poolID == removedPoolID {
+						return nil, domain.PoolNotFoundError{PoolID: poolID}
+					}
+					return defaultPool, nil
+				},
+			}
 
 			tokenMetaDataHolder := mocks.TokenMetadataHolderMock{}
 			candidateRouteFinderMock := mocks.CandidateRouteFinderMock{
@@ -552,9 +593,15 @@ func (s *RouterTestSuite) TestFilterDuplicatePoolIDRoutes() {
 		tc := tc
 		s.Run(name, func() {
 
-			actualRoutes := usecase.FilterDuplicatePoolIDRoutes(tc.routes)
+			actualRoutes, filteredRoutes := usecase.FilterDuplicatePoolIDRoutes(tc.routes)
 
 			s.Require().Equal(len(tc.expectedRoutes), len(actualRoutes))
+
+			// All routes that are not kept are reported as filtered due to a duplicate pool ID.
+			s.Require().Len(filteredRoutes, len(tc.routes)-len(tc.expectedRoutes))
+			for _, filteredRoute := range filteredRoutes {
+				s.Require().Equal(domain.FilteredRouteReasonDuplicatePoolID, filteredRoute.Reason)
+			}
 		})
 	}
 }
@@ -793,7 +840,7 @@ func (s *RouterTestSuite) TestGetOptimalQuote_Cache_Overwrites() {
 
 			// Pre-set ranked route cache
 			if len(tc.preCachedRankedRoutes.Routes) > 0 {
-				tokeInOrderOfMagnitude := usecase.GetPrecomputeOrderOfMagnitude(tc.amountIn)
+				tokeInOrderOfMagnitude := domain.GetPrecomputeOrderOfMagnitude(tc.amountIn)
 				rankedRouteCache.Set(usecase.FormatRankedRouteCacheKey(defaultTokenInDenom, defaultTokenOutDenom, tokeInOrderOfMagnitude), tc.preCachedRankedRoutes, tc.cacheExpiryDuration)
 			}
 
@@ -1039,6 +1086,68 @@ func (s *RouterTestSuite) TestSortPools() {
 	}
 }
 
+// Validates that a cosmwasm pool with a nil cosmwasm pool model is skipped
+// by ValidateAndSortPools without affecting the valid pools.
+func (s *RouterTestSuite) TestValidateAndSortPools_NilCosmWasmPoolModel() {
+	const orderbookCodeID = uint64(885)
+
+	var (
+		balancerPool = &mocks.MockRoutablePool{
+			ID:               1,
+			PoolType:         poolmanagertypes.Balancer,
+			PoolLiquidityCap: osmomath.NewInt(100),
+		}
+
+		orderbookPool = &mocks.MockRoutablePool{
+			ChainPoolModel: &cosmwasmpoolmodel.CosmWasmPool{
+				PoolId: 2,
+				CodeId: orderbookCodeID,
+			},
+			ID:               2,
+			PoolType:         poolmanagertypes.CosmWasm,
+			PoolLiquidityCap: osmomath.NewInt(200),
+			CosmWasmPoolModel: &cosmwasmpool.CosmWasmPoolModel{
+				ContractInfo: cosmwasmpool.ContractInfo{
+					Contract: cosmwasmpool.ORDERBOOK_CONTRACT_NAME,
+					Version:  cosmwasmpool.ORDERBOOK_MIN_CONTRACT_VERSION,
+				},
+				Data: cosmwasmpool.CosmWasmPoolData{
+					Orderbook: &cosmwasmpool.OrderbookData{},
+				},
+			},
+		}
+
+		nilModelOrderbookPool = &mocks.MockRoutablePool{
+			ChainPoolModel: &cosmwasmpoolmodel.CosmWasmPool{
+				PoolId: 3,
+				CodeId: orderbookCodeID,
+			},
+			ID:               3,
+			PoolType:         poolmanagertypes.CosmWasm,
+			PoolLiquidityCap: osmomath.NewInt(300),
+		}
+	)
+
+	cosmWasmPoolsConfig := domain.CosmWasmPoolRouterConfig{
+		OrderbookCodeIDs: map[uint64]struct{}{
+			orderbookCodeID: {},
+		},
+	}
+
+	initialSkippedCount := testutil.ToFloat64(domain.SQSRoutingNilCosmWasmPoolModelCounter)
+
+	sortedPools, orderbookPools := usecase.ValidateAndSortPools([]sqsdomain.PoolI{balancerPool, nilModelOrderbookPool, orderbookPool}, cosmWasmPoolsConfig, []uint64{}, noOpLogger)
+
+	s.Require().Len(sortedPools, 2)
+	s.Require().Equal(orderbookPool.GetId(), sortedPools[0].GetId())
+	s.Require().Equal(balancerPool.GetId(), sortedPools[1].GetId())
+
+	s.Require().Len(orderbookPools, 1)
+	s.Require().Equal(orderbookPool.GetId(), orderbookPools[0].GetId())
+
+	s.Require().Equal(initialSkippedCount+1, testutil.ToFloat64(domain.SQSRoutingNilCosmWasmPoolModelCounter))
+}
+
 // Validates ConvertMinTokensPoolLiquidityCapToFilter method per its spec.
 func (s *RouterTestSuite) TestConvertMinTokensPoolLiquidityCapToFilter() {
 	var (
@@ -1145,6 +1254,90 @@ func (s *RouterTestSuite) TestConvertMinTokensPoolLiquidityCapToFilter() {
 	}
 }
 
+// Validates that PreviewDynamicFilter returns the min pool liquidity cap between the denoms
+// and the filter it maps to.
+func (s *RouterTestSuite) TestPreviewDynamicFilter() {
+	var (
+		defaultFilters = routertesting.DefaultRouterConfig.DynamicMinLiquidityCapFiltersDesc
+
+		defaultConfigFilter = routertesting.DefaultRouterConfig.MinPoolLiquidityCap
+
+		defaultThresholdMinPoolLiquidityCap = defaultFilters[0].MinTokensCap
+
+		defaultAboveThresholdFilterValue = defaultFilters[0].FilterValue
+
+		capOneBelowMinThreshold = defaultFilters[len(defaultFilters)-1].MinTokensCap - 1
+
+		errMetadataNotFound = errors.New("metadata not found")
+	)
+
+	tests := []struct {
+		name string
+
+		minTokensPoolLiquidityCap      uint64
+		minTokensPoolLiquidityCapError error
+
+		expectedMinCap uint64
+		expectedFilter uint64
+		expectedErr    error
+	}{
+		{
+			name: "min pool liquidity cap at threshold -> return dynamic filter value",
+
+			minTokensPoolLiquidityCap: defaultThresholdMinPoolLiquidityCap,
+
+			expectedMinCap: defaultThresholdMinPoolLiquidityCap,
+			expectedFilter: defaultAboveThresholdFilterValue,
+		},
+		{
+			name: "min pool liquidity cap above threshold -> return dynamic filter value",
+
+			minTokensPoolLiquidityCap: defaultThresholdMinPoolLiquidityCap + 1,
+
+			expectedMinCap: defaultThresholdMinPoolLiquidityCap + 1,
+			expectedFilter: defaultAboveThresholdFilterValue,
+		},
+		{
+			name: "min pool liquidity cap below threshold -> return default filter value",
+
+			minTokensPoolLiquidityCap: capOneBelowMinThreshold,
+
+			expectedMinCap: capOneBelowMinThreshold,
+			expectedFilter: defaultConfigFilter,
+		},
+		{
+			name: "min pool liquidity cap not resolved -> error",
+
+			minTokensPoolLiquidityCapError: errMetadataNotFound,
+
+			expectedErr: errMetadataNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tokenMetadataHolder := &mocks.TokenMetadataHolderMock{
+				MockMinPoolLiquidityCap:      tt.minTokensPoolLiquidityCap,
+				MockMinPoolLiquidityCapError: tt.minTokensPoolLiquidityCapError,
+			}
+
+			routerUsecase := usecase.NewRouterUsecase(routerrepo.New(&log.NoOpLogger{}), &mocks.PoolsUsecaseMock{}, mocks.CandidateRouteFinderMock{}, tokenMetadataHolder, routertesting.DefaultRouterConfig, emptyCosmWasmPoolsRouterConfig, &log.NoOpLogger{}, cache.New(), cache.New())
+
+			// System under test
+			minCap, filter, err := routerUsecase.PreviewDynamicFilter(UOSMO, USDC)
+
+			if tt.expectedErr != nil {
+				s.Require().ErrorIs(err, tt.expectedErr)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(tt.expectedMinCap, minCap)
+			s.Require().Equal(tt.expectedFilter, filter)
+		})
+	}
+}
+
 // This test runs tests against GetCustomDirectQuotes to ensure that the method correctly calculates
 // quote across multi pool route.
 func (s *RouterTestSuite) TestGetCustomQuote_GetCustomDirectQuotes_Mainnet_UOSMOUSDC() {
@@ -1258,16 +1451,38 @@ func (s *RouterTestSuite) TestGetCustomQuote_GetCustomDirectQuotes_Mainnet_UOSMO
 			expectedPoolID:      []uint64{1093, 1301},
 		},
 		{
-			name:          "Multi pool: OSMO-USDC - fail case",
+			name:          "Multi pool: OSMO-USDC - fail case: in denom not found in second pool",
 			tokenIn:       sdk.NewCoin(UOSMO, amountIn),
 			tokenOutDenom: []string{ATOM, USDT},
 			poolID: []uint64{
 				1,    // OSMO - ATOM
 				1301, // AKT - USDC
 			},
-			expectedNumOfRoutes: 2,
-			expectedPoolID:      []uint64{1093, 1301},
-			err:                 usecase.ErrTokenInDenomPoolNotFound,
+			err: usecase.ErrTokenInDenomPoolNotFound,
+		},
+		{
+			name:          "Multi pool: OSMO-USDC - fail case: intermediate denom not produced by first pool",
+			tokenIn:       sdk.NewCoin(UOSMO, amountIn),
+			tokenOutDenom: []string{ATOM, USDC},
+			poolID: []uint64{
+				1093, // OSMO - AKT
+				1301, // AKT - USDC
+			},
+			err: usecase.IntermediateDenomMismatchError{
+				Index:    0,
+				Expected: ATOM,
+				Actual:   AKT,
+			},
+		},
+		{
+			name:          "Multi pool: OSMO-USDC - fail case: out denom not found in last pool",
+			tokenIn:       sdk.NewCoin(UOSMO, amountIn),
+			tokenOutDenom: []string{AKT, ATOM},
+			poolID: []uint64{
+				1093, // OSMO - AKT
+				1301, // AKT - USDC
+			},
+			err: usecase.ErrTokenOutDenomPoolNotFound,
 		},
 	}
 
@@ -1375,16 +1590,25 @@ func (s *RouterTestSuite) TestGetCustomQuote_GetCustomDirectQuotesInGivenOut_Mai
 			expectedPoolID:      []uint64{1},
 		},
 		{
-			name:         "Single pool: OSMO-ATOM - fail case: out denom not found",
+			name:         "Single pool: ATOM-OSMO - fail case: in denom not found",
 			tokenOut:     sdk.NewCoin(UOSMO, amountOut),
 			tokenInDenom: []string{ATOM},
 			poolID: []uint64{
 				1093, // OSMO - AKT
 			},
+			err: usecase.ErrTokenInDenomPoolNotFound,
+		},
+		{
+			name:         "Single pool: OSMO-ATOM - fail case: out denom not found",
+			tokenOut:     sdk.NewCoin(ATOM, amountOut),
+			tokenInDenom: []string{UOSMO},
+			poolID: []uint64{
+				1093, // OSMO - AKT
+			},
 			err: usecase.ErrTokenOutDenomPoolNotFound,
 		},
 		{
-			name:         "Single pool: ATOM-OSMO - fail case: in denom not found",
+			name:         "Single pool: ATOM-OSMO - fail case: neither denom found",
 			tokenOut:     sdk.NewCoin(ATOM, amountOut),
 			tokenInDenom: []string{UOSMO},
 			poolID: []uint64{
@@ -1393,27 +1617,50 @@ func (s *RouterTestSuite) TestGetCustomQuote_GetCustomDirectQuotesInGivenOut_Mai
 			err: usecase.ErrTokenInDenomPoolNotFound,
 		},
 		{
-			name:         "Multi pool: OSMO-USDC - happy case",
+			name:         "Multi pool: USDC-OSMO - happy case",
 			tokenOut:     sdk.NewCoin(UOSMO, amountOut),
-			tokenInDenom: []string{AKT, USDC},
+			tokenInDenom: []string{USDC, AKT},
 			poolID: []uint64{
-				1093, // OSMO - AKT
 				1301, // AKT - USDC
+				1093, // OSMO - AKT
 			},
 			expectedNumOfRoutes: 1,
-			expectedPoolID:      []uint64{1093, 1301},
+			// traversed in reverse from the token out
+			expectedPoolID: []uint64{1093, 1301},
 		},
 		{
-			name:         "Multi pool: OSMO-USDC - fail case",
+			name:         "Multi pool: USDT-OSMO - fail case: in denom not found in first pool",
 			tokenOut:     sdk.NewCoin(UOSMO, amountOut),
-			tokenInDenom: []string{ATOM, USDT},
+			tokenInDenom: []string{USDT, AKT},
 			poolID: []uint64{
-				1,    // OSMO - ATOM
 				1301, // AKT - USDC
+				1093, // OSMO - AKT
+			},
+			err: usecase.ErrTokenInDenomPoolNotFound,
+		},
+		{
+			name:         "Multi pool: USDC-OSMO - fail case: out denom not found in last pool",
+			tokenOut:     sdk.NewCoin(UOSMO, amountOut),
+			tokenInDenom: []string{USDC, AKT},
+			poolID: []uint64{
+				1093, // OSMO - AKT
+				1301, // AKT - USDC
+			},
+			err: usecase.ErrTokenOutDenomPoolNotFound,
+		},
+		{
+			name:         "Multi pool: USDC-OSMO - fail case: intermediate denom not in last pool",
+			tokenOut:     sdk.NewCoin(UOSMO, amountOut),
+			tokenInDenom: []string{USDC, ATOM},
+			poolID: []uint64{
+				1301, // AKT - USDC
+				1093, // OSMO - AKT
+			},
+			err: usecase.IntermediateDenomMismatchError{
+				Index:    1,
+				Expected: ATOM,
+				Actual:   AKT,
 			},
-			expectedNumOfRoutes: 2,
-			expectedPoolID:      []uint64{1093, 1301},
-			err:                 usecase.ErrTokenInDenomPoolNotFound,
 		},
 	}
 
@@ -1437,6 +1684,190 @@ func (s *RouterTestSuite) TestGetCustomQuote_GetCustomDirectQuotesInGivenOut_Mai
 	}
 }
 
+// This test validates that the 2-hop custom direct quote for the exact amount out swap method
+// takes the pools ordered as in the chain's exact amount out swap routes and matches
+// the chain's estimate for the same route within the error tolerance.
+// The tolerance is needed since the exact amount out quote is estimated by swapping the token out
+// through the pools in the reverse direction rather than with the chain's in given out math,
+// applying the price impact in the opposite direction.
+func (s *RouterTestSuite) TestGetCustomDirectQuoteMultiPoolInGivenOut_TwoHop() {
+	s.Setup()
+
+	var (
+		tokenOut = sdk.NewCoin(DenomThree, osmomath.NewInt(1_000_000))
+
+		errTolerance = osmomath.ErrTolerance{
+			MultiplicativeTolerance: osmomath.MustNewDecFromStr("0.00001"),
+		}
+	)
+
+	// Prepare the pools of the route from the token in to the token out.
+	var (
+		firstPoolID  = s.PrepareBalancerPoolWithCoins(sdk.NewCoin(DenomOne, osmomath.NewInt(1_000_000_000_000)), sdk.NewCoin(DenomTwo, osmomath.NewInt(2_000_000_000_000)))
+		secondPoolID = s.PrepareBalancerPoolWithCoins(sdk.NewCoin(DenomTwo, osmomath.NewInt(3_000_000_000_000)), sdk.NewCoin(DenomThree, osmomath.NewInt(1_000_000_000_000)))
+	)
+
+	pools := make([]sqsdomain.PoolI, 0, 2)
+	for _, poolID := range []uint64{firstPoolID, secondPoolID} {
+		chainPool, err := s.App.PoolManagerKeeper.GetPool(s.Ctx, poolID)
+		s.Require().NoError(err)
+
+		balances := s.App.BankKeeper.GetAllBalances(s.Ctx, chainPool.GetAddress())
+
+		pools = append(pools, &sqsdomain.PoolWrapper{
+			ChainModel: chainPool,
+			SQSModel: sqsdomain.SQSPool{
+				Balances:     balances,
+				PoolDenoms:   balances.Denoms(),
+				SpreadFactor: chainPool.GetSpreadFactor(s.Ctx),
+			},
+		})
+	}
+
+	// Setup router repository mock with the chain's taker fees in both directions
+	// since the pools are swapped through in reverse.
+	routerRepositoryMock := routerrepo.New(&log.NoOpLogger{})
+	for denomPair, takerFee := range s.getTakerFeeMapForAllPoolTokenPairs(pools) {
+		routerRepositoryMock.SetTakerFee(denomPair.Denom0, denomPair.Denom1, takerFee)
+		routerRepositoryMock.SetTakerFee(denomPair.Denom1, denomPair.Denom0, takerFee)
+	}
+
+	// Setup pools usecase mock.
+	poolsUsecase, err := poolsusecase.NewPoolsUsecase(&domain.PoolsConfig{}, "node-uri-placeholder", routerRepositoryMock, domain.UnsetScalingFactorGetterCb, &log.NoOpLogger{})
+	s.Require().NoError(err)
+	poolsUsecase.StorePools(pools)
+
+	routerUsecase := usecase.NewRouterUsecase(routerRepositoryMock, poolsUsecase, mocks.CandidateRouteFinderMock{}, &mocks.TokenMetadataHolderMock{}, routertesting.DefaultRouterConfig, emptyCosmWasmPoolsRouterConfig, &log.NoOpLogger{}, cache.New(), cache.New())
+
+	// Estimate the same route with the chain's exact amount out math.
+	expectedAmountIn, err := s.App.PoolManagerKeeper.MultihopEstimateInGivenExactAmountOut(s.Ctx, []poolmanagertypes.SwapAmountOutRoute{
+		{PoolId: firstPoolID, TokenInDenom: DenomOne},
+		{PoolId: secondPoolID, TokenInDenom: DenomTwo},
+	}, tokenOut)
+	s.Require().NoError(err)
+
+	// System under test
+	quote, err := routerUsecase.GetCustomDirectQuoteMultiPoolInGivenOut(context.Background(), tokenOut, []string{DenomOne, DenomTwo}, []uint64{firstPoolID, secondPoolID})
+	s.Require().NoError(err)
+
+	_, _, err = quote.PrepareResult(context.Background(), osmomath.OneDec(), &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	// After preparing the result, the amounts are presented in the exact out direction.
+	outQuote, ok := quote.(*usecase.QuoteExactAmountOutImpl)
+	s.Require().True(ok)
+	s.Require().Equal(tokenOut, outQuote.AmountOut)
+	osmoassert.Equal(s.T(), errTolerance, expectedAmountIn, outQuote.AmountIn)
+
+	routes := quote.GetRoute()
+	s.Require().Len(routes, 1)
+
+	// The pools are traversed from the token out and take the denoms in reverse.
+	routePools := routes[0].GetPools()
+	s.Require().Len(routePools, 2)
+	s.Require().Equal(secondPoolID, routePools[0].GetId())
+	s.Require().Equal(DenomTwo, routePools[0].GetTokenInDenom())
+	s.Require().Equal(firstPoolID, routePools[1].GetId())
+	s.Require().Equal(DenomOne, routePools[1].GetTokenInDenom())
+}
+
+// This test validates the custom direct quote for the exact amount out swap method over a single pool,
+// including the cases where either denom is not in the pool and where the pool is an orderbook.
+func (s *RouterTestSuite) TestGetCustomDirectQuoteInGivenOut_Mainnet() {
+	var (
+		orderbookCodeId = uint64(885)
+
+		amountOut = osmomath.NewInt(5000000)
+	)
+
+	mainnetState := s.SetupMainnetState()
+
+	// Setup router repository mock
+	routerRepositoryMock := routerrepo.New(&log.NoOpLogger{})
+	routerRepositoryMock.SetTakerFees(mainnetState.TakerFeeMap)
+
+	// Setup pools usecase mock.
+	poolsUsecase, err := poolsusecase.NewPoolsUsecase(&domain.PoolsConfig{
+		OrderbookCodeIDs: []uint64{
+			orderbookCodeId,
+		},
+	}, "node-uri-placeholder", routerRepositoryMock, domain.UnsetScalingFactorGetterCb, &log.NoOpLogger{})
+	s.Require().NoError(err)
+	poolsUsecase.StorePools(mainnetState.Pools)
+
+	tokenMetaDataHolder := mocks.TokenMetadataHolderMock{}
+	candidateRouteFinderMock := mocks.CandidateRouteFinderMock{}
+
+	routerUsecase := usecase.NewRouterUsecase(routerRepositoryMock, poolsUsecase, candidateRouteFinderMock, &tokenMetaDataHolder, routertesting.DefaultRouterConfig, domain.CosmWasmPoolRouterConfig{
+		OrderbookCodeIDs: map[uint64]struct{}{
+			orderbookCodeId: {},
+		},
+	}, &log.NoOpLogger{}, cache.New(), cache.New())
+
+	testCases := []struct {
+		name string
+
+		tokenOut     sdk.Coin
+		tokenInDenom string
+		poolID       uint64
+
+		err error
+	}{
+		{
+			name:         "happy case",
+			tokenOut:     sdk.NewCoin(UOSMO, amountOut),
+			tokenInDenom: ATOM,
+			poolID:       1, // OSMO - ATOM
+		},
+		{
+			name:         "in denom not found",
+			tokenOut:     sdk.NewCoin(UOSMO, amountOut),
+			tokenInDenom: AKT,
+			poolID:       1, // OSMO - ATOM
+			err:          usecase.ErrTokenInDenomPoolNotFound,
+		},
+		{
+			name:         "out denom not found",
+			tokenOut:     sdk.NewCoin(AKT, amountOut),
+			tokenInDenom: ATOM,
+			poolID:       1, // OSMO - ATOM
+			err:          usecase.ErrTokenOutDenomPoolNotFound,
+		},
+		{
+			name:         "orderbook pool",
+			tokenOut:     sdk.NewCoin(USDC, amountOut),
+			tokenInDenom: NATIVE_WBTC,
+			poolID:       1904, // WBTC - USDC orderbook
+			err:          usecase.ErrOrderbookPoolInGivenOutNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			quote, err := routerUsecase.GetCustomDirectQuoteInGivenOut(context.Background(), tc.tokenOut, tc.tokenInDenom, tc.poolID)
+			s.Require().ErrorIs(err, tc.err)
+			if err != nil {
+				return // nothing else to do
+			}
+
+			// Custom direct quote should have only one route
+			routes := quote.GetRoute()
+			s.Require().Len(routes, 1)
+			s.validateExpectedPoolIDsMultiHopRoute(routes[0].GetPools(), []uint64{tc.poolID})
+
+			// The amounts are inverted only when preparing the result.
+			s.Require().Equal(tc.tokenOut, quote.GetAmountIn())
+			s.Require().True(quote.GetAmountOut().IsPositive())
+
+			// The prepared route is in terms of the token in denom.
+			preparedRoutes, _, err := quote.PrepareResult(context.Background(), osmomath.OneDec(), &log.NoOpLogger{})
+			s.Require().NoError(err)
+			s.Require().Len(preparedRoutes, 1)
+			s.Require().Equal(tc.tokenInDenom, preparedRoutes[0].GetPools()[0].GetTokenInDenom())
+		})
+	}
+}
+
 func (s *RouterTestSuite) TestGetCustomQuote_GetCustomDirectQuotes_Mainnet_Orderbook() {
 	config := routertesting.DefaultRouterConfig
 	config.MaxPoolsPerRoute = 5
@@ -1611,13 +2042,171 @@ func (s *RouterTestSuite) TestCutRoutesForSplits() {
 	for _, tc := range testcases {
 		s.Run(tc.name, func() {
 
-			routes := usecase.CutRoutesForSplits(tc.maxSplitRoutes, tc.routes)
+			routes, filteredRoutes := usecase.CutRoutesForSplits(tc.maxSplitRoutes, tc.routes)
 
 			s.Require().Len(routes, tc.expectedRoutesLen)
+
+			// All routes that are cut are reported as filtered due to the max split routes.
+			s.Require().Len(filteredRoutes, len(tc.routes)-tc.expectedRoutesLen)
+			for _, filteredRoute := range filteredRoutes {
+				s.Require().Equal(domain.FilteredRouteReasonMaxSplitRoutes, filteredRoute.Reason)
+			}
 		})
 	}
 }
 
+// This test validates that the alternative quotes reuse the amounts out of the ranked routes
+// and skip the route of the selected quote only if it is a single route quote.
+func (s *RouterTestSuite) TestComputeAlternativeQuotes() {
+	tokenIn := sdk.NewCoin(DenomOne, osmomath.NewInt(1_000))
+
+	newRoute := func(amountOut int64, poolIDs ...uint64) usecase.RouteWithOutAmount {
+		pools := make([]domain.RoutablePool, 0, len(poolIDs))
+		for _, poolID := range poolIDs {
+			pools = append(pools, &mocks.MockRoutablePool{ID: poolID, TokenOutDenom: DenomTwo})
+		}
+
+		return usecase.RouteWithOutAmount{
+			RouteImpl: route.RouteImpl{Pools: pools},
+			InAmount:  tokenIn.Amount,
+			OutAmount: osmomath.NewInt(amountOut),
+		}
+	}
+
+	newQuote := func(routes ...usecase.RouteWithOutAmount) domain.Quote {
+		splitRoutes := make([]domain.SplitRoute, 0, len(routes))
+		for i := range routes {
+			splitRoutes = append(splitRoutes, &routes[i])
+		}
+
+		return &usecase.QuoteImpl{AmountIn: tokenIn, Route: splitRoutes}
+	}
+
+	var (
+		// Note: the amounts out differ from what the mock pools would estimate
+		// so that any re-estimation is detected.
+		topRoute    = newRoute(900, 1)
+		secondRoute = newRoute(800, 2, 3)
+		thirdRoute  = newRoute(700, 4)
+		zeroRoute   = newRoute(0, 5)
+
+		rankedRoutes = []usecase.RouteWithOutAmount{topRoute, secondRoute, zeroRoute, thirdRoute}
+	)
+
+	testcases := []struct {
+		name string
+
+		maxAlternatives int
+		selectedQuote   domain.Quote
+
+		expectedRoutes []usecase.RouteWithOutAmount
+	}{
+		{
+			name:            "no alternatives requested",
+			maxAlternatives: 0,
+			selectedQuote:   newQuote(topRoute),
+		},
+		{
+			name:            "single route selected: top route is skipped",
+			maxAlternatives: 3,
+			selectedQuote:   newQuote(topRoute),
+
+			expectedRoutes: []usecase.RouteWithOutAmount{secondRoute, thirdRoute},
+		},
+		{
+			name:            "single route selected: capped by max alternatives",
+			maxAlternatives: 1,
+			selectedQuote:   newQuote(topRoute),
+
+			expectedRoutes: []usecase.RouteWithOutAmount{secondRoute},
+		},
+		{
+			name:            "split selected: top route is an alternative",
+			maxAlternatives: 2,
+			selectedQuote:   newQuote(topRoute, secondRoute),
+
+			expectedRoutes: []usecase.RouteWithOutAmount{topRoute, secondRoute},
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			// System under test
+			alternatives := usecase.ComputeAlternativeQuotes(rankedRoutes, tokenIn, tc.maxAlternatives, tc.selectedQuote)
+
+			s.Require().Len(alternatives, len(tc.expectedRoutes))
+			for i, alternative := range alternatives {
+				expectedRoute := tc.expectedRoutes[i]
+
+				s.Require().Equal(tokenIn, alternative.GetAmountIn())
+				s.Require().Equal(expectedRoute.OutAmount, alternative.GetAmountOut())
+				s.Require().Len(alternative.GetRoute(), 1)
+				s.Require().Equal(expectedRoute.GetPools(), alternative.GetRoute()[0].GetPools())
+				s.Require().Equal(expectedRoute.OutAmount, alternative.GetRoute()[0].GetAmountOut())
+			}
+		})
+	}
+}
+
+// This test validates that the routes filtered out by each of the ranked route filters
+// are reported with the corresponding reason in a constructed scenario:
+// - Route 1: pool 1 -> kept
+// - Route 2: pools 1, 2 -> filtered due to the duplicate pool 1
+// - Route 3: pool 3 (generalized cosmwasm) -> kept by the duplicate filter, filtered from splits
+// - Route 4: pool 4 -> kept
+// - Route 5: pool 5 -> cut for max split routes of 3
+func (s *RouterTestSuite) TestRankedRouteFilters_FilteredRouteReasons() {
+	newRoute := func(containsGeneralizedCosmWasmPool bool, poolIDs ...uint64) usecase.RouteWithOutAmount {
+		pools := make([]domain.RoutablePool, 0, len(poolIDs))
+		for _, poolID := range poolIDs {
+			pools = append(pools, &mocks.MockRoutablePool{ID: poolID, SQSPoolType: domain.Balancer})
+		}
+
+		return usecase.RouteWithOutAmount{
+			RouteImpl: route.RouteImpl{
+				Pools:                      pools,
+				HasGeneralizedCosmWasmPool: containsGeneralizedCosmWasmPool,
+			},
+		}
+	}
+
+	rankedRoutes := []usecase.RouteWithOutAmount{
+		newRoute(false, 1),
+		newRoute(false, 1, 2),
+		newRoute(true, 3),
+		newRoute(false, 4),
+		newRoute(false, 5),
+	}
+
+	routes, duplicatePoolIDFilteredRoutes := usecase.FilterDuplicatePoolIDRoutes(rankedRoutes)
+	s.Require().Len(routes, 4)
+	s.Require().Equal([]domain.FilteredRoute{
+		{PoolIDs: []uint64{1, 2}, Reason: domain.FilteredRouteReasonDuplicatePoolID},
+	}, duplicatePoolIDFilteredRoutes)
+
+	routes, maxSplitRoutesFilteredRoutes := usecase.CutRoutesForSplits(3, routes)
+	s.Require().Len(routes, 3)
+	s.Require().Equal([]domain.FilteredRoute{
+		{PoolIDs: []uint64{5}, Reason: domain.FilteredRouteReasonMaxSplitRoutes},
+	}, maxSplitRoutesFilteredRoutes)
+
+	routes, generalizedCosmWasmPoolFilteredRoutes := usecase.FilterOutGeneralizedCosmWasmPoolRoutes(routes)
+	s.Require().Len(routes, 2)
+	s.Require().Equal([]domain.FilteredRoute{
+		{PoolIDs: []uint64{3}, Reason: domain.FilteredRouteReasonGeneralizedCosmWasmPool},
+	}, generalizedCosmWasmPoolFilteredRoutes)
+
+	// If all routes contain generalized cosmwasm pools, the top route is kept and not reported.
+	routes, generalizedCosmWasmPoolFilteredRoutes = usecase.FilterOutGeneralizedCosmWasmPoolRoutes([]route.RouteImpl{
+		newRoute(true, 3).RouteImpl,
+		newRoute(true, 6).RouteImpl,
+	})
+	s.Require().Len(routes, 1)
+	s.Require().Equal([]domain.FilteredRoute{
+		{PoolIDs: []uint64{6}, Reason: domain.FilteredRouteReasonGeneralizedCosmWasmPool},
+	}, generalizedCosmWasmPoolFilteredRoutes)
+}
+
 func (s *RouterTestSuite) TestGetMinPoolLiquidityCapFilter() {
 
 	const (
@@ -1718,3 +2307,692 @@ func (s *RouterTestSuite) validatePoolIDInRoute(routerUseCase mvc.RouterUsecase,
 	// Validate that the pool ID is the expected one
 	s.Require().Equal(expectedPoolID, routePools[0].GetId())
 }
+
+// Validates that the routed spot price is computed for pairs with a direct pool
+// as well as for pairs that are only reachable via multiple hops.
+// Additionally, validates that a typed error is returned when there is no route.
+func (s *RouterTestSuite) TestGetRoutedSpotPrice_Mainnet() {
+	// Routed spot prices include fees and the two directions are routed independently.
+	// As a result, we only expect the price to approximately equal the inverse of the reverse price.
+	errTolerance := osmomath.ErrTolerance{
+		MultiplicativeTolerance: osmomath.MustNewDecFromStr("0.05"),
+	}
+
+	testCases := []struct {
+		name       string
+		baseDenom  string
+		quoteDenom string
+
+		expectDirectPool bool
+		expectedError    error
+	}{
+		{
+			name:             "directly pooled pair",
+			baseDenom:        UOSMO,
+			quoteDenom:       USDC,
+			expectDirectPool: true,
+		},
+		{
+			name:             "multi-hop only pair",
+			baseDenom:        TIA,
+			quoteDenom:       stATOM,
+			expectDirectPool: false,
+		},
+		{
+			name:       "no route",
+			baseDenom:  UOSMO,
+			quoteDenom: "unknown",
+			expectedError: domain.RoutedSpotPriceNoRouteError{
+				BaseDenom:  UOSMO,
+				QuoteDenom: "unknown",
+			},
+		},
+		{
+			name:       "same denom",
+			baseDenom:  UOSMO,
+			quoteDenom: UOSMO,
+			expectedError: domain.SameDenomError{
+				DenomA: UOSMO,
+				DenomB: UOSMO,
+			},
+		},
+	}
+
+	mainnetState := s.SetupMainnetState()
+
+	for _, tc := range testCases {
+		tc := tc
+		s.Run(tc.name, func() {
+			mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+			// System under test
+			spotPrice, err := mainnetUseCase.Router.GetRoutedSpotPrice(context.Background(), tc.baseDenom, tc.quoteDenom)
+
+			if tc.expectedError != nil {
+				s.Require().Error(err)
+				s.Require().Equal(tc.expectedError, err)
+				return
+			}
+			s.Require().NoError(err)
+			s.Require().True(spotPrice.IsPositive())
+
+			// Validate whether the pair has a direct pool
+			directPools, err := mainnetUseCase.Pools.GetPoolsForPair(tc.baseDenom, tc.quoteDenom)
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectDirectPool, len(directPools) > 0)
+
+			// Validate against the reverse direction
+			reverseSpotPrice, err := mainnetUseCase.Router.GetRoutedSpotPrice(context.Background(), tc.quoteDenom, tc.baseDenom)
+			s.Require().NoError(err)
+
+			s.Require().Zero(errTolerance.CompareBigDec(osmomath.OneBigDec().QuoMut(reverseSpotPrice), spotPrice))
+		})
+	}
+}
+
+// Tests that GetTakerFees returns the taker fees for all denom pairs of the given pools.
+// In strict mode, the first pool and pair with a missing taker fee is identified in the error.
+// In fallback mode, the default taker fee is returned for the pairs with a missing taker fee.
+func (s *RouterTestSuite) TestGetTakerFees() {
+	const (
+		osmoAtomPoolID     uint64 = 1
+		osmoUsdcPoolID     uint64 = 2
+		atomUsdcOsmoPoolID uint64 = 3
+		missingFeePoolID   uint64 = 4
+		notFoundPoolID     uint64 = 5
+	)
+
+	var (
+		osmoAtomTakerFee = osmomath.MustNewDecFromStr("0.001")
+		osmoUsdcTakerFee = osmomath.MustNewDecFromStr("0.002")
+		atomUsdcTakerFee = osmomath.MustNewDecFromStr("0.003")
+		atomOsmoTakerFee = osmomath.MustNewDecFromStr("0.004")
+
+		pools = map[uint64]sqsdomain.PoolI{
+			osmoAtomPoolID:     &mocks.MockRoutablePool{ID: osmoAtomPoolID, Denoms: []string{UOSMO, ATOM}},
+			osmoUsdcPoolID:     &mocks.MockRoutablePool{ID: osmoUsdcPoolID, Denoms: []string{UOSMO, USDC}},
+			atomUsdcOsmoPoolID: &mocks.MockRoutablePool{ID: atomUsdcOsmoPoolID, Denoms: []string{ATOM, USDC, UOSMO}},
+			missingFeePoolID:   &mocks.MockRoutablePool{ID: missingFeePoolID, Denoms: []string{USDC, ATOM}},
+		}
+
+		osmoAtomTakerFees = []domain.TakerFeeForPair{
+			newTakerFeeForPair(UOSMO, ATOM, osmoAtomTakerFee, false),
+		}
+		osmoUsdcTakerFees = []domain.TakerFeeForPair{
+			newTakerFeeForPair(UOSMO, USDC, osmoUsdcTakerFee, false),
+		}
+		atomUsdcOsmoTakerFees = []domain.TakerFeeForPair{
+			newTakerFeeForPair(ATOM, USDC, atomUsdcTakerFee, false),
+			newTakerFeeForPair(ATOM, UOSMO, atomOsmoTakerFee, false),
+			newTakerFeeForPair(USDC, UOSMO, osmoUsdcTakerFee, false),
+		}
+		missingFeeDefaultTakerFees = []domain.TakerFeeForPair{
+			newTakerFeeForPair(USDC, ATOM, sqsdomain.DefaultTakerFee, true),
+		}
+	)
+
+	testCases := []struct {
+		name                    string
+		poolIDs                 []uint64
+		defaultTakerFeeFallback bool
+
+		expectedTakerFees map[uint64][]domain.TakerFeeForPair
+		expectedErr       error
+	}{
+		{
+			name:    "multiple pools",
+			poolIDs: []uint64{osmoAtomPoolID, osmoUsdcPoolID, atomUsdcOsmoPoolID},
+
+			expectedTakerFees: map[uint64][]domain.TakerFeeForPair{
+				osmoAtomPoolID:     osmoAtomTakerFees,
+				osmoUsdcPoolID:     osmoUsdcTakerFees,
+				atomUsdcOsmoPoolID: atomUsdcOsmoTakerFees,
+			},
+		},
+		{
+			name:    "duplicate pool IDs",
+			poolIDs: []uint64{osmoAtomPoolID, osmoAtomPoolID},
+
+			expectedTakerFees: map[uint64][]domain.TakerFeeForPair{
+				osmoAtomPoolID: osmoAtomTakerFees,
+			},
+		},
+		{
+			name:    "no pools",
+			poolIDs: []uint64{},
+
+			expectedTakerFees: map[uint64][]domain.TakerFeeForPair{},
+		},
+		{
+			name:    "strict: one pool with missing taker fee",
+			poolIDs: []uint64{osmoAtomPoolID, missingFeePoolID, osmoUsdcPoolID},
+
+			expectedErr: domain.TakerFeeNotFoundForPoolError{
+				PoolID: missingFeePoolID,
+				Denom0: USDC,
+				Denom1: ATOM,
+			},
+		},
+		{
+			name:                    "fallback: one pool with missing taker fee",
+			poolIDs:                 []uint64{osmoAtomPoolID, missingFeePoolID, osmoUsdcPoolID},
+			defaultTakerFeeFallback: true,
+
+			expectedTakerFees: map[uint64][]domain.TakerFeeForPair{
+				osmoAtomPoolID:   osmoAtomTakerFees,
+				missingFeePoolID: missingFeeDefaultTakerFees,
+				osmoUsdcPoolID:   osmoUsdcTakerFees,
+			},
+		},
+		{
+			name:    "pool not found",
+			poolIDs: []uint64{osmoAtomPoolID, notFoundPoolID},
+
+			expectedErr: domain.PoolNotFoundError{PoolID: notFoundPoolID},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			routerUsecase := newTakerFeeRouterUsecase(pools, tc.defaultTakerFeeFallback, map[sqsdomain.DenomPair]osmomath.Dec{
+				{Denom0: UOSMO, Denom1: ATOM}: osmoAtomTakerFee,
+				{Denom0: UOSMO, Denom1: USDC}: osmoUsdcTakerFee,
+				{Denom0: USDC, Denom1: UOSMO}: osmoUsdcTakerFee,
+				{Denom0: ATOM, Denom1: USDC}:  atomUsdcTakerFee,
+				{Denom0: ATOM, Denom1: UOSMO}: atomOsmoTakerFee,
+			})
+
+			// System under test
+			takerFees, err := routerUsecase.GetTakerFees(tc.poolIDs)
+
+			if tc.expectedErr != nil {
+				s.Require().Error(err)
+				s.Require().Equal(tc.expectedErr, err)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedTakerFees, takerFees)
+		})
+	}
+}
+
+// Tests that GetTakerFee errors on a missing taker fee in strict mode
+// and returns the default taker fee flagged as default in fallback mode.
+func (s *RouterTestSuite) TestGetTakerFee_DefaultTakerFeeFallback() {
+	const poolID uint64 = 1
+
+	var (
+		osmoAtomTakerFee = osmomath.MustNewDecFromStr("0.001")
+
+		pools = map[uint64]sqsdomain.PoolI{
+			poolID: &mocks.MockRoutablePool{ID: poolID, Denoms: []string{UOSMO, ATOM, USDC}},
+		}
+
+		takerFees = map[sqsdomain.DenomPair]osmomath.Dec{
+			{Denom0: UOSMO, Denom1: ATOM}: osmoAtomTakerFee,
+		}
+	)
+
+	s.Run("strict", func() {
+		routerUsecase := newTakerFeeRouterUsecase(pools, false, takerFees)
+
+		// System under test
+		_, err := routerUsecase.GetTakerFee(poolID)
+
+		s.Require().Error(err)
+		s.Require().Equal(domain.TakerFeeNotFoundForPoolError{
+			PoolID: poolID,
+			Denom0: UOSMO,
+			Denom1: USDC,
+		}, err)
+	})
+
+	s.Run("fallback", func() {
+		routerUsecase := newTakerFeeRouterUsecase(pools, true, takerFees)
+
+		// System under test
+		actualTakerFees, err := routerUsecase.GetTakerFee(poolID)
+
+		s.Require().NoError(err)
+		s.Require().Equal([]domain.TakerFeeForPair{
+			newTakerFeeForPair(UOSMO, ATOM, osmoAtomTakerFee, false),
+			newTakerFeeForPair(UOSMO, USDC, sqsdomain.DefaultTakerFee, true),
+			newTakerFeeForPair(ATOM, USDC, sqsdomain.DefaultTakerFee, true),
+		}, actualTakerFees)
+	})
+}
+
+// Tests that GetTakerFeeForPair returns the taker fee for the given denom pair
+// without requiring a pool ID.
+func (s *RouterTestSuite) TestGetTakerFeeForPair() {
+	var (
+		osmoAtomTakerFee = osmomath.MustNewDecFromStr("0.001")
+		atomOsmoTakerFee = osmomath.MustNewDecFromStr("0.002")
+
+		takerFees = map[sqsdomain.DenomPair]osmomath.Dec{
+			{Denom0: UOSMO, Denom1: ATOM}: osmoAtomTakerFee,
+			{Denom0: ATOM, Denom1: UOSMO}: atomOsmoTakerFee,
+		}
+	)
+
+	testCases := []struct {
+		name   string
+		denom0 string
+		denom1 string
+
+		expectedTakerFee osmomath.Dec
+		expectedFound    bool
+	}{
+		{
+			name:   "present pair",
+			denom0: UOSMO,
+			denom1: ATOM,
+
+			expectedTakerFee: osmoAtomTakerFee,
+			expectedFound:    true,
+		},
+		{
+			name:   "present pair - reverse direction",
+			denom0: ATOM,
+			denom1: UOSMO,
+
+			expectedTakerFee: atomOsmoTakerFee,
+			expectedFound:    true,
+		},
+		{
+			name:   "absent pair",
+			denom0: UOSMO,
+			denom1: USDC,
+
+			expectedFound: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			routerUsecase := newTakerFeeRouterUsecase(map[uint64]sqsdomain.PoolI{}, false, takerFees)
+
+			// System under test
+			takerFee, found := routerUsecase.GetTakerFeeForPair(tc.denom0, tc.denom1)
+
+			s.Require().Equal(tc.expectedFound, found)
+			if !tc.expectedFound {
+				s.Require().True(takerFee.IsNil())
+				return
+			}
+
+			s.Require().Equal(tc.expectedTakerFee, takerFee)
+		})
+	}
+}
+
+// Tests that GetOptimalQuote returns typed errors when there are no candidate routes
+// and when the best quote results in no tokens out.
+func (s *RouterTestSuite) TestGetOptimalQuote_TypedErrors() {
+	s.Run("no candidate routes", func() {
+		routerUsecase := usecase.NewRouterUsecase(routerrepo.New(&log.NoOpLogger{}), &mocks.PoolsUsecaseMock{}, mocks.CandidateRouteFinderMock{}, &mocks.TokenMetadataHolderMock{}, domain.RouterConfig{
+			RouteCacheEnabled: true,
+		}, emptyCosmWasmPoolsRouterConfig, &log.NoOpLogger{}, cache.New(), cache.New())
+
+		// System under test
+		_, err := routerUsecase.GetOptimalQuote(context.Background(), sdk.NewCoin(UOSMO, osmomath.NewInt(1_000_000)), ATOM)
+
+		s.Require().Equal(domain.NoCandidateRoutesError{TokenIn: UOSMO, TokenOut: ATOM}, err)
+
+		// Message is kept for compatibility.
+		s.Require().EqualError(err, "no candidate routes found")
+	})
+
+	s.Run("zero amount out", func() {
+		quote := &usecase.QuoteImpl{
+			AmountIn:  sdk.NewCoin(UOSMO, osmomath.OneInt()),
+			AmountOut: osmomath.ZeroInt(),
+		}
+
+		// System under test
+		err := usecase.ValidateNonZeroAmountOut(quote, UOSMO, ATOM)
+
+		s.Require().Equal(domain.ZeroAmountOutError{TokenIn: UOSMO, TokenOut: ATOM}, err)
+
+		// Message is kept for compatibility.
+		s.Require().EqualError(err, "best we can do is no tokens out")
+	})
+}
+
+// Tests that ValidateCachedRoute returns false if any of the pools in the cached routes
+// were removed or no longer contain the denoms that the routes swap over.
+func (s *RouterTestSuite) TestValidateCachedRoute() {
+	const (
+		osmoAtomPoolID uint64 = 1
+		atomUSDCPoolID uint64 = 2
+		removedPoolID  uint64 = 3
+		erroringPoolID uint64 = 4
+	)
+
+	var (
+		pools = map[uint64]sqsdomain.PoolI{
+			osmoAtomPoolID: &mocks.MockRoutablePool{ID: osmoAtomPoolID, Denoms: []string{UOSMO, ATOM}},
+			atomUSDCPoolID: &mocks.MockRoutablePool{ID: atomUSDCPoolID, Denoms: []string{ATOM, USDC}},
+		}
+
+		errGetPool = errors.New("failed to get pool")
+
+		newCandidateRoutes = func(candidatePools ...sqsdomain.CandidatePool) sqsdomain.CandidateRoutes {
+			return sqsdomain.CandidateRoutes{
+				Routes: []sqsdomain.CandidateRoute{WithCandidateRoutePools(EmptyCandidateRoute, candidatePools)},
+			}
+		}
+	)
+
+	tests := []struct {
+		name   string
+		routes sqsdomain.CandidateRoutes
+
+		expectedIsValid bool
+		expectedErr     error
+	}{
+		{
+			name:   "empty routes",
+			routes: sqsdomain.CandidateRoutes{},
+
+			expectedIsValid: true,
+		},
+		{
+			name:   "valid multi-hop route",
+			routes: newCandidateRoutes(sqsdomain.CandidatePool{ID: osmoAtomPoolID, TokenOutDenom: ATOM}, sqsdomain.CandidatePool{ID: atomUSDCPoolID, TokenOutDenom: USDC}),
+
+			expectedIsValid: true,
+		},
+		{
+			name:   "route referencing a removed pool",
+			routes: newCandidateRoutes(sqsdomain.CandidatePool{ID: osmoAtomPoolID, TokenOutDenom: ATOM}, sqsdomain.CandidatePool{ID: removedPoolID, TokenOutDenom: USDC}),
+
+			expectedIsValid: false,
+		},
+		{
+			name:   "pool no longer contains the token out denom",
+			routes: newCandidateRoutes(sqsdomain.CandidatePool{ID: osmoAtomPoolID, TokenOutDenom: USDC}),
+
+			expectedIsValid: false,
+		},
+		{
+			name:   "pool no longer contains the token out denom of the previous pool",
+			routes: newCandidateRoutes(sqsdomain.CandidatePool{ID: osmoAtomPoolID, TokenOutDenom: UOSMO}, sqsdomain.CandidatePool{ID: atomUSDCPoolID, TokenOutDenom: USDC}),
+
+			expectedIsValid: false,
+		},
+		{
+			name:   "error getting pool",
+			routes: newCandidateRoutes(sqsdomain.CandidatePool{ID: erroringPoolID, TokenOutDenom: ATOM}),
+
+			expectedErr: errGetPool,
+		},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			poolsUsecase := &mocks.PoolsUsecaseMock{
+				GetPoolFunc: func(poolID uint64) (sqsdomain.PoolI, error) {
+					if poolID == erroringPoolID {
+						return nil, errGetPool
+					}
+
+					pool, ok := pools[poolID]
+					if !ok {
+						return nil, domain.PoolNotFoundError{PoolID: poolID}
+					}
+					return pool, nil
+				},
+			}
+
+			routerUsecase := usecase.NewRouterUsecase(routerrepo.New(&log.NoOpLogger{}), poolsUsecase, mocks.CandidateRouteFinderMock{}, &mocks.TokenMetadataHolderMock{}, domain.RouterConfig{}, emptyCosmWasmPoolsRouterConfig, &log.NoOpLogger{}, cache.New(), cache.New())
+
+			// System under test
+			isValid, err := routerUsecase.ValidateCachedRoute(context.Background(), tc.routes)
+
+			if tc.expectedErr != nil {
+				s.Require().ErrorIs(err, tc.expectedErr)
+				s.Require().False(isValid)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expectedIsValid, isValid)
+		})
+	}
+}
+
+// Tests that GetTakerFee results are cached until the taker fees are set.
+func (s *RouterTestSuite) TestGetTakerFee_Cache() {
+	const poolID uint64 = 1
+
+	var (
+		initialTakerFee = osmomath.MustNewDecFromStr("0.001")
+		updatedTakerFee = osmomath.MustNewDecFromStr("0.002")
+
+		pools = map[uint64]sqsdomain.PoolI{
+			poolID: &mocks.MockRoutablePool{ID: poolID, Denoms: []string{UOSMO, ATOM}},
+		}
+	)
+
+	routerRepository := routerrepo.New(&log.NoOpLogger{})
+	routerRepository.SetTakerFee(UOSMO, ATOM, initialTakerFee)
+
+	getPoolCallCount := 0
+	poolsUsecase := &mocks.PoolsUsecaseMock{
+		GetPoolFunc: func(poolID uint64) (sqsdomain.PoolI, error) {
+			getPoolCallCount++
+			return pools[poolID], nil
+		},
+	}
+
+	routerUsecase := usecase.NewRouterUsecase(routerRepository, poolsUsecase, mocks.CandidateRouteFinderMock{}, &mocks.TokenMetadataHolderMock{}, domain.RouterConfig{}, emptyCosmWasmPoolsRouterConfig, &log.NoOpLogger{}, cache.New(), cache.New())
+
+	initialHits := testutil.ToFloat64(domain.SQSTakerFeesCacheHitsCounter)
+	initialMisses := testutil.ToFloat64(domain.SQSTakerFeesCacheMissesCounter)
+
+	// First call is a cache miss.
+	takerFees, err := routerUsecase.GetTakerFee(poolID)
+	s.Require().NoError(err)
+	s.Require().Equal([]domain.TakerFeeForPair{newTakerFeeForPair(UOSMO, ATOM, initialTakerFee, false)}, takerFees)
+	s.Require().Equal(1, getPoolCallCount)
+	s.Require().Equal(initialMisses+1, testutil.ToFloat64(domain.SQSTakerFeesCacheMissesCounter))
+
+	// Update the repository directly, bypassing the cache invalidation.
+	routerRepository.SetTakerFee(UOSMO, ATOM, updatedTakerFee)
+
+	// Second call is a cache hit, returning the cached taker fee.
+	takerFees, err = routerUsecase.GetTakerFee(poolID)
+	s.Require().NoError(err)
+	s.Require().Equal([]domain.TakerFeeForPair{newTakerFeeForPair(UOSMO, ATOM, initialTakerFee, false)}, takerFees)
+	s.Require().Equal(1, getPoolCallCount)
+	s.Require().Equal(initialHits+1, testutil.ToFloat64(domain.SQSTakerFeesCacheHitsCounter))
+
+	// Setting the taker fees invalidates the cache.
+	routerUsecase.SetTakerFees(sqsdomain.TakerFeeMap{
+		{Denom0: UOSMO, Denom1: ATOM}: updatedTakerFee,
+	})
+
+	// Third call is a cache miss, returning the updated taker fee.
+	takerFees, err = routerUsecase.GetTakerFee(poolID)
+	s.Require().NoError(err)
+	s.Require().Equal([]domain.TakerFeeForPair{newTakerFeeForPair(UOSMO, ATOM, updatedTakerFee, false)}, takerFees)
+	s.Require().Equal(2, getPoolCallCount)
+	s.Require().Equal(initialMisses+2, testutil.ToFloat64(domain.SQSTakerFeesCacheMissesCounter))
+	s.Require().Equal(initialHits+1, testutil.ToFloat64(domain.SQSTakerFeesCacheHitsCounter))
+}
+
+// newTakerFeeRouterUsecase returns a router usecase with the given pools and taker fees
+// for testing the taker fee queries.
+func newTakerFeeRouterUsecase(pools map[uint64]sqsdomain.PoolI, defaultTakerFeeFallback bool, takerFees map[sqsdomain.DenomPair]osmomath.Dec) mvc.RouterUsecase {
+	routerRepository := routerrepo.New(&log.NoOpLogger{})
+	for denomPair, takerFee := range takerFees {
+		routerRepository.SetTakerFee(denomPair.Denom0, denomPair.Denom1, takerFee)
+	}
+
+	poolsUsecase := &mocks.PoolsUsecaseMock{
+		GetPoolFunc: func(poolID uint64) (sqsdomain.PoolI, error) {
+			pool, ok := pools[poolID]
+			if !ok {
+				return nil, domain.PoolNotFoundError{PoolID: poolID}
+			}
+			return pool, nil
+		},
+	}
+
+	return usecase.NewRouterUsecase(routerRepository, poolsUsecase, mocks.CandidateRouteFinderMock{}, &mocks.TokenMetadataHolderMock{}, domain.RouterConfig{
+		DefaultTakerFeeFallback: defaultTakerFeeFallback,
+	}, emptyCosmWasmPoolsRouterConfig, &log.NoOpLogger{}, cache.New(), cache.New())
+}
+
+// newTakerFeeForPair returns a taker fee for the given pair.
+func newTakerFeeForPair(denom0, denom1 string, takerFee osmomath.Dec, isDefault bool) domain.TakerFeeForPair {
+	return domain.TakerFeeForPair{
+		TakerFeeForPair: sqsdomain.TakerFeeForPair{
+			Denom0:   denom0,
+			Denom1:   denom1,
+			TakerFee: takerFee,
+		},
+		IsDefault: isDefault,
+	}
+}
+
+// This test validates that the candidate routes are filtered by the configured allowed and denied denoms.
+// It finds an intermediate denom of a multi-hop route between OSMO and ATOM, validating that:
+// - denying it filters out all routes through pools containing it.
+// - allowing only an unrelated denom filters out all multi-hop routes while the direct routes bypass the allowlist.
+func (s *RouterTestSuite) TestGetCandidateRoutes_DenomFilter() {
+	mainnetState := s.SetupMainnetState()
+
+	oneOSMOIn := sdk.NewCoin(UOSMO, defaultAmount)
+
+	// poolDenomsInRoutes returns the denoms of all pools in the given candidate routes.
+	poolDenomsInRoutes := func(poolsUsecase mvc.PoolsUsecase, candidateRoutes sqsdomain.CandidateRoutes) map[string]struct{} {
+		denoms := map[string]struct{}{}
+		for _, candidateRoute := range candidateRoutes.Routes {
+			for _, candidatePool := range candidateRoute.Pools {
+				pool, err := poolsUsecase.GetPool(candidatePool.ID)
+				s.Require().NoError(err)
+
+				for _, denom := range pool.GetPoolDenoms() {
+					denoms[denom] = struct{}{}
+				}
+			}
+		}
+		return denoms
+	}
+
+	mainnetUsecase := s.SetupRouterAndPoolsUsecase(mainnetState)
+
+	candidateRoutes, err := mainnetUsecase.Router.GetCandidateRoutes(context.Background(), oneOSMOIn, ATOM)
+	s.Require().NoError(err)
+
+	// Find an intermediate denom of a multi-hop route.
+	intermediateDenom := ""
+	for _, candidateRoute := range candidateRoutes.Routes {
+		if len(candidateRoute.Pools) > 1 {
+			intermediateDenom = candidateRoute.Pools[0].TokenOutDenom
+			break
+		}
+	}
+	s.Require().NotEmpty(intermediateDenom)
+	s.Require().Contains(poolDenomsInRoutes(mainnetUsecase.Pools, candidateRoutes), intermediateDenom)
+
+	s.Run("denied intermediate denom", func() {
+		routerConfig := routertesting.DefaultRouterConfig
+		routerConfig.DeniedDenoms = []string{intermediateDenom}
+
+		mainnetUsecase := s.SetupRouterAndPoolsUsecase(mainnetState, routertesting.WithRouterConfig(routerConfig))
+
+		// System under test
+		candidateRoutes, err := mainnetUsecase.Router.GetCandidateRoutes(context.Background(), oneOSMOIn, ATOM)
+		s.Require().NoError(err)
+
+		s.Require().NotEmpty(candidateRoutes.Routes)
+		s.Require().NotContains(poolDenomsInRoutes(mainnetUsecase.Pools, candidateRoutes), intermediateDenom)
+	})
+
+	s.Run("allowed unrelated denom", func() {
+		routerConfig := routertesting.DefaultRouterConfig
+		routerConfig.AllowedDenoms = []string{"unrelated"}
+
+		mainnetUsecase := s.SetupRouterAndPoolsUsecase(mainnetState, routertesting.WithRouterConfig(routerConfig))
+
+		// System under test
+		candidateRoutes, err := mainnetUsecase.Router.GetCandidateRoutes(context.Background(), oneOSMOIn, ATOM)
+		s.Require().NoError(err)
+
+		// The direct OSMO/ATOM pool bypasses the allowlist.
+		s.Require().NotEmpty(candidateRoutes.Routes)
+		for _, candidateRoute := range candidateRoutes.Routes {
+			s.Require().Len(candidateRoute.Pools, 1)
+		}
+
+		poolDenoms := poolDenomsInRoutes(mainnetUsecase.Pools, candidateRoutes)
+		s.Require().Equal(map[string]struct{}{UOSMO: {}, ATOM: {}}, poolDenoms)
+	})
+
+	s.Run("allowed unrelated denom - simple quote", func() {
+		routerConfig := routertesting.DefaultRouterConfig
+		routerConfig.AllowedDenoms = []string{"unrelated"}
+
+		mainnetUsecase := s.SetupRouterAndPoolsUsecase(mainnetState, routertesting.WithRouterConfig(routerConfig))
+
+		// System under test
+		quote, err := mainnetUsecase.Router.GetSimpleQuote(context.Background(), oneOSMOIn, ATOM)
+		s.Require().NoError(err)
+
+		// The direct OSMO/ATOM pool bypasses the allowlist.
+		for _, route := range quote.GetRoute() {
+			s.Require().Len(route.GetPools(), 1)
+		}
+	})
+}
+
+// Tests that the router state snapshot only fetches the tick models
+// of the concentrated pools if requested.
+func (s *RouterTestSuite) TestGetRouterStateSnapshot() {
+	const concentratedPoolID uint64 = 2
+
+	var (
+		pools = []sqsdomain.PoolI{
+			&mocks.MockRoutablePool{ID: 1, PoolType: poolmanagertypes.Balancer},
+			&mocks.MockRoutablePool{ID: concentratedPoolID, PoolType: poolmanagertypes.Concentrated},
+		}
+
+		tickModelMap = map[uint64]*sqsdomain.TickModel{
+			concentratedPoolID: {CurrentTickIndex: 1},
+		}
+	)
+
+	for _, includeTickMap := range []bool{false, true} {
+		s.Run(fmt.Sprintf("includeTickMap=%t", includeTickMap), func() {
+			isTickModelMapFetched := false
+			poolsUsecaseMock := &mocks.PoolsUsecaseMock{
+				GetAllPoolsFunc: func() ([]sqsdomain.PoolI, error) {
+					return pools, nil
+				},
+				GetTickModelMapFunc: func(poolIDs []uint64) (map[uint64]*sqsdomain.TickModel, error) {
+					isTickModelMapFetched = true
+					s.Require().Equal([]uint64{concentratedPoolID}, poolIDs)
+					return tickModelMap, nil
+				},
+			}
+
+			routerUsecase := usecase.NewRouterUsecase(routerrepo.New(&log.NoOpLogger{}), poolsUsecaseMock, mocks.CandidateRouteFinderMock{}, &mocks.TokenMetadataHolderMock{}, routertesting.DefaultRouterConfig, emptyCosmWasmPoolsRouterConfig, &log.NoOpLogger{}, cache.New(), cache.New())
+
+			// System under test
+			routerState, err := routerUsecase.GetRouterStateSnapshot(includeTickMap)
+			s.Require().NoError(err)
+
+			s.Require().Equal(pools, routerState.Pools)
+			s.Require().Equal(includeTickMap, isTickModelMapFetched)
+			if includeTickMap {
+				s.Require().Equal(tickModelMap, routerState.TickMap)
+			} else {
+				s.Require().Nil(routerState.TickMap)
+			}
+		})
+	}
+}
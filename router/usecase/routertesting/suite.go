@@ -401,7 +401,8 @@ func (s *RouterTestHelper) SetupRouterAndPoolsUsecase(mainnetState MockMainnetSt
 	tokensUsecase := tokensusecase.NewTokensUsecase(mainnetState.TokensMetadata, 0, &log.NoOpLogger{})
 	tokensUsecase.UpdatePoolDenomMetadata(mainnetState.PoolDenomsMetaData)
 
-	candidateRouteFinder := routerusecase.NewCandidateRouteFinder(routerRepositoryMock, logger)
+	candidateRouteFinder, err := routerusecase.NewCandidateRouteSearcher(options.RouterConfig.CandidateRouteSearchAlgorithm, routerRepositoryMock, logger)
+	s.Require().NoError(err)
 
 	routerUsecase := routerusecase.NewRouterUsecase(routerRepositoryMock, poolsUsecase, candidateRouteFinder, tokensUsecase, options.RouterConfig, poolsUsecase.GetCosmWasmPoolConfig(), logger, options.RankedRoutes, options.CandidateRoutes)
 
@@ -422,7 +423,7 @@ func (s *RouterTestHelper) SetupRouterAndPoolsUsecase(mainnetState MockMainnetSt
 
 	// Set up Coingecko pricing strategy, use MockCoingeckoPriceGetter for testing purposes
 	options.PricingConfig.DefaultSource = domain.CoinGeckoPricingSourceType
-	coingeckoPricingSource := coingeckopricing.New(tokensUsecase, options.PricingConfig, mocks.DefaultMockCoingeckoPriceGetter)
+	coingeckoPricingSource, err := coingeckopricing.New(tokensUsecase, options.PricingConfig, mocks.DefaultMockCoingeckoPriceGetter)
 	s.Require().NoError(err)
 	tokensUsecase.RegisterPricingStrategy(domain.CoinGeckoPricingSourceType, coingeckoPricingSource)
 
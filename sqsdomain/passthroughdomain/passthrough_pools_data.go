@@ -1,10 +1,15 @@
 package sqspassthroughdomain
 
+import "time"
+
 // PoolAPRDataStatusWrap is a wrapper for PoolAPRData that includes status flags.
 type PoolAPRDataStatusWrap struct {
 	PoolAPR
 	IsStale bool `json:"is_stale,omitempty"`
 	IsError bool `json:"is_error,omitempty"`
+	// LastUpdated is the time when the APR data was last successfully fetched.
+	// Nil if the data has never been fetched.
+	LastUpdated *time.Time `json:"last_updated,omitempty"`
 }
 
 // PoolFeesDataStatusWrap is a wrapper for PoolFeesData that includes status flags.
@@ -12,4 +17,7 @@ type PoolFeesDataStatusWrap struct {
 	PoolFee
 	IsStale bool `json:"is_stale,omitempty"`
 	IsError bool `json:"is_error,omitempty"`
+	// LastUpdated is the time when the fees data was last successfully fetched.
+	// Nil if the data has never been fetched.
+	LastUpdated *time.Time `json:"last_updated,omitempty"`
 }
@@ -9,6 +9,7 @@ import (
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -27,6 +28,8 @@ type SystemHandler struct {
 	logger      log.Logger
 	grpcAddress string
 	CIUsecase   mvc.ChainInfoUsecase
+	PUsecase    mvc.PoolsUsecase
+	TUsecase    mvc.TokensUsecase
 	config      domain.Config
 }
 
@@ -40,6 +43,15 @@ type JsonResponse struct {
 	} `json:"result"`
 }
 
+// HealthResponse defines the response for the /health endpoint
+type HealthResponse struct {
+	LatestChainHeight    uint64 `json:"latest_chain_height"`
+	PoolsLoaded          bool   `json:"pools_loaded"`
+	TokensMetadataLoaded bool   `json:"tokens_metadata_loaded"`
+	// Nil if the asset list was never loaded.
+	LastAssetListRefreshTime *time.Time `json:"last_asset_list_refresh_time,omitempty"`
+}
+
 // ConfigPrivateResponse defines the response for the /config-private endpoint
 type ConfigPrivateResponse struct {
 	OTEL *domain.OTELConfig `json:"otel"`
@@ -52,11 +64,13 @@ const (
 )
 
 // NewSystemHandler will initialize the /debug/ppof resources endpoint
-func NewSystemHandler(e *echo.Echo, config domain.Config, logger log.Logger, us mvc.ChainInfoUsecase) {
+func NewSystemHandler(e *echo.Echo, config domain.Config, logger log.Logger, us mvc.ChainInfoUsecase, pu mvc.PoolsUsecase, tu mvc.TokensUsecase) {
 	handler := &SystemHandler{
 		logger:      logger,
 		grpcAddress: config.ChainTendermintRPCEndpoint,
 		CIUsecase:   us,
+		PUsecase:    pu,
+		TUsecase:    tu,
 		config:      config,
 	}
 
@@ -74,6 +88,7 @@ func NewSystemHandler(e *echo.Echo, config domain.Config, logger log.Logger, us
 	e.GET("/debug/pprof/trace", echo.WrapHandler(http.HandlerFunc(pprof.Trace)))
 
 	e.GET("/healthcheck", handler.GetHealthStatus)
+	e.GET("/health", handler.GetHealth)
 	e.GET("/config", handler.GetConfig)
 	e.GET("/config-private", handler.GetConfigPrivate)
 	e.GET("/version", handler.GetVersion)
@@ -214,3 +229,27 @@ func (h *SystemHandler) GetHealthStatus(c echo.Context) error {
 		"store_latest_height": fmt.Sprint(latestStoreHeight),
 	})
 }
+
+// GetHealth reports the latest observed chain height and whether the initial data
+// (pools and tokens metadata) has been loaded as well as the last successful asset list refresh.
+// Relies on readiness flags set by ingest so that it is cheap enough for frequent probes.
+// Returns HTTP 503 until the initial data is loaded.
+func (h *SystemHandler) GetHealth(c echo.Context) error {
+	response := HealthResponse{
+		LatestChainHeight: h.CIUsecase.GetLatestObservedHeight(),
+		PoolsLoaded:       h.PUsecase.ArePoolsLoaded(),
+	}
+
+	// Tokens metadata is loaded if the asset list was loaded at least once.
+	if lastAssetListRefreshTime := h.TUsecase.GetLastAssetListRefreshTime(); !lastAssetListRefreshTime.IsZero() {
+		response.TokensMetadataLoaded = true
+		response.LastAssetListRefreshTime = &lastAssetListRefreshTime
+	}
+
+	isReady := response.LatestChainHeight > 0 && response.PoolsLoaded && response.TokensMetadataLoaded
+	if !isReady {
+		return c.JSON(http.StatusServiceUnavailable, response)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -1,8 +1,14 @@
 package http_test
 
 import (
+	"encoding/json"
+	nethttp "net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/labstack/echo/v4"
+	"github.com/osmosis-labs/sqs/domain/mocks"
 	"github.com/osmosis-labs/sqs/system/delivery/http"
 	"github.com/stretchr/testify/require"
 )
@@ -51,3 +57,119 @@ func TestExtractVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHealth(t *testing.T) {
+	const defaultHeight uint64 = 100
+
+	defaultRefreshTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+
+		latestHeight     uint64
+		poolsLoaded      bool
+		assetRefreshTime time.Time
+
+		expectedStatusCode int
+		expectedResponse   http.HealthResponse
+	}{
+		{
+			name: "not ready: nothing loaded",
+
+			expectedStatusCode: nethttp.StatusServiceUnavailable,
+			expectedResponse:   http.HealthResponse{},
+		},
+		{
+			name: "not ready: pools not loaded",
+
+			latestHeight:     defaultHeight,
+			assetRefreshTime: defaultRefreshTime,
+
+			expectedStatusCode: nethttp.StatusServiceUnavailable,
+			expectedResponse: http.HealthResponse{
+				LatestChainHeight:        defaultHeight,
+				TokensMetadataLoaded:     true,
+				LastAssetListRefreshTime: &defaultRefreshTime,
+			},
+		},
+		{
+			name: "not ready: tokens metadata not loaded",
+
+			latestHeight: defaultHeight,
+			poolsLoaded:  true,
+
+			expectedStatusCode: nethttp.StatusServiceUnavailable,
+			expectedResponse: http.HealthResponse{
+				LatestChainHeight: defaultHeight,
+				PoolsLoaded:       true,
+			},
+		},
+		{
+			name: "not ready: no chain height observed",
+
+			poolsLoaded:      true,
+			assetRefreshTime: defaultRefreshTime,
+
+			expectedStatusCode: nethttp.StatusServiceUnavailable,
+			expectedResponse: http.HealthResponse{
+				PoolsLoaded:              true,
+				TokensMetadataLoaded:     true,
+				LastAssetListRefreshTime: &defaultRefreshTime,
+			},
+		},
+		{
+			name: "ready",
+
+			latestHeight:     defaultHeight,
+			poolsLoaded:      true,
+			assetRefreshTime: defaultRefreshTime,
+
+			expectedStatusCode: nethttp.StatusOK,
+			expectedResponse: http.HealthResponse{
+				LatestChainHeight:        defaultHeight,
+				PoolsLoaded:              true,
+				TokensMetadataLoaded:     true,
+				LastAssetListRefreshTime: &defaultRefreshTime,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(echo.GET, "/health", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			handler := &http.SystemHandler{
+				CIUsecase: &mocks.ChainInfoUsecaseMock{
+					GetLatestObservedHeightFunc: func() uint64 {
+						return tc.latestHeight
+					},
+				},
+				PUsecase: &mocks.PoolsUsecaseMock{
+					ArePoolsLoadedFunc: func() bool {
+						return tc.poolsLoaded
+					},
+				},
+				TUsecase: &mocks.TokensUsecaseMock{
+					GetLastAssetListRefreshTimeFunc: func() time.Time {
+						return tc.assetRefreshTime
+					},
+				},
+			}
+
+			// System under test
+			err := handler.GetHealth(c)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedStatusCode, rec.Code)
+
+			var actualResponse http.HealthResponse
+			err = json.Unmarshal(rec.Body.Bytes(), &actualResponse)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedResponse, actualResponse)
+		})
+	}
+}
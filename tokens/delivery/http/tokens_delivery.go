@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -17,48 +21,81 @@ import (
 	"github.com/osmosis-labs/sqs/domain/mvc"
 	"github.com/osmosis-labs/sqs/log"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting/parsing"
+	"github.com/osmosis-labs/sqs/tokens/usecase/pricing"
 
 	_ "github.com/osmosis-labs/sqs/docs"
 )
 
 // TokensHandler  represent the httphandler for the router
 type TokensHandler struct {
-	TUsecase mvc.TokensUsecase
-	RUsecase mvc.RouterUsecase
+	TUsecase          mvc.TokensUsecase
+	RUsecase          mvc.RouterUsecase
+	PricesBroadcaster domain.PricesBroadcaster
 
 	defaultQuoteChainDenom string
 	defaultCoingeckoDenom  string
 
+	// allowedMinLiquidityCaps are the liquidity floors accepted as a pricing override.
+	allowedMinLiquidityCaps map[uint64]struct{}
+
 	logger log.Logger
 }
 
 const (
 	routerResource = "/tokens"
+
+	// pricesStreamHeartbeatInterval is the interval at which ping frames are sent
+	// to the prices stream subscribers.
+	pricesStreamHeartbeatInterval = 30 * time.Second
+	// pricesStreamPongTimeout is the time within which a pong frame or any other message
+	// must be received from the prices stream subscriber before the connection is closed.
+	pricesStreamPongTimeout = 2 * pricesStreamHeartbeatInterval
+	// pricesStreamWriteTimeout is the timeout for writing a frame to the prices stream subscriber.
+	pricesStreamWriteTimeout = 10 * time.Second
 )
 
+var pricesStreamUpgrader = websocket.Upgrader{
+	// The prices are public, hence any origin is allowed similar to the CORS configuration.
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
 func formatTokensResource(resource string) string {
 	return routerResource + resource
 }
 
 // NewTokensHandler will initialize the pools/ resources endpoint
-func NewTokensHandler(e *echo.Echo, pricingConfig domain.PricingConfig, ts mvc.TokensUsecase, ru mvc.RouterUsecase, logger log.Logger) (err error) {
-	defaultQuoteChainDenom, err := ts.GetChainDenom(pricingConfig.DefaultQuoteHumanDenom)
+func NewTokensHandler(e *echo.Echo, pricingConfig domain.PricingConfig, ts mvc.TokensUsecase, ru mvc.RouterUsecase, pb domain.PricesBroadcaster, logger log.Logger) (err error) {
+	defaultQuoteChainDenom, err := pricing.GetDefaultQuoteChainDenom(pricingConfig, ts)
 	if err != nil {
 		return err
 	}
 
+	allowedMinLiquidityCaps := make(map[uint64]struct{}, len(pricingConfig.AllowedMinPoolLiquidityCaps)+1)
+	allowedMinLiquidityCaps[pricingConfig.MinPoolLiquidityCap] = struct{}{}
+	for _, minLiquidityCap := range pricingConfig.AllowedMinPoolLiquidityCaps {
+		allowedMinLiquidityCaps[minLiquidityCap] = struct{}{}
+	}
+
 	handler := &TokensHandler{
-		TUsecase: ts,
-		RUsecase: ru,
+		TUsecase:          ts,
+		RUsecase:          ru,
+		PricesBroadcaster: pb,
 
 		defaultQuoteChainDenom: defaultQuoteChainDenom,
 
+		allowedMinLiquidityCaps: allowedMinLiquidityCaps,
+
 		logger: logger,
 	}
 
 	e.GET(formatTokensResource("/metadata"), handler.GetMetadata)
+	e.GET(formatTokensResource("/metadata/list"), handler.GetMetadataList)
 	e.GET(formatTokensResource("/pool-metadata"), handler.GetPoolDenomMetadata)
 	e.GET(formatTokensResource("/prices"), handler.GetPrices)
+	e.GET(formatTokensResource("/market-cap"), handler.GetMarketCap)
+	e.GET(formatTokensResource("/prices/stream"), handler.GetPricesStream)
 	e.GET(formatTokensResource("/usd-price-test"), handler.GetUSDPriceTest)
 	e.POST(formatTokensResource("/store-state"), handler.StoreTokensStateInFiles)
 
@@ -122,6 +159,83 @@ func (a *TokensHandler) GetMetadata(c echo.Context) (err error) {
 	return c.JSON(http.StatusOK, tokenMetadataResult)
 }
 
+// @Summary Token Metadata List
+// @Description returns a page of the metadata of all tokens ordered by human denom.
+// @Description The tokens can optionally be filtered by whether they are listed and whether they have a coingecko id.
+// @ID get-token-metadata-list
+// @Produce  json
+// @Param  limit           query  int   false  "Maximum number of tokens to return. All remaining tokens if zero or not given"
+// @Param  offset          query  int   false  "Number of tokens to skip; defaults to 0"
+// @Param  listed          query  bool  false  "Return only listed tokens if true and only unlisted tokens if false. All tokens if not given"
+// @Param  hasCoingeckoId  query  bool  false  "Return only tokens with a coingecko id if true and only tokens without one if false. All tokens if not given"
+// @Success 200 {object} domain.TokenMetadataPage "Success"
+// @Router /tokens/metadata/list [get]
+func (a *TokensHandler) GetMetadataList(c echo.Context) (err error) {
+	limit, err := parseNonNegativeIntQueryParam(c, "limit")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+	}
+
+	offset, err := parseNonNegativeIntQueryParam(c, "offset")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+	}
+
+	listed, err := parseOptionalBooleanQueryParam(c, "listed")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+	}
+
+	hasCoingeckoID, err := parseOptionalBooleanQueryParam(c, "hasCoingeckoId")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+	}
+
+	tokenMetadata, err := a.TUsecase.GetFullTokenMetadata()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, domain.ResponseError{Message: err.Error()})
+	}
+
+	tokens := make([]domain.Token, 0, len(tokenMetadata))
+	for _, token := range tokenMetadata {
+		if listed != nil && token.IsUnlisted == *listed {
+			continue
+		}
+
+		if hasCoingeckoID != nil && (token.CoingeckoID != "") != *hasCoingeckoID {
+			continue
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	// Sort by human denom and then by chain denom for deterministic pagination
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].HumanDenom != tokens[j].HumanDenom {
+			return tokens[i].HumanDenom < tokens[j].HumanDenom
+		}
+		return tokens[i].CoinMinimalDenom < tokens[j].CoinMinimalDenom
+	})
+
+	page := domain.TokenMetadataPage{
+		Tokens: []domain.Token{},
+		Total:  len(tokens),
+	}
+
+	if offset < len(tokens) {
+		end := len(tokens)
+		// Compare against the remaining tokens rather than offset+limit to avoid overflowing.
+		if limit > 0 && limit < end-offset {
+			end = offset + limit
+			page.NextOffset = end
+		}
+
+		page.Tokens = tokens[offset:end]
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
 // @Summary Pool Denom Metadata
 // @Description returns pool denom metadata. As of today, this metadata is represented by the local market cap of the token computed over all Osmosis pools.
 // @Description For testnet, uses osmo-test-5 asset list. For mainnet, uses osmosis-1 asset list.
@@ -150,6 +264,35 @@ func (a *TokensHandler) GetPoolDenomMetadata(c echo.Context) (err error) {
 	return c.JSON(http.StatusOK, result)
 }
 
+// @Summary Market Cap
+// @Description returns the market capitalization estimate of the token in the system-configured quote denomination.
+// @Description It combines the chain price of the token with its configured circulating supply.
+// @Description For tokens without circulating supply data, the total liquidity across all Osmosis pools is used instead and `is_best_effort` is set to true.
+// @ID get-market-cap
+// @Produce  json
+// @Param  denom        query  string  true   "Denom that can either be a human denom or a chain denom based on humanDenoms parameter"
+// @Param  humanDenoms  query  bool    false  "Specify true if input denomination is in human-readable format; defaults to false"
+// @Success 200 {object} domain.MarketCap "Success"
+// @Router /tokens/market-cap [get]
+func (a *TokensHandler) GetMarketCap(c echo.Context) (err error) {
+	denom := c.QueryParam("denom")
+	if len(denom) == 0 {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: "denom is required"})
+	}
+
+	chainDenoms, err := mvc.ValidateChainDenomsQueryParam(c, a.TUsecase, []string{denom})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+	}
+
+	marketCap, err := a.TUsecase.GetMarketCap(c.Request().Context(), chainDenoms[0])
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, domain.ResponseError{Message: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, marketCap)
+}
+
 // @Summary Get prices
 // @Description Given a list of base denominations, this endpoint returns the spot price with a system-configured quote denomination.
 // If the pricing source is set to "chain" (0), it will first check the **chain** pricing cache for the price quote. If it exists, it will return it. Otherwise, it will compute the pricing on-demand if the quote is non-usdc.
@@ -161,6 +304,8 @@ func (a *TokensHandler) GetPoolDenomMetadata(c echo.Context) (err error) {
 // @Param   base          query     string  true  "Comma-separated list of base denominations (human-readable or chain format based on humanDenoms parameter)"
 // @Param   humanDenoms   query     bool    false "Specify true if input denominations are in human-readable format; defaults to false"
 // @Param	pricingSource query     int     false "Specify the pricing source. Values can be 0 (chain) or 1 (coingecko); default to 0 (chain)"
+// @Param   quote         query     string  false "Quote denomination overriding the system-configured one (human-readable or chain format based on humanDenoms parameter). Only supported by the chain pricing source."
+// @Param   minLiquidityCap query   int     false "Minimum liquidity capitalization of the pools used to compute the prices, overriding the system-configured one. Must be either the system-configured one or one of the configured allowed-min-pool-liquidity-caps. Only supported by the chain pricing source."
 // @Success 200 {object} map[string]map[string]string "A map where each key is a base denomination (on-chain format), containing another map with a key as the quote denomination (on-chain format) and the value as the spot price."
 // @Router /tokens/prices [get]
 func (a *TokensHandler) GetPrices(c echo.Context) (err error) {
@@ -193,18 +338,129 @@ func (a *TokensHandler) GetPrices(c echo.Context) (err error) {
 		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
 	}
 
+	// Override the quote denom if requested.
+	if quoteDenomStr := c.QueryParam("quote"); len(quoteDenomStr) > 0 {
+		quoteDenom, err = a.getQuoteDenomOverride(pricingSourceType, quoteDenomStr, isHumanDenoms)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+		}
+	}
+
 	// Validate base denoms
 	if err := a.validateBaseDenoms(baseDenoms, isHumanDenoms); err != nil {
 		return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
 	}
 
-	prices, err := a.TUsecase.GetPrices(ctx, baseDenoms, []string{quoteDenom}, pricingSourceType)
+	// Override the liquidity floor of the pools used for pricing if requested.
+	var pricingOptions []domain.PricingOption
+	if minLiquidityCapStr := c.QueryParam("minLiquidityCap"); len(minLiquidityCapStr) > 0 {
+		if pricingSourceType != domain.ChainPricingSourceType {
+			return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: fmt.Sprintf("min liquidity cap override is only supported by the chain pricing source, got pricing source type: %d", pricingSourceType)})
+		}
+
+		minLiquidityCap, err := strconv.ParseUint(minLiquidityCapStr, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+		}
+
+		if _, ok := a.allowedMinLiquidityCaps[minLiquidityCap]; !ok {
+			return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: fmt.Sprintf("min liquidity cap (%d) is not allowed", minLiquidityCap)})
+		}
+
+		pricingOptions = append(pricingOptions, domain.WithMinPricingPoolLiquidityCap(minLiquidityCap))
+	}
+
+	prices, err := a.TUsecase.GetPrices(ctx, baseDenoms, []string{quoteDenom}, pricingSourceType, pricingOptions...)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, domain.ResponseError{Message: err.Error()})
 	}
 	return c.JSON(http.StatusOK, prices)
 }
 
+// @Summary Stream prices
+// @Description Upgrades the connection to a WebSocket that streams the chain prices against the default quote denomination
+// @Description as they are recomputed by the pricing worker at the end of each block.
+// @Description Each message is a JSON map from base denomination to quote denomination to price, containing only
+// @Description the subscribed base denominations whose prices were recomputed within the block.
+// @Description Ping frames are sent periodically as heartbeats. If the client falls behind, the oldest pending updates are dropped.
+// @Param   base          query     string  false "Comma-separated list of base denominations to subscribe to (human-readable or chain format based on humanDenoms parameter). All denominations if empty."
+// @Param   humanDenoms   query     bool    false "Specify true if input denominations are in human-readable format; defaults to false"
+// @Success 101 {object} map[string]map[string]string "Switching protocols to WebSocket."
+// @Router /tokens/prices/stream [get]
+func (a *TokensHandler) GetPricesStream(c echo.Context) error {
+	var baseDenoms []string
+
+	baseDenomsStr := c.QueryParam("base")
+	if len(baseDenomsStr) > 0 {
+		var err error
+		baseDenoms, err = validateDenomsParam(baseDenomsStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+		}
+
+		isHumanDenoms, err := domain.ParseBooleanQueryParam(c, "humanDenoms")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+		}
+
+		if err := a.validateBaseDenoms(baseDenoms, isHumanDenoms); err != nil {
+			return c.JSON(http.StatusBadRequest, domain.ResponseError{Message: err.Error()})
+		}
+	}
+
+	conn, err := pricesStreamUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		// The upgrader responds with the error to the client.
+		return nil
+	}
+	defer conn.Close()
+
+	updates, unsubscribe := a.PricesBroadcaster.Subscribe(baseDenoms)
+	defer unsubscribe()
+
+	// Read messages to process the control frames and detect the connection
+	// being closed by the client.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+
+		_ = conn.SetReadDeadline(time.Now().Add(pricesStreamPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pricesStreamPongTimeout))
+		})
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(pricesStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return nil
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(pricesStreamWriteTimeout)); err != nil {
+				return nil
+			}
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(pricesStreamWriteTimeout))
+			if err := conn.WriteJSON(update); err != nil {
+				a.logger.Error("failed to write prices stream update", zap.Error(err))
+				return nil
+			}
+		}
+	}
+}
+
 // getPricingSource retrieves the pricing sources.
 // If not parameter is given, chain pricing source is used by default.
 // If the parameter is given, it is validated and returned.
@@ -237,6 +493,29 @@ func (a TokensHandler) getQuoteDenom(pricingSourceType domain.PricingSourceType)
 	}
 }
 
+// getQuoteDenomOverride returns the chain quote denomination overriding the system-configured one.
+// Returns error if the pricing source is not the chain pricing source, or if the quote denomination is not a listed denomination.
+func (a TokensHandler) getQuoteDenomOverride(pricingSourceType domain.PricingSourceType, quoteDenom string, isHumanDenom bool) (string, error) {
+	if pricingSourceType != domain.ChainPricingSourceType {
+		return "", fmt.Errorf("quote denom override is only supported by the chain pricing source, got pricing source type: %d", pricingSourceType)
+	}
+
+	if isHumanDenom {
+		chainDenom, err := a.TUsecase.GetChainDenom(quoteDenom)
+		if err != nil {
+			return "", err
+		}
+
+		quoteDenom = chainDenom
+	}
+
+	if !a.TUsecase.IsValidChainDenom(quoteDenom) {
+		return "", fmt.Errorf("quote denom (%s) is not a valid listed denom", quoteDenom)
+	}
+
+	return quoteDenom, nil
+}
+
 // validateBaseDenoms validates the base denominations. If the base denominations are in human-readable format, it translates them to chain format.
 // Check if the provided denoms (which can be human or chain) are valid and existing in the asset list
 // If human denoms, convert to chain denoms
@@ -262,6 +541,41 @@ func (a TokensHandler) validateBaseDenoms(baseDenoms []string, isHumanBaseDenoms
 	return nil
 }
 
+// parseNonNegativeIntQueryParam parses a non-negative integer query parameter.
+// Returns zero if the parameter is not present.
+func parseNonNegativeIntQueryParam(c echo.Context, paramName string) (int, error) {
+	paramValueStr := c.QueryParam(paramName)
+	if paramValueStr == "" {
+		return 0, nil
+	}
+
+	paramValue, err := strconv.Atoi(paramValueStr)
+	if err != nil {
+		return 0, err
+	}
+
+	if paramValue < 0 {
+		return 0, fmt.Errorf("%s must be non-negative, got %d", paramName, paramValue)
+	}
+
+	return paramValue, nil
+}
+
+// parseOptionalBooleanQueryParam parses a boolean query parameter.
+// Returns nil if the parameter is not present.
+func parseOptionalBooleanQueryParam(c echo.Context, paramName string) (*bool, error) {
+	if c.QueryParam(paramName) == "" {
+		return nil, nil
+	}
+
+	paramValue, err := domain.ParseBooleanQueryParam(c, paramName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &paramValue, nil
+}
+
 // validateDenomsParam validates the denoms param string
 // returns a denom slice if validation passes. Error otherwise
 func validateDenomsParam(denomsStr string) ([]string, error) {
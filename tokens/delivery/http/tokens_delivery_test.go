@@ -0,0 +1,457 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/log"
+	tokensdelivery "github.com/osmosis-labs/sqs/tokens/delivery/http"
+	"github.com/osmosis-labs/sqs/tokens/usecase/pricing/worker"
+)
+
+// subscriptionSignalingBroadcaster wraps a prices broadcaster and
+// signals whenever a subscription is made.
+type subscriptionSignalingBroadcaster struct {
+	domain.PricesBroadcaster
+
+	subscribed chan struct{}
+}
+
+// Subscribe implements domain.PricesBroadcaster.
+func (b *subscriptionSignalingBroadcaster) Subscribe(baseDenoms []string) (<-chan domain.PricesResult, func()) {
+	updates, unsubscribe := b.PricesBroadcaster.Subscribe(baseDenoms)
+	b.subscribed <- struct{}{}
+	return updates, unsubscribe
+}
+
+// Tests that a subscriber to the prices stream receives the prices of the subscribed
+// denoms after the pricing worker notifies the broadcaster of the update.
+func TestGetPricesStream(t *testing.T) {
+	const (
+		UOSMO = "uosmo"
+		ATOM  = "uatom"
+		USDC  = "uusdc"
+
+		streamTimeout = 5 * time.Second
+	)
+
+	broadcaster := &subscriptionSignalingBroadcaster{
+		PricesBroadcaster: worker.NewPricesBroadcaster(1),
+		subscribed:        make(chan struct{}, 1),
+	}
+
+	handler := &tokensdelivery.TokensHandler{
+		TUsecase: &mocks.TokensUsecaseMock{
+			IsValidChainDenomFunc: func(chainDenom string) bool {
+				return true
+			},
+		},
+		PricesBroadcaster: broadcaster,
+	}
+
+	e := echo.New()
+	e.GET("/tokens/prices/stream", handler.GetPricesStream)
+
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	streamURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/tokens/prices/stream?base=" + ATOM
+
+	conn, _, err := websocket.DefaultDialer.Dial(streamURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	select {
+	case <-broadcaster.subscribed:
+	case <-time.After(streamTimeout):
+		t.Fatal("timed out waiting for the subscription")
+	}
+
+	// System under test
+	err = broadcaster.OnPricingUpdate(context.Background(), 1, domain.BlockPoolMetadata{}, domain.PricesResult{
+		UOSMO: {USDC: osmomath.NewBigDec(1)},
+		ATOM:  {USDC: osmomath.NewBigDec(5)},
+	}, USDC)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(streamTimeout)))
+
+	var update map[string]map[string]string
+	require.NoError(t, conn.ReadJSON(&update))
+
+	// Only the subscribed denom is received.
+	require.Equal(t, map[string]map[string]string{
+		ATOM: {USDC: osmomath.NewBigDec(5).String()},
+	}, update)
+}
+
+// Tests that the prices stream rejects invalid base denoms before upgrading the connection.
+func TestGetPricesStream_InvalidDenom(t *testing.T) {
+	handler := &tokensdelivery.TokensHandler{
+		TUsecase:          &mocks.TokensUsecaseMock{},
+		PricesBroadcaster: worker.NewPricesBroadcaster(1),
+	}
+
+	e := echo.New()
+	e.GET("/tokens/prices/stream", handler.GetPricesStream)
+
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	streamURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/tokens/prices/stream?base=1invalid"
+
+	_, resp, err := websocket.DefaultDialer.Dial(streamURL, nil)
+	require.Error(t, err)
+	require.NotNil(t, resp)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// Tests that the prices quote denom can be overridden per request for the chain pricing source.
+func TestGetPrices_QuoteOverride(t *testing.T) {
+	const (
+		ATOM = "uatom"
+		USDC = "uusdc"
+		USDT = "uusdt"
+	)
+
+	var requestedQuoteDenoms []string
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			switch humanDenom {
+			case "usdc":
+				return USDC, nil
+			case "usdt":
+				return USDT, nil
+			default:
+				return "", fmt.Errorf("chain denom for human denom (%s) is not found", humanDenom)
+			}
+		},
+		IsValidChainDenomFunc: func(chainDenom string) bool {
+			return chainDenom == ATOM || chainDenom == USDC || chainDenom == USDT
+		},
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			requestedQuoteDenoms = quoteDenoms
+			return domain.PricesResult{}, nil
+		},
+	}
+
+	e := echo.New()
+	err := tokensdelivery.NewTokensHandler(e, domain.PricingConfig{DefaultQuoteHumanDenom: "usdc"}, tokensUsecase, nil, nil, &log.NoOpLogger{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		query string
+
+		expectedStatusCode  int
+		expectedQuoteDenoms []string
+	}{
+		{
+			name:  "no override -> default quote",
+			query: "base=" + ATOM,
+
+			expectedStatusCode:  http.StatusOK,
+			expectedQuoteDenoms: []string{USDC},
+		},
+		{
+			name:  "chain denom override",
+			query: "base=" + ATOM + "&quote=" + USDT,
+
+			expectedStatusCode:  http.StatusOK,
+			expectedQuoteDenoms: []string{USDT},
+		},
+		{
+			name:  "human denom override",
+			query: "base=usdc&quote=usdt&humanDenoms=true",
+
+			expectedStatusCode:  http.StatusOK,
+			expectedQuoteDenoms: []string{USDT},
+		},
+		{
+			name:  "human denom override without humanDenoms",
+			query: "base=" + ATOM + "&quote=usdt",
+
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "unlisted override",
+			query: "base=" + ATOM + "&quote=uunlisted",
+
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "override with coingecko pricing source",
+			query: "base=" + ATOM + "&quote=" + USDT + "&pricingSource=1",
+
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestedQuoteDenoms = nil
+
+			req := httptest.NewRequest(http.MethodGet, "/tokens/prices?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			// System under test
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.expectedStatusCode, rec.Code)
+			require.Equal(t, tt.expectedQuoteDenoms, requestedQuoteDenoms)
+		})
+	}
+}
+
+// Tests that the liquidity floor of the pools used for pricing can be overridden per request
+// for the chain pricing source.
+func TestGetPrices_MinLiquidityCap(t *testing.T) {
+	const (
+		ATOM = "uatom"
+		USDC = "uusdc"
+	)
+
+	var requestedMinLiquidityCap *uint64
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			return USDC, nil
+		},
+		IsValidChainDenomFunc: func(chainDenom string) bool {
+			return chainDenom == ATOM || chainDenom == USDC
+		},
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			if len(opts) > 0 {
+				options := domain.PricingOptions{}
+				for _, opt := range opts {
+					opt(&options)
+				}
+				requestedMinLiquidityCap = &options.MinPoolLiquidityCap
+			}
+			return domain.PricesResult{}, nil
+		},
+	}
+
+	e := echo.New()
+	err := tokensdelivery.NewTokensHandler(e, domain.PricingConfig{
+		DefaultQuoteHumanDenom:      "usdc",
+		MinPoolLiquidityCap:         10,
+		AllowedMinPoolLiquidityCaps: []uint64{1000},
+	}, tokensUsecase, nil, nil, &log.NoOpLogger{})
+	require.NoError(t, err)
+
+	var (
+		defaultMinLiquidityCap = uint64(10)
+		minLiquidityCap        = uint64(1000)
+	)
+
+	tests := []struct {
+		name  string
+		query string
+
+		expectedStatusCode      int
+		expectedMinLiquidityCap *uint64
+	}{
+		{
+			name:  "no override -> default floor",
+			query: "base=" + ATOM,
+
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:  "override",
+			query: "base=" + ATOM + "&minLiquidityCap=1000",
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMinLiquidityCap: &minLiquidityCap,
+		},
+		{
+			name:  "override with the default floor",
+			query: "base=" + ATOM + "&minLiquidityCap=10",
+
+			expectedStatusCode:      http.StatusOK,
+			expectedMinLiquidityCap: &defaultMinLiquidityCap,
+		},
+		{
+			name:  "override with a floor that is not allowed",
+			query: "base=" + ATOM + "&minLiquidityCap=1001",
+
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid override",
+			query: "base=" + ATOM + "&minLiquidityCap=-1",
+
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "override with coingecko pricing source",
+			query: "base=" + ATOM + "&minLiquidityCap=1000&pricingSource=1",
+
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestedMinLiquidityCap = nil
+
+			req := httptest.NewRequest(http.MethodGet, "/tokens/prices?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			// System under test
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.expectedStatusCode, rec.Code)
+			require.Equal(t, tt.expectedMinLiquidityCap, requestedMinLiquidityCap)
+		})
+	}
+}
+
+// Tests that the token metadata list is ordered by human denom, paginated and filtered.
+func TestGetMetadataList(t *testing.T) {
+	var (
+		atom = domain.Token{HumanDenom: "atom", CoinMinimalDenom: "uatom", CoingeckoID: "cosmos"}
+		osmo = domain.Token{HumanDenom: "osmo", CoinMinimalDenom: "uosmo", CoingeckoID: "osmosis"}
+		usdc = domain.Token{HumanDenom: "usdc", CoinMinimalDenom: "uusdc"}
+		xyz  = domain.Token{HumanDenom: "xyz", CoinMinimalDenom: "uxyz", IsUnlisted: true}
+	)
+
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			return "u" + humanDenom, nil
+		},
+		IsValidChainDenomFunc: func(chainDenom string) bool {
+			return true
+		},
+		GetFullTokenMetadataFunc: func() (map[string]domain.Token, error) {
+			return map[string]domain.Token{
+				xyz.CoinMinimalDenom:  xyz,
+				usdc.CoinMinimalDenom: usdc,
+				osmo.CoinMinimalDenom: osmo,
+				atom.CoinMinimalDenom: atom,
+			}, nil
+		},
+	}
+
+	e := echo.New()
+	err := tokensdelivery.NewTokensHandler(e, domain.PricingConfig{DefaultQuoteHumanDenom: "usdc"}, tokensUsecase, nil, nil, &log.NoOpLogger{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		query string
+
+		expectedStatusCode int
+		expectedPage       domain.TokenMetadataPage
+	}{
+		{
+			name:  "no parameters -> all tokens",
+			query: "",
+
+			expectedStatusCode: http.StatusOK,
+			expectedPage:       domain.TokenMetadataPage{Tokens: []domain.Token{atom, osmo, usdc, xyz}, Total: 4},
+		},
+		{
+			name:  "first page",
+			query: "limit=2",
+
+			expectedStatusCode: http.StatusOK,
+			expectedPage:       domain.TokenMetadataPage{Tokens: []domain.Token{atom, osmo}, Total: 4, NextOffset: 2},
+		},
+		{
+			name:  "last page",
+			query: "limit=2&offset=2",
+
+			expectedStatusCode: http.StatusOK,
+			expectedPage:       domain.TokenMetadataPage{Tokens: []domain.Token{usdc, xyz}, Total: 4},
+		},
+		{
+			name:  "offset past the end",
+			query: "limit=2&offset=10",
+
+			expectedStatusCode: http.StatusOK,
+			expectedPage:       domain.TokenMetadataPage{Tokens: []domain.Token{}, Total: 4},
+		},
+		{
+			name:  "max limit with offset does not overflow",
+			query: "limit=9223372036854775807&offset=1",
+
+			expectedStatusCode: http.StatusOK,
+			expectedPage:       domain.TokenMetadataPage{Tokens: []domain.Token{osmo, usdc, xyz}, Total: 4},
+		},
+		{
+			name:  "listed only",
+			query: "listed=true",
+
+			expectedStatusCode: http.StatusOK,
+			expectedPage:       domain.TokenMetadataPage{Tokens: []domain.Token{atom, osmo, usdc}, Total: 3},
+		},
+		{
+			name:  "unlisted only",
+			query: "listed=false",
+
+			expectedStatusCode: http.StatusOK,
+			expectedPage:       domain.TokenMetadataPage{Tokens: []domain.Token{xyz}, Total: 1},
+		},
+		{
+			name:  "with coingecko id, paginated",
+			query: "hasCoingeckoId=true&limit=1",
+
+			expectedStatusCode: http.StatusOK,
+			expectedPage:       domain.TokenMetadataPage{Tokens: []domain.Token{atom}, Total: 2, NextOffset: 1},
+		},
+		{
+			name:  "listed without coingecko id",
+			query: "listed=true&hasCoingeckoId=false",
+
+			expectedStatusCode: http.StatusOK,
+			expectedPage:       domain.TokenMetadataPage{Tokens: []domain.Token{usdc}, Total: 1},
+		},
+		{
+			name:  "negative limit",
+			query: "limit=-1",
+
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:  "invalid listed",
+			query: "listed=maybe",
+
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tokens/metadata/list?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			// System under test
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.expectedStatusCode, rec.Code)
+			if tt.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var page domain.TokenMetadataPage
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+			require.Equal(t, tt.expectedPage, page)
+		})
+	}
+}
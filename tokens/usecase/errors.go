@@ -96,3 +96,24 @@ type ScalingFactorForPrecisionNotFoundError struct {
 func (e ScalingFactorForPrecisionNotFoundError) Error() string {
 	return fmt.Sprintf("scaling factor for precision (%d) and denom (%s) not found", e.Precision, e.Denom)
 }
+
+// MarketCapQuoteDenomNotConfiguredError represents error type for when the market cap
+// is requested without the quote denom configured.
+type MarketCapQuoteDenomNotConfiguredError struct{}
+
+// Error implements the error interface.
+func (e MarketCapQuoteDenomNotConfiguredError) Error() string {
+	return "market cap quote denom is not configured"
+}
+
+// MarketCapPriceNotFoundError represents error type for when the price
+// required to estimate the market cap of a denom is not found.
+type MarketCapPriceNotFoundError struct {
+	Denom      string
+	QuoteDenom string
+}
+
+// Error implements the error interface.
+func (e MarketCapPriceNotFoundError) Error() string {
+	return fmt.Sprintf("price for denom (%s) in quote denom (%s) required to estimate the market cap is not found", e.Denom, e.QuoteDenom)
+}
@@ -1,18 +1,17 @@
 package usecase
 
-import "github.com/osmosis-labs/osmosis/osmomath"
+import (
+	"fmt"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+)
 
 var (
 	tenDec = osmomath.NewDec(10)
-	// No mutex since we only instantiate this once, and its static content
-	precisionScalingFactors []osmomath.Dec
+	// Preset into the precision scaling factors of each tokens use case.
+	presetPrecisionScalingFactors = buildPrecisionScalingFactors()
 )
 
-func init() {
-	// Initialize the precision scaling factors
-	precisionScalingFactors = buildPrecisionScalingFactors()
-}
-
 const maxDecPrecision = 74
 
 func buildPrecisionScalingFactors() []osmomath.Dec {
@@ -25,10 +24,38 @@ func buildPrecisionScalingFactors() []osmomath.Dec {
 
 // Returns a reference to the precision scaling factor for the given precision.
 // This reference should not be mutated.
-func getPrecisionScalingFactorImmutable(precision int) (osmomath.Dec, bool) {
-	if precision < 0 || precision >= len(precisionScalingFactors) {
+func (t *tokensUseCase) getPrecisionScalingFactorImmutable(precision int) (osmomath.Dec, bool) {
+	result, ok := t.precisionScalingFactors.Load(precision)
+	if !ok {
 		return osmomath.Dec{}, false
 	}
-	result := precisionScalingFactors[precision]
-	return result, true
+
+	scalingFactor, ok := result.(osmomath.Dec)
+	return scalingFactor, ok
+}
+
+// populatePrecisionScalingFactor computes 10^precision and stores it as the scaling factor
+// for the given precision if one is not present yet.
+// Returns true if the scaling factor was populated, false if it was already present.
+// Returns error if the precision is negative or its scaling factor overflows.
+func (t *tokensUseCase) populatePrecisionScalingFactor(precision int) (populated bool, err error) {
+	if _, ok := t.getPrecisionScalingFactorImmutable(precision); ok {
+		return false, nil
+	}
+
+	if precision < 0 {
+		return false, fmt.Errorf("precision (%d) must not be negative", precision)
+	}
+
+	defer func() {
+		// Power panics on overflow.
+		if r := recover(); r != nil {
+			populated = false
+			err = fmt.Errorf("scaling factor for precision (%d) overflows: %v", precision, r)
+		}
+	}()
+
+	t.precisionScalingFactors.Store(precision, tenDec.Power(uint64(precision)))
+
+	return true, nil
 }
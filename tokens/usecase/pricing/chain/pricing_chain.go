@@ -3,6 +3,7 @@ package chainpricing
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -25,6 +26,20 @@ type chainPricing struct {
 	maxPoolsPerRoute    int
 	maxRoutes           int
 	minPoolLiquidityCap uint64
+	// workerMinPoolLiquidityCap is the liquidity floor that the pricing worker pre-computes the prices with.
+	// The prices computed with it are cached under the same key as the ones computed with minPoolLiquidityCap
+	// so that the pre-computed prices are served for the requests with the default floor.
+	workerMinPoolLiquidityCap uint64
+
+	// cachedKeysByBaseDenom indexes the keys of the cached prices by their base denoms.
+	// Maps the base denom to the cache keys and the quote denom priced under each key.
+	// Used for invalidating the cache.
+	cachedKeysByBaseDenom   map[string]map[string]string
+	cachedKeysByBaseDenomMx sync.Mutex
+
+	// quoteProbeMultipliers maps the quote chain denom to the number of
+	// quote token units swapped when computing the price against it.
+	quoteProbeMultipliers map[string]uint64
 }
 
 var _ domain.PricingSource = &chainPricing{}
@@ -52,6 +67,16 @@ func New(routerUseCase mvc.SimpleRouterUsecase, tokenUseCase mvc.TokensUsecase,
 		panic(fmt.Sprintf("failed to get chain denom for default quote human denom (%s): %s", config.DefaultQuoteHumanDenom, err))
 	}
 
+	quoteProbeMultipliers := make(map[string]uint64, len(config.QuoteProbeAmounts))
+	for quoteHumanDenom, probeAmount := range config.QuoteProbeAmounts {
+		quoteChainDenom, err := tokenUseCase.GetChainDenom(quoteHumanDenom)
+		if err != nil {
+			panic(fmt.Sprintf("failed to get chain denom for quote probe amount human denom (%s): %s", quoteHumanDenom, err))
+		}
+
+		quoteProbeMultipliers[quoteChainDenom] = probeAmount
+	}
+
 	return &chainPricing{
 		RUsecase: routerUseCase,
 		TUsecase: tokenUseCase,
@@ -62,6 +87,12 @@ func New(routerUseCase mvc.SimpleRouterUsecase, tokenUseCase mvc.TokensUsecase,
 		maxRoutes:           config.MaxRoutes,
 		minPoolLiquidityCap: config.MinPoolLiquidityCap,
 		defaultQuoteDenom:   chainDefaultHumanDenom,
+
+		workerMinPoolLiquidityCap: config.WorkerMinPoolLiquidityCap,
+
+		cachedKeysByBaseDenom: map[string]map[string]string{},
+
+		quoteProbeMultipliers: quoteProbeMultipliers,
 	}
 }
 
@@ -88,7 +119,7 @@ func (c *chainPricing) GetPrice(ctx context.Context, baseDenom string, quoteDeno
 		return osmomath.OneBigDec(), nil
 	}
 
-	cacheKey := domain.FormatPricingCacheKey(baseDenom, quoteDenom)
+	cacheKey := c.formatCacheKey(baseDenom, quoteDenom, options.MinPoolLiquidityCap)
 
 	cachedValue, found := c.cache.Get(cacheKey)
 	if found {
@@ -112,7 +143,7 @@ func (c *chainPricing) GetPrice(ctx context.Context, baseDenom string, quoteDeno
 
 // computePrice computes the price for a given base and quote denom
 func (c *chainPricing) computePrice(ctx context.Context, baseDenom string, quoteDenom string, minPoolLiquidityCap uint64, isSpotPriceComputeMethod bool) (osmomath.BigDec, error) {
-	cacheKey := domain.FormatPricingCacheKey(baseDenom, quoteDenom)
+	cacheKey := c.formatCacheKey(baseDenom, quoteDenom, minPoolLiquidityCap)
 
 	if baseDenom == quoteDenom {
 		return osmomath.OneBigDec(), nil
@@ -132,7 +163,8 @@ func (c *chainPricing) computePrice(ctx context.Context, baseDenom string, quote
 
 	// Create a quote denom coin.
 	// We use multiplier so that stablecoin quotes avoid selecting low liquidity routes.
-	tenQuoteCoin := sdk.NewCoin(quoteDenom, osmomath.NewInt(tokenInMultiplier).Mul(quoteDenomScalingFactor.TruncateInt()))
+	quoteProbeMultiplier := c.getQuoteProbeMultiplier(quoteDenom)
+	probeQuoteCoin := sdk.NewCoin(quoteDenom, quoteProbeMultiplier.Mul(quoteDenomScalingFactor.TruncateInt()))
 
 	// Overwrite default config with custom values
 	// necessary for pricing.
@@ -145,8 +177,8 @@ func (c *chainPricing) computePrice(ctx context.Context, baseDenom string, quote
 		domain.WithDisableSplitRoutes(),
 	}
 
-	// Compute a quote for one quote coin.
-	quote, err := c.RUsecase.GetSimpleQuote(ctx, tenQuoteCoin, baseDenom, routingOptions...)
+	// Compute a quote for the probe amount of the quote coin.
+	quote, err := c.RUsecase.GetSimpleQuote(ctx, probeQuoteCoin, baseDenom, routingOptions...)
 	if err != nil {
 		return osmomath.BigDec{}, err
 	}
@@ -200,7 +232,7 @@ func (c *chainPricing) computePrice(ctx context.Context, baseDenom string, quote
 	// if there is an error in the spot price computation above.
 	if !isSpotPriceComputeMethod {
 		// Compute on-chain price for 10 units of base denom and resulted quote denom out.
-		chainPrice = osmomath.NewBigDecFromBigInt(tenQuoteCoin.Amount.BigIntMut()).QuoMut(osmomath.NewBigDecFromBigInt(quote.GetAmountOut().BigIntMut()))
+		chainPrice = osmomath.NewBigDecFromBigInt(probeQuoteCoin.Amount.BigIntMut()).QuoMut(osmomath.NewBigDecFromBigInt(quote.GetAmountOut().BigIntMut()))
 	}
 
 	if chainPrice.IsZero() {
@@ -209,7 +241,7 @@ func (c *chainPricing) computePrice(ctx context.Context, baseDenom string, quote
 	}
 
 	// Compute precision scaling factor.
-	precisionScalingFactor := osmomath.BigDecFromDec(osmomath.NewDec(tokenInMultiplier).MulMut(baseDenomScalingFactor.Quo(tenQuoteCoin.Amount.ToLegacyDec())))
+	precisionScalingFactor := osmomath.BigDecFromDec(quoteProbeMultiplier.ToLegacyDec().MulMut(baseDenomScalingFactor.Quo(probeQuoteCoin.Amount.ToLegacyDec())))
 
 	// Apply scaling facors to descale the amounts to real amounts.
 	chainPrice = chainPrice.MulMut(precisionScalingFactor)
@@ -220,20 +252,77 @@ func (c *chainPricing) computePrice(ctx context.Context, baseDenom string, quote
 		// We pre-compute the price for the default quote denom in ingest handler via the background
 		// pricing worker. As a result, we store them indefinitely.
 		// We track the tokens that are modified within the block and update the prices only for those tokens.
-		if quoteDenom == c.defaultQuoteDenom {
+		// The prices computed with a non-default liquidity floor are not pre-computed and, hence, expire.
+		if quoteDenom == c.defaultQuoteDenom && c.isDefaultMinPoolLiquidityCap(minPoolLiquidityCap) {
 			expirationTTL = cache.NoExpirationTTL
 		}
+
+		c.cachedKeysByBaseDenomMx.Lock()
 		c.cache.Set(cacheKey, chainPrice, expirationTTL)
+		c.indexCachedKey(cacheKey, baseDenom, quoteDenom)
+		c.cachedKeysByBaseDenomMx.Unlock()
 	}
 
 	return chainPrice, nil
 }
 
+// getQuoteProbeMultiplier returns the number of quote token units to swap when computing
+// the price against the given quote denom. Defaults to tokenInMultiplier if not configured.
+func (c *chainPricing) getQuoteProbeMultiplier(quoteDenom string) osmomath.Int {
+	if probeMultiplier, ok := c.quoteProbeMultipliers[quoteDenom]; ok {
+		return osmomath.NewIntFromUint64(probeMultiplier)
+	}
+	return osmomath.NewInt(tokenInMultiplier)
+}
+
 // InitializeCache implements domain.PricingSource.
 func (c *chainPricing) InitializeCache(cache *cache.Cache) {
 	c.cache = cache
 }
 
+// InvalidateCache implements domain.PricingSource.
+func (c *chainPricing) InvalidateCache(excludedQuoteDenom string, baseDenoms ...string) {
+	c.cachedKeysByBaseDenomMx.Lock()
+	defer c.cachedKeysByBaseDenomMx.Unlock()
+
+	for _, baseDenom := range baseDenoms {
+		baseDenomKeys := c.cachedKeysByBaseDenom[baseDenom]
+		for cacheKey, quoteDenom := range baseDenomKeys {
+			if quoteDenom == excludedQuoteDenom {
+				continue
+			}
+
+			c.cache.Delete(cacheKey)
+			delete(baseDenomKeys, cacheKey)
+		}
+
+		if len(baseDenomKeys) == 0 {
+			delete(c.cachedKeysByBaseDenom, baseDenom)
+		}
+	}
+}
+
+// indexCachedKey records that the given cache key holds the price of baseDenom against quoteDenom.
+// The caller must hold cachedKeysByBaseDenomMx.
+func (c *chainPricing) indexCachedKey(cacheKey, baseDenom, quoteDenom string) {
+	baseDenomKeys, ok := c.cachedKeysByBaseDenom[baseDenom]
+	if !ok {
+		baseDenomKeys = map[string]string{}
+		c.cachedKeysByBaseDenom[baseDenom] = baseDenomKeys
+	}
+	baseDenomKeys[cacheKey] = quoteDenom
+}
+
+// formatCacheKey returns the cache key for the price of the given denoms computed with the given liquidity floor.
+// The prices computed with the default liquidity floor are keyed by the denoms only.
+func (c *chainPricing) formatCacheKey(baseDenom, quoteDenom string, minPoolLiquidityCap uint64) string {
+	if c.isDefaultMinPoolLiquidityCap(minPoolLiquidityCap) {
+		return domain.FormatPricingCacheKey(domain.ChainPricingSourceType, baseDenom, quoteDenom)
+	}
+
+	return domain.FormatPricingCacheKeyWithMinPoolLiquidityCap(domain.ChainPricingSourceType, baseDenom, quoteDenom, minPoolLiquidityCap)
+}
+
 // GetFallbackStrategy implements pricing.PricingSource
 func (c *chainPricing) GetFallbackStrategy(quoteDenom string) domain.PricingSourceType {
 	if quoteDenom == c.defaultQuoteDenom {
@@ -242,3 +331,9 @@ func (c *chainPricing) GetFallbackStrategy(quoteDenom string) domain.PricingSour
 		return domain.NoneSourceType
 	}
 }
+
+// isDefaultMinPoolLiquidityCap returns true if the given liquidity floor is either the default one
+// or the one the pricing worker pre-computes the prices with.
+func (c *chainPricing) isDefaultMinPoolLiquidityCap(minPoolLiquidityCap uint64) bool {
+	return minPoolLiquidityCap == c.minPoolLiquidityCap || minPoolLiquidityCap == c.workerMinPoolLiquidityCap
+}
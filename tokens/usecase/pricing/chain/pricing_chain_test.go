@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/osmosis/osmoutils/osmoassert"
 	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/router/usecase"
+	"github.com/osmosis-labs/sqs/router/usecase/route"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
 	"github.com/osmosis-labs/sqs/tokens/usecase/pricing"
+	chainpricing "github.com/osmosis-labs/sqs/tokens/usecase/pricing/chain"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -107,3 +112,235 @@ func (s *PricingTestSuite) TestComputePrice_QuoteBasedMethod() {
 	// 0.1 additive tolerance.
 	osmoassert.DecApproxEq(s.T(), priceQuoteBasedMethod.Dec(), priceSpotPriceMethod.Dec(), osmomath.MustNewDecFromStr("0.1"))
 }
+
+// Tests that the configured quote probe amount, scaled by the quote denom precision,
+// is used as the token in for the candidate route search when computing prices.
+// Quote denoms without a configured probe amount default to 10 units.
+func (s *PricingTestSuite) TestComputePrice_QuoteProbeAmount() {
+	const (
+		usdcHumanDenom = "usdc"
+		usdtHumanDenom = "usdt"
+	)
+
+	var (
+		humanToChainDenoms = map[string]string{
+			usdcHumanDenom: USDC,
+			usdtHumanDenom: USDT,
+		}
+
+		sixPrecisionScalingFactor = osmomath.NewDec(1_000_000)
+	)
+
+	testcases := []struct {
+		name              string
+		quoteProbeAmounts map[string]uint64
+		quoteDenom        string
+
+		expectedTokenIn sdk.Coin
+	}{
+		{
+			name:       "no probe amounts configured -> default of 10 units",
+			quoteDenom: USDC,
+
+			expectedTokenIn: sdk.NewCoin(USDC, osmomath.NewInt(10_000_000)),
+		},
+		{
+			name: "probe amount configured for quote denom",
+			quoteProbeAmounts: map[string]uint64{
+				usdcHumanDenom: 1000,
+			},
+			quoteDenom: USDC,
+
+			expectedTokenIn: sdk.NewCoin(USDC, osmomath.NewInt(1_000_000_000)),
+		},
+		{
+			name: "probe amount configured for other quote denom -> default of 10 units",
+			quoteProbeAmounts: map[string]uint64{
+				usdtHumanDenom: 1000,
+			},
+			quoteDenom: USDC,
+
+			expectedTokenIn: sdk.NewCoin(USDC, osmomath.NewInt(10_000_000)),
+		},
+	}
+
+	for _, tc := range testcases {
+		s.Run(tc.name, func() {
+			tokensUsecase := &mocks.TokensUsecaseMock{
+				GetChainDenomFunc: func(humanDenom string) (string, error) {
+					chainDenom, ok := humanToChainDenoms[humanDenom]
+					if !ok {
+						return "", fmt.Errorf("chain denom for (%s) not found", humanDenom)
+					}
+					return chainDenom, nil
+				},
+				GetChainScalingFactorByDenomMutFunc: func(denom string) (osmomath.Dec, error) {
+					return sixPrecisionScalingFactor, nil
+				},
+			}
+
+			var actualTokenIn sdk.Coin
+			routerUsecase := &mocks.RouterUsecaseMock{
+				GetSimpleQuoteFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+					actualTokenIn = tokenIn
+					return nil, nil
+				},
+			}
+
+			config := defaultPricingConfig
+			config.DefaultQuoteHumanDenom = usdcHumanDenom
+			config.QuoteProbeAmounts = tc.quoteProbeAmounts
+
+			pricingSource := chainpricing.New(routerUsecase, tokensUsecase, config)
+
+			// System under test
+			_, err := pricingSource.GetPrice(context.Background(), ATOM, tc.quoteDenom, domain.WithRecomputePrices())
+
+			// No quote is returned by the mock.
+			s.Require().Error(err)
+			s.Require().Equal(tc.expectedTokenIn, actualTokenIn)
+		})
+	}
+}
+
+// This test validates that the prices computed with different liquidity floors
+// are cached separately so that a cached price computed with one floor is never
+// returned for a request with another floor.
+func (s *PricingTestSuite) TestGetPrice_MinPoolLiquidityCapCacheKey() {
+	const (
+		usdcHumanDenom = "usdc"
+
+		defaultMinPoolLiquidityCap = 50
+		customMinPoolLiquidityCap  = 1000
+	)
+
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			return USDC, nil
+		},
+		GetChainScalingFactorByDenomMutFunc: func(denom string) (osmomath.Dec, error) {
+			return osmomath.NewDec(1_000_000), nil
+		},
+		GetFullTokenMetadataFunc: func() (map[string]domain.Token, error) {
+			return map[string]domain.Token{ATOM: {}, USDC: {}}, nil
+		},
+	}
+
+	// The route is over the pool with ID equal to the requested liquidity floor
+	// and the spot price of the pool is equal to its ID. As a result, the computed
+	// price is equal to the liquidity floor it was computed with.
+	numQuotes := 0
+	routerUsecase := &mocks.RouterUsecaseMock{
+		GetSimpleQuoteFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+			numQuotes++
+
+			options := domain.RouterOptions{}
+			for _, opt := range opts {
+				opt(&options)
+			}
+
+			return &usecase.QuoteExactAmountIn{
+				AmountIn:  tokenIn,
+				AmountOut: osmomath.OneInt(),
+				Route: []domain.SplitRoute{
+					&usecase.RouteWithOutAmount{
+						RouteImpl: route.RouteImpl{
+							Pools: []domain.RoutablePool{
+								&mocks.MockRoutablePool{ID: options.MinPoolLiquidityCap, TokenOutDenom: tokenOutDenom},
+							},
+						},
+					},
+				},
+			}, nil
+		},
+		GetPoolSpotPriceFunc: func(ctx context.Context, poolID uint64, quoteAsset, baseAsset string) (osmomath.BigDec, error) {
+			return osmomath.NewBigDec(int64(poolID)), nil
+		},
+	}
+
+	config := defaultPricingConfig
+	config.DefaultQuoteHumanDenom = usdcHumanDenom
+	config.MinPoolLiquidityCap = defaultMinPoolLiquidityCap
+
+	pricingSource := chainpricing.New(routerUsecase, tokensUsecase, config)
+
+	var (
+		defaultPrice = osmomath.NewBigDec(defaultMinPoolLiquidityCap)
+		customPrice  = osmomath.NewBigDec(customMinPoolLiquidityCap)
+	)
+
+	// Populate the cache with the default floor.
+	price, err := pricingSource.GetPrice(context.Background(), ATOM, USDC)
+	s.Require().NoError(err)
+	s.Require().Equal(defaultPrice, price)
+	s.Require().Equal(1, numQuotes)
+
+	// System under test: a different floor misses the cache and is computed with that floor.
+	price, err = pricingSource.GetPrice(context.Background(), ATOM, USDC, domain.WithMinPricingPoolLiquidityCap(customMinPoolLiquidityCap))
+	s.Require().NoError(err)
+	s.Require().Equal(customPrice, price)
+	s.Require().Equal(2, numQuotes)
+
+	// Both floors are now served from the cache.
+	price, err = pricingSource.GetPrice(context.Background(), ATOM, USDC, domain.WithMinPricingPoolLiquidityCap(customMinPoolLiquidityCap))
+	s.Require().NoError(err)
+	s.Require().Equal(customPrice, price)
+
+	price, err = pricingSource.GetPrice(context.Background(), ATOM, USDC)
+	s.Require().NoError(err)
+	s.Require().Equal(defaultPrice, price)
+	s.Require().Equal(2, numQuotes)
+
+	// Invalidating the cache drops the prices for all floors.
+	pricingSource.InvalidateCache("", ATOM)
+
+	_, err = pricingSource.GetPrice(context.Background(), ATOM, USDC, domain.WithMinPricingPoolLiquidityCap(customMinPoolLiquidityCap))
+	s.Require().NoError(err)
+	_, err = pricingSource.GetPrice(context.Background(), ATOM, USDC)
+	s.Require().NoError(err)
+	s.Require().Equal(4, numQuotes)
+
+	// Invalidating the quote denom keeps the prices against it since only the base denoms are invalidated.
+	pricingSource.InvalidateCache("", USDC)
+
+	_, err = pricingSource.GetPrice(context.Background(), ATOM, USDC)
+	s.Require().NoError(err)
+	s.Require().Equal(4, numQuotes)
+
+	// Excluding the quote denom keeps the prices against it.
+	pricingSource.InvalidateCache(USDC, ATOM)
+
+	_, err = pricingSource.GetPrice(context.Background(), ATOM, USDC)
+	s.Require().NoError(err)
+	s.Require().Equal(4, numQuotes)
+
+	// Invalidating an unrelated denom keeps the cached prices.
+	pricingSource.InvalidateCache("", ETH)
+
+	_, err = pricingSource.GetPrice(context.Background(), ATOM, USDC)
+	s.Require().NoError(err)
+	s.Require().Equal(4, numQuotes)
+
+	// The prices pre-computed by the pricing worker are served for the requests with the default floor.
+	s.Run("worker floor is cached under the default key", func() {
+		const workerMinPoolLiquidityCap = 1
+
+		config := config
+		config.WorkerMinPoolLiquidityCap = workerMinPoolLiquidityCap
+
+		pricingSource := chainpricing.New(routerUsecase, tokensUsecase, config)
+		numQuotes = 0
+
+		// Pre-compute the price as the pricing worker does.
+		price, err := pricingSource.GetPrice(context.Background(), ATOM, USDC, domain.WithRecomputePrices(), domain.WithMinPricingPoolLiquidityCap(workerMinPoolLiquidityCap))
+		s.Require().NoError(err)
+		s.Require().Equal(osmomath.NewBigDec(workerMinPoolLiquidityCap), price)
+		s.Require().Equal(1, numQuotes)
+
+		// System under test: the request with the default floor is served from the cache.
+		price, err = pricingSource.GetPrice(context.Background(), ATOM, USDC)
+		s.Require().NoError(err)
+		s.Require().Equal(osmomath.NewBigDec(workerMinPoolLiquidityCap), price)
+		s.Require().Equal(1, numQuotes)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,11 +18,18 @@ import (
 const USDC_DENOM = "ibc/498A0751C798A0D9A389AA3691123DADA57DAA4FE165D5C75894505B876BA6E4"
 const USDT_DENOM = "ibc/4ABBEF4C8926DDDB320AE5188CFD63267ABBCEFC0583E4AE05D6E5AA2401DDAB"
 
-// CoingeckoPriceGetterFn is a function type that fetches the price of a token from Coingecko.
+// defaultStablecoinDenoms are the quote denoms accepted if no stablecoins are configured.
+var defaultStablecoinDenoms = map[string]struct{}{
+	USDC_DENOM: {},
+	USDT_DENOM: {},
+}
+
+// CoingeckoPriceGetterFn is a function type that fetches the prices of a token from Coingecko
+// against the given vs currencies in a single request. Returns the prices by vs currency.
 // We monkey-patch this function for testing purposes.
-type CoingeckoPriceGetterFn func(ctx context.Context, baseDenom string, coingeckoId string) (osmomath.BigDec, error)
+type CoingeckoPriceGetterFn func(ctx context.Context, baseDenom string, coingeckoId string, vsCurrencies []string) (map[string]osmomath.BigDec, error)
 
-// DefaultCoingeckoPriceGetter represents a placeholder for the default implementation of CoingeckoPriceGetterFn, which invokes GetPriceByCoingeckoId method that is defined on coingeckoPricing.
+// DefaultCoingeckoPriceGetter represents a placeholder for the default implementation of CoingeckoPriceGetterFn, which invokes GetPricesByCoingeckoId method that is defined on coingeckoPricing.
 var DefaultCoingeckoPriceGetter CoingeckoPriceGetterFn = nil
 
 type coingeckoPricing struct {
@@ -31,103 +39,219 @@ type coingeckoPricing struct {
 	quoteCurrency string
 	coingeckoUrl  string
 
+	// stablecoinDenoms are the chain denoms accepted as the quote denom.
+	// These are priced in the quoteCurrency.
+	stablecoinDenoms map[string]struct{}
+
+	// quoteDenomCurrencies maps the additional quote chain denoms to the Coingecko vs currency.
+	quoteDenomCurrencies map[string]string
+
 	// We monkey-patch this function for testing purposes.
 	priceGetterFn CoingeckoPriceGetterFn
 }
 
+var _ domain.MultiQuotePricingSource = &coingeckoPricing{}
+
 // New creates a new Coingecko pricing source.
 // if coinGeckoPriceGetterFn is nil, it uses the default implementation.
-func New(tokenUseCase mvc.TokensUsecase, config domain.PricingConfig, coingeckoPriceGetterFn CoingeckoPriceGetterFn) domain.PricingSource {
+// Returns error if any of the configured stablecoin or quote currency human denoms is unknown.
+func New(tokenUseCase mvc.TokensUsecase, config domain.PricingConfig, coingeckoPriceGetterFn CoingeckoPriceGetterFn) (domain.PricingSource, error) {
+	stablecoinDenoms := defaultStablecoinDenoms
+	if len(config.StablecoinHumanDenoms) > 0 {
+		stablecoinDenoms = make(map[string]struct{}, len(config.StablecoinHumanDenoms))
+		for _, stablecoinHumanDenom := range config.StablecoinHumanDenoms {
+			stablecoinChainDenom, err := tokenUseCase.GetChainDenom(stablecoinHumanDenom)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get chain denom for stablecoin human denom (%s): %w", stablecoinHumanDenom, err)
+			}
+
+			stablecoinDenoms[stablecoinChainDenom] = struct{}{}
+		}
+	}
+
+	quoteDenomCurrencies := make(map[string]string, len(config.CoingeckoQuoteCurrencies))
+	for quoteHumanDenom, vsCurrency := range config.CoingeckoQuoteCurrencies {
+		quoteChainDenom, err := tokenUseCase.GetChainDenom(quoteHumanDenom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain denom for coingecko quote currency human denom (%s): %w", quoteHumanDenom, err)
+		}
+
+		quoteDenomCurrencies[quoteChainDenom] = vsCurrency
+	}
+
 	coingeckoPricing := &coingeckoPricing{
 		TUsecase:      tokenUseCase,
 		cache:         cache.New(),
 		cacheExpiryNs: time.Duration(config.CacheExpiryMs) * time.Millisecond,
 		quoteCurrency: config.CoingeckoQuoteCurrency,
 		coingeckoUrl:  config.CoingeckoUrl,
+
+		stablecoinDenoms:     stablecoinDenoms,
+		quoteDenomCurrencies: quoteDenomCurrencies,
 	}
 
 	if coingeckoPriceGetterFn == nil {
 		// Set the default price getter function.
-		coingeckoPricing.priceGetterFn = coingeckoPricing.GetPriceByCoingeckoId
+		coingeckoPricing.priceGetterFn = coingeckoPricing.GetPricesByCoingeckoId
 	} else {
 		// Set the custom price getter function (useful for testing purposes)
 		coingeckoPricing.priceGetterFn = coingeckoPriceGetterFn
 	}
 
-	return coingeckoPricing
+	return coingeckoPricing, nil
 }
 
 // GetPrice implements pricing.PricingStrategy.
-// Coingecko pricing is always usd (i.e. usdc or usdt), as specified in the coingecko-quote-currency in config.json
-// So quoteDenom has to be nil or usdc or usdt
+// Coingecko pricing is in usd, as specified in the coingecko-quote-currency in config.json,
+// for quoteDenom that is nil or one of the configured stablecoins (usdc or usdt by default).
+// Otherwise, the quoteDenom has to be one of the configured coingecko-quote-currencies.
 func (c *coingeckoPricing) GetPrice(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
-	if quoteDenom != USDC_DENOM && quoteDenom != USDT_DENOM && strings.TrimSpace(quoteDenom) != "" {
-		return osmomath.BigDec{}, fmt.Errorf("only usdc/usdt denom or nil is allowed for the quote denom param")
+	prices, err := c.GetPricesForQuotes(ctx, baseDenom, []string{quoteDenom}, opts...)
+	if err != nil {
+		return osmomath.BigDec{}, err
 	}
+
+	return prices[quoteDenom], nil
+}
+
+// GetPricesForQuotes implements domain.MultiQuotePricingSource.
+// The prices against all quote denoms that are not cached are fetched in a single Coingecko request.
+func (c *coingeckoPricing) GetPricesForQuotes(ctx context.Context, baseDenom string, quoteDenoms []string, opts ...domain.PricingOption) (map[string]osmomath.BigDec, error) {
+	options := domain.PricingOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	quoteVsCurrencies := make(map[string]string, len(quoteDenoms))
+	for _, quoteDenom := range quoteDenoms {
+		vsCurrency, err := c.getVsCurrency(quoteDenom)
+		if err != nil {
+			return nil, err
+		}
+
+		quoteVsCurrencies[quoteDenom] = vsCurrency
+	}
+
 	coingeckoId, err := c.TUsecase.GetCoingeckoIdByChainDenom(baseDenom)
 	if err != nil {
-		return osmomath.BigDec{}, err
+		return nil, err
 	}
 
-	cacheKey := domain.FormatPricingCacheKey(baseDenom, c.quoteCurrency)
-	cachedValue, found := c.cache.Get(cacheKey)
+	// Retrieve the cached prices by vs currency, collecting the ones to fetch.
+	pricesByVsCurrency := make(map[string]osmomath.BigDec, len(quoteVsCurrencies))
+	vsCurrenciesToFetch := make([]string, 0, len(quoteVsCurrencies))
+	for _, vsCurrency := range quoteVsCurrencies {
+		if _, ok := pricesByVsCurrency[vsCurrency]; ok {
+			continue
+		}
 
-	if found {
-		// Cast cached value to correct type.
-		cachedBigDecPrice, ok := cachedValue.(osmomath.BigDec)
-		if !ok {
-			return osmomath.BigDec{}, fmt.Errorf("invalid type cached in pricing, expected BigDec, got (%T)", cachedValue)
+		cacheKey := domain.FormatPricingCacheKey(domain.CoinGeckoPricingSourceType, baseDenom, vsCurrency)
+		cachedValue, found := c.cache.Get(cacheKey)
+
+		if found {
+			// Cast cached value to correct type.
+			cachedBigDecPrice, ok := cachedValue.(osmomath.BigDec)
+			if !ok {
+				return nil, fmt.Errorf("invalid type cached in pricing, expected BigDec, got (%T)", cachedValue)
+			}
+			// Increase cache hits
+			if !options.DisableCacheMetrics {
+				domain.SQSPricingCoingeckoCacheHitsCounter.Inc()
+			}
+			pricesByVsCurrency[vsCurrency] = cachedBigDecPrice
+			continue
 		}
-		// Increase cache hits
-		domain.SQSPricingCoingeckoCacheHitsCounter.Inc()
-		return cachedBigDecPrice, nil
-	} else if !found {
+
 		// Increase cache misses
-		domain.SQSPricingCoingeckoCacheMissesCounter.Inc()
+		if !options.DisableCacheMetrics {
+			domain.SQSPricingCoingeckoCacheMissesCounter.Inc()
+		}
+
+		// Placeholder to deduplicate the vs currencies shared by multiple quote denoms.
+		pricesByVsCurrency[vsCurrency] = osmomath.BigDec{}
+		vsCurrenciesToFetch = append(vsCurrenciesToFetch, vsCurrency)
 	}
 
-	price, err := c.priceGetterFn(ctx, baseDenom, coingeckoId)
-	if err != nil {
-		return osmomath.BigDec{}, err
+	if len(vsCurrenciesToFetch) > 0 {
+		// Sort for a deterministic request.
+		sort.Strings(vsCurrenciesToFetch)
+
+		fetchedPrices, err := c.priceGetterFn(ctx, baseDenom, coingeckoId, vsCurrenciesToFetch)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vsCurrency := range vsCurrenciesToFetch {
+			price := fetchedPrices[vsCurrency]
+
+			cacheKey := domain.FormatPricingCacheKey(domain.CoinGeckoPricingSourceType, baseDenom, vsCurrency)
+			c.cache.Set(cacheKey, price, c.cacheExpiryNs)
+
+			pricesByVsCurrency[vsCurrency] = price
+		}
+	}
+
+	// Demultiplex the prices by vs currency into the prices by quote denom.
+	result := make(map[string]osmomath.BigDec, len(quoteVsCurrencies))
+	for quoteDenom, vsCurrency := range quoteVsCurrencies {
+		result[quoteDenom] = pricesByVsCurrency[vsCurrency]
+	}
+
+	return result, nil
+}
+
+// getVsCurrency returns the Coingecko vs currency for the given quote denom.
+// Returns error if the quote denom is not nil, one of the stablecoins or the configured quote currencies.
+func (c *coingeckoPricing) getVsCurrency(quoteDenom string) (string, error) {
+	if _, isStablecoin := c.stablecoinDenoms[quoteDenom]; isStablecoin || strings.TrimSpace(quoteDenom) == "" {
+		return c.quoteCurrency, nil
+	}
+
+	if vsCurrency, ok := c.quoteDenomCurrencies[quoteDenom]; ok {
+		return vsCurrency, nil
 	}
-	return price, nil
+
+	return "", fmt.Errorf("only stablecoin denom, configured quote currency denom or nil is allowed for the quote denom param, got (%s)", quoteDenom)
 }
 
-// GetPriceByCoingeckoId fetches the price of a token from Coingecko.
-func (c coingeckoPricing) GetPriceByCoingeckoId(ctx context.Context, baseDenom string, coingeckoId string) (osmomath.BigDec, error) {
+// GetPricesByCoingeckoId fetches the prices of a token from Coingecko against the given vs currencies in a single request.
+// Returns error if the price against any of the vs currencies is not found.
+func (c coingeckoPricing) GetPricesByCoingeckoId(ctx context.Context, baseDenom string, coingeckoId string, vsCurrencies []string) (map[string]osmomath.BigDec, error) {
 	if coingeckoId == "" {
-		return osmomath.BigDec{}, fmt.Errorf("coingecko ID is empty for base (%s)", baseDenom)
+		return nil, fmt.Errorf("coingecko ID is empty for base (%s)", baseDenom)
 	}
 
-	url := fmt.Sprintf("%s?ids=%s&vs_currencies=%s", c.coingeckoUrl, coingeckoId, c.quoteCurrency)
+	url := fmt.Sprintf("%s?ids=%s&vs_currencies=%s", c.coingeckoUrl, coingeckoId, strings.Join(vsCurrencies, ","))
 	resp, err := http.Get(url)
 	if err != nil {
-		return osmomath.BigDec{}, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return osmomath.BigDec{}, fmt.Errorf("failed to get price from Coingecko: %s", resp.Status)
+		return nil, fmt.Errorf("failed to get price from Coingecko: %s", resp.Status)
 	}
 
 	var data map[string]map[string]float64
 	err = json.NewDecoder(resp.Body).Decode(&data)
 	if err != nil {
-		return osmomath.BigDec{}, fmt.Errorf("failed to decode Coingecko response: %s", err)
+		return nil, fmt.Errorf("failed to decode Coingecko response: %s", err)
 	}
 
-	price, ok := data[coingeckoId][c.quoteCurrency]
-	if !ok {
-		return osmomath.BigDec{}, fmt.Errorf("price not found for coingecko ID: %s", coingeckoId)
-	}
+	result := make(map[string]osmomath.BigDec, len(vsCurrencies))
+	for _, vsCurrency := range vsCurrencies {
+		price, ok := data[coingeckoId][vsCurrency]
+		if !ok {
+			return nil, fmt.Errorf("price not found for coingecko ID: %s, vs currency: %s", coingeckoId, vsCurrency)
+		}
 
-	result, err := osmomath.NewBigDecFromStr(fmt.Sprintf("%f", price))
-	if err != nil {
-		return osmomath.BigDec{}, err
-	}
+		vsCurrencyPrice, err := osmomath.NewBigDecFromStr(fmt.Sprintf("%f", price))
+		if err != nil {
+			return nil, err
+		}
 
-	cacheKey := domain.FormatPricingCacheKey(baseDenom, c.quoteCurrency)
-	c.cache.Set(cacheKey, result, c.cacheExpiryNs)
+		result[vsCurrency] = vsCurrencyPrice
+	}
 
 	return result, nil
 }
@@ -137,6 +261,20 @@ func (c *coingeckoPricing) InitializeCache(cache *cache.Cache) {
 	c.cache = cache
 }
 
+// InvalidateCache implements pricing.PricingSource
+func (c *coingeckoPricing) InvalidateCache(excludedQuoteDenom string, baseDenoms ...string) {
+	for _, baseDenom := range baseDenoms {
+		if c.quoteCurrency != excludedQuoteDenom {
+			c.cache.Delete(domain.FormatPricingCacheKey(domain.CoinGeckoPricingSourceType, baseDenom, c.quoteCurrency))
+		}
+		for _, vsCurrency := range c.quoteDenomCurrencies {
+			if vsCurrency != excludedQuoteDenom {
+				c.cache.Delete(domain.FormatPricingCacheKey(domain.CoinGeckoPricingSourceType, baseDenom, vsCurrency))
+			}
+		}
+	}
+}
+
 // GetFallbackStrategy implements pricing.PricingSource
 func (c *coingeckoPricing) GetFallbackStrategy(quoteDenom string) domain.PricingSourceType {
 	// Currently there is no fallback mechanism for Coingecko
@@ -3,6 +3,8 @@ package coingeckopricing_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/osmosis-labs/sqs/domain/mocks"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
 	coingeckopricing "github.com/osmosis-labs/sqs/tokens/usecase/pricing/coingecko"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -46,7 +49,8 @@ func (s *CoingeckoPricingTestSuite) TestGetPrices() {
 
 	mainnetUsecase := s.SetupDefaultRouterAndPoolsUsecase()
 	defaultPricingConfig.DefaultSource = domain.CoinGeckoPricingSourceType
-	coingeckoPricingSource := coingeckopricing.New(mainnetUsecase.Tokens, defaultPricingConfig, mocks.DefaultMockCoingeckoPriceGetter)
+	coingeckoPricingSource, err := coingeckopricing.New(mainnetUsecase.Tokens, defaultPricingConfig, mocks.DefaultMockCoingeckoPriceGetter)
+	s.Require().NoError(err)
 
 	tests := []struct {
 		desc          string
@@ -79,6 +83,170 @@ func (s *CoingeckoPricingTestSuite) TestGetPrices() {
 
 }
 
+// TestGetPrices_CustomStablecoins tests that the GetPrice method of CoingeckoPricing only accepts
+// the configured stablecoins as the quote denom.
+func (s *CoingeckoPricingTestSuite) TestGetPrices_CustomStablecoins() {
+	mainnetUsecase := s.SetupDefaultRouterAndPoolsUsecase()
+
+	pricingConfig := defaultPricingConfig
+	pricingConfig.DefaultSource = domain.CoinGeckoPricingSourceType
+	pricingConfig.StablecoinHumanDenoms = []string{"usdc"}
+
+	coingeckoPricingSource, err := coingeckopricing.New(mainnetUsecase.Tokens, pricingConfig, mocks.DefaultMockCoingeckoPriceGetter)
+	s.Require().NoError(err)
+
+	tests := []struct {
+		desc          string
+		baseDenom     string
+		quoteDenom    string
+		expectedPrice osmomath.BigDec
+		shouldErr     bool
+	}{
+		{"Test coingecko GetPrice with configured stablecoin USDC as quote denom", ATOM, USDC, mocks.AtomPrice, false},
+		{"Test coingecko GetPrice with quote denom as empty string", ATOM, "", mocks.AtomPrice, false},
+		{"Test coingecko GetPrice with non-configured stablecoin USDT as quote denom", ATOM, USDT, mocks.NilBigDec, true},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.desc, func() {
+			price, err := coingeckoPricingSource.GetPrice(context.Background(), tt.baseDenom, tt.quoteDenom)
+			s.Require().Equal(tt.shouldErr, err != nil)
+			s.Require().Equal(tt.expectedPrice, price)
+		})
+	}
+
+	// Unknown stablecoin human denom is rejected at startup.
+	pricingConfig.StablecoinHumanDenoms = []string{"usdc", "unknown"}
+	_, err = coingeckopricing.New(mainnetUsecase.Tokens, pricingConfig, mocks.DefaultMockCoingeckoPriceGetter)
+	s.Require().Error(err)
+}
+
+// TestGetPricesForQuotes_MultipleVsCurrencies tests that the prices against the USD and BTC quotes
+// are fetched in a single Coingecko request and demultiplexed by quote denom.
+func (s *CoingeckoPricingTestSuite) TestGetPricesForQuotes_MultipleVsCurrencies() {
+	mainnetUsecase := s.SetupDefaultRouterAndPoolsUsecase()
+
+	wbtcChainDenom, err := mainnetUsecase.Tokens.GetChainDenom("wbtc")
+	s.Require().NoError(err)
+
+	pricingConfig := defaultPricingConfig
+	pricingConfig.DefaultSource = domain.CoinGeckoPricingSourceType
+	pricingConfig.CoingeckoQuoteCurrencies = map[string]string{"wbtc": mocks.BTC_VS_CURRENCY}
+
+	getterCalls := 0
+	var requestedVsCurrencies []string
+	countingPriceGetter := func(ctx context.Context, baseDenom string, coingeckoId string, vsCurrencies []string) (map[string]osmomath.BigDec, error) {
+		getterCalls++
+		requestedVsCurrencies = vsCurrencies
+		return mocks.DefaultMockCoingeckoPriceGetter(ctx, baseDenom, coingeckoId, vsCurrencies)
+	}
+
+	coingeckoPricingSource, err := coingeckopricing.New(mainnetUsecase.Tokens, pricingConfig, countingPriceGetter)
+	s.Require().NoError(err)
+
+	multiQuotePricingSource, ok := coingeckoPricingSource.(domain.MultiQuotePricingSource)
+	s.Require().True(ok)
+
+	// System under test
+	prices, err := multiQuotePricingSource.GetPricesForQuotes(context.Background(), ATOM, []string{USDC, USDT, wbtcChainDenom})
+	s.Require().NoError(err)
+
+	// Both vs currencies are fetched in a single request.
+	s.Require().Equal(1, getterCalls)
+	s.Require().Equal([]string{mocks.BTC_VS_CURRENCY, pricingConfig.CoingeckoQuoteCurrency}, requestedVsCurrencies)
+
+	s.Require().Equal(map[string]osmomath.BigDec{
+		USDC:           mocks.AtomPrice,
+		USDT:           mocks.AtomPrice,
+		wbtcChainDenom: mocks.AtomBtcPrice,
+	}, prices)
+
+	// Prices are cached per vs currency.
+	price, err := coingeckoPricingSource.GetPrice(context.Background(), ATOM, wbtcChainDenom)
+	s.Require().NoError(err)
+	s.Require().Equal(mocks.AtomBtcPrice, price)
+	s.Require().Equal(1, getterCalls)
+
+	// Quote denom that is neither a stablecoin nor a configured quote currency is rejected.
+	_, err = multiQuotePricingSource.GetPricesForQuotes(context.Background(), ATOM, []string{USDC, ETH})
+	s.Require().Error(err)
+
+	// Unknown quote currency human denom is rejected at startup.
+	pricingConfig.CoingeckoQuoteCurrencies = map[string]string{"unknown": mocks.BTC_VS_CURRENCY}
+	_, err = coingeckopricing.New(mainnetUsecase.Tokens, pricingConfig, mocks.DefaultMockCoingeckoPriceGetter)
+	s.Require().Error(err)
+}
+
+// TestGetPricesForQuotes_WithoutCacheMetrics tests that the cache hits and misses are not recorded
+// when the prices are fetched with the cache metrics disabled.
+func (s *CoingeckoPricingTestSuite) TestGetPricesForQuotes_WithoutCacheMetrics() {
+	mainnetUsecase := s.SetupDefaultRouterAndPoolsUsecase()
+
+	coingeckoPricingSource, err := coingeckopricing.New(mainnetUsecase.Tokens, defaultPricingConfig, mocks.DefaultMockCoingeckoPriceGetter)
+	s.Require().NoError(err)
+
+	multiQuotePricingSource, ok := coingeckoPricingSource.(domain.MultiQuotePricingSource)
+	s.Require().True(ok)
+
+	hitsBefore := testutil.ToFloat64(domain.SQSPricingCoingeckoCacheHitsCounter)
+	missesBefore := testutil.ToFloat64(domain.SQSPricingCoingeckoCacheMissesCounter)
+
+	// System under test: the cache miss is not recorded.
+	_, err = multiQuotePricingSource.GetPricesForQuotes(context.Background(), ATOM, []string{USDC}, domain.WithoutPricingCacheMetrics())
+	s.Require().NoError(err)
+
+	s.Require().Equal(hitsBefore, testutil.ToFloat64(domain.SQSPricingCoingeckoCacheHitsCounter))
+	s.Require().Equal(missesBefore, testutil.ToFloat64(domain.SQSPricingCoingeckoCacheMissesCounter))
+
+	// The subsequent lookup records the cache hit exactly once.
+	price, err := coingeckoPricingSource.GetPrice(context.Background(), ATOM, USDC)
+	s.Require().NoError(err)
+	s.Require().Equal(mocks.AtomPrice, price)
+
+	s.Require().Equal(hitsBefore+1, testutil.ToFloat64(domain.SQSPricingCoingeckoCacheHitsCounter))
+	s.Require().Equal(missesBefore, testutil.ToFloat64(domain.SQSPricingCoingeckoCacheMissesCounter))
+}
+
+// TestGetPricesByCoingeckoId_MultipleVsCurrencies tests that the default price getter requests all vs currencies
+// in a single HTTP request and populates the price for each of them.
+func (s *CoingeckoPricingTestSuite) TestGetPricesByCoingeckoId_MultipleVsCurrencies() {
+	mainnetUsecase := s.SetupDefaultRouterAndPoolsUsecase()
+
+	requestCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		s.Require().Equal(mocks.ATOM_COINGECKO_ID, r.URL.Query().Get("ids"))
+		s.Require().Equal("btc,usd", r.URL.Query().Get("vs_currencies"))
+
+		fmt.Fprint(w, `{"cosmos":{"usd":5,"btc":0.0001}}`)
+	}))
+	defer server.Close()
+
+	pricingConfig := defaultPricingConfig
+	pricingConfig.DefaultSource = domain.CoinGeckoPricingSourceType
+	pricingConfig.CoingeckoUrl = server.URL
+	pricingConfig.CoingeckoQuoteCurrencies = map[string]string{"wbtc": mocks.BTC_VS_CURRENCY}
+
+	wbtcChainDenom, err := mainnetUsecase.Tokens.GetChainDenom("wbtc")
+	s.Require().NoError(err)
+
+	coingeckoPricingSource, err := coingeckopricing.New(mainnetUsecase.Tokens, pricingConfig, coingeckopricing.DefaultCoingeckoPriceGetter)
+	s.Require().NoError(err)
+
+	multiQuotePricingSource, ok := coingeckoPricingSource.(domain.MultiQuotePricingSource)
+	s.Require().True(ok)
+
+	// System under test
+	prices, err := multiQuotePricingSource.GetPricesForQuotes(context.Background(), ATOM, []string{USDC, wbtcChainDenom})
+	s.Require().NoError(err)
+
+	s.Require().Equal(1, requestCount)
+	s.Require().Equal(map[string]osmomath.BigDec{
+		USDC:           osmomath.NewBigDec(5),
+		wbtcChainDenom: osmomath.MustNewBigDecFromStr("0.0001"),
+	}, prices)
+}
+
 // TestGetPrices_Coingecko_FindUnsupportedTokens is a test to identify which mainnet tokens are unsupported tokens in Coingecko.
 func (s *CoingeckoPricingTestSuite) TestGetPrices_Coingecko_FindUnsupportedTokens() {
 	env := os.Getenv("CI_SQS_PRICING_COINGECKO_TEST")
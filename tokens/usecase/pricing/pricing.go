@@ -3,6 +3,7 @@ package pricing
 import (
 	"fmt"
 
+	"github.com/osmosis-labs/osmosis/osmomath"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/cache"
 	"github.com/osmosis-labs/sqs/domain/mvc"
@@ -16,12 +17,52 @@ func NewPricingStrategy(config domain.PricingConfig, tokensUsecase mvc.TokensUse
 		return chainpricing.New(routerUseCase, tokensUsecase, config), nil
 	}
 	if config.DefaultSource == domain.CoinGeckoPricingSourceType {
-		return coingeckopricing.New(tokensUsecase, config, coingeckopricing.DefaultCoingeckoPriceGetter), nil
+		return coingeckopricing.New(tokensUsecase, config, coingeckopricing.DefaultCoingeckoPriceGetter)
 	}
 
 	return nil, fmt.Errorf("pricing source (%d) is not supported", config.DefaultSource)
 }
 
+// GetDefaultQuoteChainDenom returns the chain denom of the configured default quote human denom.
+// Returns error if the default quote human denom does not resolve to a listed chain denom.
+func GetDefaultQuoteChainDenom(config domain.PricingConfig, tokensUsecase mvc.TokensUsecase) (string, error) {
+	chainDenom, err := tokensUsecase.GetChainDenom(config.DefaultQuoteHumanDenom)
+	if err != nil {
+		return "", fmt.Errorf("pricing default-quote-human-denom (%s) does not resolve to a chain denom: %w", config.DefaultQuoteHumanDenom, err)
+	}
+
+	if !tokensUsecase.IsValidChainDenom(chainDenom) {
+		return "", fmt.Errorf("pricing default-quote-human-denom (%s) resolves to chain denom (%s) that is not listed", config.DefaultQuoteHumanDenom, chainDenom)
+	}
+
+	return chainDenom, nil
+}
+
+// GetCirculatingSupplies returns the configured circulating supplies in human units by chain denom.
+// Returns error if any of the human denoms is unknown or any of the supplies is not a non-negative decimal.
+func GetCirculatingSupplies(config domain.PricingConfig, tokensUsecase mvc.TokensUsecase) (map[string]osmomath.BigDec, error) {
+	circulatingSupplies := make(map[string]osmomath.BigDec, len(config.CirculatingSupplies))
+	for humanDenom, supplyStr := range config.CirculatingSupplies {
+		chainDenom, err := tokensUsecase.GetChainDenom(humanDenom)
+		if err != nil {
+			return nil, fmt.Errorf("pricing circulating-supplies human denom (%s) does not resolve to a chain denom: %w", humanDenom, err)
+		}
+
+		supply, err := osmomath.NewBigDecFromStr(supplyStr)
+		if err != nil {
+			return nil, fmt.Errorf("pricing circulating-supplies for (%s) is not a valid decimal: %w", humanDenom, err)
+		}
+
+		if supply.IsNegative() {
+			return nil, fmt.Errorf("pricing circulating-supplies for (%s) must not be negative, got (%s)", humanDenom, supplyStr)
+		}
+
+		circulatingSupplies[chainDenom] = supply
+	}
+
+	return circulatingSupplies, nil
+}
+
 // WithPricingCache initializes the pricing strategy with a given cache.
 func WithPricingCache(pricingStrategy domain.PricingSource, cache *cache.Cache) domain.PricingSource {
 	pricingStrategy.InitializeCache(cache)
@@ -0,0 +1,240 @@
+package pricing_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/cache"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/router/usecase"
+	"github.com/osmosis-labs/sqs/router/usecase/route"
+	"github.com/osmosis-labs/sqs/tokens/usecase/pricing"
+	chainpricing "github.com/osmosis-labs/sqs/tokens/usecase/pricing/chain"
+	coingeckopricing "github.com/osmosis-labs/sqs/tokens/usecase/pricing/coingecko"
+)
+
+// Tests that the default quote human denom is validated to resolve to a listed chain denom.
+func TestGetDefaultQuoteChainDenom(t *testing.T) {
+	const (
+		USDC          = "uusdc"
+		UNLISTED      = "uunlisted"
+		usdcHuman     = "usdc"
+		unlistedHuman = "unlisted"
+	)
+
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			switch humanDenom {
+			case usdcHuman:
+				return USDC, nil
+			case unlistedHuman:
+				return UNLISTED, nil
+			default:
+				return "", fmt.Errorf("chain denom for human denom (%s) is not found", humanDenom)
+			}
+		},
+		IsValidChainDenomFunc: func(chainDenom string) bool {
+			return chainDenom == USDC
+		},
+	}
+
+	tests := []struct {
+		name                   string
+		defaultQuoteHumanDenom string
+
+		expectedChainDenom string
+		expectErr          bool
+	}{
+		{
+			name:                   "valid default quote",
+			defaultQuoteHumanDenom: usdcHuman,
+
+			expectedChainDenom: USDC,
+		},
+		{
+			name:                   "unknown default quote",
+			defaultQuoteHumanDenom: "unknown",
+
+			expectErr: true,
+		},
+		{
+			name:                   "empty default quote",
+			defaultQuoteHumanDenom: "",
+
+			expectErr: true,
+		},
+		{
+			name:                   "unlisted default quote",
+			defaultQuoteHumanDenom: unlistedHuman,
+
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// System under test
+			chainDenom, err := pricing.GetDefaultQuoteChainDenom(domain.PricingConfig{DefaultQuoteHumanDenom: tt.defaultQuoteHumanDenom}, tokensUsecase)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "default-quote-human-denom")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedChainDenom, chainDenom)
+		})
+	}
+}
+
+// Tests that the configured circulating supplies are resolved to chain denoms and validated.
+func TestGetCirculatingSupplies(t *testing.T) {
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			if humanDenom == "osmo" {
+				return "uosmo", nil
+			}
+			return "", fmt.Errorf("chain denom for human denom (%s) is not found", humanDenom)
+		},
+	}
+
+	tests := []struct {
+		name                string
+		circulatingSupplies map[string]string
+
+		expectedSupplies map[string]osmomath.BigDec
+		expectErr        bool
+	}{
+		{
+			name:                "valid",
+			circulatingSupplies: map[string]string{"osmo": "700000000.5"},
+
+			expectedSupplies: map[string]osmomath.BigDec{"uosmo": osmomath.MustNewBigDecFromStr("700000000.5")},
+		},
+		{
+			name:                "unknown human denom",
+			circulatingSupplies: map[string]string{"unknown": "1"},
+
+			expectErr: true,
+		},
+		{
+			name:                "invalid supply",
+			circulatingSupplies: map[string]string{"osmo": "abc"},
+
+			expectErr: true,
+		},
+		{
+			name:                "negative supply",
+			circulatingSupplies: map[string]string{"osmo": "-1"},
+
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// System under test
+			supplies, err := pricing.GetCirculatingSupplies(domain.PricingConfig{CirculatingSupplies: tt.circulatingSupplies}, tokensUsecase)
+
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSupplies, supplies)
+		})
+	}
+}
+
+// Tests that the chain and coingecko prices for the same pair are cached separately
+// when the pricing sources share the cache.
+func TestPricingCache_SeparatedBySource(t *testing.T) {
+	const (
+		ATOM = "uatom"
+		// The coingecko quote currency is set to the chain quote denom so that
+		// the pair is the same for both sources.
+		USDC = "usdc"
+	)
+
+	var (
+		chainPrice     = osmomath.NewBigDec(2)
+		coingeckoPrice = osmomath.NewBigDec(3)
+	)
+
+	tokensUsecase := &mocks.TokensUsecaseMock{
+		GetChainDenomFunc: func(humanDenom string) (string, error) {
+			return humanDenom, nil
+		},
+		GetChainScalingFactorByDenomMutFunc: func(denom string) (osmomath.Dec, error) {
+			return osmomath.OneDec(), nil
+		},
+		GetCoingeckoIdByChainDenomFunc: func(chainDenom string) (string, error) {
+			return "cosmos", nil
+		},
+	}
+
+	numQuotes := 0
+	routerUsecase := &mocks.RouterUsecaseMock{
+		GetSimpleQuoteFunc: func(ctx context.Context, tokenIn sdk.Coin, tokenOutDenom string, opts ...domain.RouterOption) (domain.Quote, error) {
+			numQuotes++
+			return &usecase.QuoteExactAmountIn{
+				AmountIn:  tokenIn,
+				AmountOut: osmomath.OneInt(),
+				Route: []domain.SplitRoute{
+					&usecase.RouteWithOutAmount{
+						RouteImpl: route.RouteImpl{
+							Pools: []domain.RoutablePool{&mocks.MockRoutablePool{ID: 1, TokenOutDenom: tokenOutDenom}},
+						},
+					},
+				},
+			}, nil
+		},
+		GetPoolSpotPriceFunc: func(ctx context.Context, poolID uint64, quoteAsset, baseAsset string) (osmomath.BigDec, error) {
+			return chainPrice, nil
+		},
+	}
+
+	numFetches := 0
+	priceGetter := func(ctx context.Context, baseDenom string, coingeckoId string, vsCurrencies []string) (map[string]osmomath.BigDec, error) {
+		numFetches++
+		return map[string]osmomath.BigDec{USDC: coingeckoPrice}, nil
+	}
+
+	config := domain.PricingConfig{
+		CacheExpiryMs:          60_000,
+		DefaultQuoteHumanDenom: USDC,
+		StablecoinHumanDenoms:  []string{USDC},
+		CoingeckoQuoteCurrency: USDC,
+	}
+
+	pricingCache := cache.New()
+	chainPricingSource := pricing.WithPricingCache(chainpricing.New(routerUsecase, tokensUsecase, config), pricingCache)
+	coingeckoPricingSource, err := coingeckopricing.New(tokensUsecase, config, priceGetter)
+	require.NoError(t, err)
+	coingeckoPricingSource = pricing.WithPricingCache(coingeckoPricingSource, pricingCache)
+
+	// System under test: price the same pair with both sources twice.
+	for i := 0; i < 2; i++ {
+		price, err := chainPricingSource.GetPrice(context.Background(), ATOM, USDC)
+		require.NoError(t, err)
+		require.Equal(t, chainPrice, price)
+
+		price, err = coingeckoPricingSource.GetPrice(context.Background(), ATOM, USDC)
+		require.NoError(t, err)
+		require.Equal(t, coingeckoPrice, price)
+	}
+
+	// The second round is served from the cache for both sources.
+	require.Equal(t, 1, numQuotes)
+	require.Equal(t, 1, numFetches)
+	require.Equal(t, 2, pricingCache.Len())
+}
@@ -1,7 +1,9 @@
 package worker
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 
 	"cosmossdk.io/math"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -10,7 +12,8 @@ import (
 )
 
 type liquidityPricer struct {
-	defaultQuoteDenom string
+	quoteDenom   string
+	quoteDenomMu sync.RWMutex
 
 	scalingFactorGetterCb domain.ScalingFactorGetterCb
 }
@@ -21,7 +24,7 @@ var _ domain.LiquidityPricer = &liquidityPricer{}
 
 func NewLiquidityPricer(defaultQuoteDenom string, chainScalingFactorGetterCb domain.ScalingFactorGetterCb) domain.LiquidityPricer {
 	return &liquidityPricer{
-		defaultQuoteDenom: defaultQuoteDenom,
+		quoteDenom: defaultQuoteDenom,
 
 		scalingFactorGetterCb: chainScalingFactorGetterCb,
 	}
@@ -29,16 +32,28 @@ func NewLiquidityPricer(defaultQuoteDenom string, chainScalingFactorGetterCb dom
 
 // PriceCoin implements domain.PoolLiquidityPricerWorker.
 func (p *liquidityPricer) PriceCoin(coin sdk.Coin, price osmomath.BigDec) osmomath.Dec {
+	liquidityCapitalization, err := p.priceCoin(coin, price)
+	if err != nil {
+		// If there is an error, keep the total liquidity but set the capitalization to zero.
+		return osmomath.ZeroDec()
+	}
+
+	return liquidityCapitalization
+}
+
+// priceCoin computes the capitalization of the given coin using the price.
+// Returns zero capitalization and no error if the price is zero.
+// Returns error if fails to get the scaling factor or to compute the capitalization.
+func (p *liquidityPricer) priceCoin(coin sdk.Coin, price osmomath.BigDec) (osmomath.Dec, error) {
 	if price.IsZero() {
 		// If the price is zero, set the capitalization to zero.
-		return osmomath.ZeroDec()
+		return osmomath.ZeroDec(), nil
 	}
 
 	// Get the scaling factor for the base denom.
 	baseScalingFactor, err := p.scalingFactorGetterCb(coin.Denom)
 	if err != nil {
-		// If there is an error, keep the total liquidity but set the capitalization to zero.
-		return osmomath.ZeroDec()
+		return osmomath.Dec{}, err
 	}
 
 	priceInfo := domain.DenomPriceInfo{
@@ -46,17 +61,11 @@ func (p *liquidityPricer) PriceCoin(coin sdk.Coin, price osmomath.BigDec) osmoma
 		ScalingFactor: baseScalingFactor,
 	}
 
-	liquidityCapitalization, err := ComputeCoinCap(coin, priceInfo)
-	if err != nil {
-		// If there is an error, keep the total liquidity but set the capitalization to zero.
-		return osmomath.ZeroDec()
-	}
-
-	return liquidityCapitalization
+	return ComputeCoinCap(coin, priceInfo)
 }
 
 // PriceBalances implements domain.PoolLiquidityPricerWorker.
-func (p *liquidityPricer) PriceBalances(balances sdk.Coins, prices domain.PricesResult) (osmomath.Int, string) {
+func (p *liquidityPricer) PriceBalances(balances sdk.Coins, prices domain.PricesResult) (osmomath.Int, string, error) {
 	totalCapitalization := osmomath.ZeroInt()
 
 	// Note: errors may occur in any denom.
@@ -64,12 +73,21 @@ func (p *liquidityPricer) PriceBalances(balances sdk.Coins, prices domain.Prices
 	// to ease debugging if issues occur.
 	liquidityCapErrorStr := ""
 
+	quoteDenom := p.GetQuoteDenom()
+
 	for _, balance := range balances {
 		denom := balance.Denom
 
-		price := prices.GetPriceForDenom(denom, p.defaultQuoteDenom)
+		price := prices.GetPriceForDenom(denom, quoteDenom)
 
-		currentCapitalization := p.PriceCoin(balance, price)
+		currentCapitalization, err := p.priceCoin(balance, price)
+		if errors.As(err, &domain.CapitalizationOverflowError{}) {
+			return osmomath.ZeroInt(), liquidityCapErrorStr, err
+		}
+		if err != nil {
+			// If there is an error, keep the total liquidity but set the capitalization to zero.
+			currentCapitalization = osmomath.ZeroDec()
+		}
 
 		if currentCapitalization.IsZero() {
 			if len(liquidityCapErrorStr) != 0 {
@@ -79,10 +97,27 @@ func (p *liquidityPricer) PriceBalances(balances sdk.Coins, prices domain.Prices
 			liquidityCapErrorStr += formatLiquidityCapErrorStr(denom)
 		}
 
-		totalCapitalization = totalCapitalization.Add(currentCapitalization.Ceil().TruncateInt())
+		totalCapitalization, err = totalCapitalization.SafeAdd(currentCapitalization.Ceil().TruncateInt())
+		if err != nil {
+			return osmomath.ZeroInt(), liquidityCapErrorStr, domain.CapitalizationOverflowError{Denom: denom}
+		}
 	}
 
-	return totalCapitalization, liquidityCapErrorStr
+	return totalCapitalization, liquidityCapErrorStr, nil
+}
+
+// GetQuoteDenom implements domain.LiquidityPricer.
+func (p *liquidityPricer) GetQuoteDenom() string {
+	p.quoteDenomMu.RLock()
+	defer p.quoteDenomMu.RUnlock()
+	return p.quoteDenom
+}
+
+// SetQuoteDenom implements domain.LiquidityPricer.
+func (p *liquidityPricer) SetQuoteDenom(quoteDenom string) {
+	p.quoteDenomMu.Lock()
+	defer p.quoteDenomMu.Unlock()
+	p.quoteDenom = quoteDenom
 }
 
 // formatLiquidityCapErrorStr formats the liquidity cap error
@@ -97,6 +132,7 @@ func formatLiquidityCapErrorStr(denom string) string {
 // * Scaling factor is zero
 // * Truncation occurs in intermediary operations. Truncation is defined as the original amount
 // being non-zero and the computed amount being zero.
+// * The computed amount exceeds the range of osmomath.Int (CapitalizationOverflowError).
 func ComputeCoinCap(coin sdk.Coin, baseDenomPriceData domain.DenomPriceInfo) (math.LegacyDec, error) {
 	if baseDenomPriceData.Price.IsZero() {
 		return osmomath.Dec{}, fmt.Errorf("price for %s is zero", coin.Denom)
@@ -108,6 +144,12 @@ func ComputeCoinCap(coin sdk.Coin, baseDenomPriceData domain.DenomPriceInfo) (ma
 	currentCoinCap := osmomath.BigDecFromSDKInt(coin.Amount).MulMut(baseDenomPriceData.Price).QuoMut(osmomath.BigDecFromDec(baseDenomPriceData.ScalingFactor))
 	isOriginalAmountZero := coin.Amount.IsZero()
 
+	// The capitalization is rounded up when summed into osmomath.Int.
+	// As a result, guard against the ceiled value exceeding its range.
+	if currentCoinCap.Ceil().TruncateInt().BigInt().BitLen() > math.MaxBitLen {
+		return osmomath.Dec{}, domain.CapitalizationOverflowError{Denom: coin.Denom}
+	}
+
 	// Truncation in intermediary operation - return error.
 	if currentCoinCap.IsZero() && !isOriginalAmountZero {
 		return osmomath.Dec{}, fmt.Errorf("truncation occurred when multiplying (%s) of denom (%s) by the scaling factor (%s)", currentCoinCap, coin.Denom, baseDenomPriceData.ScalingFactor)
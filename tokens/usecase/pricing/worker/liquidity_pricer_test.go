@@ -3,6 +3,7 @@ package worker_test
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -19,6 +20,9 @@ var (
 
 	// 1
 	oneScalingFactor = osmomath.OneDec()
+
+	// 2^256 - 1, the largest value representable by osmomath.Int
+	maxInt = osmomath.NewIntFromBigInt(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)))
 )
 
 // TestComputeCoinCap unit tests all valid and error cases of
@@ -44,6 +48,7 @@ func (s *PoolLiquidityComputeWorkerSuite) TestComputeCoinCap() {
 
 		expectedLiquidityCap osmomath.Dec
 		expectedError        bool
+		expectedOverflow     bool
 	}{
 		{
 			name:       "default amount, price one, equal scaling factors",
@@ -106,6 +111,16 @@ func (s *PoolLiquidityComputeWorkerSuite) TestComputeCoinCap() {
 
 			expectedLiquidityCap: osmomath.ZeroDec(),
 		},
+		{
+			name:       "max amount, price one, one scaling factor",
+			coinAmount: maxInt,
+			baseDenomPriceInfo: domain.DenomPriceInfo{
+				Price:         priceOne,
+				ScalingFactor: oneScalingFactor,
+			},
+
+			expectedLiquidityCap: maxInt.ToLegacyDec(),
+		},
 
 		// Error cases
 		{
@@ -141,6 +156,17 @@ func (s *PoolLiquidityComputeWorkerSuite) TestComputeCoinCap() {
 
 			expectedError: true,
 		},
+		{
+			name:       "error: overflow",
+			coinAmount: maxInt,
+			baseDenomPriceInfo: domain.DenomPriceInfo{
+				Price:         priceTwo,
+				ScalingFactor: oneScalingFactor,
+			},
+
+			expectedError:    true,
+			expectedOverflow: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -152,6 +178,9 @@ func (s *PoolLiquidityComputeWorkerSuite) TestComputeCoinCap() {
 			usdcLiquidity, err := worker.ComputeCoinCap(sdk.NewCoin(UOSMO, tt.coinAmount), tt.baseDenomPriceInfo)
 			if tt.expectedError {
 				s.Require().Error(err)
+				if tt.expectedOverflow {
+					s.Require().ErrorAs(err, &domain.CapitalizationOverflowError{})
+				}
 				return
 			}
 
@@ -358,6 +387,7 @@ func (s *PoolLiquidityComputeWorkerSuite) TestPriceBalances() {
 
 		expectedLiquidityCap osmomath.Int
 		errorStr             string
+		expectedErr          error
 	}{
 		{
 			name: "single coin happy path",
@@ -427,6 +457,53 @@ func (s *PoolLiquidityComputeWorkerSuite) TestPriceBalances() {
 			expectedLiquidityCap: zeroCapitalization,
 			errorStr:             worker.FormatLiquidityCapErrorStr(ATOM) + worker.LiquidityCapErrorSeparator + worker.FormatLiquidityCapErrorStr(UOSMO),
 		},
+		{
+			name: "single coin at max value -> no overflow",
+
+			preSetScalingFactorMap: map[string]osmomath.Dec{UOSMO: oneScalingFactor},
+			balances:               sdk.NewCoins(sdk.NewCoin(UOSMO, maxInt)),
+
+			prices: domain.PricesResult{
+				UOSMO: {
+					USDC: osmomath.OneBigDec(),
+				},
+			},
+
+			expectedLiquidityCap: maxInt,
+			errorStr:             noErrorStr,
+		},
+		{
+			name: "single coin capitalization overflows -> error",
+
+			preSetScalingFactorMap: map[string]osmomath.Dec{UOSMO: oneScalingFactor},
+			balances:               sdk.NewCoins(sdk.NewCoin(UOSMO, maxInt)),
+
+			prices: defaultBlockPriceUpdates,
+
+			expectedLiquidityCap: zeroCapitalization,
+			errorStr:             noErrorStr,
+			expectedErr:          domain.CapitalizationOverflowError{Denom: UOSMO},
+		},
+		{
+			name: "sum of capitalizations overflows -> error",
+
+			preSetScalingFactorMap: map[string]osmomath.Dec{UOSMO: oneScalingFactor, ATOM: oneScalingFactor},
+			balances:               sdk.NewCoins(sdk.NewCoin(UOSMO, maxInt), sdk.NewCoin(ATOM, maxInt)),
+
+			prices: domain.PricesResult{
+				UOSMO: {
+					USDC: osmomath.OneBigDec(),
+				},
+				ATOM: {
+					USDC: osmomath.OneBigDec(),
+				},
+			},
+
+			// Note: ATOM is priced first since balances are sorted by denom.
+			expectedLiquidityCap: zeroCapitalization,
+			errorStr:             noErrorStr,
+			expectedErr:          domain.CapitalizationOverflowError{Denom: UOSMO},
+		},
 	}
 
 	for _, tc := range tests {
@@ -439,7 +516,12 @@ func (s *PoolLiquidityComputeWorkerSuite) TestPriceBalances() {
 			// Create liquidity pricer
 			liquidityPricer := worker.NewLiquidityPricer(USDC, scalingFactorGetterMock)
 
-			liquidityCap, errStr := liquidityPricer.PriceBalances(tc.balances, tc.prices)
+			liquidityCap, errStr, err := liquidityPricer.PriceBalances(tc.balances, tc.prices)
+			if tc.expectedErr != nil {
+				s.Require().ErrorIs(err, tc.expectedErr)
+			} else {
+				s.Require().NoError(err)
+			}
 
 			s.Require().Equal(tc.expectedLiquidityCap.String(), liquidityCap.String())
 			s.Require().Equal(tc.errorStr, errStr)
@@ -2,8 +2,10 @@ package worker
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -13,6 +15,7 @@ import (
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
 	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/sqsdomain"
 	"go.uber.org/zap"
 )
 
@@ -23,6 +26,7 @@ const (
 var (
 	_ domain.PricingUpdateListener     = &poolLiquidityPricerWorker{}
 	_ domain.PoolLiquidityPricerWorker = &poolLiquidityPricerWorker{}
+	_ domain.LiquidityDataHeightGetter = &poolLiquidityPricerWorker{}
 )
 
 type poolLiquidityPricerWorker struct {
@@ -30,9 +34,33 @@ type poolLiquidityPricerWorker struct {
 	poolHandler               mvc.PoolHandler
 
 	updateListeners []domain.PoolLiquidityComputeListener
+	// listenersMx guards the update listeners since they may be
+	// registered and deregistered concurrently with the notifications.
+	listenersMx sync.RWMutex
+
+	// notificationCoalesceWindow is the duration for which the listener notifications
+	// are delayed to be coalesced per height. Zero disables the coalescing.
+	notificationCoalesceWindow time.Duration
+	// pendingNotifications are the coalesced listener notifications
+	// awaiting to be flushed by height.
+	pendingNotifications   map[uint64]domain.BlockPoolMetadata
+	pendingNotificationsMx sync.Mutex
+
+	// liquidityCapHistoryRetention is the maximum number of liquidity capitalization
+	// history entries retained per denom. Zero disables the history.
+	liquidityCapHistoryRetention int
+	// Denom -> liquidity capitalization history ordered by height ascending.
+	liquidityCapHistory   map[string][]domain.LiquidityCapHistoryEntry
+	liquidityCapHistoryMx sync.RWMutex
 
 	liquidityPricer domain.LiquidityPricer
 
+	// pricingSource is used to retrieve the prices for the on-demand denom repricing.
+	pricingSource domain.PricingSource
+
+	// skippedDenomPrefixes are the prefixes of the denoms for which repricing is skipped.
+	skippedDenomPrefixes []string
+
 	logger log.Logger
 
 	// Denom -> Last height of the pricing update.
@@ -40,9 +68,19 @@ type poolLiquidityPricerWorker struct {
 	// height might arrive before a pricing update for an earlier height. This map is used to ensure that
 	// the latest height pricing update for a denom is used.
 	latestHeightForDenom sync.Map
+
+	// latestUpdateHeight is the latest height of the pricing updates received.
+	latestUpdateHeight atomic.Uint64
 }
 
-func NewPoolLiquidityWorker(tokensPoolLiquidityHandler mvc.TokensPoolLiquidityHandler, poolHandler mvc.PoolHandler, liquidityPricer domain.LiquidityPricer, logger log.Logger) *poolLiquidityPricerWorker {
+// NewPoolLiquidityWorker creates a new pool liquidity pricer worker.
+// Denoms containing any of the skippedDenomPrefixes are not repriced.
+// If no prefixes are given, only the gamm share denoms are skipped.
+func NewPoolLiquidityWorker(tokensPoolLiquidityHandler mvc.TokensPoolLiquidityHandler, poolHandler mvc.PoolHandler, liquidityPricer domain.LiquidityPricer, skippedDenomPrefixes []string, logger log.Logger) *poolLiquidityPricerWorker {
+	if len(skippedDenomPrefixes) == 0 {
+		skippedDenomPrefixes = []string{gammSharePrefix}
+	}
+
 	return &poolLiquidityPricerWorker{
 		tokenPoolLiquidityHandler: tokensPoolLiquidityHandler,
 		poolHandler:               poolHandler,
@@ -51,9 +89,15 @@ func NewPoolLiquidityWorker(tokensPoolLiquidityHandler mvc.TokensPoolLiquidityHa
 
 		liquidityPricer: liquidityPricer,
 
+		skippedDenomPrefixes: skippedDenomPrefixes,
+
 		logger: logger,
 
 		latestHeightForDenom: sync.Map{},
+
+		pendingNotifications: map[uint64]domain.BlockPoolMetadata{},
+
+		liquidityCapHistory: map[string][]domain.LiquidityCapHistoryEntry{},
 	}
 }
 
@@ -66,6 +110,8 @@ func (p *poolLiquidityPricerWorker) OnPricingUpdate(ctx context.Context, height
 		domain.SQSPoolLiquidityPricingWorkerComputeDurationGauge.Add(float64(time.Since(start).Milliseconds()))
 	}()
 
+	p.storeLatestUpdateHeight(height)
+
 	wg := sync.WaitGroup{}
 
 	wg.Add(1)
@@ -91,13 +137,88 @@ func (p *poolLiquidityPricerWorker) OnPricingUpdate(ctx context.Context, height
 	// Wait for goroutines to finish processing.
 	wg.Wait()
 
-	// Notify listeners.
-	for _, listener := range p.updateListeners {
+	if p.notificationCoalesceWindow > 0 {
+		p.coalesceListenerNotification(ctx, height, blockPoolMetadata)
+		return nil
+	}
+
+	p.notifyListeners(ctx, height, blockPoolMetadata)
+
+	return nil
+}
+
+// notifyListeners notifies the registered listeners of the pool liquidity compute completion.
+func (p *poolLiquidityPricerWorker) notifyListeners(ctx context.Context, height uint64, blockPoolMetadata domain.BlockPoolMetadata) {
+	// Note: iterate over a snapshot so that listeners may be (de)registered concurrently.
+	for _, listener := range p.getListenersSnapshot() {
 		// Avoid checking error since we want to execute all listeners.
 		_ = listener.OnPoolLiquidityCompute(ctx, height, blockPoolMetadata)
 	}
+}
+
+// coalesceListenerNotification merges the block pool metadata into the pending notification for the given height.
+// If there is no pending notification for the height, schedules its flush after the coalesce window.
+// As a result, the listeners receive at most one notification per height within the window with
+// the merged denoms and pool IDs.
+func (p *poolLiquidityPricerWorker) coalesceListenerNotification(ctx context.Context, height uint64, blockPoolMetadata domain.BlockPoolMetadata) {
+	p.pendingNotificationsMx.Lock()
+	defer p.pendingNotificationsMx.Unlock()
+
+	pendingBlockPoolMetadata, ok := p.pendingNotifications[height]
+	if ok {
+		p.pendingNotifications[height] = mergeBlockPoolMetadata(pendingBlockPoolMetadata, blockPoolMetadata)
+		return
+	}
 
-	return nil
+	p.pendingNotifications[height] = mergeBlockPoolMetadata(domain.BlockPoolMetadata{}, blockPoolMetadata)
+
+	// Note: the flush happens after the update is processed, so the context must not be canceled with it.
+	flushCtx := context.WithoutCancel(ctx)
+	time.AfterFunc(p.notificationCoalesceWindow, func() {
+		p.flushListenerNotification(flushCtx, height)
+	})
+}
+
+// flushListenerNotification notifies the listeners of the pending notification for the given height
+// and removes it. No-op if there is no pending notification for the height.
+func (p *poolLiquidityPricerWorker) flushListenerNotification(ctx context.Context, height uint64) {
+	p.pendingNotificationsMx.Lock()
+	blockPoolMetadata, ok := p.pendingNotifications[height]
+	delete(p.pendingNotifications, height)
+	p.pendingNotificationsMx.Unlock()
+
+	if !ok {
+		return
+	}
+
+	p.notifyListeners(ctx, height, blockPoolMetadata)
+}
+
+// mergeBlockPoolMetadata returns the union of the updated denoms and pool IDs of the given block pool metadata.
+// For the denoms present in both, the denom pool liquidity data of the update takes precedence.
+// The given metadata are not mutated.
+func mergeBlockPoolMetadata(current domain.BlockPoolMetadata, update domain.BlockPoolMetadata) domain.BlockPoolMetadata {
+	result := domain.BlockPoolMetadata{
+		DenomPoolLiquidityMap: make(domain.DenomPoolLiquidityMap, len(current.DenomPoolLiquidityMap)+len(update.DenomPoolLiquidityMap)),
+		UpdatedDenoms:         make(map[string]struct{}, len(current.UpdatedDenoms)+len(update.UpdatedDenoms)),
+		PoolIDs:               make(map[uint64]struct{}, len(current.PoolIDs)+len(update.PoolIDs)),
+	}
+
+	for _, metadata := range []domain.BlockPoolMetadata{current, update} {
+		for denom, liquidityData := range metadata.DenomPoolLiquidityMap {
+			result.DenomPoolLiquidityMap[denom] = liquidityData
+		}
+
+		for denom := range metadata.UpdatedDenoms {
+			result.UpdatedDenoms[denom] = struct{}{}
+		}
+
+		for poolID := range metadata.PoolIDs {
+			result.PoolIDs[poolID] = struct{}{}
+		}
+	}
+
+	return result
 }
 
 // RepriceDenomsMetadata implements domain.PoolLiquidityPricerWorker
@@ -119,6 +240,8 @@ func (p *poolLiquidityPricerWorker) RepriceDenomsMetadata(updateHeight uint64, b
 
 		// Store the height for the denom.
 		p.StoreHeightForDenom(updatedBlockDenom, updateHeight)
+
+		p.storeLiquidityCapHistoryEntry(updatedBlockDenom, updateHeight, poolDenomMetaData.TotalLiquidityCap)
 	}
 
 	// Return the updated token metadata for testability
@@ -160,12 +283,96 @@ func (p *poolLiquidityPricerWorker) CreatePoolDenomMetaData(updatedBlockDenom st
 	return result, nil
 }
 
+// RepriceSingleDenom implements domain.PoolLiquidityPricerWorker
+func (p *poolLiquidityPricerWorker) RepriceSingleDenom(ctx context.Context, denom string, height uint64, quoteDenom string) (domain.PoolDenomMetaData, error) {
+	if p.shouldSkipDenomRepricing(denom, height) {
+		return domain.PoolDenomMetaData{}, domain.DenomRepricingSkippedError{
+			Denom:  denom,
+			Height: height,
+		}
+	}
+
+	if p.pricingSource == nil {
+		return domain.PoolDenomMetaData{}, fmt.Errorf("pricing source is not registered for repricing denom (%s)", denom)
+	}
+
+	// Note: in case of an error, the price is zero and the liquidity capitalization
+	// is set to zero with the error returned by CreatePoolDenomMetaData.
+	price, err := p.pricingSource.GetPrice(ctx, denom, quoteDenom)
+	if err != nil {
+		p.logger.Debug("error getting price for denom repricing", zap.String("denom", denom), zap.Error(err))
+		price = osmomath.ZeroBigDec()
+	}
+
+	pools, err := p.poolHandler.GetPools()
+	if err != nil {
+		return domain.PoolDenomMetaData{}, err
+	}
+
+	blockPoolMetadata := domain.BlockPoolMetadata{
+		DenomPoolLiquidityMap: domain.DenomPoolLiquidityMap{},
+	}
+	if denomLiquidityData, ok := computeDenomPoolLiquidityData(pools, denom); ok {
+		blockPoolMetadata.DenomPoolLiquidityMap[denom] = denomLiquidityData
+	}
+
+	blockPriceUpdates := domain.PricesResult{
+		denom: {
+			quoteDenom: price,
+		},
+	}
+
+	poolDenomMetaData, err := p.CreatePoolDenomMetaData(denom, height, blockPriceUpdates, quoteDenom, blockPoolMetadata)
+
+	// Similar to RepriceDenomsMetadata, the best-effort metadata is stored even if an error occurs.
+	p.tokenPoolLiquidityHandler.UpdatePoolDenomMetadata(domain.PoolDenomMetaDataMap{
+		denom: poolDenomMetaData,
+	})
+
+	p.StoreHeightForDenom(denom, height)
+
+	p.storeLiquidityCapHistoryEntry(denom, height, poolDenomMetaData.TotalLiquidityCap)
+
+	return poolDenomMetaData, err
+}
+
+// computeDenomPoolLiquidityData sums the liquidity of the given denom across the balances of the given pools.
+// Returns false if none of the pools contain the denom.
+func computeDenomPoolLiquidityData(pools []sqsdomain.PoolI, denom string) (domain.DenomPoolLiquidityData, bool) {
+	denomLiquidityData := domain.DenomPoolLiquidityData{
+		TotalLiquidity: osmomath.ZeroInt(),
+		Pools:          map[uint64]osmomath.Int{},
+	}
+
+	for _, pool := range pools {
+		amount := pool.GetSQSPoolModel().Balances.AmountOf(denom)
+		if amount.IsZero() {
+			continue
+		}
+
+		denomLiquidityData.TotalLiquidity = denomLiquidityData.TotalLiquidity.Add(amount)
+		denomLiquidityData.Pools[pool.GetId()] = amount
+	}
+
+	return denomLiquidityData, len(denomLiquidityData.Pools) > 0
+}
+
 // shouldSkipDenomRepricing returns true if the denom repricing should be skipped.
-// Specifically, if the denom is a gamm share denom or
+// Specifically, if the denom contains one of the skipped denom prefixes (gamm shares by default) or
 // if the pool liquidity pricing worker already observed a later update
 // than the given updateHeight.
 func (p *poolLiquidityPricerWorker) shouldSkipDenomRepricing(denom string, updateHeight uint64) bool {
-	return strings.Contains(denom, gammSharePrefix) || p.hasLaterUpdateThanHeight(denom, updateHeight)
+	return p.hasSkippedDenomPrefix(denom) || p.hasLaterUpdateThanHeight(denom, updateHeight)
+}
+
+// hasSkippedDenomPrefix returns true if the denom contains any of the skipped denom prefixes.
+func (p *poolLiquidityPricerWorker) hasSkippedDenomPrefix(denom string) bool {
+	for _, prefix := range p.skippedDenomPrefixes {
+		if strings.Contains(denom, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 // GetHeightForDenom implements domain.PoolLiquidityPricerWorker.
@@ -183,6 +390,68 @@ func (p *poolLiquidityPricerWorker) GetHeightForDenom(denom string) uint64 {
 	return height
 }
 
+// GetLiquidityCapHistory implements domain.PoolLiquidityPricerWorker.
+func (p *poolLiquidityPricerWorker) GetLiquidityCapHistory(denom string, limit int) []domain.LiquidityCapHistoryEntry {
+	p.liquidityCapHistoryMx.RLock()
+	defer p.liquidityCapHistoryMx.RUnlock()
+
+	history := p.liquidityCapHistory[denom]
+	if limit > 0 && limit < len(history) {
+		history = history[len(history)-limit:]
+	}
+
+	result := make([]domain.LiquidityCapHistoryEntry, len(history))
+	copy(result, history)
+	return result
+}
+
+// storeLiquidityCapHistoryEntry appends the liquidity capitalization at the given height to the history of the denom,
+// evicting the oldest entries beyond the retention. If the latest entry is at the same height, it is overwritten.
+// No-op if the history retention is disabled.
+// Relies on the callers to skip the updates for heights earlier than the latest one for the denom.
+func (p *poolLiquidityPricerWorker) storeLiquidityCapHistoryEntry(denom string, height uint64, totalLiquidityCap osmomath.Int) {
+	if p.liquidityCapHistoryRetention <= 0 {
+		return
+	}
+
+	p.liquidityCapHistoryMx.Lock()
+	defer p.liquidityCapHistoryMx.Unlock()
+
+	entry := domain.LiquidityCapHistoryEntry{
+		Height:            height,
+		TotalLiquidityCap: totalLiquidityCap,
+	}
+
+	history := p.liquidityCapHistory[denom]
+	if len(history) > 0 && history[len(history)-1].Height == height {
+		history[len(history)-1] = entry
+		return
+	}
+
+	history = append(history, entry)
+	if len(history) > p.liquidityCapHistoryRetention {
+		history = history[len(history)-p.liquidityCapHistoryRetention:]
+	}
+
+	p.liquidityCapHistory[denom] = history
+}
+
+// GetLatestUpdateHeight implements domain.PoolLiquidityPricerWorker.
+func (p *poolLiquidityPricerWorker) GetLatestUpdateHeight() uint64 {
+	return p.latestUpdateHeight.Load()
+}
+
+// storeLatestUpdateHeight stores the given height as the latest update height
+// unless a later update height was already stored.
+func (p *poolLiquidityPricerWorker) storeLatestUpdateHeight(height uint64) {
+	for {
+		latestHeight := p.latestUpdateHeight.Load()
+		if height <= latestHeight || p.latestUpdateHeight.CompareAndSwap(latestHeight, height) {
+			return
+		}
+	}
+}
+
 // StoreHeightForDenom implements domain.PoolLiquidityPricerWorker.
 func (p *poolLiquidityPricerWorker) StoreHeightForDenom(denom string, height uint64) {
 	p.latestHeightForDenom.Store(denom, height)
@@ -212,24 +481,108 @@ func (p *poolLiquidityPricerWorker) repricePoolLiquidityCap(poolIDs map[uint64]s
 		return err
 	}
 
+	return p.repricePools(pools, blockPriceUpdates)
+}
+
+// RepriceAllPoolsLiquidityCap implements domain.PoolLiquidityPricerWorker.
+func (p *poolLiquidityPricerWorker) RepriceAllPoolsLiquidityCap(quoteDenom string, blockPriceUpdates domain.PricesResult) error {
+	p.liquidityPricer.SetQuoteDenom(quoteDenom)
+
+	// The heights were tracked for the denom metadata priced in the previous quote denom.
+	// Reset them so that the next update is not skipped.
+	p.latestHeightForDenom.Range(func(key, _ any) bool {
+		p.latestHeightForDenom.Delete(key)
+		return true
+	})
+
+	pools, err := p.poolHandler.GetPools()
+	if err != nil {
+		return err
+	}
+
+	return p.repricePools(pools, blockPriceUpdates)
+}
+
+// repricePools reprices the liquidity capitalization of the given pools using the block price updates
+// and stores them in the pool handler.
+// If the liquidity capitalization of a pool overflows, its capitalization is set to zero with the error recorded,
+// the remaining pools are still repriced and stored, and the first CapitalizationOverflowError is returned.
+func (p *poolLiquidityPricerWorker) repricePools(pools []sqsdomain.PoolI, blockPriceUpdates domain.PricesResult) error {
+	var overflowErr error
+
 	for i, pool := range pools {
 		balances := pool.GetSQSPoolModel().Balances
 
-		poolLiquidityCapitalization, poolLiquidityCapError := p.liquidityPricer.PriceBalances(balances, blockPriceUpdates)
+		poolLiquidityCapitalization, poolLiquidityCapError, err := p.liquidityPricer.PriceBalances(balances, blockPriceUpdates)
+		if err != nil {
+			poolLiquidityCapitalization = osmomath.ZeroInt()
+			poolLiquidityCapError = err.Error()
+
+			if overflowErr == nil {
+				overflowErr = fmt.Errorf("failed to reprice liquidity cap for pool (%d): %w", pool.GetId(), err)
+			}
+		}
 
 		// Update the liquidity capitalization and error (if any)
 		pools[i].SetLiquidityCap(poolLiquidityCapitalization)
 		pools[i].SetLiquidityCapError(poolLiquidityCapError)
 	}
 
+	// Note: storing the repriced pools also re-evaluates the canonical orderbook
+	// selection, promoting orderbooks whose liquidity capitalization exceeds the current canonical one.
 	if err := p.poolHandler.StorePools(pools); err != nil {
 		return err
 	}
 
-	return nil
+	return overflowErr
+}
+
+// RegisterPricingSource registers the pricing source used to retrieve the prices
+// for the on-demand denom repricing.
+func (p *poolLiquidityPricerWorker) RegisterPricingSource(pricingSource domain.PricingSource) {
+	p.pricingSource = pricingSource
+}
+
+// SetNotificationCoalesceWindow configures the listener notifications to be coalesced per height
+// within the given window so that the listeners receive at most one notification per height
+// with the merged block pool metadata. Zero disables the coalescing.
+func (p *poolLiquidityPricerWorker) SetNotificationCoalesceWindow(window time.Duration) {
+	p.notificationCoalesceWindow = window
+}
+
+// SetLiquidityCapHistoryRetention configures the maximum number of liquidity capitalization
+// history entries retained per denom. Zero disables the history.
+func (p *poolLiquidityPricerWorker) SetLiquidityCapHistoryRetention(retention int) {
+	p.liquidityCapHistoryRetention = retention
 }
 
 // RegisterListener implements PoolLiquidityPricerWorker.
 func (p *poolLiquidityPricerWorker) RegisterListener(listener domain.PoolLiquidityComputeListener) {
+	p.listenersMx.Lock()
+	defer p.listenersMx.Unlock()
+
 	p.updateListeners = append(p.updateListeners, listener)
 }
+
+// DeregisterListener implements PoolLiquidityPricerWorker.
+func (p *poolLiquidityPricerWorker) DeregisterListener(listener domain.PoolLiquidityComputeListener) {
+	p.listenersMx.Lock()
+	defer p.listenersMx.Unlock()
+
+	for i, existingListener := range p.updateListeners {
+		if existingListener == listener {
+			p.updateListeners = append(p.updateListeners[:i], p.updateListeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// getListenersSnapshot returns a copy of the currently registered update listeners.
+func (p *poolLiquidityPricerWorker) getListenersSnapshot() []domain.PoolLiquidityComputeListener {
+	p.listenersMx.RLock()
+	defer p.listenersMx.RUnlock()
+
+	listeners := make([]domain.PoolLiquidityComputeListener, len(p.updateListeners))
+	copy(listeners, p.updateListeners)
+	return listeners
+}
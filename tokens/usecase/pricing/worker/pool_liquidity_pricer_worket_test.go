@@ -3,14 +3,19 @@ package worker_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/osmosis-labs/osmosis/osmomath"
+	cosmwasmpoolmodel "github.com/osmosis-labs/osmosis/v26/x/cosmwasmpool/model"
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mocks"
 	"github.com/osmosis-labs/sqs/log"
+	poolsusecase "github.com/osmosis-labs/sqs/pools/usecase"
+	routerrepo "github.com/osmosis-labs/sqs/router/repository"
 	"github.com/osmosis-labs/sqs/router/usecase/routertesting"
 	"github.com/osmosis-labs/sqs/sqsdomain"
+	"github.com/osmosis-labs/sqs/sqsdomain/cosmwasmpool"
 	"github.com/osmosis-labs/sqs/tokens/usecase/pricing/worker"
 	"github.com/stretchr/testify/suite"
 )
@@ -132,7 +137,7 @@ func (s *PoolLiquidityComputeWorkerSuite) TestOnPricingUpdate() {
 	}
 
 	// Create the worker
-	poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(&poolLiquidityHandlerMock, &poolHandlerMock, liquidityPricer, &log.NoOpLogger{})
+	poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(&poolLiquidityHandlerMock, &poolHandlerMock, liquidityPricer, nil, &log.NoOpLogger{})
 
 	// Create & register mock listener
 	mockListener := &mocks.PoolLiquidityPricingMock{}
@@ -165,6 +170,9 @@ func (s *PoolLiquidityComputeWorkerSuite) TestOnPricingUpdate() {
 	lastHeightCalled := mockListener.GetLastHeightCalled()
 	s.Require().Equal(defaultHeight, lastHeightCalled)
 
+	// Validate that the latest update height is tracked.
+	s.Require().Equal(uint64(defaultHeight), poolLiquidityPricerWorker.GetLatestUpdateHeight())
+
 	// Validate that the pool liquidity handler mock was called with the relevant pool IDs.
 	s.validateLiquidityCapPools(map[uint64]liquidityResult{
 		defaultPoolID: {
@@ -173,6 +181,143 @@ func (s *PoolLiquidityComputeWorkerSuite) TestOnPricingUpdate() {
 	}, poolHandlerMock.Pools)
 }
 
+// Tests that a deregistered listener stops receiving the pool liquidity compute updates
+// while the remaining listeners continue to be notified.
+func (s *PoolLiquidityComputeWorkerSuite) TestDeregisterListener() {
+	// Create liquidity pricer
+	liquidityPricer := worker.NewLiquidityPricer(USDC, mocks.SetupMockScalingFactorCbFromMap(defaultScalingFactorMap))
+
+	// Set up the tokens pool liquidity mock handler
+	poolLiquidityHandlerMock := mocks.TokensPoolLiquidityHandlerMock{
+		DenomScalingFactorMap: defaultScalingFactorMap,
+	}
+
+	poolHandlerMock := mocks.PoolHandlerMock{
+		Pools: []sqsdomain.PoolI{&mocks.MockRoutablePool{ID: defaultPoolID, Balances: sdk.NewCoins(defaultUOSMOBalance)}},
+	}
+
+	// Create the worker
+	poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(&poolLiquidityHandlerMock, &poolHandlerMock, liquidityPricer, nil, &log.NoOpLogger{})
+
+	// Create & register mock listeners
+	deregisteredListener := &mocks.PoolLiquidityPricingMock{}
+	remainingListener := &mocks.PoolLiquidityPricingMock{}
+	poolLiquidityPricerWorker.RegisterListener(deregisteredListener)
+	poolLiquidityPricerWorker.RegisterListener(remainingListener)
+
+	// Fire the first update
+	err := poolLiquidityPricerWorker.OnPricingUpdate(context.TODO(), defaultHeight, defaultBlockPoolMetaData, defaultBlockPriceUpdates, USDC)
+	s.Require().NoError(err)
+
+	// Validate that both listeners were called.
+	s.Require().Equal(defaultHeight, deregisteredListener.GetLastHeightCalled())
+	s.Require().Equal(defaultHeight, remainingListener.GetLastHeightCalled())
+
+	// System under test
+	poolLiquidityPricerWorker.DeregisterListener(deregisteredListener)
+
+	// Fire the second update
+	err = poolLiquidityPricerWorker.OnPricingUpdate(context.TODO(), defaultHeight+1, defaultBlockPoolMetaData, defaultBlockPriceUpdates, USDC)
+	s.Require().NoError(err)
+
+	// Validate that only the remaining listener was called.
+	s.Require().Equal(defaultHeight, deregisteredListener.GetLastHeightCalled())
+	s.Require().Equal(defaultHeight+1, remainingListener.GetLastHeightCalled())
+
+	// Deregistering a listener that is not registered is a no-op.
+	poolLiquidityPricerWorker.DeregisterListener(deregisteredListener)
+
+	err = poolLiquidityPricerWorker.OnPricingUpdate(context.TODO(), defaultHeight+2, defaultBlockPoolMetaData, defaultBlockPriceUpdates, USDC)
+	s.Require().NoError(err)
+
+	s.Require().Equal(defaultHeight+2, remainingListener.GetLastHeightCalled())
+}
+
+// Tests that with the notification coalescing enabled, two updates for the same height
+// result in a single listener notification with the merged denoms and pool IDs.
+func (s *PoolLiquidityComputeWorkerSuite) TestOnPricingUpdate_CoalescedNotifications() {
+	const (
+		coalesceWindow = 50 * time.Millisecond
+
+		secondPoolID = defaultPoolID + 1
+	)
+
+	// Create liquidity pricer
+	liquidityPricer := worker.NewLiquidityPricer(USDC, mocks.SetupMockScalingFactorCbFromMap(defaultScalingFactorMap))
+
+	// Set up the tokens pool liquidity mock handler
+	poolLiquidityHandlerMock := mocks.TokensPoolLiquidityHandlerMock{
+		DenomScalingFactorMap: defaultScalingFactorMap,
+	}
+
+	poolHandlerMock := mocks.PoolHandlerMock{
+		Pools: []sqsdomain.PoolI{
+			&mocks.MockRoutablePool{ID: defaultPoolID, Balances: sdk.NewCoins(defaultUOSMOBalance)},
+			&mocks.MockRoutablePool{ID: secondPoolID, Balances: sdk.NewCoins(defaultATOMBalance)},
+		},
+	}
+
+	// Create the worker
+	poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(&poolLiquidityHandlerMock, &poolHandlerMock, liquidityPricer, nil, &log.NoOpLogger{})
+	poolLiquidityPricerWorker.SetNotificationCoalesceWindow(coalesceWindow)
+
+	// Create & register mock listener
+	mockListener := &mocks.PoolLiquidityPricingMock{}
+	poolLiquidityPricerWorker.RegisterListener(mockListener)
+
+	// Fire two updates for the same height with different denoms and pools.
+	err := poolLiquidityPricerWorker.OnPricingUpdate(context.TODO(), defaultHeight, defaultBlockPoolMetaData, defaultBlockPriceUpdates, USDC)
+	s.Require().NoError(err)
+
+	err = poolLiquidityPricerWorker.OnPricingUpdate(context.TODO(), defaultHeight, domain.BlockPoolMetadata{
+		UpdatedDenoms: map[string]struct{}{
+			ATOM: {},
+		},
+		DenomPoolLiquidityMap: domain.DenomPoolLiquidityMap{
+			ATOM: {
+				TotalLiquidity: defaultLiquidity,
+			},
+		},
+		PoolIDs: map[uint64]struct{}{
+			secondPoolID: {},
+		},
+	}, defaultBlockPriceUpdates, USDC)
+	s.Require().NoError(err)
+
+	// Validate that the listener is not notified before the coalesce window elapses.
+	s.Require().Zero(mockListener.GetCallCount())
+
+	// Wait for the coalesced notification.
+	s.Require().Eventually(func() bool {
+		return mockListener.GetCallCount() > 0
+	}, 10*coalesceWindow, coalesceWindow/5)
+
+	// Validate that no other notification follows.
+	time.Sleep(2 * coalesceWindow)
+	s.Require().Equal(1, mockListener.GetCallCount())
+
+	// Validate the merged block pool metadata.
+	s.Require().Equal(defaultHeight, mockListener.GetLastHeightCalled())
+	s.Require().Equal(domain.BlockPoolMetadata{
+		UpdatedDenoms: map[string]struct{}{
+			UOSMO: {},
+			ATOM:  {},
+		},
+		DenomPoolLiquidityMap: domain.DenomPoolLiquidityMap{
+			UOSMO: {
+				TotalLiquidity: defaultLiquidity,
+			},
+			ATOM: {
+				TotalLiquidity: defaultLiquidity,
+			},
+		},
+		PoolIDs: map[uint64]struct{}{
+			defaultPoolID: {},
+			secondPoolID:  {},
+		},
+	}, mockListener.GetLastBlockPoolMetadata())
+}
+
 // TestHasLaterUpdateThanHeight tests the HasLaterUpdateThanHeight method by following the spec.
 func (s *PoolLiquidityComputeWorkerSuite) TestHasLaterUpdateThanHeight() {
 	const defaultHeight = 1
@@ -522,7 +667,7 @@ func (s *PoolLiquidityComputeWorkerSuite) TestRepriceDenomsMetadata() {
 			}
 
 			// Create the worker
-			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(&poolLiquidityHandlerMock, nil, liquidityPricer, &log.NoOpLogger{})
+			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(&poolLiquidityHandlerMock, nil, liquidityPricer, nil, &log.NoOpLogger{})
 
 			// Pre-set the height for each denom.
 			for denom, height := range tt.preSetUpdateHeightForDenom {
@@ -618,7 +763,7 @@ func (s *PoolLiquidityComputeWorkerSuite) TestCreatePoolDenomMetaData() {
 			}
 
 			// Create the worker
-			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(&poolLiquidityHandlerMock, nil, liquidityPricer, &log.NoOpLogger{})
+			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(&poolLiquidityHandlerMock, nil, liquidityPricer, nil, &log.NoOpLogger{})
 
 			// Pre-set the height for the denom.
 			poolLiquidityPricerWorker.StoreHeightForDenom(tt.updatedBlockDenom, tt.preSetUpdateHeight)
@@ -639,11 +784,238 @@ func (s *PoolLiquidityComputeWorkerSuite) TestCreatePoolDenomMetaData() {
 	}
 }
 
+// Tests that a single denom is repriced on demand using the price from the registered pricing source
+// and the liquidity summed across the pool balances, respecting the later update height guard.
+func (s *PoolLiquidityComputeWorkerSuite) TestRepriceSingleDenom() {
+	tests := []struct {
+		name string
+
+		denom              string
+		preSetUpdateHeight uint64
+		updateHeight       uint64
+		price              osmomath.BigDec
+
+		expectedPoolDenomMetadData domain.PoolDenomMetaData
+		expectedHeight             uint64
+		expectedErr                error
+	}{
+		{
+			name: "happy path",
+
+			denom:        UOSMO,
+			updateHeight: defaultHeight,
+			price:        defaultPrice,
+
+			expectedPoolDenomMetadData: domain.PoolDenomMetaData{
+				Price:             defaultPrice,
+				TotalLiquidity:    defaultLiquidity.MulRaw(2),
+				TotalLiquidityCap: defaultLiquidityCap.MulRaw(2),
+			},
+			expectedHeight: defaultHeight,
+		},
+		{
+			name: "later update height -> skipped",
+
+			denom:              UOSMO,
+			preSetUpdateHeight: defaultHeight + 1,
+			updateHeight:       defaultHeight,
+			price:              defaultPrice,
+
+			expectedHeight: defaultHeight + 1,
+			expectedErr: domain.DenomRepricingSkippedError{
+				Denom:  UOSMO,
+				Height: defaultHeight,
+			},
+		},
+		{
+			name: "error: no price for denom",
+
+			denom:        UOSMO,
+			updateHeight: defaultHeight,
+			price:        zeroPrice,
+
+			expectedPoolDenomMetadData: domain.PoolDenomMetaData{
+				Price:             zeroPrice,
+				TotalLiquidity:    defaultLiquidity.MulRaw(2),
+				TotalLiquidityCap: zeroCapitalization,
+			},
+			expectedHeight: defaultHeight,
+			expectedErr: domain.PriceNotFoundForPoolLiquidityCapError{
+				Denom: UOSMO,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+
+			// Create liquidity pricer
+			liquidityPricer := worker.NewLiquidityPricer(USDC, mocks.SetupMockScalingFactorCbFromMap(defaultScalingFactorMap))
+
+			// Set up the tokens pool liquidity mock handler
+			poolLiquidityHandlerMock := mocks.TokensPoolLiquidityHandlerMock{
+				DenomScalingFactorMap: defaultScalingFactorMap,
+			}
+
+			// Create pool handler mock with the denom present in two pools.
+			// Note: the mock filters out pools with zero liquidity capitalization.
+			poolHandlerMock := &mocks.PoolHandlerMock{
+				Pools: []sqsdomain.PoolI{
+					&mocks.MockRoutablePool{ID: defaultPoolID, Balances: sdk.NewCoins(defaultUOSMOBalance), PoolLiquidityCap: osmomath.OneInt()},
+					&mocks.MockRoutablePool{ID: defaultPoolID + 1, Balances: sdk.NewCoins(defaultUOSMOBalance, defaultATOMBalance), PoolLiquidityCap: osmomath.OneInt()},
+				},
+			}
+
+			// Create the worker
+			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(&poolLiquidityHandlerMock, poolHandlerMock, liquidityPricer, nil, &log.NoOpLogger{})
+			poolLiquidityPricerWorker.RegisterPricingSource(&mocks.PricingSourceMock{
+				GetPriceFunc: func(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+					return tt.price, nil
+				},
+			})
+
+			// Pre-set the height for the denom.
+			poolLiquidityPricerWorker.StoreHeightForDenom(tt.denom, tt.preSetUpdateHeight)
+
+			// System under test
+			poolDenomMetadata, err := poolLiquidityPricerWorker.RepriceSingleDenom(context.TODO(), tt.denom, tt.updateHeight, USDC)
+
+			s.Require().Equal(tt.expectedHeight, poolLiquidityPricerWorker.GetHeightForDenom(tt.denom))
+
+			if tt.expectedErr != nil {
+				s.Require().ErrorIs(err, tt.expectedErr)
+			} else {
+				s.Require().NoError(err)
+			}
+
+			// Skipped repricing does not update the handler.
+			if _, ok := tt.expectedErr.(domain.DenomRepricingSkippedError); ok {
+				s.Require().Nil(poolLiquidityHandlerMock.PoolDenomMetadataMap)
+				return
+			}
+
+			s.Require().Equal(tt.expectedPoolDenomMetadData, poolDenomMetadata)
+			s.Require().Equal(domain.PoolDenomMetaDataMap{tt.denom: tt.expectedPoolDenomMetadData}, poolLiquidityHandlerMock.PoolDenomMetadataMap)
+		})
+	}
+}
+
+// Tests that the liquidity capitalization history is retained per denom ordered by height ascending,
+// evicting the oldest entries beyond the retention and respecting the limit.
+func (s *PoolLiquidityComputeWorkerSuite) TestGetLiquidityCapHistory() {
+	const retention = 3
+
+	var (
+		heights = []uint64{defaultHeight, defaultHeight + 1, defaultHeight + 2, defaultHeight + 3}
+
+		// Each height doubles the price of the previous one.
+		expectedEntry = func(height uint64) domain.LiquidityCapHistoryEntry {
+			return domain.LiquidityCapHistoryEntry{
+				Height:            height,
+				TotalLiquidityCap: defaultLiquidityCap.MulRaw(1 << (height - defaultHeight)),
+			}
+		}
+	)
+
+	tests := []struct {
+		name string
+
+		denom string
+		limit int
+
+		expectedHistory []domain.LiquidityCapHistoryEntry
+	}{
+		{
+			name: "no limit -> all retained entries with the oldest evicted",
+
+			denom: UOSMO,
+
+			expectedHistory: []domain.LiquidityCapHistoryEntry{
+				expectedEntry(heights[1]),
+				expectedEntry(heights[2]),
+				expectedEntry(heights[3]),
+			},
+		},
+		{
+			name: "limit -> latest entries",
+
+			denom: UOSMO,
+			limit: 2,
+
+			expectedHistory: []domain.LiquidityCapHistoryEntry{
+				expectedEntry(heights[2]),
+				expectedEntry(heights[3]),
+			},
+		},
+		{
+			name: "limit greater than retained entries -> all retained entries",
+
+			denom: UOSMO,
+			limit: retention + 1,
+
+			expectedHistory: []domain.LiquidityCapHistoryEntry{
+				expectedEntry(heights[1]),
+				expectedEntry(heights[2]),
+				expectedEntry(heights[3]),
+			},
+		},
+		{
+			name: "denom without history -> empty",
+
+			denom: ATOM,
+
+			expectedHistory: []domain.LiquidityCapHistoryEntry{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+
+			// Create liquidity pricer
+			liquidityPricer := worker.NewLiquidityPricer(USDC, mocks.SetupMockScalingFactorCbFromMap(defaultScalingFactorMap))
+
+			// Create the worker
+			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(nil, nil, liquidityPricer, nil, &log.NoOpLogger{})
+			poolLiquidityPricerWorker.SetLiquidityCapHistoryRetention(retention)
+
+			// Reprice the denom at each height, storing the same height twice
+			// to validate that the entry is overwritten rather than appended.
+			price := defaultPrice
+			for _, height := range heights {
+				blockPriceUpdates := domain.PricesResult{
+					UOSMO: {
+						USDC: price,
+					},
+				}
+
+				poolLiquidityPricerWorker.RepriceDenomsMetadata(height, blockPriceUpdates, USDC, defaultBlockPoolMetaData)
+				poolLiquidityPricerWorker.RepriceDenomsMetadata(height, blockPriceUpdates, USDC, defaultBlockPoolMetaData)
+
+				price = price.MulInt64(2)
+			}
+
+			// System under test
+			history := poolLiquidityPricerWorker.GetLiquidityCapHistory(tt.denom, tt.limit)
+
+			s.Require().Equal(tt.expectedHistory, history)
+		})
+	}
+}
+
 // Tests the helper for determining if denom repricing should be skipped.
 func (s *PoolLiquidityComputeWorkerSuite) TestShouldSkipDenomRepricing() {
+	const (
+		clSharePrefix        = "cl/pool"
+		syntheticDenomPrefix = "superbonding"
+	)
+
 	tests := []struct {
 		name string
 
+		skippedDenomPrefixes []string
+
 		updatedBlockDenom  string
 		preSetUpdateHeight uint64
 		updateHeight       uint64
@@ -678,14 +1050,62 @@ func (s *PoolLiquidityComputeWorkerSuite) TestShouldSkipDenomRepricing() {
 
 			expected: true,
 		},
+		{
+			name: "skip: denom is gamm share by default",
+
+			updatedBlockDenom: worker.GammSharePrefix + "/1",
+			updateHeight:      defaultHeight,
+
+			expected: true,
+		},
+		{
+			name: "skip: denom matches first of multiple configured prefixes",
+
+			skippedDenomPrefixes: []string{clSharePrefix, syntheticDenomPrefix},
+
+			updatedBlockDenom: clSharePrefix + "/1",
+			updateHeight:      defaultHeight,
+
+			expected: true,
+		},
+		{
+			name: "skip: denom matches second of multiple configured prefixes",
+
+			skippedDenomPrefixes: []string{clSharePrefix, syntheticDenomPrefix},
+
+			updatedBlockDenom: UOSMO + syntheticDenomPrefix,
+			updateHeight:      defaultHeight,
+
+			expected: true,
+		},
+		{
+			name: "do not skip: gamm share when not in configured prefixes",
+
+			skippedDenomPrefixes: []string{clSharePrefix, syntheticDenomPrefix},
+
+			updatedBlockDenom: worker.GammSharePrefix + "/1",
+			updateHeight:      defaultHeight,
+
+			expected: false,
+		},
+		{
+			name: "do not skip: denom does not match configured prefixes",
+
+			skippedDenomPrefixes: []string{clSharePrefix, syntheticDenomPrefix},
+
+			updatedBlockDenom: UOSMO,
+			updateHeight:      defaultHeight,
+
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
 		tt := tt
 		s.T().Run(tt.name, func(t *testing.T) {
 			// Create the worker
-			// Note: all inputs are irrelevant for this test.
-			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(nil, nil, nil, &log.NoOpLogger{})
+			// Note: all inputs other than the skipped denom prefixes are irrelevant for this test.
+			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(nil, nil, nil, tt.skippedDenomPrefixes, &log.NoOpLogger{})
 
 			// Pre-set the height for the denom.
 			poolLiquidityPricerWorker.StoreHeightForDenom(tt.updatedBlockDenom, tt.preSetUpdateHeight)
@@ -853,7 +1273,7 @@ func (s *PoolLiquidityComputeWorkerSuite) TestRepricePoolLiquidityCap() {
 			}
 
 			// Create the worker
-			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(nil, poolHandlerMock, liquidityPricer, &log.NoOpLogger{})
+			poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(nil, poolHandlerMock, liquidityPricer, nil, &log.NoOpLogger{})
 
 			// System under test
 			err := poolLiquidityPricerWorker.RepricePoolLiquidityCap(tt.poolIDs, tt.blockPriceUpdates)
@@ -882,6 +1302,254 @@ func (s *PoolLiquidityComputeWorkerSuite) TestRepricePoolLiquidityCap() {
 	}
 }
 
+// This test validates that the pools whose liquidity capitalization could not be computed
+// during repricing are surfaced by the pools use case together with the reason.
+func (s *PoolLiquidityComputeWorkerSuite) TestRepricePoolLiquidityCap_GetPoolsWithLiquidityErrors() {
+	const (
+		missingPriceDenom = "missing-price"
+
+		missingPricePoolID = defaultPoolID + 1
+	)
+
+	// Create liquidity pricer
+	liquidityPricer := worker.NewLiquidityPricer(USDC, mocks.SetupMockScalingFactorCbFromMap(defaultScalingFactorMap))
+
+	// Create pools use case
+	poolsUsecase, err := poolsusecase.NewPoolsUsecase(&domain.PoolsConfig{}, "node-uri-placeholder", routerrepo.New(&log.NoOpLogger{}), domain.UnsetScalingFactorGetterCb, &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	err = poolsUsecase.StorePools([]sqsdomain.PoolI{
+		&mocks.MockRoutablePool{ID: defaultPoolID, Balances: sdk.NewCoins(defaultUOSMOBalance)},
+		&mocks.MockRoutablePool{ID: missingPricePoolID, Balances: sdk.NewCoins(defaultUOSMOBalance, sdk.NewCoin(missingPriceDenom, defaultLiquidity))},
+	})
+	s.Require().NoError(err)
+
+	// Create the worker
+	poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(nil, poolsUsecase, liquidityPricer, nil, &log.NoOpLogger{})
+
+	// Reprice with the price missing for one of the denoms.
+	err = poolLiquidityPricerWorker.RepricePoolLiquidityCap(map[uint64]struct{}{
+		defaultPoolID:      {},
+		missingPricePoolID: {},
+	}, defaultBlockPriceUpdates)
+	s.Require().NoError(err)
+
+	// System under test
+	actualPoolErrors, err := poolsUsecase.GetPoolsWithLiquidityErrors()
+	s.Require().NoError(err)
+
+	s.Require().Equal([]domain.PoolLiquidityCapErrorResult{
+		{
+			PoolID:            missingPricePoolID,
+			LiquidityCapError: worker.FormatLiquidityCapErrorStr(missingPriceDenom),
+		},
+	}, actualPoolErrors)
+}
+
+// This test validates that a pool whose liquidity capitalization overflows is stored with
+// zero capitalization and the overflow error while the remaining pools are still repriced.
+// The overflow is surfaced to the caller as CapitalizationOverflowError.
+func (s *PoolLiquidityComputeWorkerSuite) TestRepricePoolLiquidityCap_Overflow() {
+	const overflowPoolID = defaultPoolID + 1
+
+	// Create liquidity pricer
+	liquidityPricer := worker.NewLiquidityPricer(USDC, mocks.SetupMockScalingFactorCbFromMap(map[string]osmomath.Dec{
+		UOSMO: defaultScalingFactor,
+		ATOM:  oneScalingFactor,
+	}))
+
+	// Create pool handler mock
+	poolHandlerMock := &mocks.PoolHandlerMock{
+		Pools: []sqsdomain.PoolI{
+			&mocks.MockRoutablePool{ID: defaultPoolID, Balances: sdk.NewCoins(defaultUOSMOBalance)},
+			// maxInt * defaultPrice with one scaling factor overflows osmomath.Int
+			&mocks.MockRoutablePool{ID: overflowPoolID, Balances: sdk.NewCoins(sdk.NewCoin(ATOM, maxInt))},
+		},
+	}
+
+	// Create the worker
+	poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(nil, poolHandlerMock, liquidityPricer, nil, &log.NoOpLogger{})
+
+	// System under test
+	err := poolLiquidityPricerWorker.RepricePoolLiquidityCap(map[uint64]struct{}{
+		defaultPoolID:  {},
+		overflowPoolID: {},
+	}, defaultBlockPriceUpdates)
+	s.Require().ErrorIs(err, domain.CapitalizationOverflowError{Denom: ATOM})
+
+	actualPools, err := poolHandlerMock.GetPools(domain.WithPoolIDFilter([]uint64{defaultPoolID, overflowPoolID}))
+	s.Require().NoError(err)
+
+	s.validateLiquidityCapPools(map[uint64]liquidityResult{
+		defaultPoolID: {
+			LiquidityCap: defaultLiquidityCap,
+		},
+		overflowPoolID: {
+			LiquidityCap:      zeroCapitalization,
+			LiquidityCapError: domain.CapitalizationOverflowError{Denom: ATOM}.Error(),
+		},
+	}, actualPools)
+}
+
+// This test validates that repricing the liquidity capitalization of orderbook pools
+// re-evaluates the canonical orderbook selection in the pool handler.
+// A book whose repriced cap exceeds the canonical one is promoted while
+// a book with a lower cap never demotes the canonical book.
+func (s *PoolLiquidityComputeWorkerSuite) TestRepricePoolLiquidityCap_CanonicalOrderbook() {
+	const (
+		canonicalPoolID = defaultPoolID
+		promotedPoolID  = defaultPoolID + 1
+
+		canonicalContractAddress = "canonical-address"
+		promotedContractAddress  = "promoted-address"
+	)
+
+	newOrderbookPool := func(poolID uint64, contractAddress string, balances sdk.Coins, liquidityCap osmomath.Int) *mocks.MockRoutablePool {
+		return &mocks.MockRoutablePool{
+			ID: poolID,
+			ChainPoolModel: &cosmwasmpoolmodel.CosmWasmPool{
+				PoolId:          poolID,
+				ContractAddress: contractAddress,
+			},
+			CosmWasmPoolModel: &cosmwasmpool.CosmWasmPoolModel{
+				ContractInfo: cosmwasmpool.ContractInfo{
+					Contract: cosmwasmpool.ORDERBOOK_CONTRACT_NAME,
+					Version:  cosmwasmpool.ORDERBOOK_MIN_CONTRACT_VERSION,
+				},
+				Data: cosmwasmpool.CosmWasmPoolData{
+					Orderbook: &cosmwasmpool.OrderbookData{
+						BaseDenom:  UOSMO,
+						QuoteDenom: USDC,
+					},
+				},
+			},
+			Balances:         balances,
+			PoolLiquidityCap: liquidityCap,
+		}
+	}
+
+	// Create liquidity pricer
+	liquidityPricer := worker.NewLiquidityPricer(USDC, mocks.SetupMockScalingFactorCbFromMap(defaultScalingFactorMap))
+
+	// Create pools use case
+	poolsUsecase, err := poolsusecase.NewPoolsUsecase(&domain.PoolsConfig{}, "node-uri-placeholder", routerrepo.New(&log.NoOpLogger{}), domain.UnsetScalingFactorGetterCb, &log.NoOpLogger{})
+	s.Require().NoError(err)
+
+	// The promoted pool has ten times the balance of the canonical pool but
+	// starts with a lower liquidity cap since it has not been priced yet.
+	promotedPoolBalance := sdk.NewCoin(UOSMO, defaultLiquidity.MulRaw(10))
+	promotedPoolLiquidityCap := defaultLiquidityCap.MulRaw(10)
+
+	err = poolsUsecase.StorePools([]sqsdomain.PoolI{
+		newOrderbookPool(canonicalPoolID, canonicalContractAddress, sdk.NewCoins(defaultUOSMOBalance), defaultLiquidityCap),
+		newOrderbookPool(promotedPoolID, promotedContractAddress, sdk.NewCoins(promotedPoolBalance), osmomath.ZeroInt()),
+	})
+	s.Require().NoError(err)
+
+	validateCanonicalOrderbook := func(expectedPoolID uint64, expectedContractAddress string) {
+		actualPoolID, actualContractAddress, err := poolsUsecase.GetCanonicalOrderbookPool(UOSMO, USDC)
+		s.Require().NoError(err)
+		s.Require().Equal(expectedPoolID, actualPoolID)
+		s.Require().Equal(expectedContractAddress, actualContractAddress)
+	}
+
+	// Sanity check the initial canonical orderbook
+	validateCanonicalOrderbook(canonicalPoolID, canonicalContractAddress)
+
+	// Create the worker
+	poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(nil, poolsUsecase, liquidityPricer, nil, &log.NoOpLogger{})
+
+	// System under test
+	err = poolLiquidityPricerWorker.RepricePoolLiquidityCap(map[uint64]struct{}{
+		promotedPoolID: {},
+	}, defaultBlockPriceUpdates)
+	s.Require().NoError(err)
+
+	// Validate that the repriced pool is promoted to the canonical orderbook
+	validateCanonicalOrderbook(promotedPoolID, promotedContractAddress)
+
+	promotedPool, err := poolsUsecase.GetPool(promotedPoolID)
+	s.Require().NoError(err)
+	s.Require().Equal(promotedPoolLiquidityCap.String(), promotedPool.GetLiquidityCap().String())
+
+	// Repricing both pools again is idempotent and the pool with the lower cap
+	// does not demote the canonical orderbook.
+	for i := 0; i < 2; i++ {
+		err = poolLiquidityPricerWorker.RepricePoolLiquidityCap(map[uint64]struct{}{
+			canonicalPoolID: {},
+			promotedPoolID:  {},
+		}, defaultBlockPriceUpdates)
+		s.Require().NoError(err)
+
+		validateCanonicalOrderbook(promotedPoolID, promotedContractAddress)
+	}
+
+	// Validate that only the promoted pool is marked as canonical
+	canonicalOrderbooks, err := poolsUsecase.GetAllCanonicalOrderbookPoolIDs()
+	s.Require().NoError(err)
+	s.Require().Len(canonicalOrderbooks, 1)
+	s.Require().Equal(promotedPoolID, canonicalOrderbooks[0].PoolID)
+	s.Require().False(poolsUsecase.IsCanonicalOrderbookPool(canonicalPoolID))
+	s.Require().True(poolsUsecase.IsCanonicalOrderbookPool(promotedPoolID))
+}
+
+// This test validates that switching the quote denom at runtime reprices all pools
+// against the new quote denom and resets the tracked update heights.
+func (s *PoolLiquidityComputeWorkerSuite) TestRepriceAllPoolsLiquidityCap() {
+	// UOSMO is twice as expensive in ATOM than in USDC.
+	priceMultiplier := osmomath.NewBigDec(2)
+	blockPriceUpdates := domain.PricesResult{
+		UOSMO: {
+			USDC: defaultPrice,
+			ATOM: defaultPrice.Mul(priceMultiplier),
+		},
+	}
+
+	// Create liquidity pricer
+	liquidityPricer := worker.NewLiquidityPricer(USDC, mocks.SetupMockScalingFactorCbFromMap(defaultScalingFactorMap))
+
+	// Create pool handler mock
+	poolHandlerMock := &mocks.PoolHandlerMock{
+		Pools: []sqsdomain.PoolI{
+			&mocks.MockRoutablePool{ID: defaultPoolID, Balances: sdk.NewCoins(defaultUOSMOBalance)},
+			&mocks.MockRoutablePool{ID: defaultPoolID + 1, Balances: sdk.NewCoins(defaultUOSMOBalance.Add(defaultUOSMOBalance))},
+		},
+	}
+
+	// Create the worker
+	poolLiquidityPricerWorker := worker.NewPoolLiquidityWorker(nil, poolHandlerMock, liquidityPricer, nil, &log.NoOpLogger{})
+
+	// Price all pools against the default quote denom
+	err := poolLiquidityPricerWorker.RepricePoolLiquidityCap(map[uint64]struct{}{
+		defaultPoolID:     {},
+		defaultPoolID + 1: {},
+	}, blockPriceUpdates)
+	s.Require().NoError(err)
+
+	s.validateLiquidityCapPools(map[uint64]liquidityResult{
+		defaultPoolID:     {LiquidityCap: defaultLiquidityCap},
+		defaultPoolID + 1: {LiquidityCap: defaultLiquidityCap.Add(defaultLiquidityCap)},
+	}, poolHandlerMock.Pools)
+
+	// Track a height for the denom priced in the default quote denom
+	poolLiquidityPricerWorker.StoreHeightForDenom(UOSMO, defaultUpdateHeight)
+
+	// System under test
+	err = poolLiquidityPricerWorker.RepriceAllPoolsLiquidityCap(ATOM, blockPriceUpdates)
+	s.Require().NoError(err)
+
+	// Validate that the caps changed proportionally to the new price
+	expectedLiquidityCap := defaultLiquidityCap.Mul(priceMultiplier.Dec().TruncateInt())
+	s.validateLiquidityCapPools(map[uint64]liquidityResult{
+		defaultPoolID:     {LiquidityCap: expectedLiquidityCap},
+		defaultPoolID + 1: {LiquidityCap: expectedLiquidityCap.Add(expectedLiquidityCap)},
+	}, poolHandlerMock.Pools)
+
+	// Validate that the quote denom is switched and the heights are reset
+	s.Require().Equal(ATOM, liquidityPricer.GetQuoteDenom())
+	s.Require().Zero(poolLiquidityPricerWorker.GetHeightForDenom(UOSMO))
+}
+
 // validatePoolDenomMetadata validates the pool denom metadata map.
 func (s *PoolLiquidityComputeWorkerSuite) validatePoolDenomMetadata(expected domain.PoolDenomMetaDataMap, actual domain.PoolDenomMetaDataMap) {
 	s.Require().Equal(len(expected), len(actual))
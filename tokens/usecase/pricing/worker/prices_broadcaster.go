@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/osmosis-labs/sqs/domain"
+)
+
+type pricesBroadcaster struct {
+	subscribers  map[uint64]*pricesSubscriber
+	nextID       uint64
+	subscriberMu sync.Mutex
+
+	bufferSize int
+}
+
+// pricesSubscriber is a subscriber to the pricing updates.
+type pricesSubscriber struct {
+	// baseDenoms is the set of base denoms the subscriber is interested in.
+	// Empty if all denoms are of interest.
+	baseDenoms map[string]struct{}
+	updates    chan domain.PricesResult
+}
+
+var _ domain.PricesBroadcaster = &pricesBroadcaster{}
+
+// NewPricesBroadcaster creates a new prices broadcaster where each subscriber
+// buffers up to bufferSize pending updates before the oldest ones are dropped.
+func NewPricesBroadcaster(bufferSize int) domain.PricesBroadcaster {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+
+	return &pricesBroadcaster{
+		subscribers: map[uint64]*pricesSubscriber{},
+		bufferSize:  bufferSize,
+	}
+}
+
+// OnPricingUpdate implements domain.PricingUpdateListener.
+func (p *pricesBroadcaster) OnPricingUpdate(ctx context.Context, height uint64, blockMetaData domain.BlockPoolMetadata, pricesBaseQuoteDenomMap domain.PricesResult, quoteDenom string) error {
+	p.subscriberMu.Lock()
+	defer p.subscriberMu.Unlock()
+
+	for _, subscriber := range p.subscribers {
+		update := subscriber.filter(pricesBaseQuoteDenomMap)
+		if len(update) == 0 {
+			continue
+		}
+
+		subscriber.send(update)
+	}
+
+	return nil
+}
+
+// Subscribe implements domain.PricesBroadcaster.
+func (p *pricesBroadcaster) Subscribe(baseDenoms []string) (<-chan domain.PricesResult, func()) {
+	subscriber := &pricesSubscriber{
+		baseDenoms: make(map[string]struct{}, len(baseDenoms)),
+		updates:    make(chan domain.PricesResult, p.bufferSize),
+	}
+	for _, baseDenom := range baseDenoms {
+		subscriber.baseDenoms[baseDenom] = struct{}{}
+	}
+
+	p.subscriberMu.Lock()
+	id := p.nextID
+	p.nextID++
+	p.subscribers[id] = subscriber
+	p.subscriberMu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			p.subscriberMu.Lock()
+			defer p.subscriberMu.Unlock()
+
+			delete(p.subscribers, id)
+			close(subscriber.updates)
+		})
+	}
+
+	return subscriber.updates, unsubscribe
+}
+
+// filter returns the prices for the base denoms the subscriber is interested in.
+func (s *pricesSubscriber) filter(prices domain.PricesResult) domain.PricesResult {
+	if len(s.baseDenoms) == 0 {
+		return prices
+	}
+
+	result := make(domain.PricesResult, len(s.baseDenoms))
+	for baseDenom := range s.baseDenoms {
+		if quotePrices, ok := prices[baseDenom]; ok {
+			result[baseDenom] = quotePrices
+		}
+	}
+	return result
+}
+
+// send sends the update to the subscriber without blocking.
+// If the subscriber buffer is full, the oldest pending update is dropped.
+// CONTRACT: the caller holds the broadcaster subscriber lock.
+func (s *pricesSubscriber) send(update domain.PricesResult) {
+	for {
+		select {
+		case s.updates <- update:
+			return
+		default:
+			// Drop the oldest update to make room.
+			select {
+			case <-s.updates:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/tokens/usecase/pricing/worker"
+)
+
+const (
+	broadcasterUOSMO = "uosmo"
+	broadcasterATOM  = "uatom"
+	broadcasterUSDC  = "uusdc"
+)
+
+var (
+	osmoPrices = map[string]osmomath.BigDec{broadcasterUSDC: osmomath.NewBigDec(1)}
+	atomPrices = map[string]osmomath.BigDec{broadcasterUSDC: osmomath.NewBigDec(5)}
+)
+
+// Tests that the subscribers receive the price updates filtered by the subscribed base denoms.
+func TestPricesBroadcaster_OnPricingUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseDenoms []string
+		update     domain.PricesResult
+
+		expectedUpdate domain.PricesResult
+	}{
+		{
+			name:       "all denoms",
+			baseDenoms: nil,
+			update: domain.PricesResult{
+				broadcasterUOSMO: osmoPrices,
+				broadcasterATOM:  atomPrices,
+			},
+
+			expectedUpdate: domain.PricesResult{
+				broadcasterUOSMO: osmoPrices,
+				broadcasterATOM:  atomPrices,
+			},
+		},
+		{
+			name:       "filtered by subscribed denom",
+			baseDenoms: []string{broadcasterATOM},
+			update: domain.PricesResult{
+				broadcasterUOSMO: osmoPrices,
+				broadcasterATOM:  atomPrices,
+			},
+
+			expectedUpdate: domain.PricesResult{
+				broadcasterATOM: atomPrices,
+			},
+		},
+		{
+			name:       "no subscribed denoms in update -> no update",
+			baseDenoms: []string{broadcasterATOM},
+			update: domain.PricesResult{
+				broadcasterUOSMO: osmoPrices,
+			},
+
+			expectedUpdate: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			broadcaster := worker.NewPricesBroadcaster(1)
+
+			updates, unsubscribe := broadcaster.Subscribe(tt.baseDenoms)
+			defer unsubscribe()
+
+			// System under test
+			err := broadcaster.OnPricingUpdate(context.Background(), 1, domain.BlockPoolMetadata{}, tt.update, broadcasterUSDC)
+			require.NoError(t, err)
+
+			if tt.expectedUpdate == nil {
+				require.Empty(t, updates)
+				return
+			}
+
+			require.Len(t, updates, 1)
+			require.Equal(t, tt.expectedUpdate, <-updates)
+		})
+	}
+}
+
+// Tests that the oldest pending update is dropped when the subscriber falls behind.
+func TestPricesBroadcaster_DropOldest(t *testing.T) {
+	const bufferSize = 2
+
+	broadcaster := worker.NewPricesBroadcaster(bufferSize)
+
+	updates, unsubscribe := broadcaster.Subscribe(nil)
+	defer unsubscribe()
+
+	pricesUpdates := []domain.PricesResult{
+		{broadcasterUOSMO: {broadcasterUSDC: osmomath.NewBigDec(1)}},
+		{broadcasterUOSMO: {broadcasterUSDC: osmomath.NewBigDec(2)}},
+		{broadcasterUOSMO: {broadcasterUSDC: osmomath.NewBigDec(3)}},
+	}
+
+	for i, update := range pricesUpdates {
+		err := broadcaster.OnPricingUpdate(context.Background(), uint64(i), domain.BlockPoolMetadata{}, update, broadcasterUSDC)
+		require.NoError(t, err)
+	}
+
+	// The first update is dropped.
+	require.Len(t, updates, bufferSize)
+	require.Equal(t, pricesUpdates[1], <-updates)
+	require.Equal(t, pricesUpdates[2], <-updates)
+}
+
+// Tests that unsubscribing closes the channel and stops the updates.
+func TestPricesBroadcaster_Unsubscribe(t *testing.T) {
+	broadcaster := worker.NewPricesBroadcaster(1)
+
+	updates, unsubscribe := broadcaster.Subscribe(nil)
+
+	unsubscribe()
+	// Unsubscribing is idempotent.
+	unsubscribe()
+
+	err := broadcaster.OnPricingUpdate(context.Background(), 1, domain.BlockPoolMetadata{}, domain.PricesResult{broadcasterUOSMO: osmoPrices}, broadcasterUSDC)
+	require.NoError(t, err)
+
+	_, ok := <-updates
+	require.False(t, ok)
+}
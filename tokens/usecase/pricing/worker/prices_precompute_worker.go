@@ -0,0 +1,123 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/mvc"
+	"github.com/osmosis-labs/sqs/log"
+	"go.uber.org/zap"
+)
+
+type pricesPrecomputeWorker struct {
+	tokensUseCase mvc.TokensUsecase
+	quoteDenom    string
+
+	interval                       time.Duration
+	maxConcurrentPriceComputations int
+
+	logger log.Logger
+}
+
+const (
+	defaultMaxConcurrentPriceComputations = 10
+)
+
+var _ domain.PricesPrecomputeWorker = &pricesPrecomputeWorker{}
+
+// NewPricesPrecomputeWorker creates a new prices precompute worker that prices all listed tokens
+// against the given quote denom on the given interval.
+// If maxConcurrentPriceComputations is not positive, defaults to 10.
+func NewPricesPrecomputeWorker(tokensUseCase mvc.TokensUsecase, quoteDenom string, interval time.Duration, maxConcurrentPriceComputations int, logger log.Logger) domain.PricesPrecomputeWorker {
+	if maxConcurrentPriceComputations <= 0 {
+		maxConcurrentPriceComputations = defaultMaxConcurrentPriceComputations
+	}
+
+	return &pricesPrecomputeWorker{
+		tokensUseCase: tokensUseCase,
+		quoteDenom:    quoteDenom,
+
+		interval:                       interval,
+		maxConcurrentPriceComputations: maxConcurrentPriceComputations,
+
+		logger: logger,
+	}
+}
+
+// Start implements domain.PricesPrecomputeWorker.
+func (p *pricesPrecomputeWorker) Start(ctx context.Context) {
+	p.PrecomputePrices(ctx)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.PrecomputePrices(ctx)
+		}
+	}
+}
+
+// PrecomputePrices implements domain.PricesPrecomputeWorker.
+func (p *pricesPrecomputeWorker) PrecomputePrices(ctx context.Context) (numPriced int, numFailed int) {
+	start := time.Now()
+
+	defer func() {
+		domain.SQSPricingPrecomputeWorkerPricedDenomsGauge.Set(float64(numPriced))
+		domain.SQSPricingPrecomputeWorkerFailedDenomsGauge.Set(float64(numFailed))
+
+		p.logger.Info("prices pre-computation completed", zap.Int("num_priced", numPriced), zap.Int("num_failed", numFailed), zap.Duration("duration", time.Since(start)))
+	}()
+
+	tokenMetadata, err := p.tokensUseCase.GetFullTokenMetadata()
+	if err != nil {
+		p.logger.Error("failed to get token metadata for prices pre-computation", zap.Error(err))
+		return 0, 0
+	}
+
+	baseDenoms := make([]string, 0, len(tokenMetadata))
+	for chainDenom, token := range tokenMetadata {
+		if token.IsUnlisted {
+			continue
+		}
+
+		baseDenoms = append(baseDenoms, chainDenom)
+	}
+
+	// Sort for deterministic batching.
+	sort.Strings(baseDenoms)
+
+	// Note: the prices are computed in batches of at most maxConcurrentPriceComputations denoms
+	// since GetPrices computes the prices for all base denoms in the batch concurrently.
+	for i := 0; i < len(baseDenoms); i += p.maxConcurrentPriceComputations {
+		end := i + p.maxConcurrentPriceComputations
+		if end > len(baseDenoms) {
+			end = len(baseDenoms)
+		}
+
+		batch := baseDenoms[i:end]
+
+		// Recomputing the prices stores them in the pricing cache.
+		prices, err := p.tokensUseCase.GetPrices(ctx, batch, []string{p.quoteDenom}, domain.ChainPricingSourceType, domain.WithRecomputePrices())
+		if err != nil {
+			p.logger.Error("failed to pre-compute prices", zap.Strings("base_denoms", batch), zap.Error(err))
+			numFailed += len(batch)
+			continue
+		}
+
+		for _, baseDenom := range batch {
+			if price := prices.GetPriceForDenom(baseDenom, p.quoteDenom); price.IsZero() {
+				numFailed++
+			} else {
+				numPriced++
+			}
+		}
+	}
+
+	return numPriced, numFailed
+}
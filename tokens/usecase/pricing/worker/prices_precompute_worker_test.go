@@ -0,0 +1,112 @@
+package worker_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/osmosis-labs/osmosis/osmomath"
+	"github.com/osmosis-labs/sqs/domain"
+	"github.com/osmosis-labs/sqs/domain/cache"
+	"github.com/osmosis-labs/sqs/domain/mocks"
+	"github.com/osmosis-labs/sqs/log"
+	"github.com/osmosis-labs/sqs/tokens/usecase/pricing/worker"
+)
+
+// Tests that the prices precompute worker prices all listed denoms against the quote denom
+// in batches bounded by the max concurrent price computations, populating the pricing cache.
+func TestPricesPrecomputeWorker_PrecomputePrices(t *testing.T) {
+	const (
+		precomputeQuoteDenom = "uusdc"
+
+		maxConcurrentPriceComputations = 2
+
+		// Has no price computed.
+		unpricedDenom = "unpriced"
+		// Is unlisted.
+		unlistedDenom = "unlisted"
+	)
+
+	var (
+		listedPricedDenoms = []string{"uatom", "uion", "uosmo"}
+
+		defaultPrecomputePrice = osmomath.NewBigDec(2)
+	)
+
+	tokenMetadata := map[string]domain.Token{
+		unpricedDenom: {},
+		unlistedDenom: {IsUnlisted: true},
+	}
+	for _, denom := range listedPricedDenoms {
+		tokenMetadata[denom] = domain.Token{}
+	}
+
+	// Emulate the pricing source populating the cache on recompute.
+	pricingCache := cache.New()
+
+	var (
+		mx              sync.Mutex
+		requestedDenoms []string
+	)
+
+	tokensUsecaseMock := &mocks.TokensUsecaseMock{
+		GetFullTokenMetadataFunc: func() (map[string]domain.Token, error) {
+			return tokenMetadata, nil
+		},
+		GetPricesFunc: func(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
+			require.LessOrEqual(t, len(baseDenoms), maxConcurrentPriceComputations)
+			require.Equal(t, []string{precomputeQuoteDenom}, quoteDenoms)
+
+			options := domain.PricingOptions{}
+			for _, opt := range opts {
+				opt(&options)
+			}
+			require.True(t, options.RecomputePrices)
+
+			mx.Lock()
+			defer mx.Unlock()
+
+			result := domain.PricesResult{}
+			for _, baseDenom := range baseDenoms {
+				requestedDenoms = append(requestedDenoms, baseDenom)
+
+				price := defaultPrecomputePrice
+				if baseDenom == unpricedDenom {
+					price = osmomath.ZeroBigDec()
+				} else {
+					pricingCache.Set(domain.FormatPricingCacheKey(domain.ChainPricingSourceType, baseDenom, precomputeQuoteDenom), price, cache.NoExpirationTTL)
+				}
+
+				result[baseDenom] = map[string]osmomath.BigDec{
+					precomputeQuoteDenom: price,
+				}
+			}
+
+			return result, nil
+		},
+	}
+
+	pricesPrecomputeWorker := worker.NewPricesPrecomputeWorker(tokensUsecaseMock, precomputeQuoteDenom, time.Minute, maxConcurrentPriceComputations, &log.NoOpLogger{})
+
+	// System under test
+	numPriced, numFailed := pricesPrecomputeWorker.PrecomputePrices(context.TODO())
+
+	require.Equal(t, len(listedPricedDenoms), numPriced)
+	require.Equal(t, 1, numFailed)
+
+	// Validate that only the listed denoms were requested.
+	require.ElementsMatch(t, append(listedPricedDenoms, unpricedDenom), requestedDenoms)
+
+	// Validate that the cache is populated for the priced denoms.
+	for _, denom := range listedPricedDenoms {
+		cachedPrice, found := pricingCache.Get(domain.FormatPricingCacheKey(domain.ChainPricingSourceType, denom, precomputeQuoteDenom))
+		require.True(t, found)
+		require.Equal(t, defaultPrecomputePrice, cachedPrice)
+	}
+
+	_, found := pricingCache.Get(domain.FormatPricingCacheKey(domain.ChainPricingSourceType, unlistedDenom, precomputeQuoteDenom))
+	require.False(t, found)
+}
@@ -3,8 +3,10 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/osmosis-labs/sqs/domain"
 	"github.com/osmosis-labs/sqs/domain/mvc"
@@ -19,6 +21,11 @@ const (
 	// Max number of workers to fetch prices concurrently
 	// TODO: move to config
 	maxNumWorkes = 10
+
+	// Human denoms of the stablecoins whose prices are compared
+	// to detect divergent prices.
+	usdcHumanDenom = "usdc"
+	usdtHumanDenom = "usdt"
 )
 
 type tokensUseCase struct {
@@ -27,6 +34,14 @@ type tokensUseCase struct {
 	humanToChainDenomMap      sync.Map // string
 	chainDenoms               sync.Map // struct{}
 
+	// Human denom aliases mapping the lower case alias to either a chain denom or a human denom
+	// from the registry. Only consulted if the human denom is not found in the registry.
+	humanDenomAliases sync.Map // string
+
+	// Scaling factors by precision. Preset on construction and auto-populated
+	// for the new precisions found when loading tokens.
+	precisionScalingFactors sync.Map // osmomath.Dec
+
 	// Metadata about denoms that is collected from the pools.
 	// E.g. total denom liquidity across all pools.
 	poolDenomMetaData sync.Map
@@ -38,12 +53,26 @@ type tokensUseCase struct {
 	// Map of chain denoms to coingecko IDs
 	coingeckoIds sync.Map // map[string]string
 
+	// Reverse index of coingecko IDs to the sorted chain denoms sharing them.
+	// Rebuilt from coingeckoIds whenever tokens are loaded.
+	denomsByCoingeckoIdMx sync.RWMutex
+	denomsByCoingeckoId   map[string][]string
+
+	// The quote denom of the market cap estimates.
+	marketCapQuoteDenom string
+	// Circulating supplies in human units by chain denom used for the market cap estimates.
+	circulatingSupplies map[string]osmomath.BigDec
+
 	// Represents the interval at which to update the assets from the chain registry
 	updateAssetsHeightInterval int
 
 	// TokenRegistryLoader fetches tokens from the chain registry into the tokens use case
 	tokenLoader domain.TokenRegistryLoader
 
+	// The time at which the tokens were last loaded from the asset list.
+	lastAssetListRefreshTimeMx sync.RWMutex
+	lastAssetListRefreshTime   time.Time
+
 	// Logger instance
 	logger log.Logger
 }
@@ -69,6 +98,7 @@ type priceResults struct {
 }
 
 var _ mvc.TokensUsecase = &tokensUseCase{}
+var _ domain.PricingUpdateListener = &tokensUseCase{}
 
 // NewTokensUsecase will create a new tokens use case object
 func NewTokensUsecase(tokenMetadataByChainDenom map[string]domain.Token, updateAssetsHeightInterval int, logger log.Logger) *tokensUseCase {
@@ -79,6 +109,10 @@ func NewTokensUsecase(tokenMetadataByChainDenom map[string]domain.Token, updateA
 		logger:                     logger,
 	}
 
+	for precision, scalingFactor := range presetPrecisionScalingFactors {
+		us.precisionScalingFactors.Store(precision, scalingFactor)
+	}
+
 	us.LoadTokens(tokenMetadataByChainDenom)
 
 	return &us
@@ -89,6 +123,68 @@ func (t *tokensUseCase) SetTokenRegistryLoader(loader domain.TokenRegistryLoader
 	t.tokenLoader = loader
 }
 
+// SetHumanDenomAliases sets the human denom aliases used by GetChainDenom, merged with the registry.
+// Each alias maps to either a chain denom or a human denom from the registry.
+// For example, "atom" -> "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2".
+func (t *tokensUseCase) SetHumanDenomAliases(aliases map[string]string) {
+	for alias, target := range aliases {
+		t.humanDenomAliases.Store(strings.ToLower(alias), target)
+	}
+}
+
+// validatePrecisionScalingFactors validates that the precisions of all given tokens have a scaling factor.
+// Any precision lacking one is logged and auto-populated with 10^precision.
+// If the scaling factor cannot be computed, the error is logged and the token
+// continues to fail with ScalingFactorForPrecisionNotFoundError.
+func (t *tokensUseCase) validatePrecisionScalingFactors(tokenMetadataByChainDenom map[string]domain.Token) {
+	for chainDenom, tokenMetadata := range tokenMetadataByChainDenom {
+		populated, err := t.populatePrecisionScalingFactor(tokenMetadata.Precision)
+		if err != nil {
+			t.logger.Error("failed to populate scaling factor for token precision", zap.String("denom", chainDenom), zap.Int("precision", tokenMetadata.Precision), zap.Error(err))
+			continue
+		}
+
+		if populated {
+			t.logger.Warn("populated missing scaling factor for token precision", zap.String("denom", chainDenom), zap.Int("precision", tokenMetadata.Precision))
+		}
+	}
+}
+
+// rebuildDenomsByCoingeckoId rebuilds the reverse index of coingecko IDs to chain denoms
+// from the coingecko IDs of all loaded tokens. Tokens without a coingecko ID are skipped.
+func (t *tokensUseCase) rebuildDenomsByCoingeckoId() {
+	denomsByCoingeckoId := map[string][]string{}
+	t.coingeckoIds.Range(func(key, value any) bool {
+		chainDenom, ok := key.(string)
+		if !ok {
+			return true
+		}
+
+		coingeckoId, ok := value.(string)
+		if !ok || coingeckoId == "" {
+			return true
+		}
+
+		denomsByCoingeckoId[coingeckoId] = append(denomsByCoingeckoId[coingeckoId], chainDenom)
+		return true
+	})
+
+	for _, chainDenoms := range denomsByCoingeckoId {
+		sort.Strings(chainDenoms)
+	}
+
+	t.denomsByCoingeckoIdMx.Lock()
+	defer t.denomsByCoingeckoIdMx.Unlock()
+	t.denomsByCoingeckoId = denomsByCoingeckoId
+}
+
+// SetMarketCapConfig sets the quote denom and the circulating supplies in human units
+// by chain denom used for the market cap estimates.
+func (t *tokensUseCase) SetMarketCapConfig(quoteDenom string, circulatingSupplies map[string]osmomath.BigDec) {
+	t.marketCapQuoteDenom = quoteDenom
+	t.circulatingSupplies = circulatingSupplies
+}
+
 // LoadTokensFunc is a function signature for LoadTokens.
 type LoadTokensFunc func(tokenMetadataByChainDenom map[string]domain.Token)
 
@@ -106,6 +202,23 @@ func (t *tokensUseCase) LoadTokens(tokenMetadataByChainDenom map[string]domain.T
 
 		t.coingeckoIds.Store(chainDenom, tokenMetadata.CoingeckoID)
 	}
+
+	// Rebuild the reverse index of coingecko IDs.
+	t.rebuildDenomsByCoingeckoId()
+
+	// Ensure that every loaded precision has a scaling factor.
+	t.validatePrecisionScalingFactors(tokenMetadataByChainDenom)
+
+	t.lastAssetListRefreshTimeMx.Lock()
+	defer t.lastAssetListRefreshTimeMx.Unlock()
+	t.lastAssetListRefreshTime = time.Now()
+}
+
+// GetLastAssetListRefreshTime implements mvc.TokensUsecase.
+func (t *tokensUseCase) GetLastAssetListRefreshTime() time.Time {
+	t.lastAssetListRefreshTimeMx.RLock()
+	defer t.lastAssetListRefreshTimeMx.RUnlock()
+	return t.lastAssetListRefreshTime
 }
 
 // UpdatePoolDenomMetadata implements mvc.TokensUsecase.
@@ -189,7 +302,11 @@ func (t *tokensUseCase) GetChainDenom(humanDenom string) (string, error) {
 
 	chainDenom, ok := t.humanToChainDenomMap.Load(humanDenomLowerCase)
 	if !ok {
-		return "", ChainDenomForHumanDenomNotFoundError{ChainDenom: humanDenomLowerCase}
+		// Fall back to the aliases if the human denom is not in the registry.
+		chainDenom, ok = t.resolveHumanDenomAlias(humanDenomLowerCase)
+		if !ok {
+			return "", ChainDenomForHumanDenomNotFoundError{ChainDenom: humanDenomLowerCase}
+		}
 	}
 
 	v, ok := chainDenom.(string)
@@ -200,6 +317,28 @@ func (t *tokensUseCase) GetChainDenom(humanDenom string) (string, error) {
 	return v, nil
 }
 
+// resolveHumanDenomAlias resolves the given lower case alias to the chain denom.
+// If the alias target is a known chain denom, it is returned as is. Otherwise, the target
+// is resolved as a human denom from the registry.
+// Returns false if the alias is not configured or its target is not found.
+func (t *tokensUseCase) resolveHumanDenomAlias(alias string) (any, bool) {
+	target, ok := t.humanDenomAliases.Load(alias)
+	if !ok {
+		return nil, false
+	}
+
+	targetStr, ok := target.(string)
+	if !ok {
+		return nil, false
+	}
+
+	if _, ok := t.chainDenoms.Load(targetStr); ok {
+		return targetStr, true
+	}
+
+	return t.humanToChainDenomMap.Load(strings.ToLower(targetStr))
+}
+
 // GetMetadataByChainDenom implements mvc.TokensUsecase.
 func (t *tokensUseCase) GetMetadataByChainDenom(denom string) (domain.Token, error) {
 	token, ok := t.tokenMetadataByChainDenom.Load(denom)
@@ -247,7 +386,7 @@ func (t *tokensUseCase) GetChainScalingFactorByDenomMut(denom string) (osmomath.
 		return osmomath.Dec{}, err
 	}
 
-	scalingFactor, ok := getPrecisionScalingFactorImmutable(denomMetadata.Precision)
+	scalingFactor, ok := t.getPrecisionScalingFactorImmutable(denomMetadata.Precision)
 	if !ok {
 		return osmomath.Dec{}, ScalingFactorForPrecisionNotFoundError{
 			Precision: denomMetadata.Precision,
@@ -258,6 +397,122 @@ func (t *tokensUseCase) GetChainScalingFactorByDenomMut(denom string) (osmomath.
 	return scalingFactor, nil
 }
 
+// GetMarketCap implements mvc.TokensUsecase.
+func (t *tokensUseCase) GetMarketCap(ctx context.Context, denom string) (domain.MarketCap, error) {
+	if t.marketCapQuoteDenom == "" {
+		return domain.MarketCap{}, MarketCapQuoteDenomNotConfiguredError{}
+	}
+
+	if _, err := t.GetMetadataByChainDenom(denom); err != nil {
+		return domain.MarketCap{}, err
+	}
+
+	prices, err := t.GetPrices(ctx, []string{denom}, []string{t.marketCapQuoteDenom}, domain.ChainPricingSourceType)
+	if err != nil {
+		return domain.MarketCap{}, err
+	}
+
+	price := prices.GetPriceForDenom(denom, t.marketCapQuoteDenom)
+	if price.IsNil() || price.IsZero() {
+		return domain.MarketCap{}, MarketCapPriceNotFoundError{
+			Denom:      denom,
+			QuoteDenom: t.marketCapQuoteDenom,
+		}
+	}
+
+	circulatingSupply, ok := t.circulatingSupplies[denom]
+	isBestEffort := !ok
+	if isBestEffort {
+		// Fall back to the total liquidity across all pools, converted to human units.
+		poolDenomMetadata, err := t.GetPoolDenomMetadata(denom)
+		if err != nil {
+			return domain.MarketCap{}, err
+		}
+
+		scalingFactor, err := t.GetChainScalingFactorByDenomMut(denom)
+		if err != nil {
+			return domain.MarketCap{}, err
+		}
+
+		circulatingSupply = osmomath.BigDecFromSDKInt(poolDenomMetadata.TotalLiquidity).QuoMut(osmomath.BigDecFromDec(scalingFactor))
+	}
+
+	return domain.MarketCap{
+		Denom:             denom,
+		QuoteDenom:        t.marketCapQuoteDenom,
+		Price:             price,
+		CirculatingSupply: circulatingSupply,
+		MarketCap:         price.Mul(circulatingSupply),
+		IsBestEffort:      isBestEffort,
+	}, nil
+}
+
+// FindDivergentStablePrices implements mvc.TokensUsecase.
+func (t *tokensUseCase) FindDivergentStablePrices(ctx context.Context, denoms []string, tolerance osmomath.Dec) ([]domain.DivergenceReport, error) {
+	usdcDenom, err := t.GetChainDenom(usdcHumanDenom)
+	if err != nil {
+		return nil, err
+	}
+
+	usdtDenom, err := t.GetChainDenom(usdtHumanDenom)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := t.GetPrices(ctx, denoms, []string{usdcDenom, usdtDenom}, domain.ChainPricingSourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	bigDecTolerance := osmomath.BigDecFromDec(tolerance)
+
+	reports := []domain.DivergenceReport{}
+	unpricedPairs := []string{}
+	for _, denom := range denoms {
+		usdcPrice := prices.GetPriceForDenom(denom, usdcDenom)
+		usdtPrice := prices.GetPriceForDenom(denom, usdtDenom)
+
+		// A denom that fails to be priced against one of the stablecoins would otherwise
+		// be reported as fully divergent. It is reported in the returned error instead.
+		isUSDCPriceZero := usdcPrice.IsNil() || usdcPrice.IsZero()
+		isUSDTPriceZero := usdtPrice.IsNil() || usdtPrice.IsZero()
+		if isUSDCPriceZero {
+			unpricedPairs = append(unpricedPairs, denom+"/"+usdcDenom)
+		}
+		if isUSDTPriceZero {
+			unpricedPairs = append(unpricedPairs, denom+"/"+usdtDenom)
+		}
+		if isUSDCPriceZero || isUSDTPriceZero {
+			continue
+		}
+
+		maxPrice := osmomath.MaxBigDec(usdcPrice, usdtPrice)
+
+		divergence := usdcPrice.Sub(usdtPrice).AbsMut().QuoMut(maxPrice)
+		if divergence.LTE(bigDecTolerance) {
+			continue
+		}
+
+		reports = append(reports, domain.DivergenceReport{
+			Denom:      denom,
+			USDCPrice:  usdcPrice,
+			USDTPrice:  usdtPrice,
+			Divergence: divergence,
+		})
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Denom < reports[j].Denom
+	})
+
+	if len(unpricedPairs) > 0 {
+		sort.Strings(unpricedPairs)
+		return reports, domain.ZeroPricesError{BaseQuotePairs: unpricedPairs}
+	}
+
+	return reports, nil
+}
+
 // GetPrices implements pricing.PricingStrategy.
 func (t *tokensUseCase) GetPrices(ctx context.Context, baseDenoms []string, quoteDenoms []string, pricingSourceType domain.PricingSourceType, opts ...domain.PricingOption) (domain.PricesResult, error) {
 	byBaseDenomResult := make(map[string]map[string]osmomath.BigDec, len(baseDenoms))
@@ -310,9 +565,42 @@ func (t *tokensUseCase) GetPrices(ctx context.Context, baseDenoms []string, quot
 		byBaseDenomResult[result.Result.baseDenom] = result.Result.prices
 	}
 
+	options := domain.PricingOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.IsStrict {
+		if err := validateNonZeroPrices(byBaseDenomResult); err != nil {
+			return nil, err
+		}
+	}
+
 	return byBaseDenomResult, nil
 }
 
+// validateNonZeroPrices returns domain.ZeroPricesError listing all base/quote pairs
+// that priced to zero. The pairs are sorted for determinism.
+// Returns nil if all prices are non-zero.
+func validateNonZeroPrices(prices domain.PricesResult) error {
+	var zeroPricePairs []string
+	for baseDenom, quotePrices := range prices {
+		for quoteDenom, price := range quotePrices {
+			if price.IsNil() || price.IsZero() {
+				zeroPricePairs = append(zeroPricePairs, baseDenom+"/"+quoteDenom)
+			}
+		}
+	}
+
+	if len(zeroPricePairs) == 0 {
+		return nil
+	}
+
+	sort.Strings(zeroPricePairs)
+
+	return domain.ZeroPricesError{BaseQuotePairs: zeroPricePairs}
+}
+
 // getPricesForBaseDenom fetches all prices for base denom given a slice of quotes and pricing options.
 // Pricing options determine whether to recompute prices or use the cache as well as the desired source of prices.
 // Returns a map with keys as quotes and values as prices or error, if any.
@@ -343,8 +631,17 @@ func (t *tokensUseCase) getPricesForBaseDenom(ctx context.Context, baseDenom str
 		}
 	}()
 
+	// Warm up the cache of the pricing sources that price multiple quotes at once so that
+	// the per-quote prices below are served from the cache.
+	// On error, each quote is priced individually, falling back to another source if needed.
+	// The cache metrics are only recorded by the per-quote lookups to avoid double-counting.
+	if multiQuotePricingSource, ok := pricingStrategy.(domain.MultiQuotePricingSource); ok && len(quoteDenoms) > 1 {
+		warmUpOptions := append([]domain.PricingOption{domain.WithoutPricingCacheMetrics()}, pricingOptions...)
+		_, _ = multiQuotePricingSource.GetPricesForQuotes(ctx, baseDenom, quoteDenoms, warmUpOptions...)
+	}
+
 	for _, quoteDenom := range quoteDenoms {
-		price, err := pricingStrategy.GetPrice(ctx, baseDenom, quoteDenom, pricingOptions...)
+		price, err := getPriceWithMetrics(ctx, pricingStrategy, pricingSourceType, baseDenom, quoteDenom, pricingOptions...)
 		if err != nil { // Check if we should fallback to another pricing source
 			fallbackSourceType := pricingStrategy.GetFallbackStrategy(quoteDenom)
 			if fallbackSourceType != domain.NoneSourceType {
@@ -352,7 +649,7 @@ func (t *tokensUseCase) getPricesForBaseDenom(ctx context.Context, baseDenom str
 				domain.SQSPricingFallbackCounter.Inc()
 				fallbackPricingStrategy, ok := t.pricingStrategyMap[fallbackSourceType]
 				if ok {
-					price, err = fallbackPricingStrategy.GetPrice(ctx, baseDenom, quoteDenom, pricingOptions...)
+					price, err = getPriceWithMetrics(ctx, fallbackPricingStrategy, fallbackSourceType, baseDenom, quoteDenom, pricingOptions...)
 				}
 			}
 		}
@@ -399,11 +696,50 @@ func (t *tokensUseCase) GetSpotPriceScalingFactorByDenom(baseDenom string, quote
 	return baseScalingFactor.Quo(quoteScalingFactor), nil
 }
 
+// getPriceWithMetrics returns the price for the given base and quote denom from the given pricing source.
+// It increments the pricing requests counter and, on error, the pricing failures counter labeled by the source
+// type and quote denom.
+func getPriceWithMetrics(ctx context.Context, pricingSource domain.PricingSource, pricingSourceType domain.PricingSourceType, baseDenom, quoteDenom string, pricingOptions ...domain.PricingOption) (osmomath.BigDec, error) {
+	domain.SQSPricingRequestsTotal.WithLabelValues(pricingSourceType.String(), quoteDenom).Inc()
+
+	price, err := pricingSource.GetPrice(ctx, baseDenom, quoteDenom, pricingOptions...)
+	if err != nil {
+		domain.SQSPricingFailuresTotal.WithLabelValues(pricingSourceType.String(), quoteDenom).Inc()
+	}
+
+	return price, err
+}
+
 // RegisterPricingStrategy implements mvc.TokensUsecase.
 func (t *tokensUseCase) RegisterPricingStrategy(source domain.PricingSourceType, strategy domain.PricingSource) {
 	t.pricingStrategyMap[source] = strategy
 }
 
+// InvalidatePriceCache implements mvc.TokensUsecase.
+func (t *tokensUseCase) InvalidatePriceCache(denoms ...string) {
+	t.invalidateChainPriceCache("", denoms...)
+}
+
+// OnPricingUpdate implements domain.PricingUpdateListener.
+// The pools of the denoms updated within the block changed, invalidating their cached chain prices.
+// The prices against the quote denom are kept since they were just recomputed by the pricing worker.
+func (t *tokensUseCase) OnPricingUpdate(ctx context.Context, height uint64, blockMetadata domain.BlockPoolMetadata, pricesBaseQuoteDenomMap domain.PricesResult, quoteDenom string) error {
+	t.invalidateChainPriceCache(quoteDenom, domain.KeysFromMap(blockMetadata.UpdatedDenoms)...)
+	return nil
+}
+
+// invalidateChainPriceCache removes the cached chain prices of the given base denoms
+// against all quote denoms other than excludedQuoteDenom.
+// No-op if the chain pricing source is not registered.
+func (t *tokensUseCase) invalidateChainPriceCache(excludedQuoteDenom string, baseDenoms ...string) {
+	chainPricingSource, ok := t.pricingStrategyMap[domain.ChainPricingSourceType]
+	if !ok {
+		return
+	}
+
+	chainPricingSource.InvalidateCache(excludedQuoteDenom, baseDenoms...)
+}
+
 // IsValidChainDenom implements mvc.TokensUsecase.
 func (t *tokensUseCase) IsValidChainDenom(chainDenom string) bool {
 	metaData, ok := t.tokenMetadataByChainDenom.Load(chainDenom)
@@ -466,3 +802,15 @@ func (t *tokensUseCase) GetCoingeckoIdByChainDenom(chainDenom string) (string, e
 
 	return v, nil
 }
+
+// GetDenomsByCoingeckoID implements mvc.TokensUsecase.
+func (t *tokensUseCase) GetDenomsByCoingeckoID(id string) []string {
+	t.denomsByCoingeckoIdMx.RLock()
+	chainDenoms := t.denomsByCoingeckoId[id]
+	t.denomsByCoingeckoIdMx.RUnlock()
+
+	// Copy to prevent the callers from mutating the index.
+	result := make([]string, len(chainDenoms))
+	copy(result, chainDenoms)
+	return result
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/osmosis-labs/sqs/domain"
@@ -335,7 +337,7 @@ func (s *TokensUseCaseTestSuite) TestGetPrices_Chain_PricingOptions() {
 
 			// Pre-set cache if configured.
 			if !tt.cachedPrice.IsNil() {
-				baseQuoteCacheKey := domain.FormatPricingCacheKey(defaultBase, defaultQuote)
+				baseQuoteCacheKey := domain.FormatPricingCacheKey(domain.ChainPricingSourceType, defaultBase, defaultQuote)
 				pricingCache.Set(baseQuoteCacheKey, tt.cachedPrice, defaultPricingCacheExpiry)
 			}
 
@@ -361,6 +363,227 @@ func (s *TokensUseCaseTestSuite) TestGetPrices_Chain_PricingOptions() {
 	}
 }
 
+// Tests that invalidating the price cache for a denom drops the cached price
+// so that the next request recomputes it.
+func (s *TokensUseCaseTestSuite) TestInvalidatePriceCache() {
+	var (
+		defaultBase  = ATOM
+		defaultQuote = USDC
+
+		defaultBaseInput, defaultQuoteInput = []string{defaultBase}, []string{defaultQuote}
+
+		// We are hoping that the price of ATOM only goes up and never reaches one.
+		// As a result, it is reasonable to assume that in tests and use it as a cache overwrite for testing.
+		priceOne = osmomath.OneBigDec()
+
+		baseQuoteCacheKey = domain.FormatPricingCacheKey(domain.ChainPricingSourceType, defaultBase, defaultQuote)
+	)
+
+	pricingCache := cache.New()
+
+	// Set up mainnet mock state.
+	mainnetState := s.SetupMainnetState()
+
+	// Setup mainnet use cases
+	mainnetUseCase := s.SetupRouterAndPoolsUsecase(mainnetState, routertesting.WithPricingCache(pricingCache), routertesting.WithPricingConfig(defaultPricingConfig), routertesting.WithRouterConfig(defaultPricingRouterConfig))
+
+	// Populate the pricing cache via the pricing source and overwrite the cached price with the pre-set price.
+	_, err := mainnetUseCase.Tokens.GetPrices(context.Background(), defaultBaseInput, defaultQuoteInput, domain.ChainPricingSourceType)
+	s.Require().NoError(err)
+	pricingCache.Set(baseQuoteCacheKey, priceOne, defaultPricingCacheExpiry)
+
+	// Validate that the cached price is returned prior to invalidation.
+	priceResult, err := mainnetUseCase.Tokens.GetPrices(context.Background(), defaultBaseInput, defaultQuoteInput, domain.ChainPricingSourceType)
+	s.Require().NoError(err)
+	s.Require().Equal(priceOne.String(), s.ConvertAnyToBigDec(priceResult[defaultBase][defaultQuote]).String())
+
+	// System under test.
+	mainnetUseCase.Tokens.InvalidatePriceCache(defaultBase)
+
+	_, found := pricingCache.Get(baseQuoteCacheKey)
+	s.Require().False(found)
+
+	// Validate that the price is recomputed.
+	priceResult, err = mainnetUseCase.Tokens.GetPrices(context.Background(), defaultBaseInput, defaultQuoteInput, domain.ChainPricingSourceType)
+	s.Require().NoError(err)
+
+	recomputedPrice := s.ConvertAnyToBigDec(priceResult[defaultBase][defaultQuote])
+	s.Require().False(recomputedPrice.IsZero())
+	s.Require().NotEqual(priceOne.String(), recomputedPrice.String())
+
+	// Validate that the recomputed price is cached.
+	cachedPrice, found := pricingCache.Get(baseQuoteCacheKey)
+	s.Require().True(found)
+	s.Require().Equal(recomputedPrice.String(), s.ConvertAnyToBigDec(cachedPrice).String())
+}
+
+// Tests that the pricing update invalidates the cached chain prices of the updated base denoms
+// while keeping the ones against the recomputed quote denom and the other pricing sources intact.
+func (s *TokensUseCaseTestSuite) TestOnPricingUpdate_InvalidatesChainPriceCache() {
+	var (
+		chainExcludedQuoteDenom string
+		chainBaseDenoms         []string
+
+		isCoingeckoInvalidated bool
+	)
+
+	usecase := tokensusecase.NewTokensUsecase(nil, 0, nil)
+	usecase.RegisterPricingStrategy(domain.ChainPricingSourceType, &mocks.PricingSourceMock{
+		InvalidateCacheFunc: func(excludedQuoteDenom string, baseDenoms ...string) {
+			chainExcludedQuoteDenom = excludedQuoteDenom
+			chainBaseDenoms = baseDenoms
+		},
+	})
+	usecase.RegisterPricingStrategy(domain.CoinGeckoPricingSourceType, &mocks.PricingSourceMock{
+		InvalidateCacheFunc: func(excludedQuoteDenom string, baseDenoms ...string) {
+			isCoingeckoInvalidated = true
+		},
+	})
+
+	blockMetadata := domain.BlockPoolMetadata{
+		UpdatedDenoms: map[string]struct{}{ATOM: {}},
+	}
+
+	// System under test.
+	err := usecase.OnPricingUpdate(context.Background(), 1, blockMetadata, domain.PricesResult{}, USDC)
+	s.Require().NoError(err)
+
+	s.Require().Equal(USDC, chainExcludedQuoteDenom)
+	s.Require().Equal([]string{ATOM}, chainBaseDenoms)
+	s.Require().False(isCoingeckoInvalidated)
+}
+
+// Tests that the market cap combines the price with the configured circulating supply,
+// falling back to the pool liquidity as a best-effort estimate.
+func (s *TokensUseCaseTestSuite) TestGetMarketCap() {
+	const (
+		quoteDenom         = "uusdc"
+		withSupplyDenom    = "uosmo"
+		withoutSupplyDenom = "uatom"
+		noPriceDenom       = "unoprice"
+		noMetadataDenom    = "unknown"
+	)
+
+	var (
+		osmoPrice = osmomath.NewBigDecWithPrec(5, 1)
+		atomPrice = osmomath.NewBigDec(5)
+
+		osmoSupply = osmomath.NewBigDec(700_000_000)
+
+		// 1000 ATOM in pools with precision 6.
+		atomPoolLiquidity = osmomath.NewInt(1_000_000_000)
+	)
+
+	tokensMetadata := map[string]domain.Token{
+		quoteDenom:         {Precision: 6},
+		withSupplyDenom:    {Precision: 6},
+		withoutSupplyDenom: {Precision: 6},
+		noPriceDenom:       {Precision: 6},
+	}
+
+	pricingSource := &mocks.PricingSourceMock{
+		GetPriceFunc: func(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+			switch baseDenom {
+			case withSupplyDenom:
+				return osmoPrice, nil
+			case withoutSupplyDenom:
+				return atomPrice, nil
+			default:
+				return osmomath.ZeroBigDec(), nil
+			}
+		},
+		GetFallbackStrategyFunc: func(quoteDenom string) domain.PricingSourceType {
+			return domain.NoneSourceType
+		},
+	}
+
+	tests := []struct {
+		name                string
+		denom               string
+		marketCapQuoteDenom string
+
+		expectedMarketCap domain.MarketCap
+		expectedError     error
+	}{
+		{
+			name:                "configured circulating supply",
+			denom:               withSupplyDenom,
+			marketCapQuoteDenom: quoteDenom,
+
+			expectedMarketCap: domain.MarketCap{
+				Denom:             withSupplyDenom,
+				QuoteDenom:        quoteDenom,
+				Price:             osmoPrice,
+				CirculatingSupply: osmoSupply,
+				MarketCap:         osmomath.NewBigDec(350_000_000),
+			},
+		},
+		{
+			name:                "no circulating supply -> best-effort pool liquidity",
+			denom:               withoutSupplyDenom,
+			marketCapQuoteDenom: quoteDenom,
+
+			expectedMarketCap: domain.MarketCap{
+				Denom:             withoutSupplyDenom,
+				QuoteDenom:        quoteDenom,
+				Price:             atomPrice,
+				CirculatingSupply: osmomath.NewBigDec(1000),
+				MarketCap:         osmomath.NewBigDec(5000),
+				IsBestEffort:      true,
+			},
+		},
+		{
+			name:                "no price",
+			denom:               noPriceDenom,
+			marketCapQuoteDenom: quoteDenom,
+
+			expectedError: tokensusecase.MarketCapPriceNotFoundError{Denom: noPriceDenom, QuoteDenom: quoteDenom},
+		},
+		{
+			name:                "no metadata",
+			denom:               noMetadataDenom,
+			marketCapQuoteDenom: quoteDenom,
+
+			expectedError: tokensusecase.MetadataForChainDenomNotFoundError{ChainDenom: noMetadataDenom},
+		},
+		{
+			name:  "quote denom not configured",
+			denom: withSupplyDenom,
+
+			expectedError: tokensusecase.MarketCapQuoteDenomNotConfiguredError{},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			usecase := tokensusecase.NewTokensUsecase(tokensMetadata, 0, &log.NoOpLogger{})
+			usecase.RegisterPricingStrategy(domain.ChainPricingSourceType, pricingSource)
+			usecase.UpdatePoolDenomMetadata(domain.PoolDenomMetaDataMap{
+				withoutSupplyDenom: {TotalLiquidity: atomPoolLiquidity},
+			})
+			usecase.SetMarketCapConfig(tt.marketCapQuoteDenom, map[string]osmomath.BigDec{
+				withSupplyDenom: osmoSupply,
+			})
+
+			// System under test
+			marketCap, err := usecase.GetMarketCap(context.Background(), tt.denom)
+
+			if tt.expectedError != nil {
+				s.Require().EqualError(err, tt.expectedError.Error())
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(tt.expectedMarketCap.Denom, marketCap.Denom)
+			s.Require().Equal(tt.expectedMarketCap.QuoteDenom, marketCap.QuoteDenom)
+			s.Require().Equal(tt.expectedMarketCap.Price.String(), marketCap.Price.String())
+			s.Require().Equal(tt.expectedMarketCap.CirculatingSupply.String(), marketCap.CirculatingSupply.String())
+			s.Require().Equal(tt.expectedMarketCap.MarketCap.String(), marketCap.MarketCap.String())
+			s.Require().Equal(tt.expectedMarketCap.IsBestEffort, marketCap.IsBestEffort)
+		})
+	}
+}
+
 // Basic sanity check test case to validate the updates and retrieval of pool denom liquidity.
 // It sets up mainnet mock state and updates the pool denom metadata for ATOM and OSMO.
 // It then retrieves the liquidity of ATOM and OSMO and validates if the liquidity is updated.
@@ -748,6 +971,71 @@ func (s *TokensUseCaseTestSuite) TestGetChainDenom() {
 	}
 }
 
+// Tests that GetChainDenom falls back to the configured aliases if the human denom is not in the registry.
+func (s *TokensUseCaseTestSuite) TestGetChainDenom_Aliases() {
+	const (
+		uosmo   = "uosmo"
+		ibcAtom = "ibc/atom"
+	)
+
+	testcases := []struct {
+		name           string
+		humanDenom     string
+		expectedResult string
+		expectedError  error
+	}{
+		{
+			name:           "registry human denom takes precedence over alias",
+			humanDenom:     "osmo",
+			expectedResult: uosmo,
+		},
+		{
+			name:           "alias to chain denom",
+			humanDenom:     "ATOM",
+			expectedResult: ibcAtom,
+		},
+		{
+			name:           "alias to registry human denom",
+			humanDenom:     "osmosis",
+			expectedResult: uosmo,
+		},
+		{
+			name:          "alias to unknown target",
+			humanDenom:    "dangling",
+			expectedError: tokensusecase.ChainDenomForHumanDenomNotFoundError{ChainDenom: "dangling"},
+		},
+		{
+			name:          "alias miss",
+			humanDenom:    "unknown",
+			expectedError: tokensusecase.ChainDenomForHumanDenomNotFoundError{ChainDenom: "unknown"},
+		},
+	}
+
+	usecase := tokensusecase.NewTokensUsecase(map[string]domain.Token{
+		uosmo:   {HumanDenom: "osmo"},
+		ibcAtom: {HumanDenom: "cosmoshub-atom"},
+	}, 0, nil)
+
+	usecase.SetHumanDenomAliases(map[string]string{
+		"osmo":     "unknown",
+		"Atom":     ibcAtom,
+		"osmosis":  "OSMO",
+		"dangling": "missing",
+	})
+
+	for _, tt := range testcases {
+		s.Run(tt.name, func() {
+			result, err := usecase.GetChainDenom(tt.humanDenom)
+			if tt.expectedError != nil {
+				s.Require().EqualError(err, tt.expectedError.Error())
+			} else {
+				s.Require().NoError(err)
+			}
+			s.Require().Equal(tt.expectedResult, result)
+		})
+	}
+}
+
 // Tests the GetChainScalingFactorByDenomMut function.
 func (s *TokensUseCaseTestSuite) TestGetChainScalingFactorByDenomMut() {
 	testcases := []struct {
@@ -807,6 +1095,50 @@ func (s *TokensUseCaseTestSuite) TestGetChainScalingFactorByDenomMut() {
 	}
 }
 
+// Tests that loading tokens auto-populates the scaling factors of the precisions not in the preset,
+// leaving the ones that cannot be computed unpopulated.
+func (s *TokensUseCaseTestSuite) TestLoadTokens_PopulatesMissingScalingFactors() {
+	const (
+		// Not in the preset but representable.
+		newPrecision = 75
+		// Overflows.
+		overflowPrecision = 100
+
+		newPrecisionDenom = "newPrecisionDenom"
+		overflowDenom     = "overflowDenom"
+	)
+
+	usecase := tokensusecase.NewTokensUsecase(nil, 0, &log.NoOpLogger{})
+
+	// Not populated before loading.
+	_, err := usecase.GetChainScalingFactorByDenomMut(newPrecisionDenom)
+	s.Require().Error(err)
+
+	// System under test
+	usecase.LoadTokens(map[string]domain.Token{
+		newPrecisionDenom: {Precision: newPrecision},
+		overflowDenom:     {Precision: overflowPrecision},
+	})
+
+	scalingFactor, err := usecase.GetChainScalingFactorByDenomMut(newPrecisionDenom)
+	s.Require().NoError(err)
+	s.Require().Equal(osmomath.NewDec(10).Power(newPrecision), scalingFactor)
+
+	_, err = usecase.GetChainScalingFactorByDenomMut(overflowDenom)
+	s.Require().EqualError(err, tokensusecase.ScalingFactorForPrecisionNotFoundError{
+		Precision: overflowPrecision,
+		Denom:     overflowDenom,
+	}.Error())
+
+	// The populated scaling factor is not shared with other use cases.
+	// Note: the token metadata is set directly as loading it would populate the scaling factor.
+	otherUsecase := tokensusecase.NewTokensUsecase(nil, 0, &log.NoOpLogger{})
+	otherUsecase.SetTokenMetadataByChainDenom(newPrecisionDenom, domain.Token{Precision: newPrecision})
+
+	_, err = otherUsecase.GetChainScalingFactorByDenomMut(newPrecisionDenom)
+	s.Require().Error(err)
+}
+
 // Tests the GetCoingeckoIdByChainDenom function.
 func (s *TokensUseCaseTestSuite) TestGetCoingeckoIdByChainDenom() {
 	testcases := []struct {
@@ -864,6 +1196,60 @@ func (s *TokensUseCaseTestSuite) TestGetCoingeckoIdByChainDenom() {
 }
 
 // TestUpdateAssetsAtHeightIntervalSync tests the async update of assets at height interval.
+// Tests that GetDenomsByCoingeckoID returns all chain denoms sharing the coingecko ID,
+// reflecting the reloaded tokens.
+func (s *TokensUseCaseTestSuite) TestGetDenomsByCoingeckoID() {
+	const (
+		bitcoinId = "bitcoin"
+		cosmosId  = "cosmos"
+
+		nativeWBTC = "factory/wbtc"
+		axlWBTC    = "ibc/axlwbtc"
+		allBTC     = "alloyed/allBTC"
+		ibcAtom    = "ibc/atom"
+		noIdDenom  = "noid"
+	)
+
+	usecase := tokensusecase.NewTokensUsecase(map[string]domain.Token{
+		nativeWBTC: {CoingeckoID: bitcoinId},
+		axlWBTC:    {CoingeckoID: bitcoinId},
+		allBTC:     {CoingeckoID: bitcoinId},
+		ibcAtom:    {CoingeckoID: cosmosId},
+		noIdDenom:  {},
+	}, 0, &log.NoOpLogger{})
+
+	// System under test
+	s.Require().Equal([]string{allBTC, nativeWBTC, axlWBTC}, usecase.GetDenomsByCoingeckoID(bitcoinId))
+	s.Require().Equal([]string{ibcAtom}, usecase.GetDenomsByCoingeckoID(cosmosId))
+	s.Require().Empty(usecase.GetDenomsByCoingeckoID("unknown"))
+	s.Require().Empty(usecase.GetDenomsByCoingeckoID(""))
+
+	// Reloading a token with a changed coingecko ID updates the index.
+	usecase.LoadTokens(map[string]domain.Token{
+		allBTC: {CoingeckoID: "allbtc"},
+	})
+
+	s.Require().Equal([]string{nativeWBTC, axlWBTC}, usecase.GetDenomsByCoingeckoID(bitcoinId))
+	s.Require().Equal([]string{allBTC}, usecase.GetDenomsByCoingeckoID("allbtc"))
+}
+
+// Tests that the last asset list refresh time is updated whenever the tokens are loaded.
+func (s *TokensUseCaseTestSuite) TestGetLastAssetListRefreshTime() {
+	usecase := tokensusecase.NewTokensUsecase(map[string]domain.Token{
+		UOSMO: {HumanDenom: "osmo"},
+	}, 0, nil)
+
+	initialRefreshTime := usecase.GetLastAssetListRefreshTime()
+	s.Require().False(initialRefreshTime.IsZero())
+
+	// System under test
+	usecase.LoadTokens(map[string]domain.Token{
+		ATOM: {HumanDenom: "atom"},
+	})
+
+	s.Require().False(usecase.GetLastAssetListRefreshTime().Before(initialRefreshTime))
+}
+
 func (s *TokensUseCaseTestSuite) TestUpdateAssetsAtHeightIntervalSync() {
 	testcases := []struct {
 		name              string
@@ -930,3 +1316,306 @@ func (s *TokensUseCaseTestSuite) TestUpdateAssetsAtHeightIntervalSync() {
 		})
 	}
 }
+
+// Tests that the strict pricing option returns an error listing all base/quote pairs
+// that priced to zero instead of including them in the result.
+// Validates that the strict option composes with the recompute option.
+func (s *TokensUseCaseTestSuite) TestGetPrices_StrictPricing() {
+	var (
+		atomPrice = osmomath.NewBigDec(5)
+
+		priceableAndUnpriceable = []string{ATOM, UOSMO}
+	)
+
+	testcases := []struct {
+		name           string
+		baseDenoms     []string
+		pricingOptions []domain.PricingOption
+
+		expectedPrices    domain.PricesResult
+		expectedRecompute bool
+		expectedError     error
+	}{
+		{
+			name:           "non-strict, mixed -> zero price included",
+			baseDenoms:     priceableAndUnpriceable,
+			pricingOptions: []domain.PricingOption{},
+
+			expectedPrices: domain.PricesResult{
+				ATOM:  {USDC: atomPrice},
+				UOSMO: {USDC: osmomath.ZeroBigDec()},
+			},
+		},
+		{
+			name:           "strict, all priceable -> no error",
+			baseDenoms:     []string{ATOM},
+			pricingOptions: []domain.PricingOption{domain.WithStrictPricing()},
+
+			expectedPrices: domain.PricesResult{
+				ATOM: {USDC: atomPrice},
+			},
+		},
+		{
+			name:           "strict, mixed -> error listing zero-priced denoms",
+			baseDenoms:     priceableAndUnpriceable,
+			pricingOptions: []domain.PricingOption{domain.WithStrictPricing()},
+
+			expectedError: domain.ZeroPricesError{BaseQuotePairs: []string{UOSMO + "/" + USDC}},
+		},
+		{
+			name:           "strict with recompute, mixed -> recomputes and errors",
+			baseDenoms:     priceableAndUnpriceable,
+			pricingOptions: []domain.PricingOption{domain.WithRecomputePrices(), domain.WithStrictPricing()},
+
+			expectedRecompute: true,
+			expectedError:     domain.ZeroPricesError{BaseQuotePairs: []string{UOSMO + "/" + USDC}},
+		},
+	}
+
+	for _, tt := range testcases {
+		s.Run(tt.name, func() {
+			usecase := tokensusecase.NewTokensUsecase(map[string]domain.Token{
+				ATOM:  {HumanDenom: "atom"},
+				UOSMO: {HumanDenom: "osmo"},
+			}, 0, noOpLogger)
+
+			var isRecomputed bool
+			usecase.RegisterPricingStrategy(domain.ChainPricingSourceType, &mocks.PricingSourceMock{
+				GetPriceFunc: func(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+					options := domain.PricingOptions{}
+					for _, opt := range opts {
+						opt(&options)
+					}
+					isRecomputed = options.RecomputePrices
+
+					if baseDenom == ATOM {
+						return atomPrice, nil
+					}
+					return osmomath.BigDec{}, fmt.Errorf("no route for %s", baseDenom)
+				},
+			})
+
+			// System under test
+			prices, err := usecase.GetPrices(context.Background(), tt.baseDenoms, []string{USDC}, domain.ChainPricingSourceType, tt.pricingOptions...)
+
+			s.Require().Equal(tt.expectedRecompute, isRecomputed)
+
+			if tt.expectedError != nil {
+				s.Require().EqualError(err, tt.expectedError.Error())
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(tt.expectedPrices, prices)
+		})
+	}
+}
+
+// Tests that only the denoms whose USDC and USDT prices diverge beyond the tolerance are reported
+// and that the denoms missing either price are returned in the error.
+func (s *TokensUseCaseTestSuite) TestFindDivergentStablePrices() {
+	var (
+		tolerance = osmomath.MustNewDecFromStr("0.05")
+
+		// denom -> quote denom -> price
+		prices = map[string]map[string]osmomath.BigDec{
+			// Within tolerance.
+			ATOM: {USDC: osmomath.NewBigDec(100), USDT: osmomath.NewBigDec(101)},
+			// Divergent.
+			UOSMO: {USDC: osmomath.NewBigDec(1), USDT: osmomath.NewBigDec(2)},
+			// Only priced against USDC, returned in the error.
+			AKT: {USDC: osmomath.NewBigDec(4)},
+			// UION is priced against neither, returned in the error.
+		}
+	)
+
+	usecase := tokensusecase.NewTokensUsecase(map[string]domain.Token{
+		ATOM:  {HumanDenom: "atom"},
+		UOSMO: {HumanDenom: "osmo"},
+		AKT:   {HumanDenom: "akt"},
+		UION:  {HumanDenom: "ion"},
+		USDC:  {HumanDenom: "usdc"},
+		USDT:  {HumanDenom: "usdt"},
+	}, 0, noOpLogger)
+
+	usecase.RegisterPricingStrategy(domain.ChainPricingSourceType, &mocks.PricingSourceMock{
+		GetPriceFunc: func(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+			price, ok := prices[baseDenom][quoteDenom]
+			if !ok {
+				return osmomath.BigDec{}, fmt.Errorf("no route for %s/%s", baseDenom, quoteDenom)
+			}
+			return price, nil
+		},
+	})
+
+	// System under test
+	reports, err := usecase.FindDivergentStablePrices(context.Background(), []string{UOSMO, ATOM, AKT, UION}, tolerance)
+
+	expectedUnpricedPairs := []string{AKT + "/" + USDT, UION + "/" + USDC, UION + "/" + USDT}
+	sort.Strings(expectedUnpricedPairs)
+	s.Require().Equal(domain.ZeroPricesError{BaseQuotePairs: expectedUnpricedPairs}, err)
+
+	// Sorted by denom.
+	expectedReports := []domain.DivergenceReport{
+		{
+			Denom:      UOSMO,
+			USDCPrice:  osmomath.NewBigDec(1),
+			USDTPrice:  osmomath.NewBigDec(2),
+			Divergence: osmomath.MustNewBigDecFromStr("0.5"),
+		},
+	}
+	s.Require().Len(reports, len(expectedReports))
+	for i, expected := range expectedReports {
+		s.Require().Equal(expected.Denom, reports[i].Denom)
+		s.Require().Equal(expected.USDCPrice.String(), reports[i].USDCPrice.String())
+		s.Require().Equal(expected.USDTPrice.String(), reports[i].USDTPrice.String())
+		s.Require().Equal(expected.Divergence.String(), reports[i].Divergence.String())
+	}
+}
+
+// Tests that FindDivergentStablePrices returns the error if the stablecoin denoms or the prices cannot be retrieved.
+func (s *TokensUseCaseTestSuite) TestFindDivergentStablePrices_Error() {
+	tolerance := osmomath.MustNewDecFromStr("0.05")
+
+	s.Run("stablecoin denom not found", func() {
+		usecase := tokensusecase.NewTokensUsecase(map[string]domain.Token{
+			ATOM: {HumanDenom: "atom"},
+			USDT: {HumanDenom: "usdt"},
+		}, 0, noOpLogger)
+
+		reports, err := usecase.FindDivergentStablePrices(context.Background(), []string{ATOM}, tolerance)
+		s.Require().Error(err)
+		s.Require().Nil(reports)
+	})
+
+	s.Run("pricing strategy not registered", func() {
+		usecase := tokensusecase.NewTokensUsecase(map[string]domain.Token{
+			ATOM: {HumanDenom: "atom"},
+			USDC: {HumanDenom: "usdc"},
+			USDT: {HumanDenom: "usdt"},
+		}, 0, noOpLogger)
+
+		reports, err := usecase.FindDivergentStablePrices(context.Background(), []string{ATOM}, tolerance)
+		s.Require().Error(err)
+		s.Require().Nil(reports)
+	})
+}
+
+// Tests that the pricing requests and failures counters are incremented
+// by source and quote denom for the success, failure and fallback paths.
+func (s *TokensUseCaseTestSuite) TestGetPrices_PricingSourceMetrics() {
+	var (
+		atomPrice = osmomath.NewBigDec(5)
+
+		chainSource     = domain.ChainPricingSourceType.String()
+		coingeckoSource = domain.CoinGeckoPricingSourceType.String()
+	)
+
+	type counts struct {
+		chainRequests     float64
+		chainFailures     float64
+		coingeckoRequests float64
+		coingeckoFailures float64
+	}
+
+	getCounts := func() counts {
+		return counts{
+			chainRequests:     testutil.ToFloat64(domain.SQSPricingRequestsTotal.WithLabelValues(chainSource, USDC)),
+			chainFailures:     testutil.ToFloat64(domain.SQSPricingFailuresTotal.WithLabelValues(chainSource, USDC)),
+			coingeckoRequests: testutil.ToFloat64(domain.SQSPricingRequestsTotal.WithLabelValues(coingeckoSource, USDC)),
+			coingeckoFailures: testutil.ToFloat64(domain.SQSPricingFailuresTotal.WithLabelValues(coingeckoSource, USDC)),
+		}
+	}
+
+	testcases := []struct {
+		name string
+
+		chainErr     error
+		withFallback bool
+		coingeckoErr error
+
+		expectedPrice osmomath.BigDec
+		expectedDelta counts
+	}{
+		{
+			name: "chain success",
+
+			expectedPrice: atomPrice,
+			expectedDelta: counts{chainRequests: 1},
+		},
+		{
+			name: "chain failure without fallback",
+
+			chainErr: fmt.Errorf("no route"),
+
+			expectedPrice: osmomath.ZeroBigDec(),
+			expectedDelta: counts{chainRequests: 1, chainFailures: 1},
+		},
+		{
+			name: "chain failure with coingecko fallback success",
+
+			chainErr:     fmt.Errorf("no route"),
+			withFallback: true,
+
+			expectedPrice: atomPrice,
+			expectedDelta: counts{chainRequests: 1, chainFailures: 1, coingeckoRequests: 1},
+		},
+		{
+			name: "chain failure with coingecko fallback failure",
+
+			chainErr:     fmt.Errorf("no route"),
+			withFallback: true,
+			coingeckoErr: fmt.Errorf("coingecko unavailable"),
+
+			expectedPrice: osmomath.ZeroBigDec(),
+			expectedDelta: counts{chainRequests: 1, chainFailures: 1, coingeckoRequests: 1, coingeckoFailures: 1},
+		},
+	}
+
+	for _, tt := range testcases {
+		s.Run(tt.name, func() {
+			usecase := tokensusecase.NewTokensUsecase(map[string]domain.Token{
+				ATOM: {HumanDenom: "atom"},
+			}, 0, noOpLogger)
+
+			usecase.RegisterPricingStrategy(domain.ChainPricingSourceType, &mocks.PricingSourceMock{
+				GetPriceFunc: func(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+					if tt.chainErr != nil {
+						return osmomath.BigDec{}, tt.chainErr
+					}
+					return atomPrice, nil
+				},
+				GetFallbackStrategyFunc: func(quoteDenom string) domain.PricingSourceType {
+					if tt.withFallback {
+						return domain.CoinGeckoPricingSourceType
+					}
+					return domain.NoneSourceType
+				},
+			})
+
+			usecase.RegisterPricingStrategy(domain.CoinGeckoPricingSourceType, &mocks.PricingSourceMock{
+				GetPriceFunc: func(ctx context.Context, baseDenom string, quoteDenom string, opts ...domain.PricingOption) (osmomath.BigDec, error) {
+					if tt.coingeckoErr != nil {
+						return osmomath.BigDec{}, tt.coingeckoErr
+					}
+					return atomPrice, nil
+				},
+			})
+
+			initialCounts := getCounts()
+
+			// System under test
+			prices, err := usecase.GetPrices(context.Background(), []string{ATOM}, []string{USDC}, domain.ChainPricingSourceType)
+			s.Require().NoError(err)
+			s.Require().Equal(tt.expectedPrice, prices[ATOM][USDC])
+
+			finalCounts := getCounts()
+			s.Require().Equal(tt.expectedDelta, counts{
+				chainRequests:     finalCounts.chainRequests - initialCounts.chainRequests,
+				chainFailures:     finalCounts.chainFailures - initialCounts.chainFailures,
+				coingeckoRequests: finalCounts.coingeckoRequests - initialCounts.coingeckoRequests,
+				coingeckoFailures: finalCounts.coingeckoFailures - initialCounts.coingeckoFailures,
+			})
+		})
+	}
+}